@@ -15,19 +15,32 @@ import (
 
 type (
 	ApifoxConfig               = storage.ApifoxConfig
+	CancelExecutionRequest     = storage.CancelExecutionRequest
+	CancelExecutionResponse    = storage.CancelExecutionResponse
+	CreateEnvironmentRequest   = storage.CreateEnvironmentRequest
 	CreateSceneConfigRequest   = storage.CreateSceneConfigRequest
 	CreateTaskRequest          = storage.CreateTaskRequest
 	CreateTestDataRequest      = storage.CreateTestDataRequest
+	DeleteEnvironmentRequest   = storage.DeleteEnvironmentRequest
 	DeleteInterfaceRequest     = storage.DeleteInterfaceRequest
+	DeleteMockOverrideRequest  = storage.DeleteMockOverrideRequest
+	DeleteMockOverrideResponse = storage.DeleteMockOverrideResponse
 	DeleteResponse             = storage.DeleteResponse
 	DeleteSceneConfigRequest   = storage.DeleteSceneConfigRequest
 	DeleteTaskRequest          = storage.DeleteTaskRequest
 	DeleteTestDataRequest      = storage.DeleteTestDataRequest
 	Dependency                 = storage.Dependency
 	Empty                      = storage.Empty
+	Environment                = storage.Environment
+	EnvironmentListResponse    = storage.EnvironmentListResponse
+	EnvironmentResponse        = storage.EnvironmentResponse
+	EnvironmentTLS             = storage.EnvironmentTLS
 	ExecuteTaskRequest         = storage.ExecuteTaskRequest
 	ExecuteTaskResponse        = storage.ExecuteTaskResponse
+	ExecutionRecord            = storage.ExecutionRecord
 	Expect                     = storage.Expect
+	ExportCurlRequest          = storage.ExportCurlRequest
+	ExportCurlResponse         = storage.ExportCurlResponse
 	ExtractConfig              = storage.ExtractConfig
 	Extractor                  = storage.Extractor
 	GenerateDependencyRequest  = storage.GenerateDependencyRequest
@@ -36,6 +49,11 @@ type (
 	GenerateExpectResponse     = storage.GenerateExpectResponse
 	GenerateExtractorRequest   = storage.GenerateExtractorRequest
 	GenerateExtractorResponse  = storage.GenerateExtractorResponse
+	GetEnvironmentRequest      = storage.GetEnvironmentRequest
+	GetExecutionHarRequest     = storage.GetExecutionHarRequest
+	GetExecutionHarResponse    = storage.GetExecutionHarResponse
+	GetExecutionRequest        = storage.GetExecutionRequest
+	GetExecutionResponse       = storage.GetExecutionResponse
 	GetInterfaceListResponse   = storage.GetInterfaceListResponse
 	GetInterfaceRequest        = storage.GetInterfaceRequest
 	GetInterfaceResponse       = storage.GetInterfaceResponse
@@ -46,18 +64,37 @@ type (
 	GetTestReportRequest       = storage.GetTestReportRequest
 	Header                     = storage.Header
 	InterfaceInfo              = storage.InterfaceInfo
+	ListExecutionQueueRequest  = storage.ListExecutionQueueRequest
+	ListExecutionQueueResponse = storage.ListExecutionQueueResponse
+	ListExecutionsRequest      = storage.ListExecutionsRequest
+	ListExecutionsResponse     = storage.ListExecutionsResponse
+	ListMockRoutesRequest      = storage.ListMockRoutesRequest
+	ListMockRoutesResponse     = storage.ListMockRoutesResponse
 	ListSceneConfigsRequest    = storage.ListSceneConfigsRequest
 	ListValue                  = storage.ListValue
+	MockOverride               = storage.MockOverride
+	MockRoute                  = storage.MockRoute
 	MongoConfig                = storage.MongoConfig
 	Parameter                  = storage.Parameter
+	QueuedExecution            = storage.QueuedExecution
 	RelatedApi                 = storage.RelatedApi
+	ReorderExecutionRequest    = storage.ReorderExecutionRequest
+	ReorderExecutionResponse   = storage.ReorderExecutionResponse
 	ReportListResponse         = storage.ReportListResponse
 	ResponseHeader             = storage.ResponseHeader
+	ResumeExecutionRequest     = storage.ResumeExecutionRequest
+	ResumeExecutionResponse    = storage.ResumeExecutionResponse
 	RetrySetting               = storage.RetrySetting
 	Scenarios                  = storage.Scenarios
 	SceneConfig                = storage.SceneConfig
 	SceneConfigListResponse    = storage.SceneConfigListResponse
 	SceneConfigResponse        = storage.SceneConfigResponse
+	SetMockOverrideRequest     = storage.SetMockOverrideRequest
+	SetMockOverrideResponse    = storage.SetMockOverrideResponse
+	StartMockRequest           = storage.StartMockRequest
+	StartMockResponse          = storage.StartMockResponse
+	StopMockRequest            = storage.StopMockRequest
+	StopMockResponse           = storage.StopMockResponse
 	Strategy                   = storage.Strategy
 	Struct                     = storage.Struct
 	SyncDestination            = storage.SyncDestination
@@ -71,6 +108,7 @@ type (
 	TaskMeta                   = storage.TaskMeta
 	TaskResponse               = storage.TaskResponse
 	TaskSyncSpec               = storage.TaskSyncSpec
+	TaskWorkflowSpec           = storage.TaskWorkflowSpec
 	TestData                   = storage.TestData
 	TestDataListResponse       = storage.TestDataListResponse
 	TestDataResponse           = storage.TestDataResponse
@@ -78,14 +116,35 @@ type (
 	TestReportResponse         = storage.TestReportResponse
 	TimeoutSetting             = storage.TimeoutSetting
 	Timestamp                  = storage.Timestamp
+	UpdateEnvironmentRequest   = storage.UpdateEnvironmentRequest
 	UpdateSceneConfigRequest   = storage.UpdateSceneConfigRequest
 	UpdateTaskRequest          = storage.UpdateTaskRequest
 	UpdateTestDataRequest      = storage.UpdateTestDataRequest
 	Value                      = storage.Value
+	WatchExecutionRequest      = storage.WatchExecutionRequest
+	WatchExecutionResponse     = storage.WatchExecutionResponse
 
 	ExecuteService interface {
 		// 任务执行
 		ExecuteTask(ctx context.Context, in *ExecuteTaskRequest, opts ...grpc.CallOption) (*ExecuteTaskResponse, error)
+		// 取消执行
+		CancelExecution(ctx context.Context, in *CancelExecutionRequest, opts ...grpc.CallOption) (*CancelExecutionResponse, error)
+		// 恢复失败或已取消的执行
+		ResumeExecution(ctx context.Context, in *ResumeExecutionRequest, opts ...grpc.CallOption) (*ResumeExecutionResponse, error)
+		// 订阅执行事件，执行结束后流关闭
+		WatchExecution(ctx context.Context, in *WatchExecutionRequest, opts ...grpc.CallOption) (storage.ExecuteService_WatchExecutionClient, error)
+		// 查看执行队列
+		ListExecutionQueue(ctx context.Context, in *ListExecutionQueueRequest, opts ...grpc.CallOption) (*ListExecutionQueueResponse, error)
+		// 调整排队中执行的优先级
+		ReorderExecution(ctx context.Context, in *ReorderExecutionRequest, opts ...grpc.CallOption) (*ReorderExecutionResponse, error)
+		// 查询执行记录
+		GetExecution(ctx context.Context, in *GetExecutionRequest, opts ...grpc.CallOption) (*GetExecutionResponse, error)
+		// 按任务、状态、时间范围分页查询执行记录
+		ListExecutions(ctx context.Context, in *ListExecutionsRequest, opts ...grpc.CallOption) (*ListExecutionsResponse, error)
+		// 下载执行中所有请求和响应的 HAR 文件
+		GetExecutionHar(ctx context.Context, in *GetExecutionHarRequest, opts ...grpc.CallOption) (*GetExecutionHarResponse, error)
+		// 将执行中的请求导出为 curl 命令
+		ExportCurl(ctx context.Context, in *ExportCurlRequest, opts ...grpc.CallOption) (*ExportCurlResponse, error)
 	}
 
 	defaultExecuteService struct {
@@ -104,3 +163,57 @@ func (m *defaultExecuteService) ExecuteTask(ctx context.Context, in *ExecuteTask
 	client := storage.NewExecuteServiceClient(m.cli.Conn())
 	return client.ExecuteTask(ctx, in, opts...)
 }
+
+// 取消执行
+func (m *defaultExecuteService) CancelExecution(ctx context.Context, in *CancelExecutionRequest, opts ...grpc.CallOption) (*CancelExecutionResponse, error) {
+	client := storage.NewExecuteServiceClient(m.cli.Conn())
+	return client.CancelExecution(ctx, in, opts...)
+}
+
+// 恢复失败或已取消的执行
+func (m *defaultExecuteService) ResumeExecution(ctx context.Context, in *ResumeExecutionRequest, opts ...grpc.CallOption) (*ResumeExecutionResponse, error) {
+	client := storage.NewExecuteServiceClient(m.cli.Conn())
+	return client.ResumeExecution(ctx, in, opts...)
+}
+
+// 订阅执行事件，执行结束后流关闭
+func (m *defaultExecuteService) WatchExecution(ctx context.Context, in *WatchExecutionRequest, opts ...grpc.CallOption) (storage.ExecuteService_WatchExecutionClient, error) {
+	client := storage.NewExecuteServiceClient(m.cli.Conn())
+	return client.WatchExecution(ctx, in, opts...)
+}
+
+// 查看执行队列
+func (m *defaultExecuteService) ListExecutionQueue(ctx context.Context, in *ListExecutionQueueRequest, opts ...grpc.CallOption) (*ListExecutionQueueResponse, error) {
+	client := storage.NewExecuteServiceClient(m.cli.Conn())
+	return client.ListExecutionQueue(ctx, in, opts...)
+}
+
+// 调整排队中执行的优先级
+func (m *defaultExecuteService) ReorderExecution(ctx context.Context, in *ReorderExecutionRequest, opts ...grpc.CallOption) (*ReorderExecutionResponse, error) {
+	client := storage.NewExecuteServiceClient(m.cli.Conn())
+	return client.ReorderExecution(ctx, in, opts...)
+}
+
+// 查询执行记录
+func (m *defaultExecuteService) GetExecution(ctx context.Context, in *GetExecutionRequest, opts ...grpc.CallOption) (*GetExecutionResponse, error) {
+	client := storage.NewExecuteServiceClient(m.cli.Conn())
+	return client.GetExecution(ctx, in, opts...)
+}
+
+// 按任务、状态、时间范围分页查询执行记录
+func (m *defaultExecuteService) ListExecutions(ctx context.Context, in *ListExecutionsRequest, opts ...grpc.CallOption) (*ListExecutionsResponse, error) {
+	client := storage.NewExecuteServiceClient(m.cli.Conn())
+	return client.ListExecutions(ctx, in, opts...)
+}
+
+// 下载执行中所有请求和响应的 HAR 文件
+func (m *defaultExecuteService) GetExecutionHar(ctx context.Context, in *GetExecutionHarRequest, opts ...grpc.CallOption) (*GetExecutionHarResponse, error) {
+	client := storage.NewExecuteServiceClient(m.cli.Conn())
+	return client.GetExecutionHar(ctx, in, opts...)
+}
+
+// 将执行中的请求导出为 curl 命令
+func (m *defaultExecuteService) ExportCurl(ctx context.Context, in *ExportCurlRequest, opts ...grpc.CallOption) (*ExportCurlResponse, error) {
+	client := storage.NewExecuteServiceClient(m.cli.Conn())
+	return client.ExportCurl(ctx, in, opts...)
+}
@@ -15,19 +15,32 @@ import (
 
 type (
 	ApifoxConfig               = storage.ApifoxConfig
+	CancelExecutionRequest     = storage.CancelExecutionRequest
+	CancelExecutionResponse    = storage.CancelExecutionResponse
+	CreateEnvironmentRequest   = storage.CreateEnvironmentRequest
 	CreateSceneConfigRequest   = storage.CreateSceneConfigRequest
 	CreateTaskRequest          = storage.CreateTaskRequest
 	CreateTestDataRequest      = storage.CreateTestDataRequest
+	DeleteEnvironmentRequest   = storage.DeleteEnvironmentRequest
 	DeleteInterfaceRequest     = storage.DeleteInterfaceRequest
+	DeleteMockOverrideRequest  = storage.DeleteMockOverrideRequest
+	DeleteMockOverrideResponse = storage.DeleteMockOverrideResponse
 	DeleteResponse             = storage.DeleteResponse
 	DeleteSceneConfigRequest   = storage.DeleteSceneConfigRequest
 	DeleteTaskRequest          = storage.DeleteTaskRequest
 	DeleteTestDataRequest      = storage.DeleteTestDataRequest
 	Dependency                 = storage.Dependency
 	Empty                      = storage.Empty
+	Environment                = storage.Environment
+	EnvironmentListResponse    = storage.EnvironmentListResponse
+	EnvironmentResponse        = storage.EnvironmentResponse
+	EnvironmentTLS             = storage.EnvironmentTLS
 	ExecuteTaskRequest         = storage.ExecuteTaskRequest
 	ExecuteTaskResponse        = storage.ExecuteTaskResponse
+	ExecutionRecord            = storage.ExecutionRecord
 	Expect                     = storage.Expect
+	ExportCurlRequest          = storage.ExportCurlRequest
+	ExportCurlResponse         = storage.ExportCurlResponse
 	ExtractConfig              = storage.ExtractConfig
 	Extractor                  = storage.Extractor
 	GenerateDependencyRequest  = storage.GenerateDependencyRequest
@@ -36,6 +49,11 @@ type (
 	GenerateExpectResponse     = storage.GenerateExpectResponse
 	GenerateExtractorRequest   = storage.GenerateExtractorRequest
 	GenerateExtractorResponse  = storage.GenerateExtractorResponse
+	GetEnvironmentRequest      = storage.GetEnvironmentRequest
+	GetExecutionHarRequest     = storage.GetExecutionHarRequest
+	GetExecutionHarResponse    = storage.GetExecutionHarResponse
+	GetExecutionRequest        = storage.GetExecutionRequest
+	GetExecutionResponse       = storage.GetExecutionResponse
 	GetInterfaceListResponse   = storage.GetInterfaceListResponse
 	GetInterfaceRequest        = storage.GetInterfaceRequest
 	GetInterfaceResponse       = storage.GetInterfaceResponse
@@ -46,18 +64,37 @@ type (
 	GetTestReportRequest       = storage.GetTestReportRequest
 	Header                     = storage.Header
 	InterfaceInfo              = storage.InterfaceInfo
+	ListExecutionQueueRequest  = storage.ListExecutionQueueRequest
+	ListExecutionQueueResponse = storage.ListExecutionQueueResponse
+	ListExecutionsRequest      = storage.ListExecutionsRequest
+	ListExecutionsResponse     = storage.ListExecutionsResponse
+	ListMockRoutesRequest      = storage.ListMockRoutesRequest
+	ListMockRoutesResponse     = storage.ListMockRoutesResponse
 	ListSceneConfigsRequest    = storage.ListSceneConfigsRequest
 	ListValue                  = storage.ListValue
+	MockOverride               = storage.MockOverride
+	MockRoute                  = storage.MockRoute
 	MongoConfig                = storage.MongoConfig
 	Parameter                  = storage.Parameter
+	QueuedExecution            = storage.QueuedExecution
 	RelatedApi                 = storage.RelatedApi
+	ReorderExecutionRequest    = storage.ReorderExecutionRequest
+	ReorderExecutionResponse   = storage.ReorderExecutionResponse
 	ReportListResponse         = storage.ReportListResponse
 	ResponseHeader             = storage.ResponseHeader
+	ResumeExecutionRequest     = storage.ResumeExecutionRequest
+	ResumeExecutionResponse    = storage.ResumeExecutionResponse
 	RetrySetting               = storage.RetrySetting
 	Scenarios                  = storage.Scenarios
 	SceneConfig                = storage.SceneConfig
 	SceneConfigListResponse    = storage.SceneConfigListResponse
 	SceneConfigResponse        = storage.SceneConfigResponse
+	SetMockOverrideRequest     = storage.SetMockOverrideRequest
+	SetMockOverrideResponse    = storage.SetMockOverrideResponse
+	StartMockRequest           = storage.StartMockRequest
+	StartMockResponse          = storage.StartMockResponse
+	StopMockRequest            = storage.StopMockRequest
+	StopMockResponse           = storage.StopMockResponse
 	Strategy                   = storage.Strategy
 	Struct                     = storage.Struct
 	SyncDestination            = storage.SyncDestination
@@ -71,6 +108,7 @@ type (
 	TaskMeta                   = storage.TaskMeta
 	TaskResponse               = storage.TaskResponse
 	TaskSyncSpec               = storage.TaskSyncSpec
+	TaskWorkflowSpec           = storage.TaskWorkflowSpec
 	TestData                   = storage.TestData
 	TestDataListResponse       = storage.TestDataListResponse
 	TestDataResponse           = storage.TestDataResponse
@@ -78,10 +116,13 @@ type (
 	TestReportResponse         = storage.TestReportResponse
 	TimeoutSetting             = storage.TimeoutSetting
 	Timestamp                  = storage.Timestamp
+	UpdateEnvironmentRequest   = storage.UpdateEnvironmentRequest
 	UpdateSceneConfigRequest   = storage.UpdateSceneConfigRequest
 	UpdateTaskRequest          = storage.UpdateTaskRequest
 	UpdateTestDataRequest      = storage.UpdateTestDataRequest
 	Value                      = storage.Value
+	WatchExecutionRequest      = storage.WatchExecutionRequest
+	WatchExecutionResponse     = storage.WatchExecutionResponse
 
 	ReportService interface {
 		// 测试报告
@@ -0,0 +1,77 @@
+package eventrelay
+
+import (
+	"context"
+	"sync"
+
+	"Storage/internal/components/pipeline/core"
+)
+
+// subscriberBuffer 订阅者缓冲区大小，缓冲区已满时丢弃最早未读的事件
+const subscriberBuffer = 256
+
+// MemoryRelay 进程内的事件转发，仅适用于单实例部署或本地调试
+type MemoryRelay struct {
+	mu          sync.Mutex
+	subscribers map[string]map[int]chan core.ExecutionEvent
+	nextID      int
+}
+
+// 确保实现了 core.EventRelay 接口
+var _ core.EventRelay = (*MemoryRelay)(nil)
+
+// NewMemoryRelay 创建进程内的事件转发
+func NewMemoryRelay() *MemoryRelay {
+	return &MemoryRelay{subscribers: make(map[string]map[int]chan core.ExecutionEvent)}
+}
+
+func (r *MemoryRelay) Publish(ctx context.Context, event core.ExecutionEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, ch := range r.subscribers[event.ExecutionID] {
+		send(ch, event)
+	}
+	return nil
+}
+
+func (r *MemoryRelay) Subscribe(ctx context.Context, executionID string) (<-chan core.ExecutionEvent, func(), error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.subscribers[executionID] == nil {
+		r.subscribers[executionID] = make(map[int]chan core.ExecutionEvent)
+	}
+	id := r.nextID
+	r.nextID++
+	ch := make(chan core.ExecutionEvent, subscriberBuffer)
+	r.subscribers[executionID][id] = ch
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			delete(r.subscribers[executionID], id)
+			if len(r.subscribers[executionID]) == 0 {
+				delete(r.subscribers, executionID)
+			}
+			close(ch)
+		})
+	}
+	return ch, unsubscribe, nil
+}
+
+// send 发送事件，缓冲区已满时丢弃最早未读的事件后重试
+func send(ch chan core.ExecutionEvent, event core.ExecutionEvent) {
+	for attempt := 0; attempt < 3; attempt++ {
+		select {
+		case ch <- event:
+			return
+		default:
+		}
+		select {
+		case <-ch:
+		default:
+		}
+	}
+}
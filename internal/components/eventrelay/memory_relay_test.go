@@ -0,0 +1,73 @@
+package eventrelay
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Storage/internal/components/pipeline/core"
+)
+
+func TestMemoryRelayBetweenBuses(t *testing.T) {
+	ctx := context.Background()
+	relay := NewMemoryRelay()
+	// a 运行执行，b 上的观察者通过共享的转发接收事件
+	a, b := core.NewExecutionEventBus(), core.NewExecutionEventBus()
+	a.SetRelay(relay)
+	b.SetRelay(relay)
+
+	if _, _, ok := b.Subscribe("exec-1"); ok {
+		t.Fatal("b.Subscribe() ok = true, want execution not local")
+	}
+	events, unsubscribe, err := b.Relay().Subscribe(ctx, "exec-1")
+	if err != nil {
+		t.Fatalf("Relay().Subscribe() error = %v", err)
+	}
+	defer unsubscribe()
+	other, unsubscribeOther, _ := b.Relay().Subscribe(ctx, "exec-2")
+	defer unsubscribeOther()
+
+	a.Open("exec-1")
+	a.Publish(core.ExecutionEvent{ExecutionID: "exec-1", Type: core.EventStatus, Status: core.TaskStatusRunning})
+	a.Publish(core.ExecutionEvent{ExecutionID: "exec-1", Type: core.EventSummary, Status: core.TaskStatusCompleted})
+	a.Close("exec-1")
+
+	for _, want := range []core.ExecutionEventType{core.EventStatus, core.EventSummary} {
+		select {
+		case event := <-events:
+			if event.Type != want || event.ExecutionID != "exec-1" {
+				t.Errorf("event = %+v, want %s", event, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("未收到 %s 事件", want)
+		}
+	}
+	select {
+	case event := <-other:
+		t.Errorf("其他执行的订阅者收到事件 %+v", event)
+	default:
+	}
+}
+
+func TestMemoryRelaySlowSubscriber(t *testing.T) {
+	ctx := context.Background()
+	relay := NewMemoryRelay()
+	events, unsubscribe, _ := relay.Subscribe(ctx, "exec-1")
+
+	for i := 0; i < subscriberBuffer*2; i++ {
+		relay.Publish(ctx, core.ExecutionEvent{ExecutionID: "exec-1", Type: core.EventProgress})
+	}
+	relay.Publish(ctx, core.ExecutionEvent{ExecutionID: "exec-1", Type: core.EventSummary})
+	unsubscribe()
+	unsubscribe()
+
+	var last core.ExecutionEvent
+	count := 0
+	for event := range events {
+		last = event
+		count++
+	}
+	if count != subscriberBuffer || last.Type != core.EventSummary {
+		t.Errorf("received %d events ending with %s, want %d ending with summary", count, last.Type, subscriberBuffer)
+	}
+}
@@ -0,0 +1,75 @@
+package eventrelay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"Storage/internal/components/pipeline/core"
+	"Storage/internal/components/tools"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// DefaultChannelPrefix 默认事件频道前缀
+const DefaultChannelPrefix = "storage:execution_events"
+
+// RedisRelay 基于 Redis 发布订阅的事件转发，每个执行一个频道，多实例共享
+// Redis 发布订阅不保留消息，订阅之前广播的事件由执行记录补齐
+type RedisRelay struct {
+	client *tools.RedisClient
+	prefix string
+}
+
+// 确保实现了 core.EventRelay 接口
+var _ core.EventRelay = (*RedisRelay)(nil)
+
+// NewRedisRelay 创建 Redis 事件转发，client 需已连接，prefix 为空时使用 DefaultChannelPrefix
+func NewRedisRelay(client *tools.RedisClient, prefix string) *RedisRelay {
+	if prefix == "" {
+		prefix = DefaultChannelPrefix
+	}
+	return &RedisRelay{client: client, prefix: prefix}
+}
+
+func (r *RedisRelay) Publish(ctx context.Context, event core.ExecutionEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("序列化执行事件失败: %w", err)
+	}
+	return r.client.Publish(ctx, r.channel(event.ExecutionID), payload)
+}
+
+func (r *RedisRelay) Subscribe(ctx context.Context, executionID string) (<-chan core.ExecutionEvent, func(), error) {
+	pubsub, err := r.client.Subscribe(ctx, r.channel(executionID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("订阅执行 %s 的事件失败: %w", executionID, err)
+	}
+
+	events := make(chan core.ExecutionEvent, subscriberBuffer)
+	go func() {
+		defer close(events)
+		for msg := range pubsub.Channel() {
+			var event core.ExecutionEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				logx.Errorf("解析执行 %s 的事件失败: %v", executionID, err)
+				continue
+			}
+			events <- event
+		}
+	}()
+
+	unsubscribe := func() {
+		if err := pubsub.Close(); err != nil {
+			logx.Errorf("取消订阅执行 %s 的事件失败: %v", executionID, err)
+		}
+		// 排空未读事件，转发协程随频道关闭退出
+		for range events {
+		}
+	}
+	return events, unsubscribe, nil
+}
+
+func (r *RedisRelay) channel(executionID string) string {
+	return fmt.Sprintf("%s:%s", r.prefix, executionID)
+}
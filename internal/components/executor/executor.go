@@ -0,0 +1,287 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"Storage/internal/components/pipeline/core"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// Executor 有界执行器
+// 任务按优先级排队，全局并发数和各管道类型的并发数都受限，超过排队上限时拒绝提交
+type Executor struct {
+	mu sync.Mutex
+
+	workers    int
+	queueSize  int
+	typeLimits map[core.PipelineType]int
+
+	// 按出队顺序排列的队列
+	queue []*queuedJob
+	seq   uint64
+
+	// 运行中的任务
+	running       map[string]*queuedJob
+	runningByType map[core.PipelineType]int
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	stopped bool
+}
+
+// NewExecutor 创建执行器
+func NewExecutor(opts Options) *Executor {
+	if opts.Workers <= 0 {
+		opts.Workers = DefaultWorkers
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = DefaultQueueSize
+	}
+	typeLimits := make(map[core.PipelineType]int, len(opts.TypeLimits))
+	for t, limit := range opts.TypeLimits {
+		if limit > 0 {
+			typeLimits[t] = limit
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Executor{
+		workers:       opts.Workers,
+		queueSize:     opts.QueueSize,
+		typeLimits:    typeLimits,
+		running:       make(map[string]*queuedJob),
+		runningByType: make(map[core.PipelineType]int),
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+// Submit 提交任务，返回在队列中的位置，有空闲并发时立即开始执行（位置为 0）
+func (e *Executor) Submit(job *Job) (int, error) {
+	if job == nil || job.Run == nil {
+		return 0, fmt.Errorf("任务或执行函数不能为空")
+	}
+
+	e.mu.Lock()
+	if e.stopped {
+		e.mu.Unlock()
+		return 0, ErrStopped
+	}
+	if _, ok := e.running[job.ExecutionID]; ok || e.indexOf(job.ExecutionID) >= 0 {
+		e.mu.Unlock()
+		return 0, ErrDuplicate
+	}
+	if len(e.queue) >= e.queueSize {
+		e.mu.Unlock()
+		jobsTotal.Inc(string(job.Type), "rejected")
+		return 0, ErrQueueFull
+	}
+
+	e.seq++
+	item := &queuedJob{Job: job, seq: e.seq, enqueueTime: time.Now()}
+	e.insert(item)
+	queueDepth.Inc(string(job.Type))
+	jobsTotal.Inc(string(job.Type), "submitted")
+
+	e.dispatch()
+	position := e.indexOf(job.ExecutionID) + 1
+	e.mu.Unlock()
+
+	return position, nil
+}
+
+// List 按出队顺序返回排队中的任务
+func (e *Executor) List() []QueuedJob {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	jobs := make([]QueuedJob, 0, len(e.queue))
+	for i, item := range e.queue {
+		jobs = append(jobs, QueuedJob{
+			ExecutionID: item.ExecutionID,
+			TaskID:      item.TaskID,
+			Type:        item.Type,
+			Priority:    item.Priority,
+			Position:    i + 1,
+			EnqueueTime: item.enqueueTime,
+		})
+	}
+	return jobs
+}
+
+// Reorder 调整排队中任务的优先级，返回调整后的位置
+// 同优先级的任务仍按入队顺序执行
+func (e *Executor) Reorder(executionID string, priority int32) (int, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	i := e.indexOf(executionID)
+	if i < 0 {
+		return 0, ErrNotQueued
+	}
+	item := e.queue[i]
+	e.queue = append(e.queue[:i], e.queue[i+1:]...)
+	item.Priority = priority
+	e.insert(item)
+
+	e.dispatch()
+	return e.indexOf(executionID) + 1, nil
+}
+
+// Remove 从队列中移除任务并调用其 Discard 回调，任务不在队列中时返回 false
+func (e *Executor) Remove(executionID string) bool {
+	e.mu.Lock()
+	i := e.indexOf(executionID)
+	if i < 0 {
+		e.mu.Unlock()
+		return false
+	}
+	item := e.queue[i]
+	e.queue = append(e.queue[:i], e.queue[i+1:]...)
+	queueDepth.Dec(string(item.Type))
+	jobsTotal.Inc(string(item.Type), "discarded")
+	e.mu.Unlock()
+
+	if item.Discard != nil {
+		item.Discard()
+	}
+	return true
+}
+
+// IsQueued 任务是否在排队中
+func (e *Executor) IsQueued(executionID string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.indexOf(executionID) >= 0
+}
+
+// Stats 获取执行器状态
+func (e *Executor) Stats() Stats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	stats := Stats{
+		Workers:       e.workers,
+		Running:       len(e.running),
+		Queued:        len(e.queue),
+		RunningByType: make(map[core.PipelineType]int, len(e.runningByType)),
+		QueuedByType:  make(map[core.PipelineType]int),
+	}
+	for t, n := range e.runningByType {
+		if n > 0 {
+			stats.RunningByType[t] = n
+		}
+	}
+	for _, item := range e.queue {
+		stats.QueuedByType[item.Type]++
+	}
+	return stats
+}
+
+// Stop 停止接收新任务，丢弃排队中的任务，并等待运行中的任务结束或 ctx 超时
+// 超时后取消运行中任务的上下文
+func (e *Executor) Stop(ctx context.Context) error {
+	e.mu.Lock()
+	e.stopped = true
+	discarded := e.queue
+	e.queue = nil
+	e.mu.Unlock()
+
+	for _, item := range discarded {
+		queueDepth.Dec(string(item.Type))
+		jobsTotal.Inc(string(item.Type), "discarded")
+		if item.Discard != nil {
+			item.Discard()
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		e.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		e.cancel()
+		return nil
+	case <-ctx.Done():
+		e.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// dispatch 按出队顺序启动可运行的任务，调用方需持有锁
+// 队首任务所属类型已达上限时，跳过它调度后续其他类型的任务
+func (e *Executor) dispatch() {
+	for i := 0; i < len(e.queue) && len(e.running) < e.workers; {
+		item := e.queue[i]
+		if limit, ok := e.typeLimits[item.Type]; ok && e.runningByType[item.Type] >= limit {
+			i++
+			continue
+		}
+
+		e.queue = append(e.queue[:i], e.queue[i+1:]...)
+		e.running[item.ExecutionID] = item
+		e.runningByType[item.Type]++
+
+		label := string(item.Type)
+		queueDepth.Dec(label)
+		runningJobs.Inc(label)
+		jobsTotal.Inc(label, "started")
+		queueWait.Observe(time.Since(item.enqueueTime).Milliseconds(), label)
+
+		e.wg.Add(1)
+		go e.run(item)
+	}
+}
+
+// run 执行任务，结束后释放并发并调度后续任务
+func (e *Executor) run(item *queuedJob) {
+	defer func() {
+		if r := recover(); r != nil {
+			logx.Errorf("执行 %s 发生panic: %v", item.ExecutionID, r)
+		}
+
+		e.mu.Lock()
+		delete(e.running, item.ExecutionID)
+		e.runningByType[item.Type]--
+		runningJobs.Dec(string(item.Type))
+		jobsTotal.Inc(string(item.Type), "finished")
+		if !e.stopped {
+			e.dispatch()
+		}
+		e.mu.Unlock()
+
+		e.wg.Done()
+	}()
+
+	item.Run(e.ctx)
+}
+
+// insert 按出队顺序插入，调用方需持有锁
+func (e *Executor) insert(item *queuedJob) {
+	i := sort.Search(len(e.queue), func(i int) bool {
+		return item.before(e.queue[i])
+	})
+	e.queue = append(e.queue, nil)
+	copy(e.queue[i+1:], e.queue[i:])
+	e.queue[i] = item
+}
+
+// indexOf 获取任务在队列中的下标，不存在时返回 -1，调用方需持有锁
+func (e *Executor) indexOf(executionID string) int {
+	for i, item := range e.queue {
+		if item.ExecutionID == executionID {
+			return i
+		}
+	}
+	return -1
+}
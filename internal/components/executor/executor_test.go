@@ -0,0 +1,284 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"Storage/internal/components/pipeline/core"
+)
+
+// blockingJob 运行后阻塞到 release 关闭，started 在开始运行时关闭
+func blockingJob(id string, typ core.PipelineType, priority int32, release <-chan struct{}) (*Job, <-chan struct{}) {
+	started := make(chan struct{})
+	return &Job{
+		ExecutionID: id,
+		Type:        typ,
+		Priority:    priority,
+		Run: func(ctx context.Context) {
+			close(started)
+			select {
+			case <-release:
+			case <-ctx.Done():
+			}
+		},
+	}, started
+}
+
+func waitStarted(t *testing.T, started <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("任务未开始执行")
+	}
+}
+
+func queuedIDs(e *Executor) []string {
+	var ids []string
+	for _, job := range e.List() {
+		ids = append(ids, job.ExecutionID)
+	}
+	return ids
+}
+
+func equalIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestExecutorQueueOrder(t *testing.T) {
+	type submit struct {
+		id       string
+		priority int32
+	}
+	tests := []struct {
+		name    string
+		submits []submit
+		want    []string
+	}{
+		{
+			name:    "同优先级按入队顺序",
+			submits: []submit{{"a", 0}, {"b", 0}, {"c", 0}},
+			want:    []string{"a", "b", "c"},
+		},
+		{
+			name:    "优先级高的在前",
+			submits: []submit{{"a", 0}, {"b", 5}, {"c", 1}},
+			want:    []string{"b", "c", "a"},
+		},
+		{
+			name:    "负优先级排在默认优先级之后",
+			submits: []submit{{"a", -1}, {"b", 0}, {"c", -1}, {"d", 0}},
+			want:    []string{"b", "d", "a", "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewExecutor(Options{Workers: 1})
+			release := make(chan struct{})
+			defer close(release)
+
+			job, started := blockingJob("running", core.TypeAPI, 0, release)
+			if position, err := e.Submit(job); err != nil || position != 0 {
+				t.Fatalf("Submit() = %d, %v, want 0, nil", position, err)
+			}
+			waitStarted(t, started)
+
+			for _, s := range tt.submits {
+				job, _ := blockingJob(s.id, core.TypeAPI, s.priority, release)
+				if _, err := e.Submit(job); err != nil {
+					t.Fatalf("Submit(%s) error = %v", s.id, err)
+				}
+			}
+			if got := queuedIDs(e); !equalIDs(got, tt.want) {
+				t.Errorf("List() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecutorSubmitErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(e *Executor, release <-chan struct{})
+		job     string
+		wantErr error
+	}{
+		{
+			name: "执行ID正在运行",
+			prepare: func(e *Executor, release <-chan struct{}) {
+				job, _ := blockingJob("dup", core.TypeAPI, 0, release)
+				e.Submit(job)
+			},
+			job:     "dup",
+			wantErr: ErrDuplicate,
+		},
+		{
+			name: "执行ID正在排队",
+			prepare: func(e *Executor, release <-chan struct{}) {
+				first, _ := blockingJob("first", core.TypeAPI, 0, release)
+				e.Submit(first)
+				job, _ := blockingJob("dup", core.TypeAPI, 0, release)
+				e.Submit(job)
+			},
+			job:     "dup",
+			wantErr: ErrDuplicate,
+		},
+		{
+			name: "队列已满",
+			prepare: func(e *Executor, release <-chan struct{}) {
+				for _, id := range []string{"running", "q1", "q2"} {
+					job, _ := blockingJob(id, core.TypeAPI, 0, release)
+					e.Submit(job)
+				}
+			},
+			job:     "q3",
+			wantErr: ErrQueueFull,
+		},
+		{
+			name: "执行器已停止",
+			prepare: func(e *Executor, release <-chan struct{}) {
+				e.Stop(context.Background())
+			},
+			job:     "late",
+			wantErr: ErrStopped,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewExecutor(Options{Workers: 1, QueueSize: 2})
+			release := make(chan struct{})
+			defer close(release)
+
+			tt.prepare(e, release)
+			job, _ := blockingJob(tt.job, core.TypeAPI, 0, release)
+			if _, err := e.Submit(job); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Submit() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExecutorTypeLimits(t *testing.T) {
+	e := NewExecutor(Options{
+		Workers:    2,
+		TypeLimits: map[core.PipelineType]int{core.TypeApiFox: 1},
+	})
+	release := make(chan struct{})
+	defer close(release)
+
+	sync1, started := blockingJob("sync-1", core.TypeApiFox, 0, release)
+	e.Submit(sync1)
+	waitStarted(t, started)
+
+	// 同类型已达上限，排在前面的同类型任务不阻塞其他类型
+	sync2, _ := blockingJob("sync-2", core.TypeApiFox, 10, release)
+	if position, _ := e.Submit(sync2); position != 1 {
+		t.Errorf("Submit(sync-2) position = %d, want 1", position)
+	}
+	apiJob, apiStarted := blockingJob("api-1", core.TypeAPI, 0, release)
+	if position, _ := e.Submit(apiJob); position != 0 {
+		t.Errorf("Submit(api-1) position = %d, want 0", position)
+	}
+	waitStarted(t, apiStarted)
+
+	stats := e.Stats()
+	if stats.RunningByType[core.TypeApiFox] != 1 || stats.RunningByType[core.TypeAPI] != 1 {
+		t.Errorf("RunningByType = %v, want 1 per type", stats.RunningByType)
+	}
+	if stats.QueuedByType[core.TypeApiFox] != 1 {
+		t.Errorf("QueuedByType = %v, want sync-2 queued", stats.QueuedByType)
+	}
+}
+
+func TestExecutorReorderAndRemove(t *testing.T) {
+	e := NewExecutor(Options{Workers: 1})
+	release := make(chan struct{})
+	defer close(release)
+
+	running, started := blockingJob("running", core.TypeAPI, 0, release)
+	e.Submit(running)
+	waitStarted(t, started)
+
+	var discarded []string
+	var mu sync.Mutex
+	for _, id := range []string{"a", "b", "c"} {
+		id := id
+		job, _ := blockingJob(id, core.TypeAPI, 0, release)
+		job.Discard = func() {
+			mu.Lock()
+			discarded = append(discarded, id)
+			mu.Unlock()
+		}
+		e.Submit(job)
+	}
+
+	if position, err := e.Reorder("c", 1); err != nil || position != 1 {
+		t.Fatalf("Reorder(c) = %d, %v, want 1, nil", position, err)
+	}
+	if _, err := e.Reorder("running", 1); !errors.Is(err, ErrNotQueued) {
+		t.Errorf("Reorder(running) error = %v, want %v", err, ErrNotQueued)
+	}
+	if got, want := queuedIDs(e), []string{"c", "a", "b"}; !equalIDs(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+
+	if !e.Remove("a") {
+		t.Fatal("Remove(a) = false, want true")
+	}
+	if e.Remove("running") {
+		t.Error("Remove(running) = true, want false")
+	}
+	if got, want := queuedIDs(e), []string{"c", "b"}; !equalIDs(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+
+	// 停止时丢弃剩余的排队任务，运行中的任务随执行器上下文取消
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	e.Stop(ctx)
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []string{"a", "c", "b"}; !equalIDs(discarded, want) {
+		t.Errorf("discarded = %v, want %v", discarded, want)
+	}
+}
+
+func TestExecutorStopCancelsRunning(t *testing.T) {
+	e := NewExecutor(Options{Workers: 1})
+	canceled := make(chan struct{})
+	started := make(chan struct{})
+	e.Submit(&Job{
+		ExecutionID: "running",
+		Type:        core.TypeAPI,
+		Run: func(ctx context.Context) {
+			close(started)
+			<-ctx.Done()
+			close(canceled)
+		},
+	})
+	waitStarted(t, started)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := e.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Stop() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	select {
+	case <-canceled:
+	default:
+		t.Error("Stop 超时后运行中的任务未被取消")
+	}
+}
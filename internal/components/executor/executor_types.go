@@ -0,0 +1,93 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"Storage/internal/components/pipeline/core"
+)
+
+const (
+	// DefaultWorkers 默认并发执行数
+	DefaultWorkers = 8
+	// DefaultQueueSize 默认队列容量
+	DefaultQueueSize = 1000
+)
+
+var (
+	// ErrQueueFull 队列已满
+	ErrQueueFull = errors.New("执行队列已满")
+	// ErrStopped 执行器已停止
+	ErrStopped = errors.New("执行器已停止")
+	// ErrDuplicate 执行ID已在队列或运行中
+	ErrDuplicate = errors.New("执行已存在")
+	// ErrNotQueued 执行不在队列中（不存在或已开始运行）
+	ErrNotQueued = errors.New("执行不在队列中")
+)
+
+// Options 执行器配置
+type Options struct {
+	// 全局并发执行数，<=0 时使用 DefaultWorkers
+	Workers int
+	// 排队上限，<=0 时使用 DefaultQueueSize
+	QueueSize int
+	// 按管道类型限制并发数，未配置的类型只受全局并发数限制
+	TypeLimits map[core.PipelineType]int
+}
+
+// Job 一次待执行的任务
+type Job struct {
+	// 执行ID，队列内唯一
+	ExecutionID string
+	// 任务ID
+	TaskID string
+	// 管道类型，用于按类型限流
+	Type core.PipelineType
+	// 优先级，数值越大越先执行
+	Priority int32
+	// 执行函数，ctx 在执行器停止时取消
+	Run func(ctx context.Context)
+	// 排队中被移除时的回调，用于释放为该执行准备的资源
+	Discard func()
+}
+
+// QueuedJob 排队中任务的快照
+type QueuedJob struct {
+	ExecutionID string
+	TaskID      string
+	Type        core.PipelineType
+	Priority    int32
+	// 在队列中的位置，从 1 开始
+	Position    int
+	EnqueueTime time.Time
+}
+
+// Stats 执行器状态
+type Stats struct {
+	// 全局并发数
+	Workers int
+	// 运行中的任务数
+	Running int
+	// 排队中的任务数
+	Queued int
+	// 按类型统计的运行数
+	RunningByType map[core.PipelineType]int
+	// 按类型统计的排队数
+	QueuedByType map[core.PipelineType]int
+}
+
+// queuedJob 队列中的任务
+type queuedJob struct {
+	*Job
+	seq         uint64
+	enqueueTime time.Time
+}
+
+// before 出队顺序：优先级高的在前，同优先级先入队的在前
+func (q *queuedJob) before(other *queuedJob) bool {
+	if q.Priority != other.Priority {
+		return q.Priority > other.Priority
+	}
+	return q.seq < other.seq
+}
@@ -0,0 +1,49 @@
+package executor
+
+import (
+	"github.com/zeromicro/go-zero/core/metric"
+)
+
+const (
+	metricNamespace = "storage"
+	metricSubsystem = "executor"
+)
+
+var (
+	// queueDepth 各类型排队中的任务数
+	queueDepth = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: metricSubsystem,
+		Name:      "queue_depth",
+		Help:      "executor queued jobs by pipeline type",
+		Labels:    []string{"type"},
+	})
+
+	// runningJobs 各类型运行中的任务数
+	runningJobs = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: metricSubsystem,
+		Name:      "running",
+		Help:      "executor running jobs by pipeline type",
+		Labels:    []string{"type"},
+	})
+
+	// jobsTotal 按结果统计的任务数：submitted/rejected/started/finished/discarded
+	jobsTotal = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: metricSubsystem,
+		Name:      "jobs_total",
+		Help:      "executor jobs by pipeline type and result",
+		Labels:    []string{"type", "result"},
+	})
+
+	// queueWait 任务从入队到开始执行的等待时间
+	queueWait = metric.NewHistogramVec(&metric.HistogramVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: metricSubsystem,
+		Name:      "queue_wait_ms",
+		Help:      "executor queue wait duration in milliseconds",
+		Labels:    []string{"type"},
+		Buckets:   []float64{10, 50, 100, 500, 1000, 5000, 10000, 30000, 60000, 300000},
+	})
+)
@@ -0,0 +1,197 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Lease 已持有的锁租约
+type Lease struct {
+	locker *Locker
+	key    string
+	value  string
+	info   LeaseInfo
+
+	mu       sync.Mutex
+	err      error
+	onLost   []func(error)
+	onCancel []func()
+	canceled bool
+	lost     chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+func newLease(locker *Locker, key, value string, info LeaseInfo) *Lease {
+	return &Lease{
+		locker:  locker,
+		key:     key,
+		value:   value,
+		info:    info,
+		lost:    make(chan struct{}),
+		stopped: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// Token 防护令牌
+func (l *Lease) Token() int64 {
+	return l.info.Token
+}
+
+// Info 租约信息
+func (l *Lease) Info() LeaseInfo {
+	return l.info
+}
+
+// Lost 租约失效时关闭
+func (l *Lease) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// Err 租约失效的原因，未失效时为 nil
+func (l *Lease) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// OnLost 注册租约失效回调，主动释放不会触发；已失效时立即异步调用
+func (l *Lease) OnLost(fn func(error)) {
+	l.mu.Lock()
+	if l.err == nil {
+		l.onLost = append(l.onLost, fn)
+		l.mu.Unlock()
+		return
+	}
+	err := l.err
+	l.mu.Unlock()
+	go fn(err)
+}
+
+// OnCancel 注册取消请求回调，持有期间其他实例调用 Locker.RequestCancel 时在续约时触发；
+// 已收到取消请求时立即异步调用
+func (l *Lease) OnCancel(fn func()) {
+	l.mu.Lock()
+	if !l.canceled {
+		l.onCancel = append(l.onCancel, fn)
+		l.mu.Unlock()
+		return
+	}
+	l.mu.Unlock()
+	go fn()
+}
+
+// Validate 确认锁仍由本租约持有，写入前调用以拒绝过期的持有者
+func (l *Lease) Validate(ctx context.Context) error {
+	if err := l.Err(); err != nil {
+		return err
+	}
+	value, ok, err := l.locker.store.Get(ctx, l.key)
+	if err != nil {
+		return fmt.Errorf("校验任务锁失败: %w", err)
+	}
+	if !ok || value != l.value {
+		l.markLost(ErrLeaseLost)
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+// Release 停止续约并释放锁，可重复调用
+func (l *Lease) Release(ctx context.Context) error {
+	l.stopOnce.Do(func() { close(l.stopped) })
+	<-l.done
+
+	if l.Err() != nil {
+		return nil
+	}
+	if _, err := l.locker.store.Release(ctx, l.key, l.value); err != nil {
+		return fmt.Errorf("释放任务锁失败: %w", err)
+	}
+	return nil
+}
+
+// heartbeat 定期续约，锁被删除或被他人持有时租约失效；
+// 存储不可用时持续重试，超过租约时长仍未续约成功则视为失效
+func (l *Lease) heartbeat() {
+	defer close(l.done)
+
+	opts := l.locker.opts
+	ticker := time.NewTicker(opts.RenewInterval)
+	defer ticker.Stop()
+	renewedAt := time.Now()
+
+	for {
+		select {
+		case <-l.stopped:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), opts.RenewInterval)
+		ok, err := l.locker.store.Renew(ctx, l.key, l.value, opts.TTL)
+		cancel()
+
+		switch {
+		case err == nil && ok:
+			renewedAt = time.Now()
+			l.checkCancel()
+		case err == nil:
+			l.markLost(ErrLeaseLost)
+			return
+		case time.Since(renewedAt) >= opts.TTL:
+			l.markLost(fmt.Errorf("%w: 续约失败: %v", ErrLeaseLost, err))
+			return
+		}
+	}
+}
+
+// checkCancel 查询执行是否被请求取消，首次发现时触发取消回调，查询失败时等待下次续约
+func (l *Lease) checkCancel() {
+	l.mu.Lock()
+	canceled := l.canceled
+	l.mu.Unlock()
+	if canceled {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.locker.opts.RenewInterval)
+	requested, err := l.locker.CancelRequested(ctx, l.info.ExecutionID)
+	cancel()
+	if err != nil || !requested {
+		return
+	}
+
+	l.mu.Lock()
+	l.canceled = true
+	callbacks := l.onCancel
+	l.onCancel = nil
+	l.mu.Unlock()
+
+	for _, fn := range callbacks {
+		go fn()
+	}
+}
+
+// markLost 标记租约失效并触发回调
+func (l *Lease) markLost(err error) {
+	l.mu.Lock()
+	if l.err != nil {
+		l.mu.Unlock()
+		return
+	}
+	l.err = err
+	callbacks := l.onLost
+	l.onLost = nil
+	close(l.lost)
+	l.mu.Unlock()
+
+	logLost(l.info, err)
+	for _, fn := range callbacks {
+		go fn(err)
+	}
+}
@@ -0,0 +1,77 @@
+package lock
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTTL 默认租约时长
+	DefaultTTL = 30 * time.Second
+	// DefaultRetryInterval 等待锁时的默认轮询间隔
+	DefaultRetryInterval = time.Second
+	// DefaultKeyPrefix 默认锁键前缀
+	DefaultKeyPrefix = "storage:task"
+	// CancelRequestTTL 取消请求的保留时长，覆盖执行在队列中等待重试的时间
+	CancelRequestTTL = 24 * time.Hour
+)
+
+var (
+	// ErrLocked 锁已被其他执行持有
+	ErrLocked = errors.New("任务正在执行中")
+	// ErrLeaseLost 租约已失效（过期或被抢占）
+	ErrLeaseLost = errors.New("任务锁租约已失效")
+)
+
+// Policy 同一任务执行冲突时的处理策略
+type Policy string
+
+const (
+	// PolicyReject 拒绝新的执行
+	PolicyReject Policy = "reject"
+	// PolicyQueue 等待正在运行的执行结束后再执行
+	PolicyQueue Policy = "queue"
+	// PolicyCancelOlder 取消正在运行的执行，由新的执行接管
+	PolicyCancelOlder Policy = "cancel_older"
+)
+
+// ParsePolicy 解析冲突策略，为空时使用 PolicyReject
+func ParsePolicy(s string) (Policy, error) {
+	switch policy := Policy(strings.ToLower(strings.TrimSpace(s))); policy {
+	case "":
+		return PolicyReject, nil
+	case PolicyReject, PolicyQueue, PolicyCancelOlder:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("不支持的冲突策略: %s", s)
+	}
+}
+
+// Options 锁配置
+type Options struct {
+	// 租约时长，持有者需在到期前续约，<=0 时使用 DefaultTTL
+	TTL time.Duration
+	// 续约间隔，<=0 时为 TTL 的三分之一
+	RenewInterval time.Duration
+	// PolicyQueue 锁被占用时消息重新入队的间隔，Acquire 的轮询间隔，<=0 时使用 DefaultRetryInterval
+	RetryInterval time.Duration
+	// 锁键前缀，为空时使用 DefaultKeyPrefix
+	KeyPrefix string
+	// 当前实例标识，用于排查锁由哪个实例持有
+	Owner string
+}
+
+// LeaseInfo 锁持有者信息
+type LeaseInfo struct {
+	Owner       string `json:"owner"`
+	ExecutionID string `json:"execution_id"`
+	// 防护令牌，每次加锁单调递增，写入时据此拒绝过期持有者
+	Token int64 `json:"token"`
+}
+
+// IsLocked 是否为锁已被占用的错误
+func IsLocked(err error) bool {
+	return errors.Is(err, ErrLocked)
+}
@@ -0,0 +1,196 @@
+package lock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// LockedError 锁已被占用，附带当前持有者
+type LockedError struct {
+	Holder *LeaseInfo
+}
+
+func (e *LockedError) Error() string {
+	if e.Holder == nil {
+		return ErrLocked.Error()
+	}
+	return fmt.Sprintf("%s: 执行 %s (实例 %s)", ErrLocked.Error(), e.Holder.ExecutionID, e.Holder.Owner)
+}
+
+func (e *LockedError) Unwrap() error {
+	return ErrLocked
+}
+
+// Locker 基于租约的任务锁
+// 加锁成功后后台定期续约，持有者崩溃时租约到期自动释放；
+// 每次加锁分配单调递增的防护令牌，写入方据此识别过期的持有者
+type Locker struct {
+	store Store
+	opts  Options
+}
+
+// NewLocker 创建任务锁
+func NewLocker(store Store, opts Options) *Locker {
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultTTL
+	}
+	if opts.RenewInterval <= 0 || opts.RenewInterval >= opts.TTL {
+		opts.RenewInterval = opts.TTL / 3
+	}
+	if opts.RetryInterval <= 0 {
+		opts.RetryInterval = DefaultRetryInterval
+	}
+	if opts.KeyPrefix == "" {
+		opts.KeyPrefix = DefaultKeyPrefix
+	}
+	return &Locker{store: store, opts: opts}
+}
+
+// TryAcquire 尝试加锁，锁被占用时返回 *LockedError
+func (l *Locker) TryAcquire(ctx context.Context, taskID, executionID string) (*Lease, error) {
+	info := LeaseInfo{Owner: l.opts.Owner, ExecutionID: executionID}
+	value, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+
+	key := l.lockKey(taskID)
+	ok, err := l.store.SetNX(ctx, key, string(value), l.opts.TTL)
+	if err != nil {
+		return nil, fmt.Errorf("加锁失败: %w", err)
+	}
+	if !ok {
+		holder, _ := l.Holder(ctx, taskID)
+		return nil, &LockedError{Holder: holder}
+	}
+
+	// 持有锁之后再递增令牌，保证后加锁者的令牌一定更大
+	token, err := l.store.Incr(ctx, l.fenceKey(taskID))
+	if err != nil {
+		l.store.Release(ctx, key, string(value))
+		return nil, fmt.Errorf("生成防护令牌失败: %w", err)
+	}
+	info.Token = token
+
+	lease := newLease(l, key, string(value), info)
+	go lease.heartbeat()
+	return lease, nil
+}
+
+// Acquire 等待直到加锁成功或 ctx 结束
+func (l *Locker) Acquire(ctx context.Context, taskID, executionID string) (*Lease, error) {
+	ticker := time.NewTicker(l.opts.RetryInterval)
+	defer ticker.Stop()
+
+	for {
+		lease, err := l.TryAcquire(ctx, taskID, executionID)
+		if !errors.Is(err, ErrLocked) {
+			return lease, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RetryInterval PolicyQueue 锁被占用时消息重新入队的间隔
+func (l *Locker) RetryInterval() time.Duration {
+	return l.opts.RetryInterval
+}
+
+// Preempt 抢占锁：删除当前持有者的租约后加锁，返回被抢占的持有者（锁空闲时为 nil）
+// 被抢占者在下次续约或校验时发现租约失效
+func (l *Locker) Preempt(ctx context.Context, taskID, executionID string) (*Lease, *LeaseInfo, error) {
+	key := l.lockKey(taskID)
+	var preempted *LeaseInfo
+
+	// 与其他抢占者竞争时重试有限次数
+	for attempt := 0; attempt < 3; attempt++ {
+		lease, err := l.TryAcquire(ctx, taskID, executionID)
+		if err == nil {
+			return lease, preempted, nil
+		}
+		var locked *LockedError
+		if !errors.As(err, &locked) {
+			return nil, preempted, err
+		}
+
+		value, ok, err := l.store.Get(ctx, key)
+		if err != nil {
+			return nil, preempted, fmt.Errorf("获取锁持有者失败: %w", err)
+		}
+		if !ok {
+			continue
+		}
+		if _, err := l.store.Release(ctx, key, value); err != nil {
+			return nil, preempted, fmt.Errorf("释放旧租约失败: %w", err)
+		}
+		if locked.Holder != nil {
+			preempted = locked.Holder
+		}
+	}
+	return nil, preempted, ErrLocked
+}
+
+// Holder 获取锁当前持有者，锁空闲时返回 nil
+func (l *Locker) Holder(ctx context.Context, taskID string) (*LeaseInfo, error) {
+	value, ok, err := l.store.Get(ctx, l.lockKey(taskID))
+	if err != nil || !ok {
+		return nil, err
+	}
+	var info LeaseInfo
+	if err := json.Unmarshal([]byte(value), &info); err != nil {
+		return nil, fmt.Errorf("解析锁持有者失败: %w", err)
+	}
+	if token, ok, err := l.store.Get(ctx, l.fenceKey(taskID)); err == nil && ok {
+		info.Token, _ = strconv.ParseInt(token, 10, 64)
+	}
+	return &info, nil
+}
+
+// RequestCancel 请求取消执行，执行所在实例在续约时发现取消请求并触发 Lease.OnCancel
+// 用于取消在其他实例上运行的执行
+func (l *Locker) RequestCancel(ctx context.Context, executionID string) error {
+	if err := l.store.Set(ctx, l.cancelKey(executionID), "1", CancelRequestTTL); err != nil {
+		return fmt.Errorf("写入取消请求失败: %w", err)
+	}
+	return nil
+}
+
+// ClearCancel 清除执行的取消请求，恢复已取消的执行前调用
+func (l *Locker) ClearCancel(ctx context.Context, executionID string) error {
+	_, err := l.store.Release(ctx, l.cancelKey(executionID), "1")
+	return err
+}
+
+// CancelRequested 执行是否已被请求取消
+func (l *Locker) CancelRequested(ctx context.Context, executionID string) (bool, error) {
+	_, ok, err := l.store.Get(ctx, l.cancelKey(executionID))
+	return ok, err
+}
+
+func (l *Locker) lockKey(taskID string) string {
+	return fmt.Sprintf("%s:lock:%s", l.opts.KeyPrefix, taskID)
+}
+
+func (l *Locker) fenceKey(taskID string) string {
+	return fmt.Sprintf("%s:fence:%s", l.opts.KeyPrefix, taskID)
+}
+
+func (l *Locker) cancelKey(executionID string) string {
+	return fmt.Sprintf("%s:cancel:%s", l.opts.KeyPrefix, executionID)
+}
+
+// logLost 记录租约失效
+func logLost(info LeaseInfo, err error) {
+	logx.Errorf("执行 %s 的任务锁租约失效(令牌 %d): %v", info.ExecutionID, info.Token, err)
+}
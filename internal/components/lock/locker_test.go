@@ -0,0 +1,360 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// renewStore 可控制续约结果的存储，renew 为 nil 时使用 MemoryStore 的续约
+type renewStore struct {
+	*MemoryStore
+	renew func() (bool, error)
+}
+
+func (s *renewStore) Renew(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+	if s.renew != nil {
+		return s.renew()
+	}
+	return s.MemoryStore.Renew(ctx, key, value, ttl)
+}
+
+func newTestLocker(store Store, ttl time.Duration) *Locker {
+	return NewLocker(store, Options{
+		TTL:           ttl,
+		RenewInterval: ttl / 5,
+		RetryInterval: 10 * time.Millisecond,
+		Owner:         "test",
+	})
+}
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Policy
+		wantErr bool
+	}{
+		{input: "", want: PolicyReject},
+		{input: "reject", want: PolicyReject},
+		{input: " Queue ", want: PolicyQueue},
+		{input: "CANCEL_OLDER", want: PolicyCancelOlder},
+		{input: "skip", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParsePolicy(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePolicy(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePolicy(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTryAcquireLocked(t *testing.T) {
+	ctx := context.Background()
+	locker := newTestLocker(NewMemoryStore(), time.Second)
+
+	lease, err := locker.TryAcquire(ctx, "task", "exec-1")
+	if err != nil {
+		t.Fatalf("TryAcquire() error = %v", err)
+	}
+	defer lease.Release(ctx)
+
+	_, err = locker.TryAcquire(ctx, "task", "exec-2")
+	if !IsLocked(err) {
+		t.Fatalf("TryAcquire() error = %v, want ErrLocked", err)
+	}
+	var locked *LockedError
+	if !errors.As(err, &locked) || locked.Holder == nil || locked.Holder.ExecutionID != "exec-1" {
+		t.Errorf("LockedError.Holder = %+v, want exec-1", locked.Holder)
+	}
+
+	// 不同任务互不影响
+	other, err := locker.TryAcquire(ctx, "other", "exec-3")
+	if err != nil {
+		t.Fatalf("TryAcquire(other) error = %v", err)
+	}
+	other.Release(ctx)
+}
+
+func TestFencingTokenOrdering(t *testing.T) {
+	ctx := context.Background()
+	locker := newTestLocker(NewMemoryStore(), time.Second)
+
+	tests := []struct {
+		name    string
+		acquire func(executionID string) (*Lease, error)
+	}{
+		{
+			name: "释放后加锁",
+			acquire: func(executionID string) (*Lease, error) {
+				return locker.TryAcquire(ctx, "task", executionID)
+			},
+		},
+		{
+			name: "等待加锁",
+			acquire: func(executionID string) (*Lease, error) {
+				return locker.Acquire(ctx, "task", executionID)
+			},
+		},
+		{
+			name: "抢占加锁",
+			acquire: func(executionID string) (*Lease, error) {
+				lease, _, err := locker.Preempt(ctx, "task", executionID)
+				return lease, err
+			},
+		},
+	}
+
+	var last int64
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lease, err := tt.acquire(tt.name)
+			if err != nil {
+				t.Fatalf("acquire error = %v", err)
+			}
+			defer lease.Release(ctx)
+
+			if lease.Token() <= last {
+				t.Errorf("Token() = %d, want > %d", lease.Token(), last)
+			}
+			last = lease.Token()
+
+			holder, err := locker.Holder(ctx, "task")
+			if err != nil || holder == nil || holder.Token != lease.Token() {
+				t.Errorf("Holder() = %+v, %v, want token %d", holder, err, lease.Token())
+			}
+		})
+	}
+}
+
+func TestPreemptInvalidatesOlderLease(t *testing.T) {
+	ctx := context.Background()
+	locker := newTestLocker(NewMemoryStore(), time.Second)
+
+	older, err := locker.TryAcquire(ctx, "task", "older")
+	if err != nil {
+		t.Fatalf("TryAcquire() error = %v", err)
+	}
+	var lostErr atomic.Value
+	older.OnLost(func(err error) { lostErr.Store(err) })
+
+	newer, preempted, err := locker.Preempt(ctx, "task", "newer")
+	if err != nil {
+		t.Fatalf("Preempt() error = %v", err)
+	}
+	defer newer.Release(ctx)
+
+	if preempted == nil || preempted.ExecutionID != "older" {
+		t.Errorf("Preempt() preempted = %+v, want older", preempted)
+	}
+	if newer.Token() <= older.Token() {
+		t.Errorf("newer token %d, want > older token %d", newer.Token(), older.Token())
+	}
+
+	// 被抢占者写入前校验失败
+	if err := older.Validate(ctx); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("older.Validate() error = %v, want ErrLeaseLost", err)
+	}
+	if err := newer.Validate(ctx); err != nil {
+		t.Errorf("newer.Validate() error = %v", err)
+	}
+	select {
+	case <-older.Lost():
+	case <-time.After(time.Second):
+		t.Fatal("被抢占的租约未失效")
+	}
+	// 旧租约释放不影响新的持有者
+	older.Release(ctx)
+	if holder, _ := locker.Holder(ctx, "task"); holder == nil || holder.ExecutionID != "newer" {
+		t.Errorf("Holder() = %+v, want newer", holder)
+	}
+	deadline := time.Now().Add(time.Second)
+	for lostErr.Load() == nil && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err, _ := lostErr.Load().(error); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("OnLost error = %v, want ErrLeaseLost", err)
+	}
+}
+
+func TestLeaseExpiry(t *testing.T) {
+	const ttl = 100 * time.Millisecond
+	tests := []struct {
+		name  string
+		renew func() (bool, error)
+		// 最晚在此时间内失效
+		within time.Duration
+	}{
+		{
+			name:   "锁已被删除",
+			renew:  func() (bool, error) { return false, nil },
+			within: ttl,
+		},
+		{
+			name:   "存储不可用超过租约时长",
+			renew:  func() (bool, error) { return false, errors.New("connection refused") },
+			within: 3 * ttl,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := &renewStore{MemoryStore: NewMemoryStore(), renew: tt.renew}
+			locker := newTestLocker(store, ttl)
+
+			lease, err := locker.TryAcquire(ctx, "task", "exec-1")
+			if err != nil {
+				t.Fatalf("TryAcquire() error = %v", err)
+			}
+			defer lease.Release(ctx)
+
+			select {
+			case <-lease.Lost():
+			case <-time.After(tt.within):
+				t.Fatalf("租约未在 %v 内失效", tt.within)
+			}
+			if !errors.Is(lease.Err(), ErrLeaseLost) {
+				t.Errorf("Err() = %v, want ErrLeaseLost", lease.Err())
+			}
+
+			// 租约到期后其他执行可以加锁，令牌更大
+			time.Sleep(ttl)
+			next, err := locker.TryAcquire(ctx, "task", "exec-2")
+			if err != nil {
+				t.Fatalf("TryAcquire() after expiry error = %v", err)
+			}
+			defer next.Release(ctx)
+			if next.Token() <= lease.Token() {
+				t.Errorf("next token %d, want > %d", next.Token(), lease.Token())
+			}
+		})
+	}
+}
+
+func TestLeaseRenewKeepsLock(t *testing.T) {
+	ctx := context.Background()
+	const ttl = 60 * time.Millisecond
+	locker := newTestLocker(NewMemoryStore(), ttl)
+
+	lease, err := locker.TryAcquire(ctx, "task", "exec-1")
+	if err != nil {
+		t.Fatalf("TryAcquire() error = %v", err)
+	}
+	var lost atomic.Bool
+	lease.OnLost(func(error) { lost.Store(true) })
+
+	// 持续续约，超过租约时长仍持有锁
+	time.Sleep(3 * ttl)
+	if err := lease.Validate(ctx); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	// 主动释放不触发失效回调，锁立即可用
+	if err := lease.Release(ctx); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if err := lease.Release(ctx); err != nil {
+		t.Errorf("second Release() error = %v", err)
+	}
+	next, err := locker.TryAcquire(ctx, "task", "exec-2")
+	if err != nil {
+		t.Fatalf("TryAcquire() after release error = %v", err)
+	}
+	next.Release(ctx)
+	time.Sleep(10 * time.Millisecond)
+	if lost.Load() {
+		t.Error("主动释放触发了 OnLost")
+	}
+}
+
+func TestRequestCancelFromOtherInstance(t *testing.T) {
+	ctx := context.Background()
+	const ttl = 100 * time.Millisecond
+	// 两个实例共用存储，执行运行在 a 上，取消请求由 b 发起
+	store := NewMemoryStore()
+	a := newTestLocker(store, ttl)
+	b := NewLocker(store, Options{TTL: ttl, Owner: "b"})
+
+	lease, err := a.TryAcquire(ctx, "task", "exec-1")
+	if err != nil {
+		t.Fatalf("TryAcquire() error = %v", err)
+	}
+	defer lease.Release(ctx)
+	canceled := make(chan struct{})
+	lease.OnCancel(func() { close(canceled) })
+
+	// 其他执行的取消请求不影响本租约
+	if err := b.RequestCancel(ctx, "exec-2"); err != nil {
+		t.Fatalf("RequestCancel(exec-2) error = %v", err)
+	}
+	select {
+	case <-canceled:
+		t.Fatal("其他执行的取消请求触发了 OnCancel")
+	case <-time.After(2 * ttl):
+	}
+
+	if err := b.RequestCancel(ctx, "exec-1"); err != nil {
+		t.Fatalf("RequestCancel(exec-1) error = %v", err)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(ttl):
+		t.Fatal("续约时未发现取消请求")
+	}
+
+	// 收到取消请求后仍持有锁，由执行结束时释放
+	if err := lease.Validate(ctx); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	// 之后注册的回调立即调用
+	late := make(chan struct{})
+	lease.OnCancel(func() { close(late) })
+	select {
+	case <-late:
+	case <-time.After(ttl):
+		t.Error("收到取消请求后注册的 OnCancel 未调用")
+	}
+}
+
+func TestCancelRequested(t *testing.T) {
+	ctx := context.Background()
+	locker := newTestLocker(NewMemoryStore(), time.Second)
+
+	tests := []struct {
+		name string
+		// 依次执行的操作
+		ops  []func() error
+		want bool
+	}{
+		{name: "未请求取消"},
+		{name: "已请求取消", ops: []func() error{func() error { return locker.RequestCancel(ctx, "exec-1") }}, want: true},
+		{
+			name: "恢复前清除",
+			ops: []func() error{
+				func() error { return locker.RequestCancel(ctx, "exec-1") },
+				func() error { return locker.ClearCancel(ctx, "exec-1") },
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locker.ClearCancel(ctx, "exec-1")
+			for _, op := range tt.ops {
+				if err := op(); err != nil {
+					t.Fatalf("op error = %v", err)
+				}
+			}
+			got, err := locker.CancelRequested(ctx, "exec-1")
+			if err != nil || got != tt.want {
+				t.Errorf("CancelRequested() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
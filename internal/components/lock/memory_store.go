@@ -0,0 +1,103 @@
+package lock
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MemoryStore 进程内存储，仅适用于单实例部署或本地调试
+type MemoryStore struct {
+	mu   sync.Mutex
+	data map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	value    string
+	expireAt time.Time // 零值表示不过期
+}
+
+// NewMemoryStore 创建进程内存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{data: make(map[string]memoryEntry)}
+}
+
+func (s *MemoryStore) SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.get(key); ok {
+		return false, nil
+	}
+	s.data[key] = memoryEntry{value: value, expireAt: expireAt(ttl)}
+	return true, nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, key string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.get(key)
+	return entry.value, ok, nil
+}
+
+// Set 写入键值，ttl 为 0 时不过期
+func (s *MemoryStore) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = memoryEntry{value: value, expireAt: expireAt(ttl)}
+	return nil
+}
+
+func (s *MemoryStore) Incr(ctx context.Context, key string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, _ := s.get(key)
+	n, _ := strconv.ParseInt(entry.value, 10, 64)
+	n++
+	entry.value = strconv.FormatInt(n, 10)
+	s.data[key] = entry
+	return n, nil
+}
+
+func (s *MemoryStore) Renew(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.get(key)
+	if !ok || entry.value != value {
+		return false, nil
+	}
+	entry.expireAt = expireAt(ttl)
+	s.data[key] = entry
+	return true, nil
+}
+
+func (s *MemoryStore) Release(ctx context.Context, key, value string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.get(key)
+	if !ok || entry.value != value {
+		return false, nil
+	}
+	delete(s.data, key)
+	return true, nil
+}
+
+// get 获取未过期的键，调用方需持有锁
+func (s *MemoryStore) get(key string) (memoryEntry, bool) {
+	entry, ok := s.data[key]
+	if !ok {
+		return memoryEntry{}, false
+	}
+	if !entry.expireAt.IsZero() && time.Now().After(entry.expireAt) {
+		delete(s.data, key)
+		return memoryEntry{}, false
+	}
+	return entry, true
+}
+
+func expireAt(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
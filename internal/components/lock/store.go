@@ -0,0 +1,90 @@
+package lock
+
+import (
+	"context"
+	"time"
+
+	"Storage/internal/components/tools"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Store 锁的存储后端
+type Store interface {
+	// SetNX 键不存在时写入
+	SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error)
+	// Get 获取键值，键不存在时返回 false
+	Get(ctx context.Context, key string) (string, bool, error)
+	// Set 写入键值，ttl 为 0 时不过期
+	Set(ctx context.Context, key, value string, ttl time.Duration) error
+	// Incr 自增计数
+	Incr(ctx context.Context, key string) (int64, error)
+	// Renew 键值等于 value 时重置过期时间
+	Renew(ctx context.Context, key, value string, ttl time.Duration) (bool, error)
+	// Release 键值等于 value 时删除
+	Release(ctx context.Context, key, value string) (bool, error)
+}
+
+const (
+	renewScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`
+
+	releaseScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`
+)
+
+// RedisStore 基于 Redis 的存储，多实例共享
+type RedisStore struct {
+	client *tools.RedisClient
+}
+
+// NewRedisStore 创建 Redis 存储，client 需已连接
+func NewRedisStore(client *tools.RedisClient) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (s *RedisStore) SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, key, value, ttl)
+}
+
+func (s *RedisStore) Get(ctx context.Context, key string) (string, bool, error) {
+	value, err := s.client.Get(ctx, key)
+	if err == redis.Nil {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return value, true, nil
+}
+
+// Set 写入键值，ttl 为 0 时不过期
+func (s *RedisStore) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+	return s.client.Set(ctx, key, value, ttl)
+}
+
+func (s *RedisStore) Incr(ctx context.Context, key string) (int64, error) {
+	return s.client.Increment(ctx, key)
+}
+
+func (s *RedisStore) Renew(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+	res, err := s.client.Eval(ctx, renewScript, []string{key}, value, ttl.Milliseconds())
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.(int64)
+	return n == 1, nil
+}
+
+func (s *RedisStore) Release(ctx context.Context, key, value string) (bool, error) {
+	res, err := s.client.Eval(ctx, releaseScript, []string{key}, value)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.(int64)
+	return n == 1, nil
+}
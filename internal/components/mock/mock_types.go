@@ -0,0 +1,77 @@
+package mock
+
+import (
+	"errors"
+	"time"
+
+	"Storage/internal/components/pathtemplate"
+)
+
+const (
+	// DefaultHost 默认监听地址
+	DefaultHost = "0.0.0.0"
+	// DefaultAdvertiseHost Mock 服务URL中默认使用的主机名
+	DefaultAdvertiseHost = "127.0.0.1"
+	// MaxDelay 覆盖配置中响应延迟的上限
+	MaxDelay = time.Minute
+
+	// StatusHeader 请求头，指定返回接口定义中哪个状态码的响应
+	StatusHeader = "X-Mock-Status"
+	// ApiIDHeader 响应头，匹配到的接口ID
+	ApiIDHeader = "X-Mock-Api-Id"
+)
+
+var (
+	// ErrNotRunning 项目的 Mock 服务未启动
+	ErrNotRunning = errors.New("Mock 服务未启动")
+)
+
+// Options Mock 服务配置
+type Options struct {
+	// 监听地址，为空时使用 DefaultHost
+	Host string
+	// 监听端口，为 0 时随机分配
+	Port int
+	// 服务URL中使用的主机名，为空时使用 DefaultAdvertiseHost
+	AdvertiseHost string
+}
+
+// Override 路由的覆盖配置，未设置的字段使用接口定义
+type Override struct {
+	// 响应状态码，为 0 时使用接口定义的状态码
+	Status int
+	// 响应延迟，不超过 MaxDelay
+	Delay time.Duration
+	// 响应体，为空时使用示例或按 schema 生成的响应体
+	Body string
+	// 额外的响应头
+	Headers map[string]string
+	// 响应的 Content-Type，为空时使用接口定义的类型
+	ContentType string
+}
+
+// Response 接口定义中的一个响应
+type Response struct {
+	// 状态码
+	Status int
+	// 响应名称
+	Name string
+	// Content-Type
+	ContentType string
+	// 响应体的 JSON Schema
+	Schema map[string]interface{}
+	// 响应示例，有示例时优先于 schema 生成的响应体
+	Example []byte
+}
+
+// Route 一个接口的路由
+type Route struct {
+	ApiID     string
+	Name      string
+	Method    string
+	Path      string
+	Responses []*Response
+
+	// 解析后的路径模板
+	template *pathtemplate.Template
+}
@@ -0,0 +1,81 @@
+package mock
+
+import (
+	"context"
+	"sync"
+)
+
+// Registry 按项目管理进程内的 Mock 服务
+type Registry struct {
+	opts Options
+
+	mu      sync.Mutex
+	servers map[string]*Server
+}
+
+// NewRegistry 创建 Mock 服务注册表，opts 中的端口不使用，由启动时指定
+func NewRegistry(opts Options) *Registry {
+	return &Registry{
+		opts:    opts,
+		servers: make(map[string]*Server),
+	}
+}
+
+// Start 启动项目的 Mock 服务，已启动时只替换路由和覆盖配置
+func (r *Registry) Start(projectID string, port int, routes []*Route, overrides map[string]*Override) (*Server, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	server, ok := r.servers[projectID]
+	if !ok {
+		opts := r.opts
+		opts.Port = port
+		server = NewServer(opts)
+	}
+	server.SetRoutes(routes)
+	server.SetOverrides(overrides)
+
+	if !ok {
+		if err := server.Start(); err != nil {
+			return nil, err
+		}
+		r.servers[projectID] = server
+	}
+	return server, nil
+}
+
+// Get 项目运行中的 Mock 服务
+func (r *Registry) Get(projectID string) (*Server, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	server, ok := r.servers[projectID]
+	return server, ok
+}
+
+// Stop 停止项目的 Mock 服务，未启动时返回 ErrNotRunning
+func (r *Registry) Stop(ctx context.Context, projectID string) error {
+	r.mu.Lock()
+	server, ok := r.servers[projectID]
+	delete(r.servers, projectID)
+	r.mu.Unlock()
+	if !ok {
+		return ErrNotRunning
+	}
+	return server.Close(ctx)
+}
+
+// Close 停止所有 Mock 服务
+func (r *Registry) Close(ctx context.Context) error {
+	r.mu.Lock()
+	servers := r.servers
+	r.servers = make(map[string]*Server)
+	r.mu.Unlock()
+
+	var firstErr error
+	for _, server := range servers {
+		if err := server.Close(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
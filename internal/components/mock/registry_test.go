@@ -0,0 +1,112 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// getApiID 请求 Mock 服务，返回匹配到的接口ID
+func getApiID(t *testing.T, url string) string {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s error = %v", url, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	return resp.Header.Get(ApiIDHeader)
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry(Options{Host: "127.0.0.1", Port: 1})
+	defer registry.Close(context.Background())
+
+	first, err := registry.Start("p1", 0, testRoutes(), nil)
+	if err != nil {
+		t.Fatalf("Start(p1) error = %v", err)
+	}
+	if got := getApiID(t, first.URL()+"/users/me"); got != "me" {
+		t.Errorf("p1 /users/me = %q, want %q", got, "me")
+	}
+
+	// 已启动时只替换路由和覆盖配置，服务和地址不变
+	routes := NewRoutes(nil)
+	routes = append(routes, NewRoute("only", "only", "GET", "/only", nil))
+	again, err := registry.Start("p1", 0, routes, map[string]*Override{"only": {Status: http.StatusAccepted}})
+	if err != nil {
+		t.Fatalf("second Start(p1) error = %v", err)
+	}
+	if again != first || again.URL() != first.URL() {
+		t.Errorf("second Start(p1) = %p %s, want the running server %p %s", again, again.URL(), first, first.URL())
+	}
+	resp, err := http.Get(first.URL() + "/only")
+	if err != nil {
+		t.Fatalf("GET /only error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("/only status = %d, want %d", resp.StatusCode, http.StatusAccepted)
+	}
+	if got := getApiID(t, first.URL()+"/users/me"); got != "" {
+		t.Errorf("replaced routes still match /users/me: %q", got)
+	}
+
+	// 各项目的服务相互独立
+	second, err := registry.Start("p2", 0, testRoutes(), nil)
+	if err != nil {
+		t.Fatalf("Start(p2) error = %v", err)
+	}
+	if second.URL() == first.URL() {
+		t.Errorf("p2 URL = p1 URL %s", second.URL())
+	}
+	if server, ok := registry.Get("p2"); !ok || server != second {
+		t.Errorf("Get(p2) = %v, %v, want the p2 server", server, ok)
+	}
+
+	if err := registry.Stop(context.Background(), "p1"); err != nil {
+		t.Fatalf("Stop(p1) error = %v", err)
+	}
+	if _, ok := registry.Get("p1"); ok {
+		t.Errorf("Get(p1) after Stop found a server")
+	}
+	if err := registry.Stop(context.Background(), "p1"); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("Stop(p1) again error = %v, want %v", err, ErrNotRunning)
+	}
+
+	// Close 停止剩余的服务
+	url := second.URL()
+	if err := registry.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, ok := registry.Get("p2"); ok {
+		t.Errorf("Get(p2) after Close found a server")
+	}
+	if _, err := http.Get(url + "/users"); err == nil {
+		t.Errorf("GET after Close succeeded, want connection error")
+	}
+}
+
+func TestRegistryStartError(t *testing.T) {
+	registry := NewRegistry(Options{Host: "127.0.0.1"})
+	defer registry.Close(context.Background())
+
+	server, err := registry.Start("p1", 0, nil, nil)
+	if err != nil {
+		t.Fatalf("Start(p1) error = %v", err)
+	}
+	// 端口被占用时启动失败，不记录服务
+	_, port, _ := net.SplitHostPort(strings.TrimPrefix(server.URL(), "http://"))
+	usedPort, _ := strconv.Atoi(port)
+	if _, err := registry.Start("p2", usedPort, nil, nil); err == nil {
+		t.Fatalf("Start(p2) on a used port succeeded, want error")
+	}
+	if _, ok := registry.Get("p2"); ok {
+		t.Errorf("Get(p2) found a server that failed to start")
+	}
+}
@@ -0,0 +1,188 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Apifox 响应的 contentType 对应的 Content-Type
+var contentTypes = map[string]string{
+	"json":   "application/json",
+	"xml":    "application/xml",
+	"html":   "text/html; charset=utf-8",
+	"raw":    "text/plain; charset=utf-8",
+	"text":   "text/plain; charset=utf-8",
+	"binary": "application/octet-stream",
+}
+
+// parseResponses 解析同步时保存的 responses 和 responseExamples，示例按 responseId 关联到响应
+func parseResponses(raw interface{}) []*Response {
+	doc, _ := normalize(raw).(map[string]interface{})
+	if doc == nil {
+		return nil
+	}
+
+	examples := make(map[string][]byte)
+	for _, item := range asList(doc["responseExamples"]) {
+		example := asMap(item)
+		id := toString(example["responseId"])
+		if id == "" || examples[id] != nil {
+			continue
+		}
+		switch data := example["data"].(type) {
+		case nil:
+		case string:
+			if data != "" {
+				examples[id] = []byte(data)
+			}
+		default:
+			if b, err := json.Marshal(data); err == nil {
+				examples[id] = b
+			}
+		}
+	}
+
+	var responses []*Response
+	for _, item := range asList(doc["responses"]) {
+		def := asMap(item)
+		if def == nil {
+			continue
+		}
+		status := toInt(def["code"])
+		if status == 0 {
+			status = toInt(def["statusCode"])
+		}
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		contentType := toString(def["contentType"])
+		if mapped, ok := contentTypes[strings.ToLower(contentType)]; ok {
+			contentType = mapped
+		}
+
+		schema := asMap(def["jsonSchema"])
+		if schema == nil {
+			schema = asMap(def["schema"])
+		}
+
+		responses = append(responses, &Response{
+			Status:      status,
+			Name:        toString(def["name"]),
+			ContentType: contentType,
+			Schema:      schema,
+			Example:     examples[toString(def["id"])],
+		})
+	}
+	return responses
+}
+
+// Body 响应体和 Content-Type，有示例时使用示例，否则按 schema 生成 JSON
+func (r *Response) Body() ([]byte, string) {
+	contentType := r.ContentType
+	if contentType == "" {
+		contentType = contentTypes["json"]
+	}
+	if r.Example != nil {
+		return r.Example, contentType
+	}
+	if r.Schema == nil {
+		return nil, contentType
+	}
+	body, err := json.Marshal(Generate(r.Schema))
+	if err != nil {
+		return nil, contentType
+	}
+	return body, contentType
+}
+
+// normalize 将从 MongoDB 读取的 bson 文档和数组转换为 map 和 slice
+func normalize(v interface{}) interface{} {
+	switch value := v.(type) {
+	case primitive.D:
+		m := make(map[string]interface{}, len(value))
+		for _, e := range value {
+			m[e.Key] = normalize(e.Value)
+		}
+		return m
+	case primitive.M:
+		m := make(map[string]interface{}, len(value))
+		for k, item := range value {
+			m[k] = normalize(item)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(value))
+		for k, item := range value {
+			m[k] = normalize(item)
+		}
+		return m
+	case primitive.A:
+		return normalize([]interface{}(value))
+	case []interface{}:
+		list := make([]interface{}, len(value))
+		for i, item := range value {
+			list[i] = normalize(item)
+		}
+		return list
+	case bson.Raw:
+		var m primitive.D
+		if err := bson.Unmarshal(value, &m); err != nil {
+			return nil
+		}
+		return normalize(m)
+	default:
+		return v
+	}
+}
+
+func asMap(v interface{}) map[string]interface{} {
+	m, _ := v.(map[string]interface{})
+	return m
+}
+
+func asList(v interface{}) []interface{} {
+	list, _ := v.([]interface{})
+	return list
+}
+
+// toString 字符串或数字转换为字符串，用于比较ID
+func toString(v interface{}) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case int32:
+		return strconv.FormatInt(int64(value), 10)
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case int:
+		return strconv.Itoa(value)
+	default:
+		return ""
+	}
+}
+
+func toInt(v interface{}) int {
+	switch value := v.(type) {
+	case float64:
+		return int(value)
+	case int32:
+		return int(value)
+	case int64:
+		return int(value)
+	case int:
+		return value
+	case string:
+		n, _ := strconv.Atoi(value)
+		return n
+	default:
+		return 0
+	}
+}
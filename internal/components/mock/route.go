@@ -0,0 +1,68 @@
+package mock
+
+import (
+	"net/http"
+	"strings"
+
+	"Storage/internal/components/pathtemplate"
+	apimodel "Storage/internal/model/api"
+)
+
+// NewRoutes 由同步的接口文档创建路由，缺少方法或路径的接口忽略
+func NewRoutes(apis []*apimodel.Api) []*Route {
+	routes := make([]*Route, 0, len(apis))
+	for _, api := range apis {
+		if api == nil || api.Method == "" || api.Path == "" {
+			continue
+		}
+		routes = append(routes, NewRoute(api.ApiID, api.Name, api.Method, api.Path, parseResponses(api.Responses)))
+	}
+	return routes
+}
+
+// NewRoute 创建路由，路径支持 {name} 和 :name 形式的路径参数，{name} 可出现在路径段中间
+func NewRoute(apiID, name, method, path string, responses []*Response) *Route {
+	// 忽略路径中的查询串
+	template, _, _ := strings.Cut(path, "?")
+	return &Route{
+		ApiID:     apiID,
+		Name:      name,
+		Method:    strings.ToUpper(method),
+		Path:      path,
+		Responses: responses,
+		template:  pathtemplate.Parse(template),
+	}
+}
+
+// match 路径是否匹配，path 为未解码的请求路径
+func (r *Route) match(path string) (pathtemplate.Match, bool) {
+	return r.template.Match(path)
+}
+
+// allows 路由是否接受请求方法，HEAD 请求可以匹配 GET 接口
+func (r *Route) allows(method string) bool {
+	return r.Method == method || (method == http.MethodHead && r.Method == http.MethodGet)
+}
+
+// response 选择返回的响应，依次按请求指定的状态码、覆盖配置的状态码、第一个 2xx 响应、第一个响应选择
+func (r *Route) response(requested, override int) *Response {
+	for _, status := range []int{requested, override} {
+		if status == 0 {
+			continue
+		}
+		for _, resp := range r.Responses {
+			if resp.Status == status {
+				return resp
+			}
+		}
+	}
+	for _, resp := range r.Responses {
+		if resp.Status >= 200 && resp.Status < 300 {
+			return resp
+		}
+	}
+	if len(r.Responses) > 0 {
+		return r.Responses[0]
+	}
+	return nil
+}
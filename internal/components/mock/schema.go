@@ -0,0 +1,145 @@
+package mock
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxSchemaDepth 生成示例值时 schema 的最大嵌套层数，避免循环引用
+const maxSchemaDepth = 8
+
+// 各字符串格式的示例值
+var stringFormats = map[string]string{
+	"date-time": "2024-01-01T00:00:00Z",
+	"date":      "2024-01-01",
+	"time":      "00:00:00",
+	"email":     "user@example.com",
+	"uri":       "https://example.com",
+	"url":       "https://example.com",
+	"hostname":  "example.com",
+	"ipv4":      "127.0.0.1",
+	"ipv6":      "::1",
+	"uuid":      "00000000-0000-4000-8000-000000000000",
+}
+
+// Generate 按 JSON Schema 生成示例值，优先使用 schema 中的 example、default 和 enum，
+// 同一 schema 每次生成的结果相同，未解析的 $ref 生成 null
+func Generate(schema map[string]interface{}) interface{} {
+	return generate(schema, 0)
+}
+
+func generate(schema map[string]interface{}, depth int) interface{} {
+	if schema == nil || depth > maxSchemaDepth {
+		return nil
+	}
+
+	if v, ok := schema["example"]; ok {
+		return v
+	}
+	if examples := asList(schema["examples"]); len(examples) > 0 {
+		return examples[0]
+	}
+	for _, key := range []string{"default", "const"} {
+		if v, ok := schema[key]; ok {
+			return v
+		}
+	}
+	if enum := asList(schema["enum"]); len(enum) > 0 {
+		return enum[0]
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if list := asList(schema[key]); len(list) > 0 {
+			return generate(asMap(list[0]), depth+1)
+		}
+	}
+	if list := asList(schema["allOf"]); len(list) > 0 {
+		merged := make(map[string]interface{})
+		for _, item := range list {
+			if m, ok := generate(asMap(item), depth+1).(map[string]interface{}); ok {
+				for k, v := range m {
+					merged[k] = v
+				}
+			}
+		}
+		return merged
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		properties := asMap(schema["properties"])
+		names := make([]string, 0, len(properties))
+		for name := range properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		result := make(map[string]interface{}, len(names))
+		for _, name := range names {
+			result[name] = generate(asMap(properties[name]), depth+1)
+		}
+		return result
+
+	case "array":
+		items := asMap(schema["items"])
+		if items == nil {
+			return []interface{}{}
+		}
+		n := max(toInt(schema["minItems"]), 1)
+		result := make([]interface{}, n)
+		for i := range result {
+			result[i] = generate(items, depth+1)
+		}
+		return result
+
+	case "string":
+		value := "string"
+		if v, ok := stringFormats[toString(schema["format"])]; ok {
+			value = v
+		}
+		if minLength := toInt(schema["minLength"]); len(value) < minLength {
+			value += strings.Repeat("x", minLength-len(value))
+		}
+		if maxLength := toInt(schema["maxLength"]); maxLength > 0 && len(value) > maxLength {
+			value = value[:maxLength]
+		}
+		return value
+
+	case "integer":
+		if v, ok := schema["minimum"]; ok {
+			return toInt(v)
+		}
+		return 0
+
+	case "number":
+		if v, ok := schema["minimum"]; ok {
+			return v
+		}
+		return 0
+
+	case "boolean":
+		return true
+
+	default:
+		return nil
+	}
+}
+
+// schemaType schema 的类型，类型为数组时取第一个非 null 的类型，未声明时按 properties 和 items 推断
+func schemaType(schema map[string]interface{}) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		for _, item := range t {
+			if s, ok := item.(string); ok && s != "null" {
+				return s
+			}
+		}
+	}
+	if schema["properties"] != nil {
+		return "object"
+	}
+	if schema["items"] != nil {
+		return "array"
+	}
+	return ""
+}
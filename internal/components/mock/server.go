@@ -0,0 +1,233 @@
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"Storage/internal/components/pathtemplate"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// Server 按接口定义返回示例响应的 HTTP 服务，可通过 Handler 嵌入其他服务或测试中使用
+type Server struct {
+	opts Options
+
+	mu        sync.RWMutex
+	routes    []*Route
+	overrides map[string]*Override
+
+	listener net.Listener
+	server   *http.Server
+}
+
+// NewServer 创建 Mock 服务，Start 后开始监听
+func NewServer(opts Options) *Server {
+	if opts.Host == "" {
+		opts.Host = DefaultHost
+	}
+	if opts.AdvertiseHost == "" {
+		opts.AdvertiseHost = DefaultAdvertiseHost
+	}
+	return &Server{
+		opts:      opts,
+		overrides: make(map[string]*Override),
+	}
+}
+
+// SetRoutes 替换全部路由，覆盖配置保留
+func (s *Server) SetRoutes(routes []*Route) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routes = routes
+}
+
+// Routes 当前的路由
+func (s *Server) Routes() []*Route {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.routes
+}
+
+// SetOverrides 替换全部覆盖配置，键为接口ID
+func (s *Server) SetOverrides(overrides map[string]*Override) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.overrides = make(map[string]*Override, len(overrides))
+	for apiID, override := range overrides {
+		s.overrides[apiID] = override
+	}
+}
+
+// SetOverride 设置接口的覆盖配置，override 为 nil 时删除
+func (s *Server) SetOverride(apiID string, override *Override) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if override == nil {
+		delete(s.overrides, apiID)
+		return
+	}
+	s.overrides[apiID] = override
+}
+
+// Override 接口的覆盖配置
+func (s *Server) Override(apiID string) (*Override, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	override, ok := s.overrides[apiID]
+	return override, ok
+}
+
+// Start 开始监听，端口为 0 时随机分配
+func (s *Server) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener != nil {
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(s.opts.Host, strconv.Itoa(s.opts.Port)))
+	if err != nil {
+		return fmt.Errorf("Mock 服务监听失败: %w", err)
+	}
+	server := &http.Server{Handler: s}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logx.Errorf("Mock 服务 %s 异常退出: %v", listener.Addr(), err)
+		}
+	}()
+	s.listener, s.server = listener, server
+	return nil
+}
+
+// URL 服务的基础URL，未启动时为空
+func (s *Server) URL() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.listener == nil {
+		return ""
+	}
+	port := s.listener.Addr().(*net.TCPAddr).Port
+	return "http://" + net.JoinHostPort(s.opts.AdvertiseHost, strconv.Itoa(port))
+}
+
+// Close 停止监听，等待进行中的请求结束
+func (s *Server) Close(ctx context.Context) error {
+	s.mu.Lock()
+	server := s.server
+	s.listener, s.server = nil, nil
+	s.mu.Unlock()
+	if server == nil {
+		return nil
+	}
+	return server.Shutdown(ctx)
+}
+
+// Handler 处理 Mock 请求的 handler
+func (s *Server) Handler() http.Handler {
+	return s
+}
+
+// ServeHTTP 按方法和路径匹配路由，多个路由匹配时选择最精确的路由
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	var matched *Route
+	var best pathtemplate.Match
+	var allowed []string
+	for _, route := range s.routes {
+		match, ok := route.match(r.URL.EscapedPath())
+		if !ok {
+			continue
+		}
+		if !route.allows(r.Method) {
+			allowed = append(allowed, route.Method)
+			continue
+		}
+		if matched == nil || match.MoreSpecific(best) {
+			matched, best = route, match
+		}
+	}
+	var override *Override
+	if matched != nil {
+		override = s.overrides[matched.ApiID]
+	}
+	s.mu.RUnlock()
+
+	if matched == nil {
+		status, message := http.StatusNotFound, "未找到匹配的接口"
+		if len(allowed) > 0 {
+			status, message = http.StatusMethodNotAllowed, "接口不支持该请求方法"
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+		}
+		writeError(w, status, message, r)
+		return
+	}
+
+	if override == nil {
+		override = &Override{}
+	}
+	requested, _ := strconv.Atoi(r.Header.Get(StatusHeader))
+	response := matched.response(requested, override.Status)
+
+	status := http.StatusOK
+	var body []byte
+	contentType := contentTypes["json"]
+	if response != nil {
+		status = response.Status
+		body, contentType = response.Body()
+	}
+	// 请求指定的状态码优先于覆盖配置，接口定义中没有该状态码时使用默认响应的响应体
+	if override.Status > 0 {
+		status = override.Status
+	}
+	if requested > 0 {
+		status = requested
+	}
+	if override.Body != "" {
+		body = []byte(override.Body)
+	}
+	if override.ContentType != "" {
+		contentType = override.ContentType
+	}
+
+	if delay := min(override.Delay, MaxDelay); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	for k, v := range override.Headers {
+		w.Header().Set(k, v)
+	}
+	w.Header().Set(ApiIDHeader, matched.ApiID)
+	if len(body) > 0 {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.WriteHeader(status)
+	if r.Method != http.MethodHead {
+		w.Write(body)
+	}
+}
+
+// writeError 返回 JSON 格式的错误
+func writeError(w http.ResponseWriter, status int, message string, r *http.Request) {
+	w.Header().Set("Content-Type", contentTypes["json"])
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":  message,
+		"method": r.Method,
+		"path":   r.URL.Path,
+	})
+}
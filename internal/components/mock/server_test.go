@@ -0,0 +1,254 @@
+package mock
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apimodel "Storage/internal/model/api"
+)
+
+// testRoutes 测试用的接口，路径有固定、参数和段中参数几种形式
+func testRoutes() []*Route {
+	return NewRoutes([]*apimodel.Api{
+		{ApiID: "list", Method: "get", Path: "/users"},
+		{ApiID: "me", Method: "GET", Path: "/users/me"},
+		{ApiID: "user", Method: "GET", Path: "/users/{id}", Responses: map[string]interface{}{
+			"responses": []interface{}{
+				map[string]interface{}{"id": "r1", "code": 200, "contentType": "json", "jsonSchema": map[string]interface{}{
+					"type":       "object",
+					"properties": map[string]interface{}{"name": map[string]interface{}{"type": "string", "example": "tom"}},
+				}},
+				map[string]interface{}{"id": "r2", "code": 404, "contentType": "json"},
+			},
+			"responseExamples": []interface{}{
+				map[string]interface{}{"responseId": "r2", "data": `{"error":"not found"}`},
+			},
+		}},
+		{ApiID: "update", Method: "PUT", Path: "/users/:id"},
+		{ApiID: "file", Method: "GET", Path: "/files/{name}"},
+		{ApiID: "file_json", Method: "GET", Path: "/files/{id}.json"},
+		{ApiID: "repo", Method: "GET", Path: "/repos/{org}-{repo}?tab=code"},
+		{ApiID: "ignored", Path: "/ignored"},
+	})
+}
+
+func TestNewRoutes(t *testing.T) {
+	routes := testRoutes()
+	if len(routes) != 7 {
+		t.Fatalf("routes = %d, want 7 (route without method ignored)", len(routes))
+	}
+	if routes[0].Method != http.MethodGet {
+		t.Errorf("Method = %q, want %q", routes[0].Method, http.MethodGet)
+	}
+}
+
+func TestServerRouting(t *testing.T) {
+	server := NewServer(Options{})
+	server.SetRoutes(testRoutes())
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantApiID  string
+		wantAllow  string
+	}{
+		{name: "固定路径", method: http.MethodGet, path: "/users", wantStatus: http.StatusOK, wantApiID: "list"},
+		{name: "末尾的 / 不影响匹配", method: http.MethodGet, path: "/users/", wantStatus: http.StatusOK, wantApiID: "list"},
+		{name: "固定段优先于参数", method: http.MethodGet, path: "/users/me", wantStatus: http.StatusOK, wantApiID: "me"},
+		{name: "花括号参数", method: http.MethodGet, path: "/users/42", wantStatus: http.StatusOK, wantApiID: "user"},
+		{name: "冒号参数", method: http.MethodPut, path: "/users/42", wantStatus: http.StatusOK, wantApiID: "update"},
+		{name: "段中参数优先于整段参数", method: http.MethodGet, path: "/files/report.json", wantStatus: http.StatusOK, wantApiID: "file_json"},
+		{name: "后缀不同时匹配整段参数", method: http.MethodGet, path: "/files/report.xml", wantStatus: http.StatusOK, wantApiID: "file"},
+		{name: "同一段多个参数", method: http.MethodGet, path: "/repos/acme-api", wantStatus: http.StatusOK, wantApiID: "repo"},
+		{name: "编码的路径", method: http.MethodGet, path: "/files/a%20b.json", wantStatus: http.StatusOK, wantApiID: "file_json"},
+		{name: "HEAD 匹配 GET 接口", method: http.MethodHead, path: "/users", wantStatus: http.StatusOK, wantApiID: "list"},
+		{name: "未找到接口", method: http.MethodGet, path: "/orders", wantStatus: http.StatusNotFound},
+		{name: "段中参数不匹配缺少分隔符的路径", method: http.MethodGet, path: "/repos/acme", wantStatus: http.StatusNotFound},
+		{name: "请求方法不支持", method: http.MethodDelete, path: "/users/42", wantStatus: http.StatusMethodNotAllowed, wantAllow: "GET, PUT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get(ApiIDHeader); got != tt.wantApiID {
+				t.Errorf("%s = %q, want %q", ApiIDHeader, got, tt.wantApiID)
+			}
+			if got := w.Header().Get("Allow"); got != tt.wantAllow {
+				t.Errorf("Allow = %q, want %q", got, tt.wantAllow)
+			}
+		})
+	}
+}
+
+func TestServerResponse(t *testing.T) {
+	tests := []struct {
+		name            string
+		method          string
+		override        *Override
+		requested       string
+		wantStatus      int
+		wantBody        string
+		wantContentType string
+		wantHeader      map[string]string
+	}{
+		{name: "按 schema 生成默认响应", wantStatus: http.StatusOK, wantBody: `{"name":"tom"}`, wantContentType: "application/json"},
+		{name: "请求指定状态码", requested: "404", wantStatus: http.StatusNotFound, wantBody: `{"error":"not found"}`, wantContentType: "application/json"},
+		{name: "接口没有的状态码使用默认响应体", requested: "503", wantStatus: http.StatusServiceUnavailable, wantBody: `{"name":"tom"}`, wantContentType: "application/json"},
+		{name: "覆盖状态码", override: &Override{Status: 404}, wantStatus: http.StatusNotFound, wantBody: `{"error":"not found"}`, wantContentType: "application/json"},
+		{name: "请求的状态码优先于覆盖配置", override: &Override{Status: 404}, requested: "200", wantStatus: http.StatusOK, wantBody: `{"name":"tom"}`, wantContentType: "application/json"},
+		{
+			name:            "覆盖响应体和响应头",
+			override:        &Override{Body: "ok", ContentType: "text/plain", Headers: map[string]string{"X-Trace": "t-1"}},
+			wantStatus:      http.StatusOK,
+			wantBody:        "ok",
+			wantContentType: "text/plain",
+			wantHeader:      map[string]string{"X-Trace": "t-1"},
+		},
+		{name: "HEAD 不返回响应体", method: http.MethodHead, wantStatus: http.StatusOK, wantContentType: "application/json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewServer(Options{})
+			server.SetRoutes(testRoutes())
+			server.SetOverride("user", tt.override)
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, "/users/42", nil)
+			if tt.requested != "" {
+				req.Header.Set(StatusHeader, tt.requested)
+			}
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Body.String(); got != tt.wantBody {
+				t.Errorf("body = %q, want %q", got, tt.wantBody)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantContentType)
+			}
+			for name, want := range tt.wantHeader {
+				if got := w.Header().Get(name); got != want {
+					t.Errorf("%s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestServerNotFoundBody(t *testing.T) {
+	server := NewServer(Options{})
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a%20b", nil))
+
+	want := `{"error":"未找到匹配的接口","method":"POST","path":"/a b"}` + "\n"
+	if got := w.Body.String(); got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
+
+func TestServerOverrides(t *testing.T) {
+	server := NewServer(Options{})
+	server.SetOverrides(map[string]*Override{"user": {Status: 500}, "list": {Status: 204}})
+	server.SetOverride("list", nil)
+
+	if _, ok := server.Override("list"); ok {
+		t.Errorf("Override(list) exists after SetOverride(nil)")
+	}
+	if override, ok := server.Override("user"); !ok || override.Status != 500 {
+		t.Errorf("Override(user) = %v, %v, want status 500", override, ok)
+	}
+
+	// 替换路由时保留覆盖配置
+	server.SetRoutes(testRoutes())
+	if _, ok := server.Override("user"); !ok {
+		t.Errorf("Override(user) removed by SetRoutes")
+	}
+}
+
+func TestServerDelay(t *testing.T) {
+	server := NewServer(Options{})
+	server.SetRoutes(testRoutes())
+	server.SetOverride("list", &Override{Delay: 50 * time.Millisecond})
+
+	start := time.Now()
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users", nil))
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("elapsed = %v, want at least 50ms", elapsed)
+	}
+
+	// 请求取消时不再等待
+	server.SetOverride("list", &Override{Delay: time.Hour})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		server.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users", nil).WithContext(ctx))
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("ServeHTTP does not return after the request is canceled")
+	}
+}
+
+func TestServerStartAndClose(t *testing.T) {
+	server := NewServer(Options{Host: "127.0.0.1"})
+	server.SetRoutes(testRoutes())
+	if server.URL() != "" {
+		t.Errorf("URL() before Start = %q, want empty", server.URL())
+	}
+	if err := server.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	// 重复启动不报错
+	if err := server.Start(); err != nil {
+		t.Fatalf("second Start() error = %v", err)
+	}
+	url := server.URL()
+	if !strings.HasPrefix(url, "http://127.0.0.1:") {
+		t.Fatalf("URL() = %q, want http://127.0.0.1:<port>", url)
+	}
+
+	resp, err := http.Get(url + "/files/report.json")
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if got := resp.Header.Get(ApiIDHeader); got != "file_json" {
+		t.Errorf("%s = %q, want %q", ApiIDHeader, got, "file_json")
+	}
+
+	if err := server.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if server.URL() != "" {
+		t.Errorf("URL() after Close = %q, want empty", server.URL())
+	}
+	if _, err := http.Get(url + "/users"); err == nil {
+		t.Errorf("GET after Close succeeded, want connection error")
+	}
+	if err := server.Close(context.Background()); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+}
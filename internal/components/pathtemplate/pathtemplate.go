@@ -0,0 +1,197 @@
+package pathtemplate
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// braceParam {name} 形式的路径参数，可出现在路径段中间，如 /files/{id}.json
+var braceParam = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_.-]*)\}`)
+
+// colonParam :name 形式的路径参数，占据整个路径段
+var colonParam = regexp.MustCompile(`^:([A-Za-z_][A-Za-z0-9_]*)$`)
+
+// Template 接口路径模板，支持 {name} 和 :name 形式的路径参数
+// 请求路径的参数替换和 Mock 路由的匹配使用同一套解析规则
+type Template struct {
+	// 按 / 切分的各段，保留首尾的空段，展开时按原样拼接
+	segments []segment
+}
+
+// segment 路径中的一段，由固定文本和参数交替组成
+type segment struct {
+	parts []part
+	// 段中有参数时用于匹配的正则，参数按顺序对应捕获组
+	pattern *regexp.Regexp
+}
+
+// part 固定文本或参数，param 不为空时为参数
+type part struct {
+	// 原始文本，展开时原样保留
+	raw string
+	// 解码后的文本，匹配时与解码后的请求路径比较
+	literal string
+	param   string
+}
+
+// Match 请求路径与模板的匹配结果
+type Match struct {
+	// 路径参数的值，已解码
+	Params map[string]string
+	// 全部为固定文本的段数
+	Literals int
+	// 含参数的段中固定文本的长度
+	LiteralChars int
+}
+
+// MoreSpecific 是否比 other 更精确，固定段多的更精确，其次比较含参数的段中固定文本的长度
+func (m Match) MoreSpecific(other Match) bool {
+	if m.Literals != other.Literals {
+		return m.Literals > other.Literals
+	}
+	return m.LiteralChars > other.LiteralChars
+}
+
+// Parse 解析路径模板，path 只包含路径部分，不含协议、主机和查询串
+func Parse(path string) *Template {
+	raws := strings.Split(path, "/")
+	t := &Template{segments: make([]segment, len(raws))}
+	for i, raw := range raws {
+		t.segments[i] = parseSegment(raw)
+	}
+	return t
+}
+
+func parseSegment(raw string) segment {
+	if match := colonParam.FindStringSubmatch(raw); match != nil {
+		return segment{
+			parts:   []part{{raw: raw, param: match[1]}},
+			pattern: regexp.MustCompile(`^(.+)$`),
+		}
+	}
+
+	var seg segment
+	var pattern strings.Builder
+	last := 0
+	for _, loc := range braceParam.FindAllStringSubmatchIndex(raw, -1) {
+		if loc[0] > last {
+			seg.parts = append(seg.parts, literalPart(raw[last:loc[0]]))
+			pattern.WriteString(regexp.QuoteMeta(seg.parts[len(seg.parts)-1].literal))
+		}
+		seg.parts = append(seg.parts, part{raw: raw[loc[0]:loc[1]], param: raw[loc[2]:loc[3]]})
+		pattern.WriteString(`(.+?)`)
+		last = loc[1]
+	}
+	if last < len(raw) || len(seg.parts) == 0 {
+		seg.parts = append(seg.parts, literalPart(raw[last:]))
+		pattern.WriteString(regexp.QuoteMeta(seg.parts[len(seg.parts)-1].literal))
+	}
+	if seg.hasParam() {
+		seg.pattern = regexp.MustCompile("^" + pattern.String() + "$")
+	}
+	return seg
+}
+
+// literalPart 固定文本，文档中的路径可能已编码，匹配时按解码后的值比较
+func literalPart(raw string) part {
+	literal := raw
+	if unescaped, err := url.PathUnescape(raw); err == nil {
+		literal = unescaped
+	}
+	return part{raw: raw, literal: literal}
+}
+
+func (s segment) hasParam() bool {
+	for _, p := range s.parts {
+		if p.param != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Expand 替换路径参数，参数值按路径段编码，固定文本原样保留
+// value 返回错误时停止替换并返回该错误
+func (t *Template) Expand(value func(name string) (string, error)) (string, error) {
+	segments := make([]string, len(t.segments))
+	for i, seg := range t.segments {
+		var b strings.Builder
+		for _, p := range seg.parts {
+			if p.param == "" {
+				b.WriteString(p.raw)
+				continue
+			}
+			v, err := value(p.param)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(url.PathEscape(v))
+		}
+		segments[i] = b.String()
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// Match 匹配请求路径，path 为未解码的路径，首尾的 / 不影响匹配
+func (t *Template) Match(path string) (Match, bool) {
+	parts := splitPath(path)
+	segments := t.trimmed()
+	if len(parts) != len(segments) {
+		return Match{}, false
+	}
+
+	match := Match{Params: make(map[string]string)}
+	for i, seg := range segments {
+		value := parts[i]
+		if unescaped, err := url.PathUnescape(value); err == nil {
+			value = unescaped
+		}
+		if seg.pattern == nil {
+			if seg.parts[0].literal != value {
+				return Match{}, false
+			}
+			match.Literals++
+			continue
+		}
+
+		groups := seg.pattern.FindStringSubmatch(value)
+		if groups == nil {
+			return Match{}, false
+		}
+		group := 1
+		for _, p := range seg.parts {
+			if p.param == "" {
+				match.LiteralChars += len(p.literal)
+				continue
+			}
+			match.Params[p.param] = groups[group]
+			group++
+		}
+	}
+	return match, true
+}
+
+// trimmed 去掉首尾空段后的各段
+func (t *Template) trimmed() []segment {
+	segments := t.segments
+	for len(segments) > 0 && isEmpty(segments[0]) {
+		segments = segments[1:]
+	}
+	for len(segments) > 0 && isEmpty(segments[len(segments)-1]) {
+		segments = segments[:len(segments)-1]
+	}
+	return segments
+}
+
+func isEmpty(seg segment) bool {
+	return seg.pattern == nil && seg.parts[0].raw == ""
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
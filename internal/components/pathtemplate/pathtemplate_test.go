@@ -0,0 +1,115 @@
+package pathtemplate
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestTemplateExpand(t *testing.T) {
+	values := map[string]string{"id": "42", "org": "acme", "repo": "api", "name": "a b/c?d"}
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr string
+	}{
+		{name: "没有参数", path: "/users/", want: "/users/"},
+		{name: "花括号参数", path: "/users/{id}", want: "/users/42"},
+		{name: "冒号参数", path: "users/:id/orders", want: "users/42/orders"},
+		{name: "冒号只匹配整个路径段", path: "/time/10:30", want: "/time/10:30"},
+		{name: "参数在路径段中间", path: "/files/{id}.json", want: "/files/42.json"},
+		{name: "同一路径段多个参数", path: "/{org}-{repo}/issues", want: "/acme-api/issues"},
+		{name: "参数值按路径段编码", path: "/files/{name}", want: "/files/a%20b%2Fc%3Fd"},
+		{name: "固定文本原样保留", path: "/a%20b/{id}", want: "/a%20b/42"},
+		{name: "缺少参数", path: "/users/{id}/{missing}", wantErr: "缺少路径参数 missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.path).Expand(func(name string) (string, error) {
+				value, ok := values[name]
+				if !ok {
+					return "", fmt.Errorf("缺少路径参数 %s", name)
+				}
+				return value, nil
+			})
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("Expand() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expand() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		path     string
+		want     *Match
+	}{
+		{name: "固定路径", template: "/users", path: "/users", want: &Match{Params: map[string]string{}, Literals: 1}},
+		{name: "首尾的 / 不影响匹配", template: "/users/", path: "users", want: &Match{Params: map[string]string{}, Literals: 1}},
+		{name: "根路径", template: "/", path: "/", want: &Match{Params: map[string]string{}}},
+		{name: "花括号参数", template: "/users/{id}", path: "/users/42", want: &Match{Params: map[string]string{"id": "42"}, Literals: 1}},
+		{name: "冒号参数", template: "/users/:id", path: "/users/42", want: &Match{Params: map[string]string{"id": "42"}, Literals: 1}},
+		{name: "参数在路径段中间", template: "/files/{id}.json", path: "/files/report.json", want: &Match{Params: map[string]string{"id": "report"}, Literals: 1, LiteralChars: 5}},
+		{name: "同一路径段多个参数", template: "/{org}-{repo}", path: "/acme-api-v2", want: &Match{Params: map[string]string{"org": "acme", "repo": "api-v2"}, LiteralChars: 1}},
+		{name: "参数值解码", template: "/tags/{tag}", path: "/tags/%E6%B5%8B%E8%AF%95", want: &Match{Params: map[string]string{"tag": "测试"}, Literals: 1}},
+		{name: "编码的固定文本按解码后比较", template: "/a%20b", path: "/a b", want: &Match{Params: map[string]string{}, Literals: 1}},
+		{name: "固定文本中的正则字符", template: "/v1.0/{id}.json", path: "/v1.0/1.json", want: &Match{Params: map[string]string{"id": "1"}, Literals: 1, LiteralChars: 5}},
+		{name: "段数不同", template: "/users/{id}", path: "/users", want: nil},
+		{name: "固定文本不同", template: "/users/{id}", path: "/orders/1", want: nil},
+		{name: "后缀不同", template: "/files/{id}.json", path: "/files/report.xml", want: nil},
+		{name: "参数不能为空", template: "/files/{id}.json", path: "/files/.json", want: nil},
+		{name: "正则字符按字面匹配", template: "/v1.0", path: "/v100", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Parse(tt.template).Match(tt.path)
+			if tt.want == nil {
+				if ok {
+					t.Errorf("Match(%q) = %+v, want no match", tt.path, got)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("Match(%q) no match, want %+v", tt.path, *tt.want)
+			}
+			if !reflect.DeepEqual(got, *tt.want) {
+				t.Errorf("Match(%q) = %+v, want %+v", tt.path, got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchMoreSpecific(t *testing.T) {
+	path := "/files/report.json"
+	templates := []string{"/{a}/{b}", "/files/{name}", "/files/{id}.json", "/files/report.json"}
+
+	// 按顺序越来越精确
+	var best Match
+	for i, template := range templates {
+		match, ok := Parse(template).Match(path)
+		if !ok {
+			t.Fatalf("%s does not match %s", template, path)
+		}
+		if i > 0 && !match.MoreSpecific(best) {
+			t.Errorf("%s is not more specific than %s", template, templates[i-1])
+		}
+		if i > 0 && best.MoreSpecific(match) {
+			t.Errorf("%s is more specific than %s", templates[i-1], template)
+		}
+		best = match
+	}
+}
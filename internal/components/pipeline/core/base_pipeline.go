@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"sync"
 	"time"
 )
 
@@ -30,31 +31,24 @@ type BasePipeline struct {
 
 	// 错误信息
 	Error error
-}
 
-// OnCancel implements PipelineRunner.
-func (p *BasePipeline) OnCancel(ctx context.Context, taskID string) error {
-	panic("unimplemented")
-}
+	// 状态锁
+	mu sync.RWMutex
 
-// OnComplete implements PipelineRunner.
-func (p *BasePipeline) OnComplete(ctx context.Context, taskID string, result map[string]interface{}) error {
-	panic("unimplemented")
-}
+	// 有序钩子链
+	hooks *HookChain
 
-// OnFailure implements PipelineRunner.
-func (p *BasePipeline) OnFailure(ctx context.Context, taskID string, err error) error {
-	panic("unimplemented")
-}
+	// 单次执行的上下文，Cancel 时取消
+	execCtx    context.Context
+	execCancel context.CancelFunc
 
-// OnStart implements PipelineRunner.
-func (p *BasePipeline) OnStart(ctx context.Context, taskID string, spec map[string]interface{}) error {
-	panic("unimplemented")
-}
+	// 单次执行的超时预算，<=0 时只受上层预算限制
+	timeoutLevel TimeoutLevel
+	timeout      time.Duration
 
-// OnSuccess implements PipelineRunner.
-func (p *BasePipeline) OnSuccess(ctx context.Context, taskID string, result map[string]interface{}) error {
-	panic("unimplemented")
+	// 所属执行ID及事件发布者，未绑定时不发布事件
+	executionID string
+	events      EventPublisher
 }
 
 // NewBasePipeline 创建新的基础管道
@@ -65,24 +59,253 @@ func NewBasePipeline(name, description string) *BasePipeline {
 		Status:      TaskStatusPending,
 		Progress:    0.0,
 		Result:      make(map[string]interface{}),
+		hooks:       NewHookChain(),
+	}
+}
+
+// chain 获取钩子链，兼容直接构造的 BasePipeline{}
+func (p *BasePipeline) chain() *HookChain {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.hooks == nil {
+		p.hooks = NewHookChain()
+	}
+	return p.hooks
+}
+
+// AddHook 添加钩子，出错时记录日志并继续
+func (p *BasePipeline) AddHook(hook Hook) {
+	p.chain().Add(hook, HookPolicyContinue)
+}
+
+// AddHookWithPolicy 添加钩子并指定出错时的处理策略
+func (p *BasePipeline) AddHookWithPolicy(hook Hook, policy HookErrorPolicy) {
+	p.chain().Add(hook, policy)
+}
+
+// OnStart 依次调用钩子链的 OnStart
+func (p *BasePipeline) OnStart(ctx context.Context, taskID string, spec map[string]interface{}) error {
+	return p.chain().OnStart(ctx, taskID, spec)
+}
+
+// OnSuccess 依次调用钩子链的 OnSuccess
+func (p *BasePipeline) OnSuccess(ctx context.Context, taskID string, result map[string]interface{}) error {
+	return p.chain().OnSuccess(ctx, taskID, result)
+}
+
+// OnFailure 依次调用钩子链的 OnFailure
+func (p *BasePipeline) OnFailure(ctx context.Context, taskID string, err error) error {
+	return p.chain().OnFailure(ctx, taskID, err)
+}
+
+// OnCancel 依次调用钩子链的 OnCancel
+func (p *BasePipeline) OnCancel(ctx context.Context, taskID string) error {
+	return p.chain().OnCancel(ctx, taskID)
+}
+
+// OnComplete 依次调用钩子链的 OnComplete
+func (p *BasePipeline) OnComplete(ctx context.Context, taskID string, result map[string]interface{}) error {
+	return p.chain().OnComplete(ctx, taskID, result)
+}
+
+// BindEvents 绑定所属执行，之后的状态和进度变化会发布到事件总线
+func (p *BasePipeline) BindEvents(executionID string, publisher EventPublisher) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.executionID = executionID
+	p.events = publisher
+}
+
+// ExecutionID 获取所属执行ID
+func (p *BasePipeline) ExecutionID() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.executionID
+}
+
+// EmitEvent 发布执行事件，未绑定事件发布者时忽略
+func (p *BasePipeline) EmitEvent(event ExecutionEvent) {
+	p.mu.RLock()
+	publisher, executionID := p.events, p.executionID
+	p.mu.RUnlock()
+	if publisher == nil {
+		return
+	}
+
+	event.ExecutionID = executionID
+	if event.Source == "" {
+		event.Source = p.Name
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	publisher.Publish(event)
+}
+
+// SetProgress 更新执行进度并发布进度事件
+func (p *BasePipeline) SetProgress(progress float64) {
+	p.mu.Lock()
+	p.Progress = progress
+	p.mu.Unlock()
+
+	p.EmitEvent(ExecutionEvent{Type: EventProgress, Status: p.GetStatus(context.Background()), Progress: progress})
+}
+
+// TransitionTo 按状态机流转状态，非法流转返回 errors.StateTransitionError
+func (p *BasePipeline) TransitionTo(to TaskStatus) error {
+	return p.transition(to, nil)
+}
+
+// transition 流转状态并记录错误，成功后发布状态事件
+func (p *BasePipeline) transition(to TaskStatus, execErr error) error {
+	p.mu.Lock()
+	if err := ValidateTransition(p.Status, to); err != nil {
+		p.mu.Unlock()
+		return err
+	}
+
+	p.Status = to
+	switch {
+	case to == TaskStatusRunning:
+		p.StartTime = time.Now()
+	case to == TaskStatusCompleted:
+		p.EndTime = time.Now()
+		p.Progress = 1.0
+	case to.IsTerminal():
+		p.EndTime = time.Now()
 	}
+	if execErr != nil {
+		p.Error = execErr
+	}
+	event := ExecutionEvent{Type: EventStatus, Status: to, Progress: p.Progress}
+	p.mu.Unlock()
+
+	if execErr != nil {
+		event.Error = execErr.Error()
+	}
+	p.EmitEvent(event)
+	return nil
+}
+
+// SetTimeout 设置单次执行的超时预算，在下一次 Begin 时生效
+func (p *BasePipeline) SetTimeout(level TimeoutLevel, timeout time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.timeoutLevel = level
+	p.timeout = timeout
+}
+
+// Begin 进入运行状态，创建本次执行的上下文并触发 OnStart 钩子
+// 执行上下文在 ctx 的基础上叠加本管道的超时预算；abort 策略的钩子出错时，管道直接转为失败
+func (p *BasePipeline) Begin(ctx context.Context, spec map[string]interface{}) error {
+	if err := p.TransitionTo(TaskStatusRunning); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.execCtx, p.execCancel = WithTimeout(ctx, p.timeoutLevel, p.timeout)
+	p.mu.Unlock()
+
+	if err := p.OnStart(ctx, p.hookTaskID(), spec); err != nil {
+		p.Finish(ctx, nil, err)
+		return err
+	}
+	return nil
+}
+
+// ExecutionContext 获取本次执行的上下文，子任务应基于它执行以便响应 Cancel
+func (p *BasePipeline) ExecutionContext() context.Context {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.execCtx == nil {
+		return context.Background()
+	}
+	return p.execCtx
+}
+
+// releaseExecution 取消本次执行的上下文，释放关联资源
+func (p *BasePipeline) releaseExecution() {
+	p.mu.RLock()
+	cancel := p.execCancel
+	p.mu.RUnlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// Finish 根据执行结果转为完成或失败，并触发 OnSuccess/OnFailure 和 OnComplete 钩子
+// 超时或上层取消后钩子仍需执行，钩子使用不随 ctx 取消的上下文
+func (p *BasePipeline) Finish(ctx context.Context, result map[string]interface{}, execErr error) error {
+	defer p.releaseExecution()
+	ctx = context.WithoutCancel(ctx)
+	taskID := p.hookTaskID()
+
+	if execErr != nil {
+		if err := p.transition(TaskStatusFailed, execErr); err != nil {
+			return err
+		}
+		result = p.setResult(result)
+
+		hookErr := p.OnFailure(ctx, taskID, execErr)
+		if err := p.OnComplete(ctx, taskID, result); hookErr == nil {
+			hookErr = err
+		}
+		return hookErr
+	}
+
+	if err := p.TransitionTo(TaskStatusCompleted); err != nil {
+		return err
+	}
+	result = p.setResult(result)
+
+	hookErr := p.OnSuccess(ctx, taskID, result)
+	if err := p.OnComplete(ctx, taskID, result); hookErr == nil {
+		hookErr = err
+	}
+	if hookErr != nil {
+		p.mu.Lock()
+		p.Error = hookErr
+		p.mu.Unlock()
+	}
+	return hookErr
+}
+
+// setResult 记录执行结果并返回当前结果，result 为 nil 时保留已有结果
+func (p *BasePipeline) setResult(result map[string]interface{}) map[string]interface{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if result != nil {
+		p.Result = result
+	}
+	return p.Result
+}
+
+// hookTaskID 钩子使用的任务标识，绑定执行后为执行ID，未绑定时为管道名称
+func (p *BasePipeline) hookTaskID() string {
+	if executionID := p.ExecutionID(); executionID != "" {
+		return executionID
+	}
+	return p.Name
 }
 
 // Initialize 初始化管道
 func (p *BasePipeline) Initialize(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// 运行中的管道不允许重置
+	if p.Status == TaskStatusRunning {
+		return ValidateTransition(p.Status, TaskStatusPending)
+	}
+
 	p.Status = TaskStatusPending
 	p.Progress = 0.0
 	p.StartTime = time.Time{}
 	p.EndTime = time.Time{}
 	p.Result = make(map[string]interface{})
 	p.Error = nil
-
-	// 调用钩子方法
-	// for _, hook := range p.Hooks {
-	// 	if err := hook.OnStart(ctx, p.Name, nil); err != nil {
-	// 		return err
-	// 	}
-	// } // Added comment for closing brace
+	p.execCtx = nil
+	p.execCancel = nil
 
 	return nil
 }
@@ -95,56 +318,46 @@ func (p *BasePipeline) Validate(ctx context.Context) error {
 
 // Execute 执行管道
 func (p *BasePipeline) Execute(ctx context.Context, spec map[string]interface{}) (map[string]interface{}, error) {
-	// 记录开始时间
-	p.StartTime = time.Now()
-	p.Status = TaskStatusRunning
-
-	// 调用钩子方法
-	// for _, hook := range p.Hooks {
-	// 	if err := hook.OnStart(ctx, p.Name, spec); err != nil {
-	// 		p.Error = err
-	// 		p.Status = TaskStatusFailed
-	// 		return nil, err
-	// 	}
-	// }
+	if err := p.Begin(ctx, spec); err != nil {
+		return nil, err
+	}
 
 	// 基础实现不做实际工作，返回空结果
-	p.Result = make(map[string]interface{})
-	p.Status = TaskStatusCompleted
-	// p.Progress = 1.0
-	p.EndTime = time.Now()
-
-	// 调用成功钩子
-	// for _, hook := range p.Hooks {
-	// 	if err := hook.OnSuccess(ctx, p.Name, p.Result); err != nil {
-	// 		p.Error = err
-	// 		// 不中断执行流程，但记录错误
-	// 	}
-	// }
+	if err := p.Finish(ctx, make(map[string]interface{}), nil); err != nil {
+		return p.Result, err
+	}
 
 	return p.Result, nil
 }
 
-// Cancel 取消管道执行
+// Cancel 取消管道执行，本次执行的上下文随之取消
 func (p *BasePipeline) Cancel(ctx context.Context) error {
-	if p.Status == TaskStatusRunning {
-		p.Status = TaskStatusCanceled
-		p.EndTime = time.Now()
+	// 重复取消直接返回
+	if p.GetStatus(ctx) == TaskStatusCanceled {
+		return nil
+	}
 
-		// 调用取消钩子
-		// for _, hook := range p.Hooks {
-		// 	if err := hook.OnCancel(ctx, p.Name); err != nil {
-		// 		// 记录错误但不中断
-		// 		p.Error = err
-		// 	}
-		// }
+	if err := p.TransitionTo(TaskStatusCanceled); err != nil {
+		return err
 	}
+	p.releaseExecution()
+	ctx = context.WithoutCancel(ctx)
 
-	return nil
+	taskID := p.hookTaskID()
+	hookErr := p.OnCancel(ctx, taskID)
+	if err := p.OnComplete(ctx, taskID, p.setResult(nil)); hookErr == nil {
+		hookErr = err
+	}
+	return hookErr
 }
 
 // GetStatus 获取管道状态
 func (p *BasePipeline) GetStatus(ctx context.Context) TaskStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.Status == "" {
+		return TaskStatusPending
+	}
 	return p.Status
 }
 
@@ -157,7 +370,7 @@ func (p *BasePipeline) GetProgress(ctx context.Context) (float64, error) {
 func (p *BasePipeline) GetMetrics(ctx context.Context) map[string]interface{} {
 	metrics := make(map[string]interface{})
 	metrics["name"] = p.Name
-	metrics["status"] = p.Status
+	metrics["status"] = p.GetStatus(ctx)
 
 	if !p.StartTime.IsZero() {
 		metrics["start_time"] = p.StartTime.Format(time.RFC3339)
@@ -172,6 +385,9 @@ func (p *BasePipeline) GetMetrics(ctx context.Context) map[string]interface{} {
 
 	if p.Error != nil {
 		metrics["error"] = p.Error.Error()
+		if level := TimeoutLevelOf(p.Error); level != "" {
+			metrics["timeout_level"] = string(level)
+		}
 	}
 
 	return metrics
@@ -179,18 +395,6 @@ func (p *BasePipeline) GetMetrics(ctx context.Context) map[string]interface{} {
 
 // Cleanup 清理资源
 func (p *BasePipeline) Cleanup(ctx context.Context) error {
-	// 调用完成钩子
-	// for _, hook := range p.Hooks {
-	// 	if err := hook.OnComplete(ctx, p.Name, p.Result); err != nil {
-	// 		// 记录错误但不中断
-	// 		p.Error = err
-	// 	}
-	// }
-
+	// 基础实现无资源需要释放，OnComplete 已在进入终态时触发
 	return nil
 }
-
-// AddHook 添加钩子
-func (p *BasePipeline) AddHook(hook Hook) {
-	// p.Hooks = append(p.Hooks, hook)
-}
@@ -0,0 +1,258 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// DefaultCheckpointInterval 默认检查点持久化间隔
+const DefaultCheckpointInterval = 5 * time.Second
+
+// CheckpointState 一次执行中一个管道的进度
+// 同一执行中的管道以 Source 区分，重试或恢复同一执行时按执行ID和来源找回进度
+type CheckpointState struct {
+	ExecutionID     string
+	Source          string
+	TaskID          string
+	PipelineType    PipelineType
+	CompletedApiIds []string
+	FinishedScenes  []string
+	Variables       map[string]interface{}
+	CreateAt        time.Time
+}
+
+// CheckpointStore 检查点存储，由模型层实现
+type CheckpointStore interface {
+	// Save 按执行ID和来源整体写入检查点，不存在时创建
+	Save(ctx context.Context, state *CheckpointState) error
+	// Load 查询可恢复的检查点，不存在或执行已结束时返回 nil
+	Load(ctx context.Context, executionID, source string) (*CheckpointState, error)
+	// MarkDone 标记执行结束，检查点不再用于恢复
+	MarkDone(ctx context.Context, executionID, source string) error
+}
+
+// Checkpointer 管道执行检查点
+// 进度先记录在内存中，由后台协程定期写入存储，重试或恢复同一执行时据此跳过已完成的部分
+type Checkpointer struct {
+	mu sync.RWMutex
+
+	// 检查点存储
+	store CheckpointStore
+
+	// 当前检查点数据
+	state *CheckpointState
+
+	// 已完成API的索引
+	completedApis map[string]struct{}
+
+	// 已完成场景的索引
+	finishedScenes map[string]struct{}
+
+	// 是否有未持久化的变更
+	dirty bool
+
+	// 持久化间隔
+	interval time.Duration
+
+	// 停止后台持久化
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewCheckpointer 创建检查点，source 区分同一执行中的管道，interval<=0时使用默认间隔
+func NewCheckpointer(store CheckpointStore, executionID, source, taskID string, pipelineType PipelineType, interval time.Duration) *Checkpointer {
+	if interval <= 0 {
+		interval = DefaultCheckpointInterval
+	}
+	return &Checkpointer{
+		store: store,
+		state: &CheckpointState{
+			ExecutionID:  executionID,
+			Source:       source,
+			TaskID:       taskID,
+			PipelineType: pipelineType,
+			Variables:    make(map[string]interface{}),
+		},
+		completedApis:  make(map[string]struct{}),
+		finishedScenes: make(map[string]struct{}),
+		interval:       interval,
+	}
+}
+
+// ExecutionID 获取检查点对应的执行ID
+func (c *Checkpointer) ExecutionID() string {
+	return c.state.ExecutionID
+}
+
+// Resume 加载上次的检查点，返回是否存在可恢复的进度
+func (c *Checkpointer) Resume(ctx context.Context) (bool, error) {
+	saved, err := c.store.Load(ctx, c.state.ExecutionID, c.state.Source)
+	if err != nil {
+		return false, err
+	}
+	if saved == nil {
+		return false, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.state.CreateAt = saved.CreateAt
+	c.state.CompletedApiIds = saved.CompletedApiIds
+	c.state.FinishedScenes = saved.FinishedScenes
+	if saved.Variables != nil {
+		c.state.Variables = saved.Variables
+	}
+	for _, id := range saved.CompletedApiIds {
+		c.completedApis[id] = struct{}{}
+	}
+	for _, id := range saved.FinishedScenes {
+		c.finishedScenes[id] = struct{}{}
+	}
+
+	logx.Infof("从检查点恢复执行 %s 的 %s: 已完成API %d 个, 已完成场景 %d 个",
+		c.state.ExecutionID, c.state.Source, len(c.completedApis), len(c.finishedScenes))
+	return true, nil
+}
+
+// MarkApiCompleted 记录已完成的API
+func (c *Checkpointer) MarkApiCompleted(apiID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.completedApis[apiID]; ok {
+		return
+	}
+	c.completedApis[apiID] = struct{}{}
+	c.state.CompletedApiIds = append(c.state.CompletedApiIds, apiID)
+	c.dirty = true
+}
+
+// IsApiCompleted 判断API是否已完成
+func (c *Checkpointer) IsApiCompleted(apiID string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.completedApis[apiID]
+	return ok
+}
+
+// MarkSceneFinished 记录已完成的场景
+func (c *Checkpointer) MarkSceneFinished(sceneID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.finishedScenes[sceneID]; ok {
+		return
+	}
+	c.finishedScenes[sceneID] = struct{}{}
+	c.state.FinishedScenes = append(c.state.FinishedScenes, sceneID)
+	c.dirty = true
+}
+
+// IsSceneFinished 判断场景是否已完成
+func (c *Checkpointer) IsSceneFinished(sceneID string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.finishedScenes[sceneID]
+	return ok
+}
+
+// SetVariable 记录已解析的变量
+func (c *Checkpointer) SetVariable(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.state.Variables[key] = value
+	c.dirty = true
+}
+
+// Variables 获取已解析变量的副本
+func (c *Checkpointer) Variables() map[string]interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	variables := make(map[string]interface{}, len(c.state.Variables))
+	for k, v := range c.state.Variables {
+		variables[k] = v
+	}
+	return variables
+}
+
+// Start 启动后台定期持久化
+func (c *Checkpointer) Start(ctx context.Context) {
+	c.mu.Lock()
+	if c.stop != nil {
+		c.mu.Unlock()
+		return
+	}
+	c.stop = make(chan struct{})
+	stop := c.stop
+	c.mu.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := c.Flush(context.Background()); err != nil {
+					logx.Errorf("持久化检查点 %s/%s 失败: %v", c.state.ExecutionID, c.state.Source, err)
+				}
+			}
+		}
+	}()
+}
+
+// Flush 立即持久化未保存的变更
+func (c *Checkpointer) Flush(ctx context.Context) error {
+	c.mu.Lock()
+	if !c.dirty {
+		c.mu.Unlock()
+		return nil
+	}
+	snapshot := *c.state
+	snapshot.CompletedApiIds = append([]string(nil), c.state.CompletedApiIds...)
+	snapshot.FinishedScenes = append([]string(nil), c.state.FinishedScenes...)
+	snapshot.Variables = make(map[string]interface{}, len(c.state.Variables))
+	for k, v := range c.state.Variables {
+		snapshot.Variables[k] = v
+	}
+	c.dirty = false
+	c.mu.Unlock()
+
+	if err := c.store.Save(ctx, &snapshot); err != nil {
+		c.mu.Lock()
+		c.dirty = true
+		c.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Stop 停止后台持久化并写入最后一次进度
+// done为true表示执行已结束，检查点不再用于恢复
+func (c *Checkpointer) Stop(ctx context.Context, done bool) error {
+	c.mu.Lock()
+	stop := c.stop
+	c.stop = nil
+	c.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		c.wg.Wait()
+	}
+
+	if err := c.Flush(ctx); err != nil {
+		return err
+	}
+	if done {
+		return c.store.MarkDone(ctx, c.state.ExecutionID, c.state.Source)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// memoryCheckpointStore 测试用的检查点存储，done 中的检查点不再返回
+type memoryCheckpointStore struct {
+	mu     sync.Mutex
+	states map[string]*CheckpointState
+	done   map[string]bool
+	saves  int
+}
+
+func newMemoryCheckpointStore() *memoryCheckpointStore {
+	return &memoryCheckpointStore{
+		states: make(map[string]*CheckpointState),
+		done:   make(map[string]bool),
+	}
+}
+
+func (s *memoryCheckpointStore) Save(ctx context.Context, state *CheckpointState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	saved := *state
+	s.states[state.ExecutionID+"/"+state.Source] = &saved
+	s.saves++
+	return nil
+}
+
+func (s *memoryCheckpointStore) Load(ctx context.Context, executionID, source string) (*CheckpointState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := executionID + "/" + source
+	if s.done[key] {
+		return nil, nil
+	}
+	return s.states[key], nil
+}
+
+func (s *memoryCheckpointStore) MarkDone(ctx context.Context, executionID, source string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.done[executionID+"/"+source] = true
+	return nil
+}
+
+func TestCheckpointerResume(t *testing.T) {
+	tests := []struct {
+		name string
+		// 恢复时使用的执行ID和来源
+		executionID, source string
+		// 上次执行结束时是否标记完成
+		done       bool
+		wantResume bool
+	}{
+		{name: "同一执行同一来源", executionID: "exec-1", source: "task-1", wantResume: true},
+		{name: "新的执行不复用进度", executionID: "exec-2", source: "task-1"},
+		{name: "同一执行的其他来源", executionID: "exec-1", source: "task-2"},
+		{name: "执行已结束", executionID: "exec-1", source: "task-1", done: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newMemoryCheckpointStore()
+
+			first := NewCheckpointer(store, "exec-1", "task-1", "task-1", TypeAPI, 0)
+			first.MarkApiCompleted("api-1")
+			first.MarkSceneFinished("scene-1")
+			first.SetVariable("token", "abc")
+			if err := first.Stop(ctx, tt.done); err != nil {
+				t.Fatalf("Stop() error = %v", err)
+			}
+
+			second := NewCheckpointer(store, tt.executionID, tt.source, "task-1", TypeAPI, 0)
+			resumed, err := second.Resume(ctx)
+			if err != nil {
+				t.Fatalf("Resume() error = %v", err)
+			}
+			if resumed != tt.wantResume {
+				t.Errorf("Resume() = %v, want %v", resumed, tt.wantResume)
+			}
+			if got := second.IsApiCompleted("api-1"); got != tt.wantResume {
+				t.Errorf("IsApiCompleted(api-1) = %v, want %v", got, tt.wantResume)
+			}
+			if got := second.IsSceneFinished("scene-1"); got != tt.wantResume {
+				t.Errorf("IsSceneFinished(scene-1) = %v, want %v", got, tt.wantResume)
+			}
+			if got := second.Variables()["token"] == "abc"; got != tt.wantResume {
+				t.Errorf("Variables() = %v, want token restored %v", second.Variables(), tt.wantResume)
+			}
+		})
+	}
+}
+
+func TestCheckpointerFlushOnlyWhenDirty(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryCheckpointStore()
+	c := NewCheckpointer(store, "exec-1", "task-1", "task-1", TypeApiFox, 0)
+
+	if err := c.Flush(ctx); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if store.saves != 0 {
+		t.Errorf("无变更时 saves = %d, want 0", store.saves)
+	}
+
+	// 重复标记同一API不产生新的变更
+	c.MarkApiCompleted("api-1")
+	c.Flush(ctx)
+	c.MarkApiCompleted("api-1")
+	c.Flush(ctx)
+	if store.saves != 1 {
+		t.Errorf("saves = %d, want 1", store.saves)
+	}
+
+	saved, _ := store.Load(ctx, "exec-1", "task-1")
+	if fmt.Sprint(saved.CompletedApiIds) != "[api-1]" || saved.PipelineType != TypeApiFox {
+		t.Errorf("saved = %+v, want completed [api-1] of type %s", saved, TypeApiFox)
+	}
+}
@@ -0,0 +1,258 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// ExecutionEventType 执行事件类型
+type ExecutionEventType string
+
+const (
+	// EventStatus 管道状态变化
+	EventStatus ExecutionEventType = "status"
+	// EventProgress 管道进度变化
+	EventProgress ExecutionEventType = "progress"
+	// EventScene 场景执行结果
+	EventScene ExecutionEventType = "scene"
+	// EventStep 步骤执行结果
+	EventStep ExecutionEventType = "step"
+	// EventSummary 执行结束汇总，之后不再有事件
+	EventSummary ExecutionEventType = "summary"
+)
+
+const (
+	// defaultEventHistorySize 每个执行保留的历史事件数，供后订阅的观察者回放
+	defaultEventHistorySize = 256
+	// defaultEventRetention 执行结束后事件保留时长
+	defaultEventRetention = 5 * time.Minute
+	// subscriberBuffer 订阅者缓冲区大小
+	subscriberBuffer = 64
+	// relayPublishTimeout 向其他实例广播单个事件的超时
+	relayPublishTimeout = time.Second
+)
+
+// ExecutionEvent 执行过程中产生的事件
+type ExecutionEvent struct {
+	// 执行ID
+	ExecutionID string
+	// 事件类型
+	Type ExecutionEventType
+	// 产生事件的管道名称
+	Source string
+	// 场景或步骤名称
+	Name string
+	// 状态
+	Status TaskStatus
+	// 进度，0~1
+	Progress float64
+	// 结果数据
+	Result map[string]interface{}
+	// 错误信息
+	Error string
+	// 事件时间
+	Timestamp time.Time
+}
+
+// EventPublisher 执行事件发布者
+type EventPublisher interface {
+	// Publish 发布事件
+	Publish(event ExecutionEvent)
+}
+
+// EventBindable 可绑定到执行事件的管道，组合管道应同时绑定其子管道
+type EventBindable interface {
+	// BindEvents 绑定所属执行和事件发布者
+	BindEvents(executionID string, publisher EventPublisher)
+}
+
+// EventRelay 跨实例转发执行事件的共享发布订阅
+// 执行所在实例发布的事件广播给所有实例，其他实例上的观察者据此观察执行
+type EventRelay interface {
+	// Publish 向所有实例广播事件
+	Publish(ctx context.Context, event ExecutionEvent) error
+	// Subscribe 订阅执行广播的事件，订阅生效后返回；调用方结束观察时须调用返回的取消函数
+	Subscribe(ctx context.Context, executionID string) (<-chan ExecutionEvent, func(), error)
+}
+
+// executionTopic 单个执行的事件主题
+type executionTopic struct {
+	history     []ExecutionEvent
+	subscribers map[int]chan ExecutionEvent
+	nextID      int
+	closed      bool
+}
+
+// ExecutionEventBus 执行事件总线，按执行ID分发事件
+// 执行结束后保留一段时间的历史事件，观察者晚于结束订阅时仍能拿到汇总；
+// 设置了 EventRelay 时本实例执行的事件同时广播给其他实例
+type ExecutionEventBus struct {
+	mu          sync.Mutex
+	topics      map[string]*executionTopic
+	historySize int
+	retention   time.Duration
+	relay       EventRelay
+}
+
+// NewExecutionEventBus 创建执行事件总线
+func NewExecutionEventBus() *ExecutionEventBus {
+	return &ExecutionEventBus{
+		topics:      make(map[string]*executionTopic),
+		historySize: defaultEventHistorySize,
+		retention:   defaultEventRetention,
+	}
+}
+
+// SetRelay 设置跨实例转发事件的共享发布订阅，启动时调用
+func (b *ExecutionEventBus) SetRelay(relay EventRelay) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.relay = relay
+}
+
+// Relay 跨实例转发事件的共享发布订阅，未设置时为 nil
+func (b *ExecutionEventBus) Relay() EventRelay {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.relay
+}
+
+// Open 为执行创建事件主题，执行开始前调用；主题已结束时（如执行重试）重新创建
+func (b *ExecutionEventBus) Open(executionID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if topic, ok := b.topics[executionID]; ok && !topic.closed {
+		return
+	}
+	b.topics[executionID] = &executionTopic{
+		subscribers: make(map[int]chan ExecutionEvent),
+	}
+}
+
+// Publish 发布事件，执行不存在或已结束时丢弃
+// 订阅者缓冲区已满时丢弃其最早未读的事件，不阻塞管道执行，最新的事件（包括汇总）总能送达
+func (b *ExecutionEventBus) Publish(event ExecutionEvent) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	b.mu.Lock()
+	topic, ok := b.topics[event.ExecutionID]
+	if !ok || topic.closed {
+		b.mu.Unlock()
+		return
+	}
+
+	topic.history = append(topic.history, event)
+	if len(topic.history) > b.historySize {
+		topic.history = topic.history[len(topic.history)-b.historySize:]
+	}
+
+	for id, ch := range topic.subscribers {
+		if dropped, ok := deliver(ch, event); ok && dropped != nil {
+			logx.Errorf("执行 %s 的订阅者 %d 处理过慢, 丢弃 %s 事件", event.ExecutionID, id, dropped.Type)
+		}
+	}
+	relay := b.relay
+	b.mu.Unlock()
+
+	if relay != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), relayPublishTimeout)
+		defer cancel()
+		if err := relay.Publish(ctx, event); err != nil {
+			logx.Errorf("广播执行 %s 的 %s 事件失败: %v", event.ExecutionID, event.Type, err)
+		}
+	}
+}
+
+// deliver 向订阅者发送事件，缓冲区已满时丢弃最早未读的事件后重试，返回被丢弃的事件
+// 订阅者同时在读取，重试有限次数仍失败时放弃本次发送
+func deliver(ch chan ExecutionEvent, event ExecutionEvent) (*ExecutionEvent, bool) {
+	var dropped *ExecutionEvent
+	for attempt := 0; attempt < 3; attempt++ {
+		select {
+		case ch <- event:
+			return dropped, true
+		default:
+		}
+		select {
+		case old := <-ch:
+			dropped = &old
+		default:
+		}
+	}
+	return dropped, false
+}
+
+// Local 执行是否在本实例运行（事件主题已打开且未结束）
+func (b *ExecutionEventBus) Local(executionID string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	topic, ok := b.topics[executionID]
+	return ok && !topic.closed
+}
+
+// Subscribe 订阅执行事件，先回放历史事件，执行结束后 channel 关闭
+// 执行不存在时返回 false；调用方结束观察时须调用返回的取消函数
+func (b *ExecutionEventBus) Subscribe(executionID string) (<-chan ExecutionEvent, func(), bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	topic, ok := b.topics[executionID]
+	if !ok {
+		return nil, nil, false
+	}
+
+	ch := make(chan ExecutionEvent, len(topic.history)+subscriberBuffer)
+	for _, event := range topic.history {
+		ch <- event
+	}
+	if topic.closed {
+		close(ch)
+		return ch, func() {}, true
+	}
+
+	id := topic.nextID
+	topic.nextID++
+	topic.subscribers[id] = ch
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if sub, ok := topic.subscribers[id]; ok {
+				delete(topic.subscribers, id)
+				close(sub)
+			}
+		})
+	}
+	return ch, unsubscribe, true
+}
+
+// Close 结束执行的事件主题，关闭所有订阅者，历史事件保留一段时间后清除
+func (b *ExecutionEventBus) Close(executionID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	topic, ok := b.topics[executionID]
+	if !ok || topic.closed {
+		return
+	}
+	topic.closed = true
+	for id, ch := range topic.subscribers {
+		delete(topic.subscribers, id)
+		close(ch)
+	}
+
+	time.AfterFunc(b.retention, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if b.topics[executionID] == topic {
+			delete(b.topics, executionID)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+// recordRelay 记录广播事件的转发
+type recordRelay struct {
+	mu     sync.Mutex
+	events []ExecutionEvent
+}
+
+func (r *recordRelay) Publish(ctx context.Context, event ExecutionEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *recordRelay) Subscribe(ctx context.Context, executionID string) (<-chan ExecutionEvent, func(), error) {
+	ch := make(chan ExecutionEvent)
+	close(ch)
+	return ch, func() {}, nil
+}
+
+func TestExecutionEventBusSlowSubscriberReceivesSummary(t *testing.T) {
+	bus := NewExecutionEventBus()
+	bus.Open("exec-1")
+	events, unsubscribe, ok := bus.Subscribe("exec-1")
+	if !ok {
+		t.Fatal("Subscribe() ok = false")
+	}
+	defer unsubscribe()
+
+	// 订阅者不读取，进度事件超过缓冲区
+	const total = subscriberBuffer * 3
+	for i := 0; i < total; i++ {
+		bus.Publish(ExecutionEvent{ExecutionID: "exec-1", Type: EventProgress, Progress: float64(i) / total})
+	}
+	bus.Publish(ExecutionEvent{ExecutionID: "exec-1", Type: EventSummary, Status: TaskStatusCompleted})
+	bus.Close("exec-1")
+
+	var received []ExecutionEvent
+	for event := range events {
+		received = append(received, event)
+	}
+	if len(received) == 0 || len(received) > subscriberBuffer {
+		t.Fatalf("received %d events, want 1..%d", len(received), subscriberBuffer)
+	}
+	if last := received[len(received)-1]; last.Type != EventSummary || last.Status != TaskStatusCompleted {
+		t.Errorf("last event = %+v, want completed summary", last)
+	}
+	// 保留的是最新的进度事件
+	if first := received[0]; first.Progress < float64(total-subscriberBuffer)/total {
+		t.Errorf("first event progress = %v, want the latest events kept", first.Progress)
+	}
+}
+
+func TestExecutionEventBusRelay(t *testing.T) {
+	tests := []struct {
+		name string
+		// 发布前的操作
+		setup     func(bus *ExecutionEventBus)
+		wantRelay int
+	}{
+		{name: "执行中的事件广播", setup: func(bus *ExecutionEventBus) { bus.Open("exec-1") }, wantRelay: 1},
+		{name: "未打开的执行不广播", setup: func(bus *ExecutionEventBus) {}},
+		{
+			name: "已结束的执行不广播",
+			setup: func(bus *ExecutionEventBus) {
+				bus.Open("exec-1")
+				bus.Close("exec-1")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			relay := &recordRelay{}
+			bus := NewExecutionEventBus()
+			bus.SetRelay(relay)
+			tt.setup(bus)
+
+			bus.Publish(ExecutionEvent{ExecutionID: "exec-1", Type: EventStatus, Status: TaskStatusRunning})
+			if len(relay.events) != tt.wantRelay {
+				t.Fatalf("relay events = %d, want %d", len(relay.events), tt.wantRelay)
+			}
+			if tt.wantRelay > 0 && relay.events[0].Timestamp.IsZero() {
+				t.Error("广播的事件缺少时间")
+			}
+			if got := bus.Local("exec-1"); got != (tt.wantRelay > 0) {
+				t.Errorf("Local() = %v, want %v", got, tt.wantRelay > 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Cancelable 可取消的执行单元
+type Cancelable interface {
+	// Cancel 取消执行
+	Cancel(ctx context.Context) error
+}
+
+// ExecutionRegistry 进程内运行中的执行，按执行ID索引
+// 一次执行可能包含多个管道（如多个数据源的同步），取消时全部取消
+type ExecutionRegistry struct {
+	mu         sync.RWMutex
+	executions map[string][]Cancelable
+}
+
+// NewExecutionRegistry 创建执行注册表
+func NewExecutionRegistry() *ExecutionRegistry {
+	return &ExecutionRegistry{
+		executions: make(map[string][]Cancelable),
+	}
+}
+
+// Register 登记执行ID下的管道
+func (r *ExecutionRegistry) Register(executionID string, runner Cancelable) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.executions[executionID] = append(r.executions[executionID], runner)
+}
+
+// Unregister 执行结束后移除
+func (r *ExecutionRegistry) Unregister(executionID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.executions, executionID)
+}
+
+// Exists 执行是否仍在运行
+func (r *ExecutionRegistry) Exists(executionID string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.executions[executionID]
+	return ok
+}
+
+// Cancel 取消执行ID下的所有管道，执行不存在时返回 false
+func (r *ExecutionRegistry) Cancel(ctx context.Context, executionID string) (bool, error) {
+	r.mu.RLock()
+	runners, ok := r.executions[executionID]
+	runners = append([]Cancelable(nil), runners...)
+	r.mu.RUnlock()
+	if !ok {
+		return false, nil
+	}
+
+	var firstErr error
+	for i, runner := range runners {
+		if err := runner.Cancel(ctx); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("取消执行 %s 的第 %d 个管道失败: %w", executionID, i+1, err)
+		}
+	}
+	return true, firstErr
+}
@@ -13,6 +13,15 @@ const (
 
 	// TypeApiFox ApiFox同步管道类型
 	TypeApiFox PipelineType = "apifox"
+
+	// TypeWorkflow 由多个管道组成的DAG工作流类型
+	TypeWorkflow PipelineType = "workflow"
+
+	// TypeSync 数据源到数据目标的同步管道类型
+	TypeSync PipelineType = "sync"
+
+	// TypeTask 执行一个已配置任务的管道类型，用作工作流节点
+	TypeTask PipelineType = "task"
 )
 
 // PipelineConfig 管道配置
@@ -62,6 +71,11 @@ func (f *DefaultPipelineFactory) Create(config *PipelineConfig) (PipelineRunner,
 		return nil, errors.New("unsupported pipeline type: " + string(config.Type))
 	}
 
+	// 按登记的schema校验管道配置，配置错误时不创建管道
+	if err := ValidateSpec(config.Type, config.Config); err != nil {
+		return nil, err
+	}
+
 	// 调用创建函数
 	return creator(config)
 }
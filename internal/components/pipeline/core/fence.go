@@ -0,0 +1,12 @@
+package core
+
+import "context"
+
+// Fence 防护令牌
+// 持有分布式锁的执行在写入前校验，锁已被他人持有时拒绝写入
+type Fence interface {
+	// Token 单调递增的令牌，随写入的数据一起保存
+	Token() int64
+	// Validate 确认锁仍由当前执行持有
+	Validate(ctx context.Context) error
+}
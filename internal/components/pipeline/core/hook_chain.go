@@ -0,0 +1,120 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// HookErrorPolicy 钩子出错时的处理策略
+type HookErrorPolicy string
+
+const (
+	// HookPolicyContinue 记录日志后继续执行后续钩子和管道
+	HookPolicyContinue HookErrorPolicy = "continue"
+
+	// HookPolicyAbort 中断钩子链，并把错误返回给管道
+	HookPolicyAbort HookErrorPolicy = "abort"
+)
+
+// hookEntry 钩子链中的单个钩子及其错误策略
+type hookEntry struct {
+	hook   Hook
+	policy HookErrorPolicy
+}
+
+// HookChain 有序钩子链，按注册顺序依次调用
+type HookChain struct {
+	mu      sync.RWMutex
+	entries []hookEntry
+}
+
+// NewHookChain 创建钩子链
+func NewHookChain() *HookChain {
+	return &HookChain{
+		entries: make([]hookEntry, 0),
+	}
+}
+
+// Add 按顺序追加钩子，policy为空时使用 HookPolicyContinue
+func (c *HookChain) Add(hook Hook, policy HookErrorPolicy) {
+	if hook == nil {
+		return
+	}
+	if policy == "" {
+		policy = HookPolicyContinue
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = append(c.entries, hookEntry{hook: hook, policy: policy})
+}
+
+// Len 返回钩子数量
+func (c *HookChain) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// snapshot 复制当前钩子列表，避免调用钩子时持有锁
+func (c *HookChain) snapshot() []hookEntry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entries := make([]hookEntry, len(c.entries))
+	copy(entries, c.entries)
+	return entries
+}
+
+// run 依次执行钩子，遇到 abort 策略的钩子出错时立即返回
+func (c *HookChain) run(event, taskID string, call func(h Hook) error) error {
+	for i, entry := range c.snapshot() {
+		err := call(entry.hook)
+		if err == nil {
+			continue
+		}
+
+		if entry.policy == HookPolicyAbort {
+			return fmt.Errorf("钩子[%d] %s 执行失败: %w", i, event, err)
+		}
+		logx.Errorf("钩子[%d] %s 执行失败, 任务: %s, 错误: %v", i, event, taskID, err)
+	}
+	return nil
+}
+
+// OnStart 任务开始时的钩子
+func (c *HookChain) OnStart(ctx context.Context, taskID string, spec map[string]interface{}) error {
+	return c.run("OnStart", taskID, func(h Hook) error {
+		return h.OnStart(ctx, taskID, spec)
+	})
+}
+
+// OnSuccess 任务成功时的钩子
+func (c *HookChain) OnSuccess(ctx context.Context, taskID string, result map[string]interface{}) error {
+	return c.run("OnSuccess", taskID, func(h Hook) error {
+		return h.OnSuccess(ctx, taskID, result)
+	})
+}
+
+// OnFailure 任务失败时的钩子
+func (c *HookChain) OnFailure(ctx context.Context, taskID string, err error) error {
+	return c.run("OnFailure", taskID, func(h Hook) error {
+		return h.OnFailure(ctx, taskID, err)
+	})
+}
+
+// OnCancel 任务取消时的钩子
+func (c *HookChain) OnCancel(ctx context.Context, taskID string) error {
+	return c.run("OnCancel", taskID, func(h Hook) error {
+		return h.OnCancel(ctx, taskID)
+	})
+}
+
+// OnComplete 任务完成时的钩子（无论成功失败）
+func (c *HookChain) OnComplete(ctx context.Context, taskID string, result map[string]interface{}) error {
+	return c.run("OnComplete", taskID, func(h Hook) error {
+		return h.OnComplete(ctx, taskID, result)
+	})
+}
@@ -0,0 +1,314 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordHook 记录调用顺序的钩子，failOn 中的事件返回错误
+type recordHook struct {
+	name   string
+	calls  *[]string
+	mu     *sync.Mutex
+	failOn map[string]bool
+}
+
+func (h *recordHook) record(event, taskID string) error {
+	h.mu.Lock()
+	*h.calls = append(*h.calls, fmt.Sprintf("%s.%s(%s)", h.name, event, taskID))
+	h.mu.Unlock()
+	if h.failOn[event] {
+		return fmt.Errorf("%s %s 失败", h.name, event)
+	}
+	return nil
+}
+
+func (h *recordHook) OnStart(ctx context.Context, taskID string, spec map[string]interface{}) error {
+	return h.record("OnStart", taskID)
+}
+
+func (h *recordHook) OnSuccess(ctx context.Context, taskID string, result map[string]interface{}) error {
+	return h.record("OnSuccess", taskID)
+}
+
+func (h *recordHook) OnFailure(ctx context.Context, taskID string, err error) error {
+	return h.record("OnFailure", taskID)
+}
+
+func (h *recordHook) OnCancel(ctx context.Context, taskID string) error {
+	return h.record("OnCancel", taskID)
+}
+
+func (h *recordHook) OnComplete(ctx context.Context, taskID string, result map[string]interface{}) error {
+	return h.record("OnComplete", taskID)
+}
+
+// hookSpec 测试中注册的钩子
+type hookSpec struct {
+	name   string
+	policy HookErrorPolicy
+	failOn []string
+}
+
+// newRecordHooks 按 specs 创建共享调用记录的钩子
+func newRecordHooks(specs []hookSpec) ([]*recordHook, *[]string) {
+	calls := &[]string{}
+	mu := &sync.Mutex{}
+	hooks := make([]*recordHook, len(specs))
+	for i, spec := range specs {
+		failOn := make(map[string]bool, len(spec.failOn))
+		for _, event := range spec.failOn {
+			failOn[event] = true
+		}
+		hooks[i] = &recordHook{name: spec.name, calls: calls, mu: mu, failOn: failOn}
+	}
+	return hooks, calls
+}
+
+func TestHookChain(t *testing.T) {
+	tests := []struct {
+		name      string
+		hooks     []hookSpec
+		wantCalls []string
+		wantErr   string
+	}{
+		{
+			name:      "空钩子链",
+			wantCalls: []string{},
+		},
+		{
+			name:      "按注册顺序调用",
+			hooks:     []hookSpec{{name: "a"}, {name: "b"}, {name: "c"}},
+			wantCalls: []string{"a.OnStart(t)", "b.OnStart(t)", "c.OnStart(t)"},
+		},
+		{
+			name:      "continue 策略出错后继续",
+			hooks:     []hookSpec{{name: "a", policy: HookPolicyContinue, failOn: []string{"OnStart"}}, {name: "b"}},
+			wantCalls: []string{"a.OnStart(t)", "b.OnStart(t)"},
+		},
+		{
+			name:      "未指定策略时继续",
+			hooks:     []hookSpec{{name: "a", failOn: []string{"OnStart"}}, {name: "b"}},
+			wantCalls: []string{"a.OnStart(t)", "b.OnStart(t)"},
+		},
+		{
+			name:      "abort 策略出错后中断",
+			hooks:     []hookSpec{{name: "a"}, {name: "b", policy: HookPolicyAbort, failOn: []string{"OnStart"}}, {name: "c"}},
+			wantCalls: []string{"a.OnStart(t)", "b.OnStart(t)"},
+			wantErr:   "钩子[1] OnStart 执行失败: b OnStart 失败",
+		},
+		{
+			name:      "abort 策略未出错时继续",
+			hooks:     []hookSpec{{name: "a", policy: HookPolicyAbort}, {name: "b"}},
+			wantCalls: []string{"a.OnStart(t)", "b.OnStart(t)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hooks, calls := newRecordHooks(tt.hooks)
+			chain := NewHookChain()
+			for i, hook := range hooks {
+				chain.Add(hook, tt.hooks[i].policy)
+			}
+			if chain.Len() != len(tt.hooks) {
+				t.Errorf("Len() = %d, want %d", chain.Len(), len(tt.hooks))
+			}
+
+			err := chain.OnStart(context.Background(), "t", nil)
+			if got := fmt.Sprint(err); (tt.wantErr == "" && err != nil) || (tt.wantErr != "" && got != tt.wantErr) {
+				t.Errorf("OnStart() error = %v, want %q", err, tt.wantErr)
+			}
+			if fmt.Sprint(*calls) != fmt.Sprint(tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", *calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestHookChainIgnoresNil(t *testing.T) {
+	chain := NewHookChain()
+	chain.Add(nil, HookPolicyAbort)
+	if chain.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", chain.Len())
+	}
+}
+
+func TestHookChainWrapsAbortError(t *testing.T) {
+	hookErr := errors.New("下游不可用")
+	chain := NewHookChain()
+	chain.Add(failingHook{err: hookErr}, HookPolicyAbort)
+
+	err := chain.OnFailure(context.Background(), "t", errors.New("执行失败"))
+	if !errors.Is(err, hookErr) {
+		t.Errorf("OnFailure() error = %v, want wrapping %v", err, hookErr)
+	}
+}
+
+// failingHook 所有事件都返回 err 的钩子
+type failingHook struct {
+	err error
+}
+
+func (h failingHook) OnStart(ctx context.Context, taskID string, spec map[string]interface{}) error {
+	return h.err
+}
+
+func (h failingHook) OnSuccess(ctx context.Context, taskID string, result map[string]interface{}) error {
+	return h.err
+}
+
+func (h failingHook) OnFailure(ctx context.Context, taskID string, err error) error {
+	return h.err
+}
+
+func (h failingHook) OnCancel(ctx context.Context, taskID string) error {
+	return h.err
+}
+
+func (h failingHook) OnComplete(ctx context.Context, taskID string, result map[string]interface{}) error {
+	return h.err
+}
+
+func TestBasePipelineLifecycleHooks(t *testing.T) {
+	execErr := errors.New("请求失败")
+
+	tests := []struct {
+		name string
+		// 绑定的执行ID，为空时钩子使用管道名称
+		executionID string
+		hooks       []hookSpec
+		run         func(p *BasePipeline) error
+		wantStatus  TaskStatus
+		wantCalls   []string
+		wantErr     bool
+	}{
+		{
+			name:        "成功",
+			executionID: "exec-1",
+			hooks:       []hookSpec{{name: "log"}, {name: "metrics"}},
+			run: func(p *BasePipeline) error {
+				if err := p.Begin(context.Background(), nil); err != nil {
+					return err
+				}
+				return p.Finish(context.Background(), map[string]interface{}{"ok": true}, nil)
+			},
+			wantStatus: TaskStatusCompleted,
+			wantCalls: []string{
+				"log.OnStart(exec-1)", "metrics.OnStart(exec-1)",
+				"log.OnSuccess(exec-1)", "metrics.OnSuccess(exec-1)",
+				"log.OnComplete(exec-1)", "metrics.OnComplete(exec-1)",
+			},
+		},
+		{
+			name:  "失败",
+			hooks: []hookSpec{{name: "log"}},
+			run: func(p *BasePipeline) error {
+				if err := p.Begin(context.Background(), nil); err != nil {
+					return err
+				}
+				p.Finish(context.Background(), nil, execErr)
+				return nil
+			},
+			wantStatus: TaskStatusFailed,
+			wantCalls:  []string{"log.OnStart(pipe)", "log.OnFailure(pipe)", "log.OnComplete(pipe)"},
+		},
+		{
+			name:  "取消",
+			hooks: []hookSpec{{name: "log"}},
+			run: func(p *BasePipeline) error {
+				if err := p.Begin(context.Background(), nil); err != nil {
+					return err
+				}
+				return p.Cancel(context.Background())
+			},
+			wantStatus: TaskStatusCanceled,
+			wantCalls:  []string{"log.OnStart(pipe)", "log.OnCancel(pipe)", "log.OnComplete(pipe)"},
+		},
+		{
+			name:  "开始钩子 abort 时管道失败",
+			hooks: []hookSpec{{name: "guard", policy: HookPolicyAbort, failOn: []string{"OnStart"}}, {name: "log"}},
+			run: func(p *BasePipeline) error {
+				return p.Begin(context.Background(), nil)
+			},
+			wantStatus: TaskStatusFailed,
+			wantCalls:  []string{"guard.OnStart(pipe)", "guard.OnFailure(pipe)", "log.OnFailure(pipe)", "guard.OnComplete(pipe)", "log.OnComplete(pipe)"},
+			wantErr:    true,
+		},
+		{
+			name:  "开始钩子 continue 时继续执行",
+			hooks: []hookSpec{{name: "log", failOn: []string{"OnStart"}}},
+			run: func(p *BasePipeline) error {
+				if err := p.Begin(context.Background(), nil); err != nil {
+					return err
+				}
+				return p.Finish(context.Background(), nil, nil)
+			},
+			wantStatus: TaskStatusCompleted,
+			wantCalls:  []string{"log.OnStart(pipe)", "log.OnSuccess(pipe)", "log.OnComplete(pipe)"},
+		},
+		{
+			name:  "成功钩子 abort 时记录错误但保持完成",
+			hooks: []hookSpec{{name: "report", policy: HookPolicyAbort, failOn: []string{"OnSuccess"}}},
+			run: func(p *BasePipeline) error {
+				if err := p.Begin(context.Background(), nil); err != nil {
+					return err
+				}
+				return p.Finish(context.Background(), nil, nil)
+			},
+			wantStatus: TaskStatusCompleted,
+			wantCalls:  []string{"report.OnStart(pipe)", "report.OnSuccess(pipe)", "report.OnComplete(pipe)"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hooks, calls := newRecordHooks(tt.hooks)
+			p := NewBasePipeline("pipe", "")
+			for i, hook := range hooks {
+				p.AddHookWithPolicy(hook, tt.hooks[i].policy)
+			}
+			if tt.executionID != "" {
+				p.BindEvents(tt.executionID, nil)
+			}
+
+			err := tt.run(p)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := p.GetStatus(context.Background()); got != tt.wantStatus {
+				t.Errorf("GetStatus() = %s, want %s", got, tt.wantStatus)
+			}
+			if fmt.Sprint(*calls) != fmt.Sprint(tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", strings.Join(*calls, " "), strings.Join(tt.wantCalls, " "))
+			}
+		})
+	}
+}
+
+func TestBasePipelineCancelStopsExecution(t *testing.T) {
+	p := NewBasePipeline("pipe", "")
+	if err := p.Begin(context.Background(), nil); err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	execCtx := p.ExecutionContext()
+
+	if err := p.Cancel(context.Background()); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if execCtx.Err() == nil {
+		t.Error("Cancel() 后执行上下文未取消")
+	}
+	// 重复取消不报错，取消后不能再完成
+	if err := p.Cancel(context.Background()); err != nil {
+		t.Errorf("second Cancel() error = %v", err)
+	}
+	if err := p.Finish(context.Background(), nil, nil); err == nil {
+		t.Error("Finish() after Cancel error = nil, want StateTransitionError")
+	}
+}
@@ -3,8 +3,13 @@ package hooks
 import (
 	"context"
 	"log"
+
+	"Storage/internal/components/pipeline/core"
 )
 
+// 确保实现了 core.Hook 接口
+var _ core.Hook = (*LoggingHook)(nil)
+
 // LoggingHook 日志钩子实现
 type LoggingHook struct {
 	// 日志前缀
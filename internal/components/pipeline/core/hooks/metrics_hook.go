@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"sync"
 	"time"
-	
-	"Storage/internal/logic/workflows/core/metrics/reporter"
+
+	"Storage/internal/components/pipeline/core"
+	"Storage/internal/components/pipeline/core/metrics/reporter"
 )
 
+// 确保实现了 core.Hook 接口
+var _ core.Hook = (*MetricsHook)(nil)
+
 // MetricsHook 指标收集钩子实现
 type MetricsHook struct {
 	// 指标数据锁
@@ -6,7 +6,7 @@ import (
 	"sync"
 	"time"
 	
-	"Storage/internal/logic/workflows/core/metrics/reporter"
+	"Storage/internal/components/pipeline/core/metrics/reporter"
 )
 
 // MetricsCollector 指标收集器
@@ -1,7 +1,7 @@
 package sender
 
 import (
-	"Storage/internal/logic/workflows/notification"
+	"Storage/internal/components/pipeline/core/notification"
 	"context"
 	"fmt"
 	"log"
@@ -1,7 +1,8 @@
 package sender
 
 import (
-	"Storage/internal/logic/workflows/notification"
+	"Storage/internal/components/pipeline/core/notification"
+	"Storage/internal/components/retry"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -171,35 +172,18 @@ func (s *WebhookSender) Send(ctx context.Context, message *notification.Message)
 	}
 
 	// 发送请求，带重试
-	var lastErr error
-	for i := 0; i <= s.config.RetryCount; i++ {
-		err := s.sendRequest(ctx, jsonPayload)
-		if err == nil {
-			return nil
-		}
-
-		lastErr = err
-
-		// 如果是最后一次尝试，不要等待
-		if i == s.config.RetryCount {
-			break
-		}
-
-		// 等待重试
-		retryInterval := time.Duration(s.config.RetryIntervalSeconds) * time.Second
-		if retryInterval <= 0 {
-			retryInterval = time.Second
-		}
-
-		select {
-		case <-time.After(retryInterval):
-			// 继续下一次重试
-		case <-ctx.Done():
-			return &notification.NotificationError{
-				Message: "发送Webhook请求被取消",
-				Code:    "CONTEXT_CANCELED",
-				Err:     ctx.Err(),
-			}
+	policy := retry.Constant(s.config.RetryCount, time.Duration(s.config.RetryIntervalSeconds)*time.Second)
+	err = retry.Do(ctx, policy, func(ctx context.Context, attempt int) error {
+		return s.sendRequest(ctx, jsonPayload)
+	})
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil {
+		return &notification.NotificationError{
+			Message: "发送Webhook请求被取消",
+			Code:    "CONTEXT_CANCELED",
+			Err:     ctx.Err(),
 		}
 	}
 
@@ -207,7 +191,7 @@ func (s *WebhookSender) Send(ctx context.Context, message *notification.Message)
 	return &notification.NotificationError{
 		Message: fmt.Sprintf("发送Webhook请求失败，已重试%d次", s.config.RetryCount),
 		Code:    "WEBHOOK_FAILED",
-		Err:     lastErr,
+		Err:     err,
 	}
 }
 
@@ -216,11 +200,11 @@ func (s *WebhookSender) sendRequest(ctx context.Context, jsonPayload []byte) err
 	// 创建请求
 	req, err := http.NewRequestWithContext(ctx, s.config.Method, s.config.URL, bytes.NewBuffer(jsonPayload))
 	if err != nil {
-		return &notification.NotificationError{
+		return retry.Permanent(&notification.NotificationError{
 			Message: "创建Webhook请求失败",
 			Code:    "REQUEST_ERROR",
 			Err:     err,
-		}
+		})
 	}
 
 	// 设置请求头
@@ -254,6 +238,7 @@ func (s *WebhookSender) sendRequest(ctx context.Context, jsonPayload []byte) err
 		return &notification.NotificationError{
 			Message: fmt.Sprintf("Webhook请求返回非成功状态码: %d, 响应: %s", resp.StatusCode, string(body)),
 			Code:    "HTTP_ERROR",
+			Err:     &retry.StatusError{StatusCode: resp.StatusCode},
 		}
 	}
 
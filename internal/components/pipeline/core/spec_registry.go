@@ -0,0 +1,107 @@
+package core
+
+import (
+	"sync"
+)
+
+// SpecRegistry 按管道类型登记spec schema
+type SpecRegistry struct {
+	mu      sync.RWMutex
+	schemas map[PipelineType]*SpecSchema
+}
+
+// NewSpecRegistry 创建spec schema注册表
+func NewSpecRegistry() *SpecRegistry {
+	return &SpecRegistry{
+		schemas: make(map[PipelineType]*SpecSchema),
+	}
+}
+
+// defaultSpecRegistry 各管道包在初始化时登记schema的全局注册表
+var defaultSpecRegistry = NewSpecRegistry()
+
+// Register 登记管道类型的spec schema，重复登记时覆盖
+func (r *SpecRegistry) Register(pipelineType PipelineType, schema *SpecSchema) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schemas[pipelineType] = schema
+}
+
+// Schema 获取管道类型的spec schema
+func (r *SpecRegistry) Schema(pipelineType PipelineType) (*SpecSchema, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	schema, ok := r.schemas[pipelineType]
+	return schema, ok
+}
+
+// Validate 校验spec，未登记schema的类型不做校验
+// 校验失败返回 *SpecValidationError
+func (r *SpecRegistry) Validate(pipelineType PipelineType, spec map[string]interface{}) error {
+	schema, ok := r.Schema(pipelineType)
+	if !ok {
+		return nil
+	}
+	if errs := schema.Validate(spec); len(errs) > 0 {
+		return &SpecValidationError{PipelineType: pipelineType, Fields: errs}
+	}
+	return nil
+}
+
+// ValidatePartial 只校验已出现字段的类型和取值，不检查顶层必填字段
+func (r *SpecRegistry) ValidatePartial(pipelineType PipelineType, spec map[string]interface{}) error {
+	schema, ok := r.Schema(pipelineType)
+	if !ok {
+		return nil
+	}
+	if errs := schema.ValidatePartial(spec); len(errs) > 0 {
+		return &SpecValidationError{PipelineType: pipelineType, Fields: errs}
+	}
+	return nil
+}
+
+// Decode 校验spec后严格解码到 out，未登记schema的类型只做严格解码
+func (r *SpecRegistry) Decode(pipelineType PipelineType, spec map[string]interface{}, out interface{}) error {
+	schema, ok := r.Schema(pipelineType)
+	if !ok {
+		value, err := normalizeSpec(spec)
+		if err != nil {
+			return &SpecValidationError{PipelineType: pipelineType, Fields: []FieldError{{Field: "$", Message: err.Error()}}}
+		}
+		return withPipelineType(decodeStrict(value, out), pipelineType)
+	}
+	return withPipelineType(schema.Decode(spec, out), pipelineType)
+}
+
+// withPipelineType 为校验错误补充管道类型
+func withPipelineType(err error, pipelineType PipelineType) error {
+	if specErr, ok := err.(*SpecValidationError); ok {
+		specErr.PipelineType = pipelineType
+	}
+	return err
+}
+
+// RegisterSpecSchema 在全局注册表登记管道类型的 JSON Schema，schema 无效时 panic
+func RegisterSpecSchema(pipelineType PipelineType, schemaJSON string) {
+	defaultSpecRegistry.Register(pipelineType, MustParseSpecSchema(schemaJSON))
+}
+
+// LookupSpecSchema 获取全局注册表中管道类型的spec schema
+func LookupSpecSchema(pipelineType PipelineType) (*SpecSchema, bool) {
+	return defaultSpecRegistry.Schema(pipelineType)
+}
+
+// ValidateSpec 使用全局注册表校验spec
+func ValidateSpec(pipelineType PipelineType, spec map[string]interface{}) error {
+	return defaultSpecRegistry.Validate(pipelineType, spec)
+}
+
+// ValidateSpecPartial 使用全局注册表校验部分spec
+func ValidateSpecPartial(pipelineType PipelineType, spec map[string]interface{}) error {
+	return defaultSpecRegistry.ValidatePartial(pipelineType, spec)
+}
+
+// DecodeSpec 使用全局注册表校验并严格解码spec
+func DecodeSpec(pipelineType PipelineType, spec map[string]interface{}, out interface{}) error {
+	return defaultSpecRegistry.Decode(pipelineType, spec, out)
+}
@@ -0,0 +1,455 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SpecSchema 管道spec的 JSON Schema
+// 支持的关键字: type, properties, required, additionalProperties, items, enum, default,
+// minimum, maximum, minLength, maxLength, pattern, minItems, maxItems
+type SpecSchema struct {
+	Type                 schemaTypes            `json:"type,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Properties           map[string]*SpecSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *additionalProperties  `json:"additionalProperties,omitempty"`
+	Items                *SpecSchema            `json:"items,omitempty"`
+	Enum                 []interface{}          `json:"enum,omitempty"`
+	Default              interface{}            `json:"default,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	MinLength            *int                   `json:"minLength,omitempty"`
+	MaxLength            *int                   `json:"maxLength,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	MinItems             *int                   `json:"minItems,omitempty"`
+	MaxItems             *int                   `json:"maxItems,omitempty"`
+
+	// 编译后的 pattern
+	pattern *regexp.Regexp
+}
+
+// schemaTypes type 关键字，兼容字符串和数组两种写法
+type schemaTypes []string
+
+// UnmarshalJSON 解析 "string" 或 ["string", "null"]
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	var multi []string
+	if err := json.Unmarshal(data, &multi); err != nil {
+		return fmt.Errorf("type 必须是字符串或字符串数组")
+	}
+	*t = multi
+	return nil
+}
+
+// additionalProperties 关键字，兼容布尔值和子 schema 两种写法
+type additionalProperties struct {
+	Allowed bool
+	Schema  *SpecSchema
+}
+
+// UnmarshalJSON 解析 false / true / {...}
+func (a *additionalProperties) UnmarshalJSON(data []byte) error {
+	var allowed bool
+	if err := json.Unmarshal(data, &allowed); err == nil {
+		a.Allowed = allowed
+		return nil
+	}
+	var schema SpecSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return fmt.Errorf("additionalProperties 必须是布尔值或 schema: %w", err)
+	}
+	a.Allowed = true
+	a.Schema = &schema
+	return nil
+}
+
+// FieldError 字段级校验错误
+type FieldError struct {
+	// 字段路径，如 dependencies[0].name
+	Field string `json:"field"`
+	// 错误描述
+	Message string `json:"message"`
+}
+
+// SpecValidationError spec校验失败，包含所有字段错误
+type SpecValidationError struct {
+	PipelineType PipelineType
+	Fields       []FieldError
+}
+
+// Error 实现 error 接口
+func (e *SpecValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		parts = append(parts, fmt.Sprintf("%s: %s", field.Field, field.Message))
+	}
+	if e.PipelineType == "" {
+		return fmt.Sprintf("spec校验失败: %s", strings.Join(parts, "; "))
+	}
+	return fmt.Sprintf("%s 管道spec校验失败: %s", e.PipelineType, strings.Join(parts, "; "))
+}
+
+// ParseSpecSchema 解析 JSON Schema 文本
+func ParseSpecSchema(data []byte) (*SpecSchema, error) {
+	var schema SpecSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("解析spec schema失败: %w", err)
+	}
+	if err := schema.compile("$"); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// MustParseSpecSchema 解析 JSON Schema 文本，失败时 panic，用于包初始化
+func MustParseSpecSchema(text string) *SpecSchema {
+	schema, err := ParseSpecSchema([]byte(text))
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+// compile 检查类型名并编译 pattern
+func (s *SpecSchema) compile(path string) error {
+	for _, t := range s.Type {
+		switch t {
+		case "object", "array", "string", "number", "integer", "boolean", "null":
+		default:
+			return fmt.Errorf("spec schema %s: 不支持的类型 %s", path, t)
+		}
+	}
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("spec schema %s: pattern 无效: %w", path, err)
+		}
+		s.pattern = re
+	}
+	for name, prop := range s.Properties {
+		if err := prop.compile(joinField(path, name)); err != nil {
+			return err
+		}
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		if err := s.AdditionalProperties.Schema.compile(path + ".*"); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		if err := s.Items.compile(path + "[]"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate 校验spec，返回所有字段错误
+func (s *SpecSchema) Validate(spec map[string]interface{}) []FieldError {
+	value, err := normalizeSpec(spec)
+	if err != nil {
+		return []FieldError{{Field: "$", Message: err.Error()}}
+	}
+	var errs []FieldError
+	s.validate("$", value, true, &errs)
+	return errs
+}
+
+// ValidatePartial 只校验已出现字段，不检查顶层必填字段
+// 用于运行时才补全的spec，如场景中的步骤spec
+func (s *SpecSchema) ValidatePartial(spec map[string]interface{}) []FieldError {
+	value, err := normalizeSpec(spec)
+	if err != nil {
+		return []FieldError{{Field: "$", Message: err.Error()}}
+	}
+	var errs []FieldError
+	s.validate("$", value, false, &errs)
+	return errs
+}
+
+// Decode 填充默认值并校验spec，然后严格解码到 out
+// 声明了 properties 的对象只接受已声明的字段，除非显式配置了 additionalProperties；
+// 顶层显式允许额外字段时，未在 properties 中声明的字段不参与解码
+func (s *SpecSchema) Decode(spec map[string]interface{}, out interface{}) error {
+	value, err := normalizeSpec(spec)
+	if err != nil {
+		return &SpecValidationError{Fields: []FieldError{{Field: "$", Message: err.Error()}}}
+	}
+	value = s.applyDefaults(value)
+
+	var errs []FieldError
+	s.validate("$", value, true, &errs)
+	s.rejectUndeclared("$", value, &errs)
+	if len(errs) > 0 {
+		return &SpecValidationError{Fields: errs}
+	}
+
+	if obj, ok := value.(map[string]interface{}); ok && s.explicitlyAllowsAdditional() && len(s.Properties) > 0 {
+		declared := make(map[string]interface{}, len(s.Properties))
+		for name := range s.Properties {
+			if v, ok := obj[name]; ok {
+				declared[name] = v
+			}
+		}
+		value = declared
+	}
+	return decodeStrict(value, out)
+}
+
+// validate 递归校验，requireFields 为 false 时跳过当前层的 required
+func (s *SpecSchema) validate(path string, value interface{}, requireFields bool, errs *[]FieldError) {
+	if len(s.Type) > 0 && !s.matchesType(value) {
+		*errs = append(*errs, FieldError{
+			Field:   path,
+			Message: fmt.Sprintf("类型应为 %s, 实际为 %s", strings.Join(s.Type, "|"), jsonTypeOf(value)),
+		})
+		return
+	}
+
+	if len(s.Enum) > 0 && !containsValue(s.Enum, value) {
+		*errs = append(*errs, FieldError{Field: path, Message: fmt.Sprintf("取值应为 %v 之一", s.Enum)})
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		s.validateObject(path, v, requireFields, errs)
+	case []interface{}:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			*errs = append(*errs, FieldError{Field: path, Message: fmt.Sprintf("元素个数不能少于 %d", *s.MinItems)})
+		}
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			*errs = append(*errs, FieldError{Field: path, Message: fmt.Sprintf("元素个数不能多于 %d", *s.MaxItems)})
+		}
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, true, errs)
+			}
+		}
+	case string:
+		length := len([]rune(v))
+		if s.MinLength != nil && length < *s.MinLength {
+			*errs = append(*errs, FieldError{Field: path, Message: fmt.Sprintf("长度不能小于 %d", *s.MinLength)})
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			*errs = append(*errs, FieldError{Field: path, Message: fmt.Sprintf("长度不能大于 %d", *s.MaxLength)})
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			*errs = append(*errs, FieldError{Field: path, Message: fmt.Sprintf("不匹配模式 %s", s.Pattern)})
+		}
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			*errs = append(*errs, FieldError{Field: path, Message: fmt.Sprintf("不能小于 %v", *s.Minimum)})
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			*errs = append(*errs, FieldError{Field: path, Message: fmt.Sprintf("不能大于 %v", *s.Maximum)})
+		}
+	}
+}
+
+// validateObject 校验对象的必填字段、已声明字段和额外字段
+func (s *SpecSchema) validateObject(path string, obj map[string]interface{}, requireFields bool, errs *[]FieldError) {
+	if requireFields {
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				*errs = append(*errs, FieldError{Field: joinField(path, name), Message: "缺少必填字段"})
+			}
+		}
+	}
+
+	// 按字段名排序，保证错误顺序稳定
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fieldPath := joinField(path, name)
+		if prop, ok := s.Properties[name]; ok {
+			prop.validate(fieldPath, obj[name], true, errs)
+			continue
+		}
+		if s.AdditionalProperties == nil {
+			continue
+		}
+		if !s.AdditionalProperties.Allowed {
+			*errs = append(*errs, FieldError{Field: fieldPath, Message: "不允许的字段"})
+			continue
+		}
+		if s.AdditionalProperties.Schema != nil {
+			s.AdditionalProperties.Schema.validate(fieldPath, obj[name], true, errs)
+		}
+	}
+}
+
+// applyDefaults 为缺失的字段填充 default，返回新值
+func (s *SpecSchema) applyDefaults(value interface{}) interface{} {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	for name, prop := range s.Properties {
+		current, exists := obj[name]
+		if !exists {
+			if prop.Default == nil {
+				continue
+			}
+			current = cloneValue(prop.Default)
+		}
+		obj[name] = prop.applyDefaults(current)
+	}
+	return obj
+}
+
+// explicitlyAllowsAdditional 是否显式允许未声明的字段
+func (s *SpecSchema) explicitlyAllowsAdditional() bool {
+	return s.AdditionalProperties != nil && s.AdditionalProperties.Allowed
+}
+
+// rejectUndeclared 解码时检查未声明的字段：声明了 properties 且未配置 additionalProperties 的对象不接受其他字段
+// additionalProperties 为 false 的对象已在 validate 中检查
+func (s *SpecSchema) rejectUndeclared(path string, value interface{}, errs *[]FieldError) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			fieldPath := joinField(path, name)
+			if prop, ok := s.Properties[name]; ok {
+				prop.rejectUndeclared(fieldPath, v[name], errs)
+				continue
+			}
+			switch {
+			case s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil:
+				s.AdditionalProperties.Schema.rejectUndeclared(fieldPath, v[name], errs)
+			case s.AdditionalProperties == nil && len(s.Properties) > 0:
+				*errs = append(*errs, FieldError{Field: fieldPath, Message: "未声明的字段"})
+			}
+		}
+	case []interface{}:
+		if s.Items == nil {
+			return
+		}
+		for i, item := range v {
+			s.Items.rejectUndeclared(fmt.Sprintf("%s[%d]", path, i), item, errs)
+		}
+	}
+}
+
+// matchesType 判断值是否符合 type 关键字
+func (s *SpecSchema) matchesType(value interface{}) bool {
+	actual := jsonTypeOf(value)
+	for _, t := range s.Type {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeSpec 经 JSON 编解码把spec中的Go类型统一为 JSON 基础类型
+func normalizeSpec(spec map[string]interface{}) (interface{}, error) {
+	if spec == nil {
+		return map[string]interface{}{}, nil
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("spec无法序列化为JSON: %w", err)
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("spec无法解析为JSON: %w", err)
+	}
+	return value, nil
+}
+
+// cloneValue 深拷贝 JSON 值，避免默认值被修改
+func cloneValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		cloned := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			cloned[k] = cloneValue(item)
+		}
+		return cloned
+	case []interface{}:
+		cloned := make([]interface{}, len(v))
+		for i, item := range v {
+			cloned[i] = cloneValue(item)
+		}
+		return cloned
+	default:
+		return v
+	}
+}
+
+// decodeStrict 严格解码，出现结构体未声明的字段时报错
+func decodeStrict(value interface{}, out interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("序列化spec失败: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(out); err != nil {
+		return &SpecValidationError{Fields: []FieldError{{Field: "$", Message: fmt.Sprintf("解码失败: %v", err)}}}
+	}
+	return nil
+}
+
+// jsonTypeOf 获取 JSON 值的类型名，整数值返回 integer
+func jsonTypeOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return "integer"
+		}
+		return "number"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// containsValue 判断值是否在枚举中
+func containsValue(enum []interface{}, value interface{}) bool {
+	for _, candidate := range enum {
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// joinField 拼接字段路径
+func joinField(path, name string) string {
+	if path == "$" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,377 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// testSpecSchema 覆盖各关键字的测试 schema
+const testSpecSchema = `{
+  "type": "object",
+  "required": ["name", "method"],
+  "properties": {
+    "name": {"type": "string", "minLength": 1, "maxLength": 8},
+    "method": {"enum": ["GET", "POST"]},
+    "code": {"type": "string", "pattern": "^[a-z]+$"},
+    "count": {"type": "integer", "minimum": 1, "maximum": 10},
+    "ratio": {"type": "number"},
+    "enabled": {"type": "boolean", "default": true},
+    "nullable": {"type": ["string", "null"]},
+    "tags": {"type": "array", "minItems": 1, "maxItems": 2, "items": {"type": "string"}},
+    "headers": {"type": "object", "additionalProperties": {"type": "string"}},
+    "options": {
+      "type": "object",
+      "default": {},
+      "properties": {
+        "retries": {"type": "integer", "default": 3},
+        "mode": {"enum": ["fast", "slow"]}
+      }
+    },
+    "steps": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": ["id"],
+        "additionalProperties": false,
+        "properties": {
+          "id": {"type": "string"},
+          "deps": {"type": "array", "items": {"type": "string"}}
+        }
+      }
+    },
+    "extra": {"type": "object"}
+  }
+}`
+
+type testSpecOptions struct {
+	Retries int    `json:"retries"`
+	Mode    string `json:"mode,omitempty"`
+}
+
+type testSpecStep struct {
+	ID   string   `json:"id"`
+	Deps []string `json:"deps,omitempty"`
+}
+
+type testSpec struct {
+	Name     string                 `json:"name"`
+	Method   string                 `json:"method"`
+	Code     string                 `json:"code,omitempty"`
+	Count    int                    `json:"count,omitempty"`
+	Ratio    float64                `json:"ratio,omitempty"`
+	Enabled  bool                   `json:"enabled"`
+	Nullable *string                `json:"nullable,omitempty"`
+	Tags     []string               `json:"tags,omitempty"`
+	Headers  map[string]string      `json:"headers,omitempty"`
+	Options  testSpecOptions        `json:"options"`
+	Steps    []testSpecStep         `json:"steps,omitempty"`
+	Extra    map[string]interface{} `json:"extra,omitempty"`
+}
+
+// validSpec 合法的最小spec，patch 中的字段覆盖或新增，值为 nil 时删除
+func validSpec(patch map[string]interface{}) map[string]interface{} {
+	spec := map[string]interface{}{"name": "login", "method": "GET"}
+	for k, v := range patch {
+		if v == nil {
+			delete(spec, k)
+			continue
+		}
+		spec[k] = v
+	}
+	return spec
+}
+
+// fieldErrors 格式化字段错误，便于比较
+func fieldErrors(errs []FieldError) string {
+	parts := make([]string, 0, len(errs))
+	for _, e := range errs {
+		parts = append(parts, e.Field+": "+e.Message)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func TestSpecSchemaValidate(t *testing.T) {
+	schema := MustParseSpecSchema(testSpecSchema)
+
+	tests := []struct {
+		name string
+		spec map[string]interface{}
+		// 期望的字段错误，为空表示校验通过
+		want string
+	}{
+		{name: "合法", spec: validSpec(nil)},
+		{name: "nil spec 缺少必填字段", spec: nil, want: "name: 缺少必填字段; method: 缺少必填字段"},
+		{name: "缺少必填字段", spec: validSpec(map[string]interface{}{"method": nil}), want: "method: 缺少必填字段"},
+		{name: "类型错误", spec: validSpec(map[string]interface{}{"name": 1}), want: "name: 类型应为 string, 实际为 integer"},
+		{name: "整数字段为小数", spec: validSpec(map[string]interface{}{"count": 1.5}), want: "count: 类型应为 integer, 实际为 number"},
+		{name: "number 接受整数", spec: validSpec(map[string]interface{}{"ratio": 2})},
+		{name: "多类型接受 null", spec: validSpec(map[string]interface{}{"nullable": nil, "tags": []string{"a"}})},
+		{name: "多类型", spec: map[string]interface{}{"name": "a", "method": "GET", "nullable": true}, want: "nullable: 类型应为 string|null, 实际为 boolean"},
+		{name: "枚举", spec: validSpec(map[string]interface{}{"method": "PUT"}), want: "method: 取值应为 [GET POST] 之一"},
+		{name: "字符串过短", spec: validSpec(map[string]interface{}{"name": ""}), want: "name: 长度不能小于 1"},
+		{name: "字符串按字符计长度", spec: validSpec(map[string]interface{}{"name": "登录接口测试用例"})},
+		{name: "字符串过长", spec: validSpec(map[string]interface{}{"name": "login-api"}), want: "name: 长度不能大于 8"},
+		{name: "模式不匹配", spec: validSpec(map[string]interface{}{"code": "A1"}), want: "code: 不匹配模式 ^[a-z]+$"},
+		{name: "小于最小值", spec: validSpec(map[string]interface{}{"count": 0}), want: "count: 不能小于 1"},
+		{name: "大于最大值", spec: validSpec(map[string]interface{}{"count": 11}), want: "count: 不能大于 10"},
+		{name: "元素过少", spec: validSpec(map[string]interface{}{"tags": []string{}}), want: "tags: 元素个数不能少于 1"},
+		{name: "元素过多", spec: validSpec(map[string]interface{}{"tags": []string{"a", "b", "c"}}), want: "tags: 元素个数不能多于 2"},
+		{name: "数组元素类型", spec: validSpec(map[string]interface{}{"tags": []interface{}{"a", 1}}), want: "tags[1]: 类型应为 string, 实际为 integer"},
+		{name: "额外字段的 schema", spec: validSpec(map[string]interface{}{"headers": map[string]interface{}{"X-Id": 1}}), want: "headers.X-Id: 类型应为 string, 实际为 integer"},
+		{name: "嵌套对象的枚举", spec: validSpec(map[string]interface{}{"options": map[string]interface{}{"mode": "auto"}}), want: "options.mode: 取值应为 [fast slow] 之一"},
+		{
+			name: "数组中对象的必填和不允许的字段",
+			spec: validSpec(map[string]interface{}{"steps": []interface{}{
+				map[string]interface{}{"id": "a"},
+				map[string]interface{}{"deps": []interface{}{"a"}, "when": "ok"},
+			}}),
+			want: "steps[1].id: 缺少必填字段; steps[1].when: 不允许的字段",
+		},
+		{
+			name: "多个错误按字段排序",
+			spec: validSpec(map[string]interface{}{"method": "PUT", "count": 0, "code": "A"}),
+			want: "code: 不匹配模式 ^[a-z]+$; count: 不能小于 1; method: 取值应为 [GET POST] 之一",
+		},
+		{name: "未声明 additionalProperties 时校验不限制额外字段", spec: validSpec(map[string]interface{}{"unknown": 1})},
+		{name: "Go 类型先转换为 JSON 类型", spec: validSpec(map[string]interface{}{"count": int64(3), "tags": []string{"a"}})},
+		{name: "无法序列化", spec: validSpec(map[string]interface{}{"extra": map[string]interface{}{"fn": func() {}}}), want: "$: spec无法序列化为JSON: json: unsupported type: func()"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fieldErrors(schema.Validate(tt.spec))
+			if got != tt.want {
+				t.Errorf("Validate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpecSchemaValidatePartial(t *testing.T) {
+	schema := MustParseSpecSchema(testSpecSchema)
+
+	tests := []struct {
+		name string
+		spec map[string]interface{}
+		want string
+	}{
+		{name: "缺少顶层必填字段", spec: map[string]interface{}{"count": 2}},
+		{name: "已出现字段仍校验", spec: map[string]interface{}{"count": 0}, want: "count: 不能小于 1"},
+		{name: "嵌套对象的必填字段仍校验", spec: map[string]interface{}{"steps": []interface{}{map[string]interface{}{}}}, want: "steps[0].id: 缺少必填字段"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldErrors(schema.ValidatePartial(tt.spec)); got != tt.want {
+				t.Errorf("ValidatePartial() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpecSchemaDecode(t *testing.T) {
+	schema := MustParseSpecSchema(testSpecSchema)
+
+	tests := []struct {
+		name string
+		spec map[string]interface{}
+		// 期望的错误字段，为空表示解码成功
+		wantErr string
+		check   func(t *testing.T, got testSpec)
+	}{
+		{
+			name: "填充默认值",
+			spec: validSpec(nil),
+			check: func(t *testing.T, got testSpec) {
+				if !got.Enabled || got.Options.Retries != 3 {
+					t.Errorf("Decode() = %+v, want enabled and retries 3 by default", got)
+				}
+			},
+		},
+		{
+			name: "已有值不被默认值覆盖",
+			spec: validSpec(map[string]interface{}{"enabled": false, "options": map[string]interface{}{"retries": 1, "mode": "fast"}}),
+			check: func(t *testing.T, got testSpec) {
+				if got.Enabled || got.Options.Retries != 1 || got.Options.Mode != "fast" {
+					t.Errorf("Decode() = %+v, want explicit values kept", got)
+				}
+			},
+		},
+		{
+			name: "嵌套数组",
+			spec: validSpec(map[string]interface{}{"steps": []interface{}{map[string]interface{}{"id": "a", "deps": []string{"b"}}}}),
+			check: func(t *testing.T, got testSpec) {
+				if len(got.Steps) != 1 || got.Steps[0].ID != "a" || fmt.Sprint(got.Steps[0].Deps) != "[b]" {
+					t.Errorf("Decode() steps = %+v", got.Steps)
+				}
+			},
+		},
+		{
+			name: "自由对象不限制字段",
+			spec: validSpec(map[string]interface{}{"extra": map[string]interface{}{"anything": 1}}),
+			check: func(t *testing.T, got testSpec) {
+				if got.Extra["anything"] != float64(1) {
+					t.Errorf("Decode() extra = %v", got.Extra)
+				}
+			},
+		},
+		{name: "拒绝顶层未声明的字段", spec: validSpec(map[string]interface{}{"unknown": 1}), wantErr: "unknown: 未声明的字段"},
+		{name: "拒绝嵌套对象未声明的字段", spec: validSpec(map[string]interface{}{"options": map[string]interface{}{"retry": 1}}), wantErr: "options.retry: 未声明的字段"},
+		{name: "校验失败", spec: validSpec(map[string]interface{}{"method": "PUT"}), wantErr: "method: 取值应为 [GET POST] 之一"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got testSpec
+			err := schema.Decode(tt.spec, &got)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Decode() error = %v", err)
+				}
+				tt.check(t, got)
+				return
+			}
+			var specErr *SpecValidationError
+			if !errors.As(err, &specErr) {
+				t.Fatalf("Decode() error = %v, want *SpecValidationError", err)
+			}
+			if got := fieldErrors(specErr.Fields); got != tt.wantErr {
+				t.Errorf("Decode() fields = %q, want %q", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSpecSchemaDecodeAdditionalProperties(t *testing.T) {
+	type declared struct {
+		Name string `json:"name"`
+	}
+
+	tests := []struct {
+		name    string
+		schema  string
+		spec    map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:   "显式允许额外字段时不参与解码",
+			schema: `{"type": "object", "additionalProperties": true, "properties": {"name": {"type": "string"}}}`,
+			spec:   map[string]interface{}{"name": "a", "token": "abc"},
+		},
+		{
+			name:    "额外字段的 schema 仍校验",
+			schema:  `{"type": "object", "additionalProperties": {"type": "string"}, "properties": {"name": {"type": "string"}}}`,
+			spec:    map[string]interface{}{"name": "a", "token": 1},
+			wantErr: "token: 类型应为 string, 实际为 integer",
+		},
+		{
+			name:    "不允许额外字段",
+			schema:  `{"type": "object", "additionalProperties": false, "properties": {"name": {"type": "string"}}}`,
+			spec:    map[string]interface{}{"name": "a", "token": "abc"},
+			wantErr: "token: 不允许的字段",
+		},
+		{
+			name:    "未声明 properties 时由结构体严格解码",
+			schema:  `{"type": "object"}`,
+			spec:    map[string]interface{}{"name": "a", "token": "abc"},
+			wantErr: `$: 解码失败: json: unknown field "token"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got declared
+			err := MustParseSpecSchema(tt.schema).Decode(tt.spec, &got)
+			if tt.wantErr == "" {
+				if err != nil || got.Name != "a" {
+					t.Errorf("Decode() = %+v, %v, want name a", got, err)
+				}
+				return
+			}
+			var specErr *SpecValidationError
+			if !errors.As(err, &specErr) || fieldErrors(specErr.Fields) != tt.wantErr {
+				t.Errorf("Decode() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseSpecSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr string
+	}{
+		{name: "type 为数组", schema: `{"type": ["string", "null"]}`},
+		{name: "additionalProperties 为 schema", schema: `{"additionalProperties": {"type": "string"}}`},
+		{name: "JSON 无效", schema: `{"type":`, wantErr: "解析spec schema失败"},
+		{name: "type 不是字符串", schema: `{"type": 1}`, wantErr: "type 必须是字符串或字符串数组"},
+		{name: "不支持的类型", schema: `{"properties": {"a": {"type": "date"}}}`, wantErr: "spec schema a: 不支持的类型 date"},
+		{name: "嵌套 pattern 无效", schema: `{"items": {"pattern": "("}}`, wantErr: "spec schema $[]: pattern 无效"},
+		{name: "额外字段 schema 无效", schema: `{"additionalProperties": {"type": "map"}}`, wantErr: "spec schema $.*: 不支持的类型 map"},
+		{name: "additionalProperties 类型错误", schema: `{"additionalProperties": 1}`, wantErr: "additionalProperties 必须是布尔值或 schema"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSpecSchema([]byte(tt.schema))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ParseSpecSchema() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseSpecSchema() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSpecRegistry(t *testing.T) {
+	registry := NewSpecRegistry()
+	registry.Register("test", MustParseSpecSchema(`{"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}}}`))
+
+	var out struct {
+		Name string `json:"name"`
+	}
+	tests := []struct {
+		name         string
+		pipelineType PipelineType
+		run          func(PipelineType) error
+		wantErr      string
+	}{
+		{name: "校验通过", pipelineType: "test", run: func(p PipelineType) error { return registry.Validate(p, map[string]interface{}{"name": "a"}) }},
+		{name: "校验失败带管道类型", pipelineType: "test", run: func(p PipelineType) error { return registry.Validate(p, nil) }, wantErr: "test 管道spec校验失败: name: 缺少必填字段"},
+		{name: "未登记的类型不校验", pipelineType: "other", run: func(p PipelineType) error { return registry.Validate(p, nil) }},
+		{name: "部分校验", pipelineType: "test", run: func(p PipelineType) error { return registry.ValidatePartial(p, map[string]interface{}{"name": 1}) }, wantErr: "test 管道spec校验失败: name: 类型应为 string, 实际为 integer"},
+		{
+			name:         "解码拒绝未声明的字段",
+			pipelineType: "test",
+			run: func(p PipelineType) error {
+				return registry.Decode(p, map[string]interface{}{"name": "a", "x": 1}, &out)
+			},
+			wantErr: "test 管道spec校验失败: x: 未声明的字段",
+		},
+		{
+			name:         "未登记的类型严格解码",
+			pipelineType: "other",
+			run: func(p PipelineType) error {
+				return registry.Decode(p, map[string]interface{}{"name": "a", "x": 1}, &out)
+			},
+			wantErr: `other 管道spec校验失败: $: 解码失败: json: unknown field "x"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run(tt.pipelineType)
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.wantErr {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package core
+
+import (
+	"fmt"
+
+	"Storage/internal/errors"
+)
+
+// taskTransitions 合法的状态流转表
+// pending -> running -> completed / failed / canceled，排队中的任务也允许直接取消
+var taskTransitions = map[TaskStatus][]TaskStatus{
+	TaskStatusPending: {TaskStatusRunning, TaskStatusCanceled},
+	TaskStatusRunning: {TaskStatusCompleted, TaskStatusFailed, TaskStatusCanceled},
+}
+
+// IsTerminal 是否为终态
+func (s TaskStatus) IsTerminal() bool {
+	return s == TaskStatusCompleted || s == TaskStatusFailed || s == TaskStatusCanceled
+}
+
+// CanTransition 判断状态能否从 from 流转到 to
+func CanTransition(from, to TaskStatus) bool {
+	// 零值视为 pending，兼容直接构造的 BasePipeline{}
+	if from == "" {
+		from = TaskStatusPending
+	}
+	for _, next := range taskTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTransition 校验状态流转，非法时返回 errors.StateTransitionError
+func ValidateTransition(from, to TaskStatus) error {
+	if CanTransition(from, to) {
+		return nil
+	}
+	return errors.New(errors.StateTransitionError).
+		WithDetails(fmt.Sprintf("非法的状态流转: %s -> %s", from, to), map[string]interface{}{"from": from, "to": to})
+}
@@ -0,0 +1,142 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"Storage/internal/errors"
+)
+
+func TestValidateTransition(t *testing.T) {
+	tests := []struct {
+		from, to TaskStatus
+		wantOK   bool
+	}{
+		{"", TaskStatusRunning, true},
+		{TaskStatusPending, TaskStatusRunning, true},
+		{TaskStatusPending, TaskStatusCanceled, true},
+		{TaskStatusRunning, TaskStatusCompleted, true},
+		{TaskStatusRunning, TaskStatusFailed, true},
+		{TaskStatusRunning, TaskStatusCanceled, true},
+
+		{TaskStatusPending, TaskStatusCompleted, false},
+		{TaskStatusPending, TaskStatusFailed, false},
+		{TaskStatusPending, TaskStatusPending, false},
+		{TaskStatusRunning, TaskStatusRunning, false},
+		{TaskStatusRunning, TaskStatusPending, false},
+		{TaskStatusCompleted, TaskStatusRunning, false},
+		{TaskStatusCompleted, TaskStatusFailed, false},
+		{TaskStatusFailed, TaskStatusCompleted, false},
+		{TaskStatusCanceled, TaskStatusRunning, false},
+		{TaskStatusCanceled, TaskStatusCompleted, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := CanTransition(tt.from, tt.to); got != tt.wantOK {
+				t.Errorf("CanTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.wantOK)
+			}
+
+			err := ValidateTransition(tt.from, tt.to)
+			if tt.wantOK {
+				if err != nil {
+					t.Errorf("ValidateTransition(%q, %q) error = %v", tt.from, tt.to, err)
+				}
+				return
+			}
+			if !errors.Is(err, errors.StateTransitionError) {
+				t.Fatalf("ValidateTransition(%q, %q) error = %v, want StateTransitionError", tt.from, tt.to, err)
+			}
+			key := fmt.Sprintf("非法的状态流转: %s -> %s", tt.from, tt.to)
+			details, _ := err.(*errors.Error).Details[key].(map[string]interface{})
+			if details["from"] != tt.from || details["to"] != tt.to {
+				t.Errorf("error details = %v, want from %q to %q", err.(*errors.Error).Details, tt.from, tt.to)
+			}
+		})
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	tests := []struct {
+		status TaskStatus
+		want   bool
+	}{
+		{TaskStatusPending, false},
+		{TaskStatusRunning, false},
+		{TaskStatusCompleted, true},
+		{TaskStatusFailed, true},
+		{TaskStatusCanceled, true},
+	}
+	for _, tt := range tests {
+		if got := tt.status.IsTerminal(); got != tt.want {
+			t.Errorf("%s.IsTerminal() = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestBasePipelineTransitionTo(t *testing.T) {
+	tests := []struct {
+		name string
+		// 依次流转的状态，最后一步的结果由 wantErr 判断，之前的都应成功
+		steps      []TaskStatus
+		wantErr    bool
+		wantStatus TaskStatus
+	}{
+		{name: "完成", steps: []TaskStatus{TaskStatusRunning, TaskStatusCompleted}, wantStatus: TaskStatusCompleted},
+		{name: "排队中取消", steps: []TaskStatus{TaskStatusCanceled}, wantStatus: TaskStatusCanceled},
+		{name: "未开始不能完成", steps: []TaskStatus{TaskStatusCompleted}, wantErr: true, wantStatus: TaskStatusPending},
+		{name: "终态不能重新运行", steps: []TaskStatus{TaskStatusRunning, TaskStatusFailed, TaskStatusRunning}, wantErr: true, wantStatus: TaskStatusFailed},
+		{name: "取消后不能失败", steps: []TaskStatus{TaskStatusRunning, TaskStatusCanceled, TaskStatusFailed}, wantErr: true, wantStatus: TaskStatusCanceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &BasePipeline{}
+			var err error
+			for i, status := range tt.steps {
+				err = p.TransitionTo(status)
+				if i < len(tt.steps)-1 && err != nil {
+					t.Fatalf("TransitionTo(%s) error = %v", status, err)
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TransitionTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, errors.StateTransitionError) {
+				t.Errorf("TransitionTo() error = %v, want StateTransitionError", err)
+			}
+			if got := p.GetStatus(context.Background()); got != tt.wantStatus {
+				t.Errorf("GetStatus() = %s, want %s", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestBasePipelineTransitionEvents(t *testing.T) {
+	bus := NewExecutionEventBus()
+	bus.Open("exec-1")
+	events, unsubscribe, ok := bus.Subscribe("exec-1")
+	if !ok {
+		t.Fatal("Subscribe() ok = false")
+	}
+	defer unsubscribe()
+
+	p := NewBasePipeline("pipe", "")
+	p.BindEvents("exec-1", bus)
+	p.TransitionTo(TaskStatusRunning)
+	p.TransitionTo(TaskStatusCompleted)
+	p.TransitionTo(TaskStatusRunning) // 非法流转不发布事件
+
+	for _, want := range []TaskStatus{TaskStatusRunning, TaskStatusCompleted} {
+		event := <-events
+		if event.Type != EventStatus || event.Status != want || event.ExecutionID != "exec-1" || event.Source != "pipe" {
+			t.Errorf("event = %+v, want status %s", event, want)
+		}
+	}
+	select {
+	case event := <-events:
+		t.Errorf("unexpected event %+v", event)
+	default:
+	}
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TimeoutLevel 超时预算所在的层级，由外到内依次嵌套：任务 ⊃ 场景 ⊃ 步骤 ⊃ 断言组
+type TimeoutLevel string
+
+const (
+	TimeoutTask      TimeoutLevel = "task"
+	TimeoutScene     TimeoutLevel = "scene"
+	TimeoutStep      TimeoutLevel = "step"
+	TimeoutAssertion TimeoutLevel = "assertion"
+)
+
+// TimeoutError 某一层级的超时预算耗尽
+// errors.Is(err, context.DeadlineExceeded) 对其成立
+type TimeoutError struct {
+	Level   TimeoutLevel
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s 级超时, 预算 %v 已耗尽", e.Level, e.Timeout)
+}
+
+// Is 兼容按 context.DeadlineExceeded 判断超时的调用方
+func (e *TimeoutError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+// WithTimeout 在 ctx 上叠加一层超时预算，timeout<=0 时不限制
+// 外层预算更早到期时以外层为准，ctx 结束的原因记录触发的层级
+func WithTimeout(ctx context.Context, level TimeoutLevel, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeoutCause(ctx, timeout, &TimeoutError{Level: level, Timeout: timeout})
+}
+
+// TimeoutOf 获取导致 ctx 结束的超时，ctx 未结束或不是因超时结束时返回 nil
+func TimeoutOf(ctx context.Context) *TimeoutError {
+	if ctx.Err() == nil {
+		return nil
+	}
+	var timeoutErr *TimeoutError
+	if errors.As(context.Cause(ctx), &timeoutErr) {
+		return timeoutErr
+	}
+	return nil
+}
+
+// timeoutCauseError 标注了超时层级的错误，errors.Is/As 对超时和原始错误链均成立
+type timeoutCauseError struct {
+	timeout *TimeoutError
+	err     error
+}
+
+func (e *timeoutCauseError) Error() string {
+	return e.timeout.Error() + ": " + e.err.Error()
+}
+
+func (e *timeoutCauseError) Unwrap() []error {
+	return []error{e.timeout, e.err}
+}
+
+// WrapTimeout ctx 因超时结束时，在 err 上标注触发的层级并保留原始错误链，已标注过的错误原样返回
+func WrapTimeout(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return err
+	}
+	if timeoutErr = TimeoutOf(ctx); timeoutErr == nil {
+		return err
+	}
+	return &timeoutCauseError{timeout: timeoutErr, err: err}
+}
+
+// TimeoutLevelOf 获取错误对应的超时层级，不是超时错误时返回空
+func TimeoutLevelOf(err error) TimeoutLevel {
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return timeoutErr.Level
+	}
+	return ""
+}
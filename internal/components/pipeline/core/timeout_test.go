@@ -0,0 +1,305 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// budget 一层超时预算，timeout<=0 表示该层不限制
+type budget struct {
+	level   TimeoutLevel
+	timeout time.Duration
+}
+
+func TestNestedTimeouts(t *testing.T) {
+	tests := []struct {
+		name    string
+		budgets []budget
+		// 期望触发的层级，为空时表示在等待时间内不超时
+		want TimeoutLevel
+	}{
+		{
+			name:    "内层预算先到期",
+			budgets: []budget{{TimeoutTask, time.Second}, {TimeoutScene, 500 * time.Millisecond}, {TimeoutStep, 20 * time.Millisecond}},
+			want:    TimeoutStep,
+		},
+		{
+			name:    "外层预算先到期",
+			budgets: []budget{{TimeoutTask, 20 * time.Millisecond}, {TimeoutScene, time.Second}, {TimeoutStep, time.Second}},
+			want:    TimeoutTask,
+		},
+		{
+			name:    "中间层预算先到期",
+			budgets: []budget{{TimeoutTask, time.Second}, {TimeoutScene, 20 * time.Millisecond}, {TimeoutStep, time.Second}},
+			want:    TimeoutScene,
+		},
+		{
+			name:    "未配置的层级只受外层限制",
+			budgets: []budget{{TimeoutTask, 20 * time.Millisecond}, {TimeoutScene, 0}, {TimeoutStep, -1}},
+			want:    TimeoutTask,
+		},
+		{
+			name:    "断言组预算",
+			budgets: []budget{{TimeoutStep, time.Second}, {TimeoutAssertion, 20 * time.Millisecond}},
+			want:    TimeoutAssertion,
+		},
+		{
+			name:    "都未配置时不超时",
+			budgets: []budget{{TimeoutTask, 0}, {TimeoutScene, 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			for _, b := range tt.budgets {
+				var cancel context.CancelFunc
+				ctx, cancel = WithTimeout(ctx, b.level, b.timeout)
+				defer cancel()
+			}
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(200 * time.Millisecond):
+			}
+
+			got := TimeoutOf(ctx)
+			if tt.want == "" {
+				if got != nil || ctx.Err() != nil {
+					t.Fatalf("TimeoutOf() = %v, ctx.Err() = %v, want no timeout", got, ctx.Err())
+				}
+				return
+			}
+			if got == nil || got.Level != tt.want {
+				t.Fatalf("TimeoutOf() = %v, want level %s", got, tt.want)
+			}
+			if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				t.Errorf("ctx.Err() = %v, want DeadlineExceeded", ctx.Err())
+			}
+		})
+	}
+}
+
+func TestTimeoutOfCanceled(t *testing.T) {
+	ctx, cancel := WithTimeout(context.Background(), TimeoutTask, time.Hour)
+	if got := TimeoutOf(ctx); got != nil {
+		t.Errorf("TimeoutOf() before done = %v, want nil", got)
+	}
+	cancel()
+	if got := TimeoutOf(ctx); got != nil {
+		t.Errorf("TimeoutOf() after cancel = %v, want nil", got)
+	}
+}
+
+// causeError 带类型的原始错误，用于检查错误链是否保留
+type causeError struct {
+	code int
+}
+
+func (e *causeError) Error() string {
+	return fmt.Sprintf("上游返回 %d", e.code)
+}
+
+func TestWrapTimeout(t *testing.T) {
+	expired, cancel := WithTimeout(context.Background(), TimeoutScene, time.Nanosecond)
+	defer cancel()
+	<-expired.Done()
+
+	canceled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+
+	base := errors.New("请求失败")
+	stepTimeout := fmt.Errorf("%w: 读取响应", &TimeoutError{Level: TimeoutStep, Timeout: time.Second})
+	typed := fmt.Errorf("读取响应: %w", &causeError{code: 502})
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		err       error
+		wantLevel TimeoutLevel
+		wantMsg   string
+	}{
+		{name: "nil 错误", ctx: expired, err: nil},
+		{name: "ctx 未结束", ctx: context.Background(), err: base, wantMsg: "请求失败"},
+		{name: "ctx 被取消", ctx: canceled, err: base, wantMsg: "请求失败"},
+		{name: "ctx 超时时标注层级", ctx: expired, err: base, wantLevel: TimeoutScene, wantMsg: "scene 级超时, 预算 1ns 已耗尽: 请求失败"},
+		{name: "已标注的层级不被外层覆盖", ctx: expired, err: stepTimeout, wantLevel: TimeoutStep, wantMsg: "step 级超时, 预算 1s 已耗尽: 读取响应"},
+		{name: "保留原始错误链", ctx: expired, err: typed, wantLevel: TimeoutScene, wantMsg: "scene 级超时, 预算 1ns 已耗尽: 读取响应: 上游返回 502"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WrapTimeout(tt.ctx, tt.err)
+			if tt.err == nil {
+				if got != nil {
+					t.Fatalf("WrapTimeout() = %v, want nil", got)
+				}
+				return
+			}
+			if !errors.Is(got, tt.err) {
+				t.Errorf("WrapTimeout() = %v, want errors.Is original error", got)
+			}
+			if got.Error() != tt.wantMsg {
+				t.Errorf("WrapTimeout().Error() = %q, want %q", got.Error(), tt.wantMsg)
+			}
+			var cause *causeError
+			if errors.As(tt.err, &cause) && (!errors.As(got, &cause) || cause.code != 502) {
+				t.Errorf("errors.As(WrapTimeout(), *causeError) failed for %v", got)
+			}
+			if level := TimeoutLevelOf(got); level != tt.wantLevel {
+				t.Errorf("TimeoutLevelOf(WrapTimeout()) = %q, want %q", level, tt.wantLevel)
+			}
+			if tt.wantLevel != "" && !errors.Is(got, context.DeadlineExceeded) {
+				t.Errorf("WrapTimeout() = %v, want errors.Is DeadlineExceeded", got)
+			}
+		})
+	}
+}
+
+func TestBasePipelineTimeout(t *testing.T) {
+	taskCtx, cancel := WithTimeout(context.Background(), TimeoutTask, time.Second)
+	defer cancel()
+
+	p := NewBasePipeline("scene", "")
+	p.SetTimeout(TimeoutScene, 20*time.Millisecond)
+	if err := p.Begin(taskCtx, nil); err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+
+	execCtx := p.ExecutionContext()
+	<-execCtx.Done()
+	err := WrapTimeout(execCtx, execCtx.Err())
+	if level := TimeoutLevelOf(err); level != TimeoutScene {
+		t.Errorf("TimeoutLevelOf() = %q, want %q", level, TimeoutScene)
+	}
+	if taskCtx.Err() != nil {
+		t.Error("场景超时不应结束任务的上下文")
+	}
+
+	p.Finish(taskCtx, nil, err)
+	metrics := p.GetMetrics(context.Background())
+	if metrics["timeout_level"] != string(TimeoutScene) || p.GetStatus(context.Background()) != TaskStatusFailed {
+		t.Errorf("metrics = %v, status = %s, want failed scene timeout", metrics, p.GetStatus(context.Background()))
+	}
+}
+
+func TestWrapTimeoutNestedLevels(t *testing.T) {
+	levels := []TimeoutLevel{TimeoutTask, TimeoutScene, TimeoutStep}
+
+	tests := []struct {
+		name     string
+		timeouts []time.Duration
+		want     TimeoutLevel
+	}{
+		{name: "步骤超时", timeouts: []time.Duration{time.Second, time.Second, 20 * time.Millisecond}, want: TimeoutStep},
+		{name: "场景超时", timeouts: []time.Duration{time.Second, 20 * time.Millisecond, time.Second}, want: TimeoutScene},
+		{name: "任务超时", timeouts: []time.Duration{20 * time.Millisecond, time.Second, time.Second}, want: TimeoutTask},
+		{name: "步骤未配置时由场景超时", timeouts: []time.Duration{time.Second, 20 * time.Millisecond, 0}, want: TimeoutScene},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctxs := make([]context.Context, len(levels))
+			ctx := context.Background()
+			for i, level := range levels {
+				var cancel context.CancelFunc
+				ctx, cancel = WithTimeout(ctx, level, tt.timeouts[i])
+				defer cancel()
+				ctxs[i] = ctx
+			}
+			<-ctx.Done()
+
+			// 由内向外逐层标注并包装，与步骤、场景、任务的错误传递一致
+			base := &causeError{code: 504}
+			var err error = base
+			for i := len(levels) - 1; i >= 0; i-- {
+				err = fmt.Errorf("%s 执行失败: %w", levels[i], WrapTimeout(ctxs[i], err))
+			}
+
+			if level := TimeoutLevelOf(err); level != tt.want {
+				t.Errorf("TimeoutLevelOf() = %q, want %q (err: %v)", level, tt.want, err)
+			}
+			if !errors.Is(err, base) || !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("err = %v, want errors.Is original error and DeadlineExceeded", err)
+			}
+			if n := strings.Count(err.Error(), "级超时"); n != 1 {
+				t.Errorf("err = %q, want the timeout level recorded once, got %d", err.Error(), n)
+			}
+		})
+	}
+}
+
+// ctxHook 记录钩子被调用时 ctx 是否已结束
+type ctxHook struct {
+	mu     sync.Mutex
+	events map[string]error
+	err    error
+}
+
+func (h *ctxHook) record(ctx context.Context, event string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events[event] = ctx.Err()
+	return nil
+}
+
+func (h *ctxHook) OnStart(ctx context.Context, taskID string, spec map[string]interface{}) error {
+	return h.record(ctx, "OnStart")
+}
+
+func (h *ctxHook) OnSuccess(ctx context.Context, taskID string, result map[string]interface{}) error {
+	return h.record(ctx, "OnSuccess")
+}
+
+func (h *ctxHook) OnFailure(ctx context.Context, taskID string, err error) error {
+	h.mu.Lock()
+	h.err = err
+	h.mu.Unlock()
+	return h.record(ctx, "OnFailure")
+}
+
+func (h *ctxHook) OnCancel(ctx context.Context, taskID string) error {
+	return h.record(ctx, "OnCancel")
+}
+
+func (h *ctxHook) OnComplete(ctx context.Context, taskID string, result map[string]interface{}) error {
+	return h.record(ctx, "OnComplete")
+}
+
+func TestFinishHooksRunAfterTimeout(t *testing.T) {
+	hook := &ctxHook{events: make(map[string]error)}
+	p := NewBasePipeline("scene", "")
+	p.AddHook(hook)
+	p.SetTimeout(TimeoutScene, 20*time.Millisecond)
+	if err := p.Begin(context.Background(), nil); err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+
+	execCtx := p.ExecutionContext()
+	<-execCtx.Done()
+	base := &causeError{code: 504}
+	if err := p.Finish(execCtx, nil, WrapTimeout(execCtx, base)); err != nil {
+		t.Fatalf("Finish() error = %v", err)
+	}
+
+	// 超时后失败和完成钩子仍执行，且使用未结束的上下文
+	for _, event := range []string{"OnFailure", "OnComplete"} {
+		ctxErr, called := hook.events[event]
+		if !called {
+			t.Errorf("%s not called after timeout", event)
+		} else if ctxErr != nil {
+			t.Errorf("%s ctx.Err() = %v, want nil", event, ctxErr)
+		}
+	}
+	if level := TimeoutLevelOf(hook.err); level != TimeoutScene || !errors.Is(hook.err, base) {
+		t.Errorf("OnFailure err = %v, want scene timeout wrapping the cause", hook.err)
+	}
+	if metrics := p.GetMetrics(context.Background()); metrics["timeout_level"] != string(TimeoutScene) {
+		t.Errorf("metrics timeout_level = %v, want %s", metrics["timeout_level"], TimeoutScene)
+	}
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner/auth"
+)
+
+// ServiceURLsVariable 执行环境中各服务基础URL的变量名，值为 服务名 -> 基础URL
+const ServiceURLsVariable = "service_urls"
+
+// Environment 执行环境，同一份API定义按环境使用不同的基础URL、变量、认证和TLS配置
+type Environment struct {
+	// 环境ID
+	EnvID string `json:"env_id"`
+
+	// 环境名称，如 dev、staging、prod
+	Name string `json:"name"`
+
+	// 默认基础URL，作为请求模板中的 base_url 变量
+	BaseURL string `json:"base_url,omitempty"`
+
+	// 各服务的基础URL，API定义配置了服务名时优先使用
+	ServiceURLs map[string]string `json:"service_urls,omitempty"`
+
+	// 环境变量，作为请求模板的变量和 env 数据来源
+	Variables map[string]string `json:"variables,omitempty"`
+
+	// 环境的认证方式，场景和步骤未配置认证时使用
+	Auth *auth.Config `json:"auth,omitempty"`
+
+	// TLS 配置
+	TLS *TLSConfig `json:"tls,omitempty"`
+}
+
+// TLSConfig 请求的TLS配置，证书和私钥均为 PEM 格式
+type TLSConfig struct {
+	// 自定义CA证书，配置后只信任这些CA
+	CACert string `bson:"ca_cert,omitempty" json:"ca_cert,omitempty"`
+
+	// 客户端证书和私钥，用于双向TLS
+	ClientCert string `bson:"client_cert,omitempty" json:"client_cert,omitempty"`
+	ClientKey  string `bson:"client_key,omitempty" json:"client_key,omitempty"`
+
+	// 是否跳过服务端证书校验
+	InsecureSkipVerify bool `bson:"insecure_skip_verify,omitempty" json:"insecure_skip_verify,omitempty"`
+
+	// 校验证书时使用的服务端名称，为空时使用请求的主机名
+	ServerName string `bson:"server_name,omitempty" json:"server_name,omitempty"`
+}
+
+// EnvironmentAware 支持执行环境的执行器
+type EnvironmentAware interface {
+	// SetEnvironment 设置执行器使用的环境，为 nil 时不使用环境
+	SetEnvironment(env *Environment) error
+}
+
+// Scope 环境提供的模板变量，包括环境变量、base_url 和 service_urls
+func (e *Environment) Scope() map[string]interface{} {
+	scope := make(map[string]interface{}, len(e.Variables)+2)
+	for k, v := range e.Variables {
+		scope[k] = v
+	}
+	if e.BaseURL != "" {
+		scope[BaseURLVariable] = e.BaseURL
+	}
+	if len(e.ServiceURLs) > 0 {
+		urls := make(map[string]interface{}, len(e.ServiceURLs))
+		for k, v := range e.ServiceURLs {
+			urls[k] = v
+		}
+		scope[ServiceURLsVariable] = urls
+	}
+	return scope
+}
+
+// SessionConfig 合并环境配置的场景会话配置，场景未配置认证或TLS时使用环境的配置
+func (e *Environment) SessionConfig(config *SessionConfig) *SessionConfig {
+	merged := SessionConfig{}
+	if config != nil {
+		merged = *config
+	}
+	if merged.Auth == nil {
+		merged.Auth = e.Auth
+	}
+	if merged.TLS == nil {
+		merged.TLS = e.TLS
+	}
+	return &merged
+}
+
+// Validate 校验环境的认证和TLS配置
+func (e *Environment) Validate() error {
+	if _, err := auth.NewProvider(e.Auth, nil); err != nil {
+		return fmt.Errorf("认证配置错误: %w", err)
+	}
+	if _, err := e.TLS.Build(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Build 构建 tls.Config，配置为空时返回 nil
+func (c *TLSConfig) Build() (*tls.Config, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		InsecureSkipVerify: c.InsecureSkipVerify,
+		ServerName:         c.ServerName,
+	}
+	if c.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(c.CACert)) {
+			return nil, fmt.Errorf("CA证书无效, 需要 PEM 格式")
+		}
+		config.RootCAs = pool
+	}
+	if c.ClientCert != "" || c.ClientKey != "" {
+		cert, err := tls.X509KeyPair([]byte(c.ClientCert), []byte(c.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("客户端证书无效: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// NewTransport 按TLS配置创建 transport，配置为空时返回 nil
+func (c *TLSConfig) NewTransport() (*http.Transport, error) {
+	config, err := c.Build()
+	if err != nil || config == nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = config
+	return transport, nil
+}
@@ -1,11 +1,12 @@
 package api
 
 import (
-	"Storage/internal/logic/workflows/api/apirunner/dependency"
-	"Storage/internal/logic/workflows/api/apirunner/expect"
-	"Storage/internal/logic/workflows/api/apirunner/extract"
-	"Storage/internal/logic/workflows/api/provider"
-	"Storage/internal/logic/workflows/core"
+	"Storage/internal/components/pipeline/core"
+	"Storage/internal/components/pipeline/runner/api/apirunner/auth"
+	"Storage/internal/components/pipeline/runner/api/apirunner/cassette"
+	"Storage/internal/components/pipeline/runner/api/apirunner/expect"
+	"Storage/internal/components/pipeline/runner/api/apirunner/har"
+	"Storage/internal/components/pipeline/runner/api/provider"
 	"Storage/storage"
 	"context"
 	"fmt"
@@ -22,6 +23,9 @@ type ApiPipeline struct {
 
 	// Logic提供者
 	providers provider.LogicProvider
+
+	// 步骤spec，在场景中执行时作为输入
+	StepSpec map[string]interface{}
 }
 
 // API任务的spec信息
@@ -36,8 +40,8 @@ type ApiTaskMeta struct {
 	contextData map[string]interface{}
 }
 
-// ApiDefinition API定义
-// todo: 需要扩展兼容rpc / graphQL接口
+// ApiDefinition API定义，请求方法为 GRPC 时按 gRPC 接口调用
+// todo: 需要扩展兼容graphQL接口
 type ApiDefinition struct {
 	// API 唯一标识
 	ApiID string `json:"api_id"`
@@ -48,9 +52,18 @@ type ApiDefinition struct {
 	// HTTP方法
 	Method string `json:"method"`
 
-	// 请求路径
+	// 请求路径，支持 {name} 和 :name 形式的路径参数
 	Path string `json:"path"`
 
+	// 基础URL，为空时依次使用执行环境中 Service 对应的基础URL和 base_url 变量
+	BaseURL string `json:"base_url,omitempty"`
+
+	// 所属服务，用于从执行环境中选择服务的基础URL
+	Service string `json:"service,omitempty"`
+
+	// 路径参数，未配置的参数从依赖数据中按名称获取
+	PathParams map[string]string `json:"path_params,omitempty"`
+
 	// 请求头
 	Headers map[string]string `json:"headers,omitempty"`
 
@@ -63,6 +76,9 @@ type ApiDefinition struct {
 	// 请求体
 	Body interface{} `json:"body,omitempty"`
 
+	// gRPC 接口的描述符来源，请求方法为 GRPC 时使用
+	Rpc *RpcConfig `json:"rpc,omitempty"`
+
 	// 描述
 	Description string `json:"description,omitempty"`
 
@@ -70,6 +86,9 @@ type ApiDefinition struct {
 	Tags []string `json:"tags,omitempty"`
 }
 
+// BaseURLVariable 执行环境中基础URL的变量名
+const BaseURLVariable = "base_url"
+
 // 依赖类型定义
 const (
 	DependTypeVariable = "variable" // 变量依赖
@@ -123,9 +142,18 @@ type ApiMetrics struct {
 	// 请求方法
 	Method string `json:"method"`
 
-	// 请求路径
+	// 请求路径，支持 {name} 和 :name 形式的路径参数
 	Path string `json:"path"`
 
+	// 基础URL，为空时依次使用执行环境中 Service 对应的基础URL和 base_url 变量
+	BaseURL string `json:"base_url,omitempty"`
+
+	// 所属服务，用于从执行环境中选择服务的基础URL
+	Service string `json:"service,omitempty"`
+
+	// 路径参数，未配置的参数从依赖数据中按名称获取
+	PathParams map[string]string `json:"path_params,omitempty"`
+
 	// 开始时间
 	StartTime string `json:"start_time"`
 
@@ -187,6 +215,42 @@ func NewApiPipeline(name string, description string, runner ApiRunner, provider
 	}
 }
 
+// SetSession 注入场景会话，执行器支持会话时步骤的请求共用会话的 cookie、默认请求头和连接
+func (p *ApiPipeline) SetSession(session *Session) {
+	if aware, ok := p.runner.(SessionAware); ok {
+		aware.SetSession(session)
+	}
+}
+
+// SetTokenStore 注入 OAuth2 令牌的缓存存储，执行器支持时环境、会话和步骤的 OAuth2 认证共享已申请的令牌
+func (p *ApiPipeline) SetTokenStore(store auth.TokenStore) {
+	if aware, ok := p.runner.(auth.TokenStoreAware); ok {
+		aware.SetTokenStore(store)
+	}
+}
+
+// SetEnvironment 注入执行环境，执行器支持环境时按环境的变量、基础URL、认证和TLS配置发送请求
+func (p *ApiPipeline) SetEnvironment(env *Environment) error {
+	if aware, ok := p.runner.(EnvironmentAware); ok {
+		return aware.SetEnvironment(env)
+	}
+	return nil
+}
+
+// SetHarRecorder 注入 HAR 记录器，执行器支持时记录每次请求和响应
+func (p *ApiPipeline) SetHarRecorder(recorder har.Recorder) {
+	if aware, ok := p.runner.(har.RecorderAware); ok {
+		aware.SetHarRecorder(recorder)
+	}
+}
+
+// SetCassette 注入磁带，执行器支持时按磁带的模式录制或回放请求
+func (p *ApiPipeline) SetCassette(c *cassette.Cassette) {
+	if aware, ok := p.runner.(cassette.CassetteAware); ok {
+		aware.SetCassette(c)
+	}
+}
+
 // Initialize 初始化管道
 func (p *ApiPipeline) Initialize(ctx context.Context) error {
 	// 调用基础初始化
@@ -274,25 +338,37 @@ func (p *ApiPipeline) FetchApiInfoByApiId(ctx context.Context, apiId string) (Ap
 
 // Execute 执行管道
 func (p *ApiPipeline) Execute(ctx context.Context, spec map[string]interface{}) (map[string]interface{}, error) {
+	// 更新状态，触发 OnStart 钩子
+	if err := p.Begin(ctx, spec); err != nil {
+		return nil, err
+	}
+	p.Progress = 0.1
+
+	// 请求基于本次执行的上下文，Cancel 时中断进行中的请求
+	execCtx := p.ExecutionContext()
+
 	// 记录开始时间
-	startTime := time.Now()
+	startTime := p.StartTime
 	p.metrics.StartTime = startTime.Format(time.RFC3339)
 	p.metrics.Status = "running"
 
-	// 更新状态
-	p.Status = core.TaskStatusRunning
-	p.Progress = 0.1
-
-	// 将spec转换为API定义
-	apiDef, err := ConvertToApiDefinition(spec)
+	// 按schema校验并解码spec，配置错误时在发出请求前失败
+	apiSpec, err := DecodeApiSpec(spec)
 	if err != nil {
 		p.metrics.Status = "failed"
 		p.metrics.Error = &core.PipelineError{
-			Message: fmt.Sprintf("Failed to convert spec to API definition: %v", err),
+			Message: fmt.Sprintf("Invalid API spec: %v", err),
 			Code:    "INVALID_SPEC",
+			Cause:   err,
 		}
+		p.Finish(ctx, nil, err)
 		return nil, err
 	}
+	apiDef := &apiSpec.ApiDefinition
+
+	// 步骤的超时预算嵌套在场景的预算内，覆盖依赖准备、请求和断言
+	execCtx, cancelStep := core.WithTimeout(execCtx, core.TimeoutStep, apiSpec.StepTimeout())
+	defer cancelStep()
 
 	p.apiDefinition = apiDef
 	p.metrics.ApiID = apiDef.ApiID
@@ -302,64 +378,47 @@ func (p *ApiPipeline) Execute(ctx context.Context, spec map[string]interface{})
 
 	// 准备依赖数据
 	p.Progress = 0.2
-	dependencies := make([]dependency.Dependency, 0)
-	if deps, ok := spec["dependencies"].([]dependency.Dependency); ok {
-		dependencies = deps
-	} else if depsArray, ok := spec["dependencies"].([]interface{}); ok {
-		// 转换通用接口数组为依赖数组
-		for _, dep := range depsArray {
-			if depMap, ok := dep.(map[string]interface{}); ok {
-				dependencies = append(dependencies, dependency.Dependency{
-					DependID: fmt.Sprintf("%v", depMap["depend_id"]),
-					Name:     fmt.Sprintf("%v", depMap["name"]),
-					Type:     fmt.Sprintf("%v", depMap["type"]),
-					Value:    depMap["value"],
-				})
-			}
-		}
-	}
-
-	dependencyValues, err := p.runner.PrepareDependencies(ctx, dependencies)
+	dependencyValues, err := p.runner.PrepareDependencies(execCtx, apiSpec.Dependencies)
 	if err != nil {
+		err = core.WrapTimeout(execCtx, err)
 		p.metrics.Status = "failed"
-		p.metrics.Error = &core.PipelineError{
-			Message: fmt.Sprintf("Failed to prepare dependencies: %v", err),
-			Code:    "DEPENDENCY_ERROR",
-		}
+		p.metrics.Error = newPipelineError("DEPENDENCY_ERROR", fmt.Sprintf("Failed to prepare dependencies: %v", err), err)
+		p.Finish(ctx, nil, err)
 		return nil, err
 	}
 
 	// 构建请求
 	p.Progress = 0.4
-	request, err := p.runner.BuildRequest(ctx, apiDef, dependencyValues)
+	request, err := p.runner.BuildRequest(execCtx, apiDef, apiSpec.Scope(dependencyValues))
 	if err != nil {
+		err = core.WrapTimeout(execCtx, err)
 		p.metrics.Status = "failed"
-		p.metrics.Error = &core.PipelineError{
-			Message: fmt.Sprintf("Failed to build request: %v", err),
-			Code:    "BUILD_REQUEST_ERROR",
-		}
+		p.metrics.Error = newPipelineError("BUILD_REQUEST_ERROR", fmt.Sprintf("Failed to build request: %v", err), err)
+		p.Finish(ctx, nil, err)
 		return nil, err
 	}
 
 	// 执行请求
 	p.Progress = 0.6
-	response, err := p.runner.ExecuteRequest(ctx, request)
+	response, err := p.runner.ExecuteRequest(execCtx, request)
 	if err != nil {
+		err = core.WrapTimeout(execCtx, err)
 		p.metrics.Status = "failed"
-		p.metrics.Error = &core.PipelineError{
-			Message: fmt.Sprintf("Failed to execute request: %v", err),
-			Code:    "EXECUTE_REQUEST_ERROR",
-		}
+		p.metrics.Error = newPipelineError("EXECUTE_REQUEST_ERROR", fmt.Sprintf("Failed to execute request: %v", err), err)
+		p.Finish(ctx, nil, err)
 		return nil, err
 	}
 
 	// 处理验证
 	p.Progress = 0.8
 
-	validationResult, err := p.runner.ValidateResponse(ctx, response, &expect.AssertionGroup{})
+	validationResult, err := p.runner.ValidateResponse(execCtx, response, &expect.AssertionGroup{})
 	if err != nil {
 		// 将验证错误包含到响应中，但不中断执行
 		response["validation_error"] = err.Error()
+		if level := core.TimeoutLevelOf(err); level != "" {
+			response["timeout_level"] = string(level)
+		}
 	}
 
 	var errorCount int
@@ -375,20 +434,7 @@ func (p *ApiPipeline) Execute(ctx context.Context, spec map[string]interface{})
 	}
 
 	// 提取数据
-	extractors := make(map[string]string)
-	if ext, ok := spec["extractors"].(map[string]string); ok {
-		extractors = ext
-	}
-
-	extractorList := make([]extract.Extractor, 0)
-	for name, path := range extractors {
-		extractorList = append(extractorList, extract.Extractor{
-			JsonPath: path,
-			Target:   extract.TargetValue{Type: "string", Value: name},
-		})
-	}
-
-	extractedData, err := p.runner.ExtractData(ctx, response, extractorList)
+	extractedData, err := p.runner.ExtractData(execCtx, response, apiSpec.ExtractorList())
 	if err != nil {
 		// 将提取错误包含到响应中，但不中断执行
 		response["extraction_error"] = err.Error()
@@ -419,21 +465,20 @@ func (p *ApiPipeline) Execute(ctx context.Context, spec map[string]interface{})
 	}
 
 	// 设置执行状态
+	var execErr error
 	if p.metrics.Error != nil {
 		p.metrics.Status = "failed"
-		p.Status = core.TaskStatusFailed
+		execErr = fmt.Errorf("%s", p.metrics.Error.Message)
 	} else if p.metrics.AssertionsFailed > 0 {
 		p.metrics.Status = "partially_succeeded"
-		p.Status = core.TaskStatusCompleted
 	} else {
 		p.metrics.Status = "succeeded"
-		p.Status = core.TaskStatusCompleted
 	}
 
 	// 上报指标
 	reportConfig := &ReportConfig{Enabled: false}
-	if cfg, ok := spec["report_config"].(*ReportConfig); ok {
-		reportConfig = cfg
+	if apiSpec.ReportConfig != nil {
+		reportConfig = apiSpec.ReportConfig
 	}
 
 	if reportConfig.Enabled {
@@ -443,13 +488,39 @@ func (p *ApiPipeline) Execute(ctx context.Context, spec map[string]interface{})
 		}
 	}
 
-	// 更新结果
-	p.Result = response
-	p.EndTime = endTime
+	// 更新结果，转为终态并触发钩子
+	if err := p.Finish(ctx, response, execErr); err != nil {
+		return response, err
+	}
 
 	return response, nil
 }
 
+// newPipelineError 构建执行错误，超时导致的失败使用 TIMEOUT 错误码并在上下文中记录超时层级
+func newPipelineError(code, message string, err error) *core.PipelineError {
+	pipelineErr := &core.PipelineError{
+		Message:   message,
+		Code:      code,
+		Timestamp: time.Now(),
+		Cause:     err,
+	}
+	if level := core.TimeoutLevelOf(err); level != "" {
+		pipelineErr.Code = "TIMEOUT"
+		pipelineErr.Context = map[string]interface{}{"timeout_level": string(level)}
+	}
+	return pipelineErr
+}
+
+// Cancel 取消管道执行，同时取消执行器中进行中的请求
+func (p *ApiPipeline) Cancel(ctx context.Context) error {
+	if p.runner != nil {
+		if err := p.runner.Cancel(ctx); err != nil {
+			return fmt.Errorf("failed to cancel API runner: %w", err)
+		}
+	}
+	return p.BasePipeline.Cancel(ctx)
+}
+
 // GetMetrics 获取执行指标
 func (p *ApiPipeline) GetMetrics(ctx context.Context) map[string]interface{} {
 	baseMetrics := p.BasePipeline.GetMetrics(ctx)
@@ -470,88 +541,19 @@ func (p *ApiPipeline) GetMetrics(ctx context.Context) map[string]interface{} {
 	return baseMetrics
 }
 
-// ConvertToApiDefinition 将规格转换为API定义
+// ConvertToApiDefinition 将规格转换为API定义，spec需符合API管道的schema
 func ConvertToApiDefinition(spec map[string]interface{}) (*ApiDefinition, error) {
-	apiDef := &ApiDefinition{}
-
-	// 设置API ID
-	if apiID, ok := spec["api_id"].(string); ok && apiID != "" {
-		apiDef.ApiID = apiID
-	} else {
-		return nil, fmt.Errorf("missing or invalid api_id")
-	}
-
-	// 设置API名称
-	if name, ok := spec["name"].(string); ok && name != "" {
-		apiDef.Name = name
-	} else {
-		return nil, fmt.Errorf("missing or invalid name")
-	}
-
-	// 设置HTTP方法
-	if method, ok := spec["method"].(string); ok && method != "" {
-		apiDef.Method = method
-	} else {
-		return nil, fmt.Errorf("missing or invalid method")
-	}
-
-	// 设置路径
-	if path, ok := spec["path"].(string); ok && path != "" {
-		apiDef.Path = path
-	} else {
-		return nil, fmt.Errorf("missing or invalid path")
-	}
-
-	// 设置请求头
-	if headers, ok := spec["headers"].(map[string]string); ok {
-		apiDef.Headers = headers
-	} else if headersMap, ok := spec["headers"].(map[string]interface{}); ok {
-		// 转换通用接口到字符串
-		headers := make(map[string]string)
-		for k, v := range headersMap {
-			headers[k] = fmt.Sprintf("%v", v)
-		}
-		apiDef.Headers = headers
-	}
-
-	// 设置查询参数
-	if queryParams, ok := spec["query_params"].(map[string]string); ok {
-		apiDef.QueryParams = queryParams
-	} else if queryParamsMap, ok := spec["query_params"].(map[string]interface{}); ok {
-		// 转换通用接口到字符串
-		queryParams := make(map[string]string)
-		for k, v := range queryParamsMap {
-			queryParams[k] = fmt.Sprintf("%v", v)
-		}
-		apiDef.QueryParams = queryParams
-	}
-
-	// 设置请求体类型
-	if bodyType, ok := spec["body_type"].(string); ok {
-		apiDef.BodyType = bodyType
-	}
-
-	// 设置请求体
-	if body, ok := spec["body"]; ok {
-		apiDef.Body = body
-	}
-
-	// 设置描述
-	if description, ok := spec["description"].(string); ok {
-		apiDef.Description = description
+	apiSpec, err := DecodeApiSpec(spec)
+	if err != nil {
+		return nil, err
 	}
+	return &apiSpec.ApiDefinition, nil
+}
 
-	// 设置标签
-	if tags, ok := spec["tags"].([]string); ok {
-		apiDef.Tags = tags
-	} else if tagsArray, ok := spec["tags"].([]interface{}); ok {
-		// 转换通用接口到字符串
-		tags := make([]string, 0, len(tagsArray))
-		for _, tag := range tagsArray {
-			tags = append(tags, fmt.Sprintf("%v", tag))
-		}
-		apiDef.Tags = tags
+// Validate 校验步骤spec中已配置字段的类型，必填字段在执行时随变量补全后校验
+func (p *ApiPipeline) Validate(ctx context.Context) error {
+	if err := p.BasePipeline.Validate(ctx); err != nil {
+		return err
 	}
-
-	return apiDef, nil
+	return core.ValidateSpecPartial(core.TypeAPI, p.StepSpec)
 }
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"fmt"
+	"os"
+)
+
+// MethodGRPC gRPC 接口的请求方法，请求路径为 /包名.服务名/方法名，基础URL的协议为 grpc（明文）或 grpcs（TLS）
+const MethodGRPC = "GRPC"
+
+// RpcConfig gRPC 接口的描述符来源，未配置 protoset 时通过服务端反射获取
+type RpcConfig struct {
+	// protoset 文件路径，由 protoc --include_imports --descriptor_set_out 生成
+	Protoset string `bson:"protoset,omitempty" json:"protoset,omitempty"`
+
+	// 上传的 protoset 文件内容，JSON 中为 base64 编码，优先于 Protoset
+	ProtosetData []byte `bson:"protoset_data,omitempty" json:"protoset_data,omitempty"`
+
+	// 服务端流最多接收的消息数，为 0 时接收到流结束
+	MaxMessages int `bson:"max_messages,omitempty" json:"max_messages,omitempty"`
+}
+
+// UseReflection 是否通过服务端反射获取描述符
+func (c *RpcConfig) UseReflection() bool {
+	return c == nil || (c.Protoset == "" && len(c.ProtosetData) == 0)
+}
+
+// LoadProtoset 读取 protoset 文件内容
+func (c *RpcConfig) LoadProtoset() ([]byte, error) {
+	if len(c.ProtosetData) > 0 {
+		return c.ProtosetData, nil
+	}
+	data, err := os.ReadFile(c.Protoset)
+	if err != nil {
+		return nil, fmt.Errorf("读取 protoset 文件失败: %w", err)
+	}
+	return data, nil
+}
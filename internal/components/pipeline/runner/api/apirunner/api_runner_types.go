@@ -1,11 +1,11 @@
 package api
 
 import (
-	"Storage/internal/logic/workflows/api/apirunner/dependency"
-	"Storage/internal/logic/workflows/api/apirunner/expect"
-	"Storage/internal/logic/workflows/api/apirunner/extract"
-	"Storage/internal/logic/workflows/api/apirunner/store"
-	"Storage/internal/logic/workflows/core"
+	"Storage/internal/components/pipeline/core"
+	"Storage/internal/components/pipeline/runner/api/apirunner/dependency"
+	"Storage/internal/components/pipeline/runner/api/apirunner/expect"
+	"Storage/internal/components/pipeline/runner/api/apirunner/extract"
+	"Storage/internal/components/pipeline/runner/api/apirunner/store"
 	"context"
 )
 
@@ -28,7 +28,7 @@ type ApiRunnable interface {
 	ExtractData(ctx context.Context, response map[string]interface{}, extractors []extract.Extractor) (map[string]interface{}, error)
 
 	// 将运行数据存储到公共地方，给其他场景使用
-	StoreData(ctx context.Context, data map[string]interface{}, storeConfig []*store.ReportStoreConfig) error
+	StoreData(ctx context.Context, data map[string]interface{}, storeConfig []*store.ReportRunData) error
 
 	// 上报指标
 	ReportMetrics(ctx context.Context, metrics *ApiMetrics, config *ReportConfig) error
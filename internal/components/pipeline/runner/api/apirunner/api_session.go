@@ -0,0 +1,169 @@
+package api
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sync"
+	"time"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner/auth"
+)
+
+// 会话连接池的默认配置
+const (
+	DefaultMaxIdleConnsPerHost = 16
+	DefaultIdleConnTimeout     = 90 * time.Second
+)
+
+// SessionConfig 场景会话配置
+type SessionConfig struct {
+	// 所有请求默认携带的请求头，步骤中配置的同名请求头优先
+	DefaultHeaders map[string]string `bson:"default_headers,omitempty" json:"default_headers,omitempty"`
+
+	// 是否禁用 cookie 共享
+	DisableCookies bool `bson:"disable_cookies,omitempty" json:"disable_cookies,omitempty"`
+
+	// 是否禁用长连接
+	DisableKeepAlives bool `bson:"disable_keep_alives,omitempty" json:"disable_keep_alives,omitempty"`
+
+	// 是否禁用 HTTP/2，未禁用时对 HTTPS 请求协商 HTTP/2
+	DisableHTTP2 bool `bson:"disable_http2,omitempty" json:"disable_http2,omitempty"`
+
+	// 每个主机的最大连接数，为 0 时不限制
+	MaxConnsPerHost int `bson:"max_conns_per_host,omitempty" json:"max_conns_per_host,omitempty"`
+
+	// 每个主机的最大空闲连接数，为 0 时使用 DefaultMaxIdleConnsPerHost
+	MaxIdleConnsPerHost int `bson:"max_idle_conns_per_host,omitempty" json:"max_idle_conns_per_host,omitempty"`
+
+	// 空闲连接超时（秒），为 0 时使用 DefaultIdleConnTimeout
+	IdleConnTimeout int `bson:"idle_conn_timeout,omitempty" json:"idle_conn_timeout,omitempty"`
+
+	// 场景内请求的认证方式，步骤配置的认证优先
+	Auth *auth.Config `bson:"auth,omitempty" json:"auth,omitempty"`
+
+	// TLS 配置，为空时使用系统默认配置
+	TLS *TLSConfig `bson:"tls,omitempty" json:"tls,omitempty"`
+}
+
+// Session 场景级的HTTP会话，场景内所有步骤共用 cookie、默认请求头和连接池
+type Session struct {
+	client    *http.Client
+	transport *http.Transport
+	jar       http.CookieJar
+	headers   map[string]string
+	auth      auth.Provider
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// SessionAware 支持场景会话的执行器
+type SessionAware interface {
+	// SetSession 设置执行器使用的会话，为 nil 时恢复执行器自身的客户端
+	SetSession(session *Session)
+}
+
+// NewSession 按配置创建会话，config 为空时使用默认配置，store 为会话 OAuth2 认证的令牌缓存存储
+func NewSession(config *SessionConfig, store auth.TokenStore) (*Session, error) {
+	if config == nil {
+		config = &SessionConfig{}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableKeepAlives = config.DisableKeepAlives
+	transport.ForceAttemptHTTP2 = !config.DisableHTTP2
+	transport.MaxConnsPerHost = config.MaxConnsPerHost
+	transport.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	if config.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	}
+	transport.IdleConnTimeout = DefaultIdleConnTimeout
+	if config.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = time.Duration(config.IdleConnTimeout) * time.Second
+	}
+	if config.DisableHTTP2 {
+		// 非空的 TLSNextProto 阻止 transport 自动启用 HTTP/2
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+
+	tlsConfig, err := config.TLS.Build()
+	if err != nil {
+		return nil, fmt.Errorf("场景TLS配置错误: %w", err)
+	}
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	provider, err := auth.NewProvider(config.Auth, store)
+	if err != nil {
+		return nil, fmt.Errorf("场景认证配置错误: %w", err)
+	}
+
+	session := &Session{
+		transport: transport,
+		headers:   make(map[string]string, len(config.DefaultHeaders)),
+		auth:      provider,
+	}
+	for k, v := range config.DefaultHeaders {
+		session.headers[k] = v
+	}
+	if !config.DisableCookies {
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return nil, fmt.Errorf("创建cookie jar失败: %w", err)
+		}
+		session.jar = jar
+	}
+
+	// 客户端不设超时，由上下文中任务、场景、步骤的超时预算控制
+	session.client = &http.Client{
+		Transport: transport,
+		Jar:       session.jar,
+	}
+	return session, nil
+}
+
+// Client 会话的HTTP客户端
+func (s *Session) Client() *http.Client {
+	return s.client
+}
+
+// DefaultHeaders 会话的默认请求头
+func (s *Session) DefaultHeaders() map[string]string {
+	headers := make(map[string]string, len(s.headers))
+	for k, v := range s.headers {
+		headers[k] = v
+	}
+	return headers
+}
+
+// Auth 会话的认证方式，未配置时为 nil
+func (s *Session) Auth() auth.Provider {
+	return s.auth
+}
+
+// Cookies 会话中发往 rawURL 的 cookie
+func (s *Session) Cookies(rawURL string) ([]*http.Cookie, error) {
+	if s.jar == nil {
+		return nil, nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return s.jar.Cookies(u), nil
+}
+
+// Close 关闭会话的空闲连接，场景结束时调用
+func (s *Session) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	s.transport.CloseIdleConnections()
+}
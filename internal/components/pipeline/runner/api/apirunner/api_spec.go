@@ -0,0 +1,210 @@
+package api
+
+import (
+	"sort"
+	"time"
+
+	"Storage/internal/components/pipeline/core"
+	"Storage/internal/components/pipeline/runner/api/apirunner/auth"
+	"Storage/internal/components/pipeline/runner/api/apirunner/dependency"
+	"Storage/internal/components/pipeline/runner/api/apirunner/expect"
+	"Storage/internal/components/pipeline/runner/api/apirunner/extract"
+)
+
+// apiSpecSchema API管道spec的 JSON Schema
+// 场景中前序步骤提取的变量以顶层键注入spec，因此顶层允许未声明的字段
+const apiSpecSchema = `{
+  "type": "object",
+  "required": ["api_id", "name", "method", "path"],
+  "additionalProperties": true,
+  "properties": {
+    "api_id": {"type": "string", "minLength": 1},
+    "name": {"type": "string", "minLength": 1},
+    "method": {"type": "string", "pattern": "^[A-Za-z]+$"},
+    "path": {"type": "string", "minLength": 1},
+    "base_url": {"type": "string"},
+    "service": {"type": "string"},
+    "path_params": {"type": "object", "additionalProperties": {"type": "string"}},
+    "headers": {"type": "object", "additionalProperties": {"type": "string"}},
+    "query_params": {"type": "object", "additionalProperties": {"type": "string"}},
+    "body_type": {"type": "string"},
+    "body": {},
+    "rpc": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "protoset": {"type": "string"},
+        "protoset_data": {"type": "string"},
+        "max_messages": {"type": "integer", "minimum": 0}
+      }
+    },
+    "description": {"type": "string"},
+    "tags": {"type": "array", "items": {"type": "string"}},
+    "dependencies": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": ["name"],
+        "additionalProperties": false,
+        "properties": {
+          "depend_id": {"type": "string"},
+          "type": {"type": "string"},
+          "name": {"type": "string", "minLength": 1},
+          "key": {"type": "object"},
+          "value": {}
+        }
+      }
+    },
+    "assertions": {"type": "array", "items": {"type": "object"}},
+    "assert_groups": {"type": "array", "items": {"type": "object"}},
+    "extractors": {
+      "type": "object",
+      "additionalProperties": {
+        "type": ["string", "object"],
+        "minLength": 1,
+        "additionalProperties": false,
+        "properties": {
+          "source": {"enum": ["", "jsonpath", "header", "cookie", "status", "duration", "regex", "xpath"]},
+          "expression": {"type": "string"},
+          "json_path": {"type": "string"},
+          "group": {"type": "integer", "minimum": 0},
+          "default": {},
+          "value_type": {"enum": ["", "string", "int", "float", "bool", "json"]}
+        }
+      }
+    },
+    "auth": {
+      "type": "object",
+      "required": ["type"],
+      "additionalProperties": false,
+      "properties": {
+        "type": {"enum": ["none", "bearer", "basic", "api_key", "oauth2", "hmac"]},
+        "bearer": {"type": "object"},
+        "basic": {"type": "object"},
+        "api_key": {"type": "object"},
+        "oauth2": {"type": "object"},
+        "hmac": {"type": "object"}
+      }
+    },
+    "timeout_ms": {"type": "integer", "minimum": 0},
+    "retry": {
+      "type": "object",
+      "properties": {
+        "max_retries": {"type": "integer", "minimum": 0},
+        "interval": {"type": "integer", "minimum": 0},
+        "strategy": {"enum": ["", "constant", "linear_backoff", "exponential_backoff", "fibonacci_backoff", "random_backoff"]},
+        "max_interval": {"type": "integer", "minimum": 0},
+        "random_range": {
+          "type": "object",
+          "properties": {
+            "min": {"type": "integer", "minimum": 0},
+            "max": {"type": "integer", "minimum": 0}
+          }
+        },
+        "jitter": {"type": "number", "minimum": 0, "maximum": 1}
+      }
+    },
+    "report_config": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "enabled": {"type": "boolean"},
+        "target": {"type": "string"},
+        "config": {"type": "object"}
+      }
+    }
+  }
+}`
+
+// DefaultStepTimeout 步骤未配置超时时的默认超时预算
+const DefaultStepTimeout = 30 * time.Second
+
+func init() {
+	core.RegisterSpecSchema(core.TypeAPI, apiSpecSchema)
+}
+
+// ApiSpec API管道的spec
+type ApiSpec struct {
+	ApiDefinition
+
+	// 请求依赖
+	Dependencies []dependency.Dependency `json:"dependencies,omitempty"`
+
+	// 断言
+	Assertions []expect.Assertion `json:"assertions,omitempty"`
+
+	// 断言组，配置后优先于 Assertions
+	AssertGroups []expect.AssertionGroup `json:"assert_groups,omitempty"`
+
+	// 提取器，变量名 -> 整个响应上的 JSONPath，或指定来源、默认值和类型转换的提取规则
+	Extractors map[string]extract.Extractor `json:"extractors,omitempty"`
+
+	// 请求重试配置，只对网络错误和 408/425/429/5xx 响应（gRPC 为 UNAVAILABLE、RESOURCE_EXHAUSTED）重试
+	Retry *expect.RetryConfig `json:"retry,omitempty"`
+
+	// 认证方式，覆盖场景会话的认证，type 为 none 时不认证
+	Auth *auth.Config `json:"auth,omitempty"`
+
+	// 步骤超时（毫秒），覆盖依赖准备、请求（含重试）和断言，为 0 时使用 DefaultStepTimeout
+	TimeoutMs int `json:"timeout_ms,omitempty"`
+
+	// 指标上报配置
+	ReportConfig *ReportConfig `json:"report_config,omitempty"`
+
+	// spec 顶层未声明的字段，即场景注入的变量，作为请求模板的变量
+	Variables map[string]interface{} `json:"-"`
+}
+
+// DecodeApiSpec 按schema校验spec并严格解码，校验失败返回 *core.SpecValidationError
+func DecodeApiSpec(spec map[string]interface{}) (*ApiSpec, error) {
+	var apiSpec ApiSpec
+	if err := core.DecodeSpec(core.TypeAPI, spec, &apiSpec); err != nil {
+		return nil, err
+	}
+
+	apiSpec.Variables = make(map[string]interface{})
+	schema, _ := core.LookupSpecSchema(core.TypeAPI)
+	for k, v := range spec {
+		if _, declared := schema.Properties[k]; !declared {
+			apiSpec.Variables[k] = v
+		}
+	}
+	return &apiSpec, nil
+}
+
+// Scope 请求模板的变量作用域，依赖数据与 spec 注入的变量同名时以依赖数据为准
+func (s *ApiSpec) Scope(dependencies map[string]interface{}) map[string]interface{} {
+	scope := make(map[string]interface{}, len(s.Variables)+len(dependencies))
+	for k, v := range s.Variables {
+		scope[k] = v
+	}
+	for k, v := range dependencies {
+		scope[k] = v
+	}
+	return scope
+}
+
+// StepTimeout 步骤的超时预算
+func (s *ApiSpec) StepTimeout() time.Duration {
+	if s.TimeoutMs > 0 {
+		return time.Duration(s.TimeoutMs) * time.Millisecond
+	}
+	return DefaultStepTimeout
+}
+
+// ExtractorList 按变量名排序的提取器列表
+func (s *ApiSpec) ExtractorList() []extract.Extractor {
+	names := make([]string, 0, len(s.Extractors))
+	for name := range s.Extractors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	extractors := make([]extract.Extractor, 0, len(names))
+	for _, name := range names {
+		extractor := s.Extractors[name]
+		extractor.Name = name
+		extractors = append(extractors, extractor)
+	}
+	return extractors
+}
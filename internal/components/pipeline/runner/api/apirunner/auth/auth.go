@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// NewProvider 按配置创建认证方式，config 为空或 Type 为 none 时返回 nil
+// store 为 OAuth2 令牌的缓存存储，为空时令牌不在实例间和执行间共享
+func NewProvider(config *Config, store TokenStore) (Provider, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	switch config.Type {
+	case "", TypeNone:
+		return nil, nil
+
+	case TypeBearer:
+		if config.Bearer == nil || config.Bearer.Token == "" {
+			return nil, fmt.Errorf("bearer 认证需要配置 token")
+		}
+		return &bearerProvider{config: *config.Bearer}, nil
+
+	case TypeBasic:
+		if config.Basic == nil || config.Basic.Username == "" {
+			return nil, fmt.Errorf("basic 认证需要配置 username")
+		}
+		return &basicProvider{config: *config.Basic}, nil
+
+	case TypeAPIKey:
+		if config.APIKey == nil || config.APIKey.Name == "" {
+			return nil, fmt.Errorf("api_key 认证需要配置 name")
+		}
+		switch config.APIKey.In {
+		case "", InHeader, InQuery:
+		default:
+			return nil, fmt.Errorf("api_key 不支持放在 %s 中", config.APIKey.In)
+		}
+		return &apiKeyProvider{config: *config.APIKey}, nil
+
+	case TypeOAuth2:
+		if config.OAuth2 == nil {
+			return nil, fmt.Errorf("oauth2 认证需要配置 oauth2")
+		}
+		return newOAuth2Provider(*config.OAuth2, store)
+
+	case TypeHMAC:
+		if config.HMAC == nil {
+			return nil, fmt.Errorf("hmac 认证需要配置 hmac")
+		}
+		return newHMACProvider(*config.HMAC)
+
+	default:
+		return nil, fmt.Errorf("不支持的认证方式: %s", config.Type)
+	}
+}
+
+// bearerProvider Bearer Token 认证
+type bearerProvider struct {
+	config BearerConfig
+}
+
+func (p *bearerProvider) Apply(ctx context.Context, req *http.Request, body []byte) error {
+	header := p.config.Header
+	if header == "" {
+		header = "Authorization"
+	}
+	prefix := p.config.Prefix
+	if prefix == "" {
+		prefix = "Bearer"
+	}
+	req.Header.Set(header, prefix+" "+p.config.Token)
+	return nil
+}
+
+func (p *bearerProvider) Redacted() (headers, query []string) {
+	if p.config.Header == "" {
+		return []string{"Authorization"}, nil
+	}
+	return []string{p.config.Header}, nil
+}
+
+// basicProvider HTTP Basic 认证
+type basicProvider struct {
+	config BasicConfig
+}
+
+func (p *basicProvider) Apply(ctx context.Context, req *http.Request, body []byte) error {
+	req.SetBasicAuth(p.config.Username, p.config.Password)
+	return nil
+}
+
+func (p *basicProvider) Redacted() (headers, query []string) {
+	return []string{"Authorization"}, nil
+}
+
+// apiKeyProvider API Key 认证
+type apiKeyProvider struct {
+	config APIKeyConfig
+}
+
+func (p *apiKeyProvider) Apply(ctx context.Context, req *http.Request, body []byte) error {
+	if p.config.In == InQuery {
+		query := req.URL.Query()
+		query.Set(p.config.Name, p.config.Value)
+		req.URL.RawQuery = query.Encode()
+		return nil
+	}
+	req.Header.Set(p.config.Name, p.config.Value)
+	return nil
+}
+
+func (p *apiKeyProvider) Redacted() (headers, query []string) {
+	if p.config.In == InQuery {
+		return nil, []string{p.config.Name}
+	}
+	return []string{p.config.Name}, nil
+}
@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+)
+
+// 认证方式
+const (
+	TypeNone   = "none"
+	TypeBearer = "bearer"
+	TypeBasic  = "basic"
+	TypeAPIKey = "api_key"
+	TypeOAuth2 = "oauth2"
+	TypeHMAC   = "hmac"
+)
+
+// API Key 的位置
+const (
+	InHeader = "header"
+	InQuery  = "query"
+)
+
+// Provider 为发出的请求添加认证信息
+type Provider interface {
+	// Apply 在请求头和请求体确定后、发送前调用，body 为编码后的请求体
+	Apply(ctx context.Context, req *http.Request, body []byte) error
+}
+
+// Invalidator 持有可失效凭证的认证方式，请求返回 401 时丢弃当前凭证，下次请求重新获取
+type Invalidator interface {
+	Invalidate(ctx context.Context) error
+}
+
+// Redactor 认证方式添加凭证的请求头和查询参数，记录请求时遮盖这些值
+type Redactor interface {
+	Redacted() (headers, query []string)
+}
+
+// TokenStoreAware 使用 OAuth2 认证的执行器或管道，由调用方注入令牌的缓存存储
+type TokenStoreAware interface {
+	// SetTokenStore 设置 OAuth2 令牌的缓存存储，为 nil 时令牌只保存在认证方式中
+	SetTokenStore(store TokenStore)
+}
+
+// Config 认证配置，按 Type 使用对应的子配置
+type Config struct {
+	// 认证方式，none 表示不认证，用于在步骤中关闭场景的认证
+	Type string `bson:"type" json:"type"`
+
+	Bearer *BearerConfig `bson:"bearer,omitempty" json:"bearer,omitempty"`
+	Basic  *BasicConfig  `bson:"basic,omitempty" json:"basic,omitempty"`
+	APIKey *APIKeyConfig `bson:"api_key,omitempty" json:"api_key,omitempty"`
+	OAuth2 *OAuth2Config `bson:"oauth2,omitempty" json:"oauth2,omitempty"`
+	HMAC   *HMACConfig   `bson:"hmac,omitempty" json:"hmac,omitempty"`
+}
+
+// BearerConfig Bearer Token 认证
+type BearerConfig struct {
+	Token string `bson:"token" json:"token"`
+
+	// 认证请求头，默认为 Authorization
+	Header string `bson:"header,omitempty" json:"header,omitempty"`
+
+	// 令牌前缀，默认为 Bearer
+	Prefix string `bson:"prefix,omitempty" json:"prefix,omitempty"`
+}
+
+// BasicConfig HTTP Basic 认证
+type BasicConfig struct {
+	Username string `bson:"username" json:"username"`
+	Password string `bson:"password" json:"password"`
+}
+
+// APIKeyConfig API Key 认证
+type APIKeyConfig struct {
+	// 请求头或查询参数的名称
+	Name  string `bson:"name" json:"name"`
+	Value string `bson:"value" json:"value"`
+
+	// 放置位置，header 或 query，默认为 header
+	In string `bson:"in,omitempty" json:"in,omitempty"`
+}
+
+// OAuth2Config OAuth2 客户端凭证模式
+type OAuth2Config struct {
+	TokenURL     string   `bson:"token_url" json:"token_url"`
+	ClientID     string   `bson:"client_id" json:"client_id"`
+	ClientSecret string   `bson:"client_secret" json:"client_secret"`
+	Scopes       []string `bson:"scopes,omitempty" json:"scopes,omitempty"`
+	Audience     string   `bson:"audience,omitempty" json:"audience,omitempty"`
+
+	// 令牌请求的其他参数
+	Params map[string]string `bson:"params,omitempty" json:"params,omitempty"`
+
+	// 客户端凭证的传递方式，header 使用 Basic 认证，body 放在表单中，默认为 header
+	AuthStyle string `bson:"auth_style,omitempty" json:"auth_style,omitempty"`
+
+	// 令牌过期前多少秒刷新，为 0 时使用 DefaultRefreshBefore
+	RefreshBefore int `bson:"refresh_before,omitempty" json:"refresh_before,omitempty"`
+}
+
+// HMACConfig HMAC 请求签名
+type HMACConfig struct {
+	KeyID  string `bson:"key_id,omitempty" json:"key_id,omitempty"`
+	Secret string `bson:"secret" json:"secret"`
+
+	// 签名算法，sha1、sha256 或 sha512，默认为 sha256
+	Algorithm string `bson:"algorithm,omitempty" json:"algorithm,omitempty"`
+
+	// 签名编码，hex 或 base64，默认为 hex
+	Encoding string `bson:"encoding,omitempty" json:"encoding,omitempty"`
+
+	// 待签名串的组成部分及顺序，默认为 DefaultHMACComponents，
+	// 可用 method、host、path、query、headers、body、body_hash、timestamp、nonce、key_id
+	Components []string `bson:"components,omitempty" json:"components,omitempty"`
+
+	// 各组成部分之间的分隔符，默认为换行
+	Separator *string `bson:"separator,omitempty" json:"separator,omitempty"`
+
+	// 参与签名的请求头，按配置顺序以 name:value 的小写名称形式拼接
+	SignedHeaders []string `bson:"signed_headers,omitempty" json:"signed_headers,omitempty"`
+
+	// 时间戳请求头，默认为 X-Timestamp
+	TimestampHeader string `bson:"timestamp_header,omitempty" json:"timestamp_header,omitempty"`
+
+	// 时间戳格式，unix、unix_ms 或 rfc3339，默认为 unix
+	TimestampFormat string `bson:"timestamp_format,omitempty" json:"timestamp_format,omitempty"`
+
+	// 随机数请求头，默认为 X-Nonce，组成部分包含 nonce 时才生成
+	NonceHeader string `bson:"nonce_header,omitempty" json:"nonce_header,omitempty"`
+
+	// 签名请求头，默认为 X-Signature
+	SignatureHeader string `bson:"signature_header,omitempty" json:"signature_header,omitempty"`
+
+	// 签名请求头的值，可用 {signature}、{key_id}、{algorithm}、{timestamp}、{nonce}、{signed_headers} 占位，默认为 {signature}
+	SignatureFormat string `bson:"signature_format,omitempty" json:"signature_format,omitempty"`
+}
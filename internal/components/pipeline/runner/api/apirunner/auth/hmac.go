@@ -0,0 +1,244 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultHMACComponents 默认的待签名串组成部分
+var DefaultHMACComponents = []string{"method", "path", "query", "timestamp", "body_hash"}
+
+// hmacAlgorithms 支持的签名算法
+var hmacAlgorithms = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// hmacComponents 支持的待签名串组成部分
+var hmacComponents = []string{"method", "host", "path", "query", "headers", "body", "body_hash", "timestamp", "nonce", "key_id"}
+
+// hmacProvider HMAC 请求签名
+type hmacProvider struct {
+	config     HMACConfig
+	newHash    func() hash.Hash
+	components []string
+	separator  string
+	// 签名时间，测试时替换
+	now func() time.Time
+}
+
+func newHMACProvider(config HMACConfig) (*hmacProvider, error) {
+	if config.Secret == "" {
+		return nil, fmt.Errorf("hmac 认证需要配置 secret")
+	}
+
+	if config.Algorithm == "" {
+		config.Algorithm = "sha256"
+	}
+	config.Algorithm = strings.ToLower(config.Algorithm)
+	newHash, ok := hmacAlgorithms[config.Algorithm]
+	if !ok {
+		return nil, fmt.Errorf("hmac 不支持的签名算法: %s", config.Algorithm)
+	}
+
+	switch config.Encoding {
+	case "", "hex", "base64":
+	default:
+		return nil, fmt.Errorf("hmac 不支持的签名编码: %s", config.Encoding)
+	}
+	switch config.TimestampFormat {
+	case "", "unix", "unix_ms", "rfc3339":
+	default:
+		return nil, fmt.Errorf("hmac 不支持的时间戳格式: %s", config.TimestampFormat)
+	}
+
+	components := config.Components
+	if len(components) == 0 {
+		components = DefaultHMACComponents
+	}
+	for _, component := range components {
+		if !slices.Contains(hmacComponents, component) {
+			return nil, fmt.Errorf("hmac 不支持的签名组成部分: %s", component)
+		}
+	}
+
+	separator := "\n"
+	if config.Separator != nil {
+		separator = *config.Separator
+	}
+
+	if config.TimestampHeader == "" {
+		config.TimestampHeader = "X-Timestamp"
+	}
+	if config.NonceHeader == "" {
+		config.NonceHeader = "X-Nonce"
+	}
+	if config.SignatureHeader == "" {
+		config.SignatureHeader = "X-Signature"
+	}
+	if config.SignatureFormat == "" {
+		config.SignatureFormat = "{signature}"
+	}
+
+	return &hmacProvider{
+		config:     config,
+		newHash:    newHash,
+		components: components,
+		separator:  separator,
+		now:        time.Now,
+	}, nil
+}
+
+// Apply 设置时间戳和随机数请求头后，按配置拼接待签名串并签名
+func (p *hmacProvider) Apply(ctx context.Context, req *http.Request, body []byte) error {
+	timestamp := p.timestamp(p.now())
+	if p.uses("timestamp") {
+		req.Header.Set(p.config.TimestampHeader, timestamp)
+	}
+
+	nonce := ""
+	if p.uses("nonce") {
+		buf := make([]byte, 16)
+		if _, err := rand.Read(buf); err != nil {
+			return fmt.Errorf("生成签名随机数失败: %w", err)
+		}
+		nonce = hex.EncodeToString(buf)
+		req.Header.Set(p.config.NonceHeader, nonce)
+	}
+
+	mac := hmac.New(p.newHash, []byte(p.config.Secret))
+	mac.Write([]byte(p.canonicalString(req, body, timestamp, nonce)))
+	sum := mac.Sum(nil)
+
+	signature := hex.EncodeToString(sum)
+	if p.config.Encoding == "base64" {
+		signature = base64.StdEncoding.EncodeToString(sum)
+	}
+
+	signedHeaders := make([]string, len(p.config.SignedHeaders))
+	for i, name := range p.config.SignedHeaders {
+		signedHeaders[i] = strings.ToLower(name)
+	}
+	value := strings.NewReplacer(
+		"{signature}", signature,
+		"{key_id}", p.config.KeyID,
+		"{algorithm}", p.config.Algorithm,
+		"{timestamp}", timestamp,
+		"{nonce}", nonce,
+		"{signed_headers}", strings.Join(signedHeaders, ";"),
+	).Replace(p.config.SignatureFormat)
+	req.Header.Set(p.config.SignatureHeader, value)
+	return nil
+}
+
+func (p *hmacProvider) Redacted() (headers, query []string) {
+	return []string{p.config.SignatureHeader}, nil
+}
+
+// canonicalString 按组成部分的顺序拼接待签名串
+func (p *hmacProvider) canonicalString(req *http.Request, body []byte, timestamp, nonce string) string {
+	parts := make([]string, 0, len(p.components))
+	for _, component := range p.components {
+		switch component {
+		case "method":
+			parts = append(parts, strings.ToUpper(req.Method))
+		case "host":
+			parts = append(parts, strings.ToLower(requestHost(req)))
+		case "path":
+			path := req.URL.EscapedPath()
+			if path == "" {
+				path = "/"
+			}
+			parts = append(parts, path)
+		case "query":
+			parts = append(parts, canonicalQuery(req.URL.Query()))
+		case "headers":
+			for _, name := range p.config.SignedHeaders {
+				value := req.Header.Get(name)
+				if strings.EqualFold(name, "Host") {
+					value = requestHost(req)
+				}
+				parts = append(parts, strings.ToLower(name)+":"+strings.TrimSpace(value))
+			}
+		case "body":
+			parts = append(parts, string(body))
+		case "body_hash":
+			h := p.newHash()
+			h.Write(body)
+			parts = append(parts, hex.EncodeToString(h.Sum(nil)))
+		case "timestamp":
+			parts = append(parts, timestamp)
+		case "nonce":
+			parts = append(parts, nonce)
+		case "key_id":
+			parts = append(parts, p.config.KeyID)
+		}
+	}
+	return strings.Join(parts, p.separator)
+}
+
+// uses 待签名串或签名请求头是否用到某个组成部分
+func (p *hmacProvider) uses(component string) bool {
+	return slices.Contains(p.components, component) ||
+		strings.Contains(p.config.SignatureFormat, "{"+component+"}")
+}
+
+// timestamp 按配置格式化时间戳
+func (p *hmacProvider) timestamp(now time.Time) string {
+	switch p.config.TimestampFormat {
+	case "unix_ms":
+		return strconv.FormatInt(now.UnixMilli(), 10)
+	case "rfc3339":
+		return now.UTC().Format(time.RFC3339)
+	default:
+		return strconv.FormatInt(now.Unix(), 10)
+	}
+}
+
+// canonicalQuery 按键和值排序并按 RFC 3986 编码的查询串
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		values := slices.Clone(query[k])
+		sort.Strings(values)
+		for _, v := range values {
+			pairs = append(pairs, escape(k)+"="+escape(v))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// escape RFC 3986 编码，空格编码为 %20
+func escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// requestHost 请求的主机名，优先使用显式设置的 Host
+func requestHost(req *http.Request) string {
+	if req.Host != "" {
+		return req.Host
+	}
+	return req.URL.Host
+}
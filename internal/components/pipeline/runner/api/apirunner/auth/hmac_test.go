@@ -0,0 +1,186 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// signTime 签名向量使用的时间
+var signTime = time.Unix(1700000000, 0)
+
+func TestHMACProviderVectors(t *testing.T) {
+	body := []byte(`{"id":1}`)
+	pipe, colon, empty := "|", ":", ""
+
+	tests := []struct {
+		name   string
+		config HMACConfig
+		method string
+		url    string
+		body   []byte
+		header map[string]string
+		// 期望的请求头
+		want map[string]string
+	}{
+		{
+			name:   "默认配置",
+			config: HMACConfig{Secret: "secret"},
+			method: http.MethodPost,
+			url:    "https://api.example.com/v1/orders?b=2&a=1&a=0&q=hello+world",
+			body:   body,
+			want: map[string]string{
+				"X-Timestamp": "1700000000",
+				"X-Signature": "c4b53c40776700165b2cf44d91b3d78b4fcdca62edad3ab26d79aaa8b9520c87",
+			},
+		},
+		{
+			name: "签名请求头和 base64 编码",
+			config: HMACConfig{
+				KeyID:           "k1",
+				Secret:          "secret",
+				Algorithm:       "SHA1",
+				Encoding:        "base64",
+				Components:      []string{"method", "host", "path", "headers"},
+				Separator:       &pipe,
+				SignedHeaders:   []string{"Host", "X-Request-Id"},
+				SignatureHeader: "Authorization",
+				SignatureFormat: "HMAC {key_id}:{signature}",
+			},
+			method: http.MethodPost,
+			url:    "https://api.example.com/v1/orders",
+			body:   body,
+			header: map[string]string{"X-Request-Id": " r-1 "},
+			want: map[string]string{
+				"Authorization": "HMAC k1:3FqZCLRaYkt1Z/+I2C3B/XnL99w=",
+				"X-Timestamp":   "",
+			},
+		},
+		{
+			name: "rfc3339 时间戳和原始请求体",
+			config: HMACConfig{
+				Secret:          "secret",
+				Algorithm:       "sha512",
+				Components:      []string{"timestamp", "body"},
+				Separator:       &empty,
+				TimestampFormat: "rfc3339",
+				TimestampHeader: "X-Date",
+			},
+			method: http.MethodPut,
+			url:    "http://example.com/",
+			body:   body,
+			want: map[string]string{
+				"X-Date":      "2023-11-14T22:13:20Z",
+				"X-Signature": "ea0e791add065f411c9e870b58fa746c5fe5fe485f009a070c8e1f14671f73f6e9e58f4ad9dee6470ebb2eae1c202e96f8b655e4662bab12cece480eb07c448a",
+			},
+		},
+		{
+			name: "毫秒时间戳和签名格式占位符",
+			config: HMACConfig{
+				KeyID:           "k1",
+				Secret:          "secret",
+				Components:      []string{"key_id", "timestamp"},
+				Separator:       &colon,
+				TimestampFormat: "unix_ms",
+				SignedHeaders:   []string{"Content-Type", "X-A"},
+				SignatureFormat: "{algorithm} {signed_headers} {timestamp} {signature}",
+			},
+			method: http.MethodGet,
+			url:    "http://example.com",
+			want: map[string]string{
+				"X-Timestamp": "1700000000000",
+				"X-Signature": "sha256 content-type;x-a 1700000000000 a318dec804760e0d1c97b905214b65db90785bd6b2d8ff658b4c5682a401c17e",
+			},
+		},
+		{
+			name:   "编码后的路径和空请求体",
+			config: HMACConfig{Secret: "secret"},
+			method: http.MethodGet,
+			url:    "http://example.com/v1/files/a%20b",
+			want: map[string]string{
+				"X-Signature": "8bd31e61dd7c7e34ea31c06b662b3e32d063187b1bc4308adf833568243d97cc",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewProvider(&Config{Type: TypeHMAC, HMAC: &tt.config}, nil)
+			if err != nil {
+				t.Fatalf("NewProvider() error = %v", err)
+			}
+			provider.(*hmacProvider).now = func() time.Time { return signTime }
+
+			req, err := http.NewRequest(tt.method, tt.url, nil)
+			if err != nil {
+				t.Fatalf("NewRequest() error = %v", err)
+			}
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			if err := provider.Apply(context.Background(), req, tt.body); err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			for name, want := range tt.want {
+				if got := req.Header.Get(name); got != want {
+					t.Errorf("header %s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHMACProviderNonce(t *testing.T) {
+	provider, err := newHMACProvider(HMACConfig{Secret: "secret", Components: []string{"nonce", "timestamp"}})
+	if err != nil {
+		t.Fatalf("newHMACProvider() error = %v", err)
+	}
+	provider.now = func() time.Time { return signTime }
+
+	// 每次签名生成新的随机数，签名按请求头中的随机数计算
+	seen := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+		if err := provider.Apply(context.Background(), req, nil); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		nonce := req.Header.Get("X-Nonce")
+		if len(nonce) != 32 || seen[nonce] {
+			t.Fatalf("X-Nonce = %q, want a new 32 hex chars nonce", nonce)
+		}
+		seen[nonce] = true
+
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte(nonce + "\n1700000000"))
+		if got, want := req.Header.Get("X-Signature"), hex.EncodeToString(mac.Sum(nil)); got != want {
+			t.Errorf("X-Signature = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestNewHMACProviderError(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  HMACConfig
+		wantErr string
+	}{
+		{name: "缺少密钥", config: HMACConfig{}, wantErr: "hmac 认证需要配置 secret"},
+		{name: "不支持的算法", config: HMACConfig{Secret: "s", Algorithm: "md5"}, wantErr: "hmac 不支持的签名算法: md5"},
+		{name: "不支持的编码", config: HMACConfig{Secret: "s", Encoding: "base32"}, wantErr: "hmac 不支持的签名编码: base32"},
+		{name: "不支持的时间戳格式", config: HMACConfig{Secret: "s", TimestampFormat: "iso"}, wantErr: "hmac 不支持的时间戳格式: iso"},
+		{name: "不支持的组成部分", config: HMACConfig{Secret: "s", Components: []string{"method", "url"}}, wantErr: "hmac 不支持的签名组成部分: url"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newHMACProvider(tt.config)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("newHMACProvider() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
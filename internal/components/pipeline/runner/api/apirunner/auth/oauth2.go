@@ -0,0 +1,299 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// OAuth2 令牌的默认配置
+const (
+	DefaultRefreshBefore = 60 * time.Second
+	DefaultTokenTTL      = time.Hour
+	TokenKeyPrefix       = "auth:oauth2:"
+)
+
+// TokenStore OAuth2 令牌的缓存存储，多实例部署时使用共享存储避免重复申请令牌
+type TokenStore interface {
+	// Get 获取键值，键不存在时返回 false
+	Get(ctx context.Context, key string) (string, bool, error)
+	// Set 写入键值
+	Set(ctx context.Context, key, value string, ttl time.Duration) error
+	// Release 键值等于 value 时删除
+	Release(ctx context.Context, key, value string) (bool, error)
+}
+
+// oauth2Token 缓存的令牌
+type oauth2Token struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type,omitempty"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Expiry       time.Time `json:"expiry"`
+	RefreshAt    time.Time `json:"refresh_at"`
+}
+
+// fresh 令牌是否无需刷新
+func (t *oauth2Token) fresh(now time.Time) bool {
+	return t != nil && t.AccessToken != "" && now.Before(t.RefreshAt)
+}
+
+// authorization Authorization 请求头的值
+func (t *oauth2Token) authorization() string {
+	tokenType := t.TokenType
+	if tokenType == "" || strings.EqualFold(tokenType, "bearer") {
+		tokenType = "Bearer"
+	}
+	return tokenType + " " + t.AccessToken
+}
+
+// tokenResponse 令牌端点的响应
+type tokenResponse struct {
+	AccessToken      string      `json:"access_token"`
+	TokenType        string      `json:"token_type"`
+	RefreshToken     string      `json:"refresh_token"`
+	ExpiresIn        json.Number `json:"expires_in"`
+	Error            string      `json:"error"`
+	ErrorDescription string      `json:"error_description"`
+}
+
+// oauth2Provider OAuth2 客户端凭证模式，令牌缓存在 TokenStore 中，过期前刷新
+// store 为空时令牌只保存在 provider 中
+type oauth2Provider struct {
+	config OAuth2Config
+	key    string
+	client *http.Client
+	store  TokenStore
+	// 当前时间，测试时替换
+	now func() time.Time
+
+	mu    sync.Mutex
+	token *oauth2Token
+	raw   string
+}
+
+func newOAuth2Provider(config OAuth2Config, store TokenStore) (*oauth2Provider, error) {
+	if config.TokenURL == "" {
+		return nil, fmt.Errorf("oauth2 认证需要配置 token_url")
+	}
+	if _, err := url.ParseRequestURI(config.TokenURL); err != nil {
+		return nil, fmt.Errorf("无效的 token_url %s: %w", config.TokenURL, err)
+	}
+	if config.ClientID == "" {
+		return nil, fmt.Errorf("oauth2 认证需要配置 client_id")
+	}
+	switch config.AuthStyle {
+	case "", "header", "body":
+	default:
+		return nil, fmt.Errorf("oauth2 不支持的 auth_style: %s", config.AuthStyle)
+	}
+
+	// 密钥参与缓存键，轮换密钥后不再使用旧密钥申请的令牌
+	hash := sha256.New()
+	for _, part := range []string{config.TokenURL, config.ClientID, config.ClientSecret, strings.Join(config.Scopes, " "), config.Audience} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+
+	return &oauth2Provider{
+		config: config,
+		key:    TokenKeyPrefix + hex.EncodeToString(hash.Sum(nil))[:32],
+		client: &http.Client{},
+		store:  store,
+		now:    time.Now,
+	}, nil
+}
+
+func (p *oauth2Provider) Apply(ctx context.Context, req *http.Request, body []byte) error {
+	token, err := p.Token(ctx)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", token.authorization())
+	return nil
+}
+
+func (p *oauth2Provider) Redacted() (headers, query []string) {
+	return []string{"Authorization"}, nil
+}
+
+// Token 获取有效的令牌，依次使用本地令牌、缓存的令牌，都需要刷新时向令牌端点申请
+func (p *oauth2Provider) Token(ctx context.Context) (*oauth2Token, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	if p.token.fresh(now) {
+		return p.token, nil
+	}
+
+	if p.store != nil {
+		if raw, ok, err := p.store.Get(ctx, p.key); err != nil {
+			logx.Errorf("读取OAuth2令牌缓存失败: %v", err)
+		} else if ok {
+			var cached oauth2Token
+			if err := json.Unmarshal([]byte(raw), &cached); err == nil {
+				p.token, p.raw = &cached, raw
+				if cached.fresh(now) {
+					return p.token, nil
+				}
+			}
+		}
+	}
+
+	var token *oauth2Token
+	var err error
+	if p.token != nil && p.token.RefreshToken != "" && now.Before(p.token.Expiry) {
+		token, err = p.requestToken(ctx, url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {p.token.RefreshToken},
+		})
+		if err != nil {
+			logx.Infof("刷新OAuth2令牌失败，重新申请: %v", err)
+		}
+	}
+	if token == nil {
+		values := url.Values{"grant_type": {"client_credentials"}}
+		if len(p.config.Scopes) > 0 {
+			values.Set("scope", strings.Join(p.config.Scopes, " "))
+		}
+		if p.config.Audience != "" {
+			values.Set("audience", p.config.Audience)
+		}
+		token, err = p.requestToken(ctx, values)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	raw, err := json.Marshal(token)
+	if err != nil {
+		return nil, fmt.Errorf("序列化OAuth2令牌失败: %w", err)
+	}
+	if p.store != nil {
+		if err := p.store.Set(ctx, p.key, string(raw), token.Expiry.Sub(p.now())); err != nil {
+			logx.Errorf("写入OAuth2令牌缓存失败: %v", err)
+		}
+	}
+	p.token, p.raw = token, string(raw)
+	return token, nil
+}
+
+// Invalidate 丢弃当前令牌，只删除与本地相同的缓存，避免删掉其他实例刚申请的令牌
+func (p *oauth2Provider) Invalidate(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token == nil {
+		return nil
+	}
+	raw := p.raw
+	p.token, p.raw = nil, ""
+	if p.store == nil {
+		return nil
+	}
+	if _, err := p.store.Release(ctx, p.key, raw); err != nil {
+		return fmt.Errorf("删除OAuth2令牌缓存失败: %w", err)
+	}
+	return nil
+}
+
+// requestToken 向令牌端点申请令牌
+func (p *oauth2Provider) requestToken(ctx context.Context, values url.Values) (*oauth2Token, error) {
+	for k, v := range p.config.Params {
+		values.Set(k, v)
+	}
+	if p.config.AuthStyle == "body" {
+		values.Set("client_id", p.config.ClientID)
+		values.Set("client_secret", p.config.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.TokenURL, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("创建OAuth2令牌请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.config.AuthStyle != "body" {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	issuedAt := p.now()
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求OAuth2令牌失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("读取OAuth2令牌响应失败: %w", err)
+	}
+
+	var result tokenResponse
+	if strings.Contains(resp.Header.Get("Content-Type"), "application/x-www-form-urlencoded") ||
+		strings.Contains(resp.Header.Get("Content-Type"), "text/plain") {
+		form, err := url.ParseQuery(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("解析OAuth2令牌响应失败: %w", err)
+		}
+		result = tokenResponse{
+			AccessToken:      form.Get("access_token"),
+			TokenType:        form.Get("token_type"),
+			RefreshToken:     form.Get("refresh_token"),
+			ExpiresIn:        json.Number(form.Get("expires_in")),
+			Error:            form.Get("error"),
+			ErrorDescription: form.Get("error_description"),
+		}
+	} else if err := json.Unmarshal(data, &result); err != nil && resp.StatusCode/100 == 2 {
+		return nil, fmt.Errorf("解析OAuth2令牌响应失败: %w", err)
+	}
+
+	if resp.StatusCode/100 != 2 || result.Error != "" {
+		message := result.Error
+		if result.ErrorDescription != "" {
+			message += ": " + result.ErrorDescription
+		}
+		if message == "" {
+			message = strings.TrimSpace(string(data))
+		}
+		return nil, fmt.Errorf("申请OAuth2令牌失败, 状态码 %d: %s", resp.StatusCode, message)
+	}
+	if result.AccessToken == "" {
+		return nil, fmt.Errorf("OAuth2令牌响应中没有 access_token")
+	}
+
+	lifetime := DefaultTokenTTL
+	if result.ExpiresIn != "" {
+		seconds, err := result.ExpiresIn.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("无效的 expires_in: %s", result.ExpiresIn)
+		}
+		if seconds > 0 {
+			lifetime = time.Duration(seconds) * time.Second
+		}
+	}
+
+	// 有效期较短的令牌在过半时刷新，避免每次请求都重新申请
+	refreshBefore := DefaultRefreshBefore
+	if p.config.RefreshBefore > 0 {
+		refreshBefore = time.Duration(p.config.RefreshBefore) * time.Second
+	}
+	refreshBefore = min(refreshBefore, lifetime/2)
+
+	return &oauth2Token{
+		AccessToken:  result.AccessToken,
+		TokenType:    result.TokenType,
+		RefreshToken: result.RefreshToken,
+		Expiry:       issuedAt.Add(lifetime),
+		RefreshAt:    issuedAt.Add(lifetime - refreshBefore),
+	}, nil
+}
@@ -0,0 +1,359 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"Storage/internal/components/lock"
+)
+
+// tokenServer 模拟 OAuth2 令牌端点，记录每次令牌请求的表单
+type tokenServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []url.Values
+	// 令牌有效期（秒），为 0 时不返回 expires_in
+	expiresIn int
+	// 刷新令牌请求返回的错误
+	refreshErr string
+}
+
+func newTokenServer(t *testing.T, expiresIn int) *tokenServer {
+	s := &tokenServer{expiresIn: expiresIn}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		form := r.PostForm
+		if id, secret, ok := r.BasicAuth(); ok {
+			form.Set("basic", id+":"+secret)
+		}
+
+		s.mu.Lock()
+		s.requests = append(s.requests, form)
+		n := len(s.requests)
+		refreshErr := s.refreshErr
+		s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if form.Get("grant_type") == "refresh_token" && refreshErr != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"error":%q}`, refreshErr)
+			return
+		}
+		expires := ""
+		if s.expiresIn > 0 {
+			expires = fmt.Sprintf(`,"expires_in":%d`, s.expiresIn)
+		}
+		fmt.Fprintf(w, `{"access_token":"a-%d","token_type":"bearer","refresh_token":"r-%d"%s}`, n, n, expires)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// grants 各次令牌请求的 grant_type
+func (s *tokenServer) grants() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	grants := make([]string, len(s.requests))
+	for i, form := range s.requests {
+		grants[i] = form.Get("grant_type")
+	}
+	return grants
+}
+
+func (s *tokenServer) request(i int) url.Values {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[i]
+}
+
+// fakeClock 可手动推进的时钟
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestOAuth2Provider(t *testing.T, config OAuth2Config, store TokenStore, clock *fakeClock) *oauth2Provider {
+	t.Helper()
+	provider, err := NewProvider(&Config{Type: TypeOAuth2, OAuth2: &config}, store)
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	p := provider.(*oauth2Provider)
+	if clock != nil {
+		p.now = clock.Now
+	}
+	return p
+}
+
+// authorize 发送一次请求并返回 Authorization 请求头
+func authorize(t *testing.T, provider Provider) string {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	if err := provider.Apply(context.Background(), req, nil); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	return req.Header.Get("Authorization")
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestOAuth2ProviderRefreshOnExpiry(t *testing.T) {
+	server := newTokenServer(t, 3600)
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	provider := newTestOAuth2Provider(t, OAuth2Config{
+		TokenURL:      server.URL,
+		ClientID:      "client",
+		ClientSecret:  "s&cret",
+		Scopes:        []string{"read", "write"},
+		Audience:      "api",
+		Params:        map[string]string{"resource": "orders"},
+		RefreshBefore: 300,
+	}, lock.NewMemoryStore(), clock)
+
+	if got := authorize(t, provider); got != "Bearer a-1" {
+		t.Fatalf("Authorization = %q, want %q", got, "Bearer a-1")
+	}
+	form := server.request(0)
+	for key, want := range map[string]string{
+		"grant_type": "client_credentials",
+		"scope":      "read write",
+		"audience":   "api",
+		"resource":   "orders",
+		"basic":      "client:s%26cret",
+	} {
+		if got := form.Get(key); got != want {
+			t.Errorf("token request %s = %q, want %q", key, got, want)
+		}
+	}
+
+	// 刷新时间之前复用令牌
+	clock.Advance(3600*time.Second - 301*time.Second)
+	if got := authorize(t, provider); got != "Bearer a-1" {
+		t.Errorf("Authorization before refresh = %q, want %q", got, "Bearer a-1")
+	}
+
+	// 到达刷新时间后使用刷新令牌申请新令牌
+	clock.Advance(2 * time.Second)
+	if got := authorize(t, provider); got != "Bearer a-2" {
+		t.Errorf("Authorization after refresh = %q, want %q", got, "Bearer a-2")
+	}
+	if got := server.request(1).Get("refresh_token"); got != "r-1" {
+		t.Errorf("refresh_token = %q, want %q", got, "r-1")
+	}
+
+	// 令牌过期后刷新令牌也不再使用，重新申请
+	clock.Advance(time.Hour)
+	if got := authorize(t, provider); got != "Bearer a-3" {
+		t.Errorf("Authorization after expiry = %q, want %q", got, "Bearer a-3")
+	}
+
+	want := []string{"client_credentials", "refresh_token", "client_credentials"}
+	if got := server.grants(); !equalStrings(got, want) {
+		t.Errorf("grants = %v, want %v", got, want)
+	}
+}
+
+func TestOAuth2ProviderRefreshFailure(t *testing.T) {
+	server := newTokenServer(t, 120)
+	server.refreshErr = "invalid_grant"
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	provider := newTestOAuth2Provider(t, OAuth2Config{TokenURL: server.URL, ClientID: "client"}, nil, clock)
+
+	authorize(t, provider)
+	// 有效期较短时在过半时刷新，刷新失败时重新申请
+	clock.Advance(61 * time.Second)
+	if got := authorize(t, provider); got != "Bearer a-3" {
+		t.Errorf("Authorization = %q, want %q", got, "Bearer a-3")
+	}
+	want := []string{"client_credentials", "refresh_token", "client_credentials"}
+	if got := server.grants(); !equalStrings(got, want) {
+		t.Errorf("grants = %v, want %v", got, want)
+	}
+}
+
+func TestOAuth2ProviderTokenStore(t *testing.T) {
+	tests := []struct {
+		name string
+		// 第二个 provider 的密钥
+		secret string
+		// 是否共用存储
+		shared       bool
+		wantRequests int
+	}{
+		{name: "共用存储的 provider 共享令牌", secret: "secret", shared: true, wantRequests: 1},
+		{name: "密钥不同时不共享", secret: "rotated", shared: true, wantRequests: 2},
+		{name: "没有存储时各自申请", secret: "secret", wantRequests: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTokenServer(t, 3600)
+			var first, second TokenStore
+			if tt.shared {
+				store := lock.NewMemoryStore()
+				first, second = store, store
+			}
+			a := newTestOAuth2Provider(t, OAuth2Config{TokenURL: server.URL, ClientID: "client", ClientSecret: "secret"}, first, nil)
+			b := newTestOAuth2Provider(t, OAuth2Config{TokenURL: server.URL, ClientID: "client", ClientSecret: tt.secret}, second, nil)
+
+			authorize(t, a)
+			authorize(t, b)
+			if got := len(server.grants()); got != tt.wantRequests {
+				t.Errorf("token requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestOAuth2ProviderInvalidate(t *testing.T) {
+	server := newTokenServer(t, 3600)
+	store := lock.NewMemoryStore()
+	a := newTestOAuth2Provider(t, OAuth2Config{TokenURL: server.URL, ClientID: "client"}, store, nil)
+	b := newTestOAuth2Provider(t, OAuth2Config{TokenURL: server.URL, ClientID: "client"}, store, nil)
+	ctx := context.Background()
+
+	authorize(t, a)
+	if err := a.Invalidate(ctx); err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+	if _, ok, _ := store.Get(ctx, a.key); ok {
+		t.Fatalf("token is still cached after Invalidate")
+	}
+
+	// b 申请新令牌后，a 持有的旧令牌失效不会删除 b 的令牌
+	if got := authorize(t, b); got != "Bearer a-2" {
+		t.Fatalf("Authorization = %q, want %q", got, "Bearer a-2")
+	}
+	a.token, a.raw = &oauth2Token{AccessToken: "a-1"}, `{"access_token":"a-1"}`
+	if err := a.Invalidate(ctx); err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+	if got := authorize(t, a); got != "Bearer a-2" {
+		t.Errorf("Authorization = %q, want %q (cached by b)", got, "Bearer a-2")
+	}
+	if got := len(server.grants()); got != 2 {
+		t.Errorf("token requests = %d, want 2", got)
+	}
+}
+
+func TestOAuth2ProviderBodyAuthStyle(t *testing.T) {
+	server := newTokenServer(t, 0)
+	provider := newTestOAuth2Provider(t, OAuth2Config{TokenURL: server.URL, ClientID: "client", ClientSecret: "secret", AuthStyle: "body"}, nil, nil)
+
+	authorize(t, provider)
+	form := server.request(0)
+	if form.Get("client_id") != "client" || form.Get("client_secret") != "secret" || form.Get("basic") != "" {
+		t.Errorf("token request = %v, want client credentials in body", form)
+	}
+	// 没有 expires_in 时使用默认有效期
+	if got := provider.token.Expiry.Sub(provider.token.RefreshAt); got != DefaultRefreshBefore {
+		t.Errorf("refresh before expiry = %v, want %v", got, DefaultRefreshBefore)
+	}
+}
+
+func TestOAuth2ProviderTokenError(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		wantErr     string
+	}{
+		{name: "错误响应", status: http.StatusUnauthorized, body: `{"error":"invalid_client","error_description":"bad secret"}`, wantErr: "申请OAuth2令牌失败, 状态码 401: invalid_client: bad secret"},
+		{name: "非 JSON 错误响应", status: http.StatusBadGateway, contentType: "text/html", body: " bad gateway ", wantErr: "申请OAuth2令牌失败, 状态码 502: bad gateway"},
+		{name: "成功状态码中的错误", status: http.StatusOK, body: `{"error":"access_denied"}`, wantErr: "申请OAuth2令牌失败, 状态码 200: access_denied"},
+		{name: "表单格式的错误", status: http.StatusOK, contentType: "application/x-www-form-urlencoded", body: "error=invalid_scope", wantErr: "申请OAuth2令牌失败, 状态码 200: invalid_scope"},
+		{name: "没有 access_token", status: http.StatusOK, body: `{"token_type":"bearer"}`, wantErr: "OAuth2令牌响应中没有 access_token"},
+		{name: "无效的 expires_in", status: http.StatusOK, body: `{"access_token":"a","expires_in":1.5}`, wantErr: "无效的 expires_in: 1.5"},
+		{name: "无效的 JSON", status: http.StatusOK, body: `{`, wantErr: "解析OAuth2令牌响应失败: unexpected end of JSON input"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				contentType := tt.contentType
+				if contentType == "" {
+					contentType = "application/json"
+				}
+				w.Header().Set("Content-Type", contentType)
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			provider := newTestOAuth2Provider(t, OAuth2Config{TokenURL: server.URL, ClientID: "client"}, nil, nil)
+			req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+			err := provider.Apply(context.Background(), req, nil)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Apply() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOAuth2ProviderFormResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-www-form-urlencoded")
+		fmt.Fprint(w, "access_token=f-1&token_type=mac&expires_in=60")
+	}))
+	defer server.Close()
+
+	provider := newTestOAuth2Provider(t, OAuth2Config{TokenURL: server.URL, ClientID: "client"}, nil, nil)
+	if got := authorize(t, provider); got != "mac f-1" {
+		t.Errorf("Authorization = %q, want %q", got, "mac f-1")
+	}
+}
+
+func TestNewOAuth2ProviderError(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  OAuth2Config
+		wantErr string
+	}{
+		{name: "缺少 token_url", config: OAuth2Config{ClientID: "c"}, wantErr: "oauth2 认证需要配置 token_url"},
+		{name: "无效的 token_url", config: OAuth2Config{TokenURL: "token", ClientID: "c"}, wantErr: `无效的 token_url token: parse "token": invalid URI for request`},
+		{name: "缺少 client_id", config: OAuth2Config{TokenURL: "http://a/token"}, wantErr: "oauth2 认证需要配置 client_id"},
+		{name: "不支持的 auth_style", config: OAuth2Config{TokenURL: "http://a/token", ClientID: "c", AuthStyle: "query"}, wantErr: "oauth2 不支持的 auth_style: query"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newOAuth2Provider(tt.config, nil)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("newOAuth2Provider() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package cassette
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cassette 场景一次执行使用的磁带，场景内的步骤共用，并发安全
+type Cassette struct {
+	name    string
+	mode    Mode
+	store   Store
+	matcher *matcher
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	// 回放时已使用的请求
+	used []bool
+}
+
+// New 按配置创建磁带，store 为磁带的存储
+func New(config *Config, store Store) (*Cassette, error) {
+	if config == nil {
+		return nil, fmt.Errorf("磁带配置不能为空")
+	}
+	if config.Mode != ModeRecord && config.Mode != ModeReplay {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMode, config.Mode)
+	}
+	if config.Name == "" {
+		return nil, fmt.Errorf("磁带名称不能为空")
+	}
+	if store == nil {
+		return nil, fmt.Errorf("磁带 %s 没有可用的存储", config.Name)
+	}
+	m, err := newMatcher(config.Match)
+	if err != nil {
+		return nil, err
+	}
+	return &Cassette{
+		name:    config.Name,
+		mode:    config.Mode,
+		store:   store,
+		matcher: m,
+	}, nil
+}
+
+// Name 磁带名称
+func (c *Cassette) Name() string {
+	return c.name
+}
+
+// Mode 磁带的工作模式
+func (c *Cassette) Mode() Mode {
+	return c.mode
+}
+
+// Load 开始使用磁带，录制时清空已有内容，回放时加载录制的请求
+func (c *Cassette) Load(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.interactions, c.used = nil, nil
+	if c.mode == ModeRecord {
+		if err := c.store.Reset(ctx, c.name); err != nil {
+			return fmt.Errorf("清空磁带 %s 失败: %w", c.name, err)
+		}
+		return nil
+	}
+
+	interactions, err := c.store.Load(ctx, c.name)
+	if err != nil {
+		return fmt.Errorf("加载磁带 %s 失败: %w", c.name, err)
+	}
+	if len(interactions) == 0 {
+		return fmt.Errorf("磁带 %s 不存在或没有录制的请求", c.name)
+	}
+	c.interactions = interactions
+	c.used = make([]bool, len(interactions))
+	return nil
+}
+
+// Record 录制一次请求和响应，body 为发送的请求体，respBody 为读取的响应体
+// 磁带中不保存凭证：去掉 Authorization 和认证添加的请求头、查询参数，cookie 的值被遮盖
+// authHeaders、authQuery 为认证添加的请求头和查询参数名称（见 auth.Redactor）
+func (c *Cassette) Record(ctx context.Context, req *http.Request, body []byte, resp *http.Response, respBody []byte, authHeaders, authQuery []string) error {
+	url, headers := redactRequest(req, authHeaders, authQuery)
+	interaction := &Interaction{
+		Request: &Request{
+			Method:  req.Method,
+			URL:     url,
+			Headers: headers,
+			Body:    body,
+		},
+		Response: &Response{
+			Status:     resp.StatusCode,
+			StatusText: strings.TrimSpace(strings.TrimPrefix(resp.Status, strconv.Itoa(resp.StatusCode))),
+			Proto:      resp.Proto,
+			Headers:    redactResponse(resp),
+			Body:       respBody,
+		},
+		RecordedAt: time.Now(),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.store.Append(ctx, c.name, len(c.interactions), interaction); err != nil {
+		return fmt.Errorf("写入磁带 %s 失败: %w", c.name, err)
+	}
+	c.interactions = append(c.interactions, interaction)
+	return nil
+}
+
+// Replay 返回与请求匹配的录制响应，不访问网络
+// 多个录制的请求匹配时按录制顺序依次返回，全部使用过后重复返回最后一个，重试和轮询的请求按录制时的顺序回放
+func (c *Cassette) Replay(req *http.Request, body []byte) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last := -1
+	for i, interaction := range c.interactions {
+		if !c.matcher.match(interaction.Request, req, body) {
+			continue
+		}
+		if !c.used[i] {
+			c.used[i] = true
+			return interaction.Response.httpResponse(req), nil
+		}
+		last = i
+	}
+	if last >= 0 {
+		return c.interactions[last].Response.httpResponse(req), nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL.String())
+}
+
+// httpResponse 转换为请求的 HTTP 响应
+func (r *Response) httpResponse(req *http.Request) *http.Response {
+	proto := r.Proto
+	major, minor, ok := http.ParseHTTPVersion(proto)
+	if !ok {
+		proto, major, minor = "HTTP/1.1", 1, 1
+	}
+	statusText := r.StatusText
+	if statusText == "" {
+		statusText = http.StatusText(r.Status)
+	}
+	header := r.Headers.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, statusText),
+		StatusCode:    r.Status,
+		Proto:         proto,
+		ProtoMajor:    major,
+		ProtoMinor:    minor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
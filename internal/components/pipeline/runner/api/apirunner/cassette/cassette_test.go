@@ -0,0 +1,339 @@
+package cassette
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// newRequest 测试用的请求
+func newRequest(method, url, body string, headers map[string]string) *http.Request {
+	req, _ := http.NewRequest(method, url, strings.NewReader(body))
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	return req
+}
+
+// newResponse 测试用的响应
+func newResponse(status int, headers http.Header) *http.Response {
+	if headers == nil {
+		headers = make(http.Header)
+	}
+	return &http.Response{
+		Status:     strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode: status,
+		Proto:      "HTTP/1.1",
+		Header:     headers,
+	}
+}
+
+// newTestCassette 创建并加载磁带
+func newTestCassette(t *testing.T, mode Mode, store Store, match *MatchConfig) *Cassette {
+	t.Helper()
+	c, err := New(&Config{Name: "orders", Mode: mode, Match: match}, store)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := c.Load(context.Background()); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	return c
+}
+
+func TestCassetteRecordRedactsCredentials(t *testing.T) {
+	dir := t.TempDir()
+	c := newTestCassette(t, ModeRecord, NewFileStore(dir), nil)
+
+	req := newRequest(http.MethodPost, "https://api.example.com/orders?SK=k-secret&page=1", `{"id":1}`, map[string]string{
+		"Authorization":       "Bearer t-secret",
+		"Proxy-Authorization": "Basic cHJveHk=",
+		"X-Token":             "x-secret",
+		"Cookie":              "sid=s-1; theme=dark",
+		"Content-Type":        "application/json",
+	})
+	resp := newResponse(http.StatusCreated, http.Header{
+		"Set-Cookie":   {"sid=s-2; Path=/; HttpOnly", "lang=zh"},
+		"Content-Type": {"application/json"},
+	})
+	if err := c.Record(context.Background(), req, []byte(`{"id":1}`), resp, []byte(`{"ok":true}`), []string{"x-token"}, []string{"sk"}); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	// 发送的请求不受影响
+	if req.Header.Get("Authorization") != "Bearer t-secret" || req.URL.Query().Get("SK") != "k-secret" {
+		t.Errorf("Record() modified the sent request: %v %s", req.Header, req.URL)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "orders.json"))
+	if err != nil {
+		t.Fatalf("read cassette file error = %v", err)
+	}
+	for _, secret := range []string{"t-secret", "cHJveHk=", "x-secret", "k-secret", "s-1", "s-2", "dark"} {
+		if bytes.Contains(data, []byte(secret)) {
+			t.Errorf("cassette file contains %q:\n%s", secret, data)
+		}
+	}
+
+	interactions, err := NewFileStore(dir).Load(context.Background(), "orders")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(interactions) != 1 {
+		t.Fatalf("interactions = %d, want 1", len(interactions))
+	}
+	recorded := interactions[0]
+	if want := "https://api.example.com/orders?page=1"; recorded.Request.URL != want {
+		t.Errorf("URL = %q, want %q", recorded.Request.URL, want)
+	}
+	wantHeaders := http.Header{
+		"Cookie":       {"sid=" + Masked + "; theme=" + Masked},
+		"Content-Type": {"application/json"},
+	}
+	if !reflect.DeepEqual(recorded.Request.Headers, wantHeaders) {
+		t.Errorf("request headers = %v, want %v", recorded.Request.Headers, wantHeaders)
+	}
+	wantSetCookie := []string{"sid=" + Masked + "; Path=/; HttpOnly", "lang=" + Masked}
+	if got := recorded.Response.Headers.Values("Set-Cookie"); !reflect.DeepEqual(got, wantSetCookie) {
+		t.Errorf("Set-Cookie = %q, want %q", got, wantSetCookie)
+	}
+	if string(recorded.Request.Body) != `{"id":1}` || string(recorded.Response.Body) != `{"ok":true}` {
+		t.Errorf("bodies = %q, %q", recorded.Request.Body, recorded.Response.Body)
+	}
+}
+
+func TestCassetteRecordKeepsURLWithoutAuthQuery(t *testing.T) {
+	c := newTestCassette(t, ModeRecord, NewFileStore(t.TempDir()), nil)
+	// 没有认证参数时保留原始的查询串
+	req := newRequest(http.MethodGet, "https://api.example.com/search?q=a+b&page=1&q=c", "", nil)
+	if err := c.Record(context.Background(), req, nil, newResponse(http.StatusOK, nil), nil, nil, []string{"api_key"}); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if got, want := c.interactions[0].Request.URL, "https://api.example.com/search?q=a+b&page=1&q=c"; got != want {
+		t.Errorf("URL = %q, want %q", got, want)
+	}
+}
+
+func TestCassetteReplay(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+	recorder := newTestCassette(t, ModeRecord, store, nil)
+	record := func(method, url, body string, status int, respBody string) {
+		t.Helper()
+		resp := newResponse(status, http.Header{"Set-Cookie": {"sid=s-1"}})
+		if err := recorder.Record(context.Background(), newRequest(method, url, body, nil), []byte(body), resp, []byte(respBody), nil, []string{"api_key"}); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+	// 轮询同一个接口，按录制顺序回放
+	record(http.MethodGet, "https://api.example.com/jobs/1?api_key=k-1", "", http.StatusAccepted, "pending")
+	record(http.MethodGet, "https://api.example.com/jobs/1?api_key=k-1", "", http.StatusOK, "done")
+	record(http.MethodPost, "https://api.example.com/jobs", `{"a":1,"b":2}`, http.StatusCreated, "created")
+
+	c := newTestCassette(t, ModeReplay, store, nil)
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		body       string
+		wantStatus string
+		wantBody   string
+		wantErr    error
+	}{
+		{name: "第一次轮询", method: http.MethodGet, url: "http://localhost/jobs/1", wantStatus: "202 Accepted", wantBody: "pending"},
+		{name: "第二次轮询", method: http.MethodGet, url: "http://localhost/jobs/1/", wantStatus: "200 OK", wantBody: "done"},
+		{name: "用完后重复最后一个", method: http.MethodGet, url: "http://localhost/jobs/1", wantStatus: "200 OK", wantBody: "done"},
+		{name: "JSON 请求体忽略键顺序", method: http.MethodPost, url: "http://localhost/jobs", body: "{\"b\": 2,\n \"a\": 1}", wantStatus: "201 Created", wantBody: "created"},
+		{name: "请求体不同", method: http.MethodPost, url: "http://localhost/jobs", body: `{"a":2}`, wantErr: ErrNoInteraction},
+		{name: "查询参数不同", method: http.MethodGet, url: "http://localhost/jobs/1?page=2", wantErr: ErrNoInteraction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newRequest(tt.method, tt.url, tt.body, nil)
+			resp, err := c.Replay(req, []byte(tt.body))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Replay() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Replay() error = %v", err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if resp.Status != tt.wantStatus || string(body) != tt.wantBody {
+				t.Errorf("Replay() = %q %q, want %q %q", resp.Status, body, tt.wantStatus, tt.wantBody)
+			}
+			if resp.Request != req || resp.ProtoMajor != 1 || resp.ProtoMinor != 1 {
+				t.Errorf("Replay() request = %p, proto = %d.%d", resp.Request, resp.ProtoMajor, resp.ProtoMinor)
+			}
+			// 回放时仍设置遮盖值后的 cookie
+			if cookies := resp.Cookies(); len(cookies) != 1 || cookies[0].Value != Masked {
+				t.Errorf("Replay() cookies = %v, want sid=%s", cookies, Masked)
+			}
+		})
+	}
+}
+
+func TestCassetteLoad(t *testing.T) {
+	// 回放空磁带报错
+	c, err := New(&Config{Name: "empty", Mode: ModeReplay}, NewFileStore(t.TempDir()))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := c.Load(context.Background()); err == nil || err.Error() != "磁带 empty 不存在或没有录制的请求" {
+		t.Errorf("Load() error = %v", err)
+	}
+
+	// 重新录制时清空已有内容
+	store := NewFileStore(t.TempDir())
+	for i := 0; i < 2; i++ {
+		recorder := newTestCassette(t, ModeRecord, store, nil)
+		if err := recorder.Record(context.Background(), newRequest(http.MethodGet, "http://localhost/a", "", nil), nil, newResponse(http.StatusOK, nil), nil, nil, nil); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+	interactions, err := store.Load(context.Background(), "orders")
+	if err != nil || len(interactions) != 1 {
+		t.Errorf("Load() = %d interactions, %v, want 1", len(interactions), err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+	tests := []struct {
+		name    string
+		config  *Config
+		store   Store
+		wantErr string
+	}{
+		{name: "配置为空", store: store, wantErr: "磁带配置不能为空"},
+		{name: "模式不支持", config: &Config{Name: "a", Mode: "rewind"}, store: store, wantErr: "不支持的磁带模式: rewind"},
+		{name: "名称为空", config: &Config{Mode: ModeRecord}, store: store, wantErr: "磁带名称不能为空"},
+		{name: "没有存储", config: &Config{Name: "a", Mode: ModeRecord}, wantErr: "磁带 a 没有可用的存储"},
+		{name: "匹配维度不支持", config: &Config{Name: "a", Mode: ModeReplay, Match: &MatchConfig{On: []string{"cookie"}}}, store: store, wantErr: "不支持的匹配维度: cookie"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.config, tt.store)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("New() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatcher(t *testing.T) {
+	recorded := &Request{
+		Method: http.MethodPost,
+		URL:    "https://api.example.com/orders/?b=2&a=1",
+		Headers: http.Header{
+			"Content-Type": {"application/json"},
+			"X-Tenant":     {"t1"},
+			"X-Trace":      {"trace-1"},
+			"Cookie":       {"sid=" + Masked},
+		},
+		Body: []byte(`{"name":"a","items":[1,2]}`),
+	}
+	headers := map[string]string{"Content-Type": "application/json", "X-Tenant": "t1", "X-Trace": "trace-1"}
+
+	tests := []struct {
+		name    string
+		match   *MatchConfig
+		method  string
+		url     string
+		headers map[string]string
+		body    string
+		want    bool
+	}{
+		{name: "默认维度不比较主机", url: "http://localhost:8080/orders?a=1&b=2", body: `{"items":[1,2],"name":"a"}`, want: true},
+		{name: "方法不同", method: http.MethodPut, url: "http://localhost/orders?a=1&b=2", body: `{"name":"a","items":[1,2]}`, want: false},
+		{name: "路径不同", url: "http://localhost/order?a=1&b=2", body: `{"name":"a","items":[1,2]}`, want: false},
+		{name: "查询参数不同", url: "http://localhost/orders?a=1", body: `{"name":"a","items":[1,2]}`, want: false},
+		{name: "数组顺序不同", url: "http://localhost/orders?a=1&b=2", body: `{"name":"a","items":[2,1]}`, want: false},
+		{name: "只比较主机", match: &MatchConfig{On: []string{MatchHost}}, url: "https://API.example.com/other", want: true},
+		{name: "主机不同", match: &MatchConfig{On: []string{MatchHost}}, url: "http://localhost/orders", want: false},
+		{
+			name:    "请求头忽略认证和 cookie",
+			match:   &MatchConfig{On: []string{MatchHeaders}},
+			url:     "http://localhost/",
+			headers: map[string]string{"Content-Type": "application/json", "X-Tenant": "t1", "X-Trace": "trace-1", "Authorization": "Bearer t", "Cookie": "sid=s-1"},
+			want:    true,
+		},
+		{
+			name:    "请求头不同",
+			match:   &MatchConfig{On: []string{MatchHeaders}},
+			url:     "http://localhost/",
+			headers: map[string]string{"Content-Type": "application/json", "X-Tenant": "t2", "X-Trace": "trace-1"},
+			want:    false,
+		},
+		{
+			name:    "缺少请求头",
+			match:   &MatchConfig{On: []string{MatchHeaders}},
+			url:     "http://localhost/",
+			headers: map[string]string{"Content-Type": "application/json", "X-Tenant": "t1"},
+			want:    false,
+		},
+		{
+			name:    "忽略配置的请求头",
+			match:   &MatchConfig{On: []string{MatchHeaders}, IgnoreHeaders: []string{"x-trace"}},
+			url:     "http://localhost/",
+			headers: map[string]string{"Content-Type": "application/json", "X-Tenant": "t1", "X-Trace": "trace-2"},
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := newMatcher(tt.match)
+			if err != nil {
+				t.Fatalf("newMatcher() error = %v", err)
+			}
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			reqHeaders := tt.headers
+			if reqHeaders == nil {
+				reqHeaders = headers
+			}
+			req := newRequest(method, tt.url, tt.body, reqHeaders)
+			if got := m.match(recorded, req, []byte(tt.body)); got != tt.want {
+				t.Errorf("match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		want        string
+	}{
+		{name: "空白请求体", body: " \n", contentType: "application/json", want: ""},
+		{name: "JSON 按键排序", body: `{ "b": 1, "a": {"d": 2, "c": 3} }`, contentType: "application/json; charset=utf-8", want: `{"a":{"c":3,"d":2},"b":1}`},
+		{name: "没有类型时按 JSON 处理", body: `[1, 2]`, want: `[1,2]`},
+		{name: "无效的 JSON 按原始字节", body: `{"a":`, contentType: "application/json", want: `{"a":`},
+		{name: "表单按参数名排序", body: "b=2&a=1&a=0", contentType: "application/x-www-form-urlencoded", want: "a=1&a=0&b=2"},
+		{name: "其他类型按原始字节", body: "<a> 1 </a>", contentType: "application/xml", want: "<a> 1 </a>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(normalizeBody([]byte(tt.body), tt.contentType)); got != tt.want {
+				t.Errorf("normalizeBody() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Mode 磁带的工作模式
+type Mode string
+
+const (
+	// ModeOff 不使用磁带，正常发送请求
+	ModeOff Mode = ""
+	// ModeRecord 正常发送请求，并将请求和响应写入磁带，开始录制时清空已有内容
+	ModeRecord Mode = "record"
+	// ModeReplay 不访问网络，按匹配规则从磁带中返回录制的响应
+	ModeReplay Mode = "replay"
+)
+
+// 匹配请求的维度
+const (
+	MatchMethod  = "method"  // 请求方法
+	MatchHost    = "host"    // 主机和端口
+	MatchPath    = "path"    // 路径
+	MatchQuery   = "query"   // 查询参数，与顺序无关
+	MatchBody    = "body"    // 归一化后的请求体，JSON 忽略键顺序和空白，表单忽略参数顺序
+	MatchHeaders = "headers" // 未忽略的请求头
+)
+
+// 磁带存储的类型
+const (
+	StoreMongo = "mongo"
+	StoreFile  = "file"
+)
+
+// DefaultMatch 未配置匹配维度时使用的维度，不比较主机，切换环境后仍可回放
+var DefaultMatch = []string{MatchMethod, MatchPath, MatchQuery, MatchBody}
+
+// DefaultIgnoreHeaders 比较请求头时总是忽略的请求头，回放时不添加认证信息，认证头也不参与比较
+var DefaultIgnoreHeaders = []string{"Authorization", "Proxy-Authorization", "Content-Length", "User-Agent", "Accept-Encoding", "Cookie", "Date"}
+
+var (
+	// ErrNoInteraction 回放时磁带中没有匹配的请求
+	ErrNoInteraction = errors.New("磁带中没有匹配的请求")
+	// ErrInvalidMode 不支持的磁带模式
+	ErrInvalidMode = errors.New("不支持的磁带模式")
+)
+
+// Config 场景的磁带配置
+type Config struct {
+	// 磁带名称，为空时使用场景ID
+	Name string `bson:"name,omitempty" json:"name,omitempty"`
+	// 工作模式：record、replay，为空时不使用磁带
+	Mode Mode `bson:"mode,omitempty" json:"mode,omitempty"`
+	// 存储类型：mongo、file，默认 mongo
+	Store string `bson:"store,omitempty" json:"store,omitempty"`
+	// 文件存储的目录，磁带保存为目录下的 <name>.json
+	Dir string `bson:"dir,omitempty" json:"dir,omitempty"`
+	// 匹配规则
+	Match *MatchConfig `bson:"match,omitempty" json:"match,omitempty"`
+}
+
+// MatchConfig 回放时请求的匹配规则
+type MatchConfig struct {
+	// 比较的维度，为空时使用 DefaultMatch
+	On []string `bson:"on,omitempty" json:"on,omitempty"`
+	// 比较请求头时额外忽略的请求头，不区分大小写
+	IgnoreHeaders []string `bson:"ignore_headers,omitempty" json:"ignore_headers,omitempty"`
+}
+
+// Interaction 录制的一次请求和响应
+type Interaction struct {
+	Request    *Request  `bson:"request" json:"request"`
+	Response   *Response `bson:"response" json:"response"`
+	RecordedAt time.Time `bson:"recordedAt" json:"recorded_at"`
+}
+
+// Request 录制的请求，不含认证添加的请求头和查询参数，cookie 的值被遮盖
+type Request struct {
+	Method  string      `bson:"method" json:"method"`
+	URL     string      `bson:"url" json:"url"`
+	Headers http.Header `bson:"headers,omitempty" json:"headers,omitempty"`
+	Body    []byte      `bson:"body,omitempty" json:"body,omitempty"`
+}
+
+// Response 录制的响应
+type Response struct {
+	Status     int         `bson:"status" json:"status"`
+	StatusText string      `bson:"statusText,omitempty" json:"status_text,omitempty"`
+	Proto      string      `bson:"proto,omitempty" json:"proto,omitempty"`
+	Headers    http.Header `bson:"headers,omitempty" json:"headers,omitempty"`
+	Body       []byte      `bson:"body,omitempty" json:"body,omitempty"`
+}
+
+// Store 磁带的存储
+type Store interface {
+	// Load 按录制顺序返回磁带的所有请求，磁带不存在时返回空
+	Load(ctx context.Context, name string) ([]*Interaction, error)
+	// Reset 清空磁带
+	Reset(ctx context.Context, name string) error
+	// Append 追加一次请求，index 为请求在磁带中的序号
+	Append(ctx context.Context, name string, index int, interaction *Interaction) error
+}
+
+// CassetteAware 支持录制和回放的执行器
+type CassetteAware interface {
+	// SetCassette 设置执行器使用的磁带，为 nil 时正常发送请求
+	SetCassette(cassette *Cassette)
+}
+
+// StoreAware 按配置创建磁带的管道，由调用方注入 mongo 存储
+type StoreAware interface {
+	// SetCassetteStore 设置磁带的 mongo 存储
+	SetCassetteStore(store Store)
+}
@@ -0,0 +1,96 @@
+package cassette
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// FileStore 将磁带保存为目录下 JSON 文件的存储，适合随代码一起提交的离线用例
+type FileStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// fileCassette 磁带文件的内容
+type fileCassette struct {
+	Name         string         `json:"name"`
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// NewFileStore 创建文件存储，目录不存在时在写入时创建
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+// path 磁带文件的路径，名称中的路径分隔符替换为 _，避免写到目录外
+func (s *FileStore) path(name string) string {
+	name = strings.NewReplacer("/", "_", "\\", "_", "..", "_").Replace(name)
+	return filepath.Join(s.dir, name+".json")
+}
+
+func (s *FileStore) Load(ctx context.Context, name string) ([]*Interaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.read(name)
+}
+
+func (s *FileStore) Reset(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(name, nil)
+}
+
+func (s *FileStore) Append(ctx context.Context, name string, index int, interaction *Interaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	interactions, err := s.read(name)
+	if err != nil {
+		return err
+	}
+	if index < len(interactions) {
+		interactions = interactions[:index]
+	}
+	return s.write(name, append(interactions, interaction))
+}
+
+func (s *FileStore) read(name string) ([]*Interaction, error) {
+	data, err := os.ReadFile(s.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var content fileCassette
+	if err := json.Unmarshal(data, &content); err != nil {
+		return nil, fmt.Errorf("解析磁带文件 %s 失败: %w", s.path(name), err)
+	}
+	return content.Interactions, nil
+}
+
+// write 先写入临时文件再替换，录制中断时不会留下不完整的磁带
+func (s *FileStore) write(name string, interactions []*Interaction) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+	if interactions == nil {
+		interactions = []*Interaction{}
+	}
+	data, err := json.MarshalIndent(fileCassette{Name: name, Interactions: interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := s.path(name)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,114 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	urls "net/url"
+	"strings"
+)
+
+// matcher 按配置的维度比较录制的请求和当前请求
+type matcher struct {
+	on            map[string]bool
+	ignoreHeaders map[string]bool
+}
+
+func newMatcher(config *MatchConfig) (*matcher, error) {
+	on := DefaultMatch
+	var ignore []string
+	if config != nil {
+		if len(config.On) > 0 {
+			on = config.On
+		}
+		ignore = config.IgnoreHeaders
+	}
+
+	m := &matcher{
+		on:            make(map[string]bool, len(on)),
+		ignoreHeaders: make(map[string]bool, len(DefaultIgnoreHeaders)+len(ignore)),
+	}
+	for _, name := range on {
+		switch name {
+		case MatchMethod, MatchHost, MatchPath, MatchQuery, MatchBody, MatchHeaders:
+			m.on[name] = true
+		default:
+			return nil, fmt.Errorf("不支持的匹配维度: %s", name)
+		}
+	}
+	for _, name := range append(append([]string{}, DefaultIgnoreHeaders...), ignore...) {
+		m.ignoreHeaders[http.CanonicalHeaderKey(name)] = true
+	}
+	return m, nil
+}
+
+func (m *matcher) match(recorded *Request, req *http.Request, body []byte) bool {
+	if recorded == nil {
+		return false
+	}
+	if m.on[MatchMethod] && !strings.EqualFold(recorded.Method, req.Method) {
+		return false
+	}
+	u, err := urls.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+	if m.on[MatchHost] && !strings.EqualFold(u.Host, req.URL.Host) {
+		return false
+	}
+	if m.on[MatchPath] && strings.TrimSuffix(u.Path, "/") != strings.TrimSuffix(req.URL.Path, "/") {
+		return false
+	}
+	if m.on[MatchQuery] && u.Query().Encode() != req.URL.Query().Encode() {
+		return false
+	}
+	if m.on[MatchBody] && !bytes.Equal(normalizeBody(recorded.Body, recorded.Headers.Get("Content-Type")), normalizeBody(body, req.Header.Get("Content-Type"))) {
+		return false
+	}
+	if m.on[MatchHeaders] && !m.headersEqual(recorded.Headers, req.Header) {
+		return false
+	}
+	return true
+}
+
+// headersEqual 比较未忽略的请求头，请求头的多个值按顺序比较
+func (m *matcher) headersEqual(a, b http.Header) bool {
+	return m.headersContain(a, b) && m.headersContain(b, a)
+}
+
+func (m *matcher) headersContain(a, b http.Header) bool {
+	for name, values := range a {
+		name = http.CanonicalHeaderKey(name)
+		if m.ignoreHeaders[name] {
+			continue
+		}
+		if strings.Join(values, ", ") != strings.Join(b.Values(name), ", ") {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeBody 归一化请求体，JSON 按键排序并去除空白，表单按参数名排序，其他请求体按原始字节比较
+func normalizeBody(body []byte, contentType string) []byte {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		if values, err := urls.ParseQuery(string(body)); err == nil {
+			return []byte(values.Encode())
+		}
+	case mediaType == "" || strings.Contains(mediaType, "json"):
+		var v interface{}
+		if err := json.Unmarshal(body, &v); err == nil {
+			if normalized, err := json.Marshal(v); err == nil {
+				return normalized
+			}
+		}
+	}
+	return body
+}
@@ -0,0 +1,64 @@
+package cassette
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Masked 录制时遮盖的 cookie 值
+const Masked = "******"
+
+// StrippedHeaders 录制时去掉的请求头，回放时不添加认证信息，这些请求头也不参与比较
+var StrippedHeaders = []string{"Authorization", "Proxy-Authorization"}
+
+// redactRequest 录制的URL和请求头，去掉认证添加的请求头和查询参数，cookie 只保留名称
+// authHeaders、authQuery 为认证添加的请求头和查询参数名称，名称不区分大小写
+func redactRequest(req *http.Request, authHeaders, authQuery []string) (string, http.Header) {
+	headers := req.Header.Clone()
+	for _, name := range append(append([]string{}, StrippedHeaders...), authHeaders...) {
+		headers.Del(name)
+	}
+	if cookies := req.Cookies(); len(cookies) > 0 {
+		masked := make([]string, len(cookies))
+		for i, cookie := range cookies {
+			masked[i] = cookie.Name + "=" + Masked
+		}
+		headers.Set("Cookie", strings.Join(masked, "; "))
+	}
+
+	u := *req.URL
+	if len(authQuery) > 0 && u.RawQuery != "" {
+		query := u.Query()
+		changed := false
+		for name := range query {
+			for _, auth := range authQuery {
+				if strings.EqualFold(name, auth) {
+					query.Del(name)
+					changed = true
+				}
+			}
+		}
+		if changed {
+			u.RawQuery = query.Encode()
+		}
+	}
+	return u.String(), headers
+}
+
+// redactResponse 录制的响应头，Set-Cookie 只保留名称和属性，回放时仍会设置 cookie
+func redactResponse(resp *http.Response) http.Header {
+	headers := resp.Header.Clone()
+	if values := headers.Values("Set-Cookie"); len(values) > 0 {
+		headers.Del("Set-Cookie")
+		for _, value := range values {
+			pair, attrs, _ := strings.Cut(value, ";")
+			name, _, _ := strings.Cut(pair, "=")
+			masked := strings.TrimSpace(name) + "=" + Masked
+			if attrs != "" {
+				masked += ";" + attrs
+			}
+			headers.Add("Set-Cookie", masked)
+		}
+	}
+	return headers
+}
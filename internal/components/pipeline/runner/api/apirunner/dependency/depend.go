@@ -1 +1,39 @@
 package dependency
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Storage/internal/components/retry"
+)
+
+// 获取数据失败时的处理策略
+const (
+	OnFailureFail       = "fail"        // 直接失败
+	OnFailureRetry      = "retry"       // 按配置重试
+	OnFailureUseDefault = "use_default" // 使用默认值
+)
+
+// Policy 转换为重试策略，只有 on_failure=retry 时重试，间隔单位为秒
+func (s FetchStrategy) Policy() retry.Policy {
+	if s.OnFailure != OnFailureRetry || s.MaxRetries <= 0 {
+		return retry.None()
+	}
+	return retry.Constant(s.MaxRetries, time.Duration(s.RetryInterval)*time.Second)
+}
+
+// Fetch 按获取策略获取依赖数据
+// retry 策略下失败时按配置重试，use_default 策略下失败时返回默认值
+func (c *DConfig) Fetch(ctx context.Context, fetch func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	value, err := retry.DoValue(ctx, c.Strategy.Policy(), func(ctx context.Context, attempt int) (interface{}, error) {
+		return fetch(ctx)
+	})
+	if err == nil {
+		return value, nil
+	}
+	if c.Strategy.OnFailure == OnFailureUseDefault && ctx.Err() == nil {
+		return c.DefaultValue, nil
+	}
+	return nil, fmt.Errorf("获取依赖数据失败: %w", err)
+}
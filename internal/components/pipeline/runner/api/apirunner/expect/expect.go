@@ -1,12 +1,22 @@
 package expect
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
+
+	"Storage/internal/components/pipeline/core"
+	"Storage/internal/components/pipeline/runner/api/apirunner/jsonpath"
+	"Storage/internal/components/retry"
 )
 
+// errAssertionFailed 断言未通过，用于驱动重试
+var errAssertionFailed = errors.New("断言未通过")
+
 // AssertAll executes all assertions in the group and returns the results
 func (g *AssertionGroup) AssertAll() *AssertionGroupResult {
 	result := &AssertionGroupResult{
@@ -14,6 +24,8 @@ func (g *AssertionGroup) AssertAll() *AssertionGroupResult {
 		Description: g.Description,
 		Results:     make([]*AssertionResult, 0, len(g.Assertions)),
 		Parallel:    g.Options.Parallel,
+		Passed:      true,
+		Attempts:    1,
 	}
 
 	// 如果启用了并行执行
@@ -36,7 +48,6 @@ func (g *AssertionGroup) AssertAll() *AssertionGroupResult {
 		}
 	} else {
 		// 串行执行
-		result.Passed = true
 		for _, assertion := range g.Assertions {
 			assertResult := assertion.Assert()
 			result.Results = append(result.Results, assertResult)
@@ -54,6 +65,34 @@ func (g *AssertionGroup) AssertAll() *AssertionGroupResult {
 	return result
 }
 
+// AssertWithRetry 按分组的重试配置重新评估断言，直到全部通过或重试次数用尽
+// refresh 在每次重试前调用，用于重新获取实际值（如重新发送请求），为空时只重新评估；
+// 断言未通过通过结果返回，只有 refresh 失败或 ctx 结束时返回错误；
+// 分组的超时预算覆盖所有重试，超时时错误中标注触发的层级
+func (g *AssertionGroup) AssertWithRetry(ctx context.Context, refresh func(ctx context.Context, attempt int) error) (*AssertionGroupResult, error) {
+	ctx, cancel := core.WithTimeout(ctx, core.TimeoutAssertion, time.Duration(g.Options.Timeout)*time.Second)
+	defer cancel()
+
+	var result *AssertionGroupResult
+	err := retry.Do(ctx, g.Options.Retry.Policy(), func(ctx context.Context, attempt int) error {
+		if attempt > 1 && refresh != nil {
+			if err := refresh(ctx, attempt); err != nil {
+				return err
+			}
+		}
+		result = g.AssertAll()
+		result.Attempts = attempt
+		if !result.Passed {
+			return errAssertionFailed
+		}
+		return nil
+	})
+	if err != nil && errors.Is(err, errAssertionFailed) {
+		return result, nil
+	}
+	return result, core.WrapTimeout(ctx, err)
+}
+
 // Assert executes the assertion
 func (a *Assertion) Assert() *AssertionResult {
 	result := &AssertionResult{
@@ -241,27 +280,9 @@ func compareValues(actual, expected interface{}, tolerance float64) int {
 	return 0
 }
 
-// hasField checks if the specified field exists in the actual value
+// hasField checks if the specified JSONPath exists in the actual value
 func hasField(actual interface{}, fieldPath string) bool {
-	actualValue := reflect.ValueOf(actual)
-
-	// 处理指针
-	if actualValue.Kind() == reflect.Ptr {
-		actualValue = actualValue.Elem()
-	}
-
-	// 处理结构体
-	if actualValue.Kind() == reflect.Struct {
-		_, found := actualValue.Type().FieldByName(fieldPath)
-		return found
-	}
-
-	// 处理 map
-	if actualValue.Kind() == reflect.Map {
-		return actualValue.MapIndex(reflect.ValueOf(fieldPath)).IsValid()
-	}
-
-	return false
+	return jsonpath.Exists(actual, fieldPath)
 }
 
 // getLength returns the length of a value if it supports length operations
@@ -1,6 +1,11 @@
 package expect
 
-import "Storage/internal/logic/workflows/api/apirunner/dependency"
+import (
+	"time"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner/dependency"
+	"Storage/internal/components/retry"
+)
 
 // AssertionType defines the type of assertion to perform
 type AssertionType string
@@ -131,6 +136,8 @@ type AssertionGroupResult struct {
 	Passed      bool               `json:"passed"`
 	Results     []*AssertionResult `json:"results"`
 	Parallel    bool               `json:"parallel,omitempty"`
+	// 评估次数，配置了重试时大于 1 表示发生过重试
+	Attempts int `json:"attempts,omitempty"`
 }
 
 // AssertionGroup represents a group of related assertions
@@ -186,6 +193,32 @@ type RetryConfig struct {
 		Min int `json:"min"`
 		Max int `json:"max"`
 	} `json:"random_range,omitempty"`
+
+	// 抖动比例，取值 0~1
+	// 实际间隔在 ±Jitter 范围内随机浮动，避免集中重试
+	Jitter float64 `json:"jitter,omitempty"`
+}
+
+// Policy 转换为重试策略，间隔单位为毫秒
+func (c *RetryConfig) Policy() retry.Policy {
+	if c == nil || c.MaxRetries <= 0 {
+		return retry.None()
+	}
+	policy := retry.Policy{
+		MaxRetries:  c.MaxRetries,
+		Strategy:    retry.Strategy(c.Strategy),
+		Interval:    time.Duration(c.Interval) * time.Millisecond,
+		MaxInterval: time.Duration(c.MaxInterval) * time.Millisecond,
+		Jitter:      c.Jitter,
+	}
+	if policy.Strategy == "" {
+		policy.Strategy = retry.StrategyConstant
+	}
+	if c.RandomRange != nil {
+		policy.RandomMin = time.Duration(c.RandomRange.Min) * time.Millisecond
+		policy.RandomMax = time.Duration(c.RandomRange.Max) * time.Millisecond
+	}
+	return policy
 }
 
 // NewAssertion creates a new Assertion with the given parameters
@@ -1,10 +1,41 @@
 package extract
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"strconv"
-	"strings"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner/jsonpath"
+)
+
+// SourceType 提取来源
+type SourceType string
+
+const (
+	// SourceJSONPath 按 JSONPath 从 JSON 响应体中提取
+	SourceJSONPath SourceType = "jsonpath"
+	// SourceHeader 响应头，名称不区分大小写，多个值以 ", " 连接
+	SourceHeader SourceType = "header"
+	// SourceCookie 响应 Set-Cookie 中的 cookie 值
+	SourceCookie SourceType = "cookie"
+	// SourceStatus 响应状态码
+	SourceStatus SourceType = "status"
+	// SourceDuration 响应耗时（毫秒）
+	SourceDuration SourceType = "duration"
+	// SourceRegex 正则表达式在原始响应体上的捕获组
+	SourceRegex SourceType = "regex"
+	// SourceXPath 按 XPath 从 XML/HTML 响应体中提取
+	SourceXPath SourceType = "xpath"
+)
+
+// 提取值的类型转换
+const (
+	ValueTypeString = "string"
+	ValueTypeInt    = "int"
+	ValueTypeFloat  = "float"
+	ValueTypeBool   = "bool"
+	ValueTypeJSON   = "json"
 )
 
 type Extractor struct {
@@ -12,6 +43,22 @@ type Extractor struct {
 	Data     map[string]interface{} `json:"data"`
 	JsonPath string                 `json:"json_path"`
 	Target   TargetValue            `json:"target"`
+
+	// 提取来源，为空时按 JsonPath 从 Data（即整个响应）中提取
+	Source SourceType `json:"source,omitempty"`
+
+	// 来源中的表达式：header/cookie 为名称，regex 为正则，xpath 为 XPath，
+	// jsonpath 为响应体中的 JSONPath（为空时使用 JsonPath）
+	Expression string `json:"expression,omitempty"`
+
+	// 正则的捕获组，为空时有捕获组取第 1 组，否则取整个匹配
+	Group *int `json:"group,omitempty"`
+
+	// 提取不到值时使用的默认值，未配置时报错
+	Default interface{} `json:"default,omitempty"`
+
+	// 类型转换：string、int、float、bool、json，为空时保留原始类型
+	ValueType string `json:"value_type,omitempty"`
 }
 
 type TargetValue struct {
@@ -19,34 +66,39 @@ type TargetValue struct {
 	Value interface{} `json:"value"`
 }
 
+// UnmarshalJSON 兼容 JSONPath 字符串和提取规则对象两种写法
+func (e *Extractor) UnmarshalJSON(data []byte) error {
+	var path string
+	if err := json.Unmarshal(data, &path); err == nil {
+		*e = Extractor{JsonPath: path}
+		return nil
+	}
+
+	type rawExtractor Extractor
+	var raw rawExtractor
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&raw); err != nil {
+		return err
+	}
+	*e = Extractor(raw)
+	return nil
+}
+
+// Extract 按来源从 Data 中提取值，提取失败且配置了默认值时使用默认值，最后按 ValueType 转换类型
+// JSONPath 的语法见 jsonpath.Compile，兼容 $.data.0.name 的写法
 func (e *Extractor) Extract() (TargetValue, error) {
-	// 解析e.jsonpath, 格式类似 $.data.name, 需要兼容$.data.0.name的场景
-	jsonPath := strings.Split(e.JsonPath, ".")
-	var result interface{}
-	result = e.Data
-	for _, p := range jsonPath[1:] {
-		switch v := result.(type) {
-		case map[string]interface{}:
-			// 如果当前是 map，不应该出现数字索引
-			if _, err := strconv.Atoi(p); err == nil {
-				return TargetValue{}, fmt.Errorf("在 map 中不能使用数字索引: %s", e.JsonPath)
-			}
-			result = v[p]
-		case []interface{}:
-			i, err := strconv.Atoi(p)
-			if err != nil {
-				return TargetValue{}, fmt.Errorf("数组索引必须是数字: %s", e.JsonPath)
-			}
-			if i < 0 || i >= len(v) {
-				return TargetValue{}, fmt.Errorf("数组索引越界: %s", e.JsonPath)
-			}
-			result = v[i]
-		default:
-			return TargetValue{}, fmt.Errorf("invalid json path: %s", e.JsonPath)
+	result, err := e.extract()
+	if err != nil {
+		if e.Default == nil {
+			return TargetValue{}, err
 		}
+		result = e.Default
 	}
-	if result == nil {
-		return TargetValue{}, fmt.Errorf("invalid json path: %s", e.JsonPath)
+
+	result, err = convertValue(result, e.ValueType)
+	if err != nil {
+		return TargetValue{}, err
 	}
 	return TargetValue{
 		Type:  fmt.Sprintf("%T", result),
@@ -55,6 +107,37 @@ func (e *Extractor) Extract() (TargetValue, error) {
 
 }
 
+// extract 按来源提取原始值
+func (e *Extractor) extract() (interface{}, error) {
+	switch e.Source {
+	case "":
+		result, err := jsonpath.Lookup(e.Data, e.JsonPath)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			return nil, fmt.Errorf("invalid json path: %s", e.JsonPath)
+		}
+		return result, nil
+	case SourceJSONPath:
+		return e.fromJSONBody()
+	case SourceHeader:
+		return e.fromHeader()
+	case SourceCookie:
+		return e.fromCookie()
+	case SourceStatus:
+		return e.fromStatus()
+	case SourceDuration:
+		return e.fromDuration()
+	case SourceRegex:
+		return e.fromRegex()
+	case SourceXPath:
+		return e.fromXPath()
+	default:
+		return nil, fmt.Errorf("不支持的提取来源: %s", e.Source)
+	}
+}
+
 func getTargetValue[T any](value interface{}) (T, error) {
 	switch v := value.(type) {
 	case string:
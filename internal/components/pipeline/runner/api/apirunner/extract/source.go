@@ -0,0 +1,308 @@
+package extract
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner/jsonpath"
+)
+
+// 响应中各部分的键，与 HttpRunner 的响应结构一致
+const (
+	responseBody       = "body"
+	responseJSON       = "json"
+	responseHeaders    = "headers"
+	responseCookies    = "cookies"
+	responseStatusCode = "status_code"
+	responseDuration   = "duration"
+)
+
+// fromJSONBody 按 JSONPath 从 JSON 响应体中提取，响应未解析 JSON 时按 JSON 解析原始响应体
+func (e *Extractor) fromJSONBody() (interface{}, error) {
+	path := e.Expression
+	if path == "" {
+		path = e.JsonPath
+	}
+	if path == "" {
+		return nil, fmt.Errorf("jsonpath 提取需要配置表达式")
+	}
+
+	body, ok := e.Data[responseJSON]
+	if !ok {
+		raw, err := e.body()
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(raw), &body); err != nil {
+			return nil, fmt.Errorf("响应体不是有效的JSON: %w", err)
+		}
+	}
+	return jsonpath.Lookup(body, path)
+}
+
+// fromHeader 提取响应头
+func (e *Extractor) fromHeader() (interface{}, error) {
+	if e.Expression == "" {
+		return nil, fmt.Errorf("header 提取需要配置响应头名称")
+	}
+	value, ok := lookupFold(e.Data[responseHeaders], e.Expression)
+	if !ok {
+		return nil, fmt.Errorf("响应头 %s 不存在", e.Expression)
+	}
+	return value, nil
+}
+
+// fromCookie 提取响应设置的 cookie，没有解析好的 cookie 时从 Set-Cookie 响应头解析
+func (e *Extractor) fromCookie() (interface{}, error) {
+	if e.Expression == "" {
+		return nil, fmt.Errorf("cookie 提取需要配置 cookie 名称")
+	}
+	if value, ok := lookupExact(e.Data[responseCookies], e.Expression); ok {
+		return value, nil
+	}
+	if header, ok := lookupFold(e.Data[responseHeaders], "Set-Cookie"); ok {
+		for _, line := range strings.Split(fmt.Sprint(header), "\n") {
+			cookie, err := http.ParseSetCookie(strings.TrimSpace(line))
+			if err == nil && cookie.Name == e.Expression {
+				return cookie.Value, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("cookie %s 不存在", e.Expression)
+}
+
+// fromStatus 提取响应状态码
+func (e *Extractor) fromStatus() (interface{}, error) {
+	status, ok := e.Data[responseStatusCode]
+	if !ok {
+		return nil, fmt.Errorf("响应中没有状态码")
+	}
+	return status, nil
+}
+
+// fromDuration 提取响应耗时，响应中的耗时单位为秒，提取结果为毫秒
+func (e *Extractor) fromDuration() (interface{}, error) {
+	seconds, ok := toFloat(e.Data[responseDuration])
+	if !ok {
+		return nil, fmt.Errorf("响应中没有耗时")
+	}
+	return int64(math.Round(seconds * 1000)), nil
+}
+
+// fromRegex 提取正则在原始响应体上第一个匹配的捕获组
+func (e *Extractor) fromRegex() (interface{}, error) {
+	if e.Expression == "" {
+		return nil, fmt.Errorf("regex 提取需要配置正则表达式")
+	}
+	re, err := regexp.Compile(e.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("无效的正则表达式 %s: %w", e.Expression, err)
+	}
+
+	group := 0
+	if e.Group != nil {
+		group = *e.Group
+	} else if re.NumSubexp() > 0 {
+		group = 1
+	}
+	if group < 0 || group > re.NumSubexp() {
+		return nil, fmt.Errorf("正则表达式 %s 没有第 %d 个捕获组", e.Expression, group)
+	}
+
+	body, err := e.body()
+	if err != nil {
+		return nil, err
+	}
+	match := re.FindStringSubmatch(body)
+	if match == nil {
+		return nil, fmt.Errorf("正则表达式 %s 没有匹配", e.Expression)
+	}
+	return match[group], nil
+}
+
+// fromXPath 按 XPath 从 XML/HTML 响应体中提取第一个匹配节点的文本
+func (e *Extractor) fromXPath() (interface{}, error) {
+	if e.Expression == "" {
+		return nil, fmt.Errorf("xpath 提取需要配置 XPath 表达式")
+	}
+	body, err := e.body()
+	if err != nil {
+		return nil, err
+	}
+
+	contentType, _ := lookupFold(e.Data[responseHeaders], "Content-Type")
+	var doc *xnode
+	if isHTML(fmt.Sprint(contentType), body) {
+		doc, err = parseHTML(body)
+	} else {
+		doc, err = parseXML(body)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := selectXPath(doc, e.Expression)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("XPath %s 没有匹配", e.Expression)
+	}
+	return nodes[0].text(), nil
+}
+
+// body 原始响应体
+func (e *Extractor) body() (string, error) {
+	switch body := e.Data[responseBody].(type) {
+	case string:
+		return body, nil
+	case []byte:
+		return string(body), nil
+	default:
+		return "", fmt.Errorf("响应中没有响应体")
+	}
+}
+
+// lookupFold 按名称不区分大小写地从响应头中取值
+func lookupFold(values interface{}, name string) (interface{}, bool) {
+	switch v := values.(type) {
+	case http.Header:
+		items := v.Values(name)
+		if len(items) == 0 {
+			return nil, false
+		}
+		return strings.Join(items, "\n"), true
+	case map[string]string:
+		for k, item := range v {
+			if strings.EqualFold(k, name) {
+				return item, true
+			}
+		}
+	case map[string]interface{}:
+		for k, item := range v {
+			if strings.EqualFold(k, name) {
+				return item, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// lookupExact 按名称从 cookie 中取值
+func lookupExact(values interface{}, name string) (interface{}, bool) {
+	switch v := values.(type) {
+	case map[string]string:
+		item, ok := v[name]
+		return item, ok
+	case map[string]interface{}:
+		item, ok := v[name]
+		return item, ok
+	}
+	return nil, false
+}
+
+// convertValue 按 ValueType 转换提取到的值
+func convertValue(value interface{}, valueType string) (interface{}, error) {
+	switch valueType {
+	case "":
+		return value, nil
+
+	case ValueTypeString:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case nil:
+			return "", nil
+		}
+		if n, ok := toFloat(value); ok {
+			return strconv.FormatFloat(n, 'f', -1, 64), nil
+		}
+		if kind := reflect.ValueOf(value).Kind(); kind == reflect.Map || kind == reflect.Slice {
+			data, err := json.Marshal(value)
+			if err != nil {
+				return nil, fmt.Errorf("无法将 %v 转换为 string: %w", value, err)
+			}
+			return string(data), nil
+		}
+		return fmt.Sprint(value), nil
+
+	case ValueTypeInt:
+		if s, ok := value.(string); ok {
+			s = strings.TrimSpace(s)
+			if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return i, nil
+			}
+			value = s
+		}
+		n, ok := toFloat(value)
+		if !ok || n != math.Trunc(n) {
+			return nil, fmt.Errorf("无法将 %v 转换为 int", value)
+		}
+		return int64(n), nil
+
+	case ValueTypeFloat:
+		n, ok := toFloat(value)
+		if !ok {
+			return nil, fmt.Errorf("无法将 %v 转换为 float", value)
+		}
+		return n, nil
+
+	case ValueTypeBool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return nil, fmt.Errorf("无法将 %v 转换为 bool", value)
+			}
+			return b, nil
+		}
+		if n, ok := toFloat(value); ok {
+			return n != 0, nil
+		}
+		return nil, fmt.Errorf("无法将 %v 转换为 bool", value)
+
+	case ValueTypeJSON:
+		s, ok := value.(string)
+		if !ok {
+			return value, nil
+		}
+		var result interface{}
+		if err := json.Unmarshal([]byte(s), &result); err != nil {
+			return nil, fmt.Errorf("无法将 %v 转换为 json: %w", value, err)
+		}
+		return result, nil
+
+	default:
+		return nil, fmt.Errorf("不支持的类型转换: %s", valueType)
+	}
+}
+
+// toFloat 将数值或数字字符串转换为 float64
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return n, err == nil
+	case json.Number:
+		n, err := v.Float64()
+		return n, err == nil
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
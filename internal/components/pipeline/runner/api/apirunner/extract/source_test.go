@@ -0,0 +1,178 @@
+package extract
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func testResponse() map[string]interface{} {
+	return map[string]interface{}{
+		responseBody: `{"code":0,"data":{"token":"t-1","expires":"3600","items":[{"id":1},{"id":2}]}}`,
+		responseHeaders: map[string]string{
+			"Content-Type": "application/json",
+			"X-Request-Id": "r-1",
+			"Set-Cookie":   "sid=abc; Path=/; HttpOnly\nlang=zh",
+		},
+		responseCookies:    map[string]string{"theme": "dark"},
+		responseStatusCode: 200,
+		responseDuration:   0.1234,
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestExtractorExtract(t *testing.T) {
+	tests := []struct {
+		name      string
+		extractor Extractor
+		// 替换响应中的部分
+		data map[string]interface{}
+		want interface{}
+	}{
+		{name: "整个响应的 JSONPath", extractor: Extractor{JsonPath: "$.status_code"}, want: 200},
+		{name: "响应体 JSONPath", extractor: Extractor{Source: SourceJSONPath, Expression: "$.data.token"}, want: "t-1"},
+		{name: "响应体 JSONPath 使用 json_path", extractor: Extractor{Source: SourceJSONPath, JsonPath: "$.data.items[1].id"}, want: float64(2)},
+		{
+			name:      "优先使用已解析的 JSON",
+			extractor: Extractor{Source: SourceJSONPath, Expression: "$.data.token"},
+			data:      map[string]interface{}{responseJSON: map[string]interface{}{"data": map[string]interface{}{"token": "parsed"}}},
+			want:      "parsed",
+		},
+		{name: "响应头不区分大小写", extractor: Extractor{Source: SourceHeader, Expression: "x-request-id"}, want: "r-1"},
+		{
+			name:      "http.Header 的多个值",
+			extractor: Extractor{Source: SourceHeader, Expression: "x-tag"},
+			data:      map[string]interface{}{responseHeaders: http.Header{"X-Tag": {"a", "b"}}},
+			want:      "a\nb",
+		},
+		{name: "已解析的 cookie", extractor: Extractor{Source: SourceCookie, Expression: "theme"}, want: "dark"},
+		{name: "从 Set-Cookie 解析 cookie", extractor: Extractor{Source: SourceCookie, Expression: "lang"}, want: "zh"},
+		{name: "Set-Cookie 带属性", extractor: Extractor{Source: SourceCookie, Expression: "sid"}, want: "abc"},
+		{name: "状态码", extractor: Extractor{Source: SourceStatus}, want: 200},
+		{name: "耗时转为毫秒", extractor: Extractor{Source: SourceDuration}, want: int64(123)},
+
+		{name: "正则默认取第 1 个捕获组", extractor: Extractor{Source: SourceRegex, Expression: `"token":"([^"]+)"`}, want: "t-1"},
+		{name: "正则没有捕获组取整个匹配", extractor: Extractor{Source: SourceRegex, Expression: `t-\d`}, want: "t-1"},
+		{name: "正则指定第 0 组", extractor: Extractor{Source: SourceRegex, Expression: `"token":"([^"]+)"`, Group: intPtr(0)}, want: `"token":"t-1"`},
+		{name: "正则指定捕获组", extractor: Extractor{Source: SourceRegex, Expression: `(?P<prefix>\w+)-(?P<n>\d)`, Group: intPtr(2)}, want: "1"},
+		{name: "正则只取第一个匹配", extractor: Extractor{Source: SourceRegex, Expression: `"id":(\d+)`}, want: "1"},
+		{name: "正则未参与匹配的捕获组为空", extractor: Extractor{Source: SourceRegex, Expression: `"code":(x)?0`}, want: ""},
+
+		{
+			name:      "XPath 提取 XML",
+			extractor: Extractor{Source: SourceXPath, Expression: "//book[@id='2']/title"},
+			data:      map[string]interface{}{responseBody: testXML, responseHeaders: map[string]string{"Content-Type": "application/xml"}},
+			want:      "Rust",
+		},
+		{
+			name:      "XPath 按 Content-Type 解析 HTML",
+			extractor: Extractor{Source: SourceXPath, Expression: "//a[1]/@href"},
+			data:      map[string]interface{}{responseBody: `<div><a href="/x">x</a><br></div>`, responseHeaders: map[string]string{"content-type": "text/html"}},
+			want:      "/x",
+		},
+		{
+			name:      "XPath 响应体为字节",
+			extractor: Extractor{Source: SourceXPath, Expression: "/a/@v"},
+			data:      map[string]interface{}{responseBody: []byte(`<a v="1"/>`)},
+			want:      "1",
+		},
+
+		{name: "转换为 int", extractor: Extractor{Source: SourceJSONPath, Expression: "$.data.expires", ValueType: ValueTypeInt}, want: int64(3600)},
+		{name: "转换为 string", extractor: Extractor{Source: SourceStatus, ValueType: ValueTypeString}, want: "200"},
+		{name: "转换为 bool", extractor: Extractor{Source: SourceJSONPath, Expression: "$.code", ValueType: ValueTypeBool}, want: false},
+		{name: "对象转换为 JSON 字符串", extractor: Extractor{Source: SourceJSONPath, Expression: "$.data.items[0]", ValueType: ValueTypeString}, want: `{"id":1}`},
+		{name: "提取失败使用默认值", extractor: Extractor{Source: SourceHeader, Expression: "X-Missing", Default: "none"}, want: "none"},
+		{name: "默认值也按类型转换", extractor: Extractor{Source: SourceRegex, Expression: `nothing`, Default: "5", ValueType: ValueTypeFloat}, want: float64(5)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := tt.extractor
+			e.Data = testResponse()
+			for k, v := range tt.data {
+				e.Data[k] = v
+			}
+			got, err := e.Extract()
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Value, tt.want) {
+				t.Errorf("Extract() = %#v, want %#v", got.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractorExtractError(t *testing.T) {
+	tests := []struct {
+		name      string
+		extractor Extractor
+		// 替换响应中的部分，值为 nil 时删除
+		data    map[string]interface{}
+		wantErr string
+	}{
+		{name: "不支持的来源", extractor: Extractor{Source: "body"}, wantErr: "不支持的提取来源: body"},
+		{name: "JSONPath 没有表达式", extractor: Extractor{Source: SourceJSONPath}, wantErr: "jsonpath 提取需要配置表达式"},
+		{
+			name:      "响应体不是 JSON",
+			extractor: Extractor{Source: SourceJSONPath, Expression: "$.a"},
+			data:      map[string]interface{}{responseBody: "<a/>"},
+			wantErr:   "响应体不是有效的JSON: invalid character '<' looking for beginning of value",
+		},
+		{name: "响应头不存在", extractor: Extractor{Source: SourceHeader, Expression: "X-Trace"}, wantErr: "响应头 X-Trace 不存在"},
+		{name: "cookie 不存在", extractor: Extractor{Source: SourceCookie, Expression: "token"}, wantErr: "cookie token 不存在"},
+		{name: "没有状态码", extractor: Extractor{Source: SourceStatus}, data: map[string]interface{}{responseStatusCode: nil}, wantErr: "响应中没有状态码"},
+		{name: "没有耗时", extractor: Extractor{Source: SourceDuration}, data: map[string]interface{}{responseDuration: "slow"}, wantErr: "响应中没有耗时"},
+
+		{name: "正则没有表达式", extractor: Extractor{Source: SourceRegex}, wantErr: "regex 提取需要配置正则表达式"},
+		{name: "无效的正则", extractor: Extractor{Source: SourceRegex, Expression: `(`}, wantErr: "无效的正则表达式 (: error parsing regexp: missing closing ): `(`"},
+		{name: "捕获组超出范围", extractor: Extractor{Source: SourceRegex, Expression: `(a)(b)`, Group: intPtr(3)}, wantErr: "正则表达式 (a)(b) 没有第 3 个捕获组"},
+		{name: "捕获组为负数", extractor: Extractor{Source: SourceRegex, Expression: `a`, Group: intPtr(-1)}, wantErr: "正则表达式 a 没有第 -1 个捕获组"},
+		{name: "正则没有匹配", extractor: Extractor{Source: SourceRegex, Expression: `x{3}`}, wantErr: "正则表达式 x{3} 没有匹配"},
+		{name: "没有响应体", extractor: Extractor{Source: SourceRegex, Expression: `a`}, data: map[string]interface{}{responseBody: nil}, wantErr: "响应中没有响应体"},
+
+		{name: "XPath 没有表达式", extractor: Extractor{Source: SourceXPath}, wantErr: "xpath 提取需要配置 XPath 表达式"},
+		{
+			name:      "XPath 语法错误",
+			extractor: Extractor{Source: SourceXPath, Expression: "//a["},
+			data:      map[string]interface{}{responseBody: "<a/>"},
+			wantErr:   "XPath 语法错误: 谓词不完整, 位置 4, 表达式: //a[",
+		},
+		{
+			name:      "XPath 没有匹配",
+			extractor: Extractor{Source: SourceXPath, Expression: "//b"},
+			data:      map[string]interface{}{responseBody: "<a/>"},
+			wantErr:   "XPath //b 没有匹配",
+		},
+		{
+			name:      "XML 无法解析",
+			extractor: Extractor{Source: SourceXPath, Expression: "//a"},
+			data:      map[string]interface{}{responseBody: "<a><!-- x"},
+			wantErr:   "解析XML响应体失败: XML syntax error on line 1: unexpected EOF",
+		},
+
+		{name: "类型转换失败", extractor: Extractor{Source: SourceJSONPath, Expression: "$.data.token", ValueType: ValueTypeInt}, wantErr: "无法将 t-1 转换为 int"},
+		{name: "不支持的类型转换", extractor: Extractor{Source: SourceStatus, ValueType: "date"}, wantErr: "不支持的类型转换: date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := tt.extractor
+			e.Data = testResponse()
+			for k, v := range tt.data {
+				if v == nil {
+					delete(e.Data, k)
+					continue
+				}
+				e.Data[k] = v
+			}
+			_, err := e.Extract()
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Extract() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
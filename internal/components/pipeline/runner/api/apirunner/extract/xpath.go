@@ -0,0 +1,691 @@
+package extract
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// xnodeKind XML/HTML 节点类型
+type xnodeKind int
+
+const (
+	xnodeDocument xnodeKind = iota
+	xnodeElement
+	xnodeText
+	xnodeAttr
+)
+
+// xnode XPath 求值使用的节点，XML 和 HTML 解析后统一为该结构，忽略命名空间
+type xnode struct {
+	kind     xnodeKind
+	name     string
+	value    string
+	attrs    []*xnode
+	children []*xnode
+	parent   *xnode
+}
+
+// text 节点的字符串值：元素和文档为所有后代文本的拼接，文本和属性为其值
+func (n *xnode) text() string {
+	if n.kind == xnodeText || n.kind == xnodeAttr {
+		return n.value
+	}
+	var sb strings.Builder
+	for _, c := range n.children {
+		sb.WriteString(c.text())
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// appendChild 添加子节点
+func (n *xnode) appendChild(child *xnode) {
+	child.parent = n
+	n.children = append(n.children, child)
+}
+
+// isHTML 按 Content-Type 或内容判断响应体是否为 HTML
+func isHTML(contentType, body string) bool {
+	if strings.Contains(strings.ToLower(contentType), "html") {
+		return true
+	}
+	head := strings.ToLower(strings.TrimSpace(body))
+	if len(head) > 64 {
+		head = head[:64]
+	}
+	for _, prefix := range []string{"<!doctype html", "<html"} {
+		// 标签名需要完整匹配，避免把 <html-report> 等 XML 元素当作 HTML
+		if rest, ok := strings.CutPrefix(head, prefix); ok && (rest == "" || strings.ContainsRune(" \t\r\n>/", rune(rest[0]))) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseXML 解析 XML 响应体
+func parseXML(body string) (*xnode, error) {
+	decoder := xml.NewDecoder(strings.NewReader(body))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+
+	root := &xnode{kind: xnodeDocument}
+	current := root
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("解析XML响应体失败: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &xnode{kind: xnodeElement, name: t.Name.Local}
+			for _, attr := range t.Attr {
+				node.attrs = append(node.attrs, &xnode{kind: xnodeAttr, name: attr.Name.Local, value: attr.Value, parent: node})
+			}
+			current.appendChild(node)
+			current = node
+		case xml.EndElement:
+			if current.parent != nil {
+				current = current.parent
+			}
+		case xml.CharData:
+			if text := string(t); strings.TrimSpace(text) != "" {
+				current.appendChild(&xnode{kind: xnodeText, value: text})
+			}
+		}
+	}
+	return root, nil
+}
+
+// parseHTML 解析 HTML 响应体
+func parseHTML(body string) (*xnode, error) {
+	doc, err := html.Parse(strings.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("解析HTML响应体失败: %w", err)
+	}
+
+	var convert func(n *html.Node) *xnode
+	convert = func(n *html.Node) *xnode {
+		var node *xnode
+		switch n.Type {
+		case html.DocumentNode:
+			node = &xnode{kind: xnodeDocument}
+		case html.ElementNode:
+			node = &xnode{kind: xnodeElement, name: n.Data}
+			for _, attr := range n.Attr {
+				node.attrs = append(node.attrs, &xnode{kind: xnodeAttr, name: attr.Key, value: attr.Val, parent: node})
+			}
+		case html.TextNode:
+			if strings.TrimSpace(n.Data) == "" {
+				return nil
+			}
+			return &xnode{kind: xnodeText, value: n.Data}
+		default:
+			return nil
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if child := convert(c); child != nil {
+				node.appendChild(child)
+			}
+		}
+		return node
+	}
+	return convert(doc), nil
+}
+
+// xstep XPath 中的一步
+type xstep struct {
+	// 是否经由 // 匹配所有后代
+	descendant bool
+	// 节点测试：元素名、*、@属性名、@*、text()、node()、. 或 ..
+	test string
+	// 谓词
+	predicates []xexpr
+}
+
+// selectXPath 选取匹配的节点
+// 支持的语法: /、//、.、..、*、元素名、@属性、text()、node()，以及谓词中的位置、last()、position()、
+// 比较运算（= != < <= > >=）、and/or、not()、contains()、starts-with()；元素名不区分大小写
+func selectXPath(doc *xnode, expression string) ([]*xnode, error) {
+	steps, err := parseXPath(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []*xnode{doc}
+	for _, step := range steps {
+		var next []*xnode
+		seen := make(map[*xnode]bool)
+		for _, node := range nodes {
+			contexts := []*xnode{node}
+			if step.descendant {
+				contexts = descendantsOrSelf(node)
+			}
+			for _, context := range contexts {
+				matched := step.match(context)
+				for _, predicate := range step.predicates {
+					matched = filterNodes(matched, predicate)
+				}
+				for _, m := range matched {
+					if !seen[m] {
+						seen[m] = true
+						next = append(next, m)
+					}
+				}
+			}
+		}
+		nodes = next
+	}
+	return nodes, nil
+}
+
+// match 按节点测试选取 context 的子节点、属性、自身或父节点
+func (s *xstep) match(context *xnode) []*xnode {
+	switch s.test {
+	case ".":
+		return []*xnode{context}
+	case "..":
+		if context.parent == nil {
+			return nil
+		}
+		return []*xnode{context.parent}
+	}
+
+	if strings.HasPrefix(s.test, "@") {
+		name := s.test[1:]
+		var result []*xnode
+		for _, attr := range context.attrs {
+			if name == "*" || strings.EqualFold(attr.name, name) {
+				result = append(result, attr)
+			}
+		}
+		return result
+	}
+
+	var result []*xnode
+	for _, child := range context.children {
+		switch s.test {
+		case "node()":
+			result = append(result, child)
+		case "text()":
+			if child.kind == xnodeText {
+				result = append(result, child)
+			}
+		case "*":
+			if child.kind == xnodeElement {
+				result = append(result, child)
+			}
+		default:
+			if child.kind == xnodeElement && strings.EqualFold(child.name, s.test) {
+				result = append(result, child)
+			}
+		}
+	}
+	return result
+}
+
+// filterNodes 按谓词过滤，数值谓词表示位置（从 1 开始）
+func filterNodes(nodes []*xnode, predicate xexpr) []*xnode {
+	var result []*xnode
+	for i, node := range nodes {
+		ctx := &xcontext{node: node, position: i + 1, size: len(nodes)}
+		value := predicate.eval(ctx)
+		if n, ok := value.(float64); ok {
+			if int(n) == ctx.position {
+				result = append(result, node)
+			}
+			continue
+		}
+		if toBool(value) {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+// descendantsOrSelf 返回节点自身及其所有后代元素
+func descendantsOrSelf(node *xnode) []*xnode {
+	result := []*xnode{node}
+	for _, child := range node.children {
+		if child.kind == xnodeElement {
+			result = append(result, descendantsOrSelf(child)...)
+		}
+	}
+	return result
+}
+
+// xcontext 谓词求值的上下文
+type xcontext struct {
+	node     *xnode
+	position int
+	size     int
+}
+
+// xexpr 谓词表达式，值为 float64、string、bool 或 []*xnode
+type xexpr interface {
+	eval(ctx *xcontext) interface{}
+}
+
+type (
+	xliteral  struct{ value interface{} }
+	xpathExpr struct{ steps []xstep }
+	xfunc     struct {
+		name string
+		args []xexpr
+	}
+	xbinary struct {
+		op          string
+		left, right xexpr
+	}
+)
+
+func (e *xliteral) eval(ctx *xcontext) interface{} {
+	return e.value
+}
+
+// eval 相对上下文节点求值路径
+func (e *xpathExpr) eval(ctx *xcontext) interface{} {
+	nodes := []*xnode{ctx.node}
+	for i := range e.steps {
+		var next []*xnode
+		for _, node := range nodes {
+			next = append(next, e.steps[i].match(node)...)
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+func (e *xfunc) eval(ctx *xcontext) interface{} {
+	switch e.name {
+	case "position":
+		return float64(ctx.position)
+	case "last":
+		return float64(ctx.size)
+	case "not":
+		return !toBool(e.args[0].eval(ctx))
+	case "contains":
+		return strings.Contains(toString(e.args[0].eval(ctx)), toString(e.args[1].eval(ctx)))
+	case "starts-with":
+		return strings.HasPrefix(toString(e.args[0].eval(ctx)), toString(e.args[1].eval(ctx)))
+	case "normalize-space":
+		return strings.Join(strings.Fields(toString(e.args[0].eval(ctx))), " ")
+	}
+	return nil
+}
+
+// eval 节点集与其他值比较时，任一节点满足即成立
+func (e *xbinary) eval(ctx *xcontext) interface{} {
+	switch e.op {
+	case "and":
+		return toBool(e.left.eval(ctx)) && toBool(e.right.eval(ctx))
+	case "or":
+		return toBool(e.left.eval(ctx)) || toBool(e.right.eval(ctx))
+	}
+
+	left, right := e.left.eval(ctx), e.right.eval(ctx)
+	for _, l := range atomize(left) {
+		for _, r := range atomize(right) {
+			if compareAtoms(e.op, l, r) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// atomize 将节点集展开为各节点的字符串值
+func atomize(value interface{}) []interface{} {
+	nodes, ok := value.([]*xnode)
+	if !ok {
+		return []interface{}{value}
+	}
+	result := make([]interface{}, len(nodes))
+	for i, node := range nodes {
+		result[i] = node.text()
+	}
+	return result
+}
+
+// compareAtoms 任一侧为数值或运算为大小比较时按数值比较，否则按字符串比较
+func compareAtoms(op string, left, right interface{}) bool {
+	_, leftNum := left.(float64)
+	_, rightNum := right.(float64)
+	if leftNum || rightNum || (op != "=" && op != "!=") {
+		l, lok := toFloat(left)
+		r, rok := toFloat(right)
+		if !lok || !rok {
+			return false
+		}
+		switch op {
+		case "=":
+			return l == r
+		case "!=":
+			return l != r
+		case "<":
+			return l < r
+		case "<=":
+			return l <= r
+		case ">":
+			return l > r
+		default:
+			return l >= r
+		}
+	}
+	if op == "=" {
+		return toString(left) == toString(right)
+	}
+	return toString(left) != toString(right)
+}
+
+// toBool 节点集非空、字符串非空、数值非零为真
+func toBool(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	case []*xnode:
+		return len(v) > 0
+	}
+	return false
+}
+
+// toString 节点集取第一个节点的字符串值
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []*xnode:
+		if len(v) == 0 {
+			return ""
+		}
+		return v[0].text()
+	}
+	return ""
+}
+
+// xpathParser XPath 解析器
+type xpathParser struct {
+	src string
+	pos int
+}
+
+// parseXPath 解析 XPath，相对路径从文档根开始
+func parseXPath(expression string) ([]xstep, error) {
+	p := &xpathParser{src: strings.TrimSpace(expression)}
+	if p.src == "" {
+		return nil, fmt.Errorf("XPath 为空")
+	}
+
+	var steps []xstep
+	for p.pos < len(p.src) {
+		descendant := false
+		switch {
+		case strings.HasPrefix(p.src[p.pos:], "//"):
+			descendant = true
+			p.pos += 2
+		case p.src[p.pos] == '/':
+			p.pos++
+		case len(steps) > 0:
+			return nil, p.errorf("无法解析的内容 %q", p.src[p.pos:])
+		}
+
+		step, err := p.parseStep()
+		if err != nil {
+			return nil, err
+		}
+		step.descendant = descendant
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// parseStep 解析节点测试和谓词
+func (p *xpathParser) parseStep() (xstep, error) {
+	var step xstep
+	start := p.pos
+	switch {
+	case strings.HasPrefix(p.src[p.pos:], ".."):
+		p.pos += 2
+	case strings.HasPrefix(p.src[p.pos:], "."):
+		p.pos++
+	default:
+		if p.peek('@') {
+			p.pos++
+		}
+		if p.peek('*') {
+			p.pos++
+		} else if p.parseName() == "" {
+			return step, p.errorf("缺少节点名")
+		}
+		if strings.HasPrefix(p.src[p.pos:], "()") {
+			p.pos += 2
+		}
+	}
+	step.test = p.src[start:p.pos]
+	if step.test != "text()" && step.test != "node()" && strings.HasSuffix(step.test, "()") {
+		return step, p.errorf("不支持的节点测试 %s", step.test)
+	}
+
+	for p.peek('[') {
+		p.pos++
+		predicate, err := p.parseOr()
+		if err != nil {
+			return step, err
+		}
+		p.skipSpaces()
+		if !p.peek(']') {
+			return step, p.errorf("缺少 ]")
+		}
+		p.pos++
+		step.predicates = append(step.predicates, predicate)
+	}
+	return step, nil
+}
+
+func (p *xpathParser) parseOr() (xexpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &xbinary{op: "or", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *xpathParser) parseAnd() (xexpr, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		left = &xbinary{op: "and", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *xpathParser) parseComparison() (xexpr, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	for _, op := range []string{"!=", "<=", ">=", "=", "<", ">"} {
+		if strings.HasPrefix(p.src[p.pos:], op) {
+			p.pos += len(op)
+			right, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			return &xbinary{op: op, left: left, right: right}, nil
+		}
+	}
+	return left, nil
+}
+
+// xfuncArgs 谓词中可用的函数及参数个数
+var xfuncArgs = map[string]int{
+	"position":        0,
+	"last":            0,
+	"not":             1,
+	"contains":        2,
+	"starts-with":     2,
+	"normalize-space": 1,
+}
+
+// parseOperand 解析字面量、函数调用、括号或相对路径
+func (p *xpathParser) parseOperand() (xexpr, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.src) {
+		return nil, p.errorf("谓词不完整")
+	}
+
+	c := p.src[p.pos]
+	switch {
+	case c == '\'' || c == '"':
+		end := strings.IndexByte(p.src[p.pos+1:], c)
+		if end < 0 {
+			return nil, p.errorf("字符串缺少结束引号")
+		}
+		value := p.src[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		return &xliteral{value: value}, nil
+
+	case c == '-' || (c >= '0' && c <= '9'):
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.src) && (p.src[p.pos] == '.' || (p.src[p.pos] >= '0' && p.src[p.pos] <= '9')) {
+			p.pos++
+		}
+		n, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+		if err != nil {
+			return nil, p.errorf("无效的数字")
+		}
+		return &xliteral{value: n}, nil
+
+	case c == '(':
+		p.pos++
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if !p.peek(')') {
+			return nil, p.errorf("缺少 )")
+		}
+		p.pos++
+		return x, nil
+	}
+
+	// 函数调用
+	start := p.pos
+	name := p.parseName()
+	if count, ok := xfuncArgs[name]; ok && p.peek('(') {
+		p.pos++
+		fn := &xfunc{name: name}
+		for {
+			p.skipSpaces()
+			if p.peek(')') {
+				p.pos++
+				break
+			}
+			if len(fn.args) > 0 {
+				if !p.peek(',') {
+					return nil, p.errorf("函数 %s 的参数格式错误", name)
+				}
+				p.pos++
+			}
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			fn.args = append(fn.args, arg)
+		}
+		if len(fn.args) != count {
+			return nil, p.errorf("函数 %s 需要 %d 个参数", name, count)
+		}
+		return fn, nil
+	}
+	p.pos = start
+
+	// 相对路径，如 @id、text()、title、a/b
+	var steps []xstep
+	for {
+		step, err := p.parseStep()
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+		if !p.peek('/') || strings.HasPrefix(p.src[p.pos:], "//") {
+			break
+		}
+		p.pos++
+	}
+	return &xpathExpr{steps: steps}, nil
+}
+
+// parseName 解析节点名或函数名
+func (p *xpathParser) parseName() string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		letter := c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		other := c == '-' || c == ':' || c == '.' || (c >= '0' && c <= '9')
+		if !letter && !(other && p.pos > start) {
+			break
+		}
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+// keyword 跳过空白后匹配关键字 and/or
+func (p *xpathParser) keyword(word string) bool {
+	p.skipSpaces()
+	if !strings.HasPrefix(p.src[p.pos:], word) {
+		return false
+	}
+	end := p.pos + len(word)
+	if end < len(p.src) && p.src[end] != ' ' && p.src[end] != '(' {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+func (p *xpathParser) peek(c byte) bool {
+	return p.pos < len(p.src) && p.src[p.pos] == c
+}
+
+func (p *xpathParser) skipSpaces() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *xpathParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("XPath 语法错误: %s, 位置 %d, 表达式: %s", fmt.Sprintf(format, args...), p.pos, p.src)
+}
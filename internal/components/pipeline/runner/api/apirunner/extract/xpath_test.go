@@ -0,0 +1,157 @@
+package extract
+
+import (
+	"strings"
+	"testing"
+)
+
+const testXML = `<?xml version="1.0" encoding="UTF-8"?>
+<catalog xmlns:x="urn:x">
+  <book id="1" lang="en"><title>Go</title><price>30</price><author>A</author></book>
+  <book id="2" lang="zh"><title>Rust</title><price>45.5</price><author>B</author><author>C</author></book>
+  <book id="3"><x:title>Zig</x:title><price>20</price></book>
+  <magazine id="4"><title> Wired  News </title></magazine>
+</catalog>`
+
+const testHTML = `<!DOCTYPE html>
+<html>
+<head><title>首页</title></head>
+<body>
+  <div class="item"><a href="/a">A</a></div>
+  <div class="item active"><a href="/b">B&amp;C</a><br></div>
+</body>
+</html>`
+
+func TestSelectXPath(t *testing.T) {
+	xmlDoc, err := parseXML(testXML)
+	if err != nil {
+		t.Fatalf("parseXML() error = %v", err)
+	}
+	htmlDoc, err := parseHTML(testHTML)
+	if err != nil {
+		t.Fatalf("parseHTML() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		doc        *xnode
+		expression string
+		// 期望的各节点文本
+		want []string
+	}{
+		{name: "绝对路径", doc: xmlDoc, expression: "/catalog/book/title", want: []string{"Go", "Rust", "Zig"}},
+		{name: "相对路径从文档根开始", doc: xmlDoc, expression: "catalog/magazine/@id", want: []string{"4"}},
+		{name: "忽略命名空间前缀", doc: xmlDoc, expression: "//book[@id='3']/title", want: []string{"Zig"}},
+		{name: "后代", doc: xmlDoc, expression: "//title", want: []string{"Go", "Rust", "Zig", "Wired  News"}},
+		{name: "路径中间的后代", doc: xmlDoc, expression: "/catalog//author", want: []string{"A", "B", "C"}},
+		{name: "元素的文本为后代文本的拼接", doc: xmlDoc, expression: "//book[1]", want: []string{"Go30A"}},
+		{name: "通配元素", doc: xmlDoc, expression: "/catalog/*/@id", want: []string{"1", "2", "3", "4"}},
+		{name: "通配属性", doc: xmlDoc, expression: "//book[1]/@*", want: []string{"1", "en"}},
+		{name: "文本节点保留空白", doc: xmlDoc, expression: "//magazine/title/text()", want: []string{" Wired  News "}},
+		{name: "子节点", doc: xmlDoc, expression: "//book[3]/node()", want: []string{"Zig", "20"}},
+		{name: "自身", doc: xmlDoc, expression: "//price/.", want: []string{"30", "45.5", "20"}},
+		{name: "父节点", doc: xmlDoc, expression: "//title[.='Rust']/../@id", want: []string{"2"}},
+		{name: "父节点去重", doc: xmlDoc, expression: "//author/../@id", want: []string{"1", "2"}},
+		{name: "元素名不区分大小写", doc: xmlDoc, expression: "//BOOK[@ID='1']/Title", want: []string{"Go"}},
+
+		{name: "位置谓词", doc: xmlDoc, expression: "//book[2]/title", want: []string{"Rust"}},
+		{name: "位置按父节点计算", doc: xmlDoc, expression: "//author[1]", want: []string{"A", "B"}},
+		{name: "last()", doc: xmlDoc, expression: "//book[last()]/@id", want: []string{"3"}},
+		{name: "position()", doc: xmlDoc, expression: "//book[position() > 1]/@id", want: []string{"2", "3"}},
+		{name: "属性存在", doc: xmlDoc, expression: "//book[@lang]/@id", want: []string{"1", "2"}},
+		{name: "属性值比较", doc: xmlDoc, expression: `//book[@lang="zh"]/title`, want: []string{"Rust"}},
+		{name: "not()", doc: xmlDoc, expression: "//book[not(@lang)]/@id", want: []string{"3"}},
+		{name: "数值比较", doc: xmlDoc, expression: "//book[price >= 30]/@id", want: []string{"1", "2"}},
+		{name: "不等于", doc: xmlDoc, expression: "//book[price != 30]/@id", want: []string{"2", "3"}},
+		{name: "节点集任一节点满足", doc: xmlDoc, expression: "//book[author='C']/@id", want: []string{"2"}},
+		{name: "and", doc: xmlDoc, expression: "//book[price > 25 and price < 40]/@id", want: []string{"1"}},
+		{name: "or", doc: xmlDoc, expression: "//book[price > 40 or @id='3']/@id", want: []string{"2", "3"}},
+		{name: "括号", doc: xmlDoc, expression: "//book[(price < 25 or price > 40) and @lang]/@id", want: []string{"2"}},
+		{name: "contains()", doc: xmlDoc, expression: "//book[contains(title, 'us')]/@id", want: []string{"2"}},
+		{name: "starts-with()", doc: xmlDoc, expression: "//book[starts-with(title, 'G')]/@id", want: []string{"1"}},
+		{name: "normalize-space()", doc: xmlDoc, expression: "//magazine[normalize-space(title)='Wired News']/@id", want: []string{"4"}},
+		{name: "多个谓词依次过滤", doc: xmlDoc, expression: "//book[@lang][2]/@id", want: []string{"2"}},
+		{name: "谓词中的相对路径", doc: xmlDoc, expression: "/catalog[book/title='Zig']/magazine/@id", want: []string{"4"}},
+		{name: "没有匹配", doc: xmlDoc, expression: "//book[@id='9']", want: nil},
+
+		{name: "HTML 标题", doc: htmlDoc, expression: "/html/head/title", want: []string{"首页"}},
+		{name: "HTML 属性包含", doc: htmlDoc, expression: "//div[contains(@class, 'active')]/a/@href", want: []string{"/b"}},
+		{name: "HTML 实体", doc: htmlDoc, expression: "//div[2]/a", want: []string{"B&C"}},
+		{name: "HTML 空元素", doc: htmlDoc, expression: "//div/*", want: []string{"A", "B&C", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, err := selectXPath(tt.doc, tt.expression)
+			if err != nil {
+				t.Fatalf("selectXPath(%q) error = %v", tt.expression, err)
+			}
+			var got []string
+			for _, node := range nodes {
+				got = append(got, node.text())
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") || len(got) != len(tt.want) {
+				t.Errorf("selectXPath(%q) = %q, want %q", tt.expression, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectXPathError(t *testing.T) {
+	doc, err := parseXML(testXML)
+	if err != nil {
+		t.Fatalf("parseXML() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		wantErr    string
+	}{
+		{name: "表达式为空", expression: "  ", wantErr: "XPath 为空"},
+		{name: "缺少节点名", expression: "//[1]", wantErr: "XPath 语法错误: 缺少节点名, 位置 2, 表达式: //[1]"},
+		{name: "多余的内容", expression: "/catalog]", wantErr: `XPath 语法错误: 无法解析的内容 "]", 位置 8, 表达式: /catalog]`},
+		{name: "不支持的节点测试", expression: "//book/last()", wantErr: "XPath 语法错误: 不支持的节点测试 last(), 位置 13, 表达式: //book/last()"},
+		{name: "谓词不完整", expression: "//book[", wantErr: "XPath 语法错误: 谓词不完整, 位置 7, 表达式: //book["},
+		{name: "缺少 ]", expression: "//book[@id='1'", wantErr: "XPath 语法错误: 缺少 ], 位置 14, 表达式: //book[@id='1'"},
+		{name: "缺少 )", expression: "//book[(@id='1']", wantErr: "XPath 语法错误: 缺少 ), 位置 15, 表达式: //book[(@id='1']"},
+		{name: "字符串缺少结束引号", expression: "//book[@id='1]", wantErr: "XPath 语法错误: 字符串缺少结束引号, 位置 11, 表达式: //book[@id='1]"},
+		{name: "无效的数字", expression: "//book[1.2.3]", wantErr: "XPath 语法错误: 无效的数字, 位置 12, 表达式: //book[1.2.3]"},
+		{name: "函数参数个数错误", expression: "//book[contains(title)]", wantErr: "XPath 语法错误: 函数 contains 需要 2 个参数, 位置 22, 表达式: //book[contains(title)]"},
+		{name: "函数参数缺少逗号", expression: "//book[contains(title 'G')]", wantErr: "XPath 语法错误: 函数 contains 的参数格式错误, 位置 22, 表达式: //book[contains(title 'G')]"},
+		{name: "不支持的函数", expression: "//book[count(author)]", wantErr: "XPath 语法错误: 缺少 ], 位置 12, 表达式: //book[count(author)]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := selectXPath(doc, tt.expression)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("selectXPath(%q) error = %v, want %q", tt.expression, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsHTML(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        bool
+	}{
+		{name: "HTML Content-Type", contentType: "text/html; charset=utf-8", body: "<p>x</p>", want: true},
+		{name: "XHTML Content-Type", contentType: "application/xhtml+xml", body: "<html/>", want: true},
+		{name: "doctype", body: "\n  <!DOCTYPE html><html></html>", want: true},
+		{name: "html 标签", body: "<HTML><body></body></HTML>", want: true},
+		{name: "XML", contentType: "application/xml", body: "<html-report/>", want: false},
+		{name: "没有 Content-Type 的 XML", body: `<?xml version="1.0"?><a/>`, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isHTML(tt.contentType, tt.body); got != tt.want {
+				t.Errorf("isHTML(%q, %q) = %v, want %v", tt.contentType, tt.body, got, tt.want)
+			}
+		})
+	}
+}
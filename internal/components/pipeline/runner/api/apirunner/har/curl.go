@@ -0,0 +1,44 @@
+package har
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Curl 将请求导出为等价的 curl 命令
+// base64 编码的请求体通过管道解码后作为请求体发送，被截断的请求体只包含记录的部分
+func (r *Request) Curl() string {
+	var b strings.Builder
+	binary := r.PostData != nil && r.PostData.Encoding == "base64"
+	if binary {
+		b.WriteString("echo " + shellQuote(r.PostData.Text) + " | base64 -d | ")
+	}
+
+	b.WriteString("curl")
+	if r.Method != http.MethodGet || r.PostData != nil {
+		b.WriteString(" -X " + shellQuote(r.Method))
+	}
+	b.WriteString(" " + shellQuote(r.URL))
+
+	for _, header := range r.Headers {
+		// 请求体长度由 curl 计算
+		if strings.EqualFold(header.Name, "Content-Length") {
+			continue
+		}
+		b.WriteString(" \\\n  -H " + shellQuote(header.Name+": "+header.Value))
+	}
+
+	if r.PostData != nil {
+		if binary {
+			b.WriteString(" \\\n  --data-binary @-")
+		} else {
+			b.WriteString(" \\\n  --data-raw " + shellQuote(r.PostData.Text))
+		}
+	}
+	return b.String()
+}
+
+// shellQuote 使用单引号转义 shell 参数
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
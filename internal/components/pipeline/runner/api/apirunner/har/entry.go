@@ -0,0 +1,184 @@
+package har
+
+import (
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// NewEntry 由即将发送的请求创建条目，jar 不为空时按客户端发送时的方式补充 cookie 请求头
+// 默认的敏感请求头、cookie 和查询参数的值被遮盖，认证方式使用的其他请求头和查询参数由 Request.Redact 遮盖
+// 响应由 SetResponse 或 SetError 填充
+func NewEntry(req *http.Request, body []byte, jar http.CookieJar, timer *Timer) *Entry {
+	// 克隆请求后补充 cookie，与客户端实际发出的请求头一致
+	sent := req.Clone(req.Context())
+	if jar != nil {
+		for _, cookie := range jar.Cookies(req.URL) {
+			sent.AddCookie(cookie)
+		}
+	}
+
+	request := &Request{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		HTTPVersion: req.Proto,
+		Cookies:     toCookies(sent.Cookies()),
+		Headers:     toHeaders(sent.Header),
+		QueryString: toQueryString(req.URL.Query()),
+		HeadersSize: -1,
+		BodySize:    int64(len(body)),
+	}
+	if body != nil {
+		request.PostData = toPostData(sent.Header.Get("Content-Type"), body)
+	}
+	request.Redact(nil, nil)
+
+	return &Entry{
+		StartedDateTime: timer.start,
+		Request:         request,
+		Response: &Response{
+			Cookies:     []Cookie{},
+			Headers:     []NameValue{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Timings: Timings{Blocked: -1, DNS: -1, Connect: -1, SSL: -1},
+	}
+}
+
+// SetResponse 记录响应和各阶段耗时，body 为读取的完整响应体，响应设置的 cookie 值被遮盖
+func (e *Entry) SetResponse(resp *http.Response, body []byte, timer *Timer) {
+	e.Request.HTTPVersion = resp.Proto
+	contentType := resp.Header.Get("Content-Type")
+	content := Content{
+		Size:     int64(len(body)),
+		MimeType: contentType,
+	}
+	if len(body) > 0 {
+		content.Text, content.Encoding, content.Comment = encodeBody(body)
+	}
+
+	e.Response = &Response{
+		Status:      resp.StatusCode,
+		StatusText:  strings.TrimSpace(strings.TrimPrefix(resp.Status, fmt.Sprint(resp.StatusCode))),
+		HTTPVersion: resp.Proto,
+		Cookies:     toCookies(resp.Cookies()),
+		Headers:     toHeaders(resp.Header),
+		Content:     content,
+		RedirectURL: resp.Header.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    int64(len(body)),
+	}
+	e.Response.redact()
+	e.setTimings(timer)
+}
+
+// SetError 记录请求失败，响应状态码为 0
+func (e *Entry) SetError(err error, timer *Timer) {
+	e.Response.Comment = err.Error()
+	e.setTimings(timer)
+}
+
+func (e *Entry) setTimings(timer *Timer) {
+	e.Timings, e.Time = timer.Timings()
+
+	timer.mu.Lock()
+	defer timer.mu.Unlock()
+	e.ServerIPAddress = timer.remoteAddr
+	e.Connection = timer.localAddr
+}
+
+// toPostData 请求体，表单请求体同时解析出参数
+func toPostData(contentType string, body []byte) *PostData {
+	postData := &PostData{MimeType: contentType}
+	postData.Text, postData.Encoding, postData.Comment = encodeBody(body)
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "application/x-www-form-urlencoded" {
+		if values, err := url.ParseQuery(string(body)); err == nil {
+			for _, nv := range toQueryString(values) {
+				postData.Params = append(postData.Params, Param{Name: nv.Name, Value: nv.Value})
+			}
+		}
+	}
+	return postData
+}
+
+// encodeBody 按 UTF-8 文本记录内容，非 UTF-8 的内容使用 base64 编码，超过 MaxBodySize 时截断
+func encodeBody(body []byte) (text, encoding, comment string) {
+	binary := !utf8.Valid(body)
+	if len(body) > MaxBodySize {
+		n := MaxBodySize
+		if !binary {
+			// 在字符边界截断
+			for n > 0 && !utf8.RuneStart(body[n]) {
+				n--
+			}
+		}
+		comment = fmt.Sprintf("内容共 %d 字节, 只记录前 %d 字节", len(body), n)
+		body = body[:n]
+	}
+	if binary {
+		return base64.StdEncoding.EncodeToString(body), "base64", comment
+	}
+	return string(body), "", comment
+}
+
+// toHeaders 按名称排序的请求头或响应头，同名的多个值分别记录
+func toHeaders(header http.Header) []NameValue {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	headers := make([]NameValue, 0, len(header))
+	for _, name := range names {
+		for _, value := range header[name] {
+			headers = append(headers, NameValue{Name: name, Value: value})
+		}
+	}
+	return headers
+}
+
+// toQueryString 按名称排序的查询参数
+func toQueryString(query url.Values) []NameValue {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	params := make([]NameValue, 0, len(query))
+	for _, name := range names {
+		for _, value := range query[name] {
+			params = append(params, NameValue{Name: name, Value: value})
+		}
+	}
+	return params
+}
+
+func toCookies(cookies []*http.Cookie) []Cookie {
+	result := make([]Cookie, 0, len(cookies))
+	for _, c := range cookies {
+		cookie := Cookie{
+			Name:     c.Name,
+			Value:    c.Value,
+			Path:     c.Path,
+			Domain:   c.Domain,
+			HTTPOnly: c.HttpOnly,
+			Secure:   c.Secure,
+		}
+		if !c.Expires.IsZero() {
+			expires := c.Expires
+			cookie.Expires = &expires
+		}
+		result = append(result, cookie)
+	}
+	return result
+}
@@ -0,0 +1,173 @@
+package har
+
+import (
+	"context"
+	"time"
+)
+
+// HAR 的版本和生成工具
+const (
+	Version        = "1.2"
+	CreatorName    = "Storage"
+	CreatorVersion = "1.0"
+)
+
+// MaxBodySize 条目中记录的请求体和响应体的最大字节数，超过时截断
+const MaxBodySize = 1 << 20
+
+// Recorder 记录执行中每次请求和响应的条目
+type Recorder interface {
+	// Record 在请求结束（包括发送失败）后调用，记录失败不影响请求结果
+	Record(ctx context.Context, entry *Entry) error
+}
+
+// RecorderAware 支持记录 HAR 条目的执行器
+type RecorderAware interface {
+	// SetHarRecorder 设置执行器使用的记录器，为 nil 时不记录
+	SetHarRecorder(recorder Recorder)
+}
+
+// Log HAR 1.2 文档的 log 对象
+type Log struct {
+	Version string   `bson:"version" json:"version"`
+	Creator Creator  `bson:"creator" json:"creator"`
+	Entries []*Entry `bson:"entries" json:"entries"`
+	Comment string   `bson:"comment,omitempty" json:"comment,omitempty"`
+}
+
+// Document HAR 文件的顶层对象
+type Document struct {
+	Log *Log `json:"log"`
+}
+
+// Creator 生成 HAR 的工具
+type Creator struct {
+	Name    string `bson:"name" json:"name"`
+	Version string `bson:"version" json:"version"`
+}
+
+// Entry 一次请求和响应
+type Entry struct {
+	// 请求开始时间
+	StartedDateTime time.Time `bson:"startedDateTime" json:"startedDateTime"`
+
+	// 请求总耗时（毫秒），为各阶段耗时之和
+	Time float64 `bson:"time" json:"time"`
+
+	Request  *Request  `bson:"request" json:"request"`
+	Response *Response `bson:"response" json:"response"`
+	Cache    Cache     `bson:"cache" json:"cache"`
+	Timings  Timings   `bson:"timings" json:"timings"`
+
+	// 服务端IP和连接标识
+	ServerIPAddress string `bson:"serverIPAddress,omitempty" json:"serverIPAddress,omitempty"`
+	Connection      string `bson:"connection,omitempty" json:"connection,omitempty"`
+
+	// 发出请求的API名称
+	Comment string `bson:"comment,omitempty" json:"comment,omitempty"`
+}
+
+// Request 请求
+type Request struct {
+	Method      string      `bson:"method" json:"method"`
+	URL         string      `bson:"url" json:"url"`
+	HTTPVersion string      `bson:"httpVersion" json:"httpVersion"`
+	Cookies     []Cookie    `bson:"cookies" json:"cookies"`
+	Headers     []NameValue `bson:"headers" json:"headers"`
+	QueryString []NameValue `bson:"queryString" json:"queryString"`
+	PostData    *PostData   `bson:"postData,omitempty" json:"postData,omitempty"`
+
+	// 请求头大小未知时为 -1
+	HeadersSize int64 `bson:"headersSize" json:"headersSize"`
+	BodySize    int64 `bson:"bodySize" json:"bodySize"`
+}
+
+// Response 响应，请求发送失败时状态码为 0，错误记录在 Comment 中
+type Response struct {
+	Status      int         `bson:"status" json:"status"`
+	StatusText  string      `bson:"statusText" json:"statusText"`
+	HTTPVersion string      `bson:"httpVersion" json:"httpVersion"`
+	Cookies     []Cookie    `bson:"cookies" json:"cookies"`
+	Headers     []NameValue `bson:"headers" json:"headers"`
+	Content     Content     `bson:"content" json:"content"`
+	RedirectURL string      `bson:"redirectURL" json:"redirectURL"`
+
+	// 响应头大小未知时为 -1
+	HeadersSize int64 `bson:"headersSize" json:"headersSize"`
+	BodySize    int64 `bson:"bodySize" json:"bodySize"`
+
+	Comment string `bson:"comment,omitempty" json:"comment,omitempty"`
+}
+
+// Cookie 请求或响应的 cookie
+type Cookie struct {
+	Name     string     `bson:"name" json:"name"`
+	Value    string     `bson:"value" json:"value"`
+	Path     string     `bson:"path,omitempty" json:"path,omitempty"`
+	Domain   string     `bson:"domain,omitempty" json:"domain,omitempty"`
+	Expires  *time.Time `bson:"expires,omitempty" json:"expires,omitempty"`
+	HTTPOnly bool       `bson:"httpOnly,omitempty" json:"httpOnly,omitempty"`
+	Secure   bool       `bson:"secure,omitempty" json:"secure,omitempty"`
+}
+
+// NameValue 请求头、响应头和查询参数
+type NameValue struct {
+	Name  string `bson:"name" json:"name"`
+	Value string `bson:"value" json:"value"`
+}
+
+// PostData 请求体，非 UTF-8 的内容以 base64 编码记录
+type PostData struct {
+	MimeType string  `bson:"mimeType" json:"mimeType"`
+	Params   []Param `bson:"params,omitempty" json:"params,omitempty"`
+	Text     string  `bson:"text" json:"text"`
+	Comment  string  `bson:"comment,omitempty" json:"comment,omitempty"`
+
+	// Text 的编码，HAR 的 postData 没有该字段，按自定义字段的约定以下划线开头
+	Encoding string `bson:"encoding,omitempty" json:"_encoding,omitempty"`
+}
+
+// Param 表单请求体的参数
+type Param struct {
+	Name        string `bson:"name" json:"name"`
+	Value       string `bson:"value,omitempty" json:"value,omitempty"`
+	FileName    string `bson:"fileName,omitempty" json:"fileName,omitempty"`
+	ContentType string `bson:"contentType,omitempty" json:"contentType,omitempty"`
+}
+
+// Content 响应体，非 UTF-8 的内容以 base64 编码记录
+type Content struct {
+	Size     int64  `bson:"size" json:"size"`
+	MimeType string `bson:"mimeType" json:"mimeType"`
+	Text     string `bson:"text,omitempty" json:"text,omitempty"`
+	Encoding string `bson:"encoding,omitempty" json:"encoding,omitempty"`
+	Comment  string `bson:"comment,omitempty" json:"comment,omitempty"`
+}
+
+// Cache 缓存信息，执行器不使用缓存，始终为空对象
+type Cache struct{}
+
+// Timings 请求各阶段的耗时（毫秒），不适用的阶段为 -1
+type Timings struct {
+	Blocked float64 `bson:"blocked" json:"blocked"`
+	DNS     float64 `bson:"dns" json:"dns"`
+	Connect float64 `bson:"connect" json:"connect"`
+	Send    float64 `bson:"send" json:"send"`
+	Wait    float64 `bson:"wait" json:"wait"`
+	Receive float64 `bson:"receive" json:"receive"`
+	SSL     float64 `bson:"ssl" json:"ssl"`
+}
+
+// NewDocument 由条目创建 HAR 文档
+func NewDocument(entries []*Entry) *Document {
+	if entries == nil {
+		entries = []*Entry{}
+	}
+	return &Document{
+		Log: &Log{
+			Version: Version,
+			Creator: Creator{Name: CreatorName, Version: CreatorVersion},
+			Entries: entries,
+		},
+	}
+}
@@ -0,0 +1,128 @@
+package har
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Masked 条目中遮盖凭证后的值
+const Masked = "******"
+
+// SensitiveHeaders 条目中默认遮盖值的请求头和响应头，名称不区分大小写
+var SensitiveHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Api-Key",
+	"X-Auth-Token",
+}
+
+// SensitiveQuery 条目中默认遮盖值的查询参数，名称不区分大小写
+var SensitiveQuery = []string{
+	"access_token",
+	"api_key",
+	"apikey",
+	"token",
+	"signature",
+	"sig",
+	"X-Amz-Credential",
+	"X-Amz-Security-Token",
+	"X-Amz-Signature",
+}
+
+// Redact 遮盖请求中的凭证，headers 和 query 为默认之外需要遮盖的请求头和查询参数
+// 条目在添加认证信息后创建，认证方式使用的请求头和查询参数在保存前遮盖
+func (r *Request) Redact(headers, query []string) {
+	for i, header := range r.Headers {
+		if isSensitive(header.Name, SensitiveHeaders, headers) {
+			r.Headers[i].Value = maskHeader(header.Name, header.Value)
+		}
+	}
+	for i := range r.Cookies {
+		r.Cookies[i].Value = Masked
+	}
+
+	masked := false
+	for i, param := range r.QueryString {
+		if isSensitive(param.Name, SensitiveQuery, query) {
+			r.QueryString[i].Value = Masked
+			masked = true
+		}
+	}
+	if masked {
+		r.URL = maskURL(r.URL, query)
+	}
+}
+
+// redact 遮盖响应中设置的 cookie
+func (r *Response) redact() {
+	for i, header := range r.Headers {
+		if isSensitive(header.Name, SensitiveHeaders, nil) {
+			r.Headers[i].Value = maskHeader(header.Name, header.Value)
+		}
+	}
+	for i := range r.Cookies {
+		r.Cookies[i].Value = Masked
+	}
+}
+
+func isSensitive(name string, defaults, extra []string) bool {
+	for _, names := range [][]string{defaults, extra} {
+		for _, sensitive := range names {
+			if strings.EqualFold(name, sensitive) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// maskHeader 遮盖请求头的值，cookie 保留名称和属性，只遮盖值
+func maskHeader(name, value string) string {
+	switch {
+	case strings.EqualFold(name, "Cookie"):
+		pairs := strings.Split(value, ";")
+		for i, pair := range pairs {
+			pairs[i] = maskCookie(pair)
+		}
+		return strings.Join(pairs, ";")
+	case strings.EqualFold(name, "Set-Cookie"):
+		cookie, attrs, found := strings.Cut(value, ";")
+		if found {
+			return maskCookie(cookie) + ";" + attrs
+		}
+		return maskCookie(cookie)
+	default:
+		return Masked
+	}
+}
+
+func maskCookie(pair string) string {
+	name, _, found := strings.Cut(pair, "=")
+	if !found {
+		return pair
+	}
+	return name + "=" + Masked
+}
+
+// maskURL 遮盖 URL 中的敏感查询参数，其余部分保持原样
+func maskURL(rawURL string, query []string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.RawQuery == "" {
+		return rawURL
+	}
+	pairs := strings.Split(u.RawQuery, "&")
+	for i, pair := range pairs {
+		key, _, _ := strings.Cut(pair, "=")
+		name, err := url.QueryUnescape(key)
+		if err != nil {
+			name = key
+		}
+		if isSensitive(name, SensitiveQuery, query) {
+			pairs[i] = key + "=" + Masked
+		}
+	}
+	u.RawQuery = strings.Join(pairs, "&")
+	return u.String()
+}
@@ -0,0 +1,137 @@
+package har
+
+import (
+	"crypto/tls"
+	"math"
+	"net"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Timer 通过 httptrace 记录请求各阶段的时间点，跟随重定向时记录最后一次请求的连接
+type Timer struct {
+	mu sync.Mutex
+
+	start        time.Time
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	gotConn      time.Time
+	wroteRequest time.Time
+	firstByte    time.Time
+	end          time.Time
+
+	remoteAddr string
+	localAddr  string
+}
+
+// NewTimer 创建计时器，以创建时间作为请求开始时间
+func NewTimer() *Timer {
+	return &Timer{start: time.Now()}
+}
+
+// Trace 记录各阶段时间点的 ClientTrace
+func (t *Timer) Trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { t.mark(&t.dnsStart) },
+		DNSDone:  func(httptrace.DNSDoneInfo) { t.mark(&t.dnsDone) },
+		ConnectStart: func(network, addr string) {
+			// 多地址并发拨号时只记录第一次
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if t.connectStart.IsZero() || !t.connectDone.IsZero() {
+				t.connectStart = time.Now()
+				t.connectDone = time.Time{}
+			}
+		},
+		ConnectDone:       func(network, addr string, err error) { t.mark(&t.connectDone) },
+		TLSHandshakeStart: func() { t.mark(&t.tlsStart) },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { t.mark(&t.tlsDone) },
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.gotConn = time.Now()
+			if info.Reused {
+				// 复用的连接没有建立连接的耗时
+				t.dnsStart, t.dnsDone = time.Time{}, time.Time{}
+				t.connectStart, t.connectDone = time.Time{}, time.Time{}
+				t.tlsStart, t.tlsDone = time.Time{}, time.Time{}
+			}
+			if info.Conn != nil {
+				if addr, ok := info.Conn.RemoteAddr().(*net.TCPAddr); ok {
+					t.remoteAddr = addr.IP.String()
+				} else {
+					t.remoteAddr = info.Conn.RemoteAddr().String()
+				}
+				t.localAddr = info.Conn.LocalAddr().String()
+			}
+		},
+		WroteRequest:         func(httptrace.WroteRequestInfo) { t.mark(&t.wroteRequest) },
+		GotFirstResponseByte: func() { t.mark(&t.firstByte) },
+	}
+}
+
+// Done 标记响应体读取结束
+func (t *Timer) Done() {
+	t.mark(&t.end)
+}
+
+func (t *Timer) mark(at *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	*at = time.Now()
+}
+
+// Timings 各阶段耗时，connect 包含 TLS 握手的耗时，未记录到的等待时间计入 blocked
+func (t *Timer) Timings() (Timings, float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	end := t.end
+	if end.IsZero() {
+		end = time.Now()
+	}
+	total := milliseconds(end.Sub(t.start))
+
+	timings := Timings{
+		DNS:     span(t.dnsStart, t.dnsDone),
+		Connect: -1,
+		SSL:     span(t.tlsStart, t.tlsDone),
+		Send:    max(span(t.gotConn, t.wroteRequest), 0),
+		Wait:    max(span(t.wroteRequest, t.firstByte), 0),
+		Receive: max(span(t.firstByte, end), 0),
+	}
+	if !t.connectStart.IsZero() {
+		connectEnd := t.connectDone
+		if !t.tlsDone.IsZero() {
+			connectEnd = t.tlsDone
+		}
+		timings.Connect = span(t.connectStart, connectEnd)
+	}
+
+	known := timings.Send + timings.Wait + timings.Receive
+	for _, d := range []float64{timings.DNS, timings.Connect} {
+		if d > 0 {
+			known += d
+		}
+	}
+	timings.Blocked = math.Round(max(total-known, 0)*1000) / 1000
+	return timings, total
+}
+
+// span 两个时间点之间的毫秒数，任一时间点未记录时为 -1
+func span(from, to time.Time) float64 {
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		return -1
+	}
+	return milliseconds(to.Sub(from))
+}
+
+// milliseconds 精确到微秒的毫秒数
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,291 @@
+package jsonpath
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"unicode/utf8"
+)
+
+// test 判断过滤表达式对当前节点是否成立，单独的路径表示字段存在
+func test(e expr, root, current interface{}) bool {
+	if path, ok := e.(*pathExpr); ok {
+		_, exists := path.eval(root, current)
+		return exists
+	}
+	value, ok := e.eval(root, current)
+	b, isBool := value.(bool)
+	return ok && isBool && b
+}
+
+func (e *literalExpr) eval(root, current interface{}) (interface{}, bool) {
+	return e.value, true
+}
+
+func (e *regexExpr) eval(root, current interface{}) (interface{}, bool) {
+	return e.re, true
+}
+
+// eval 确定路径取单个值，其他路径取匹配值组成的数组，末尾的函数作用于结果
+func (e *pathExpr) eval(root, current interface{}) (interface{}, bool) {
+	base := current
+	if e.fromRoot {
+		base = root
+	}
+	nodes, missing := e.path.eval(root, base)
+
+	var value interface{}
+	if e.path.Definite() {
+		if missing >= 0 {
+			return nil, false
+		}
+		value = nodes[0]
+	} else {
+		value = nodes
+	}
+
+	if e.path.fn != "" {
+		result, err := funcs[e.path.fn](value)
+		if err != nil {
+			return nil, false
+		}
+		return result, true
+	}
+	return value, true
+}
+
+func (e *notExpr) eval(root, current interface{}) (interface{}, bool) {
+	return !test(e.x, root, current), true
+}
+
+func (e *logicalExpr) eval(root, current interface{}) (interface{}, bool) {
+	if e.op == "&&" {
+		return test(e.left, root, current) && test(e.right, root, current), true
+	}
+	return test(e.left, root, current) || test(e.right, root, current), true
+}
+
+// eval 不存在的值只与不存在的值相等，大小比较要求两侧同为数值或同为字符串
+func (e *compareExpr) eval(root, current interface{}) (interface{}, bool) {
+	left, leftOk := e.left.eval(root, current)
+	right, rightOk := e.right.eval(root, current)
+
+	switch e.op {
+	case "==":
+		return leftOk == rightOk && (!leftOk || equal(left, right)), true
+	case "!=":
+		return !(leftOk == rightOk && (!leftOk || equal(left, right))), true
+	case "=~":
+		return leftOk && rightOk && match(left, right), true
+	}
+
+	if !leftOk || !rightOk {
+		return false, true
+	}
+	cmp, ok := compare(left, right)
+	if !ok {
+		return false, true
+	}
+	switch e.op {
+	case "<":
+		return cmp < 0, true
+	case "<=":
+		return cmp <= 0, true
+	case ">":
+		return cmp > 0, true
+	default:
+		return cmp >= 0, true
+	}
+}
+
+// equal 数值按大小比较，其他值深度比较
+func equal(left, right interface{}) bool {
+	if l, ok := toFloat(left); ok {
+		r, ok := toFloat(right)
+		return ok && l == r
+	}
+	return reflect.DeepEqual(left, right)
+}
+
+// compare 比较两个数值或两个字符串
+func compare(left, right interface{}) (int, bool) {
+	if l, ok := toFloat(left); ok {
+		r, ok := toFloat(right)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case l < r:
+			return -1, true
+		case l > r:
+			return 1, true
+		default:
+			return 0, true
+		}
+	}
+	l, ok := left.(string)
+	if !ok {
+		return 0, false
+	}
+	r, ok := right.(string)
+	if !ok {
+		return 0, false
+	}
+	switch {
+	case l < r:
+		return -1, true
+	case l > r:
+		return 1, true
+	default:
+		return 0, true
+	}
+}
+
+// match 正则匹配，右侧可以是正则字面量或字符串
+func match(left, right interface{}) bool {
+	s, ok := left.(string)
+	if !ok {
+		return false
+	}
+	switch re := right.(type) {
+	case *regexp.Regexp:
+		return re.MatchString(s)
+	case string:
+		matched, err := regexp.MatchString(re, s)
+		return err == nil && matched
+	}
+	return false
+}
+
+// funcs 路径末尾可用的函数
+var funcs = map[string]func(value interface{}) (interface{}, error){
+	"length": lengthFunc,
+	"size":   lengthFunc,
+	"min":    minFunc,
+	"max":    maxFunc,
+	"sum":    sumFunc,
+	"avg":    avgFunc,
+	"keys":   keysFunc,
+	"first":  firstFunc,
+	"last":   lastFunc,
+}
+
+// lengthFunc length() 字符串的字符数，数组和对象的元素数
+func lengthFunc(value interface{}) (interface{}, error) {
+	if s, ok := value.(string); ok {
+		return utf8.RuneCountInString(s), nil
+	}
+	rv := indirect(reflect.ValueOf(value))
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String:
+		return rv.Len(), nil
+	}
+	return nil, fmt.Errorf("length() 不支持 %T", value)
+}
+
+// minFunc min() 数组中的最小值
+func minFunc(value interface{}) (interface{}, error) {
+	numbers, err := numbersOf("min", value)
+	if err != nil {
+		return nil, err
+	}
+	result := math.Inf(1)
+	for _, n := range numbers {
+		result = math.Min(result, n)
+	}
+	return result, nil
+}
+
+// maxFunc max() 数组中的最大值
+func maxFunc(value interface{}) (interface{}, error) {
+	numbers, err := numbersOf("max", value)
+	if err != nil {
+		return nil, err
+	}
+	result := math.Inf(-1)
+	for _, n := range numbers {
+		result = math.Max(result, n)
+	}
+	return result, nil
+}
+
+// sumFunc sum() 数组元素之和
+func sumFunc(value interface{}) (interface{}, error) {
+	numbers, err := numbersOf("sum", value)
+	if err != nil {
+		return nil, err
+	}
+	var result float64
+	for _, n := range numbers {
+		result += n
+	}
+	return result, nil
+}
+
+// avgFunc avg() 数组元素的平均值
+func avgFunc(value interface{}) (interface{}, error) {
+	numbers, err := numbersOf("avg", value)
+	if err != nil {
+		return nil, err
+	}
+	var result float64
+	for _, n := range numbers {
+		result += n
+	}
+	return result / float64(len(numbers)), nil
+}
+
+// keysFunc keys() 对象的键，按字典序排列
+func keysFunc(value interface{}) (interface{}, error) {
+	rv := indirect(reflect.ValueOf(value))
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("keys() 需要对象, 实际为 %T", value)
+	}
+	keys := make([]string, 0, rv.Len())
+	for _, key := range rv.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	result := make([]interface{}, len(keys))
+	for i, key := range keys {
+		result[i] = key
+	}
+	return result, nil
+}
+
+// firstFunc first() 数组的第一个元素
+func firstFunc(value interface{}) (interface{}, error) {
+	items, ok := elements(value)
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("first() 需要非空数组")
+	}
+	return items[0], nil
+}
+
+// lastFunc last() 数组的最后一个元素
+func lastFunc(value interface{}) (interface{}, error) {
+	items, ok := elements(value)
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("last() 需要非空数组")
+	}
+	return items[len(items)-1], nil
+}
+
+// numbersOf 将非空数组转换为数值列表
+func numbersOf(name string, value interface{}) ([]float64, error) {
+	items, ok := elements(value)
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("%s() 需要非空数组", name)
+	}
+	numbers := make([]float64, len(items))
+	for i, item := range items {
+		n, ok := toFloat(item)
+		if !ok {
+			return nil, fmt.Errorf("%s() 的第 %d 个元素不是数值: %v", name, i+1, item)
+		}
+		numbers[i] = n
+	}
+	return numbers, nil
+}
@@ -0,0 +1,365 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// compiled 已编译路径的缓存
+var compiled sync.Map
+
+// Compile 编译 JSONPath 表达式
+// 支持 $ 根节点、.name 和 ['name'] 字段、[0] 和 [-1] 下标、.* 和 [*] 通配符、.. 递归下降、
+// [start:end:step] 切片、[?(@.status=='ok')] 过滤表达式，以及路径末尾的 length() 等函数；
+// 开头的 $ 可以省略，对数组使用数字字段名时按下标处理，兼容 $.data.0.name 的写法
+func Compile(path string) (*Path, error) {
+	if cached, ok := compiled.Load(path); ok {
+		return cached.(*Path), nil
+	}
+
+	p := &parser{src: strings.TrimSpace(path)}
+	result, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	result.raw = path
+	compiled.Store(path, result)
+	return result, nil
+}
+
+// Lookup 按路径从数据中取值，见 (*Path).Lookup
+func Lookup(data interface{}, path string) (interface{}, error) {
+	p, err := Compile(path)
+	if err != nil {
+		return nil, err
+	}
+	return p.Lookup(data)
+}
+
+// Exists 路径在数据中是否存在，路径无效时返回 false
+func Exists(data interface{}, path string) bool {
+	p, err := Compile(path)
+	if err != nil {
+		return false
+	}
+	return p.Exists(data)
+}
+
+// Get 返回路径匹配的所有值，没有匹配时返回空切片，不应用路径末尾的函数
+func (p *Path) Get(data interface{}) []interface{} {
+	nodes, _ := p.eval(data, data)
+	if nodes == nil {
+		return []interface{}{}
+	}
+	return nodes
+}
+
+// Lookup 按路径取值
+// 确定路径返回匹配的单个值，不存在时返回 NotFoundError；其他路径返回匹配值组成的数组；
+// 路径末尾有函数时，函数作用于上述结果
+func (p *Path) Lookup(data interface{}) (interface{}, error) {
+	nodes, missing := p.eval(data, data)
+
+	var value interface{}
+	if p.Definite() {
+		if missing >= 0 {
+			return nil, &NotFoundError{Path: p.raw, Missing: p.prefix(missing)}
+		}
+		value = nodes[0]
+	} else if nodes == nil {
+		value = []interface{}{}
+	} else {
+		value = nodes
+	}
+
+	if p.fn != "" {
+		return funcs[p.fn](value)
+	}
+	return value, nil
+}
+
+// Exists 路径是否匹配到值，值为 null 的字段也视为存在
+func (p *Path) Exists(data interface{}) bool {
+	if p.fn != "" {
+		_, err := p.Lookup(data)
+		return err == nil
+	}
+	nodes, _ := p.eval(data, data)
+	return len(nodes) > 0
+}
+
+// eval 从 current 开始逐段匹配，返回匹配的值和第一个没有匹配的段的下标，全部匹配时下标为 -1
+func (p *Path) eval(root, current interface{}) ([]interface{}, int) {
+	nodes := []interface{}{current}
+	for i := range p.segments {
+		seg := &p.segments[i]
+		var next []interface{}
+		for _, node := range nodes {
+			if seg.recursive {
+				for _, item := range descendants(node) {
+					next = append(next, seg.apply(root, item)...)
+				}
+				continue
+			}
+			next = append(next, seg.apply(root, node)...)
+		}
+		if len(next) == 0 {
+			return nil, i
+		}
+		nodes = next
+	}
+	return nodes, -1
+}
+
+// prefix 返回原始路径中到第 i 段为止的部分
+func (p *Path) prefix(i int) string {
+	text := strings.TrimSpace(p.raw)
+	end := p.segments[i].end
+	if end > len(text) {
+		return text
+	}
+	return text[:end]
+}
+
+// apply 对单个节点应用路径段
+func (s *segment) apply(root, node interface{}) []interface{} {
+	var result []interface{}
+	switch s.kind {
+	case segChild:
+		for _, name := range s.names {
+			if value, ok := child(node, name); ok {
+				result = append(result, value)
+			}
+		}
+
+	case segIndex:
+		items, ok := elements(node)
+		if !ok {
+			return nil
+		}
+		for _, index := range s.indexes {
+			if index < 0 {
+				index += len(items)
+			}
+			if index >= 0 && index < len(items) {
+				result = append(result, items[index])
+			}
+		}
+
+	case segWildcard:
+		result = children(node)
+
+	case segSlice:
+		items, ok := elements(node)
+		if !ok {
+			return nil
+		}
+		for _, i := range sliceIndexes(len(items), s.from, s.to, s.step) {
+			result = append(result, items[i])
+		}
+
+	case segFilter:
+		for _, item := range children(node) {
+			if test(s.filter, root, item) {
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
+
+// sliceIndexes 按 Python 切片的规则计算下标
+func sliceIndexes(length int, from, to *int, step int) []int {
+	normalize := func(i int) int {
+		if i < 0 {
+			i += length
+		}
+		return i
+	}
+
+	var indexes []int
+	if step > 0 {
+		start, end := 0, length
+		if from != nil {
+			start = max(normalize(*from), 0)
+		}
+		if to != nil {
+			end = min(normalize(*to), length)
+		}
+		for i := start; i < end; i += step {
+			indexes = append(indexes, i)
+		}
+		return indexes
+	}
+
+	start, end := length-1, -1
+	if from != nil {
+		start = min(normalize(*from), length-1)
+	}
+	if to != nil {
+		end = max(normalize(*to), -1)
+	}
+	for i := start; i > end; i += step {
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+// child 获取 map 的字段、结构体的字段或数组的元素（字段名为数字时）
+func child(value interface{}, name string) (interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		item, ok := v[name]
+		return item, ok
+	case []interface{}:
+		index, ok := atoi(name)
+		if !ok || index < 0 || index >= len(v) {
+			return nil, false
+		}
+		return v[index], true
+	}
+
+	rv := indirect(reflect.ValueOf(value))
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		item := rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key()))
+		if !item.IsValid() {
+			return nil, false
+		}
+		return item.Interface(), true
+	case reflect.Slice, reflect.Array:
+		index, ok := atoi(name)
+		if !ok || index < 0 || index >= rv.Len() {
+			return nil, false
+		}
+		return rv.Index(index).Interface(), true
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			field := rv.Type().Field(i)
+			if field.IsExported() && (fieldName(field) == name || field.Name == name) {
+				return rv.Field(i).Interface(), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// elements 获取数组的元素
+func elements(value interface{}) ([]interface{}, bool) {
+	if items, ok := value.([]interface{}); ok {
+		return items, true
+	}
+	rv := indirect(reflect.ValueOf(value))
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+	if rv.Type().Elem().Kind() == reflect.Uint8 {
+		// []byte 视为字符串而不是数组
+		return nil, false
+	}
+	items := make([]interface{}, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items, true
+}
+
+// children 获取所有直接子节点，map 按键排序以保证结果稳定
+func children(value interface{}) []interface{} {
+	if items, ok := elements(value); ok {
+		return items
+	}
+
+	rv := indirect(reflect.ValueOf(value))
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		items := make([]interface{}, 0, len(keys))
+		for _, key := range keys {
+			items = append(items, rv.MapIndex(key).Interface())
+		}
+		return items
+	case reflect.Struct:
+		var items []interface{}
+		for i := 0; i < rv.NumField(); i++ {
+			if rv.Type().Field(i).IsExported() {
+				items = append(items, rv.Field(i).Interface())
+			}
+		}
+		return items
+	}
+	return nil
+}
+
+// descendants 返回节点自身及其所有后代，先序遍历
+func descendants(value interface{}) []interface{} {
+	result := []interface{}{value}
+	for _, item := range children(value) {
+		result = append(result, descendants(item)...)
+	}
+	return result
+}
+
+// indirect 解引用指针和接口
+func indirect(rv reflect.Value) reflect.Value {
+	for rv.IsValid() && (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) {
+		if rv.IsNil() {
+			return reflect.Value{}
+		}
+		rv = rv.Elem()
+	}
+	return rv
+}
+
+// fieldName 结构体字段的 json 名称
+func fieldName(field reflect.StructField) string {
+	if tag, ok := field.Tag.Lookup("json"); ok {
+		if name, _, _ := strings.Cut(tag, ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// atoi 解析非负整数字段名
+func atoi(s string) (int, bool) {
+	if s == "" {
+		return 0, false
+	}
+	n := 0
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, true
+}
+
+// toFloat 将数值转换为 float64
+func toFloat(value interface{}) (float64, bool) {
+	if n, ok := value.(json.Number); ok {
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
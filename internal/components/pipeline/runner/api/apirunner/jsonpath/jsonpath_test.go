@@ -0,0 +1,248 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const testDocument = `{
+	"store": {
+		"book": [
+			{"category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95},
+			{"category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99},
+			{"category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99},
+			{"category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99}
+		],
+		"bicycle": {"color": "red", "price": 19.95}
+	},
+	"data": {"items": [{"id": 1, "status": "ok", "tags": ["a"]}, {"id": 2, "status": "fail", "tags": []}], "total": 2, "note": null},
+	"threshold": 10
+}`
+
+func testData(t *testing.T) interface{} {
+	t.Helper()
+	var data interface{}
+	if err := json.Unmarshal([]byte(testDocument), &data); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	return data
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want interface{}
+	}{
+		// 字段与下标
+		{name: "根节点字段", path: "$.threshold", want: float64(10)},
+		{name: "省略 $", path: "data.total", want: float64(2)},
+		{name: "以点号开头", path: ".data.total", want: float64(2)},
+		{name: "括号字段名", path: `$['store']["bicycle"].color`, want: "red"},
+		{name: "下标", path: "$.store.book[0].author", want: "Nigel Rees"},
+		{name: "数字字段名按下标处理", path: "$.data.items.1.id", want: float64(2)},
+		{name: "值为 null 的字段", path: "$.data.note", want: nil},
+
+		// 负数下标
+		{name: "负数下标取最后一个", path: "$.store.book[-1].title", want: "The Lord of the Rings"},
+		{name: "负数下标取倒数第二个", path: "$.store.book[-2].author", want: "Herman Melville"},
+		{name: "负数下标列表", path: "$.store.book[0,-1].price", want: []interface{}{8.95, 22.99}},
+		{name: "越界的负数下标在列表中被忽略", path: "$.store.book[-9,-4].price", want: []interface{}{8.95}},
+
+		// 切片
+		{name: "切片", path: "$.store.book[1:3].price", want: []interface{}{12.99, 8.99}},
+		{name: "负数切片起点", path: "$.store.book[-2:].price", want: []interface{}{8.99, 22.99}},
+		{name: "负数切片终点", path: "$.store.book[:-3].price", want: []interface{}{8.95}},
+		{name: "切片步长", path: "$.store.book[::2].price", want: []interface{}{8.95, 8.99}},
+		{name: "反向切片", path: "$.store.book[::-1].price", want: []interface{}{22.99, 8.99, 12.99, 8.95}},
+
+		// 通配符与递归下降
+		{name: "通配符", path: "$.store.book[*].author", want: []interface{}{"Nigel Rees", "Evelyn Waugh", "Herman Melville", "J. R. R. Tolkien"}},
+		{name: "对象通配符按键排序", path: "$.store.bicycle.*", want: []interface{}{"red", 19.95}},
+		{name: "递归下降", path: "$..price", want: []interface{}{19.95, 8.95, 12.99, 8.99, 22.99}},
+		{name: "递归下降下标", path: "$..book[-1].isbn", want: []interface{}{"0-395-19395-8"}},
+		{name: "多个字段名", path: "$.store.bicycle['color','price']", want: []interface{}{"red", 19.95}},
+		{name: "不确定路径没有匹配时返回空数组", path: "$.store.book[*].missing", want: []interface{}{}},
+
+		// 过滤表达式
+		{name: "过滤数值比较", path: "$.store.book[?(@.price < 10)].title", want: []interface{}{"Sayings of the Century", "Moby Dick"}},
+		{name: "过滤字符串相等", path: "$.data.items[?(@.status == 'ok')].id", want: []interface{}{float64(1)}},
+		{name: "过滤不等", path: `$.data.items[?(@.status != "ok")].id`, want: []interface{}{float64(2)}},
+		{name: "过滤字段存在", path: "$.store.book[?(@.isbn)].title", want: []interface{}{"Moby Dick", "The Lord of the Rings"}},
+		{name: "过滤字段不存在", path: "$.store.book[?(!@.isbn)].price", want: []interface{}{8.95, 12.99}},
+		{name: "过滤逻辑与", path: "$.store.book[?(@.category == 'fiction' && @.price < 20)].title", want: []interface{}{"Sword of Honour", "Moby Dick"}},
+		{name: "过滤逻辑或与括号", path: "$.store.book[?(@.price > 20 || (@.price < 9 && !@.isbn))].price", want: []interface{}{8.95, 22.99}},
+		{name: "过滤引用根节点", path: "$.store.book[?(@.price > $.threshold)].price", want: []interface{}{12.99, 22.99}},
+		{name: "过滤正则", path: "$.store.book[?(@.author =~ /^j\\. r/i)].title", want: []interface{}{"The Lord of the Rings"}},
+		{name: "过滤中使用函数", path: "$.data.items[?(@.tags.length() > 0)].id", want: []interface{}{float64(1)}},
+		{name: "过滤中的负数下标", path: "$.data.items[?(@.tags[-1] == 'a')].id", want: []interface{}{float64(1)}},
+		{name: "过滤不存在的字段与 null 不相等", path: "$.data.items[?(@.missing == null)].id", want: []interface{}{}},
+		{name: "过滤没有匹配", path: "$.store.book[?(@.price > 100)]", want: []interface{}{}},
+
+		// 函数
+		{name: "数组长度", path: "$.store.book.length()", want: 4},
+		{name: "字符串长度按字符计算", path: "$.store.bicycle.color.length()", want: 3},
+		{name: "不确定路径的长度", path: "$.store.book[?(@.isbn)].length()", want: 2},
+		{name: "最小值", path: "$..price.min()", want: 8.95},
+		{name: "求和", path: "$.data.items[*].id.sum()", want: float64(3)},
+		{name: "对象的键", path: "$.store.bicycle.keys()", want: []interface{}{"color", "price"}},
+		{name: "最后一个元素", path: "$.store.book[*].category.last()", want: "fiction"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Lookup(testData(t), tt.path)
+			if err != nil {
+				t.Fatalf("Lookup(%q) error = %v", tt.path, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lookup(%q) = %#v, want %#v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupNotFound(t *testing.T) {
+	tests := []struct {
+		path        string
+		wantMissing string
+	}{
+		{"$.nope", "$.nope"},
+		{"$.store.book[4].title", "$.store.book[4]"},
+		{"$.store.book[-5].title", "$.store.book[-5]"},
+		{"$.data.items.9", "$.data.items.9"},
+		{"data.total.value", "data.total.value"},
+		{"$.store.bicycle[0]", "$.store.bicycle[0]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			_, err := Lookup(testData(t), tt.path)
+			var notFound *NotFoundError
+			if !errors.As(err, &notFound) {
+				t.Fatalf("Lookup(%q) error = %v, want NotFoundError", tt.path, err)
+			}
+			if notFound.Missing != tt.wantMissing {
+				t.Errorf("Lookup(%q) missing = %q, want %q", tt.path, notFound.Missing, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"空路径", " "},
+		{"缺少 ]", "$.a[0"},
+		{"空的括号", "$.a[]"},
+		{"点号后缺少字段名", "$.a."},
+		{"递归下降后缺少字段名", "$..."},
+		{"切片步长为 0", "$.a[::0]"},
+		{"下标列表格式错误", "$.a[0,]"},
+		{"字符串未闭合", "$['a"},
+		{"未定义的函数", "$.a.reverse()"},
+		{"函数带参数", "$.a.length(1)"},
+		{"函数不在末尾", "$.a.length().b"},
+		{"过滤中使用单个等号", "$.a[?(@.b = 1)]"},
+		{"过滤缺少 )", "$.a[?(@.b == 1]"},
+		{"正则未闭合", "$.a[?(@.b =~ /x)]"},
+		{"无效的正则", "$.a[?(@.b =~ /(/)]"},
+		{"多余的内容", "$.a b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.path)
+			var syntax *SyntaxError
+			if !errors.As(err, &syntax) {
+				t.Errorf("Compile(%q) error = %v, want SyntaxError", tt.path, err)
+			}
+		})
+	}
+}
+
+func TestDefinite(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"$.a.b[0]", true},
+		{"$.a[-1]", true},
+		{"$.a.length()", true},
+		{"$.a[0,1]", false},
+		{"$.a[*]", false},
+		{"$..a", false},
+		{"$.a[1:]", false},
+		{"$.a[?(@.b)]", false},
+		{"$['a','b']", false},
+	}
+	for _, tt := range tests {
+		p, err := Compile(tt.path)
+		if err != nil {
+			t.Fatalf("Compile(%q) error = %v", tt.path, err)
+		}
+		if got := p.Definite(); got != tt.want {
+			t.Errorf("Definite(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestExists(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"$.data.note", true},
+		{"$.data.missing", false},
+		{"$.store.book[-1]", true},
+		{"$.store.book[?(@.price > 100)]", false},
+		{"$.data.items[1].tags.first()", false},
+		{"$.a[", false},
+	}
+	for _, tt := range tests {
+		if got := Exists(testData(t), tt.path); got != tt.want {
+			t.Errorf("Exists(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestLookupStruct(t *testing.T) {
+	type item struct {
+		ID   int    `json:"id"`
+		Name string `json:"name,omitempty"`
+		note string
+	}
+	data := map[string]interface{}{
+		"items": []item{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}},
+		"bytes": []byte("raw"),
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want interface{}
+	}{
+		{name: "json 标签", path: "$.items[-1].name", want: "b"},
+		{name: "字段名", path: "$.items[0].ID", want: 1},
+		{name: "过滤结构体", path: "$.items[?(@.id >= 2)].name", want: []interface{}{"b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Lookup(data, tt.path)
+			if err != nil {
+				t.Fatalf("Lookup(%q) error = %v", tt.path, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lookup(%q) = %#v, want %#v", tt.path, got, tt.want)
+			}
+		})
+	}
+
+	for _, path := range []string{"$.items[0].note", "$.bytes[0]"} {
+		if _, err := Lookup(data, path); err == nil {
+			t.Errorf("Lookup(%q) error = nil, want NotFoundError", path)
+		}
+	}
+}
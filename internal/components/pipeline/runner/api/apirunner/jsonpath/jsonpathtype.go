@@ -0,0 +1,152 @@
+package jsonpath
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// SyntaxError 路径语法错误
+type SyntaxError struct {
+	// 原始路径
+	Path string
+	// 出错位置
+	Pos int
+	// 错误描述
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("JSONPath 语法错误: %s, 位置 %d, 路径: %s", e.Message, e.Pos, e.Path)
+}
+
+// NotFoundError 确定路径在数据中不存在
+type NotFoundError struct {
+	// 原始路径
+	Path string
+	// 路径中第一个不存在的部分
+	Missing string
+}
+
+func (e *NotFoundError) Error() string {
+	if e.Missing == "" || e.Missing == e.Path {
+		return fmt.Sprintf("路径 %s 不存在", e.Path)
+	}
+	return fmt.Sprintf("路径 %s 不存在 (%s 不存在)", e.Path, e.Missing)
+}
+
+// segmentKind 路径段的类型
+type segmentKind int
+
+const (
+	// segChild 字段，.name 或 ['a','b']；对数组使用数字字段名时按下标处理，兼容 $.data.0.name
+	segChild segmentKind = iota
+	// segIndex 数组下标，[0] 或 [0,-1]，负数从末尾计数
+	segIndex
+	// segWildcard 通配符，.* 或 [*]
+	segWildcard
+	// segSlice 数组切片，[start:end:step]
+	segSlice
+	// segFilter 过滤表达式，[?(@.status=='ok')]
+	segFilter
+)
+
+// segment 路径中的一段
+type segment struct {
+	kind segmentKind
+
+	// 是否通过 .. 递归下降匹配
+	recursive bool
+
+	// 字段名，segChild 使用
+	names []string
+
+	// 下标，segIndex 使用
+	indexes []int
+
+	// 切片范围，segSlice 使用，from/to 为空表示未指定
+	from, to *int
+	step     int
+
+	// 过滤表达式，segFilter 使用
+	filter expr
+
+	// 该段在原始路径中的结束位置，用于标注不存在的部分
+	end int
+}
+
+// definite 是否只匹配单个值
+func (s *segment) definite() bool {
+	if s.recursive {
+		return false
+	}
+	switch s.kind {
+	case segChild:
+		return len(s.names) == 1
+	case segIndex:
+		return len(s.indexes) == 1
+	default:
+		return false
+	}
+}
+
+// Path 编译后的 JSONPath
+type Path struct {
+	raw      string
+	segments []segment
+
+	// 路径末尾的函数，如 length()
+	fn string
+}
+
+// String 返回原始路径
+func (p *Path) String() string {
+	return p.raw
+}
+
+// Definite 路径是否只匹配单个值，包含通配符、递归下降、切片、过滤或多个字段/下标时为否
+func (p *Path) Definite() bool {
+	for i := range p.segments {
+		if !p.segments[i].definite() {
+			return false
+		}
+	}
+	return true
+}
+
+// expr 过滤表达式中的节点，返回值和值是否存在
+type expr interface {
+	eval(root, current interface{}) (interface{}, bool)
+}
+
+// literalExpr 字面量
+type literalExpr struct {
+	value interface{}
+}
+
+// pathExpr 相对当前节点(@)或根节点($)的路径
+type pathExpr struct {
+	path     *Path
+	fromRoot bool
+}
+
+// notExpr 逻辑非
+type notExpr struct {
+	x expr
+}
+
+// logicalExpr 逻辑与、逻辑或
+type logicalExpr struct {
+	op          string
+	left, right expr
+}
+
+// compareExpr 比较，支持 == != < <= > >= 和正则匹配 =~
+type compareExpr struct {
+	op          string
+	left, right expr
+}
+
+// regexExpr 正则字面量，/pattern/flags
+type regexExpr struct {
+	re *regexp.Regexp
+}
@@ -0,0 +1,497 @@
+package jsonpath
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// nameStop 字段名的结束字符
+const nameStop = ".[]() \t\r\n=!<>&|,'\""
+
+// parser 路径和过滤表达式的解析器
+type parser struct {
+	src string
+	pos int
+}
+
+// parse 解析完整路径，路径可以省略开头的 $，如 data.items[0] 等价于 $.data.items[0]
+func (p *parser) parse() (*Path, error) {
+	path := &Path{raw: p.src}
+	if p.pos >= len(p.src) {
+		return nil, p.errorf("路径为空")
+	}
+
+	switch p.src[p.pos] {
+	case '$':
+		p.pos++
+	case '.', '[':
+		// 省略 $ 的路径
+	default:
+		// 以字段名开头的路径
+		name := p.parseName()
+		if name == "" {
+			return nil, p.errorf("无法解析的字符 %q", p.src[p.pos])
+		}
+		if p.peek('(') {
+			return nil, p.errorf("函数 %s 需要跟在路径之后", name)
+		}
+		path.segments = append(path.segments, segment{kind: segChild, names: []string{name}, end: p.pos})
+	}
+
+	if err := p.parseSegments(path); err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.src) {
+		return nil, p.errorf("无法解析的内容 %q", p.src[p.pos:])
+	}
+	return path, nil
+}
+
+// parseSegments 解析 $ 或 @ 之后的路径段，遇到无法作为路径段的字符时停止
+func (p *parser) parseSegments(path *Path) error {
+	for p.pos < len(p.src) {
+		var seg segment
+		switch {
+		case strings.HasPrefix(p.src[p.pos:], ".."):
+			p.pos += 2
+			var err error
+			switch {
+			case p.peek('['):
+				seg, err = p.parseBracket()
+				if err != nil {
+					return err
+				}
+			case p.peek('*'):
+				p.pos++
+				seg = segment{kind: segWildcard}
+			default:
+				name := p.parseName()
+				if name == "" {
+					return p.errorf(".. 之后缺少字段名")
+				}
+				seg = segment{kind: segChild, names: []string{name}}
+			}
+			seg.recursive = true
+
+		case p.peek('.'):
+			p.pos++
+			if p.peek('*') {
+				p.pos++
+				seg = segment{kind: segWildcard}
+				break
+			}
+			name := p.parseName()
+			if name == "" {
+				return p.errorf(". 之后缺少字段名")
+			}
+			if p.peek('(') {
+				return p.parseFunc(path, name)
+			}
+			seg = segment{kind: segChild, names: []string{name}}
+
+		case p.peek('['):
+			var err error
+			seg, err = p.parseBracket()
+			if err != nil {
+				return err
+			}
+
+		default:
+			return nil
+		}
+
+		seg.end = p.pos
+		path.segments = append(path.segments, seg)
+	}
+	return nil
+}
+
+// parseFunc 解析路径末尾的函数调用，如 .length()
+func (p *parser) parseFunc(path *Path, name string) error {
+	if _, ok := funcs[name]; !ok {
+		return p.errorf("未定义的函数 %s", name)
+	}
+	p.pos++
+	p.skipSpaces()
+	if !p.peek(')') {
+		return p.errorf("函数 %s 不接受参数", name)
+	}
+	p.pos++
+	if p.peek('.') || p.peek('[') {
+		return p.errorf("函数 %s 必须位于路径末尾", name)
+	}
+	path.fn = name
+	return nil
+}
+
+// parseBracket 解析 [...]：通配符、过滤表达式、字段名列表、下标列表或切片
+func (p *parser) parseBracket() (segment, error) {
+	p.pos++
+	p.skipSpaces()
+
+	var seg segment
+	switch {
+	case p.peek('*'):
+		p.pos++
+		seg = segment{kind: segWildcard}
+
+	case p.peek('?'):
+		p.pos++
+		filter, err := p.parseOr()
+		if err != nil {
+			return seg, err
+		}
+		seg = segment{kind: segFilter, filter: filter}
+
+	case p.peek('\'') || p.peek('"'):
+		seg = segment{kind: segChild}
+		for {
+			name, err := p.parseQuoted()
+			if err != nil {
+				return seg, err
+			}
+			seg.names = append(seg.names, name)
+			p.skipSpaces()
+			if !p.peek(',') {
+				break
+			}
+			p.pos++
+			p.skipSpaces()
+		}
+
+	default:
+		var err error
+		seg, err = p.parseIndexOrSlice()
+		if err != nil {
+			return seg, err
+		}
+	}
+
+	p.skipSpaces()
+	if !p.peek(']') {
+		return seg, p.errorf("缺少 ]")
+	}
+	p.pos++
+	return seg, nil
+}
+
+// parseIndexOrSlice 解析 [0]、[0,2,-1] 或 [start:end:step]
+func (p *parser) parseIndexOrSlice() (segment, error) {
+	first, err := p.parseOptionalInt()
+	if err != nil {
+		return segment{}, err
+	}
+
+	p.skipSpaces()
+	if p.peek(':') {
+		seg := segment{kind: segSlice, from: first, step: 1}
+		p.pos++
+		p.skipSpaces()
+		if seg.to, err = p.parseOptionalInt(); err != nil {
+			return seg, err
+		}
+		p.skipSpaces()
+		if p.peek(':') {
+			p.pos++
+			p.skipSpaces()
+			step, err := p.parseOptionalInt()
+			if err != nil {
+				return seg, err
+			}
+			if step != nil {
+				if *step == 0 {
+					return seg, p.errorf("切片步长不能为 0")
+				}
+				seg.step = *step
+			}
+		}
+		return seg, nil
+	}
+
+	if first == nil {
+		return segment{}, p.errorf("[] 中需要字段名、下标、切片、* 或过滤表达式")
+	}
+	seg := segment{kind: segIndex, indexes: []int{*first}}
+	for {
+		p.skipSpaces()
+		if !p.peek(',') {
+			return seg, nil
+		}
+		p.pos++
+		p.skipSpaces()
+		index, err := p.parseOptionalInt()
+		if err != nil {
+			return seg, err
+		}
+		if index == nil {
+			return seg, p.errorf("下标列表格式错误")
+		}
+		seg.indexes = append(seg.indexes, *index)
+	}
+}
+
+// parseOptionalInt 解析可选的整数，没有数字时返回 nil
+func (p *parser) parseOptionalInt() (*int, error) {
+	start := p.pos
+	if p.peek('-') {
+		p.pos++
+	}
+	for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+		p.pos++
+	}
+	if p.pos == start {
+		return nil, nil
+	}
+	text := p.src[start:p.pos]
+	n, err := strconv.Atoi(text)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("无效的下标 %q", text)
+	}
+	return &n, nil
+}
+
+// parseName 解析点号之后的字段名
+func (p *parser) parseName() string {
+	start := p.pos
+	for p.pos < len(p.src) && !strings.ContainsRune(nameStop, rune(p.src[p.pos])) {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+// parseQuoted 解析单引号或双引号字符串，支持反斜杠转义
+func (p *parser) parseQuoted() (string, error) {
+	quote := p.src[p.pos]
+	start := p.pos
+	var sb strings.Builder
+	for p.pos++; p.pos < len(p.src); p.pos++ {
+		c := p.src[p.pos]
+		switch {
+		case c == '\\' && p.pos+1 < len(p.src):
+			if quote == '"' {
+				sb.WriteByte(c)
+			}
+			p.pos++
+			sb.WriteByte(p.src[p.pos])
+		case c == quote:
+			p.pos++
+			if quote == '\'' {
+				return sb.String(), nil
+			}
+			s, err := strconv.Unquote(`"` + sb.String() + `"`)
+			if err != nil {
+				p.pos = start
+				return "", p.errorf("字符串格式错误")
+			}
+			return s, nil
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	p.pos = start
+	return "", p.errorf("字符串缺少结束引号")
+}
+
+// parseOr 解析 ||
+func (p *parser) parseOr() (expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if !strings.HasPrefix(p.src[p.pos:], "||") {
+			return left, nil
+		}
+		p.pos += 2
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{op: "||", left: left, right: right}
+	}
+}
+
+// parseAnd 解析 &&
+func (p *parser) parseAnd() (expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if !strings.HasPrefix(p.src[p.pos:], "&&") {
+			return left, nil
+		}
+		p.pos += 2
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{op: "&&", left: left, right: right}
+	}
+}
+
+// parseUnary 解析 !
+func (p *parser) parseUnary() (expr, error) {
+	p.skipSpaces()
+	if p.peek('!') && !strings.HasPrefix(p.src[p.pos:], "!=") {
+		p.pos++
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{x: x}, nil
+	}
+	return p.parseComparison()
+}
+
+// compareOps 比较运算符，长的在前
+var compareOps = []string{"==", "!=", "<=", ">=", "=~", "<", ">"}
+
+// parseComparison 解析比较表达式
+func (p *parser) parseComparison() (expr, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	for _, op := range compareOps {
+		if !strings.HasPrefix(p.src[p.pos:], op) {
+			continue
+		}
+		p.pos += len(op)
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		return &compareExpr{op: op, left: left, right: right}, nil
+	}
+	if p.peek('=') {
+		return nil, p.errorf("比较需要使用 ==")
+	}
+	return left, nil
+}
+
+// keywordValues 过滤表达式中的关键字字面量
+var keywordValues = map[string]interface{}{"true": true, "false": false, "null": nil}
+
+// parsePrimary 解析括号、路径、字面量和正则
+func (p *parser) parsePrimary() (expr, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.src) {
+		return nil, p.errorf("过滤表达式不完整")
+	}
+
+	c := p.src[p.pos]
+	switch {
+	case c == '(':
+		p.pos++
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if !p.peek(')') {
+			return nil, p.errorf("缺少 )")
+		}
+		p.pos++
+		return x, nil
+
+	case c == '@' || c == '$':
+		p.pos++
+		path := &Path{raw: p.src}
+		if err := p.parseSegments(path); err != nil {
+			return nil, err
+		}
+		return &pathExpr{path: path, fromRoot: c == '$'}, nil
+
+	case c == '\'' || c == '"':
+		s, err := p.parseQuoted()
+		if err != nil {
+			return nil, err
+		}
+		return &literalExpr{value: s}, nil
+
+	case c == '/':
+		return p.parseRegex()
+
+	case c == '-' || (c >= '0' && c <= '9'):
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.src) && strings.ContainsRune("0123456789.eE+-", rune(p.src[p.pos])) {
+			p.pos++
+		}
+		n, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+		if err != nil {
+			p.pos = start
+			return nil, p.errorf("无效的数字")
+		}
+		return &literalExpr{value: n}, nil
+	}
+
+	for _, keyword := range []string{"true", "false", "null"} {
+		if strings.HasPrefix(p.src[p.pos:], keyword) {
+			p.pos += len(keyword)
+			return &literalExpr{value: keywordValues[keyword]}, nil
+		}
+	}
+	return nil, p.errorf("无法解析的过滤表达式 %q", p.src[p.pos:])
+}
+
+// parseRegex 解析 /pattern/flags，flags 支持 i、m、s
+func (p *parser) parseRegex() (expr, error) {
+	start := p.pos
+	var sb strings.Builder
+	for p.pos++; p.pos < len(p.src); p.pos++ {
+		c := p.src[p.pos]
+		if c == '\\' && p.pos+1 < len(p.src) && p.src[p.pos+1] == '/' {
+			p.pos++
+			sb.WriteByte('/')
+			continue
+		}
+		if c != '/' {
+			sb.WriteByte(c)
+			continue
+		}
+
+		p.pos++
+		flags := ""
+		for p.pos < len(p.src) && strings.ContainsRune("ims", rune(p.src[p.pos])) {
+			flags += string(p.src[p.pos])
+			p.pos++
+		}
+		pattern := sb.String()
+		if flags != "" {
+			pattern = "(?" + flags + ")" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			p.pos = start
+			return nil, p.errorf("无效的正则表达式: %v", err)
+		}
+		return &regexExpr{re: re}, nil
+	}
+	p.pos = start
+	return nil, p.errorf("正则表达式缺少结束的 /")
+}
+
+// peek 当前字符是否为 c
+func (p *parser) peek(c byte) bool {
+	return p.pos < len(p.src) && p.src[p.pos] == c
+}
+
+// skipSpaces 跳过空白
+func (p *parser) skipSpaces() {
+	for p.pos < len(p.src) && strings.ContainsRune(" \t\r\n", rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+// errorf 构建当前位置的语法错误
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{Path: p.src, Pos: p.pos, Message: fmt.Sprintf(format, args...)}
+}
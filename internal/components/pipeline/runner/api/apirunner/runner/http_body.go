@@ -0,0 +1,280 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	urls "net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner/template"
+)
+
+// 请求体类型
+const (
+	BodyTypeJSON      = "json"
+	BodyTypeForm      = "form"
+	BodyTypeMultipart = "multipart"
+	BodyTypeRaw       = "raw"
+	BodyTypeText      = "text"
+	BodyTypeXML       = "xml"
+	BodyTypeBinary    = "binary"
+)
+
+// MultipartPart multipart 请求体中的一个部分，配置了 filename、path、base64 或 content 之一时为文件，否则为文本字段
+type MultipartPart struct {
+	// 字段名
+	Name string `json:"name"`
+
+	// 文本字段的值
+	Value string `json:"value,omitempty"`
+
+	// 文件名，为空时取 path 的文件名
+	Filename string `json:"filename,omitempty"`
+
+	// 文件的内容类型，为空时使用 application/octet-stream
+	ContentType string `json:"content_type,omitempty"`
+
+	// 文件内容来源：本地文件路径、base64 编码或文本内容
+	Path    string `json:"path,omitempty"`
+	Base64  string `json:"base64,omitempty"`
+	Content string `json:"content,omitempty"`
+
+	// 读取后的文件内容
+	data []byte
+}
+
+// isFile 是否为文件部分
+func (p *MultipartPart) isFile() bool {
+	return p.Filename != "" || p.Path != "" || p.Base64 != "" || p.Content != ""
+}
+
+// load 读取文件内容
+func (p *MultipartPart) load() error {
+	if !p.isFile() {
+		return nil
+	}
+
+	var data []byte
+	var err error
+	switch {
+	case p.Path != "":
+		data, err = os.ReadFile(p.Path)
+		if err != nil {
+			return fmt.Errorf("读取文件 %s 失败: %w", p.Path, err)
+		}
+		if p.Filename == "" {
+			p.Filename = filepath.Base(p.Path)
+		}
+	case p.Base64 != "":
+		data, err = base64.StdEncoding.DecodeString(p.Base64)
+		if err != nil {
+			return fmt.Errorf("base64 解码失败: %w", err)
+		}
+	default:
+		data = []byte(p.Content)
+	}
+	if p.Filename == "" {
+		p.Filename = p.Name
+	}
+	if p.ContentType == "" {
+		p.ContentType = "application/octet-stream"
+	}
+	p.data = data
+	return nil
+}
+
+// BinarySource 二进制请求体的来源，path、base64、content 三选一
+type BinarySource struct {
+	Path    string `json:"path,omitempty"`
+	Base64  string `json:"base64,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// parseMultipartParts 解析 multipart 请求体
+// 支持按顺序排列的部分数组，或 字段名 -> 文本值/文件配置 的映射（按字段名排序）
+func parseMultipartParts(body interface{}) ([]*MultipartPart, error) {
+	var parts []*MultipartPart
+	switch v := body.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		for i, item := range v {
+			part := &MultipartPart{}
+			if err := decodeBodyConfig(item, part); err != nil {
+				return nil, fmt.Errorf("multipart 第 %d 个部分格式错误: %w", i+1, err)
+			}
+			if part.Name == "" {
+				return nil, fmt.Errorf("multipart 第 %d 个部分缺少字段名", i+1)
+			}
+			parts = append(parts, part)
+		}
+	case map[string]interface{}:
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			part := &MultipartPart{}
+			if obj, ok := v[name].(map[string]interface{}); ok {
+				if err := decodeBodyConfig(obj, part); err != nil {
+					return nil, fmt.Errorf("multipart 字段 %s 格式错误: %w", name, err)
+				}
+			} else {
+				value, err := template.Stringify(v[name])
+				if err != nil {
+					return nil, fmt.Errorf("multipart 字段 %s: %w", name, err)
+				}
+				part.Value = value
+			}
+			part.Name = name
+			parts = append(parts, part)
+		}
+	default:
+		return nil, fmt.Errorf("multipart 请求体需要是数组或对象, 实际为 %T", body)
+	}
+
+	for _, part := range parts {
+		if err := part.load(); err != nil {
+			return nil, fmt.Errorf("multipart 字段 %s: %w", part.Name, err)
+		}
+	}
+	return parts, nil
+}
+
+// parseBinary 解析二进制请求体：字符串按原样发送，对象按 path/base64/content 读取
+func parseBinary(body interface{}) ([]byte, error) {
+	switch v := body.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	case map[string]interface{}:
+		var source BinarySource
+		if err := decodeBodyConfig(v, &source); err != nil {
+			return nil, fmt.Errorf("二进制请求体格式错误: %w", err)
+		}
+		switch {
+		case source.Path != "":
+			data, err := os.ReadFile(source.Path)
+			if err != nil {
+				return nil, fmt.Errorf("读取文件 %s 失败: %w", source.Path, err)
+			}
+			return data, nil
+		case source.Base64 != "":
+			data, err := base64.StdEncoding.DecodeString(source.Base64)
+			if err != nil {
+				return nil, fmt.Errorf("base64 解码失败: %w", err)
+			}
+			return data, nil
+		default:
+			return []byte(source.Content), nil
+		}
+	default:
+		return nil, fmt.Errorf("二进制请求体需要是字符串或对象, 实际为 %T", body)
+	}
+}
+
+// encodeBody 按请求体的类型和 Content-Type 序列化请求体，返回实际使用的 Content-Type
+// multipart 请求体的 Content-Type 带有本次生成的 boundary
+func encodeBody(body interface{}, contentType string) ([]byte, string, error) {
+	switch v := body.(type) {
+	case nil:
+		return nil, contentType, nil
+	case []byte:
+		return v, contentType, nil
+	case string:
+		return []byte(v), contentType, nil
+	case []*MultipartPart:
+		return encodeMultipart(v)
+	case map[string]interface{}:
+		if strings.Contains(contentType, "application/x-www-form-urlencoded") {
+			formValues := urls.Values{}
+			for k, item := range v {
+				value, err := template.Stringify(item)
+				if err != nil {
+					return nil, "", fmt.Errorf("表单字段 %s: %w", k, err)
+				}
+				formValues.Set(k, value)
+			}
+			return []byte(formValues.Encode()), contentType, nil
+		}
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, "", fmt.Errorf("序列化JSON请求体失败: %w", err)
+	}
+	return data, contentType, nil
+}
+
+// encodeMultipart 按顺序写入文本字段和文件
+func encodeMultipart(parts []*MultipartPart) ([]byte, string, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	for _, part := range parts {
+		if !part.isFile() {
+			if err := writer.WriteField(part.Name, part.Value); err != nil {
+				return nil, "", fmt.Errorf("写入 multipart 字段 %s 失败: %w", part.Name, err)
+			}
+			continue
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+			escapeQuotes(part.Name), escapeQuotes(part.Filename)))
+		header.Set("Content-Type", part.ContentType)
+		w, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, "", fmt.Errorf("写入 multipart 文件 %s 失败: %w", part.Name, err)
+		}
+		if _, err := w.Write(part.data); err != nil {
+			return nil, "", fmt.Errorf("写入 multipart 文件 %s 失败: %w", part.Name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", fmt.Errorf("写入 multipart 请求体失败: %w", err)
+	}
+	return buf.Bytes(), writer.FormDataContentType(), nil
+}
+
+// decodeBodyConfig 将请求体中的配置对象解码为结构体，不允许未知字段
+func decodeBodyConfig(value interface{}, out interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(out)
+}
+
+// isTextContent 是否为可直接记录到原始请求中的文本内容
+func isTextContent(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	if contentType == "" || strings.HasPrefix(contentType, "text/") {
+		return true
+	}
+	for _, kind := range []string{"json", "xml", "x-www-form-urlencoded", "javascript"} {
+		if strings.Contains(contentType, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// escapeQuotes 转义 Content-Disposition 中的引号，与 mime/multipart 一致
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
@@ -0,0 +1,313 @@
+package runner
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	urls "net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner"
+)
+
+// capturedRequest 测试服务端收到的请求
+type capturedRequest struct {
+	contentType   string
+	contentLength int64
+	body          []byte
+}
+
+// sendBody 按接口定义构建并发送请求，返回服务端收到的请求
+func sendBody(t *testing.T, def *api.ApiDefinition, dependencies map[string]interface{}) *capturedRequest {
+	t.Helper()
+	got := &capturedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.contentType = r.Header.Get("Content-Type")
+		got.contentLength = r.ContentLength
+		got.body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if dependencies == nil {
+		dependencies = make(map[string]interface{})
+	}
+	dependencies[api.BaseURLVariable] = server.URL
+	if def.Method == "" {
+		def.Method = http.MethodPost
+	}
+	if def.Path == "" {
+		def.Path = "/upload"
+	}
+
+	r := NewHttpRunner(nil)
+	request, err := r.BuildRequest(context.Background(), def, dependencies)
+	if err != nil {
+		t.Fatalf("BuildRequest() error = %v", err)
+	}
+	if _, err := r.ExecuteRequest(context.Background(), request); err != nil {
+		t.Fatalf("ExecuteRequest() error = %v", err)
+	}
+	return got
+}
+
+// receivedPart 服务端解析出的 multipart 部分
+type receivedPart struct {
+	name, filename, contentType, data string
+}
+
+func TestHttpRunnerMultipartBody(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "report.csv")
+	if err := os.WriteFile(file, []byte("id,name\n1,a\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		body    interface{}
+		want    []receivedPart
+	}{
+		{
+			name: "部分数组按顺序发送",
+			body: []interface{}{
+				map[string]interface{}{"name": "title", "value": "{{title}}"},
+				map[string]interface{}{"name": "report", "path": file, "content_type": "text/csv"},
+				map[string]interface{}{"name": "avatar", "filename": "a.png", "base64": base64.StdEncoding.EncodeToString([]byte{0x89, 'P', 'N', 'G'})},
+				map[string]interface{}{"name": "note", "content": "hello"},
+			},
+			want: []receivedPart{
+				{name: "title", data: "周报"},
+				{name: "report", filename: "report.csv", contentType: "text/csv", data: "id,name\n1,a\n"},
+				{name: "avatar", filename: "a.png", contentType: "application/octet-stream", data: "\x89PNG"},
+				{name: "note", filename: "note", contentType: "application/octet-stream", data: "hello"},
+			},
+		},
+		{
+			name: "字段映射按字段名排序",
+			body: map[string]interface{}{
+				"size": float64(2),
+				"file": map[string]interface{}{"filename": `a"b.txt`, "content": "x"},
+			},
+			want: []receivedPart{
+				{name: "file", filename: `a"b.txt`, contentType: "application/octet-stream", data: "x"},
+				{name: "size", data: "2"},
+			},
+		},
+		{
+			name:    "配置的 Content-Type 替换为带 boundary 的值",
+			headers: map[string]string{"content-type": "multipart/form-data"},
+			body:    map[string]interface{}{"a": "1"},
+			want:    []receivedPart{{name: "a", data: "1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sendBody(t, &api.ApiDefinition{BodyType: BodyTypeMultipart, Headers: tt.headers, Body: tt.body},
+				map[string]interface{}{"title": "周报"})
+
+			mediaType, params, err := mime.ParseMediaType(got.contentType)
+			if err != nil {
+				t.Fatalf("Content-Type %q: %v", got.contentType, err)
+			}
+			if mediaType != "multipart/form-data" || params["boundary"] == "" {
+				t.Fatalf("Content-Type = %q, want multipart/form-data with boundary", got.contentType)
+			}
+			if got.contentLength != int64(len(got.body)) {
+				t.Errorf("Content-Length = %d, want %d", got.contentLength, len(got.body))
+			}
+
+			reader := multipart.NewReader(strings.NewReader(string(got.body)), params["boundary"])
+			var parts []receivedPart
+			for {
+				part, err := reader.NextPart()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("NextPart() error = %v", err)
+				}
+				data, _ := io.ReadAll(part)
+				received := receivedPart{name: part.FormName(), filename: part.FileName(), data: string(data)}
+				if received.filename != "" {
+					received.contentType = part.Header.Get("Content-Type")
+				}
+				parts = append(parts, received)
+			}
+
+			if len(parts) != len(tt.want) {
+				t.Fatalf("parts = %+v, want %+v", parts, tt.want)
+			}
+			for i := range parts {
+				if parts[i] != tt.want[i] {
+					t.Errorf("part[%d] = %+v, want %+v", i, parts[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHttpRunnerBinaryBody(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "data.bin")
+	if err := os.WriteFile(file, []byte{0, 1, 2, 0xff}, 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		headers         map[string]string
+		body            interface{}
+		wantContentType string
+		wantBody        string
+	}{
+		{name: "字符串原样发送", body: "raw bytes", wantContentType: "application/octet-stream", wantBody: "raw bytes"},
+		{name: "读取本地文件", body: map[string]interface{}{"path": file}, wantContentType: "application/octet-stream", wantBody: "\x00\x01\x02\xff"},
+		{name: "base64 解码", body: map[string]interface{}{"base64": "AAEC/w=="}, wantContentType: "application/octet-stream", wantBody: "\x00\x01\x02\xff"},
+		{name: "文本内容", body: map[string]interface{}{"content": "hello"}, wantContentType: "application/octet-stream", wantBody: "hello"},
+		{
+			name:            "保留配置的 Content-Type",
+			headers:         map[string]string{"Content-Type": "image/png"},
+			body:            map[string]interface{}{"base64": "iVBORw=="},
+			wantContentType: "image/png",
+			wantBody:        "\x89PNG",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sendBody(t, &api.ApiDefinition{BodyType: BodyTypeBinary, Headers: tt.headers, Body: tt.body}, nil)
+			if got.contentType != tt.wantContentType {
+				t.Errorf("Content-Type = %q, want %q", got.contentType, tt.wantContentType)
+			}
+			if string(got.body) != tt.wantBody {
+				t.Errorf("body = %q, want %q", got.body, tt.wantBody)
+			}
+			if got.contentLength != int64(len(tt.wantBody)) {
+				t.Errorf("Content-Length = %d, want %d", got.contentLength, len(tt.wantBody))
+			}
+		})
+	}
+}
+
+func TestHttpRunnerFormBody(t *testing.T) {
+	got := sendBody(t, &api.ApiDefinition{
+		BodyType: BodyTypeForm,
+		Body: map[string]interface{}{
+			"user":  "{{user}}",
+			"age":   float64(18),
+			"query": "a b&c=d",
+			"tags":  []interface{}{"x", "y"},
+		},
+	}, map[string]interface{}{"user": "张三"})
+
+	if got.contentType != "application/x-www-form-urlencoded" {
+		t.Errorf("Content-Type = %q, want %q", got.contentType, "application/x-www-form-urlencoded")
+	}
+	// 字段按名称排序编码，非字符串的值转为字符串或 JSON
+	want := "age=18&query=a+b%26c%3Dd&tags=%5B%22x%22%2C%22y%22%5D&user=%E5%BC%A0%E4%B8%89"
+	if string(got.body) != want {
+		t.Errorf("body = %q, want %q", got.body, want)
+	}
+	values, err := urls.ParseQuery(string(got.body))
+	if err != nil || values.Get("user") != "张三" || values.Get("query") != "a b&c=d" {
+		t.Errorf("ParseQuery(body) = %v, %v", values, err)
+	}
+}
+
+func TestHttpRunnerRawBody(t *testing.T) {
+	tests := []struct {
+		name            string
+		bodyType        string
+		headers         map[string]string
+		body            interface{}
+		wantContentType string
+		wantBody        string
+	}{
+		{name: "原始文本", bodyType: BodyTypeRaw, body: "id={{id}}", wantContentType: "text/plain; charset=utf-8", wantBody: "id=7"},
+		{name: "纯文本", bodyType: BodyTypeText, body: "hello", wantContentType: "text/plain; charset=utf-8", wantBody: "hello"},
+		{name: "XML", bodyType: BodyTypeXML, body: "<id>{{id}}</id>", wantContentType: "application/xml", wantBody: "<id>7</id>"},
+		{name: "对象序列化为 JSON 文本", bodyType: BodyTypeRaw, body: map[string]interface{}{"id": "{{id}}"}, wantContentType: "text/plain; charset=utf-8", wantBody: `{"id":7}`},
+		{
+			name:            "保留配置的 Content-Type",
+			bodyType:        BodyTypeRaw,
+			headers:         map[string]string{"Content-Type": "application/csv"},
+			body:            "a,b",
+			wantContentType: "application/csv",
+			wantBody:        "a,b",
+		},
+		{name: "JSON", bodyType: BodyTypeJSON, body: map[string]interface{}{"id": "{{id}}"}, wantContentType: "application/json", wantBody: `{"id":7}`},
+		{name: "默认为 JSON", body: []interface{}{"{{id}}"}, wantContentType: "application/json", wantBody: `[7]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sendBody(t, &api.ApiDefinition{BodyType: tt.bodyType, Headers: tt.headers, Body: tt.body},
+				map[string]interface{}{"id": float64(7)})
+			if got.contentType != tt.wantContentType {
+				t.Errorf("Content-Type = %q, want %q", got.contentType, tt.wantContentType)
+			}
+			if string(got.body) != tt.wantBody {
+				t.Errorf("body = %q, want %q", got.body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestHttpRunnerEmptyBody(t *testing.T) {
+	got := sendBody(t, &api.ApiDefinition{Method: http.MethodGet}, nil)
+	if got.contentType != "" || got.contentLength != 0 || len(got.body) != 0 {
+		t.Errorf("request = %+v, want no body and no Content-Type", got)
+	}
+}
+
+func TestParseMultipartPartsError(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    interface{}
+		wantErr string
+	}{
+		{name: "类型错误", body: "a=1", wantErr: "multipart 请求体需要是数组或对象, 实际为 string"},
+		{name: "缺少字段名", body: []interface{}{map[string]interface{}{"value": "1"}}, wantErr: "multipart 第 1 个部分缺少字段名"},
+		{name: "未知配置", body: []interface{}{map[string]interface{}{"name": "a", "file": "x"}}, wantErr: `multipart 第 1 个部分格式错误: json: unknown field "file"`},
+		{name: "base64 无效", body: map[string]interface{}{"a": map[string]interface{}{"base64": "!"}}, wantErr: "multipart 字段 a: base64 解码失败: illegal base64 data at input byte 0"},
+		{name: "文件不存在", body: map[string]interface{}{"a": map[string]interface{}{"path": "/nonexistent/x"}}, wantErr: "multipart 字段 a: 读取文件 /nonexistent/x 失败: open /nonexistent/x: no such file or directory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseMultipartParts(tt.body)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("parseMultipartParts() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseBinaryError(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    interface{}
+		wantErr string
+	}{
+		{name: "类型错误", body: float64(1), wantErr: "二进制请求体需要是字符串或对象, 实际为 float64"},
+		{name: "未知配置", body: map[string]interface{}{"file": "x"}, wantErr: `二进制请求体格式错误: json: unknown field "file"`},
+		{name: "base64 无效", body: map[string]interface{}{"base64": "!"}, wantErr: "base64 解码失败: illegal base64 data at input byte 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseBinary(tt.body)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("parseBinary() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
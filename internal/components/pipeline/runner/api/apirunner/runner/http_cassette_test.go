@@ -0,0 +1,107 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner"
+	"Storage/internal/components/pipeline/runner/api/apirunner/auth"
+	"Storage/internal/components/pipeline/runner/api/apirunner/cassette"
+)
+
+// sendWithCassette 使用磁带发送一次带认证的请求，返回响应
+func sendWithCassette(t *testing.T, tape *cassette.Cassette, baseURL string, config *auth.Config) map[string]interface{} {
+	t.Helper()
+	r := NewHttpRunner(nil)
+	r.SetCassette(tape)
+	def := &api.ApiDefinition{
+		Method:      http.MethodGet,
+		Path:        "/orders",
+		QueryParams: map[string]string{"page": "1"},
+	}
+	request, err := r.BuildRequest(context.Background(), def, map[string]interface{}{api.BaseURLVariable: baseURL})
+	if err != nil {
+		t.Fatalf("BuildRequest() error = %v", err)
+	}
+	if request["auth"], err = auth.NewProvider(config, nil); err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	response, err := r.ExecuteRequest(context.Background(), request)
+	if err != nil {
+		t.Fatalf("ExecuteRequest() error = %v", err)
+	}
+	return response
+}
+
+func TestHttpRunnerCassetteWithoutCredentials(t *testing.T) {
+	tests := []struct {
+		name   string
+		auth   *auth.Config
+		secret string
+	}{
+		{
+			name:   "Authorization 请求头",
+			auth:   &auth.Config{Type: auth.TypeBasic, Basic: &auth.BasicConfig{Username: "u", Password: "p-secret"}},
+			secret: "dTpwLXNlY3JldA==",
+		},
+		{
+			name:   "自定义请求头的 bearer",
+			auth:   &auth.Config{Type: auth.TypeBearer, Bearer: &auth.BearerConfig{Token: "t-secret", Header: "X-Token"}},
+			secret: "t-secret",
+		},
+		{
+			name:   "查询参数中的 api_key",
+			auth:   &auth.Config{Type: auth.TypeAPIKey, APIKey: &auth.APIKeyConfig{Name: "sk", Value: "k-secret", In: auth.InQuery}},
+			secret: "k-secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.SetCookie(w, &http.Cookie{Name: "sid", Value: "s-secret"})
+				w.Write([]byte(`{"total":1}`))
+			}))
+			dir := t.TempDir()
+			store := cassette.NewFileStore(dir)
+
+			recorder, err := cassette.New(&cassette.Config{Name: "orders", Mode: cassette.ModeRecord}, store)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if err := recorder.Load(context.Background()); err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			sendWithCassette(t, recorder, server.URL, tt.auth)
+			server.Close()
+
+			data, err := os.ReadFile(filepath.Join(dir, "orders.json"))
+			if err != nil {
+				t.Fatalf("read cassette file error = %v", err)
+			}
+			for _, secret := range []string{tt.secret, "s-secret"} {
+				if bytes.Contains(data, []byte(secret)) {
+					t.Errorf("cassette file contains %q:\n%s", secret, data)
+				}
+			}
+
+			// 回放时不添加认证信息，去掉凭证后的请求仍能匹配
+			player, err := cassette.New(&cassette.Config{Name: "orders", Mode: cassette.ModeReplay}, store)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if err := player.Load(context.Background()); err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			response := sendWithCassette(t, player, server.URL, tt.auth)
+			if response["status_code"] != http.StatusOK || response["body"] != `{"total":1}` {
+				t.Errorf("replayed response = %v %v", response["status_code"], response["body"])
+			}
+		})
+	}
+}
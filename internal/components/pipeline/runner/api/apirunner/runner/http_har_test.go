@@ -0,0 +1,183 @@
+package runner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner"
+	"Storage/internal/components/pipeline/runner/api/apirunner/auth"
+	"Storage/internal/components/pipeline/runner/api/apirunner/har"
+)
+
+// memoryRecorder 保存记录的 HAR 条目
+type memoryRecorder struct {
+	mu      sync.Mutex
+	entries []*har.Entry
+}
+
+func (r *memoryRecorder) Record(ctx context.Context, entry *har.Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func TestHttpRunnerHarRedactsCredentials(t *testing.T) {
+	// 服务端收到的凭证
+	var received http.Header
+	var receivedQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		receivedQuery = r.URL.RawQuery
+		http.SetCookie(w, &http.Cookie{Name: "sid", Value: "s-2", Path: "/"})
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		auth *auth.Config
+		// 服务端收到、记录中被遮盖的凭证
+		wantHeader string
+		wantQuery  string
+		secret     string
+	}{
+		{
+			name:       "自定义请求头的 bearer",
+			auth:       &auth.Config{Type: auth.TypeBearer, Bearer: &auth.BearerConfig{Token: "t-secret", Header: "X-Token"}},
+			wantHeader: "X-Token",
+			secret:     "t-secret",
+		},
+		{
+			name:      "查询参数中的 api_key",
+			auth:      &auth.Config{Type: auth.TypeAPIKey, APIKey: &auth.APIKeyConfig{Name: "sk", Value: "k-secret", In: auth.InQuery}},
+			wantQuery: "sk",
+			secret:    "k-secret",
+		},
+		{
+			name:       "hmac 签名请求头",
+			auth:       &auth.Config{Type: auth.TypeHMAC, HMAC: &auth.HMACConfig{Secret: "s", SignatureHeader: "X-Sign", SignatureFormat: "sig-{signature}"}},
+			wantHeader: "X-Sign",
+			secret:     "sig-",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session, err := api.NewSession(nil, nil)
+			if err != nil {
+				t.Fatalf("NewSession() error = %v", err)
+			}
+			defer session.Close()
+			// 先由响应设置会话 cookie
+			runStep(t, session, server.URL, "/login", nil)
+
+			recorder := &memoryRecorder{}
+			r := NewHttpRunner(nil)
+			r.SetSession(session)
+			r.SetHarRecorder(recorder)
+
+			def := &api.ApiDefinition{
+				Method:      http.MethodGet,
+				Path:        "/orders",
+				Headers:     map[string]string{"Proxy-Authorization": "Basic cHJveHk=", "X-Trace": "trace-1"},
+				QueryParams: map[string]string{"access_token": "a-secret", "page": "1"},
+			}
+			request, err := r.BuildRequest(context.Background(), def, map[string]interface{}{api.BaseURLVariable: server.URL})
+			if err != nil {
+				t.Fatalf("BuildRequest() error = %v", err)
+			}
+			// 步骤的认证
+			request["auth"], err = auth.NewProvider(tt.auth, nil)
+			if err != nil {
+				t.Fatalf("NewProvider() error = %v", err)
+			}
+			response, err := r.ExecuteRequest(context.Background(), request)
+			if err != nil {
+				t.Fatalf("ExecuteRequest() error = %v", err)
+			}
+
+			// 服务端收到未遮盖的凭证
+			if tt.wantHeader != "" && !strings.Contains(received.Get(tt.wantHeader), tt.secret) {
+				t.Errorf("server %s = %q, want %q", tt.wantHeader, received.Get(tt.wantHeader), tt.secret)
+			}
+			if tt.wantQuery != "" && !strings.Contains(receivedQuery, tt.wantQuery+"="+tt.secret) {
+				t.Errorf("server query = %q, want %s=%s", receivedQuery, tt.wantQuery, tt.secret)
+			}
+			if received.Get("Cookie") != "sid=s-2" {
+				t.Errorf("server Cookie = %q, want %q", received.Get("Cookie"), "sid=s-2")
+			}
+
+			if len(recorder.entries) != 1 {
+				t.Fatalf("recorded entries = %d, want 1", len(recorder.entries))
+			}
+			entry := recorder.entries[0]
+			headers := make(map[string]string)
+			for _, header := range entry.Request.Headers {
+				headers[header.Name] = header.Value
+			}
+			wantHeaders := map[string]string{
+				"Proxy-Authorization": har.Masked,
+				"Cookie":              "sid=" + har.Masked,
+				"X-Trace":             "trace-1",
+			}
+			if tt.wantHeader != "" {
+				wantHeaders[tt.wantHeader] = har.Masked
+			}
+			for name, want := range wantHeaders {
+				if got := headers[name]; got != want {
+					t.Errorf("HAR header %s = %q, want %q", name, got, want)
+				}
+			}
+			for _, cookie := range entry.Request.Cookies {
+				if cookie.Value != har.Masked {
+					t.Errorf("HAR cookie %s = %q, want masked", cookie.Name, cookie.Value)
+				}
+			}
+
+			query := make(map[string]string)
+			for _, param := range entry.Request.QueryString {
+				query[param.Name] = param.Value
+			}
+			wantQuery := map[string]string{"access_token": har.Masked, "page": "1"}
+			if tt.wantQuery != "" {
+				wantQuery[tt.wantQuery] = har.Masked
+			}
+			for name, want := range wantQuery {
+				if got := query[name]; got != want {
+					t.Errorf("HAR query %s = %q, want %q", name, got, want)
+				}
+				if !strings.Contains(entry.Request.URL, name+"="+want) {
+					t.Errorf("HAR url = %q, want %s=%s", entry.Request.URL, name, want)
+				}
+			}
+
+			for _, header := range entry.Response.Headers {
+				if header.Name == "Set-Cookie" && header.Value != "sid="+har.Masked+"; Path=/" {
+					t.Errorf("HAR Set-Cookie = %q, want masked value", header.Value)
+				}
+			}
+			for _, cookie := range entry.Response.Cookies {
+				if cookie.Value != har.Masked {
+					t.Errorf("HAR response cookie %s = %q, want masked", cookie.Name, cookie.Value)
+				}
+			}
+
+			// 原始请求和导出的 curl 命令中没有凭证
+			for name, text := range map[string]string{
+				"raw_request": response["raw_request"].(string),
+				"curl":        entry.Request.Curl(),
+			} {
+				for _, secret := range []string{tt.secret, "a-secret", "cHJveHk=", "s-2"} {
+					if strings.Contains(text, secret) {
+						t.Errorf("%s contains %q:\n%s", name, secret, text)
+					}
+				}
+			}
+		})
+	}
+}
@@ -1,21 +1,31 @@
 package runner
 
 import (
-	"Storage/internal/logic/tools"
-	"Storage/internal/logic/workflows/api"
-	"Storage/internal/logic/workflows/api/apirunner/dependency"
-	expect "Storage/internal/logic/workflows/api/apirunner/expect"
-	"Storage/internal/logic/workflows/api/apirunner/store"
-	"Storage/internal/logic/workflows/core"
-	"Storage/internal/logic/workflows/core/metrics/reporter"
+	"Storage/internal/components/pipeline/core"
+	"Storage/internal/components/pipeline/core/metrics/reporter"
+	"Storage/internal/components/pipeline/runner/api/apirunner"
+	"Storage/internal/components/pipeline/runner/api/apirunner/auth"
+	"Storage/internal/components/pipeline/runner/api/apirunner/cassette"
+	"Storage/internal/components/pipeline/runner/api/apirunner/dependency"
+	expect "Storage/internal/components/pipeline/runner/api/apirunner/expect"
+	"Storage/internal/components/pipeline/runner/api/apirunner/extract"
+	"Storage/internal/components/pipeline/runner/api/apirunner/har"
+	"Storage/internal/components/pipeline/runner/api/apirunner/store"
+	"Storage/internal/components/pipeline/runner/api/apirunner/template"
+	"Storage/internal/components/retry"
+	"Storage/internal/components/tools"
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptrace"
 	urls "net/url"
+	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -39,6 +49,34 @@ type HttpRunner struct {
 
 	// 指标上报器
 	metricsReporter reporter.MetricsReporter
+
+	// 请求锁
+	mu sync.Mutex
+
+	// 取消进行中的请求
+	cancelRequest context.CancelFunc
+
+	// 场景会话，设置后使用会话的客户端和默认请求头
+	session *api.Session
+
+	// 执行环境及其认证方式
+	environment *api.Environment
+	envAuth     auth.Provider
+
+	// OAuth2 令牌的缓存存储
+	tokenStore auth.TokenStore
+
+	// 记录每次请求和响应的 HAR 条目
+	harRecorder har.Recorder
+
+	// 录制或回放请求的磁带
+	cassette *cassette.Cassette
+
+	// 构建和发送请求的实现，Execute 的流程与协议无关
+	sender requestSender
+
+	// 是否已取消
+	canceled bool
 }
 
 // HttpMetricsReporter 扩展核心指标上报接口，专用于HTTP API指标
@@ -50,20 +88,130 @@ type HttpMetricsReporter interface {
 	ReportHttpMetrics(ctx context.Context, metrics *api.ApiMetrics) error
 }
 
+// requestSender 构建和发送请求，HTTP 和 gRPC 执行器分别实现，依赖准备、重试、断言和数据提取共用
+type requestSender interface {
+	BuildRequest(ctx context.Context, api *api.ApiDefinition, dependencies map[string]interface{}) (map[string]interface{}, error)
+	ExecuteRequest(ctx context.Context, request map[string]interface{}) (map[string]interface{}, error)
+	// statusError 响应的状态需要重试时返回错误，否则返回 nil
+	statusError(response map[string]interface{}) error
+}
+
+var _ api.ApiRunner = (*HttpRunner)(nil)
+
 // NewHttpRunner 创建新的HTTP执行器
 func NewHttpRunner(contextData map[string]interface{}) *HttpRunner {
 	if contextData == nil {
 		contextData = make(map[string]interface{})
 	}
 
-	return &HttpRunner{
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+	// 客户端不设超时，由上下文中任务、场景、步骤的超时预算控制
+	r := &HttpRunner{
+		client:      &http.Client{},
 		contextData: contextData,
 		status:      core.TaskStatusPending,
 		metrics:     &api.ApiMetrics{},
 	}
+	r.sender = r
+	return r
+}
+
+// SetSession 设置场景会话，为 nil 时恢复执行器自身的客户端
+func (r *HttpRunner) SetSession(session *api.Session) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.session = session
+}
+
+// SetTokenStore 设置 OAuth2 令牌的缓存存储，需在 SetEnvironment 之前设置
+func (r *HttpRunner) SetTokenStore(store auth.TokenStore) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokenStore = store
+}
+
+// SetEnvironment 设置执行环境，环境的变量和基础URL作为请求模板的变量，
+// 认证方式在场景和步骤未配置认证时使用，TLS 配置作用于执行器自身的客户端
+func (r *HttpRunner) SetEnvironment(env *api.Environment) error {
+	client := &http.Client{}
+	store := r.currentTokenStore()
+	var provider auth.Provider
+	if env != nil {
+		transport, err := env.TLS.NewTransport()
+		if err != nil {
+			return fmt.Errorf("环境 %s 的TLS配置错误: %w", env.Name, err)
+		}
+		if transport != nil {
+			client.Transport = transport
+		}
+		if provider, err = auth.NewProvider(env.Auth, store); err != nil {
+			return fmt.Errorf("环境 %s 的认证配置错误: %w", env.Name, err)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.client = client
+	r.environment = env
+	r.envAuth = provider
+	return nil
+}
+
+// SetHarRecorder 设置 HAR 条目的记录器，为 nil 时不记录
+func (r *HttpRunner) SetHarRecorder(recorder har.Recorder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.harRecorder = recorder
+}
+
+// SetCassette 设置录制或回放请求的磁带，为 nil 时正常发送请求
+func (r *HttpRunner) SetCassette(c *cassette.Cassette) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette = c
+}
+
+// currentCassette 当前的磁带，未设置时为 nil
+func (r *HttpRunner) currentCassette() *cassette.Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette
+}
+
+// recordHar 记录请求的 HAR 条目，请求被取消时仍然记录，记录失败只输出日志
+func (r *HttpRunner) recordHar(ctx context.Context, entry *har.Entry) {
+	r.mu.Lock()
+	recorder := r.harRecorder
+	r.mu.Unlock()
+	if recorder == nil {
+		return
+	}
+	if err := recorder.Record(context.WithoutCancel(ctx), entry); err != nil {
+		logx.Errorf("记录请求 %s %s 的HAR条目失败: %v", entry.Request.Method, entry.Request.URL, err)
+	}
+}
+
+// httpClient 发送请求使用的客户端，有场景会话时使用会话的客户端
+func (r *HttpRunner) httpClient() (*http.Client, *api.Session) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.session != nil {
+		return r.session.Client(), r.session
+	}
+	return r.client, nil
+}
+
+// currentTokenStore 当前的 OAuth2 令牌缓存存储，未设置时为 nil
+func (r *HttpRunner) currentTokenStore() auth.TokenStore {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.tokenStore
+}
+
+// currentEnvironment 当前的执行环境，未设置时为 nil
+func (r *HttpRunner) currentEnvironment() *api.Environment {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.environment
 }
 
 // SetMetricsReporter 设置指标上报器
@@ -73,6 +221,11 @@ func (r *HttpRunner) SetMetricsReporter(reporter reporter.MetricsReporter) {
 
 // Initialize 初始化执行器
 func (r *HttpRunner) Initialize(ctx context.Context) error {
+	r.mu.Lock()
+	r.canceled = false
+	r.cancelRequest = nil
+	r.mu.Unlock()
+
 	r.status = core.TaskStatusPending
 	r.metrics = &api.ApiMetrics{}
 	return nil
@@ -86,54 +239,52 @@ func (r *HttpRunner) Validate(ctx context.Context) error {
 
 // Execute 执行API请求
 func (r *HttpRunner) Execute(ctx context.Context, spec map[string]interface{}) (map[string]interface{}, error) {
-	// 将规格转换为API定义
-	apiDef, err := api.ConvertToApiDefinition(spec)
+	// 按schema校验并解码spec
+	apiSpec, err := api.DecodeApiSpec(spec)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert spec to API definition: %w", err)
+		return nil, fmt.Errorf("invalid API spec: %w", err)
 	}
+	apiDef := &apiSpec.ApiDefinition
 
-	// 准备依赖数据
-	dependencies := make([]dependency.Dependency, 0)
-	if deps, ok := spec["dependencies"].([]dependency.Dependency); ok {
-		dependencies = deps
-	} else if depsArray, ok := spec["dependencies"].([]interface{}); ok {
-		// 转换通用接口数组为依赖数组
-		for _, dep := range depsArray {
-			if depMap, ok := dep.(map[string]interface{}); ok {
-				dependencies = append(dependencies, dependency.Dependency{
-					DependID: fmt.Sprintf("%v", depMap["depend_id"]),
-					Name:     fmt.Sprintf("%v", depMap["name"]),
-					Type:     fmt.Sprintf("%v", depMap["type"]),
-					Value:    depMap["value"],
-				})
-			}
-		}
-	}
+	// 步骤的超时预算覆盖依赖准备、请求和断言
+	ctx, cancel := core.WithTimeout(ctx, core.TimeoutStep, apiSpec.StepTimeout())
+	defer cancel()
 
 	// 准备依赖数据
-	dependencyValues, err := r.PrepareDependencies(ctx, dependencies)
+	dependencyValues, err := r.PrepareDependencies(ctx, apiSpec.Dependencies)
 	if err != nil {
 		return nil, err
 	}
 
 	// 构建请求
-	request, err := r.BuildRequest(ctx, apiDef, dependencyValues)
+	request, err := r.sender.BuildRequest(ctx, apiDef, apiSpec.Scope(dependencyValues))
 	if err != nil {
 		return nil, err
 	}
 
-	// 执行请求
-	response, err := r.ExecuteRequest(ctx, request)
+	// 步骤配置的认证覆盖场景会话的认证
+	if apiSpec.Auth != nil {
+		provider, err := auth.NewProvider(apiSpec.Auth, r.currentTokenStore())
+		if err != nil {
+			return nil, fmt.Errorf("认证配置错误: %w", err)
+		}
+		request["auth"] = provider
+	}
+
+	// 执行请求，按重试配置对网络错误和可重试的状态码重试
+	requestPolicy := apiSpec.Retry.Policy()
+	response, err := r.executeWithRetry(ctx, request, requestPolicy)
 	if err != nil {
 		return nil, err
 	}
 
 	// 处理响应验证
 	assertions := make([]expect.Assertion, 0)
-	if assertList, ok := spec["assertions"].([]expect.Assertion); ok {
-		assertions = assertList
+	if len(apiSpec.Assertions) > 0 {
+		assertions = apiSpec.Assertions
 	}
 
+	// 默认分组不重试，避免断言失败时重复发送非幂等请求
 	assertGroups := expect.AssertionGroup{
 		Assertions: assertions,
 		Name:       "Default Assertions",
@@ -141,21 +292,29 @@ func (r *HttpRunner) Execute(ctx context.Context, spec map[string]interface{}) (
 			StopOnFirstFailure: false,
 			Timeout:            0,
 			Parallel:           false,
-			Retry: &expect.RetryConfig{
-				MaxRetries: 1,
-				Strategy:   expect.RetryLinearBackoff,
-			},
 		},
 		Description: "Default Assertions",
 	}
-	if groupList, ok := spec["assert_groups"].([]expect.AssertionGroup); ok {
-		assertGroups = groupList[0]
+	if len(apiSpec.AssertGroups) > 0 {
+		assertGroups = apiSpec.AssertGroups[0]
 	}
 
-	validationResult, err := r.ValidateResponse(ctx, response, &assertGroups)
+	// 断言未通过时按分组的重试配置重新发送请求后再次评估
+	validationResult, err := assertGroups.AssertWithRetry(ctx, func(ctx context.Context, attempt int) error {
+		fresh, err := r.executeWithRetry(ctx, request, requestPolicy)
+		if err != nil {
+			return err
+		}
+		response = fresh
+		return nil
+	})
+	r.recordAssertions(validationResult)
 	if err != nil {
 		// 将验证错误包含到响应中，但不中断执行
 		response["validation_error"] = err.Error()
+		if level := core.TimeoutLevelOf(err); level != "" {
+			response["timeout_level"] = string(level)
+		}
 	}
 
 	if validationResult != nil {
@@ -163,12 +322,7 @@ func (r *HttpRunner) Execute(ctx context.Context, spec map[string]interface{}) (
 	}
 
 	// 提取数据
-	extractors := make(map[string]string)
-	if ext, ok := spec["extractors"].(map[string]string); ok {
-		extractors = ext
-	}
-
-	extractedData, err := r.ExtractData(ctx, response, extractors)
+	extractedData, err := r.ExtractData(ctx, response, apiSpec.ExtractorList())
 	if err != nil {
 		return nil, err
 	}
@@ -179,8 +333,8 @@ func (r *HttpRunner) Execute(ctx context.Context, spec map[string]interface{}) (
 
 	// 上报指标（如果配置了）
 	reportConfig := &api.ReportConfig{}
-	if cfg, ok := spec["report_config"].(*api.ReportConfig); ok {
-		reportConfig = cfg
+	if apiSpec.ReportConfig != nil {
+		reportConfig = apiSpec.ReportConfig
 	}
 
 	if reportConfig.Enabled && r.metricsReporter != nil {
@@ -198,6 +352,13 @@ func (r *HttpRunner) Execute(ctx context.Context, spec map[string]interface{}) (
 func (r *HttpRunner) PrepareDependencies(ctx context.Context, dependencies []dependency.Dependency) (map[string]interface{}, error) {
 	result := make(map[string]interface{})
 
+	// 环境变量优先级最低，上下文数据和依赖覆盖同名变量
+	if env := r.currentEnvironment(); env != nil {
+		for k, v := range env.Scope() {
+			result[k] = v
+		}
+	}
+
 	// 复制上下文数据
 	for k, v := range r.contextData {
 		result[k] = v
@@ -207,8 +368,16 @@ func (r *HttpRunner) PrepareDependencies(ctx context.Context, dependencies []dep
 	for _, dep := range dependencies {
 		switch dep.Type {
 		case api.DependTypeVariable:
-			// 处理变量依赖
-			if value, ok := dep.Value.(string); ok {
+			// 处理变量依赖，未直接给值时按数据来源和获取策略获取
+			if dep.Value == nil && dep.Key.SourceType != "" {
+				value, err := dep.Key.Fetch(ctx, func(ctx context.Context) (interface{}, error) {
+					return r.fetchDependency(dep)
+				})
+				if err != nil {
+					return nil, fmt.Errorf("依赖 %s: %w", dep.Name, err)
+				}
+				result[dep.Name] = value
+			} else if value, ok := dep.Value.(string); ok {
 				result[dep.Name] = value
 			} else {
 				result[dep.Name] = dep.Value
@@ -224,68 +393,139 @@ func (r *HttpRunner) PrepareDependencies(ctx context.Context, dependencies []dep
 	return result, nil
 }
 
+// fetchDependency 从依赖的数据来源获取一次数据
+func (r *HttpRunner) fetchDependency(dep dependency.Dependency) (interface{}, error) {
+	switch dep.Key.SourceType {
+	case dependency.DataSourceEnv:
+		name := dep.Key.EnvName
+		if name == "" {
+			name = dep.Name
+		}
+		// 优先取执行环境的变量，其次取进程环境变量
+		if env := r.currentEnvironment(); env != nil {
+			if value, ok := env.Variables[name]; ok {
+				return value, nil
+			}
+		}
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return nil, fmt.Errorf("环境变量 %s 不存在", name)
+		}
+		return value, nil
+	case dependency.DataSourceScene:
+		// 前序步骤的数据以变量注入上下文
+		value, ok := r.contextData[dep.Name]
+		if !ok {
+			return nil, fmt.Errorf("场景数据 %s 不存在", dep.Name)
+		}
+		return value, nil
+	case dependency.DataSourceCustom:
+		return dep.Key.DefaultValue, nil
+	default:
+		return nil, retry.Permanent(fmt.Errorf("暂不支持的数据来源: %s", dep.Key.SourceType))
+	}
+}
+
 // BuildRequest 构建HTTP请求
+// 路径、请求头、查询参数和请求体中的 {{变量}}、{{函数()}} 按依赖数据渲染，请求体中的整值占位符保留原始类型；
+// 路径参数替换后与基础URL拼接
 func (r *HttpRunner) BuildRequest(ctx context.Context, api *api.ApiDefinition, dependencies map[string]interface{}) (map[string]interface{}, error) {
 	request := make(map[string]interface{})
 
-	// 设置请求方法
+	// 设置API信息和请求方法，API信息用于指标和 HAR 条目
+	request["api_id"] = api.ApiID
+	request["api_name"] = api.Name
 	request["method"] = api.Method
 
-	// 处理URL和路径参数
-	// TODO: 实现路径参数替换
-	request["url"] = api.Path
+	// 处理URL和路径参数，缺少路径参数或基础URL时在发送前失败
+	path, err := template.RenderString(api.Path, dependencies)
+	if err != nil {
+		return nil, fmt.Errorf("渲染请求路径失败: %w", err)
+	}
+	url, err := resolveRequestURL(api, path, dependencies)
+	if err != nil {
+		return nil, err
+	}
+	request["url"] = url
 
 	// 处理请求头
-	headers := make(map[string]string)
-	if api.Headers != nil {
-		for k, v := range api.Headers {
-			// TODO: 处理从依赖中替换的值
-			headers[k] = v
-		}
+	headers, err := template.RenderStringMap(api.Headers, dependencies)
+	if err != nil {
+		return nil, fmt.Errorf("渲染请求头失败: %w", err)
+	}
+	if headers == nil {
+		headers = make(map[string]string)
 	}
 	request["headers"] = headers
 
 	// 处理查询参数
-	queryParams := make(map[string]string)
-	if api.QueryParams != nil {
-		for k, v := range api.QueryParams {
-			// TODO: 处理从依赖中替换的值
-			queryParams[k] = v
-		}
+	queryParams, err := template.RenderStringMap(api.QueryParams, dependencies)
+	if err != nil {
+		return nil, fmt.Errorf("渲染查询参数失败: %w", err)
+	}
+	if queryParams == nil {
+		queryParams = make(map[string]string)
 	}
 	request["query_params"] = queryParams
 
 	// 处理请求体
+	body, err := template.RenderValue(api.Body, dependencies)
+	if err != nil {
+		return nil, fmt.Errorf("渲染请求体失败: %w", err)
+	}
 	switch api.BodyType {
-	case "json":
-		request["body"] = api.Body
+	case BodyTypeJSON:
+		request["body"] = body
 		if _, ok := headers["Content-Type"]; !ok {
 			headers["Content-Type"] = "application/json"
 		}
-	case "form":
+	case BodyTypeForm:
 		formData := make(map[string]interface{})
-		if body, ok := api.Body.(map[string]interface{}); ok {
+		if body, ok := body.(map[string]interface{}); ok {
 			formData = body
 		}
 		request["body"] = formData
 		if _, ok := headers["Content-Type"]; !ok {
 			headers["Content-Type"] = "application/x-www-form-urlencoded"
 		}
-	case "multipart":
-		// TODO: 处理多部分表单数据
+	case BodyTypeMultipart:
+		// 文件在构建时读取，boundary 在发送时生成
+		parts, err := parseMultipartParts(body)
+		if err != nil {
+			return nil, err
+		}
+		request["body"] = parts
 		if _, ok := headers["Content-Type"]; !ok {
 			headers["Content-Type"] = "multipart/form-data"
 		}
-	case "raw":
-		if raw, ok := api.Body.(string); ok {
+	case BodyTypeRaw, BodyTypeText, BodyTypeXML:
+		if body != nil {
+			raw, err := template.Stringify(body)
+			if err != nil {
+				return nil, fmt.Errorf("请求体: %w", err)
+			}
 			request["body"] = raw
 		}
-	case "binary":
-		// TODO: 处理二进制数据
+		if _, ok := headers["Content-Type"]; !ok {
+			if api.BodyType == BodyTypeXML {
+				headers["Content-Type"] = "application/xml"
+			} else {
+				headers["Content-Type"] = "text/plain; charset=utf-8"
+			}
+		}
+	case BodyTypeBinary:
+		data, err := parseBinary(body)
+		if err != nil {
+			return nil, err
+		}
+		request["body"] = data
+		if _, ok := headers["Content-Type"]; !ok {
+			headers["Content-Type"] = "application/octet-stream"
+		}
 	default:
 		// 默认为JSON
-		request["body"] = api.Body
-		if _, ok := headers["Content-Type"]; !ok && api.Body != nil {
+		request["body"] = body
+		if _, ok := headers["Content-Type"]; !ok && body != nil {
 			headers["Content-Type"] = "application/json"
 		}
 	}
@@ -301,22 +541,22 @@ func (r *HttpRunner) ExecuteRequest(ctx context.Context, request map[string]inte
 	method, _ := request["method"].(string)
 	url, _ := request["url"].(string)
 	headers, _ := request["headers"].(map[string]string)
-	body, _ := request["body"].(map[string]interface{})
+	body := request["body"]
 	queryParams, _ := request["query_params"].(map[string]string)
 
-	// 检查请求方法和URL
+	// 检查请求方法和URL，配置错误重试也不会成功
 	if method == "" {
-		return nil, fmt.Errorf("请求方法不能为空")
+		return nil, retry.Permanent(fmt.Errorf("请求方法不能为空"))
 	}
 	if url == "" {
-		return nil, fmt.Errorf("请求URL不能为空")
+		return nil, retry.Permanent(fmt.Errorf("请求URL不能为空"))
 	}
 
 	// 处理查询参数
 	if len(queryParams) > 0 {
 		urlObj, err := urls.Parse(url)
 		if err != nil {
-			return nil, fmt.Errorf("解析URL失败: %w", err)
+			return nil, retry.Permanent(fmt.Errorf("解析URL失败: %w", err))
 		}
 
 		// 获取现有查询参数
@@ -331,89 +571,159 @@ func (r *HttpRunner) ExecuteRequest(ctx context.Context, request map[string]inte
 	}
 
 	// 准备请求体
-	var reqBody []byte
-	var err error
-	var reqBodyReader *bytes.Reader
 	contentType := ""
-
-	if headers != nil {
-		for k, v := range headers {
-			if strings.ToLower(k) == "content-type" {
-				contentType = v
-				break
-			}
+	contentTypeKey := "Content-Type"
+	for k, v := range headers {
+		if strings.ToLower(k) == "content-type" {
+			contentType = v
+			contentTypeKey = k
+			break
 		}
 	}
 
-	if body != nil {
-		switch {
-		case strings.Contains(contentType, "application/json"):
-			reqBody, err = json.Marshal(body)
-			if err != nil {
-				return nil, fmt.Errorf("序列化JSON请求体失败: %w", err)
-			}
-		case strings.Contains(contentType, "application/x-www-form-urlencoded"):
-			formValues := urls.Values{}
-			for k, v := range body {
-				formValues.Set(k, fmt.Sprintf("%v", v))
-			}
-			reqBody = []byte(formValues.Encode())
-		default:
-			// 默认尝试JSON序列化
-			reqBody, err = json.Marshal(body)
-			if err != nil {
-				return nil, fmt.Errorf("序列化请求体失败: %w", err)
-			}
-		}
+	reqBody, bodyContentType, err := encodeBody(body, contentType)
+	if err != nil {
+		return nil, retry.Permanent(err)
 	}
 
+	// 没有请求体时不传 reader，有请求体时由 bytes.Reader 设置 Content-Length
+	var reqBodyReader io.Reader
 	if reqBody != nil {
 		reqBodyReader = bytes.NewReader(reqBody)
 	}
 
+	// 请求上下文由执行器持有，Cancel 时中断进行中的请求
+	reqCtx, err := r.beginRequest(ctx)
+	if err != nil {
+		return nil, retry.Permanent(err)
+	}
+	defer r.endRequest()
+
 	// 创建HTTP请求
-	req, err := http.NewRequestWithContext(ctx, method, url, reqBodyReader)
+	req, err := http.NewRequestWithContext(reqCtx, method, url, reqBodyReader)
 	if err != nil {
-		return nil, fmt.Errorf("创建HTTP请求失败: %w", err)
+		return nil, retry.Permanent(fmt.Errorf("创建HTTP请求失败: %w", err))
 	}
 
-	// 设置请求头
+	// 设置请求头，会话的默认请求头优先级最低，multipart 的 Content-Type 替换为带 boundary 的值
+	client, session := r.httpClient()
+	if session != nil {
+		for k, v := range session.DefaultHeaders() {
+			req.Header.Set(k, v)
+		}
+	}
 	for k, v := range headers {
 		req.Header.Set(k, v)
 	}
+	if bodyContentType != contentType {
+		req.Header.Del(contentTypeKey)
+		req.Header.Set("Content-Type", bodyContentType)
+	}
 
-	// 记录请求开始时间
-	startTime := time.Now()
+	// 添加认证信息，优先级依次为步骤、场景会话、执行环境
+	// 回放时不访问网络，不添加认证信息（OAuth2 等需要请求令牌）
+	tape := r.currentCassette()
+	replay := tape != nil && tape.Mode() == cassette.ModeReplay
+	var provider auth.Provider
+	if session != nil {
+		provider = session.Auth()
+	} else {
+		r.mu.Lock()
+		provider = r.envAuth
+		r.mu.Unlock()
+	}
+	if value, ok := request["auth"]; ok {
+		provider, _ = value.(auth.Provider)
+	}
+	if provider != nil && !replay {
+		if err := provider.Apply(reqCtx, req, reqBody); err != nil {
+			return nil, fmt.Errorf("添加请求认证失败: %w", core.WrapTimeout(reqCtx, err))
+		}
+	}
 
-	// 记录原始请求
-	rawRequest := fmt.Sprintf("%s %s\n", req.Method, req.URL.String())
-	for k, v := range req.Header {
-		rawRequest += fmt.Sprintf("%s: %s\n", k, strings.Join(v, ", "))
+	// 记录请求开始时间，请求和响应按 HAR 格式记录，计时器记录各阶段耗时
+	startTime := time.Now()
+	timer := har.NewTimer()
+	entry := har.NewEntry(req, reqBody, client.Jar, timer)
+	var authHeaders, authQuery []string
+	if redactor, ok := provider.(auth.Redactor); ok {
+		authHeaders, authQuery = redactor.Redacted()
+		entry.Request.Redact(authHeaders, authQuery)
+	}
+	entry.Comment, _ = request["api_name"].(string)
+	response["har_entry"] = entry
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), timer.Trace()))
+
+	// 记录原始请求，URL 和请求头取自遮盖凭证后的 HAR 条目
+	rawRequest := fmt.Sprintf("%s %s\n", req.Method, entry.Request.URL)
+	for _, header := range entry.Request.Headers {
+		rawRequest += fmt.Sprintf("%s: %s\n", header.Name, header.Value)
 	}
 	if reqBody != nil {
-		rawRequest += "\n" + string(reqBody)
+		if isTextContent(req.Header.Get("Content-Type")) {
+			rawRequest += "\n" + string(reqBody)
+		} else {
+			rawRequest += fmt.Sprintf("\n<%d bytes>", len(reqBody))
+		}
 	}
 	response["raw_request"] = rawRequest
 
 	// 更新执行状态
 	r.status = core.TaskStatusRunning
 
-	// 发送请求
-	resp, err := r.client.Do(req)
+	// 发送请求，回放时从磁带返回录制的响应
+	var resp *http.Response
+	if replay {
+		if resp, err = tape.Replay(req, reqBody); err == nil && client.Jar != nil {
+			client.Jar.SetCookies(req.URL, resp.Cookies())
+		}
+	} else {
+		resp, err = client.Do(req)
+	}
+	if errors.Is(err, cassette.ErrNoInteraction) {
+		entry.SetError(err, timer)
+		r.recordHar(ctx, entry)
+		return nil, retry.Permanent(err)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("执行HTTP请求失败: %w", err)
+		entry.SetError(err, timer)
+		r.recordHar(ctx, entry)
+		if r.isCanceled() {
+			r.status = core.TaskStatusCanceled
+			return nil, retry.Permanent(fmt.Errorf("HTTP请求已取消: %w", err))
+		}
+		return nil, fmt.Errorf("执行HTTP请求失败: %w", core.WrapTimeout(reqCtx, err))
 	}
 	defer resp.Body.Close()
 
+	// 认证失败时丢弃缓存的凭证，后续请求重新获取
+	if resp.StatusCode == http.StatusUnauthorized {
+		if invalidator, ok := provider.(auth.Invalidator); ok {
+			if err := invalidator.Invalidate(ctx); err != nil {
+				logx.Errorf("丢弃认证凭证失败: %v", err)
+			}
+		}
+	}
+
 	// 记录响应结束时间
 	endTime := time.Now()
 	duration := endTime.Sub(startTime).Seconds()
 
 	// 读取响应体
 	respBody, err := io.ReadAll(resp.Body)
+	timer.Done()
 	if err != nil {
+		entry.SetError(err, timer)
+		r.recordHar(ctx, entry)
 		return nil, fmt.Errorf("读取响应体失败: %w", err)
 	}
+	entry.SetResponse(resp, respBody, timer)
+	r.recordHar(ctx, entry)
+	if tape != nil && tape.Mode() == cassette.ModeRecord {
+		if err := tape.Record(context.WithoutCancel(ctx), req, reqBody, resp, respBody, authHeaders, authQuery); err != nil {
+			return nil, retry.Permanent(err)
+		}
+	}
 
 	// 记录原始响应
 	rawResponse := fmt.Sprintf("HTTP/%d.%d %d %s\n", resp.ProtoMajor, resp.ProtoMinor, resp.StatusCode, resp.Status)
@@ -430,6 +740,10 @@ func (r *HttpRunner) ExecuteRequest(ctx context.Context, request map[string]inte
 	for k, v := range resp.Header {
 		response["headers"].(map[string]string)[k] = strings.Join(v, ", ")
 	}
+	response["cookies"] = make(map[string]string)
+	for _, cookie := range resp.Cookies() {
+		response["cookies"].(map[string]string)[cookie.Name] = cookie.Value
+	}
 	response["body"] = string(respBody)
 	response["duration"] = duration
 
@@ -475,9 +789,60 @@ func (r *HttpRunner) ExecuteRequest(ctx context.Context, request map[string]inte
 	return response, nil
 }
 
-// ValidateResponse 验证响应
+// executeWithRetry 按重试策略执行请求
+// 网络错误和需要重试的响应（HTTP 为 408/425/429/5xx）会重试，重试用尽时仍返回最后一次的响应，由断言判断结果
+func (r *HttpRunner) executeWithRetry(ctx context.Context, request map[string]interface{}, policy retry.Policy) (map[string]interface{}, error) {
+	var response map[string]interface{}
+	var attempts int
+	err := retry.Do(ctx, policy, func(ctx context.Context, attempt int) error {
+		attempts = attempt
+		resp, err := r.sender.ExecuteRequest(ctx, request)
+		if err != nil {
+			return err
+		}
+		response = resp
+		if err := r.sender.statusError(resp); err != nil {
+			return &responseStatusError{err: err}
+		}
+		return nil
+	})
+
+	var statusErr *responseStatusError
+	if err != nil && (response == nil || !errors.As(err, &statusErr)) {
+		return nil, err
+	}
+	response["attempts"] = attempts
+	return response, nil
+}
+
+// responseStatusError 请求成功但响应状态需要重试，重试用尽时仍返回最后一次的响应
+type responseStatusError struct {
+	err error
+}
+
+func (e *responseStatusError) Error() string { return e.err.Error() }
+func (e *responseStatusError) Unwrap() error { return e.err }
+
+// statusError 408/425/429/5xx 响应需要重试
+func (r *HttpRunner) statusError(response map[string]interface{}) error {
+	if code, _ := response["status_code"].(int); retry.RetryableStatus(code) {
+		return &retry.StatusError{StatusCode: code}
+	}
+	return nil
+}
+
+// ValidateResponse 验证响应，断言未通过时按分组的重试配置重新评估
 func (r *HttpRunner) ValidateResponse(ctx context.Context, response map[string]interface{}, assertions *expect.AssertionGroup) (*expect.AssertionGroupResult, error) {
-	result := assertions.AssertAll()
+	result, err := assertions.AssertWithRetry(ctx, nil)
+	r.recordAssertions(result)
+	return result, err
+}
+
+// recordAssertions 将断言结果计入指标
+func (r *HttpRunner) recordAssertions(result *expect.AssertionGroupResult) {
+	if result == nil {
+		return
+	}
 
 	var errorCount int
 	for _, assertion := range result.Results {
@@ -489,42 +854,20 @@ func (r *HttpRunner) ValidateResponse(ctx context.Context, response map[string]i
 	// 更新指标
 	r.metrics.AssertionsPassed = len(result.Results) - errorCount
 	r.metrics.AssertionsFailed = errorCount
-
-	return result, nil
-}
-
-// getValueByPath 通过路径获取值
-func getValueByPath(data map[string]interface{}, path string) (interface{}, error) {
-	// TODO: 实现更复杂的JSON路径解析
-	parts := strings.Split(path, ".")
-	current := data
-
-	for i, part := range parts {
-		if i == len(parts)-1 {
-			return current[part], nil
-		}
-
-		if next, ok := current[part].(map[string]interface{}); ok {
-			current = next
-		} else {
-			return nil, fmt.Errorf("路径 %s 不存在", path)
-		}
-	}
-
-	return current, nil
 }
 
-// ExtractData 从响应中提取数据
-func (r *HttpRunner) ExtractData(ctx context.Context, response map[string]interface{}, extractors map[string]string) (map[string]interface{}, error) {
+// ExtractData 从响应中提取数据，按提取器的来源从响应体、响应头、cookie、状态码或耗时中取值
+func (r *HttpRunner) ExtractData(ctx context.Context, response map[string]interface{}, extractors []extract.Extractor) (map[string]interface{}, error) {
 	result := make(map[string]interface{})
 
-	for name, path := range extractors {
-		value, err := getValueByPath(response, path)
+	for _, extractor := range extractors {
+		extractor.Data = response
+		target, err := extractor.Extract()
 		if err != nil {
-			return nil, fmt.Errorf("提取数据失败: %w", err)
+			return nil, fmt.Errorf("提取数据 %s 失败: %w", extractor.Name, err)
 		}
 
-		result[name] = value
+		result[extractor.Name] = target.Value
 	}
 
 	return result, nil
@@ -595,10 +938,53 @@ func (r *HttpRunner) convertApiMetricsToGeneric(metrics *api.ApiMetrics, config
 	return result
 }
 
-// Cancel 取消执行
+// beginRequest 创建可取消的请求上下文，执行器已取消时直接返回错误
+func (r *HttpRunner) beginRequest(ctx context.Context) (context.Context, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.canceled {
+		return nil, fmt.Errorf("HTTP执行器已取消")
+	}
+
+	reqCtx, cancel := context.WithCancel(ctx)
+	r.cancelRequest = cancel
+	return reqCtx, nil
+}
+
+// endRequest 释放请求上下文
+func (r *HttpRunner) endRequest() {
+	r.mu.Lock()
+	cancel := r.cancelRequest
+	r.cancelRequest = nil
+	r.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// isCanceled 是否已调用 Cancel
+func (r *HttpRunner) isCanceled() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.canceled
+}
+
+// Cancel 取消执行，中断进行中的HTTP请求，之后的请求直接失败
 func (r *HttpRunner) Cancel(ctx context.Context) error {
-	// TODO: 实现取消HTTP请求
-	r.status = core.TaskStatusCanceled
+	r.mu.Lock()
+	r.canceled = true
+	cancel := r.cancelRequest
+	r.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+
+	if !r.status.IsTerminal() {
+		r.status = core.TaskStatusCanceled
+	}
 	return nil
 }
 
@@ -656,6 +1042,28 @@ func (r *HttpRunner) Cleanup(ctx context.Context) error {
 	return nil
 }
 
+// 生命周期钩子由所属 ApiPipeline 的钩子链处理，执行器自身不做处理
+
+func (r *HttpRunner) OnStart(ctx context.Context, taskID string, spec map[string]interface{}) error {
+	return nil
+}
+
+func (r *HttpRunner) OnSuccess(ctx context.Context, taskID string, result map[string]interface{}) error {
+	return nil
+}
+
+func (r *HttpRunner) OnFailure(ctx context.Context, taskID string, err error) error {
+	return nil
+}
+
+func (r *HttpRunner) OnCancel(ctx context.Context, taskID string) error {
+	return nil
+}
+
+func (r *HttpRunner) OnComplete(ctx context.Context, taskID string, result map[string]interface{}) error {
+	return nil
+}
+
 func (r *HttpRunner) StoreData(ctx context.Context, data map[string]interface{}, storeConfig []*store.ReportRunData) error {
 	// 1. 数据有效性检查
 	if len(data) == 0 {
@@ -0,0 +1,234 @@
+package runner
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner"
+)
+
+// sessionServer 模拟登录态接口，并统计连接的建立和关闭
+type sessionServer struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	opened int
+	closed int
+	// 最近一次请求的 X-Client 请求头
+	client string
+}
+
+func newSessionServer(t *testing.T) *sessionServer {
+	s := &sessionServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "sid", Value: "s-1", Path: "/"})
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/profile", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.client = r.Header.Get("X-Client")
+		s.mu.Unlock()
+		if cookie, err := r.Cookie("sid"); err != nil || cookie.Value != "s-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/logout", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "sid", Path: "/", MaxAge: -1})
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s.Server = httptest.NewUnstartedServer(mux)
+	s.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch state {
+		case http.StateNew:
+			s.opened++
+		case http.StateClosed:
+			s.closed++
+		}
+	}
+	s.Start()
+	t.Cleanup(s.Close)
+	return s
+}
+
+// conns 已建立和已关闭的连接数
+func (s *sessionServer) conns() (opened, closed int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.opened, s.closed
+}
+
+func (s *sessionServer) lastClient() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client
+}
+
+// runStep 用新的执行器发送一个步骤的请求，返回响应状态码
+func runStep(t *testing.T, session *api.Session, baseURL, path string, headers map[string]string) int {
+	t.Helper()
+	r := NewHttpRunner(nil)
+	r.SetSession(session)
+	defer r.SetSession(nil)
+
+	def := &api.ApiDefinition{Method: http.MethodGet, Path: path, Headers: headers}
+	request, err := r.BuildRequest(context.Background(), def, map[string]interface{}{api.BaseURLVariable: baseURL})
+	if err != nil {
+		t.Fatalf("BuildRequest(%s) error = %v", path, err)
+	}
+	response, err := r.ExecuteRequest(context.Background(), request)
+	if err != nil {
+		t.Fatalf("ExecuteRequest(%s) error = %v", path, err)
+	}
+	return response["status_code"].(int)
+}
+
+func TestSessionSharedAcrossSteps(t *testing.T) {
+	server := newSessionServer(t)
+	session, err := api.NewSession(&api.SessionConfig{DefaultHeaders: map[string]string{"X-Client": "scene"}}, nil)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	defer session.Close()
+
+	// 每个步骤使用各自的执行器，cookie、默认请求头和连接由会话共享
+	steps := []struct {
+		name       string
+		path       string
+		headers    map[string]string
+		wantStatus int
+		wantClient string
+	}{
+		{name: "登录前未认证", path: "/profile", wantStatus: http.StatusUnauthorized, wantClient: "scene"},
+		{name: "登录设置 cookie", path: "/login", wantStatus: http.StatusOK},
+		{name: "后续步骤携带 cookie", path: "/profile", wantStatus: http.StatusOK, wantClient: "scene"},
+		{name: "步骤的请求头覆盖默认请求头", path: "/profile", headers: map[string]string{"x-client": "step"}, wantStatus: http.StatusOK, wantClient: "step"},
+		{name: "登出清除 cookie", path: "/logout", wantStatus: http.StatusOK},
+		{name: "登出后未认证", path: "/profile", wantStatus: http.StatusUnauthorized, wantClient: "scene"},
+	}
+	for _, step := range steps {
+		if got := runStep(t, session, server.URL, step.path, step.headers); got != step.wantStatus {
+			t.Fatalf("步骤 %s: status = %d, want %d", step.name, got, step.wantStatus)
+		}
+		if step.wantClient != "" {
+			if got := server.lastClient(); got != step.wantClient {
+				t.Errorf("步骤 %s: X-Client = %q, want %q", step.name, got, step.wantClient)
+			}
+		}
+	}
+
+	// 所有步骤复用同一个长连接
+	if opened, _ := server.conns(); opened != 1 {
+		t.Errorf("opened connections = %d, want 1", opened)
+	}
+}
+
+func TestSessionCookiesNotSharedOutsideSession(t *testing.T) {
+	server := newSessionServer(t)
+	session, err := api.NewSession(nil, nil)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	defer session.Close()
+
+	if got := runStep(t, session, server.URL, "/login", nil); got != http.StatusOK {
+		t.Fatalf("login status = %d, want %d", got, http.StatusOK)
+	}
+	cookies, err := session.Cookies(server.URL + "/profile")
+	if err != nil || len(cookies) != 1 || cookies[0].Name != "sid" || cookies[0].Value != "s-1" {
+		t.Fatalf("Cookies() = %v, %v, want sid=s-1", cookies, err)
+	}
+
+	// 没有会话的执行器使用自身的客户端，不携带会话的 cookie
+	if got := runStep(t, nil, server.URL, "/profile", nil); got != http.StatusUnauthorized {
+		t.Errorf("status without session = %d, want %d", got, http.StatusUnauthorized)
+	}
+
+	// 其他场景的会话互不影响
+	other, err := api.NewSession(nil, nil)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	defer other.Close()
+	if got := runStep(t, other, server.URL, "/profile", nil); got != http.StatusUnauthorized {
+		t.Errorf("status with other session = %d, want %d", got, http.StatusUnauthorized)
+	}
+}
+
+func TestSessionDisableCookies(t *testing.T) {
+	server := newSessionServer(t)
+	session, err := api.NewSession(&api.SessionConfig{DisableCookies: true}, nil)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	defer session.Close()
+
+	if got := runStep(t, session, server.URL, "/login", nil); got != http.StatusOK {
+		t.Fatalf("login status = %d, want %d", got, http.StatusOK)
+	}
+	if got := runStep(t, session, server.URL, "/profile", nil); got != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d (cookies disabled)", got, http.StatusUnauthorized)
+	}
+	if cookies, err := session.Cookies(server.URL); err != nil || cookies != nil {
+		t.Errorf("Cookies() = %v, %v, want nil", cookies, err)
+	}
+}
+
+func TestSessionClose(t *testing.T) {
+	server := newSessionServer(t)
+	session, err := api.NewSession(nil, nil)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+
+	for _, path := range []string{"/login", "/profile"} {
+		runStep(t, session, server.URL, path, nil)
+	}
+	if opened, closed := server.conns(); opened != 1 || closed != 0 {
+		t.Fatalf("connections before Close = %d opened, %d closed, want 1, 0", opened, closed)
+	}
+
+	// 场景结束时关闭会话的空闲连接，重复关闭不报错
+	session.Close()
+	session.Close()
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, closed := server.conns(); closed == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			_, closed := server.conns()
+			t.Fatalf("closed connections = %d, want 1", closed)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSessionDisableKeepAlives(t *testing.T) {
+	server := newSessionServer(t)
+	session, err := api.NewSession(&api.SessionConfig{DisableKeepAlives: true}, nil)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	defer session.Close()
+
+	// 禁用长连接时 cookie 仍然共享，每个请求使用新连接
+	for _, path := range []string{"/login", "/profile", "/profile"} {
+		if got := runStep(t, session, server.URL, path, nil); got != http.StatusOK {
+			t.Fatalf("%s status = %d, want %d", path, got, http.StatusOK)
+		}
+	}
+	if opened, _ := server.conns(); opened != 3 {
+		t.Errorf("opened connections = %d, want 3", opened)
+	}
+}
@@ -0,0 +1,130 @@
+package runner
+
+import (
+	"fmt"
+	urls "net/url"
+	"strings"
+
+	"Storage/internal/components/pathtemplate"
+	"Storage/internal/components/pipeline/runner/api/apirunner"
+	"Storage/internal/components/pipeline/runner/api/apirunner/template"
+)
+
+// resolveRequestURL 替换路径参数并与基础URL拼接
+// 路径参数优先取 path_params 中的配置，其次按名称取依赖数据，参数值按路径段编码；
+// 路径已是完整URL时忽略基础URL，基础URL为空时依次取执行环境中服务的基础URL和 base_url 变量
+func resolveRequestURL(def *api.ApiDefinition, path string, dependencies map[string]interface{}) (string, error) {
+	params, err := template.RenderStringMap(def.PathParams, dependencies)
+	if err != nil {
+		return "", fmt.Errorf("渲染路径参数失败: %w", err)
+	}
+	path, err = substitutePathParams(path, params, dependencies)
+	if err != nil {
+		return "", err
+	}
+
+	if isAbsoluteURL(path) {
+		return path, nil
+	}
+
+	baseURL, err := template.RenderString(def.BaseURL, dependencies)
+	if err != nil {
+		return "", fmt.Errorf("渲染基础URL失败: %w", err)
+	}
+	if baseURL == "" && def.Service != "" {
+		if value, ok := serviceURL(dependencies[api.ServiceURLsVariable], def.Service); ok {
+			if baseURL, err = template.Stringify(value); err != nil {
+				return "", err
+			}
+		}
+	}
+	if baseURL == "" {
+		if value, ok := dependencies[api.BaseURLVariable]; ok {
+			if baseURL, err = template.Stringify(value); err != nil {
+				return "", err
+			}
+		}
+	}
+	if baseURL == "" {
+		return "", fmt.Errorf("请求路径 %s 不是完整的URL, 且执行环境未配置基础URL", path)
+	}
+	if !isAbsoluteURL(baseURL) {
+		return "", fmt.Errorf("基础URL %s 无效, 需要包含协议和主机", baseURL)
+	}
+	return joinURL(baseURL, path), nil
+}
+
+// serviceURL 从执行环境的 service_urls 变量中取服务的基础URL
+func serviceURL(urls interface{}, service string) (interface{}, bool) {
+	switch v := urls.(type) {
+	case map[string]interface{}:
+		value, ok := v[service]
+		return value, ok && value != nil
+	case map[string]string:
+		value, ok := v[service]
+		return value, ok
+	}
+	return nil, false
+}
+
+// substitutePathParams 替换路径中的 {name} 和 :name 参数，只处理路径部分，不影响协议、主机和查询串
+func substitutePathParams(rawPath string, params map[string]string, dependencies map[string]interface{}) (string, error) {
+	prefix, path, suffix := splitURLPath(rawPath)
+	path, err := pathtemplate.Parse(path).Expand(func(name string) (string, error) {
+		return pathParamValue(name, params, dependencies)
+	})
+	if err != nil {
+		return "", err
+	}
+	return prefix + path + suffix, nil
+}
+
+// pathParamValue 获取路径参数的值，参数不存在或为空时报错
+func pathParamValue(name string, params map[string]string, dependencies map[string]interface{}) (string, error) {
+	if value, ok := params[name]; ok && value != "" {
+		return value, nil
+	}
+	if value, ok := dependencies[name]; ok && value != nil {
+		s, err := template.Stringify(value)
+		if err != nil {
+			return "", fmt.Errorf("路径参数 %s: %w", name, err)
+		}
+		if s != "" {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("缺少路径参数 %s", name)
+}
+
+// splitURLPath 将URL拆分为 协议+主机、路径、查询串+片段 三部分
+func splitURLPath(rawURL string) (prefix, path, suffix string) {
+	path = rawURL
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path, suffix = path[:i], path[i:]
+	}
+	if i := strings.Index(path, "://"); i >= 0 {
+		rest := path[i+3:]
+		if j := strings.IndexByte(rest, '/'); j >= 0 {
+			return path[:i+3+j], rest[j:], suffix
+		}
+		return path, "", suffix
+	}
+	return "", path, suffix
+}
+
+// isAbsoluteURL 是否为包含协议和主机的完整URL
+func isAbsoluteURL(rawURL string) bool {
+	u, err := urls.Parse(rawURL)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+// joinURL 拼接基础URL和路径，保留基础URL中的路径前缀
+func joinURL(baseURL, path string) string {
+	if path == "" {
+		return baseURL
+	}
+	if strings.HasPrefix(path, "?") || strings.HasPrefix(path, "#") {
+		return baseURL + path
+	}
+	return strings.TrimRight(baseURL, "/") + "/" + strings.TrimLeft(path, "/")
+}
@@ -0,0 +1,282 @@
+package runner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner"
+)
+
+func TestSubstitutePathParams(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		params       map[string]string
+		dependencies map[string]interface{}
+		want         string
+		wantErr      string
+	}{
+		{name: "没有路径参数", path: "/users", want: "/users"},
+		{name: "花括号参数", path: "/users/{id}", params: map[string]string{"id": "42"}, want: "/users/42"},
+		{name: "冒号参数", path: "/users/:id/orders", params: map[string]string{"id": "42"}, want: "/users/42/orders"},
+		{name: "冒号只匹配整个路径段", path: "/time/10:30", want: "/time/10:30"},
+		{name: "参数在路径段中间", path: "/files/{id}.json", params: map[string]string{"id": "report"}, want: "/files/report.json"},
+		{name: "同一路径段多个参数", path: "/{org}-{repo}/issues", params: map[string]string{"org": "acme", "repo": "api"}, want: "/acme-api/issues"},
+		{name: "参数值按路径段编码", path: "/files/{name}", params: map[string]string{"name": "a b/c?d"}, want: "/files/a%20b%2Fc%3Fd"},
+		{name: "非 ASCII 参数值", path: "/tags/{tag}", params: map[string]string{"tag": "测试"}, want: "/tags/%E6%B5%8B%E8%AF%95"},
+		{name: "从依赖数据取参数", path: "/users/{id}", dependencies: map[string]interface{}{"id": float64(7)}, want: "/users/7"},
+		{
+			name:         "路径参数配置优先于依赖数据",
+			path:         "/users/{id}",
+			params:       map[string]string{"id": "1"},
+			dependencies: map[string]interface{}{"id": "2"},
+			want:         "/users/1",
+		},
+		{
+			name:         "配置为空时取依赖数据",
+			path:         "/users/{id}",
+			params:       map[string]string{"id": ""},
+			dependencies: map[string]interface{}{"id": "2"},
+			want:         "/users/2",
+		},
+		{
+			name:   "完整URL只替换路径部分",
+			path:   "http://{host}:8080/users/{id}?q={id}#{id}",
+			params: map[string]string{"id": "1", "host": "example.com"},
+			want:   "http://{host}:8080/users/1?q={id}#{id}",
+		},
+		{name: "缺少花括号参数", path: "/users/{id}", wantErr: "缺少路径参数 id"},
+		{name: "缺少冒号参数", path: "/users/:id", wantErr: "缺少路径参数 id"},
+		{name: "依赖数据为空字符串", path: "/users/{id}", dependencies: map[string]interface{}{"id": ""}, wantErr: "缺少路径参数 id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := substitutePathParams(tt.path, tt.params, tt.dependencies)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("substitutePathParams(%q) error = %v, want %q", tt.path, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("substitutePathParams(%q) error = %v", tt.path, err)
+			}
+			if got != tt.want {
+				t.Errorf("substitutePathParams(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitURLPath(t *testing.T) {
+	tests := []struct {
+		name                 string
+		url                  string
+		prefix, path, suffix string
+	}{
+		{name: "相对路径", url: "/users/1", path: "/users/1"},
+		{name: "相对路径带查询串", url: "/users?id=1#top", path: "/users", suffix: "?id=1#top"},
+		{name: "完整URL", url: "https://example.com/api/users", prefix: "https://example.com", path: "/api/users"},
+		{name: "只有主机", url: "https://example.com", prefix: "https://example.com"},
+		{name: "只有主机带查询串", url: "https://example.com?a=1", prefix: "https://example.com", suffix: "?a=1"},
+		{name: "查询串中的协议不作为主机", url: "/redirect?to=http://a/b", path: "/redirect", suffix: "?to=http://a/b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, path, suffix := splitURLPath(tt.url)
+			if prefix != tt.prefix || path != tt.path || suffix != tt.suffix {
+				t.Errorf("splitURLPath(%q) = %q, %q, %q, want %q, %q, %q",
+					tt.url, prefix, path, suffix, tt.prefix, tt.path, tt.suffix)
+			}
+		})
+	}
+}
+
+func TestJoinURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		path    string
+		want    string
+	}{
+		{name: "基础URL不带斜杠", baseURL: "http://example.com", path: "/users", want: "http://example.com/users"},
+		{name: "基础URL带斜杠", baseURL: "http://example.com/", path: "/users", want: "http://example.com/users"},
+		{name: "路径不带斜杠", baseURL: "http://example.com", path: "users", want: "http://example.com/users"},
+		{name: "保留基础URL的路径前缀", baseURL: "http://example.com/api/v1/", path: "/users", want: "http://example.com/api/v1/users"},
+		{name: "路径为空", baseURL: "http://example.com/api", path: "", want: "http://example.com/api"},
+		{name: "路径只有查询串", baseURL: "http://example.com/api", path: "?id=1", want: "http://example.com/api?id=1"},
+		{name: "路径只有片段", baseURL: "http://example.com/api", path: "#top", want: "http://example.com/api#top"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := joinURL(tt.baseURL, tt.path); got != tt.want {
+				t.Errorf("joinURL(%q, %q) = %q, want %q", tt.baseURL, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveRequestURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		def          api.ApiDefinition
+		dependencies map[string]interface{}
+		want         string
+		wantErr      string
+	}{
+		{
+			name: "完整URL忽略基础URL",
+			def:  api.ApiDefinition{Path: "https://other.com/users/{id}", BaseURL: "http://example.com", PathParams: map[string]string{"id": "1"}},
+			want: "https://other.com/users/1",
+		},
+		{
+			name: "接口的基础URL",
+			def:  api.ApiDefinition{Path: "/users/{id}", BaseURL: "http://example.com/api", PathParams: map[string]string{"id": "1"}},
+			want: "http://example.com/api/users/1",
+		},
+		{
+			name:         "基础URL中的变量",
+			def:          api.ApiDefinition{Path: "/users", BaseURL: "http://{{host}}"},
+			dependencies: map[string]interface{}{"host": "example.com:8080"},
+			want:         "http://example.com:8080/users",
+		},
+		{
+			name:         "路径参数配置中的变量",
+			def:          api.ApiDefinition{Path: "/users/{id}", BaseURL: "http://example.com", PathParams: map[string]string{"id": "{{user_id}}"}},
+			dependencies: map[string]interface{}{"user_id": "u-1"},
+			want:         "http://example.com/users/u-1",
+		},
+		{
+			name: "执行环境中服务的基础URL",
+			def:  api.ApiDefinition{Path: "/orders", Service: "order"},
+			dependencies: map[string]interface{}{
+				api.ServiceURLsVariable: map[string]interface{}{"order": "http://order.local"},
+				api.BaseURLVariable:     "http://default.local",
+			},
+			want: "http://order.local/orders",
+		},
+		{
+			name: "服务未配置时使用 base_url 变量",
+			def:  api.ApiDefinition{Path: "/orders", Service: "order"},
+			dependencies: map[string]interface{}{
+				api.ServiceURLsVariable: map[string]string{"user": "http://user.local"},
+				api.BaseURLVariable:     "http://default.local",
+			},
+			want: "http://default.local/orders",
+		},
+		{
+			name:    "没有基础URL",
+			def:     api.ApiDefinition{Path: "/users"},
+			wantErr: "请求路径 /users 不是完整的URL, 且执行环境未配置基础URL",
+		},
+		{
+			name:    "基础URL缺少协议",
+			def:     api.ApiDefinition{Path: "/users", BaseURL: "example.com"},
+			wantErr: "基础URL example.com 无效, 需要包含协议和主机",
+		},
+		{
+			name:    "缺少路径参数",
+			def:     api.ApiDefinition{Path: "/users/{id}", BaseURL: "http://example.com"},
+			wantErr: "缺少路径参数 id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveRequestURL(&tt.def, tt.def.Path, tt.dependencies)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("resolveRequestURL() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveRequestURL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveRequestURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHttpRunnerQueryParams(t *testing.T) {
+	var gotPath, gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.EscapedPath(), r.URL.RawQuery
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		path         string
+		queryParams  map[string]string
+		dependencies map[string]interface{}
+		wantPath     string
+		wantQuery    string
+	}{
+		{name: "没有查询参数", path: "/users", wantPath: "/users"},
+		{
+			name:        "查询参数按键排序并编码",
+			path:        "/search",
+			queryParams: map[string]string{"q": "a b&c=d", "lang": "中文"},
+			wantPath:    "/search",
+			wantQuery:   "lang=%E4%B8%AD%E6%96%87&q=a+b%26c%3Dd",
+		},
+		{
+			name:         "查询参数中的变量",
+			path:         "/users",
+			queryParams:  map[string]string{"page": "{{page}}"},
+			dependencies: map[string]interface{}{"page": float64(2)},
+			wantPath:     "/users",
+			wantQuery:    "page=2",
+		},
+		{
+			name:        "与路径中的查询串合并且覆盖同名参数",
+			path:        "/users?page=1&size=10",
+			queryParams: map[string]string{"page": "3"},
+			wantPath:    "/users",
+			wantQuery:   "page=3&size=10",
+		},
+		{
+			name:         "编码后的路径参数原样发送",
+			path:         "/files/{name}.json",
+			queryParams:  map[string]string{"v": "1"},
+			dependencies: map[string]interface{}{"name": "a b/c"},
+			wantPath:     "/files/a%20b%2Fc.json",
+			wantQuery:    "v=1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPath, gotQuery = "", ""
+			dependencies := map[string]interface{}{api.BaseURLVariable: server.URL}
+			for k, v := range tt.dependencies {
+				dependencies[k] = v
+			}
+			def := &api.ApiDefinition{Method: http.MethodGet, Path: tt.path, QueryParams: tt.queryParams}
+
+			r := NewHttpRunner(nil)
+			request, err := r.BuildRequest(context.Background(), def, dependencies)
+			if err != nil {
+				t.Fatalf("BuildRequest() error = %v", err)
+			}
+			if _, err := r.ExecuteRequest(context.Background(), request); err != nil {
+				t.Fatalf("ExecuteRequest() error = %v", err)
+			}
+			if gotPath != tt.wantPath {
+				t.Errorf("request path = %q, want %q", gotPath, tt.wantPath)
+			}
+			if gotQuery != tt.wantQuery {
+				t.Errorf("request query = %q, want %q", gotQuery, tt.wantQuery)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner"
+
+	"google.golang.org/grpc"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// fileFetcher 按文件名获取描述符中缺少的依赖文件
+type fileFetcher func(name string) (*descriptorpb.FileDescriptorProto, error)
+
+// loadProtosetFiles 解析 protoset 文件中的描述符
+func loadProtosetFiles(config *api.RpcConfig) (*descriptorSet, error) {
+	data, err := config.LoadProtoset()
+	if err != nil {
+		return nil, err
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("解析 protoset 失败: %w", err)
+	}
+	protos := make(map[string]*descriptorpb.FileDescriptorProto, len(set.File))
+	for _, fd := range set.File {
+		protos[fd.GetName()] = fd
+	}
+	return buildFiles(protos, nil)
+}
+
+// reflectFiles 通过服务端反射获取服务所在文件及其依赖的描述符
+func reflectFiles(ctx context.Context, conn grpc.ClientConnInterface, service string) (*descriptorSet, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("连接反射服务失败: %w", err)
+	}
+	defer stream.CloseSend()
+
+	protos := make(map[string]*descriptorpb.FileDescriptorProto)
+	request := func(req *reflectionpb.ServerReflectionRequest) error {
+		if err := stream.Send(req); err != nil {
+			return fmt.Errorf("发送反射请求失败: %w", err)
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return fmt.Errorf("接收反射响应失败: %w", err)
+		}
+		if errResp := resp.GetErrorResponse(); errResp != nil {
+			return fmt.Errorf("反射服务返回错误: %s", errResp.GetErrorMessage())
+		}
+		// 响应中包含请求的文件，以及服务端认为客户端尚未获取的依赖文件
+		for _, raw := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fd := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(raw, fd); err != nil {
+				return fmt.Errorf("解析反射返回的描述符失败: %w", err)
+			}
+			protos[fd.GetName()] = fd
+		}
+		return nil
+	}
+
+	err = request(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("获取服务 %s 的描述符失败: %w", service, err)
+	}
+	return buildFiles(protos, func(name string) (*descriptorpb.FileDescriptorProto, error) {
+		err := request(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: name},
+		})
+		if err != nil {
+			return nil, err
+		}
+		fd, ok := protos[name]
+		if !ok {
+			return nil, fmt.Errorf("反射服务未返回文件 %s", name)
+		}
+		return fd, nil
+	})
+}
+
+// buildFiles 按依赖顺序注册描述符，缺少的依赖优先使用进程内已注册的文件（如 google/protobuf 下的标准类型），
+// 其次通过 fetch 获取
+func buildFiles(protos map[string]*descriptorpb.FileDescriptorProto, fetch fileFetcher) (*descriptorSet, error) {
+	files := &protoregistry.Files{}
+	set := &descriptorSet{local: files}
+	visiting := make(map[string]bool)
+
+	var register func(name string) error
+	register = func(name string) error {
+		if _, err := set.FindFileByPath(name); err == nil {
+			return nil
+		}
+		if visiting[name] {
+			return fmt.Errorf("描述符 %s 存在循环依赖", name)
+		}
+		fd, ok := protos[name]
+		if !ok {
+			if fetch == nil {
+				return fmt.Errorf("缺少依赖的描述符 %s", name)
+			}
+			var err error
+			if fd, err = fetch(name); err != nil {
+				return err
+			}
+		}
+
+		visiting[name] = true
+		defer delete(visiting, name)
+		for _, dep := range fd.GetDependency() {
+			if err := register(dep); err != nil {
+				return err
+			}
+		}
+		file, err := protodesc.NewFile(fd, set)
+		if err != nil {
+			return fmt.Errorf("解析描述符 %s 失败: %w", name, err)
+		}
+		return files.RegisterFile(file)
+	}
+
+	for name := range protos {
+		if err := register(name); err != nil {
+			return nil, err
+		}
+	}
+	set.types = dynamicpb.NewTypes(files)
+	return set, nil
+}
+
+// descriptorSet 解析出的描述符，查找时先查找解析出的描述符，再查找进程内注册的描述符
+type descriptorSet struct {
+	local *protoregistry.Files
+	types *dynamicpb.Types
+}
+
+func (r *descriptorSet) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if fd, err := r.local.FindFileByPath(path); err == nil {
+		return fd, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+func (r *descriptorSet) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if d, err := r.local.FindDescriptorByName(name); err == nil {
+		return d, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
+
+// FindMessageByName 查找消息类型，用于 JSON 转换 google.protobuf.Any 字段
+func (r *descriptorSet) FindMessageByName(name protoreflect.FullName) (protoreflect.MessageType, error) {
+	if mt, err := r.types.FindMessageByName(name); err == nil {
+		return mt, nil
+	}
+	return protoregistry.GlobalTypes.FindMessageByName(name)
+}
+
+func (r *descriptorSet) FindMessageByURL(url string) (protoreflect.MessageType, error) {
+	if mt, err := r.types.FindMessageByURL(url); err == nil {
+		return mt, nil
+	}
+	return protoregistry.GlobalTypes.FindMessageByURL(url)
+}
+
+func (r *descriptorSet) FindExtensionByName(name protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	if xt, err := r.types.FindExtensionByName(name); err == nil {
+		return xt, nil
+	}
+	return protoregistry.GlobalTypes.FindExtensionByName(name)
+}
+
+func (r *descriptorSet) FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error) {
+	if xt, err := r.types.FindExtensionByNumber(message, field); err == nil {
+		return xt, nil
+	}
+	return protoregistry.GlobalTypes.FindExtensionByNumber(message, field)
+}
+
+// findMethod 查找服务的方法，service 为服务的完整名称
+func (r *descriptorSet) findMethod(service, method string) (protoreflect.MethodDescriptor, error) {
+	d, err := r.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("描述符中不存在服务 %s", service)
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s 不是服务", service)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, fmt.Errorf("服务 %s 不存在方法 %s", service, method)
+	}
+	return md, nil
+}
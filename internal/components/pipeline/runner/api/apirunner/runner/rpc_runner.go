@@ -1 +1,499 @@
 package runner
+
+import (
+	"Storage/internal/components/pipeline/core"
+	"Storage/internal/components/pipeline/runner/api/apirunner"
+	"Storage/internal/components/pipeline/runner/api/apirunner/auth"
+	"Storage/internal/components/retry"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	urls "net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// RpcRunner gRPC API执行器，按服务端反射或 protoset 中的描述符动态调用一元方法和服务端流方法
+// 请求体为 JSON 格式的请求消息，请求头作为 metadata 发送，响应消息转换为 JSON 后供断言和提取器使用；
+// 依赖准备、认证、重试、断言和数据提取与 HTTP 执行器相同，场景会话、HAR 和磁带只作用于 HTTP 请求
+type RpcRunner struct {
+	*HttpRunner
+
+	// 解析出的描述符，键为 protoset 或反射的目标服务
+	descMu      sync.Mutex
+	descriptors map[string]*descriptorSet
+}
+
+// rpc 响应中 gRPC 状态的字段
+const (
+	responseGrpcMessage  = "grpc_message"
+	responseMessageCount = "message_count"
+)
+
+// rpcJSON 响应消息转换为 JSON 的配置，使用 proto 中的字段名并输出零值字段，断言可以直接引用 proto 中定义的字段
+var rpcJSON = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+// rpcReservedHeaders 由 gRPC 传输层设置的请求头，不作为 metadata 发送
+var rpcReservedHeaders = map[string]bool{
+	"content-type":   true,
+	"content-length": true,
+	"connection":     true,
+	"host":           true,
+	"te":             true,
+}
+
+// NewRpcRunner 创建新的gRPC执行器
+func NewRpcRunner(contextData map[string]interface{}) *RpcRunner {
+	r := &RpcRunner{
+		HttpRunner:  NewHttpRunner(contextData),
+		descriptors: make(map[string]*descriptorSet),
+	}
+	r.HttpRunner.sender = r
+	return r
+}
+
+// BuildRequest 构建gRPC请求，请求路径为 /包名.服务名/方法名，与基础URL拼接为 grpc://主机:端口/包名.服务名/方法名
+func (r *RpcRunner) BuildRequest(ctx context.Context, api *api.ApiDefinition, dependencies map[string]interface{}) (map[string]interface{}, error) {
+	request, err := r.HttpRunner.BuildRequest(ctx, api, dependencies)
+	if err != nil {
+		return nil, err
+	}
+	request["rpc"] = api.Rpc
+	return request, nil
+}
+
+// ExecuteRequest 执行gRPC请求
+// 服务端返回的错误状态作为响应返回，status_code 为 gRPC 状态码，由断言判断结果；连接失败和超时返回错误
+func (r *RpcRunner) ExecuteRequest(ctx context.Context, request map[string]interface{}) (map[string]interface{}, error) {
+	response := make(map[string]interface{})
+
+	rawURL, _ := request["url"].(string)
+	headers, _ := request["headers"].(map[string]string)
+	config, _ := request["rpc"].(*api.RpcConfig)
+
+	// 解析目标地址和方法，配置错误重试也不会成功
+	target, service, method, secure, err := parseRpcURL(rawURL)
+	if err != nil {
+		return nil, retry.Permanent(err)
+	}
+	payload, err := rpcPayload(request["body"])
+	if err != nil {
+		return nil, retry.Permanent(err)
+	}
+
+	// 请求上下文由执行器持有，Cancel 时中断进行中的请求
+	reqCtx, err := r.beginRequest(ctx)
+	if err != nil {
+		return nil, retry.Permanent(err)
+	}
+	defer r.endRequest()
+
+	// 认证信息按 HTTP 请求头添加后作为 metadata 发送
+	md, err := r.rpcMetadata(reqCtx, rawURL, headers, payload, request)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := r.dial(target, secure)
+	if err != nil {
+		return nil, retry.Permanent(err)
+	}
+	defer conn.Close()
+
+	// 反射请求同样携带 metadata，需要认证的服务端可以获取描述符
+	callCtx := metadata.NewOutgoingContext(reqCtx, md)
+	descs, err := r.loadDescriptors(callCtx, conn, config, target, service)
+	if err != nil {
+		if r.isCanceled() {
+			return nil, retry.Permanent(fmt.Errorf("gRPC请求已取消: %w", err))
+		}
+		return nil, fmt.Errorf("获取gRPC描述符失败: %w", core.WrapTimeout(reqCtx, err))
+	}
+	methodDesc, err := descs.findMethod(service, method)
+	if err != nil {
+		return nil, retry.Permanent(err)
+	}
+	if methodDesc.IsStreamingClient() {
+		return nil, retry.Permanent(fmt.Errorf("暂不支持客户端流和双向流方法 %s", methodDesc.FullName()))
+	}
+
+	input := dynamicpb.NewMessage(methodDesc.Input())
+	if err := (protojson.UnmarshalOptions{Resolver: descs}).Unmarshal(payload, input); err != nil {
+		return nil, retry.Permanent(fmt.Errorf("请求体无法转换为 %s: %w", methodDesc.Input().FullName(), err))
+	}
+
+	// 记录原始请求
+	fullMethod := "/" + service + "/" + method
+	rawRequest := fmt.Sprintf("%s %s\n", api.MethodGRPC, rawURL)
+	for _, k := range sortedKeys(md) {
+		rawRequest += fmt.Sprintf("%s: %s\n", k, strings.Join(md[k], ", "))
+	}
+	rawRequest += "\n" + string(payload)
+	response["raw_request"] = rawRequest
+
+	// 更新执行状态
+	r.status = core.TaskStatusRunning
+	startTime := time.Now()
+
+	// 发送请求，服务端流方法接收到流结束或达到消息数上限
+	var header, trailer metadata.MD
+	var messages []proto.Message
+	if methodDesc.IsStreamingServer() {
+		maxMessages := 0
+		if config != nil {
+			maxMessages = config.MaxMessages
+		}
+		var stream grpc.ClientStream
+		stream, err = conn.NewStream(callCtx, &grpc.StreamDesc{ServerStreams: true}, fullMethod)
+		if err == nil {
+			messages, err = receiveStream(stream, input, methodDesc.Output(), maxMessages)
+			header, _ = stream.Header()
+			trailer = stream.Trailer()
+		}
+	} else {
+		output := dynamicpb.NewMessage(methodDesc.Output())
+		err = conn.Invoke(callCtx, fullMethod, input, output, grpc.Header(&header), grpc.Trailer(&trailer))
+		if err == nil {
+			messages = append(messages, output)
+		}
+	}
+	endTime := time.Now()
+	duration := endTime.Sub(startTime).Seconds()
+
+	// 取消和超时返回错误，服务端返回的状态作为响应
+	st, ok := status.FromError(err)
+	if err != nil && (!ok || reqCtx.Err() != nil) {
+		if r.isCanceled() {
+			r.status = core.TaskStatusCanceled
+			return nil, retry.Permanent(fmt.Errorf("gRPC请求已取消: %w", err))
+		}
+		return nil, fmt.Errorf("执行gRPC请求失败: %w", core.WrapTimeout(reqCtx, err))
+	}
+
+	// 一元方法返回响应消息，服务端流方法返回消息数组，失败时返回 google.rpc.Status
+	var body []byte
+	switch {
+	case st.Code() != codes.OK && len(messages) == 0:
+		body, err = compactJSON(protojson.MarshalOptions{UseProtoNames: true, Resolver: descs}.Marshal(st.Proto()))
+	case methodDesc.IsStreamingServer():
+		body, err = marshalMessages(messages, descs)
+	default:
+		body, err = marshalMessage(messages[0], descs)
+	}
+	if err != nil {
+		return nil, retry.Permanent(fmt.Errorf("响应消息转换为JSON失败: %w", err))
+	}
+
+	// 响应头包括 header 和 trailer metadata
+	respHeaders := make(map[string]string, len(header)+len(trailer))
+	for _, m := range []metadata.MD{header, trailer} {
+		for k, v := range m {
+			respHeaders[k] = strings.Join(v, ", ")
+		}
+	}
+
+	// 记录原始响应
+	rawResponse := fmt.Sprintf("%s %s\n", st.Code(), st.Message())
+	for _, k := range sortedKeys(respHeaders) {
+		rawResponse += fmt.Sprintf("%s: %s\n", k, respHeaders[k])
+	}
+	rawResponse += "\n" + string(body)
+	response["raw_response"] = rawResponse
+
+	// 设置响应数据
+	response["status_code"] = int(st.Code())
+	response["status"] = st.Code().String()
+	response[responseGrpcMessage] = st.Message()
+	response[responseMessageCount] = len(messages)
+	response["headers"] = respHeaders
+	response["cookies"] = make(map[string]string)
+	response["body"] = string(body)
+	response["duration"] = duration
+	var jsonData interface{}
+	if err := json.Unmarshal(body, &jsonData); err == nil {
+		response["json"] = jsonData
+	}
+
+	// 更新指标
+	if apiID, ok := request["api_id"].(string); ok {
+		r.metrics.ApiID = apiID
+	}
+	if apiName, ok := request["api_name"].(string); ok {
+		r.metrics.ApiName = apiName
+	}
+	r.metrics.Method = api.MethodGRPC
+	r.metrics.Path = rawURL
+	r.metrics.StartTime = startTime.Format(time.RFC3339)
+	r.metrics.EndTime = endTime.Format(time.RFC3339)
+	r.metrics.Duration = duration
+	r.metrics.StatusCode = int(st.Code())
+	r.metrics.RequestSize = int64(proto.Size(input))
+	r.metrics.ResponseSize = 0
+	for _, msg := range messages {
+		r.metrics.ResponseSize += int64(proto.Size(msg))
+	}
+
+	// 设置状态
+	if st.Code() == codes.OK {
+		r.metrics.Status = "succeeded"
+		r.status = core.TaskStatusCompleted
+	} else {
+		r.metrics.Status = "failed"
+		r.metrics.Error = &core.PipelineError{
+			Message: fmt.Sprintf("gRPC request failed with status: %s", st.Code()),
+			Code:    "GRPC_ERROR",
+		}
+		r.status = core.TaskStatusFailed
+	}
+
+	return response, nil
+}
+
+// statusError UNAVAILABLE 和 RESOURCE_EXHAUSTED 状态需要重试
+func (r *RpcRunner) statusError(response map[string]interface{}) error {
+	code, _ := response["status_code"].(int)
+	switch codes.Code(code) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return fmt.Errorf("gRPC 状态 %s", codes.Code(code))
+	}
+	return nil
+}
+
+// rpcMetadata 将请求头和认证信息转换为 metadata，认证方式的优先级与 HTTP 请求相同
+// 以 -bin 结尾的请求头按 base64 解码为二进制值
+func (r *RpcRunner) rpcMetadata(ctx context.Context, rawURL string, headers map[string]string, payload []byte, request map[string]interface{}) (metadata.MD, error) {
+	carrier, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, nil)
+	if err != nil {
+		return nil, retry.Permanent(fmt.Errorf("创建gRPC请求失败: %w", err))
+	}
+	for k, v := range headers {
+		carrier.Header.Set(k, v)
+	}
+
+	r.mu.Lock()
+	provider := r.envAuth
+	r.mu.Unlock()
+	if value, ok := request["auth"]; ok {
+		provider, _ = value.(auth.Provider)
+	}
+	if provider != nil {
+		if err := provider.Apply(ctx, carrier, payload); err != nil {
+			return nil, fmt.Errorf("添加请求认证失败: %w", core.WrapTimeout(ctx, err))
+		}
+	}
+
+	md := make(metadata.MD, len(carrier.Header))
+	for k, values := range carrier.Header {
+		key := strings.ToLower(k)
+		if rpcReservedHeaders[key] {
+			continue
+		}
+		for _, v := range values {
+			if strings.HasSuffix(key, "-bin") {
+				decoded, err := base64.StdEncoding.DecodeString(v)
+				if err != nil {
+					return nil, retry.Permanent(fmt.Errorf("metadata %s 需要 base64 编码: %w", k, err))
+				}
+				v = string(decoded)
+			}
+			md.Append(key, v)
+		}
+	}
+	return md, nil
+}
+
+// dial 创建到目标地址的连接，grpcs 和 https 协议使用执行环境的TLS配置
+func (r *RpcRunner) dial(target string, secure bool) (*grpc.ClientConn, error) {
+	creds := insecure.NewCredentials()
+	if secure {
+		var config *tls.Config
+		if env := r.currentEnvironment(); env != nil {
+			var err error
+			if config, err = env.TLS.Build(); err != nil {
+				return nil, err
+			}
+		}
+		if config == nil {
+			config = &tls.Config{}
+		}
+		creds = credentials.NewTLS(config)
+	}
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("创建gRPC连接失败: %w", err)
+	}
+	return conn, nil
+}
+
+// loadDescriptors 获取描述符，配置了 protoset 时解析 protoset，否则通过服务端反射获取，结果按来源缓存
+func (r *RpcRunner) loadDescriptors(ctx context.Context, conn *grpc.ClientConn, config *api.RpcConfig, target, service string) (*descriptorSet, error) {
+	var key string
+	if config.UseReflection() {
+		key = "reflection:" + target + "/" + service
+	} else if len(config.ProtosetData) > 0 {
+		sum := sha256.Sum256(config.ProtosetData)
+		key = "protoset:" + hex.EncodeToString(sum[:])
+	} else {
+		key = "protoset:" + config.Protoset
+	}
+
+	r.descMu.Lock()
+	defer r.descMu.Unlock()
+	if descs, ok := r.descriptors[key]; ok {
+		return descs, nil
+	}
+
+	var descs *descriptorSet
+	var err error
+	if config.UseReflection() {
+		descs, err = reflectFiles(ctx, conn, service)
+	} else if descs, err = loadProtosetFiles(config); err != nil {
+		err = retry.Permanent(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.descriptors[key] = descs
+	return descs, nil
+}
+
+// parseRpcURL 解析 grpc://主机:端口/包名.服务名/方法名 形式的URL
+func parseRpcURL(rawURL string) (target, service, method string, secure bool, err error) {
+	u, err := urls.Parse(rawURL)
+	if err != nil {
+		return "", "", "", false, fmt.Errorf("解析gRPC地址失败: %w", err)
+	}
+	switch u.Scheme {
+	case "grpc", "http":
+	case "grpcs", "https":
+		secure = true
+	default:
+		return "", "", "", false, fmt.Errorf("gRPC地址 %s 的协议需要为 grpc 或 grpcs", rawURL)
+	}
+	if u.Host == "" {
+		return "", "", "", false, fmt.Errorf("gRPC地址 %s 缺少主机", rawURL)
+	}
+
+	path := strings.Trim(u.Path, "/")
+	i := strings.LastIndexByte(path, '/')
+	if i <= 0 || i == len(path)-1 {
+		return "", "", "", false, fmt.Errorf("gRPC请求路径 %s 需要为 /包名.服务名/方法名", u.Path)
+	}
+	// 基础URL可能带有路径前缀，服务名取方法名前的最后一段
+	service, method = path[:i], path[i+1:]
+	if j := strings.LastIndexByte(service, '/'); j >= 0 {
+		service = service[j+1:]
+	}
+	return u.Host, service, method, secure, nil
+}
+
+// rpcPayload 将请求体转换为 JSON，字符串按 JSON 文本使用，为空时发送空消息
+func rpcPayload(body interface{}) ([]byte, error) {
+	switch v := body.(type) {
+	case nil:
+		return []byte("{}"), nil
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return []byte("{}"), nil
+		}
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("请求体无法序列化为JSON: %w", err)
+		}
+		return data, nil
+	}
+}
+
+// receiveStream 发送请求消息并接收服务端流的消息，maxMessages 大于 0 时接收到上限后结束
+func receiveStream(stream grpc.ClientStream, input proto.Message, output protoreflect.MessageDescriptor, maxMessages int) ([]proto.Message, error) {
+	if err := stream.SendMsg(input); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	var messages []proto.Message
+	for maxMessages <= 0 || len(messages) < maxMessages {
+		msg := dynamicpb.NewMessage(output)
+		err := stream.RecvMsg(msg)
+		if errors.Is(err, io.EOF) {
+			return messages, nil
+		}
+		if err != nil {
+			return messages, err
+		}
+		messages = append(messages, msg)
+	}
+	return messages, nil
+}
+
+func marshalMessage(msg proto.Message, descs *descriptorSet) ([]byte, error) {
+	options := rpcJSON
+	options.Resolver = descs
+	return compactJSON(options.Marshal(msg))
+}
+
+// compactJSON 去除 protojson 输出中随机的空白，相同的消息得到相同的响应体
+func compactJSON(data []byte, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// marshalMessages 将服务端流的消息转换为 JSON 数组
+func marshalMessages(messages []proto.Message, descs *descriptorSet) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, msg := range messages {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		data, err := marshalMessage(msg, descs)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
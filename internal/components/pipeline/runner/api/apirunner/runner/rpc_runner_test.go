@@ -0,0 +1,598 @@
+package runner
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner"
+	"Storage/internal/components/pipeline/runner/api/apirunner/auth"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// echoSentAt 测试服务响应中固定的时间
+var echoSentAt = time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+
+// echoFileProto 测试服务的描述符，EchoReply 引用 google.protobuf.Timestamp，用于验证依赖文件的解析
+func echoFileProto() *descriptorpb.FileDescriptorProto {
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:   proto.String(name),
+			Number: proto.Int32(number),
+			Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:   typ.Enum(),
+		}
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+	method := func(name string, clientStreaming, serverStreaming bool) *descriptorpb.MethodDescriptorProto {
+		return &descriptorpb.MethodDescriptorProto{
+			Name:            proto.String(name),
+			InputType:       proto.String(".test.v1.EchoRequest"),
+			OutputType:      proto.String(".test.v1.EchoReply"),
+			ClientStreaming: proto.Bool(clientStreaming),
+			ServerStreaming: proto.Bool(serverStreaming),
+		}
+	}
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("test/v1/echo.proto"),
+		Package:    proto.String("test.v1"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/timestamp.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("EchoRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("message", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+					field("count", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, ""),
+					field("code", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+				},
+			},
+			{
+				Name: proto.String("EchoReply"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("message", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+					field("index", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, ""),
+					field("sent_at", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Timestamp"),
+				},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Echo"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				method("Say", false, false),
+				method("Repeat", false, true),
+				method("Chat", true, true),
+			},
+		}},
+	}
+}
+
+// echoServer 本地 gRPC 测试服务，启用反射，记录最近一次调用收到的 metadata
+type echoServer struct {
+	addr  string
+	token string
+
+	request protoreflect.MessageDescriptor
+	reply   protoreflect.MessageDescriptor
+
+	mu       sync.Mutex
+	received metadata.MD
+	// FLAKY 请求的调用次数
+	flaky atomic.Int32
+}
+
+// startEchoServer 启动测试服务，token 不为空时所有调用（包括反射）需要 Bearer 认证
+func startEchoServer(t *testing.T, token string) *echoServer {
+	t.Helper()
+	fd, err := protodesc.NewFile(echoFileProto(), protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatalf("NewFile() error = %v", err)
+	}
+	files := &protoregistry.Files{}
+	if err := files.RegisterFile(fd); err != nil {
+		t.Fatalf("RegisterFile() error = %v", err)
+	}
+	if err := files.RegisterFile(timestamppb.File_google_protobuf_timestamp_proto); err != nil {
+		t.Fatalf("RegisterFile() error = %v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	s := &echoServer{
+		addr:    listener.Addr().String(),
+		token:   token,
+		request: fd.Messages().ByName("EchoRequest"),
+		reply:   fd.Messages().ByName("EchoReply"),
+	}
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if err := s.authenticate(ctx); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := s.authenticate(stream.Context()); err != nil {
+				return err
+			}
+			return handler(srv, stream)
+		}),
+	)
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "test.v1.Echo",
+		HandlerType: (*interface{})(nil),
+		Methods:     []grpc.MethodDesc{{MethodName: "Say", Handler: s.say}},
+		Streams: []grpc.StreamDesc{
+			{StreamName: "Repeat", ServerStreams: true, Handler: s.repeat},
+			{StreamName: "Chat", ServerStreams: true, ClientStreams: true, Handler: s.repeat},
+		},
+	}, struct{}{})
+	reflectionpb.RegisterServerReflectionServer(server, reflection.NewServerV1(reflection.ServerOptions{
+		Services:           server,
+		DescriptorResolver: files,
+	}))
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return s
+}
+
+func (s *echoServer) authenticate(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.mu.Lock()
+	s.received = md
+	s.mu.Unlock()
+	if s.token != "" && strings.Join(md.Get("authorization"), "") != "Bearer "+s.token {
+		return status.Error(codes.Unauthenticated, "缺少认证信息")
+	}
+	return nil
+}
+
+// lastMetadata 最近一次调用收到的 metadata
+func (s *echoServer) lastMetadata() metadata.MD {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.received
+}
+
+// newReply 创建响应消息
+func (s *echoServer) newReply(message string, index int) *dynamicpb.Message {
+	reply := dynamicpb.NewMessage(s.reply)
+	fields := s.reply.Fields()
+	reply.Set(fields.ByName("message"), protoreflect.ValueOfString(message))
+	reply.Set(fields.ByName("index"), protoreflect.ValueOfInt32(int32(index)))
+	reply.Set(fields.ByName("sent_at"), protoreflect.ValueOfMessage(timestamppb.New(echoSentAt).ProtoReflect()))
+	return reply
+}
+
+// status 请求的 code 字段指定返回的状态，FLAKY 第一次返回 UNAVAILABLE
+func (s *echoServer) status(req *dynamicpb.Message) error {
+	code := req.Get(s.request.Fields().ByName("code")).String()
+	switch code {
+	case "":
+		return nil
+	case "FLAKY":
+		if s.flaky.Add(1) == 1 {
+			return status.Error(codes.Unavailable, "服务暂不可用")
+		}
+		return nil
+	}
+	var c codes.Code
+	if err := c.UnmarshalJSON([]byte(`"` + code + `"`)); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(c, "echo "+code)
+}
+
+// say 一元方法，与生成的代码一样经过拦截器处理
+func (s *echoServer) say(_ interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	req := dynamicpb.NewMessage(s.request)
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.Echo/Say"}
+	return interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return s.handleSay(ctx, req.(*dynamicpb.Message))
+	})
+}
+
+func (s *echoServer) handleSay(ctx context.Context, req *dynamicpb.Message) (interface{}, error) {
+	grpc.SetHeader(ctx, metadata.Pairs("x-served-by", "echo"))
+	grpc.SetTrailer(ctx, metadata.Pairs("x-trailer", "done"))
+	if err := s.status(req); err != nil {
+		return nil, err
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	message := req.Get(s.request.Fields().ByName("message")).String()
+	if tenant := md.Get("x-tenant"); len(tenant) > 0 {
+		message += "@" + tenant[0]
+	}
+	return s.newReply(message, 0), nil
+}
+
+// repeat 按 count 返回多条消息，code 不为空时发送完消息后返回该状态
+func (s *echoServer) repeat(_ interface{}, stream grpc.ServerStream) error {
+	req := dynamicpb.NewMessage(s.request)
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+	stream.SetHeader(metadata.Pairs("x-served-by", "echo"))
+	message := req.Get(s.request.Fields().ByName("message")).String()
+	count := int(req.Get(s.request.Fields().ByName("count")).Int())
+	for i := 0; i < count; i++ {
+		if err := stream.SendMsg(s.newReply(message, i)); err != nil {
+			return err
+		}
+	}
+	stream.SetTrailer(metadata.Pairs("x-trailer", "done"))
+	return s.status(req)
+}
+
+// echoProtoset 测试服务的 protoset，includeImports 为 true 时包含依赖的文件
+func echoProtoset(t *testing.T, includeImports bool) []byte {
+	t.Helper()
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{echoFileProto()}}
+	if includeImports {
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(timestamppb.File_google_protobuf_timestamp_proto))
+	}
+	data, err := proto.Marshal(set)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	return data
+}
+
+// callRpc 构建并执行一次 gRPC 请求
+func callRpc(t *testing.T, r *RpcRunner, addr string, def *api.ApiDefinition) (map[string]interface{}, error) {
+	t.Helper()
+	def.Method = api.MethodGRPC
+	if def.BodyType == "" {
+		def.BodyType = BodyTypeJSON
+	}
+	request, err := r.BuildRequest(context.Background(), def, map[string]interface{}{api.BaseURLVariable: "grpc://" + addr})
+	if err != nil {
+		t.Fatalf("BuildRequest() error = %v", err)
+	}
+	return r.ExecuteRequest(context.Background(), request)
+}
+
+func TestRpcRunnerDescriptors(t *testing.T) {
+	server := startEchoServer(t, "")
+	protosetPath := filepath.Join(t.TempDir(), "echo.protoset")
+	if err := os.WriteFile(protosetPath, echoProtoset(t, true), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		rpc  *api.RpcConfig
+		body interface{}
+		// 期望的响应体，为空时期望返回错误
+		wantBody string
+		// 期望的错误，以 ... 结尾时只比较前缀
+		wantErr string
+	}{
+		{name: "服务端反射", path: "/test.v1.Echo/Say", wantBody: `{"message":"hi","index":0,"sent_at":"2024-05-01T08:00:00Z"}`},
+		{name: "上传的 protoset，依赖使用进程内注册的文件", path: "/test.v1.Echo/Say", rpc: &api.RpcConfig{ProtosetData: echoProtoset(t, false)}, wantBody: `{"message":"hi","index":0,"sent_at":"2024-05-01T08:00:00Z"}`},
+		{name: "protoset 文件", path: "/test.v1.Echo/Say", rpc: &api.RpcConfig{Protoset: protosetPath}, wantBody: `{"message":"hi","index":0,"sent_at":"2024-05-01T08:00:00Z"}`},
+		{name: "基础URL带路径前缀", path: "/prefix/test.v1.Echo/Say", wantBody: `{"message":"hi","index":0,"sent_at":"2024-05-01T08:00:00Z"}`},
+		{name: "protoset 文件不存在", path: "/test.v1.Echo/Say", rpc: &api.RpcConfig{Protoset: filepath.Join(t.TempDir(), "missing.protoset")}, wantErr: "获取gRPC描述符失败: 读取 protoset 文件失败: open ..."},
+		{name: "protoset 内容无效", path: "/test.v1.Echo/Say", rpc: &api.RpcConfig{ProtosetData: []byte("not a protoset")}, wantErr: "获取gRPC描述符失败: 解析 protoset 失败: ..."},
+		{name: "反射找不到服务", path: "/test.v1.Missing/Say", wantErr: "获取gRPC描述符失败: 获取服务 test.v1.Missing 的描述符失败: 反射服务返回错误: ..."},
+		{name: "protoset 中没有服务", path: "/test.v1.Missing/Say", rpc: &api.RpcConfig{ProtosetData: echoProtoset(t, false)}, wantErr: "描述符中不存在服务 test.v1.Missing"},
+		{name: "方法不存在", path: "/test.v1.Echo/Shout", wantErr: "服务 test.v1.Echo 不存在方法 Shout"},
+		{name: "不支持双向流", path: "/test.v1.Echo/Chat", wantErr: "暂不支持客户端流和双向流方法 test.v1.Echo.Chat"},
+		{name: "请求体字段不存在", path: "/test.v1.Echo/Say", body: map[string]interface{}{"msg": "hi"}, wantErr: "请求体无法转换为 test.v1.EchoRequest: ..."},
+		{name: "路径缺少方法", path: "/test.v1.Echo", wantErr: "gRPC请求路径 /test.v1.Echo 需要为 /包名.服务名/方法名"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := tt.body
+			if body == nil {
+				body = map[string]interface{}{"message": "hi"}
+			}
+			response, err := callRpc(t, NewRpcRunner(nil), server.addr, &api.ApiDefinition{Path: tt.path, Body: body, Rpc: tt.rpc})
+			if tt.wantErr != "" {
+				prefix, partial := strings.CutSuffix(tt.wantErr, "...")
+				if err == nil || (partial && !strings.HasPrefix(err.Error(), prefix)) || (!partial && err.Error() != tt.wantErr) {
+					t.Fatalf("ExecuteRequest() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExecuteRequest() error = %v", err)
+			}
+			if response["body"] != tt.wantBody {
+				t.Errorf("body = %s, want %s", response["body"], tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestRpcRunnerDescriptorCache(t *testing.T) {
+	server := startEchoServer(t, "")
+	r := NewRpcRunner(nil)
+	if _, err := callRpc(t, r, server.addr, &api.ApiDefinition{Path: "/test.v1.Echo/Say"}); err != nil {
+		t.Fatalf("ExecuteRequest() error = %v", err)
+	}
+	if len(r.descriptors) != 1 {
+		t.Fatalf("descriptors = %d, want 1", len(r.descriptors))
+	}
+	// 同一服务的其他方法使用缓存的描述符
+	if _, err := callRpc(t, r, server.addr, &api.ApiDefinition{Path: "/test.v1.Echo/Repeat", Body: map[string]interface{}{"count": 1}}); err != nil {
+		t.Fatalf("ExecuteRequest() error = %v", err)
+	}
+	if len(r.descriptors) != 1 {
+		t.Errorf("descriptors = %d, want 1", len(r.descriptors))
+	}
+}
+
+func TestRpcRunnerCalls(t *testing.T) {
+	server := startEchoServer(t, "")
+
+	tests := []struct {
+		name        string
+		path        string
+		body        string
+		rpc         *api.RpcConfig
+		wantCode    codes.Code
+		wantMessage string
+		wantCount   int
+		wantBody    string
+		wantHeaders map[string]string
+	}{
+		{
+			name:        "一元方法",
+			path:        "/test.v1.Echo/Say",
+			body:        `{"message":"hi"}`,
+			wantCount:   1,
+			wantBody:    `{"message":"hi","index":0,"sent_at":"2024-05-01T08:00:00Z"}`,
+			wantHeaders: map[string]string{"x-served-by": "echo", "x-trailer": "done"},
+		},
+		{
+			name:      "空请求体发送空消息",
+			path:      "/test.v1.Echo/Say",
+			wantCount: 1,
+			wantBody:  `{"message":"","index":0,"sent_at":"2024-05-01T08:00:00Z"}`,
+		},
+		{
+			name:        "一元方法返回错误状态",
+			path:        "/test.v1.Echo/Say",
+			body:        `{"code":"NOT_FOUND"}`,
+			wantCode:    codes.NotFound,
+			wantMessage: "echo NOT_FOUND",
+			wantBody:    `{"code":5,"message":"echo NOT_FOUND"}`,
+			wantHeaders: map[string]string{"x-served-by": "echo", "x-trailer": "done"},
+		},
+		{
+			name:        "服务端流",
+			path:        "/test.v1.Echo/Repeat",
+			body:        `{"message":"tick","count":3}`,
+			wantCount:   3,
+			wantBody:    `[{"message":"tick","index":0,"sent_at":"2024-05-01T08:00:00Z"},{"message":"tick","index":1,"sent_at":"2024-05-01T08:00:00Z"},{"message":"tick","index":2,"sent_at":"2024-05-01T08:00:00Z"}]`,
+			wantHeaders: map[string]string{"x-served-by": "echo", "x-trailer": "done"},
+		},
+		{
+			name:     "服务端流没有消息",
+			path:     "/test.v1.Echo/Repeat",
+			body:     `{"message":"tick"}`,
+			wantBody: `[]`,
+		},
+		{
+			name:      "服务端流达到消息数上限",
+			path:      "/test.v1.Echo/Repeat",
+			body:      `{"message":"tick","count":5}`,
+			rpc:       &api.RpcConfig{MaxMessages: 2},
+			wantCount: 2,
+			wantBody:  `[{"message":"tick","index":0,"sent_at":"2024-05-01T08:00:00Z"},{"message":"tick","index":1,"sent_at":"2024-05-01T08:00:00Z"}]`,
+		},
+		{
+			name:        "服务端流发送消息后失败",
+			path:        "/test.v1.Echo/Repeat",
+			body:        `{"message":"tick","count":1,"code":"ABORTED"}`,
+			wantCode:    codes.Aborted,
+			wantMessage: "echo ABORTED",
+			wantCount:   1,
+			wantBody:    `[{"message":"tick","index":0,"sent_at":"2024-05-01T08:00:00Z"}]`,
+		},
+		{
+			name:        "服务端流没有消息时失败",
+			path:        "/test.v1.Echo/Repeat",
+			body:        `{"code":"PERMISSION_DENIED"}`,
+			wantCode:    codes.PermissionDenied,
+			wantMessage: "echo PERMISSION_DENIED",
+			wantBody:    `{"code":7,"message":"echo PERMISSION_DENIED"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRpcRunner(nil)
+			def := &api.ApiDefinition{Path: tt.path, BodyType: BodyTypeRaw, Rpc: tt.rpc}
+			if tt.body != "" {
+				def.Body = tt.body
+			}
+			response, err := callRpc(t, r, server.addr, def)
+			if err != nil {
+				t.Fatalf("ExecuteRequest() error = %v", err)
+			}
+
+			if response["status_code"] != int(tt.wantCode) || response["status"] != tt.wantCode.String() {
+				t.Errorf("status = %v %v, want %d %s", response["status_code"], response["status"], tt.wantCode, tt.wantCode)
+			}
+			if response[responseGrpcMessage] != tt.wantMessage {
+				t.Errorf("%s = %q, want %q", responseGrpcMessage, response[responseGrpcMessage], tt.wantMessage)
+			}
+			if response[responseMessageCount] != tt.wantCount {
+				t.Errorf("%s = %v, want %d", responseMessageCount, response[responseMessageCount], tt.wantCount)
+			}
+			if response["body"] != tt.wantBody {
+				t.Errorf("body = %s, want %s", response["body"], tt.wantBody)
+			}
+			if _, ok := response["json"]; !ok {
+				t.Errorf("json not set for body %s", response["body"])
+			}
+			headers := response["headers"].(map[string]string)
+			for name, want := range tt.wantHeaders {
+				if headers[name] != want {
+					t.Errorf("header %s = %q, want %q", name, headers[name], want)
+				}
+			}
+
+			// 错误状态记为执行失败，由断言判断结果
+			wantStatus := "succeeded"
+			if tt.wantCode != codes.OK {
+				wantStatus = "failed"
+			}
+			if r.metrics.Status != wantStatus || r.metrics.StatusCode != int(tt.wantCode) || r.metrics.Method != api.MethodGRPC {
+				t.Errorf("metrics = %s %d %s, want %s %d %s", r.metrics.Status, r.metrics.StatusCode, r.metrics.Method, wantStatus, tt.wantCode, api.MethodGRPC)
+			}
+		})
+	}
+}
+
+func TestRpcRunnerMetadata(t *testing.T) {
+	server := startEchoServer(t, "t-1")
+	bearer := &auth.Config{Type: auth.TypeBearer, Bearer: &auth.BearerConfig{Token: "t-1"}}
+
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		auth     *auth.Config
+		wantCode codes.Code
+		// 服务端收到的 metadata
+		wantMetadata map[string][]string
+		wantErr      string
+	}{
+		{
+			name:     "请求头和认证作为 metadata 发送",
+			headers:  map[string]string{"X-Tenant": "t1", "Trace-Bin": base64.StdEncoding.EncodeToString([]byte{0, 1, 2}), "Content-Type": "application/json"},
+			auth:     bearer,
+			wantCode: codes.OK,
+			wantMetadata: map[string][]string{
+				"x-tenant":      {"t1"},
+				"trace-bin":     {string([]byte{0, 1, 2})},
+				"authorization": {"Bearer t-1"},
+				"content-type":  {"application/grpc"},
+			},
+		},
+		{
+			name:    "缺少认证时反射请求被拒绝",
+			headers: map[string]string{"X-Tenant": "t1"},
+			wantErr: "获取gRPC描述符失败: 获取服务 test.v1.Echo 的描述符失败: 接收反射响应失败: rpc error: code = Unauthenticated desc = 缺少认证信息",
+		},
+		{
+			name:    "二进制 metadata 不是 base64",
+			headers: map[string]string{"Trace-Bin": "%%"},
+			auth:    bearer,
+			wantErr: "metadata Trace-Bin 需要 base64 编码: illegal base64 data at input byte 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRpcRunner(nil)
+			def := &api.ApiDefinition{Method: api.MethodGRPC, Path: "/test.v1.Echo/Say", Headers: tt.headers, BodyType: BodyTypeJSON, Body: map[string]interface{}{"message": "hi"}}
+			request, err := r.BuildRequest(context.Background(), def, map[string]interface{}{api.BaseURLVariable: "grpc://" + server.addr})
+			if err != nil {
+				t.Fatalf("BuildRequest() error = %v", err)
+			}
+			if tt.auth != nil {
+				if request["auth"], err = auth.NewProvider(tt.auth, nil); err != nil {
+					t.Fatalf("NewProvider() error = %v", err)
+				}
+			}
+			response, err := r.ExecuteRequest(context.Background(), request)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("ExecuteRequest() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExecuteRequest() error = %v", err)
+			}
+			if response["status_code"] != int(tt.wantCode) {
+				t.Errorf("status_code = %v, want %d", response["status_code"], tt.wantCode)
+			}
+			if response["body"] != `{"message":"hi@t1","index":0,"sent_at":"2024-05-01T08:00:00Z"}` {
+				t.Errorf("body = %s", response["body"])
+			}
+			received := server.lastMetadata()
+			for name, want := range tt.wantMetadata {
+				if got := received.Get(name); !reflect.DeepEqual(got, want) {
+					t.Errorf("metadata %s = %q, want %q", name, got, want)
+				}
+			}
+			// 原始请求中记录发送的 metadata
+			if raw := response["raw_request"].(string); !strings.Contains(raw, "x-tenant: t1\n") || !strings.HasPrefix(raw, "GRPC grpc://"+server.addr+"/test.v1.Echo/Say\n") {
+				t.Errorf("raw_request = %q", raw)
+			}
+		})
+	}
+}
+
+func TestRpcRunnerRetryStatus(t *testing.T) {
+	server := startEchoServer(t, "")
+	tests := []struct {
+		name         string
+		code         string
+		wantCode     codes.Code
+		wantAttempts int
+	}{
+		{name: "UNAVAILABLE 重试后成功", code: "FLAKY", wantCode: codes.OK, wantAttempts: 2},
+		{name: "其他错误状态不重试", code: "INVALID_ARGUMENT", wantCode: codes.InvalidArgument, wantAttempts: 1},
+		{name: "重试用尽时返回最后一次的响应", code: "RESOURCE_EXHAUSTED", wantCode: codes.ResourceExhausted, wantAttempts: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server.flaky.Store(0)
+			r := NewRpcRunner(nil)
+			response, err := r.Execute(context.Background(), map[string]interface{}{
+				"api_id":    "echo",
+				"name":      "say",
+				"method":    api.MethodGRPC,
+				"path":      "/test.v1.Echo/Say",
+				"base_url":  "grpc://" + server.addr,
+				"body_type": BodyTypeJSON,
+				"body":      map[string]interface{}{"code": tt.code},
+				"retry":     map[string]interface{}{"max_retries": 2, "interval": 1},
+			})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if response["status_code"] != int(tt.wantCode) || response["attempts"] != tt.wantAttempts {
+				t.Errorf("status_code = %v, attempts = %v, want %d, %d", response["status_code"], response["attempts"], tt.wantCode, tt.wantAttempts)
+			}
+		})
+	}
+}
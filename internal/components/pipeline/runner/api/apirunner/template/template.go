@@ -0,0 +1,347 @@
+package template
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner/jsonpath"
+)
+
+// Render 渲染字符串模板，支持 {{变量路径}} 和 {{函数(参数...)}}
+// 整个字符串只有一个占位符时返回原始值以保留类型，否则把各占位符的值转为字符串后拼接
+func Render(text string, scope map[string]interface{}) (interface{}, error) {
+	if !strings.Contains(text, leftDelim) {
+		return text, nil
+	}
+
+	// 整值替换
+	if strings.HasPrefix(text, leftDelim) {
+		end, err := closeIndex(text, len(leftDelim))
+		if err != nil {
+			return nil, &SyntaxError{Template: text, Message: err.Error()}
+		}
+		if end == len(text)-len(rightDelim) {
+			return evaluate(text[len(leftDelim):end], scope)
+		}
+	}
+	return RenderString(text, scope)
+}
+
+// RenderString 渲染字符串模板，结果总是字符串
+func RenderString(text string, scope map[string]interface{}) (string, error) {
+	var sb strings.Builder
+	rest := text
+	for {
+		start := strings.Index(rest, leftDelim)
+		if start < 0 {
+			sb.WriteString(rest)
+			return sb.String(), nil
+		}
+		end, err := closeIndex(rest, start+len(leftDelim))
+		if err != nil {
+			return "", &SyntaxError{Template: text, Message: err.Error()}
+		}
+
+		value, err := evaluate(rest[start+len(leftDelim):end], scope)
+		if err != nil {
+			return "", err
+		}
+		s, err := Stringify(value)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(rest[:start])
+		sb.WriteString(s)
+		rest = rest[end+len(rightDelim):]
+	}
+}
+
+// RenderValue 递归渲染值中的字符串，支持嵌套的 map 和切片，map 的键按字符串渲染
+func RenderValue(value interface{}, scope map[string]interface{}) (interface{}, error) {
+	return renderValue(value, scope, "")
+}
+
+// RenderStringMap 渲染请求头、查询参数等字符串映射的键和值
+func RenderStringMap(values map[string]string, scope map[string]interface{}) (map[string]string, error) {
+	if values == nil {
+		return nil, nil
+	}
+	result := make(map[string]string, len(values))
+	for k, v := range values {
+		key, err := RenderString(k, scope)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+		value, err := RenderString(v, scope)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+// renderValue 递归渲染，path 用于在错误中定位字段
+func renderValue(value interface{}, scope map[string]interface{}, path string) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		rendered, err := Render(v, scope)
+		if err != nil {
+			return nil, withPath(path, err)
+		}
+		return rendered, nil
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			key, err := RenderString(k, scope)
+			if err != nil {
+				return nil, withPath(joinPath(path, k), err)
+			}
+			rendered, err := renderValue(item, scope, joinPath(path, key))
+			if err != nil {
+				return nil, err
+			}
+			result[key] = rendered
+		}
+		return result, nil
+	case map[string]string:
+		result, err := RenderStringMap(v, scope)
+		if err != nil {
+			return nil, withPath(path, err)
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			rendered, err := renderValue(item, scope, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			result[i] = rendered
+		}
+		return result, nil
+	case []string:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			rendered, err := Render(item, scope)
+			if err != nil {
+				return nil, withPath(fmt.Sprintf("%s[%d]", path, i), err)
+			}
+			result[i] = rendered
+		}
+		return result, nil
+	default:
+		return value, nil
+	}
+}
+
+// evaluate 计算占位符中的表达式：函数调用或变量路径
+func evaluate(expr string, scope map[string]interface{}) (interface{}, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, &SyntaxError{Template: leftDelim + rightDelim, Message: "占位符为空"}
+	}
+
+	// 函数名之外的调用交给变量路径，如 items.length()
+	if open := strings.IndexByte(expr, '('); open > 0 && strings.HasSuffix(expr, ")") && isIdentifier(strings.TrimSpace(expr[:open])) {
+		name := strings.TrimSpace(expr[:open])
+		fn, ok := lookupFunc(name)
+		if !ok {
+			return nil, &SyntaxError{Template: expr, Message: fmt.Sprintf("未定义的函数 %s", name)}
+		}
+
+		rawArgs, err := splitArgs(expr[open+1 : len(expr)-1])
+		if err != nil {
+			return nil, &SyntaxError{Template: expr, Message: err.Error()}
+		}
+		args := make([]interface{}, 0, len(rawArgs))
+		for _, raw := range rawArgs {
+			arg, err := evaluateArg(raw, scope)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+		}
+
+		value, err := fn(args...)
+		if err != nil {
+			return nil, fmt.Errorf("调用函数 %s 失败: %w", name, err)
+		}
+		return value, nil
+	}
+
+	return lookup(expr, scope)
+}
+
+// evaluateArg 计算函数参数：字符串、数值、布尔、null 字面量，或变量路径、嵌套函数调用
+func evaluateArg(raw string, scope map[string]interface{}) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(raw, `"`):
+		s, err := strconv.Unquote(raw)
+		if err != nil {
+			return nil, &SyntaxError{Template: raw, Message: "字符串参数格式错误"}
+		}
+		return s, nil
+	case strings.HasPrefix(raw, "'"):
+		if len(raw) < 2 || !strings.HasSuffix(raw, "'") {
+			return nil, &SyntaxError{Template: raw, Message: "字符串参数格式错误"}
+		}
+		return raw[1 : len(raw)-1], nil
+	case raw == "true":
+		return true, nil
+	case raw == "false":
+		return false, nil
+	case raw == "null":
+		return nil, nil
+	}
+	if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(raw, 64); err == nil {
+		return f, nil
+	}
+	return evaluate(raw, scope)
+}
+
+// lookup 按 JSONPath 从作用域中取值，路径语法见 jsonpath.Compile，如 a.b[0].c、a.b.0.c、items.length()
+func lookup(path string, scope map[string]interface{}) (interface{}, error) {
+	value, err := jsonpath.Lookup(scope, path)
+	if err == nil {
+		return value, nil
+	}
+
+	var notFound *jsonpath.NotFoundError
+	if errors.As(err, &notFound) {
+		return nil, &UndefinedError{Name: path, Missing: notFound.Missing}
+	}
+	var syntax *jsonpath.SyntaxError
+	if errors.As(err, &syntax) {
+		return nil, &SyntaxError{Template: path, Message: syntax.Message}
+	}
+	return nil, err
+}
+
+// splitArgs 按顶层逗号拆分函数参数，忽略引号和括号内的逗号
+func splitArgs(text string) ([]string, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+
+	var args []string
+	var quote byte
+	depth, start := 0, 0
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			args = append(args, strings.TrimSpace(text[start:i]))
+			start = i + 1
+		}
+	}
+	if quote != 0 || depth != 0 {
+		return nil, fmt.Errorf("参数中的引号或括号未闭合")
+	}
+	args = append(args, strings.TrimSpace(text[start:]))
+	for _, arg := range args {
+		if arg == "" {
+			return nil, fmt.Errorf("参数不能为空")
+		}
+	}
+	return args, nil
+}
+
+// closeIndex 从 from 开始查找与占位符匹配的 }}，跳过引号中的内容
+func closeIndex(text string, from int) (int, error) {
+	var quote byte
+	for i := from; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case strings.HasPrefix(text[i:], rightDelim):
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("占位符缺少 %s", rightDelim)
+}
+
+// Stringify 将值转为字符串，map 和切片序列化为 JSON
+func Stringify(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", fmt.Errorf("序列化模板变量失败: %w", err)
+		}
+		return string(data), nil
+	default:
+		return fmt.Sprint(value), nil
+	}
+}
+
+// isIdentifier 是否为合法的函数名
+func isIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		if c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (i > 0 && c >= '0' && c <= '9') {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// withPath 在错误中标注所在字段
+func withPath(path string, err error) error {
+	if path == "" {
+		return err
+	}
+	return fmt.Errorf("%s: %w", path, err)
+}
+
+// joinPath 拼接字段路径
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,212 @@
+package template
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testScope() map[string]interface{} {
+	return map[string]interface{}{
+		"token": "abc",
+		"count": float64(3),
+		"user": map[string]interface{}{
+			"name": "alice",
+			"tags": []interface{}{"a", "b"},
+		},
+		"items": []interface{}{
+			map[string]interface{}{"id": float64(1)},
+			map[string]interface{}{"id": float64(2)},
+		},
+		SceneNamespace: map[string]interface{}{
+			"login": map[string]interface{}{"body": map[string]interface{}{"token": "t-1"}},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want interface{}
+	}{
+		{name: "没有占位符", text: "plain", want: "plain"},
+		{name: "整值替换保留类型", text: "{{count}}", want: float64(3)},
+		{name: "整值替换对象", text: "{{ user.tags }}", want: []interface{}{"a", "b"}},
+		{name: "拼接时转为字符串", text: "Bearer {{token}}", want: "Bearer abc"},
+		{name: "多个占位符", text: "{{user.name}}-{{count}}", want: "alice-3"},
+		{name: "拼接对象时序列化为 JSON", text: "tags={{user.tags}}", want: `tags=["a","b"]`},
+		{name: "下标", text: "{{items[1].id}}", want: float64(2)},
+		{name: "数字字段名", text: "{{items.0.id}}", want: float64(1)},
+		{name: "负数下标", text: "{{user.tags[-1]}}", want: "b"},
+		{name: "路径函数", text: "{{items.length()}}", want: 2},
+		{name: "场景步骤结果", text: "{{scene.login.body.token}}", want: "t-1"},
+		{name: "值为 null", text: "x{{missing_ok}}y", want: "xy"},
+		{name: "函数参数为变量", text: `{{random_int(count, count)}}`, want: int64(3)},
+		{name: "函数参数为字面量", text: `{{random_string(0)}}`, want: ""},
+		{name: "引号中的定界符", text: `{{now("}}")}}`, want: "}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope := testScope()
+			scope["missing_ok"] = nil
+			got, err := Render(tt.text, scope)
+			if err != nil {
+				t.Fatalf("Render(%q) error = %v", tt.text, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Render(%q) = %#v, want %#v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		// 期望的未定义变量，为空时期望语法错误
+		wantUndefined *UndefinedError
+		wantMessage   string
+	}{
+		{
+			name:          "未定义的变量",
+			text:          "{{password}}",
+			wantUndefined: &UndefinedError{Name: "password", Missing: "password"},
+			wantMessage:   "未定义的变量: password",
+		},
+		{
+			name:          "未定义的嵌套字段",
+			text:          "hello {{user.profile.age}}",
+			wantUndefined: &UndefinedError{Name: "user.profile.age", Missing: "user.profile"},
+			wantMessage:   "未定义的变量: user.profile.age (user.profile 不存在)",
+		},
+		{
+			name:          "下标越界",
+			text:          "{{items[5].id}}",
+			wantUndefined: &UndefinedError{Name: "items[5].id", Missing: "items[5]"},
+		},
+		{
+			name:          "未执行的场景步骤",
+			text:          "{{scene.logout.body}}",
+			wantUndefined: &UndefinedError{Name: "scene.logout.body", Missing: "scene.logout"},
+		},
+		{
+			name:          "函数参数中的未定义变量",
+			text:          "{{random_int(low, 10)}}",
+			wantUndefined: &UndefinedError{Name: "low", Missing: "low"},
+		},
+		{name: "占位符未闭合", text: "a {{token"},
+		{name: "占位符为空", text: "{{ }}"},
+		{name: "未定义的函数", text: "{{md5(token)}}"},
+		{name: "参数引号未闭合", text: `{{now("2006)}}`},
+		{name: "参数为空", text: "{{random_int(1,)}}"},
+		{name: "路径语法错误", text: "{{user..}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Render(tt.text, testScope())
+			if err == nil {
+				t.Fatalf("Render(%q) error = nil, want error", tt.text)
+			}
+
+			if tt.wantUndefined == nil {
+				var syntax *SyntaxError
+				if !errors.As(err, &syntax) {
+					t.Errorf("Render(%q) error = %v, want SyntaxError", tt.text, err)
+				}
+				return
+			}
+
+			var undefined *UndefinedError
+			if !errors.As(err, &undefined) {
+				t.Fatalf("Render(%q) error = %v, want UndefinedError", tt.text, err)
+			}
+			if *undefined != *tt.wantUndefined {
+				t.Errorf("Render(%q) error = %+v, want %+v", tt.text, *undefined, *tt.wantUndefined)
+			}
+			if tt.wantMessage != "" && err.Error() != tt.wantMessage {
+				t.Errorf("Render(%q) error = %q, want %q", tt.text, err.Error(), tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestRenderFuncError(t *testing.T) {
+	_, err := Render("{{random_int(5, 1)}}", testScope())
+	if err == nil || !strings.Contains(err.Error(), "调用函数 random_int 失败") {
+		t.Errorf("Render() error = %v, want random_int failure", err)
+	}
+}
+
+func TestRenderValue(t *testing.T) {
+	value := map[string]interface{}{
+		"auth": "Bearer {{token}}",
+		"{{user.name}}": []interface{}{
+			"{{count}}",
+			map[string]interface{}{"tags": "{{user.tags}}"},
+		},
+		"headers": map[string]string{"X-Count": "{{count}}"},
+		"list":    []string{"{{user.name}}"},
+		"raw":     float64(7),
+	}
+	want := map[string]interface{}{
+		"auth": "Bearer abc",
+		"alice": []interface{}{
+			float64(3),
+			map[string]interface{}{"tags": []interface{}{"a", "b"}},
+		},
+		"headers": map[string]string{"X-Count": "3"},
+		"list":    []interface{}{"alice"},
+		"raw":     float64(7),
+	}
+
+	got, err := RenderValue(value, testScope())
+	if err != nil {
+		t.Fatalf("RenderValue() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RenderValue() = %#v, want %#v", got, want)
+	}
+}
+
+func TestRenderValueErrorPath(t *testing.T) {
+	value := map[string]interface{}{
+		"body": map[string]interface{}{
+			"items": []interface{}{"ok", "{{nope}}"},
+		},
+	}
+	_, err := RenderValue(value, testScope())
+	var undefined *UndefinedError
+	if !errors.As(err, &undefined) {
+		t.Fatalf("RenderValue() error = %v, want UndefinedError", err)
+	}
+	if !strings.HasPrefix(err.Error(), "body.items[1]: ") {
+		t.Errorf("RenderValue() error = %q, want prefix %q", err.Error(), "body.items[1]: ")
+	}
+}
+
+func TestRegisterFunc(t *testing.T) {
+	RegisterFunc("test_join", func(args ...interface{}) (interface{}, error) {
+		parts := make([]string, len(args))
+		for i, arg := range args {
+			s, err := Stringify(arg)
+			if err != nil {
+				return nil, err
+			}
+			parts[i] = s
+		}
+		return strings.Join(parts, "|"), nil
+	})
+
+	got, err := RenderString(`{{test_join(token, 'x,y', 2, true, null)}}`, testScope())
+	if err != nil {
+		t.Fatalf("RenderString() error = %v", err)
+	}
+	if want := "abc|x,y|2|true|"; got != want {
+		t.Errorf("RenderString() = %q, want %q", got, want)
+	}
+}
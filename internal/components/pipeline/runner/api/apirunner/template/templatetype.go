@@ -0,0 +1,199 @@
+package template
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 模板占位符的定界符
+const (
+	leftDelim  = "{{"
+	rightDelim = "}}"
+)
+
+// SceneNamespace 场景中前序步骤结果所在的命名空间，{{scene.步骤名.字段路径}} 引用步骤的执行结果
+const SceneNamespace = "scene"
+
+// Func 模板函数，参数为字面量或变量的值
+type Func func(args ...interface{}) (interface{}, error)
+
+// UndefinedError 模板引用了未定义的变量
+type UndefinedError struct {
+	// 变量路径
+	Name string
+	// 路径中第一个不存在的部分
+	Missing string
+}
+
+func (e *UndefinedError) Error() string {
+	if e.Missing == "" || e.Missing == e.Name {
+		return fmt.Sprintf("未定义的变量: %s", e.Name)
+	}
+	return fmt.Sprintf("未定义的变量: %s (%s 不存在)", e.Name, e.Missing)
+}
+
+// SyntaxError 模板语法错误
+type SyntaxError struct {
+	Template string
+	Message  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("模板语法错误: %s, 模板: %s", e.Message, e.Template)
+}
+
+var (
+	funcsMu sync.RWMutex
+	funcs   = map[string]Func{
+		"uuid":          uuidFunc,
+		"now":           nowFunc,
+		"timestamp":     timestampFunc,
+		"timestamp_ms":  timestampMsFunc,
+		"random_int":    randomIntFunc,
+		"random_string": randomStringFunc,
+		"env":           envFunc,
+	}
+)
+
+// RegisterFunc 注册模板函数，同名函数会被覆盖
+func RegisterFunc(name string, fn Func) {
+	funcsMu.Lock()
+	defer funcsMu.Unlock()
+	funcs[name] = fn
+}
+
+// lookupFunc 获取模板函数
+func lookupFunc(name string) (Func, bool) {
+	funcsMu.RLock()
+	defer funcsMu.RUnlock()
+	fn, ok := funcs[name]
+	return fn, ok
+}
+
+// uuidFunc uuid() 生成随机UUID
+func uuidFunc(args ...interface{}) (interface{}, error) {
+	if err := expectArgs("uuid", args, 0, 0); err != nil {
+		return nil, err
+	}
+	return uuid.New().String(), nil
+}
+
+// nowFunc now(layout) 按 Go 时间格式返回当前时间，不传 layout 时使用 RFC3339
+func nowFunc(args ...interface{}) (interface{}, error) {
+	if err := expectArgs("now", args, 0, 1); err != nil {
+		return nil, err
+	}
+	layout := time.RFC3339
+	if len(args) == 1 {
+		layout = fmt.Sprint(args[0])
+	}
+	return time.Now().Format(layout), nil
+}
+
+// timestampFunc timestamp() 返回当前的秒级时间戳
+func timestampFunc(args ...interface{}) (interface{}, error) {
+	if err := expectArgs("timestamp", args, 0, 0); err != nil {
+		return nil, err
+	}
+	return time.Now().Unix(), nil
+}
+
+// timestampMsFunc timestamp_ms() 返回当前的毫秒级时间戳
+func timestampMsFunc(args ...interface{}) (interface{}, error) {
+	if err := expectArgs("timestamp_ms", args, 0, 0); err != nil {
+		return nil, err
+	}
+	return time.Now().UnixMilli(), nil
+}
+
+// randomIntFunc random_int(min, max) 返回 [min, max] 内的随机整数
+func randomIntFunc(args ...interface{}) (interface{}, error) {
+	if err := expectArgs("random_int", args, 2, 2); err != nil {
+		return nil, err
+	}
+	min, err := toInt64(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("random_int 的参数 min 无效: %w", err)
+	}
+	max, err := toInt64(args[1])
+	if err != nil {
+		return nil, fmt.Errorf("random_int 的参数 max 无效: %w", err)
+	}
+	if max < min {
+		return nil, fmt.Errorf("random_int 的参数 max 不能小于 min")
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(max-min+1))
+	if err != nil {
+		return nil, err
+	}
+	return min + n.Int64(), nil
+}
+
+// randomStringFunc random_string(length) 返回指定长度的随机字母数字串
+func randomStringFunc(args ...interface{}) (interface{}, error) {
+	if err := expectArgs("random_string", args, 1, 1); err != nil {
+		return nil, err
+	}
+	length, err := toInt64(args[0])
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("random_string 的参数 length 无效: %v", args[0])
+	}
+
+	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	buf := make([]byte, length)
+	for i := range buf {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(letters))))
+		if err != nil {
+			return nil, err
+		}
+		buf[i] = letters[n.Int64()]
+	}
+	return string(buf), nil
+}
+
+// envFunc env(name) 读取环境变量，不存在时报错
+func envFunc(args ...interface{}) (interface{}, error) {
+	if err := expectArgs("env", args, 1, 1); err != nil {
+		return nil, err
+	}
+	name := fmt.Sprint(args[0])
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return nil, fmt.Errorf("环境变量 %s 不存在", name)
+	}
+	return value, nil
+}
+
+// expectArgs 校验函数参数个数
+func expectArgs(name string, args []interface{}, min, max int) error {
+	if len(args) < min || len(args) > max {
+		if min == max {
+			return fmt.Errorf("函数 %s 需要 %d 个参数, 实际为 %d 个", name, min, len(args))
+		}
+		return fmt.Errorf("函数 %s 需要 %d~%d 个参数, 实际为 %d 个", name, min, max, len(args))
+	}
+	return nil
+}
+
+// toInt64 将数值或数字字符串转换为 int64
+func toInt64(v interface{}) (int64, error) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), nil
+	case int64:
+		return n, nil
+	case float64:
+		return int64(n), nil
+	case string:
+		return strconv.ParseInt(n, 10, 64)
+	default:
+		return 0, fmt.Errorf("不是整数: %v", v)
+	}
+}
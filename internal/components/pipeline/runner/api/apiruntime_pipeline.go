@@ -4,8 +4,17 @@ package api
 // 任务级别
 
 import (
-	"Storage/internal/logic/workflows/core"
+	"Storage/internal/components/pipeline/core"
+	apirunner "Storage/internal/components/pipeline/runner/api/apirunner"
+	"Storage/internal/components/pipeline/runner/api/apirunner/auth"
+	"Storage/internal/components/pipeline/runner/api/apirunner/cassette"
+	"Storage/internal/components/pipeline/runner/api/apirunner/har"
+	"Storage/internal/components/pipeline/runner/api/scene"
 	"context"
+	"fmt"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
 )
 
 // Initialize 初始化pipeline
@@ -21,34 +30,239 @@ func (p *ApiRuntimePipeline) Validate(ctx context.Context) error {
 }
 
 // Execute 执行pipeline
+// 按顺序执行场景，配置了检查点时跳过已完成的场景，并恢复已解析的变量
+// Cancel 后进行中的场景随执行上下文中断，未开始的场景不再执行
 func (p *ApiRuntimePipeline) Execute(ctx context.Context, spec map[string]interface{}) (map[string]interface{}, error) {
-	// 空实现
+	startTime := time.Now()
+	p.Status = StatusRunning
+	p.StartTime = &startTime
+	if p.Stats == nil {
+		p.Stats = &RuntimeStats{}
+	}
+	p.Stats.TotalScenes = len(p.Scenes)
+	p.Stats.CompletedScenes = 0
+
+	p.mu.Lock()
+	if p.canceled {
+		p.mu.Unlock()
+		p.finish(StatusCancelled, nil)
+		return nil, context.Canceled
+	}
+	ctx, p.cancel = context.WithCancel(ctx)
+	cancel := p.cancel
+	p.mu.Unlock()
+	defer cancel()
+
+	p.emit(core.ExecutionEvent{Type: core.EventStatus, Status: core.TaskStatusRunning})
+
+	variables := make(map[string]interface{})
+	if p.Checkpointer != nil {
+		if _, err := p.Checkpointer.Resume(ctx); err != nil {
+			logx.Errorf("加载检查点失败, 将从头开始执行: %v", err)
+		}
+		variables = p.Checkpointer.Variables()
+		p.Checkpointer.Start(ctx)
+		defer func() {
+			if err := p.Checkpointer.Stop(context.Background(), p.Status == StatusCompleted); err != nil {
+				logx.Errorf("保存检查点失败: %v", err)
+			}
+		}()
+	}
+
 	result := make(map[string]interface{})
+	for _, scenePipeline := range p.Scenes {
+		sceneID := sceneKey(scenePipeline)
+		if p.Checkpointer != nil && p.Checkpointer.IsSceneFinished(sceneID) {
+			p.Stats.CompletedScenes++
+			continue
+		}
+
+		// 场景输入：任务spec + 前序场景解析出的变量
+		input := make(map[string]interface{}, len(spec)+len(variables))
+		for k, v := range spec {
+			input[k] = v
+		}
+		for k, v := range variables {
+			input[k] = v
+		}
+
+		sceneResult, err := scenePipeline.Execute(ctx, input)
+		p.emitScene(sceneID, scenePipeline, sceneResult, err)
+		if err != nil && p.isCanceled() {
+			p.finish(StatusCancelled, nil)
+			return result, err
+		}
+		if err != nil {
+			p.Stats.FailedScenes++
+			p.finish(StatusFailed, &core.PipelineError{
+				Message: fmt.Sprintf("场景 %s 执行失败: %v", sceneID, err),
+				Code:    "SCENE_ERROR",
+				Cause:   err,
+			})
+			return result, err
+		}
+
+		p.Stats.CompletedScenes++
+		result[sceneID] = sceneResult
+		for k, v := range sceneResult {
+			variables[k] = v
+			if p.Checkpointer != nil {
+				p.Checkpointer.SetVariable(k, v)
+			}
+		}
+		if p.Checkpointer != nil {
+			p.Checkpointer.MarkSceneFinished(sceneID)
+		}
+		progress, _ := p.GetProgress(ctx)
+		p.emit(core.ExecutionEvent{Type: core.EventProgress, Status: core.TaskStatusRunning, Progress: progress})
+	}
+
+	p.finish(StatusCompleted, nil)
 	return result, nil
 }
 
-// Cancel 取消pipeline执行
+// finish 记录结束状态和耗时
+func (p *ApiRuntimePipeline) finish(status PipelineStatus, pipelineErr *core.PipelineError) {
+	finishTime := time.Now()
+	p.Status = status
+	p.FinishTime = &finishTime
+	p.Error = pipelineErr
+	if p.StartTime != nil {
+		p.Stats.TotalDuration = finishTime.Sub(*p.StartTime).Milliseconds()
+	}
+
+	progress, _ := p.GetProgress(context.Background())
+	event := core.ExecutionEvent{Type: core.EventStatus, Status: p.GetStatus(context.Background()), Progress: progress}
+	if pipelineErr != nil {
+		event.Error = pipelineErr.Message
+	}
+	p.emit(event)
+}
+
+// BindEvents 绑定所属执行，各场景一并绑定
+func (p *ApiRuntimePipeline) BindEvents(executionID string, publisher core.EventPublisher) {
+	p.executionID = executionID
+	p.events = publisher
+	for _, scenePipeline := range p.Scenes {
+		scenePipeline.BindEvents(executionID, publisher)
+	}
+}
+
+// SetEnvironment 设置各场景的执行环境，场景中的步骤按环境的变量、基础URL、认证和TLS配置发送请求
+func (p *ApiRuntimePipeline) SetEnvironment(env *apirunner.Environment) error {
+	for _, scenePipeline := range p.Scenes {
+		if err := scenePipeline.SetEnvironment(env); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetHarRecorder 设置各场景的 HAR 记录器，场景中每个步骤的请求和响应都会记录
+func (p *ApiRuntimePipeline) SetHarRecorder(recorder har.Recorder) {
+	for _, scenePipeline := range p.Scenes {
+		scenePipeline.SetHarRecorder(recorder)
+	}
+}
+
+// SetCassetteStore 设置各场景磁带的 mongo 存储，场景配置了磁带时按配置录制或回放请求
+func (p *ApiRuntimePipeline) SetCassetteStore(store cassette.Store) {
+	for _, scenePipeline := range p.Scenes {
+		scenePipeline.SetCassetteStore(store)
+	}
+}
+
+// SetTokenStore 设置各场景 OAuth2 令牌的缓存存储，环境、会话和步骤的 OAuth2 认证共享已申请的令牌
+func (p *ApiRuntimePipeline) SetTokenStore(store auth.TokenStore) {
+	for _, scenePipeline := range p.Scenes {
+		scenePipeline.SetTokenStore(store)
+	}
+}
+
+// emit 发布执行事件，未绑定时忽略
+func (p *ApiRuntimePipeline) emit(event core.ExecutionEvent) {
+	if p.events == nil {
+		return
+	}
+	event.ExecutionID = p.executionID
+	event.Source = p.PipelineName
+	event.Timestamp = time.Now()
+	p.events.Publish(event)
+}
+
+// emitScene 发布场景执行结果事件
+func (p *ApiRuntimePipeline) emitScene(sceneID string, scenePipeline *scene.ScenePipeline, result map[string]interface{}, err error) {
+	event := core.ExecutionEvent{
+		Type:   core.EventScene,
+		Name:   sceneID,
+		Status: scenePipeline.GetStatus(context.Background()),
+		Result: result,
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	p.emit(event)
+}
+
+// sceneKey 获取场景在检查点中的标识
+func sceneKey(scenePipeline *scene.ScenePipeline) string {
+	if scenePipeline.SceneDefinition != nil && scenePipeline.SceneDefinition.SceneID != "" {
+		return scenePipeline.SceneDefinition.SceneID
+	}
+	return scenePipeline.Name
+}
+
+// Cancel 取消pipeline执行，进行中的场景随执行上下文中断
 func (p *ApiRuntimePipeline) Cancel(ctx context.Context) error {
-	// 空实现
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.canceled = true
+	if p.cancel != nil {
+		p.cancel()
+	}
 	return nil
 }
 
+// isCanceled 是否已调用 Cancel
+func (p *ApiRuntimePipeline) isCanceled() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.canceled
+}
+
 // GetStatus 获取pipeline状态
 func (p *ApiRuntimePipeline) GetStatus(ctx context.Context) core.TaskStatus {
-	// 空实现
-	return core.TaskStatusPending
+	switch p.Status {
+	case StatusRunning:
+		return core.TaskStatusRunning
+	case StatusCompleted:
+		return core.TaskStatusCompleted
+	case StatusFailed:
+		return core.TaskStatusFailed
+	case StatusCancelled:
+		return core.TaskStatusCanceled
+	default:
+		return core.TaskStatusPending
+	}
 }
 
-// GetProgress 获取执行进度
+// GetProgress 获取执行进度，为已完成场景的占比
 func (p *ApiRuntimePipeline) GetProgress(ctx context.Context) (float64, error) {
-	// 空实现
-	return 0.0, nil
+	if p.Stats == nil || p.Stats.TotalScenes == 0 {
+		return 0.0, nil
+	}
+	return float64(p.Stats.CompletedScenes) / float64(p.Stats.TotalScenes), nil
 }
 
-// GetMetrics 获取执行指标
+// GetMetrics 获取执行指标，为场景的执行统计
 func (p *ApiRuntimePipeline) GetMetrics(ctx context.Context) map[string]interface{} {
-	// 空实现
 	metrics := make(map[string]interface{})
+	if p.Stats != nil {
+		metrics["total_scenes"] = p.Stats.TotalScenes
+		metrics["completed_scenes"] = p.Stats.CompletedScenes
+		metrics["failed_scenes"] = p.Stats.FailedScenes
+		metrics["duration_ms"] = p.Stats.TotalDuration
+	}
 	return metrics
 }
 
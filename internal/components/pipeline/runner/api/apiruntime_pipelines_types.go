@@ -2,10 +2,12 @@ package api
 
 // 定义ApiRuntime的类型和接口
 import (
-	"Storage/internal/logic/workflows/api/scene"
-	"Storage/internal/logic/workflows/core"
-	"Storage/internal/logic/workflows/notification"
+	"Storage/internal/components/pipeline/core"
+	"Storage/internal/components/pipeline/core/notification"
+	"Storage/internal/components/pipeline/runner/api/scene"
 	"Storage/internal/model/task"
+	"context"
+	"sync"
 	"time"
 )
 
@@ -42,6 +44,18 @@ type ApiRuntimePipeline struct {
 
 	// 执行统计
 	Stats *RuntimeStats `json:"stats,omitempty"`
+
+	// 检查点，记录已完成场景和已解析变量
+	Checkpointer *core.Checkpointer `json:"-"`
+
+	// 所属执行ID及事件发布者
+	executionID string
+	events      core.EventPublisher
+
+	// 本次执行的取消函数，Cancel 先于 Execute 时 canceled 使执行直接结束
+	mu       sync.Mutex
+	cancel   context.CancelFunc
+	canceled bool
 }
 
 // RuntimeStats 记录执行统计信息
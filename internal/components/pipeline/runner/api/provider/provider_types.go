@@ -1,7 +1,7 @@
 package provider
 
 import (
-	logic "Storage/internal/logic/datahub/interface"
+	logic "Storage/internal/logic/interfaceservice"
 	"Storage/internal/svc"
 	"context"
 )
@@ -1,16 +1,33 @@
 package scene
 
 import (
-	api "Storage/internal/logic/workflows/api/apirunner"
-	"Storage/internal/logic/workflows/core"
+	"Storage/internal/components/pipeline/core"
+	api "Storage/internal/components/pipeline/runner/api/apirunner"
+	"Storage/internal/components/pipeline/runner/api/apirunner/auth"
+	"Storage/internal/components/pipeline/runner/api/apirunner/cassette"
+	"Storage/internal/components/pipeline/runner/api/apirunner/har"
+	"Storage/internal/components/pipeline/runner/api/apirunner/template"
+	"Storage/internal/components/retry"
 	"context"
+	"fmt"
 )
 
 // 实现ScenePipeline
 // 子任务级别
 
+// NewScenePipeline 创建按顺序执行 apiPipelines 的场景
 func NewScenePipeline(name string, description string, apiPipelines []*api.ApiPipeline) *ScenePipeline {
-	return &ScenePipeline{}
+	s := &ScenePipeline{
+		SceneDefinition: &SceneDefinition{
+			ApiPipelines: apiPipelines,
+			SharedMemory: &SharedMemory{},
+		},
+		Stats: &SceneRunStats{Status: StatusPending},
+	}
+	s.Name = name
+	s.Description = description
+	s.Status = core.TaskStatusPending
+	return s
 }
 
 func (s *ScenePipeline) Initialize(ctx context.Context) error {
@@ -20,28 +37,245 @@ func (s *ScenePipeline) Initialize(ctx context.Context) error {
 	return nil
 }
 
+// SetEnvironment 设置场景的执行环境，执行时注入每个步骤
+func (s *ScenePipeline) SetEnvironment(env *api.Environment) error {
+	s.Environment = env
+	return nil
+}
+
+// SetHarRecorder 设置场景的 HAR 记录器，执行时注入每个步骤
+func (s *ScenePipeline) SetHarRecorder(recorder har.Recorder) {
+	s.HarRecorder = recorder
+}
+
+// SetCassetteStore 设置磁带的 mongo 存储
+func (s *ScenePipeline) SetCassetteStore(store cassette.Store) {
+	s.CassetteStore = store
+}
+
+// SetTokenStore 设置 OAuth2 令牌的缓存存储，执行时注入会话和每个步骤
+func (s *ScenePipeline) SetTokenStore(store auth.TokenStore) {
+	s.TokenStore = store
+}
+
+// newCassette 按场景的磁带配置创建磁带，未配置或模式为空时返回 nil
+func (s *ScenePipeline) newCassette(ctx context.Context) (*cassette.Cassette, error) {
+	if s.SceneDefinition == nil || s.SceneDefinition.Cassette == nil || s.SceneDefinition.Cassette.Mode == cassette.ModeOff {
+		return nil, nil
+	}
+	config := *s.SceneDefinition.Cassette
+	if config.Name == "" {
+		config.Name = s.SceneDefinition.SceneID
+	}
+
+	var store cassette.Store
+	switch config.Store {
+	case "", cassette.StoreMongo:
+		store = s.CassetteStore
+	case cassette.StoreFile:
+		if config.Dir == "" {
+			return nil, fmt.Errorf("磁带 %s 的文件存储目录不能为空", config.Name)
+		}
+		store = cassette.NewFileStore(config.Dir)
+	default:
+		return nil, fmt.Errorf("不支持的磁带存储: %s", config.Store)
+	}
+
+	tape, err := cassette.New(&config, store)
+	if err != nil {
+		return nil, err
+	}
+	if err := tape.Load(ctx); err != nil {
+		return nil, err
+	}
+	return tape, nil
+}
+
+// Execute 按顺序执行场景步骤，前序步骤提取的数据和执行结果作为后续步骤的输入
+// 场景被取消或超时时，进行中的步骤随执行上下文中断，未开始的步骤不再执行
 func (s *ScenePipeline) Execute(ctx context.Context, spec map[string]interface{}) (map[string]interface{}, error) {
-	return nil, nil
+	// 场景的超时预算嵌套在调用方（任务）的预算内
+	if s.SceneDefinition != nil && s.SceneDefinition.Strategy != nil {
+		s.SetTimeout(core.TimeoutScene, s.SceneDefinition.Strategy.Timeout.Budget())
+	}
+	if err := s.Begin(ctx, spec); err != nil {
+		return nil, err
+	}
+	execCtx := s.ExecutionContext()
+
+	var steps []*api.ApiPipeline
+	var sessionConfig *api.SessionConfig
+	if s.SceneDefinition != nil {
+		steps = s.SceneDefinition.ApiPipelines
+		sessionConfig = s.SceneDefinition.Session
+	}
+	if s.Environment != nil {
+		sessionConfig = s.Environment.SessionConfig(sessionConfig)
+	}
+
+	// 场景内的步骤共用一个会话，cookie 在步骤间传递，场景结束时释放
+	session, err := api.NewSession(sessionConfig, s.TokenStore)
+	if err != nil {
+		s.Finish(ctx, nil, err)
+		return nil, err
+	}
+	for _, step := range steps {
+		step.SetSession(session)
+	}
+	defer func() {
+		for _, step := range steps {
+			step.SetSession(nil)
+		}
+		session.Close()
+	}()
+
+	// 步骤按场景的执行环境解析变量和基础URL，请求和响应记录到场景的 HAR 记录器
+	for _, step := range steps {
+		step.SetTokenStore(s.TokenStore)
+		if err := step.SetEnvironment(s.Environment); err != nil {
+			s.Finish(ctx, nil, err)
+			return nil, err
+		}
+		step.SetHarRecorder(s.HarRecorder)
+	}
+
+	// 步骤共用场景的磁带，录制时按执行顺序写入，回放时不访问网络
+	tape, err := s.newCassette(execCtx)
+	if err != nil {
+		err = fmt.Errorf("初始化磁带失败: %w", err)
+		s.Finish(ctx, nil, err)
+		return nil, err
+	}
+	for _, step := range steps {
+		step.SetCassette(tape)
+	}
+
+	variables := make(map[string]interface{})
+	result := make(map[string]interface{})
+	for i, step := range steps {
+		if err := execCtx.Err(); err != nil {
+			err = core.WrapTimeout(execCtx, err)
+			if s.GetStatus(ctx) != core.TaskStatusCanceled {
+				s.Finish(ctx, result, err)
+			}
+			return result, err
+		}
+
+		input := make(map[string]interface{}, len(spec)+len(variables)+len(step.StepSpec))
+		for k, v := range spec {
+			input[k] = v
+		}
+		for k, v := range variables {
+			input[k] = v
+		}
+		for k, v := range step.StepSpec {
+			input[k] = v
+		}
+		// 前序步骤的执行结果，步骤中以 {{scene.步骤名.字段路径}} 引用
+		input[template.SceneNamespace] = result
+
+		stepResult, err := s.executeStep(execCtx, step, input)
+		s.emitStep(step, stepResult, err)
+		if err != nil {
+			err = fmt.Errorf("步骤 %s 执行失败: %w", step.Name, core.WrapTimeout(execCtx, err))
+			if s.GetStatus(ctx) != core.TaskStatusCanceled {
+				s.Finish(ctx, result, err)
+			}
+			return result, err
+		}
+
+		result[step.Name] = stepResult
+		if extracted, ok := stepResult["extracted_data"].(map[string]interface{}); ok {
+			for k, v := range extracted {
+				variables[k] = v
+				if s.SceneDefinition.SharedMemory != nil {
+					s.SceneDefinition.SharedMemory.Set(k, v)
+				}
+			}
+		}
+		s.SetProgress(float64(i+1) / float64(len(steps)))
+	}
+
+	if err := s.Finish(ctx, result, nil); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// executeStep 执行步骤，失败时按场景的重试配置重置步骤后重试，步骤被取消时不再重试
+func (s *ScenePipeline) executeStep(ctx context.Context, step *api.ApiPipeline, input map[string]interface{}) (map[string]interface{}, error) {
+	policy := retry.None()
+	if s.SceneDefinition.Strategy != nil {
+		policy = s.SceneDefinition.Strategy.Retry.Policy()
+	}
+
+	return retry.DoValue(ctx, policy, func(ctx context.Context, attempt int) (map[string]interface{}, error) {
+		if attempt > 1 {
+			if err := step.BasePipeline.Initialize(ctx); err != nil {
+				return nil, retry.Permanent(err)
+			}
+		}
+		result, err := step.Execute(ctx, input)
+		if err != nil && step.GetStatus(ctx) == core.TaskStatusCanceled {
+			return result, retry.Permanent(err)
+		}
+		return result, err
+	})
+}
+
+// emitStep 发布步骤执行结果事件
+func (s *ScenePipeline) emitStep(step *api.ApiPipeline, result map[string]interface{}, err error) {
+	event := core.ExecutionEvent{
+		Type:   core.EventStep,
+		Name:   step.Name,
+		Status: step.GetStatus(context.Background()),
+		Result: result,
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	s.EmitEvent(event)
+}
+
+// BindEvents 绑定所属执行，场景下的步骤一并绑定
+func (s *ScenePipeline) BindEvents(executionID string, publisher core.EventPublisher) {
+	s.BasePipeline.BindEvents(executionID, publisher)
+	if s.SceneDefinition == nil {
+		return
+	}
+	for _, step := range s.SceneDefinition.ApiPipelines {
+		if step.BasePipeline != nil {
+			step.BindEvents(executionID, publisher)
+		}
+	}
 }
 
-// Validate 验证pipeline配置
+// Validate 验证pipeline配置，逐个校验步骤spec
 func (s *ScenePipeline) Validate(ctx context.Context) error {
+	if s.SceneDefinition == nil {
+		return nil
+	}
+	for _, step := range s.SceneDefinition.ApiPipelines {
+		if err := step.Validate(ctx); err != nil {
+			return fmt.Errorf("步骤 %s 配置无效: %w", step.Name, err)
+		}
+	}
 	return nil
 }
 
 // Cancel 取消pipeline执行
 func (s *ScenePipeline) Cancel(ctx context.Context) error {
-	return nil
+	return s.BasePipeline.Cancel(ctx)
 }
 
 // GetStatus 获取pipeline状态
 func (s *ScenePipeline) GetStatus(ctx context.Context) core.TaskStatus {
-	return core.TaskStatusPending
+	return s.BasePipeline.GetStatus(ctx)
 }
 
 // GetProgress 获取执行进度
 func (s *ScenePipeline) GetProgress(ctx context.Context) (float64, error) {
-	return 0, nil
+	return s.BasePipeline.GetProgress(ctx)
 }
 
 // GetMetrics 获取执行指标
@@ -49,9 +283,25 @@ func (s *ScenePipeline) GetMetrics(ctx context.Context) map[string]interface{} {
 	return nil
 }
 
-// Cleanup 清理资源
+// Cleanup 清理所有步骤的资源，场景超时或取消后仍需执行
 func (s *ScenePipeline) Cleanup(ctx context.Context) error {
-	return nil
+	ctx = context.WithoutCancel(ctx)
+	var firstErr error
+	if s.SceneDefinition != nil {
+		for _, step := range s.SceneDefinition.ApiPipelines {
+			if step.BasePipeline == nil {
+				continue
+			}
+			if err := step.Cleanup(ctx); err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("清理步骤 %s 失败: %w", step.Name, err)
+			}
+		}
+	}
+
+	if err := s.BasePipeline.Cleanup(ctx); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
 }
 
 func (s *ScenePipeline) StartAllApiPipelines(ctx context.Context) error {
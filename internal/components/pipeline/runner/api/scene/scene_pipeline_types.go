@@ -1,8 +1,11 @@
 package scene
 
 import (
-	api "Storage/internal/logic/workflows/api/apirunner"
-	"Storage/internal/logic/workflows/core"
+	"Storage/internal/components/pipeline/core"
+	api "Storage/internal/components/pipeline/runner/api/apirunner"
+	"Storage/internal/components/pipeline/runner/api/apirunner/auth"
+	"Storage/internal/components/pipeline/runner/api/apirunner/cassette"
+	"Storage/internal/components/pipeline/runner/api/apirunner/har"
 	"Storage/internal/model/task"
 	"context"
 	"sync"
@@ -35,6 +38,18 @@ type ScenePipeline struct {
 
 	// 上下文数据
 	ContextData map[string]interface{} `json:"context_data,omitempty"`
+
+	// 执行环境，为空时步骤按自身配置的基础URL发送请求
+	Environment *api.Environment `json:"environment,omitempty"`
+
+	// HAR 记录器，执行时注入每个步骤
+	HarRecorder har.Recorder `json:"-"`
+
+	// 磁带的 mongo 存储，磁带配置为文件存储时不使用
+	CassetteStore cassette.Store `json:"-"`
+
+	// OAuth2 令牌的缓存存储，执行时注入会话和每个步骤
+	TokenStore auth.TokenStore `json:"-"`
 }
 
 type ScenePipelineRunner interface {
@@ -55,6 +70,10 @@ type SceneDefinition struct {
 	ApiPipelines []*api.ApiPipeline `json:"scenes"`
 	Strategy     *SceneStrategy     `json:"strategy"`
 	SharedMemory *SharedMemory      `json:"shared_memory"`
+	// 场景会话配置，为空时使用默认配置
+	Session *api.SessionConfig `json:"session,omitempty"`
+	// 磁带配置，录制场景的请求和响应，或不访问网络回放录制的响应
+	Cassette *cassette.Config `json:"cassette,omitempty"`
 }
 
 // RuntimeStats 记录执行统计信息
@@ -11,6 +11,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/zeromicro/go-zero/core/logx"
@@ -34,6 +35,13 @@ type APITreeNode struct {
 	Path     string         `json:"path,omitempty"`
 }
 
+// Parameter represents a request parameter in the API
+type Parameter struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+}
+
 // APIDetail represents detailed information about an API endpoint
 type APIDetail struct {
 	ID          string                 `json:"id"`
@@ -82,18 +90,32 @@ type ApiFoxSyncPipeline struct {
 	ApiDetailChan chan *APIDetail
 	mongo         []*tools.MongoClient
 	// Hooks           []func(recordId string, taskId string, spec map[string]interface{}, result map[string]interface{}) error
-	taskrecordModel taskrecord.TaskRecordModel
+	taskrecordModel *taskrecord.TaskRecordModel
+	// 所属任务ID，记录执行结果时使用
+	taskID string
+	// 检查点，为空时不记录进度
+	checkpointer *core.Checkpointer
+	// 任务锁的防护令牌，为空时不校验
+	fence core.Fence
+	// 后台同步结束的信号
+	done chan struct{}
 }
 
+// DefaultSyncTimeout 任务未配置超时时，单次同步的默认超时预算
+const DefaultSyncTimeout = 5 * time.Minute
+
 type ApiClient struct {
 	Client  *http.Client
 	Cookies []*http.Cookie
 }
 
 // NewApiFoxSyncPipeline creates a new instance of ApiFoxSyncPipeline
-func NewApiFoxSyncPipeline(config ApiFoxSyncConfig, taskrecordModel taskrecord.TaskRecordModel) *ApiFoxSyncPipeline {
+func NewApiFoxSyncPipeline(config ApiFoxSyncConfig, taskrecordModel *taskrecord.TaskRecordModel) *ApiFoxSyncPipeline {
 	// hooks := make([]func(recordId string, taskId string, spec map[string]interface{}, result map[string]interface{}) error, 0)
+	basePipeline := core.NewBasePipeline("apifox_sync", "ApiFox 接口文档同步")
+	basePipeline.SetTimeout(core.TimeoutTask, DefaultSyncTimeout)
 	return &ApiFoxSyncPipeline{
+		BasePipeline:    basePipeline,
 		Config:          config,
 		Client:          &ApiClient{Client: &http.Client{}},
 		BaseURL:         "https://apifox.com/api/v1",
@@ -107,6 +129,22 @@ func NewApiFoxSyncPipeline(config ApiFoxSyncConfig, taskrecordModel taskrecord.T
 	}
 }
 
+// SetCheckpointer 设置检查点，执行时从上次进度恢复并定期持久化已同步的API
+func (p *ApiFoxSyncPipeline) SetCheckpointer(checkpointer *core.Checkpointer) {
+	p.checkpointer = checkpointer
+}
+
+// SetTaskRecord 设置执行记录，同步的开始、错误和结果写入所属执行的记录，为空时不记录
+func (p *ApiFoxSyncPipeline) SetTaskRecord(taskrecordModel *taskrecord.TaskRecordModel, taskID string) {
+	p.taskrecordModel = taskrecordModel
+	p.taskID = taskID
+}
+
+// SetFence 设置防护令牌，写入前校验锁仍由本次执行持有，并在文档中记录令牌
+func (p *ApiFoxSyncPipeline) SetFence(fence core.Fence) {
+	p.fence = fence
+}
+
 // Execute implements the Pipeline interface for ApiFox synchronization
 // GetExtractedApiIds returns the list of extracted API IDs
 func (p *ApiFoxSyncPipeline) GetExtractedApiIds() []string {
@@ -114,6 +152,13 @@ func (p *ApiFoxSyncPipeline) GetExtractedApiIds() []string {
 }
 
 func (p *ApiFoxSyncPipeline) Execute(ctx context.Context) error {
+	// 进入运行状态，触发 OnStart 钩子
+	// 同步在后台运行，不随调用方（如RPC请求）的上下文结束而取消，只响应 Cancel
+	if err := p.BasePipeline.Begin(context.WithoutCancel(ctx), nil); err != nil {
+		return err
+	}
+	ctx = p.ExecutionContext()
+
 	// 初始化 MongoDB 客户端
 	p.mongo = make([]*tools.MongoClient, 0, len(p.Config.Mongo))
 
@@ -139,20 +184,25 @@ func (p *ApiFoxSyncPipeline) Execute(ctx context.Context) error {
 
 	// 检查是否至少有一个可用的 MongoDB 连接
 	if len(p.mongo) == 0 {
-		return fmt.Errorf("所有 MongoDB 连接都失败")
+		err := fmt.Errorf("所有 MongoDB 连接都失败")
+		p.BasePipeline.Finish(ctx, nil, err)
+		return err
 	}
 
 	// Authenticate with shared document
-	if err := p.authenticate(); err != nil {
-		return fmt.Errorf("authentication failed: %w", err)
+	if err := p.authenticate(ctx); err != nil {
+		err = fmt.Errorf("authentication failed: %w", core.WrapTimeout(ctx, err))
+		p.BasePipeline.Finish(ctx, nil, err)
+		return err
 	}
 
 	// Start the pipeline
+	p.done = make(chan struct{})
 	go p.runPipeline()
 	return nil
 }
 
-func (p *ApiFoxSyncPipeline) authenticate() error {
+func (p *ApiFoxSyncPipeline) authenticate(ctx context.Context) error {
 	authURL := "https://apifox.com/api/v1/shared-doc-auth"
 
 	// Create form data
@@ -161,7 +211,7 @@ func (p *ApiFoxSyncPipeline) authenticate() error {
 	formData.Set("password", "psu123456")
 
 	// Create request
-	req, err := http.NewRequest(http.MethodPost, authURL, strings.NewReader(formData.Encode()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, authURL, strings.NewReader(formData.Encode()))
 	if err != nil {
 		return fmt.Errorf("failed to create auth request: %w", err)
 	}
@@ -194,75 +244,112 @@ func (p *ApiFoxSyncPipeline) authenticate() error {
 	return nil
 }
 
+// Done 返回后台同步结束的信号，Execute 成功返回后才有意义
+func (p *ApiFoxSyncPipeline) Done() <-chan struct{} {
+	return p.done
+}
+
 func (p *ApiFoxSyncPipeline) runPipeline() {
-	p.OnStart("recordId", "taskID", nil)
+	defer close(p.done)
+	p.OnStart(p.ExecutionID(), p.taskID, nil)
 	p.ErrorChan = make(chan *ApiError)
 	p.ApiIdChan = make(chan string)
 	p.ApiDetailChan = make(chan *APIDetail)
 
-	defer func() {
-		close(p.ApiIdChan)
-		close(p.ApiDetailChan)
-	}()
+	// 本次执行的上下文带有任务的超时预算，超时或 Cancel 时所有协程随之退出
+	timeoutCtx := p.ExecutionContext()
 
-	// Create a context with timeout
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-	defer cancel()
+	// 从检查点恢复进度，结束时写入最后一次进度
+	if p.checkpointer != nil {
+		if resumed, err := p.checkpointer.Resume(timeoutCtx); err != nil {
+			logx.Errorf("加载检查点失败, 将从头开始同步: %v", err)
+		} else if resumed {
+			logx.Infof("ApiFox 同步从检查点 %s 恢复", p.checkpointer.ExecutionID())
+		}
+		p.checkpointer.Start(timeoutCtx)
+		defer func() {
+			done := p.GetStatus(context.Background()) == core.TaskStatusCompleted
+			if err := p.checkpointer.Stop(context.Background(), done); err != nil {
+				logx.Errorf("保存检查点失败: %v", err)
+			}
+		}()
+	}
 
 	// Create errgroup with timeout context
 	g, gctx := errgroup.WithContext(timeoutCtx)
 
+	// getApifoxTree 和 transformApiDetail 结束时关闭各自输出的 channel，下游据此退出
 	g.Go(func() error {
+		defer close(p.ApiIdChan)
 		p.getApifoxTree(gctx)
 		return nil
 	})
 
 	g.Go(func() error {
+		defer close(p.ApiDetailChan)
 		p.transformApiDetail(gctx)
 		return nil
 	})
 
 	g.Go(func() error {
 		p.storeAPI(gctx)
-		p.ErrorChan <- nil
+		p.sendError(gctx, nil)
 		return nil
 	})
 
 	g.Go(func() error {
-		for apiId := range p.ErrorChan {
-			if apiId == nil {
+		for {
+			select {
+			case <-gctx.Done():
 				return nil
+			case apiErr := <-p.ErrorChan:
+				if apiErr == nil {
+					return nil
+				}
+				logx.Errorf("ApiFox 同步出错, API: %s, 错误: %v", apiErr.ApiID, apiErr.Error)
+				p.OnError(p.ExecutionID(), p.taskID, nil, map[string]interface{}{
+					"api_id": apiErr.ApiID,
+					"error":  apiErr.Error,
+				})
 			}
-			p.OnError("recordId", "taskID", nil, nil)
 		}
-		return nil
 	})
 
-	select {
-	// case <-p.ErrorChan:
-	// 	logx.Errorf("Pipeline encountered an error")
-	// 	p.OnError("recordId", "taskID", nil, nil)
-	// 	return
-	case <-gctx.Done():
-		if gctx.Err() == context.DeadlineExceeded {
-			logx.Error("Pipeline timed out after 5 minutes")
-			p.OnError("recordId", "taskID", nil, nil)
-		} else {
-			logx.Error(gctx.Err())
-			logx.Error("Pipeline was cancelled")
-			p.OnError("recordId", "taskID", nil, nil)
-		}
-		return
+	err := g.Wait()
+	switch {
+	case err != nil:
+		logx.Errorf("Pipeline failed with error: %v", err)
+		p.OnError(p.ExecutionID(), p.taskID, nil, map[string]interface{}{"error": err})
+		p.BasePipeline.Finish(context.Background(), nil, err)
+	case timeoutCtx.Err() == context.DeadlineExceeded:
+		err := core.WrapTimeout(timeoutCtx, timeoutCtx.Err())
+		logx.Errorf("Pipeline timed out: %v", err)
+		p.OnError(p.ExecutionID(), p.taskID, nil, map[string]interface{}{"error": err})
+		p.BasePipeline.Finish(context.Background(), nil, err)
+	case timeoutCtx.Err() == context.Canceled:
+		logx.Error("Pipeline was cancelled")
+		p.BasePipeline.Cancel(context.Background())
 	default:
-		if err := g.Wait(); err != nil {
-			logx.Errorf("Pipeline failed with error: %v", err)
-			close(p.ErrorChan)
-		} else {
-			p.OnFinish("recordId", "taskID", nil, nil)
-			logx.Info("Pipeline completed successfully")
-			close(p.ErrorChan)
-		}
-		return
+		logx.Info("Pipeline completed successfully")
+		p.BasePipeline.Finish(context.Background(), nil, nil)
+	}
+
+	// 记录本数据源的最终状态和统计
+	result := map[string]interface{}{
+		"status":  string(p.GetStatus(context.Background())),
+		"metrics": p.GetMetrics(context.Background()),
+	}
+	if p.Error != nil {
+		result["error"] = p.Error.Error()
+	}
+	p.OnFinish(p.ExecutionID(), p.taskID, nil, result)
+}
+
+// sendError 发送错误，执行被取消时直接丢弃，避免协程阻塞
+func (p *ApiFoxSyncPipeline) sendError(ctx context.Context, apiErr *ApiError) {
+	select {
+	case p.ErrorChan <- apiErr:
+	case <-ctx.Done():
 	}
 }
 
@@ -289,7 +376,7 @@ func (p *ApiFoxSyncPipeline) getApifoxTree(ctx context.Context) {
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
 	if err != nil {
-		p.ErrorChan <- &ApiError{DocID: p.Config.SharedDocID, Error: fmt.Errorf("failed to create API tree request: %w", err)}
+		p.sendError(ctx, &ApiError{DocID: p.Config.SharedDocID, Error: fmt.Errorf("failed to create API tree request: %w", err)})
 		return
 	}
 
@@ -305,7 +392,7 @@ func (p *ApiFoxSyncPipeline) getApifoxTree(ctx context.Context) {
 	logx.Infof("Fetching API tree for shared doc ID: %s", p.Config.SharedDocID)
 	resp, err := p.Client.Client.Do(req)
 	if err != nil {
-		p.ErrorChan <- &ApiError{DocID: p.Config.SharedDocID, Error: fmt.Errorf("API tree request failed: %w", err)}
+		p.sendError(ctx, &ApiError{DocID: p.Config.SharedDocID, Error: fmt.Errorf("API tree request failed: %w", err)})
 		return
 	}
 	defer resp.Body.Close()
@@ -321,20 +408,20 @@ func (p *ApiFoxSyncPipeline) getApifoxTree(ctx context.Context) {
 	// Read response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		p.ErrorChan <- &ApiError{DocID: p.Config.SharedDocID, Error: fmt.Errorf("failed to read API tree response: %w", err)}
+		p.sendError(ctx, &ApiError{DocID: p.Config.SharedDocID, Error: fmt.Errorf("failed to read API tree response: %w", err)})
 		return
 	}
 
 	// Check response status
 	if resp.StatusCode != http.StatusOK {
-		p.ErrorChan <- &ApiError{DocID: p.Config.SharedDocID, Error: fmt.Errorf("failed to get API tree with status %d: %s", resp.StatusCode, string(body))}
+		p.sendError(ctx, &ApiError{DocID: p.Config.SharedDocID, Error: fmt.Errorf("failed to get API tree with status %d: %s", resp.StatusCode, string(body))})
 		return
 	}
 
 	// Parse response
 	var apiTree map[string]interface{}
 	if err := json.Unmarshal(body, &apiTree); err != nil {
-		p.ErrorChan <- &ApiError{DocID: p.Config.SharedDocID, Error: fmt.Errorf("failed to parse API tree: %w", err)}
+		p.sendError(ctx, &ApiError{DocID: p.Config.SharedDocID, Error: fmt.Errorf("failed to parse API tree: %w", err)})
 		return
 	}
 	logx.Info("Successfully retrieved API tree")
@@ -343,7 +430,7 @@ func (p *ApiFoxSyncPipeline) getApifoxTree(ctx context.Context) {
 	jsonStr, err := json.Marshal(apiTree)
 	if err != nil {
 		logx.Errorf("failed to marshal node: %v", err)
-		p.ErrorChan <- &ApiError{DocID: p.Config.SharedDocID, Error: fmt.Errorf("failed to marshal API tree: %w", err)}
+		p.sendError(ctx, &ApiError{DocID: p.Config.SharedDocID, Error: fmt.Errorf("failed to marshal API tree: %w", err)})
 		return
 	}
 
@@ -354,7 +441,7 @@ func (p *ApiFoxSyncPipeline) getApifoxTree(ctx context.Context) {
 	// Check if any API IDs were found
 	if len(matches) == 0 {
 		logx.Error("No API IDs found in API tree")
-		p.ErrorChan <- &ApiError{DocID: p.Config.SharedDocID, Error: fmt.Errorf("no API IDs found in API tree")}
+		p.sendError(ctx, &ApiError{DocID: p.Config.SharedDocID, Error: fmt.Errorf("no API IDs found in API tree")})
 		return
 	}
 
@@ -368,7 +455,11 @@ func (p *ApiFoxSyncPipeline) getApifoxTree(ctx context.Context) {
 		default:
 			if len(match) > 1 {
 				logx.Error(match[1])
-				p.ApiIdChan <- match[1]
+				select {
+				case p.ApiIdChan <- match[1]:
+				case <-ctx.Done():
+					return
+				}
 			}
 		}
 	}
@@ -379,7 +470,7 @@ func (p *ApiFoxSyncPipeline) transformApiDetail(ctx context.Context) {
 	defer func() {
 		if r := recover(); r != nil {
 			logx.Errorf("transformApiDetail panic: %v", r)
-			p.ErrorChan <- &ApiError{Error: fmt.Errorf("transformApiDetail panic: %v", r)}
+			p.sendError(ctx, &ApiError{Error: fmt.Errorf("transformApiDetail panic: %v", r)})
 		}
 		_ = cancel
 		// cancel()
@@ -408,14 +499,26 @@ func (p *ApiFoxSyncPipeline) transformApiDetail(ctx context.Context) {
 			if apiId == "" {
 				break
 			}
+			// 检查点中已同步的API直接跳过
+			if p.checkpointer != nil && p.checkpointer.IsApiCompleted(apiId) {
+				logx.Infof("API 已在检查点中完成, 跳过: %s", apiId)
+				break
+			}
 			apiDetail, err := p.fetchAPIDetail(ctx, p.Client, apiId, p.Config.SharedDocID)
 			if err != nil {
-				p.ErrorChan <- &ApiError{ApiID: apiId, Error: err}
+				p.sendError(ctx, &ApiError{ApiID: apiId, Error: err})
 				return
 			}
+			if apiDetail.ID == "" {
+				apiDetail.ID = apiId
+			}
 
 			// Send API detail to data channel
-			p.ApiDetailChan <- apiDetail
+			select {
+			case p.ApiDetailChan <- apiDetail:
+			case <-ctx.Done():
+				return
+			}
 			logx.Infof("Successfully fetched API detail: %s", apiId)
 		}
 	}
@@ -443,6 +546,10 @@ func (p *ApiFoxSyncPipeline) storeAPI(ctx context.Context) {
 		return
 	}
 
+	// 等待进行中的写入完成后再返回，避免执行结束时写入被中断
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -459,17 +566,20 @@ func (p *ApiFoxSyncPipeline) storeAPI(ctx context.Context) {
 			// Convert APIDetail to BSON document
 			doc, err := bson.Marshal(apiDetail)
 			if err != nil {
-				p.ErrorChan <- &ApiError{
+				p.sendError(ctx, &ApiError{
 					ApiID: apiDetail.ID,
 					Error: fmt.Errorf("failed to marshal API detail to BSON: %w", err),
-				}
+				})
 				continue
 			}
 
 			// 使用 goroutine 异步存储数据到所有 MongoDB 实例
+			wg.Add(1)
 			go func(apiID string, document []byte) {
-				// 为操作创建带超时的上下文
-				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer wg.Done()
+
+				// 为操作创建带超时的上下文，执行取消时中断写入
+				ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 				defer cancel()
 
 				// 创建用于 upsert 操作的过滤器
@@ -478,16 +588,29 @@ func (p *ApiFoxSyncPipeline) storeAPI(ctx context.Context) {
 				// 反序列化回 BSON 文档用于更新
 				var updateDoc bson.D
 				if err := bson.Unmarshal(document, &updateDoc); err != nil {
-					p.ErrorChan <- &ApiError{
+					p.sendError(ctx, &ApiError{
 						ApiID: apiID,
 						Error: fmt.Errorf("反序列化 BSON 文档失败: %w", err),
-					}
+					})
 					return
 				}
 
+				// 锁已被其他执行接管时放弃写入，避免覆盖新执行的数据
+				if p.fence != nil {
+					if err := p.fence.Validate(ctx); err != nil {
+						p.sendError(ctx, &ApiError{
+							ApiID: apiID,
+							Error: fmt.Errorf("任务锁校验失败, 放弃写入: %w", err),
+						})
+						return
+					}
+					updateDoc = append(updateDoc, bson.E{Key: "fence_token", Value: p.fence.Token()})
+				}
+
 				// 遍历所有集合执行 upsert 操作
+				stored := true
 				for i, collection := range collections {
-					_, err = collection.UpdateOne(
+					_, err := collection.UpdateOne(
 						ctx,
 						filter,
 						bson.D{{Key: "$set", Value: updateDoc}},
@@ -495,72 +618,87 @@ func (p *ApiFoxSyncPipeline) storeAPI(ctx context.Context) {
 					)
 
 					if err != nil {
-						p.ErrorChan <- &ApiError{
+						stored = false
+						p.sendError(ctx, &ApiError{
 							ApiID: apiID,
 							Error: fmt.Errorf("存储 API 详情到 MongoDB-%d 失败: %w", i+1, err),
-						}
+						})
 						continue
 					}
 
 					logx.Infof("成功存储 API 详情到 MongoDB-%d: %s", i+1, apiID)
 				}
+
+				// 所有实例均写入成功后记录到检查点
+				if stored && p.checkpointer != nil {
+					p.checkpointer.MarkApiCompleted(apiID)
+				}
+
+				// 每个API作为一个步骤上报同步结果
+				status := core.TaskStatusCompleted
+				if !stored {
+					status = core.TaskStatusFailed
+				}
+				p.EmitEvent(core.ExecutionEvent{
+					Type:   core.EventStep,
+					Name:   apiID,
+					Status: status,
+					Result: map[string]interface{}{"collections": len(collections)},
+				})
 			}(apiDetail.ID, doc)
 		}
 	}
 }
 
+// OnStart 第一个开始的数据源将执行记录置为运行中
 func (p *ApiFoxSyncPipeline) OnStart(recordId, taskID string, taskSpec map[string]interface{}) error {
-	record := &taskrecord.TaskRecord{
-		TaskID:    taskID,
-		TaskType:  "sync",
-		SubType:   "apifox",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-		Status:    "0",
-		TaskSpec:  taskSpec,
-		Result:    make([]map[string]interface{}, 0),
-	}
-
-	err := p.taskrecordModel.Create(context.Background(), record)
-	if err != nil {
-		return fmt.Errorf("failed to create task record: %w", err)
+	if p.taskrecordModel == nil || recordId == "" {
+		return nil
+	}
+
+	if _, err := p.taskrecordModel.Transition(context.Background(), recordId, taskrecord.StatusRunning, bson.M{
+		"started_at": time.Now(),
+	}); err != nil {
+		logx.Errorf("更新执行记录 %s 为运行中失败: %v", recordId, err)
+		return fmt.Errorf("failed to update task record: %w", err)
 	}
 
 	return nil
 }
 
+// OnFinish 将数据源的同步结果追加到执行记录
 func (p *ApiFoxSyncPipeline) OnFinish(recordId, taskID string, taskSpec, result map[string]interface{}) error {
-	update := bson.M{
-		"$set": bson.M{
-			"status":     "1",
-			"updated_at": time.Now(),
-		},
-		"$push": bson.M{
-			"result": result,
-		},
+	if p.taskrecordModel == nil || recordId == "" {
+		return nil
 	}
 
-	err := p.taskrecordModel.UpdateByRecordId(context.Background(), recordId, update)
-	if err != nil {
+	entry := map[string]interface{}{
+		"source":      p.Config.ProjectID,
+		"finished_at": time.Now(),
+	}
+	for k, v := range result {
+		entry[k] = v
+	}
+	if err := p.taskrecordModel.AppendResult(context.Background(), recordId, entry); err != nil {
+		logx.Errorf("记录执行 %s 的同步结果失败: %v", recordId, err)
 		return fmt.Errorf("failed to update task record: %w", err)
 	}
 
 	return nil
 }
 
+// OnError 将同步过程中的错误追加到执行记录，执行的最终状态由执行结束时汇总
 func (p *ApiFoxSyncPipeline) OnError(recordId, taskID string, taskSpec, errorInfo map[string]interface{}) error {
-	update := bson.M{
-		"$set": bson.M{
-			"status":     "2",
-			"updated_at": time.Now(),
-		},
-		"$push": bson.M{
-			"result": errorInfo,
-		},
+	if p.taskrecordModel == nil || recordId == "" {
+		return nil
 	}
 
-	err := p.taskrecordModel.UpdateByRecordId(context.Background(), recordId, update)
-	if err != nil {
+	message := fmt.Sprintf("%s: %v", p.Config.ProjectID, errorInfo["error"])
+	if apiID, ok := errorInfo["api_id"].(string); ok && apiID != "" {
+		message = fmt.Sprintf("%s/%s: %v", p.Config.ProjectID, apiID, errorInfo["error"])
+	}
+	if err := p.taskrecordModel.AppendError(context.Background(), recordId, message); err != nil {
+		logx.Errorf("记录执行 %s 的错误失败: %v", recordId, err)
 		return fmt.Errorf("failed to update task record: %w", err)
 	}
 
@@ -691,7 +829,7 @@ func (p *ApiFoxSyncPipeline) fetchAPIDetail(ctx context.Context, client *ApiClie
 	apiURL := fmt.Sprintf("https://apifox.com/api/v1/shared-docs/%s/http-apis/%s", docId, apiID)
 
 	// Create request
-	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create API detail request: %w", err)
 	}
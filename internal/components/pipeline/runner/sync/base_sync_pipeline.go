@@ -118,81 +118,52 @@ func (p *BaseSyncPipeline) Validate(ctx context.Context) error {
 
 // Execute 执行同步
 func (p *BaseSyncPipeline) Execute(ctx context.Context, spec map[string]interface{}) (map[string]interface{}, error) {
+	// 进入运行状态，触发 OnStart 钩子
+	if err := p.Begin(ctx, spec); err != nil {
+		return nil, err
+	}
+
+	// 数据源和目标基于本次执行的上下文，Cancel 时中断
+	execCtx := p.ExecutionContext()
+
 	// 记录开始时间
-	startTime := time.Now()
+	startTime := p.StartTime
 	p.result.Metrics.StartTime = startTime
 	p.running = true
-	p.Status = core.TaskStatusRunning
 
 	// 转换配置
 	if err := p.parseConfig(spec); err != nil {
-		p.result.Error = &core.PipelineError{
-			Message: fmt.Sprintf("解析配置失败: %v", err),
-			Code:    "CONFIG_ERROR",
-		}
-		p.Status = core.TaskStatusFailed
-		p.running = false
-		return nil, err
+		return nil, p.fail(ctx, "CONFIG_ERROR", fmt.Sprintf("解析配置失败: %v", err), err)
 	}
 
 	// 连接数据源
-	if err := p.source.Connect(ctx, p.config.SourceConfig); err != nil {
-		p.result.Error = &core.PipelineError{
-			Message: fmt.Sprintf("连接数据源失败: %v", err),
-			Code:    "SOURCE_CONNECT_ERROR",
-		}
-		p.Status = core.TaskStatusFailed
-		p.running = false
-		return nil, err
+	if err := p.source.Connect(execCtx, p.config.SourceConfig); err != nil {
+		return nil, p.fail(ctx, "SOURCE_CONNECT_ERROR", fmt.Sprintf("连接数据源失败: %v", err), err)
 	}
 
 	// 连接数据目标
-	if err := p.target.Connect(ctx, p.config.TargetConfig); err != nil {
-		p.result.Error = &core.PipelineError{
-			Message: fmt.Sprintf("连接数据目标失败: %v", err),
-			Code:    "TARGET_CONNECT_ERROR",
-		}
-		p.Status = core.TaskStatusFailed
-		p.running = false
-		return nil, err
+	if err := p.target.Connect(execCtx, p.config.TargetConfig); err != nil {
+		return nil, p.fail(ctx, "TARGET_CONNECT_ERROR", fmt.Sprintf("连接数据目标失败: %v", err), err)
 	}
 
 	// 获取数据
 	p.Progress = 0.3
-	data, err := p.source.Fetch(ctx, p.config.Options)
+	data, err := p.source.Fetch(execCtx, p.config.Options)
 	if err != nil {
-		p.result.Error = &core.PipelineError{
-			Message: fmt.Sprintf("获取数据失败: %v", err),
-			Code:    "FETCH_ERROR",
-		}
-		p.Status = core.TaskStatusFailed
-		p.running = false
-		return nil, err
+		return nil, p.fail(ctx, "FETCH_ERROR", fmt.Sprintf("获取数据失败: %v", err), err)
 	}
 
 	// 转换数据
 	p.Progress = 0.6
-	transformedData, err := p.source.Transform(ctx, data, p.config.TransformConfig)
+	transformedData, err := p.source.Transform(execCtx, data, p.config.TransformConfig)
 	if err != nil {
-		p.result.Error = &core.PipelineError{
-			Message: fmt.Sprintf("转换数据失败: %v", err),
-			Code:    "TRANSFORM_ERROR",
-		}
-		p.Status = core.TaskStatusFailed
-		p.running = false
-		return nil, err
+		return nil, p.fail(ctx, "TRANSFORM_ERROR", fmt.Sprintf("转换数据失败: %v", err), err)
 	}
 
 	// 写入数据
 	p.Progress = 0.8
-	if err := p.target.Write(ctx, transformedData, p.config.Options); err != nil {
-		p.result.Error = &core.PipelineError{
-			Message: fmt.Sprintf("写入数据失败: %v", err),
-			Code:    "WRITE_ERROR",
-		}
-		p.Status = core.TaskStatusFailed
-		p.running = false
-		return nil, err
+	if err := p.target.Write(execCtx, transformedData, p.config.Options); err != nil {
+		return nil, p.fail(ctx, "WRITE_ERROR", fmt.Sprintf("写入数据失败: %v", err), err)
 	}
 
 	// 完成同步
@@ -224,36 +195,33 @@ func (p *BaseSyncPipeline) Execute(ctx context.Context, spec map[string]interfac
 		"duration":        p.result.Metrics.Duration,
 	}
 
-	p.Status = core.TaskStatusCompleted
-	p.Result = resultMap
-	p.EndTime = endTime
 	p.running = false
+	if err := p.Finish(ctx, resultMap, nil); err != nil {
+		return resultMap, err
+	}
 
 	return resultMap, nil
 }
 
-// parseConfig 解析配置
-func (p *BaseSyncPipeline) parseConfig(spec map[string]interface{}) error {
-	// 解析源配置
-	if sourceConfig, ok := spec["source_config"].(map[string]interface{}); ok {
-		p.config.SourceConfig = sourceConfig
-	}
-
-	// 解析目标配置
-	if targetConfig, ok := spec["target_config"].(map[string]interface{}); ok {
-		p.config.TargetConfig = targetConfig
-	}
-
-	// 解析选项
-	if options, ok := spec["options"].(map[string]interface{}); ok {
-		p.config.Options = options
+// fail 记录同步错误并将管道转为失败状态
+func (p *BaseSyncPipeline) fail(ctx context.Context, code, message string, err error) error {
+	p.result.Error = &core.PipelineError{
+		Message: message,
+		Code:    code,
+		Cause:   err,
 	}
+	p.running = false
+	p.Finish(ctx, nil, err)
+	return err
+}
 
-	// 解析转换配置
-	if transformConfig, ok := spec["transform_config"].(map[string]interface{}); ok {
-		p.config.TransformConfig = transformConfig
+// parseConfig 按schema校验并解码配置
+func (p *BaseSyncPipeline) parseConfig(spec map[string]interface{}) error {
+	var config SyncConfig
+	if err := core.DecodeSpec(core.TypeSync, spec, &config); err != nil {
+		return err
 	}
-
+	p.config = config
 	return nil
 }
 
@@ -264,9 +232,6 @@ func (p *BaseSyncPipeline) Cancel(ctx context.Context) error {
 	}
 
 	p.cancelled = true
-	p.Status = core.TaskStatusCanceled
-	endTime := time.Now()
-	p.EndTime = endTime
 
 	// 关闭连接
 	if p.source != nil {
@@ -277,7 +242,13 @@ func (p *BaseSyncPipeline) Cancel(ctx context.Context) error {
 		_ = p.target.Close(ctx)
 	}
 
+	p.running = false
+
+	// 转为取消状态，触发 OnCancel 钩子
+	err := p.BasePipeline.Cancel(ctx)
+
 	// 更新指标
+	endTime := p.EndTime
 	p.result.Metrics.EndTime = endTime
 	p.result.Metrics.Duration = endTime.Sub(p.result.Metrics.StartTime).Seconds()
 	p.result.Success = false
@@ -286,9 +257,7 @@ func (p *BaseSyncPipeline) Cancel(ctx context.Context) error {
 		Code:    "TASK_CANCELED",
 	}
 
-	p.running = false
-
-	return p.BasePipeline.Cancel(ctx)
+	return err
 }
 
 // GetSyncMetrics 获取同步指标
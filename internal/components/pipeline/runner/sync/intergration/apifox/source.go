@@ -1,6 +1,7 @@
 package apifox
 
 import (
+	"Storage/internal/components/pipeline/core"
 	"Storage/internal/components/pipeline/runner/sync"
 	"context"
 	"encoding/json"
@@ -19,6 +20,89 @@ const (
 	ApiFoxAPIDetailEndpoint = "https://apifox.com/api/v1/shared-docs/%s/apis/%s"
 )
 
+// sourceConfigSchema ApiFox数据源配置的 JSON Schema
+var sourceConfigSchema = core.MustParseSpecSchema(`{
+  "type": "object",
+  "required": ["shared_doc_id"],
+  "additionalProperties": false,
+  "properties": {
+    "shared_doc_id": {"type": "string", "minLength": 1},
+    "password": {"type": "string"},
+    "exclude_folders": {"type": "array", "items": {"type": "string"}},
+    "output_dir": {"type": "string", "minLength": 1, "default": "./api_data"},
+    "generate_summary": {"type": "boolean", "default": true}
+  }
+}`)
+
+// APITreeNode API树节点
+type APITreeNode struct {
+	// 节点ID
+	ID string `json:"id"`
+
+	// 节点名称
+	Name string `json:"name"`
+
+	// 节点类型 (folder/api)
+	Type string `json:"type"`
+
+	// HTTP方法
+	Method string `json:"method,omitempty"`
+
+	// API路径
+	Path string `json:"path,omitempty"`
+
+	// 子节点
+	Children []*APITreeNode `json:"children,omitempty"`
+}
+
+// APIDetail API详情
+type APIDetail struct {
+	// API ID
+	ID string `json:"id"`
+
+	// API名称
+	Name string `json:"name"`
+
+	// HTTP方法
+	Method string `json:"method"`
+
+	// 路径
+	Path string `json:"path"`
+
+	// 状态
+	Status string `json:"status"`
+
+	// 描述
+	Description string `json:"description,omitempty"`
+
+	// 请求头
+	Headers []map[string]interface{} `json:"headers,omitempty"`
+
+	// 请求参数
+	Parameters []map[string]interface{} `json:"parameters,omitempty"`
+
+	// 请求体
+	RequestBody map[string]interface{} `json:"requestBody,omitempty"`
+
+	// 响应
+	Responses []map[string]interface{} `json:"responses,omitempty"`
+}
+
+// FolderDetail 文件夹详情
+type FolderDetail struct {
+	// 文件夹ID
+	ID string `json:"id"`
+
+	// 文件夹名称
+	Name string `json:"name"`
+
+	// 子API列表
+	APIs []*APIDetail `json:"apis,omitempty"`
+
+	// 子文件夹
+	Folders []*FolderDetail `json:"folders,omitempty"`
+}
+
 // ApiFoxSourceConfig ApiFox数据源配置
 type ApiFoxSourceConfig struct {
 	// 共享文档ID
@@ -39,7 +123,14 @@ type ApiFoxSourceConfig struct {
 
 // ApiFoxSource ApiFox数据源实现
 type ApiFoxSource struct {
-	*sync.ApiDocSource
+	// HTTP客户端
+	client *http.Client
+
+	// 状态
+	status sync.SourceStatus
+
+	// API详情，API ID -> 详情
+	apiDetails map[string]*APIDetail
 
 	// 配置
 	config ApiFoxSourceConfig
@@ -57,15 +148,10 @@ type ApiFoxSource struct {
 // NewApiFoxSource 创建ApiFox数据源
 func NewApiFoxSource() *ApiFoxSource {
 	return &ApiFoxSource{
-		ApiDocSource: &sync.ApiDocSource{
-			client: &http.Client{
-				Timeout: 30 * time.Second,
-			},
-			status: sync.SourceStatus{
-				Connected: false,
-			},
-			apiDetails: make(map[string]*APIDetail),
+		client: &http.Client{
+			Timeout: 30 * time.Second,
 		},
+		apiDetails: make(map[string]*APIDetail),
 	}
 }
 
@@ -88,47 +174,13 @@ func (s *ApiFoxSource) Connect(ctx context.Context, config map[string]interface{
 	return nil
 }
 
-// parseConfig 解析配置
+// parseConfig 按schema校验并解码配置
 func (s *ApiFoxSource) parseConfig(config map[string]interface{}) error {
-	// 读取共享文档ID
-	if docID, ok := config["shared_doc_id"].(string); ok && docID != "" {
-		s.config.SharedDocID = docID
-	} else {
-		return fmt.Errorf("必须提供共享文档ID")
-	}
-
-	// 读取密码
-	if password, ok := config["password"].(string); ok {
-		s.config.Password = password
-	}
-
-	// 读取排除文件夹
-	if excludeFolders, ok := config["exclude_folders"].([]string); ok {
-		s.config.ExcludeFolders = excludeFolders
-	} else if excludeFoldersArray, ok := config["exclude_folders"].([]interface{}); ok {
-		folders := make([]string, 0, len(excludeFoldersArray))
-		for _, folder := range excludeFoldersArray {
-			if folderName, ok := folder.(string); ok {
-				folders = append(folders, folderName)
-			}
-		}
-		s.config.ExcludeFolders = folders
-	}
-
-	// 读取输出目录
-	if outputDir, ok := config["output_dir"].(string); ok && outputDir != "" {
-		s.config.OutputDir = outputDir
-	} else {
-		s.config.OutputDir = "./api_data"
+	var sourceConfig ApiFoxSourceConfig
+	if err := sourceConfigSchema.Decode(config, &sourceConfig); err != nil {
+		return fmt.Errorf("ApiFox数据源配置无效: %w", err)
 	}
-
-	// 读取是否生成摘要
-	if generateSummary, ok := config["generate_summary"].(bool); ok {
-		s.config.GenerateSummary = generateSummary
-	} else {
-		s.config.GenerateSummary = true
-	}
-
+	s.config = sourceConfig
 	return nil
 }
 
@@ -480,72 +532,3 @@ func (s *ApiFoxSource) Close(ctx context.Context) error {
 	s.status.Connected = false
 	return nil
 }
-
-// // APITreeNode API树节点
-// type APITreeNode struct {
-// 	// 节点ID
-// 	ID string `json:"id"`
-
-// 	// 节点名称
-// 	Name string `json:"name"`
-
-// 	// 节点类型 (folder/api)
-// 	Type string `json:"type"`
-
-// 	// HTTP方法
-// 	Method string `json:"method,omitempty"`
-
-// 	// API路径
-// 	Path string `json:"path,omitempty"`
-
-// 	// 子节点
-// 	Children []*APITreeNode `json:"children,omitempty"`
-// }
-
-// // APIDetail API详情
-// type APIDetail struct {
-// 	// API ID
-// 	ID string `json:"id"`
-
-// 	// API名称
-// 	Name string `json:"name"`
-
-// 	// HTTP方法
-// 	Method string `json:"method"`
-
-// 	// 路径
-// 	Path string `json:"path"`
-
-// 	// 状态
-// 	Status string `json:"status"`
-
-// 	// 描述
-// 	Description string `json:"description,omitempty"`
-
-// 	// 请求头
-// 	Headers []map[string]interface{} `json:"headers,omitempty"`
-
-// 	// 请求参数
-// 	Parameters []map[string]interface{} `json:"parameters,omitempty"`
-
-// 	// 请求体
-// 	RequestBody map[string]interface{} `json:"requestBody,omitempty"`
-
-// 	// 响应
-// 	Responses []map[string]interface{} `json:"responses,omitempty"`
-// }
-
-// // FolderDetail 文件夹详情
-// type FolderDetail struct {
-// 	// 文件夹ID
-// 	ID string `json:"id"`
-
-// 	// 文件夹名称
-// 	Name string `json:"name"`
-
-// 	// 子API列表
-// 	APIs []*APIDetail `json:"apis,omitempty"`
-
-// 	// 子文件夹
-// 	Folders []*FolderDetail `json:"folders,omitempty"`
-// }
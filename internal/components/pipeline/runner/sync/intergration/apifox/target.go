@@ -1,7 +1,8 @@
 package apifox
 
 import (
-	"Storage/internal/logic/workflows/sync"
+	"Storage/internal/components/pipeline/core"
+	"Storage/internal/components/pipeline/runner/sync"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -10,6 +11,19 @@ import (
 	"time"
 )
 
+// targetConfigSchema 文件系统目标配置的 JSON Schema
+var targetConfigSchema = core.MustParseSpecSchema(`{
+  "type": "object",
+  "additionalProperties": false,
+  "properties": {
+    "output_dir": {"type": "string", "minLength": 1, "default": "./api_data"},
+    "file_prefix": {"type": "string", "default": "apifox"},
+    "generate_summary": {"type": "boolean", "default": true},
+    "include_timestamp": {"type": "boolean", "default": true},
+    "pretty_json": {"type": "boolean", "default": true}
+  }
+}`)
+
 // FileSystemTargetConfig 文件系统目标配置
 type FileSystemTargetConfig struct {
 	// 输出目录
@@ -72,43 +86,13 @@ func (t *FileSystemTarget) Connect(ctx context.Context, config map[string]interf
 	return nil
 }
 
-// parseConfig 解析配置
+// parseConfig 按schema校验并解码配置
 func (t *FileSystemTarget) parseConfig(config map[string]interface{}) error {
-	// 读取输出目录
-	if outputDir, ok := config["output_dir"].(string); ok && outputDir != "" {
-		t.config.OutputDir = outputDir
-	} else {
-		t.config.OutputDir = "./api_data"
-	}
-
-	// 读取文件前缀
-	if filePrefix, ok := config["file_prefix"].(string); ok {
-		t.config.FilePrefix = filePrefix
-	} else {
-		t.config.FilePrefix = "apifox"
-	}
-
-	// 读取是否生成摘要
-	if generateSummary, ok := config["generate_summary"].(bool); ok {
-		t.config.GenerateSummary = generateSummary
-	} else {
-		t.config.GenerateSummary = true
+	var targetConfig FileSystemTargetConfig
+	if err := targetConfigSchema.Decode(config, &targetConfig); err != nil {
+		return fmt.Errorf("文件系统目标配置无效: %w", err)
 	}
-
-	// 读取是否包含时间戳
-	if includeTimestamp, ok := config["include_timestamp"].(bool); ok {
-		t.config.IncludeTimestamp = includeTimestamp
-	} else {
-		t.config.IncludeTimestamp = true
-	}
-
-	// 读取是否格式化JSON
-	if prettyJSON, ok := config["pretty_json"].(bool); ok {
-		t.config.PrettyJSON = prettyJSON
-	} else {
-		t.config.PrettyJSON = true
-	}
-
+	t.config = targetConfig
 	return nil
 }
 
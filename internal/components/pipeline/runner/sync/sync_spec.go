@@ -0,0 +1,23 @@
+package sync
+
+import (
+	"Storage/internal/components/pipeline/core"
+)
+
+// syncSpecSchema 同步管道spec的 JSON Schema
+// 在工作流中上游结果会合并到顶层，因此顶层允许未声明的字段
+const syncSpecSchema = `{
+  "type": "object",
+  "required": ["source_config", "target_config"],
+  "additionalProperties": true,
+  "properties": {
+    "source_config": {"type": "object"},
+    "target_config": {"type": "object"},
+    "options": {"type": "object"},
+    "transform_config": {"type": "object"}
+  }
+}`
+
+func init() {
+	core.RegisterSpecSchema(core.TypeSync, syncSpecSchema)
+}
@@ -0,0 +1,509 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"Storage/internal/components/pipeline/core"
+)
+
+// Workflow 由多个 PipelineRunner 组成的DAG工作流
+// 节点在所有上游完成后执行，上游的执行结果作为下游的spec输入
+type Workflow struct {
+	*core.BasePipeline
+
+	// 节点锁
+	mu sync.RWMutex
+
+	// 节点映射
+	nodes map[string]*Node
+
+	// 节点注册顺序
+	order []string
+
+	// 取消当前执行
+	cancel context.CancelFunc
+}
+
+// 确保实现了 core.PipelineRunner 接口
+var _ core.PipelineRunner = (*Workflow)(nil)
+
+// NewWorkflow 创建工作流
+func NewWorkflow(name, description string) *Workflow {
+	return &Workflow{
+		BasePipeline: core.NewBasePipeline(name, description),
+		nodes:        make(map[string]*Node),
+		order:        make([]string, 0),
+	}
+}
+
+// NewWorkflowFromConfig 根据配置创建工作流，节点管道由factory创建
+func NewWorkflowFromConfig(factory core.PipelineFactory, config *WorkflowConfig) (*Workflow, error) {
+	if config == nil {
+		return nil, fmt.Errorf("工作流配置不能为空")
+	}
+
+	w := NewWorkflow(config.Name, config.Description)
+	for i := range config.Nodes {
+		nodeConfig := config.Nodes[i]
+		runner, err := factory.Create(&nodeConfig.Pipeline)
+		if err != nil {
+			return nil, fmt.Errorf("创建节点 %s 的管道失败: %w", nodeConfig.ID, err)
+		}
+		// 节点spec执行时才与上游结果合并，此处只校验已配置字段
+		if err := core.ValidateSpecPartial(nodeConfig.Pipeline.Type, nodeConfig.Spec); err != nil {
+			return nil, fmt.Errorf("节点 %s 的spec无效: %w", nodeConfig.ID, err)
+		}
+		if err := w.AddNode(nodeConfig.ID, runner, nodeConfig.Spec, nodeConfig.DependsOn...); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := w.topologicalOrder(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func init() {
+	core.RegisterSpecSchema(core.TypeWorkflow, workflowSpecSchema)
+	core.RegisterSpecSchema(core.TypeTask, taskNodeSchema)
+}
+
+// Register 在工厂中注册工作流类型，节点管道同样由该工厂创建
+func Register(factory *core.DefaultPipelineFactory) {
+	factory.Register(core.TypeWorkflow, func(config *core.PipelineConfig) (core.PipelineRunner, error) {
+		workflowConfig, err := ParseConfig(config.Config)
+		if err != nil {
+			return nil, err
+		}
+		workflowConfig.Name = config.Name
+		workflowConfig.Description = config.Description
+
+		return NewWorkflowFromConfig(factory, workflowConfig)
+	})
+}
+
+// ParseConfig 按工作流的spec schema校验并解析工作流配置
+func ParseConfig(config map[string]interface{}) (*WorkflowConfig, error) {
+	var workflowConfig WorkflowConfig
+	if err := core.DecodeSpec(core.TypeWorkflow, config, &workflowConfig); err != nil {
+		return nil, fmt.Errorf("解析工作流配置失败: %w", err)
+	}
+	return &workflowConfig, nil
+}
+
+// ValidateConfig 校验工作流配置的节点ID和依赖关系，不创建节点管道
+func ValidateConfig(config *WorkflowConfig) error {
+	w := NewWorkflow(config.Name, config.Description)
+	for _, node := range config.Nodes {
+		if err := w.AddNode(node.ID, core.NewBasePipeline(node.ID, ""), node.Spec, node.DependsOn...); err != nil {
+			return err
+		}
+	}
+	_, err := w.topologicalOrder()
+	return err
+}
+
+// AddNode 添加节点，dependsOn为上游节点ID
+func (w *Workflow) AddNode(id string, runner core.PipelineRunner, spec map[string]interface{}, dependsOn ...string) error {
+	if id == "" {
+		return fmt.Errorf("节点ID不能为空")
+	}
+	if runner == nil {
+		return fmt.Errorf("节点 %s 的执行器不能为空", id)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, exists := w.nodes[id]; exists {
+		return fmt.Errorf("节点 %s 已存在", id)
+	}
+
+	w.nodes[id] = &Node{
+		ID:        id,
+		Runner:    runner,
+		DependsOn: dependsOn,
+		Spec:      spec,
+		Status:    core.TaskStatusPending,
+	}
+	w.order = append(w.order, id)
+	return nil
+}
+
+// topologicalOrder 校验依赖并返回拓扑顺序，存在未知依赖或环时返回错误
+func (w *Workflow) topologicalOrder() ([]string, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	inDegree := make(map[string]int, len(w.nodes))
+	downstream := make(map[string][]string, len(w.nodes))
+	for _, id := range w.order {
+		node := w.nodes[id]
+		for _, dep := range node.DependsOn {
+			if _, ok := w.nodes[dep]; !ok {
+				return nil, fmt.Errorf("节点 %s 依赖的节点 %s 不存在", id, dep)
+			}
+			if dep == id {
+				return nil, fmt.Errorf("节点 %s 不能依赖自身", id)
+			}
+			inDegree[id]++
+			downstream[dep] = append(downstream[dep], id)
+		}
+	}
+
+	queue := make([]string, 0)
+	for _, id := range w.order {
+		if inDegree[id] == 0 {
+			queue = append(queue, id)
+		}
+	}
+
+	sorted := make([]string, 0, len(w.order))
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		sorted = append(sorted, id)
+		for _, next := range downstream[id] {
+			inDegree[next]--
+			if inDegree[next] == 0 {
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	if len(sorted) != len(w.order) {
+		return nil, fmt.Errorf("工作流 %s 存在循环依赖", w.Name)
+	}
+	return sorted, nil
+}
+
+// Initialize 初始化工作流及所有节点
+func (w *Workflow) Initialize(ctx context.Context) error {
+	if err := w.BasePipeline.Initialize(ctx); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, id := range w.order {
+		node := w.nodes[id]
+		if err := node.Runner.Initialize(ctx); err != nil {
+			return fmt.Errorf("初始化节点 %s 失败: %w", id, err)
+		}
+		w.resetNode(node)
+	}
+	return nil
+}
+
+// Validate 校验DAG结构及所有节点配置
+func (w *Workflow) Validate(ctx context.Context) error {
+	if len(w.order) == 0 {
+		return fmt.Errorf("工作流 %s 没有任何节点", w.Name)
+	}
+
+	if _, err := w.topologicalOrder(); err != nil {
+		return err
+	}
+
+	for _, id := range w.order {
+		if err := w.nodes[id].Runner.Validate(ctx); err != nil {
+			return fmt.Errorf("节点 %s 校验失败: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// Execute 执行工作流，无依赖关系的节点并行执行
+// 任一节点失败时取消其余节点，未执行的下游节点标记为取消
+func (w *Workflow) Execute(ctx context.Context, spec map[string]interface{}) (map[string]interface{}, error) {
+	if _, err := w.topologicalOrder(); err != nil {
+		return nil, err
+	}
+
+	if err := w.Begin(ctx, spec); err != nil {
+		return nil, err
+	}
+
+	// 节点基于本次执行的上下文运行，Cancel 时一并取消
+	runCtx, cancel := context.WithCancel(w.ExecutionContext())
+	defer cancel()
+
+	w.mu.Lock()
+	w.cancel = cancel
+	for _, id := range w.order {
+		w.resetNode(w.nodes[id])
+	}
+	nodes := make([]*Node, 0, len(w.order))
+	for _, id := range w.order {
+		nodes = append(nodes, w.nodes[id])
+	}
+	w.mu.Unlock()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	for _, node := range nodes {
+		wg.Add(1)
+		go func(node *Node) {
+			defer wg.Done()
+			defer close(node.done)
+
+			if err := w.runNode(runCtx, node, spec); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(node)
+	}
+	wg.Wait()
+
+	w.mu.Lock()
+	w.cancel = nil
+	result := make(map[string]interface{}, len(nodes))
+	for _, node := range nodes {
+		if node.Status == core.TaskStatusCompleted {
+			result[node.ID] = node.Result
+		}
+	}
+	w.mu.Unlock()
+
+	// 已通过 Cancel 取消，状态已流转
+	if w.GetStatus(ctx) == core.TaskStatusCanceled {
+		return result, context.Canceled
+	}
+
+	if firstErr == nil && ctx.Err() != nil {
+		firstErr = ctx.Err()
+	}
+	if err := w.Finish(ctx, result, firstErr); err != nil && firstErr == nil {
+		return result, err
+	}
+	return result, firstErr
+}
+
+// runNode 等待上游完成后执行单个节点
+func (w *Workflow) runNode(ctx context.Context, node *Node, spec map[string]interface{}) error {
+	for _, dep := range node.DependsOn {
+		<-w.nodes[dep].done
+	}
+
+	// 上游未成功或工作流已取消时跳过
+	w.mu.RLock()
+	skip := ctx.Err() != nil
+	for _, dep := range node.DependsOn {
+		if w.nodes[dep].Status != core.TaskStatusCompleted {
+			skip = true
+		}
+	}
+	w.mu.RUnlock()
+	if skip {
+		w.setNodeStatus(node, core.TaskStatusCanceled, nil, nil)
+		return nil
+	}
+
+	input := w.buildSpec(node, spec)
+	w.setNodeStatus(node, core.TaskStatusRunning, nil, nil)
+
+	result, err := node.Runner.Execute(ctx, input)
+	if err != nil {
+		if ctx.Err() != nil {
+			w.setNodeStatus(node, core.TaskStatusCanceled, result, err)
+			return nil
+		}
+		w.setNodeStatus(node, core.TaskStatusFailed, result, err)
+		return fmt.Errorf("节点 %s 执行失败: %w", node.ID, err)
+	}
+
+	w.setNodeStatus(node, core.TaskStatusCompleted, result, nil)
+	return nil
+}
+
+// buildSpec 组装节点输入：工作流spec < 上游结果 < 节点静态spec
+func (w *Workflow) buildSpec(node *Node, spec map[string]interface{}) map[string]interface{} {
+	input := make(map[string]interface{})
+	for k, v := range spec {
+		input[k] = v
+	}
+
+	w.mu.RLock()
+	if len(node.DependsOn) > 0 {
+		upstream := make(map[string]interface{}, len(node.DependsOn))
+		for _, dep := range node.DependsOn {
+			depResult := w.nodes[dep].Result
+			upstream[dep] = depResult
+			for k, v := range depResult {
+				input[k] = v
+			}
+		}
+		input[UpstreamKey] = upstream
+	}
+	w.mu.RUnlock()
+
+	for k, v := range node.Spec {
+		input[k] = v
+	}
+	return input
+}
+
+// setNodeStatus 按状态机更新节点状态，节点结束时发布步骤事件和整体进度
+func (w *Workflow) setNodeStatus(node *Node, status core.TaskStatus, result map[string]interface{}, err error) {
+	w.mu.Lock()
+	if core.ValidateTransition(node.Status, status) != nil {
+		w.mu.Unlock()
+		return
+	}
+
+	node.Status = status
+	switch {
+	case status == core.TaskStatusRunning:
+		node.StartTime = time.Now()
+	case status.IsTerminal():
+		node.EndTime = time.Now()
+		node.Result = result
+		node.Error = err
+	}
+	w.mu.Unlock()
+
+	if !status.IsTerminal() {
+		return
+	}
+	event := core.ExecutionEvent{Type: core.EventStep, Name: node.ID, Status: status, Result: result}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	w.EmitEvent(event)
+	if progress, err := w.GetProgress(context.Background()); err == nil {
+		w.SetProgress(progress)
+	}
+}
+
+// resetNode 重置节点执行状态，调用方需持有写锁
+func (w *Workflow) resetNode(node *Node) {
+	node.Status = core.TaskStatusPending
+	node.StartTime = time.Time{}
+	node.EndTime = time.Time{}
+	node.Result = nil
+	node.Error = nil
+	node.done = make(chan struct{})
+}
+
+// Cancel 取消工作流及运行中的节点
+func (w *Workflow) Cancel(ctx context.Context) error {
+	w.mu.RLock()
+	cancel := w.cancel
+	running := make([]*Node, 0)
+	for _, id := range w.order {
+		if node := w.nodes[id]; node.Status == core.TaskStatusRunning {
+			running = append(running, node)
+		}
+	}
+	w.mu.RUnlock()
+
+	if err := w.BasePipeline.Cancel(ctx); err != nil {
+		return err
+	}
+
+	if cancel != nil {
+		cancel()
+	}
+	for _, node := range running {
+		if err := node.Runner.Cancel(ctx); err != nil {
+			return fmt.Errorf("取消节点 %s 失败: %w", node.ID, err)
+		}
+	}
+	return nil
+}
+
+// BindEvents 绑定所属执行，已注册的节点一并绑定
+func (w *Workflow) BindEvents(executionID string, publisher core.EventPublisher) {
+	w.BasePipeline.BindEvents(executionID, publisher)
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	for _, id := range w.order {
+		if bindable, ok := w.nodes[id].Runner.(core.EventBindable); ok {
+			bindable.BindEvents(executionID, publisher)
+		}
+	}
+}
+
+// GetProgress 获取整体进度，为各节点进度的平均值
+func (w *Workflow) GetProgress(ctx context.Context) (float64, error) {
+	states := w.GetNodeStates(ctx)
+	if len(states) == 0 {
+		return 0, nil
+	}
+
+	var total float64
+	for _, state := range states {
+		total += state.Progress
+	}
+	return total / float64(len(states)), nil
+}
+
+// GetNodeStates 按注册顺序返回各节点的状态和进度
+func (w *Workflow) GetNodeStates(ctx context.Context) []NodeState {
+	w.mu.RLock()
+	nodes := make([]*Node, 0, len(w.order))
+	states := make([]NodeState, 0, len(w.order))
+	for _, id := range w.order {
+		node := w.nodes[id]
+		state := NodeState{
+			ID:        node.ID,
+			DependsOn: node.DependsOn,
+			Status:    node.Status,
+			StartTime: node.StartTime,
+			EndTime:   node.EndTime,
+			Result:    node.Result,
+		}
+		if node.Error != nil {
+			state.Error = node.Error.Error()
+		}
+		nodes = append(nodes, node)
+		states = append(states, state)
+	}
+	w.mu.RUnlock()
+
+	// 进度在锁外获取，避免节点执行器回调时死锁
+	for i, node := range nodes {
+		switch states[i].Status {
+		case core.TaskStatusCompleted:
+			states[i].Progress = 1.0
+		case core.TaskStatusRunning:
+			if progress, err := node.Runner.GetProgress(ctx); err == nil {
+				states[i].Progress = progress
+			}
+		}
+	}
+	return states
+}
+
+// GetMetrics 获取工作流及各节点的执行指标
+func (w *Workflow) GetMetrics(ctx context.Context) map[string]interface{} {
+	metrics := w.BasePipeline.GetMetrics(ctx)
+	metrics["progress"], _ = w.GetProgress(ctx)
+	metrics["nodes"] = w.GetNodeStates(ctx)
+	return metrics
+}
+
+// Cleanup 清理所有节点资源
+func (w *Workflow) Cleanup(ctx context.Context) error {
+	var firstErr error
+	for _, id := range w.order {
+		if err := w.nodes[id].Runner.Cleanup(ctx); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("清理节点 %s 失败: %w", id, err)
+		}
+	}
+
+	if err := w.BasePipeline.Cleanup(ctx); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
@@ -0,0 +1,387 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"Storage/internal/components/pipeline/core"
+)
+
+// fakeRunner 测试用节点，记录收到的spec并按 run 返回结果
+type fakeRunner struct {
+	*core.BasePipeline
+
+	mu    sync.Mutex
+	input map[string]interface{}
+	run   func(ctx context.Context, spec map[string]interface{}) (map[string]interface{}, error)
+}
+
+func newFakeRunner(name string, run func(ctx context.Context, spec map[string]interface{}) (map[string]interface{}, error)) *fakeRunner {
+	return &fakeRunner{BasePipeline: core.NewBasePipeline(name, ""), run: run}
+}
+
+func (r *fakeRunner) Execute(ctx context.Context, spec map[string]interface{}) (map[string]interface{}, error) {
+	r.mu.Lock()
+	r.input = spec
+	r.mu.Unlock()
+	if r.run == nil {
+		return map[string]interface{}{"node": r.Name}, nil
+	}
+	return r.run(ctx, spec)
+}
+
+func (r *fakeRunner) received() map[string]interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.input
+}
+
+// result 返回固定结果的节点执行函数
+func result(values map[string]interface{}) func(context.Context, map[string]interface{}) (map[string]interface{}, error) {
+	return func(ctx context.Context, spec map[string]interface{}) (map[string]interface{}, error) {
+		return values, nil
+	}
+}
+
+func TestWorkflowFanOutFanIn(t *testing.T) {
+	// b 和 c 都开始后才结束，两者不能并行时超时失败
+	started := make(chan string, 2)
+	bothStarted := make(chan struct{})
+	go func() {
+		<-started
+		<-started
+		close(bothStarted)
+	}()
+	parallel := func(name string) func(context.Context, map[string]interface{}) (map[string]interface{}, error) {
+		return func(ctx context.Context, spec map[string]interface{}) (map[string]interface{}, error) {
+			started <- name
+			select {
+			case <-bothStarted:
+				return map[string]interface{}{name + "_count": 1}, nil
+			case <-time.After(2 * time.Second):
+				return nil, fmt.Errorf("%s 等待并行节点超时", name)
+			}
+		}
+	}
+
+	a := newFakeRunner("a", result(map[string]interface{}{"token": "t-1"}))
+	b := newFakeRunner("b", parallel("b"))
+	c := newFakeRunner("c", parallel("c"))
+	d := newFakeRunner("d", nil)
+
+	w := NewWorkflow("fan", "")
+	mustAdd(t, w, "a", a, nil)
+	mustAdd(t, w, "b", b, nil, "a")
+	mustAdd(t, w, "c", c, nil, "a")
+	mustAdd(t, w, "d", d, nil, "b", "c")
+
+	got, err := w.Execute(context.Background(), map[string]interface{}{"env": "test"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(got) != 4 {
+		t.Errorf("Execute() result = %v, want results of 4 nodes", got)
+	}
+	if status := w.GetStatus(context.Background()); status != core.TaskStatusCompleted {
+		t.Errorf("GetStatus() = %s, want completed", status)
+	}
+
+	// 汇聚节点收到两个上游的结果
+	input := d.received()
+	upstream, _ := input[UpstreamKey].(map[string]interface{})
+	if len(upstream) != 2 || upstream["b"] == nil || upstream["c"] == nil {
+		t.Errorf("d upstream = %v, want results of b and c", input[UpstreamKey])
+	}
+	if input["b_count"] != 1 || input["c_count"] != 1 || input["env"] != "test" {
+		t.Errorf("d input = %v, want merged upstream results and workflow spec", input)
+	}
+	// 分叉节点都收到上游 a 的结果
+	for _, node := range []*fakeRunner{b, c} {
+		if node.received()["token"] != "t-1" {
+			t.Errorf("%s input = %v, want token from a", node.Name, node.received())
+		}
+	}
+
+	for _, state := range w.GetNodeStates(context.Background()) {
+		if state.Status != core.TaskStatusCompleted || state.Progress != 1.0 {
+			t.Errorf("node %s state = %s/%v, want completed/1", state.ID, state.Status, state.Progress)
+		}
+	}
+}
+
+func TestWorkflowSpecPrecedence(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     map[string]interface{}
+		upstream map[string]interface{}
+		nodeSpec map[string]interface{}
+		want     map[string]interface{}
+	}{
+		{
+			name: "只有工作流spec",
+			spec: map[string]interface{}{"env": "test"},
+			want: map[string]interface{}{"env": "test", UpstreamKey: map[string]interface{}{"up": map[string]interface{}{}}},
+		},
+		{
+			name:     "上游结果覆盖工作流spec",
+			spec:     map[string]interface{}{"env": "test", "user": "a"},
+			upstream: map[string]interface{}{"user": "b"},
+			want: map[string]interface{}{
+				"env": "test", "user": "b",
+				UpstreamKey: map[string]interface{}{"up": map[string]interface{}{"user": "b"}},
+			},
+		},
+		{
+			name:     "节点spec覆盖上游结果",
+			upstream: map[string]interface{}{"user": "b", "id": 1},
+			nodeSpec: map[string]interface{}{"user": "c"},
+			want: map[string]interface{}{
+				"user": "c", "id": 1,
+				UpstreamKey: map[string]interface{}{"up": map[string]interface{}{"user": "b", "id": 1}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := tt.upstream
+			if upstream == nil {
+				upstream = map[string]interface{}{}
+			}
+			down := newFakeRunner("down", nil)
+			w := NewWorkflow("precedence", "")
+			mustAdd(t, w, "up", newFakeRunner("up", result(upstream)), nil)
+			mustAdd(t, w, "down", down, tt.nodeSpec, "up")
+
+			if _, err := w.Execute(context.Background(), tt.spec); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got := down.received(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("down input = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkflowNodeFailure(t *testing.T) {
+	nodeErr := errors.New("接口回归失败")
+	blocked := newFakeRunner("blocked", func(ctx context.Context, spec map[string]interface{}) (map[string]interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	report := newFakeRunner("report", nil)
+
+	w := NewWorkflow("failure", "")
+	mustAdd(t, w, "sync", newFakeRunner("sync", nil), nil)
+	mustAdd(t, w, "test", newFakeRunner("test", func(ctx context.Context, spec map[string]interface{}) (map[string]interface{}, error) {
+		return nil, nodeErr
+	}), nil, "sync")
+	mustAdd(t, w, "blocked", blocked, nil, "sync")
+	mustAdd(t, w, "report", report, nil, "test", "blocked")
+
+	got, err := w.Execute(context.Background(), nil)
+	if !errors.Is(err, nodeErr) {
+		t.Fatalf("Execute() error = %v, want %v", err, nodeErr)
+	}
+	if _, ok := got["sync"]; !ok || len(got) != 1 {
+		t.Errorf("Execute() result = %v, want only sync", got)
+	}
+	if status := w.GetStatus(context.Background()); status != core.TaskStatusFailed {
+		t.Errorf("GetStatus() = %s, want failed", status)
+	}
+
+	want := map[string]core.TaskStatus{
+		"sync":    core.TaskStatusCompleted,
+		"test":    core.TaskStatusFailed,
+		"blocked": core.TaskStatusCanceled,
+		"report":  core.TaskStatusCanceled,
+	}
+	for _, state := range w.GetNodeStates(context.Background()) {
+		if state.Status != want[state.ID] {
+			t.Errorf("node %s status = %s, want %s", state.ID, state.Status, want[state.ID])
+		}
+	}
+	if report.received() != nil {
+		t.Error("上游失败后下游节点仍被执行")
+	}
+}
+
+func TestWorkflowCancel(t *testing.T) {
+	running := make(chan struct{})
+	w := NewWorkflow("cancel", "")
+	mustAdd(t, w, "wait", newFakeRunner("wait", func(ctx context.Context, spec map[string]interface{}) (map[string]interface{}, error) {
+		close(running)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}), nil)
+	mustAdd(t, w, "next", newFakeRunner("next", nil), nil, "wait")
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := w.Execute(context.Background(), nil)
+		done <- err
+	}()
+	<-running
+	if err := w.Cancel(context.Background()); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Execute() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Cancel() 后工作流未结束")
+	}
+	if status := w.GetStatus(context.Background()); status != core.TaskStatusCanceled {
+		t.Errorf("GetStatus() = %s, want canceled", status)
+	}
+}
+
+func TestWorkflowRejectsInvalidDAG(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodes   []NodeConfig
+		wantErr string
+	}{
+		{
+			name: "两个节点循环依赖",
+			nodes: []NodeConfig{
+				{ID: "a", Pipeline: fakeConfig(), DependsOn: []string{"b"}},
+				{ID: "b", Pipeline: fakeConfig(), DependsOn: []string{"a"}},
+			},
+			wantErr: "存在循环依赖",
+		},
+		{
+			name: "三个节点循环依赖",
+			nodes: []NodeConfig{
+				{ID: "root", Pipeline: fakeConfig()},
+				{ID: "a", Pipeline: fakeConfig(), DependsOn: []string{"root", "c"}},
+				{ID: "b", Pipeline: fakeConfig(), DependsOn: []string{"a"}},
+				{ID: "c", Pipeline: fakeConfig(), DependsOn: []string{"b"}},
+			},
+			wantErr: "存在循环依赖",
+		},
+		{
+			name:    "依赖自身",
+			nodes:   []NodeConfig{{ID: "a", Pipeline: fakeConfig(), DependsOn: []string{"a"}}},
+			wantErr: "不能依赖自身",
+		},
+		{
+			name:    "依赖不存在的节点",
+			nodes:   []NodeConfig{{ID: "a", Pipeline: fakeConfig(), DependsOn: []string{"missing"}}},
+			wantErr: "依赖的节点 missing 不存在",
+		},
+		{
+			name: "节点ID重复",
+			nodes: []NodeConfig{
+				{ID: "a", Pipeline: fakeConfig()},
+				{ID: "a", Pipeline: fakeConfig()},
+			},
+			wantErr: "节点 a 已存在",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &WorkflowConfig{Name: "dag", Nodes: tt.nodes}
+			if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateConfig() error = %v, want containing %q", err, tt.wantErr)
+			}
+			if _, err := NewWorkflowFromConfig(newFakeFactory(), config); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewWorkflowFromConfig() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	factory := newFakeFactory()
+	Register(factory)
+
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "合法配置",
+			config: map[string]interface{}{
+				"nodes": []interface{}{
+					map[string]interface{}{"id": "sync", "pipeline": map[string]interface{}{"type": "fake"}},
+					map[string]interface{}{"id": "test", "pipeline": map[string]interface{}{"type": "fake"}, "depends_on": []interface{}{"sync"}},
+				},
+			},
+		},
+		{
+			name:    "缺少节点",
+			config:  map[string]interface{}{"nodes": []interface{}{}},
+			wantErr: true,
+		},
+		{
+			name: "未注册的节点类型",
+			config: map[string]interface{}{
+				"nodes": []interface{}{
+					map[string]interface{}{"id": "a", "pipeline": map[string]interface{}{"type": "unknown"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "循环依赖",
+			config: map[string]interface{}{
+				"nodes": []interface{}{
+					map[string]interface{}{"id": "a", "pipeline": map[string]interface{}{"type": "fake"}, "depends_on": []interface{}{"b"}},
+					map[string]interface{}{"id": "b", "pipeline": map[string]interface{}{"type": "fake"}, "depends_on": []interface{}{"a"}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, err := factory.Create(&core.PipelineConfig{Type: core.TypeWorkflow, Name: "wf", Config: tt.config})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			result, err := runner.Execute(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if len(result) != 2 {
+				t.Errorf("Execute() result = %v, want results of 2 nodes", result)
+			}
+		})
+	}
+}
+
+func mustAdd(t *testing.T, w *Workflow, id string, runner core.PipelineRunner, spec map[string]interface{}, dependsOn ...string) {
+	t.Helper()
+	if err := w.AddNode(id, runner, spec, dependsOn...); err != nil {
+		t.Fatalf("AddNode(%s) error = %v", id, err)
+	}
+}
+
+func fakeConfig() core.PipelineConfig {
+	return core.PipelineConfig{Type: "fake"}
+}
+
+// newFakeFactory 创建注册了 fake 节点类型的工厂
+func newFakeFactory() *core.DefaultPipelineFactory {
+	factory := core.NewPipelineFactory()
+	factory.Register("fake", func(config *core.PipelineConfig) (core.PipelineRunner, error) {
+		return newFakeRunner(config.Name, nil), nil
+	})
+	return factory
+}
@@ -0,0 +1,136 @@
+package workflow
+
+import (
+	"time"
+
+	"Storage/internal/components/pipeline/core"
+)
+
+const (
+	// UpstreamKey 下游节点spec中存放上游结果的键，值为 map[节点ID]结果
+	UpstreamKey = "upstream"
+)
+
+// workflowSpecSchema 工作流配置（PipelineConfig.Config）的 JSON Schema
+const workflowSpecSchema = `{
+  "type": "object",
+  "required": ["nodes"],
+  "additionalProperties": false,
+  "properties": {
+    "name": {"type": "string"},
+    "description": {"type": "string"},
+    "nodes": {
+      "type": "array",
+      "minItems": 1,
+      "items": {
+        "type": "object",
+        "required": ["id", "pipeline"],
+        "additionalProperties": false,
+        "properties": {
+          "id": {"type": "string", "minLength": 1},
+          "pipeline": {
+            "type": "object",
+            "required": ["type"],
+            "additionalProperties": false,
+            "properties": {
+              "type": {"type": "string", "minLength": 1},
+              "name": {"type": "string"},
+              "description": {"type": "string"},
+              "config": {"type": ["object", "null"]}
+            }
+          },
+          "depends_on": {"type": "array", "items": {"type": "string", "minLength": 1}},
+          "spec": {"type": ["object", "null"]}
+        }
+      }
+    }
+  }
+}`
+
+// taskNodeSchema 任务节点（core.TypeTask）管道配置的 JSON Schema
+const taskNodeSchema = `{
+  "type": "object",
+  "required": ["task_id"],
+  "additionalProperties": false,
+  "properties": {
+    "task_id": {"type": "string", "minLength": 1}
+  }
+}`
+
+// TaskNodeConfig 任务节点的管道配置
+type TaskNodeConfig struct {
+	// 节点执行的任务ID
+	TaskID string `json:"task_id"`
+}
+
+// NodeConfig 工作流节点配置
+type NodeConfig struct {
+	// 节点ID，工作流内唯一
+	ID string `json:"id"`
+
+	// 节点对应的管道配置
+	Pipeline core.PipelineConfig `json:"pipeline"`
+
+	// 依赖的上游节点ID
+	DependsOn []string `json:"depends_on,omitempty"`
+
+	// 节点静态spec，优先级高于上游结果
+	Spec map[string]interface{} `json:"spec,omitempty"`
+}
+
+// WorkflowConfig 工作流配置
+type WorkflowConfig struct {
+	// 工作流名称
+	Name string `json:"name"`
+
+	// 工作流描述
+	Description string `json:"description"`
+
+	// 节点列表
+	Nodes []NodeConfig `json:"nodes"`
+}
+
+// Node 工作流中的单个节点
+type Node struct {
+	// 节点ID
+	ID string
+
+	// 节点执行器
+	Runner core.PipelineRunner
+
+	// 依赖的上游节点ID
+	DependsOn []string
+
+	// 节点静态spec
+	Spec map[string]interface{}
+
+	// 节点状态
+	Status core.TaskStatus
+
+	// 开始时间
+	StartTime time.Time
+
+	// 结束时间
+	EndTime time.Time
+
+	// 执行结果
+	Result map[string]interface{}
+
+	// 错误信息
+	Error error
+
+	// 节点执行完成的信号
+	done chan struct{}
+}
+
+// NodeState 节点状态快照
+type NodeState struct {
+	ID        string                 `json:"id"`
+	DependsOn []string               `json:"depends_on,omitempty"`
+	Status    core.TaskStatus        `json:"status"`
+	Progress  float64                `json:"progress"`
+	StartTime time.Time              `json:"start_time,omitempty"`
+	EndTime   time.Time              `json:"end_time,omitempty"`
+	Result    map[string]interface{} `json:"result,omitempty"`
+	Error     string                 `json:"error,omitempty"`
+}
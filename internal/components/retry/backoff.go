@@ -0,0 +1,87 @@
+package retry
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Backoff 第 retry 次重试（从 1 开始）前的等待时间，已包含上限和抖动
+func (p Policy) Backoff(retry int) time.Duration {
+	if retry < 1 {
+		retry = 1
+	}
+	interval := p.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	maxInterval := p.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultMaxInterval
+	}
+
+	var wait time.Duration
+	switch p.Strategy {
+	case StrategyLinear:
+		wait = scale(interval, int64(retry), maxInterval)
+	case StrategyExponential:
+		wait = interval
+		for i := 1; i < retry && wait < maxInterval; i++ {
+			wait *= 2
+		}
+	case StrategyFibonacci:
+		wait = scale(interval, fibonacci(retry, int64(maxInterval/interval)+1), maxInterval)
+	case StrategyRandom:
+		min, max := p.RandomMin, p.RandomMax
+		if max <= 0 {
+			max = interval
+		}
+		if min < 0 || min > max {
+			min = 0
+		}
+		wait = min
+		if max > min {
+			wait += time.Duration(rand.Int63n(int64(max-min) + 1))
+		}
+		// 随机策略本身已经分散，不再叠加抖动
+		return wait
+	default:
+		wait = interval
+	}
+
+	if wait > maxInterval {
+		wait = maxInterval
+	}
+	return jitter(wait, p.Jitter)
+}
+
+// scale 计算 interval*n，超过上限时返回上限，避免溢出
+func scale(interval time.Duration, n int64, maxInterval time.Duration) time.Duration {
+	if n > int64(maxInterval/interval) {
+		return maxInterval
+	}
+	return interval * time.Duration(n)
+}
+
+// fibonacci 第 n 个斐波那契数（1, 1, 2, 3, 5...），超过 limit 时提前返回
+func fibonacci(n int, limit int64) int64 {
+	a, b := int64(1), int64(1)
+	for i := 2; i < n && a <= limit; i++ {
+		a, b = b, a+b
+	}
+	if n <= 2 {
+		return 1
+	}
+	return b
+}
+
+// jitter 在 ±ratio 范围内随机浮动
+func jitter(wait time.Duration, ratio float64) time.Duration {
+	if ratio <= 0 || wait <= 0 {
+		return wait
+	}
+	if ratio > 1 {
+		ratio = 1
+	}
+	delta := float64(wait) * ratio
+	return time.Duration(float64(wait) - delta + rand.Float64()*2*delta)
+}
@@ -0,0 +1,158 @@
+package retry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		// 第 1..n 次重试的等待时间
+		want []time.Duration
+	}{
+		{
+			name:   "默认固定间隔",
+			policy: Policy{},
+			want:   []time.Duration{time.Second, time.Second, time.Second},
+		},
+		{
+			name:   "固定间隔",
+			policy: Policy{Strategy: StrategyConstant, Interval: 3 * time.Second},
+			want:   []time.Duration{3 * time.Second, 3 * time.Second},
+		},
+		{
+			name:   "线性递增",
+			policy: Policy{Strategy: StrategyLinear, Interval: time.Second},
+			want:   []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second},
+		},
+		{
+			name:   "线性递增不超过上限",
+			policy: Policy{Strategy: StrategyLinear, Interval: time.Second, MaxInterval: 2500 * time.Millisecond},
+			want:   []time.Duration{time.Second, 2 * time.Second, 2500 * time.Millisecond, 2500 * time.Millisecond},
+		},
+		{
+			name:   "指数递增",
+			policy: Policy{Strategy: StrategyExponential, Interval: 100 * time.Millisecond},
+			want:   []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond},
+		},
+		{
+			name:   "指数递增不超过上限",
+			policy: Policy{Strategy: StrategyExponential, Interval: time.Second, MaxInterval: 5 * time.Second},
+			want:   []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second},
+		},
+		{
+			name:   "斐波那契递增",
+			policy: Policy{Strategy: StrategyFibonacci, Interval: time.Second},
+			want:   []time.Duration{time.Second, time.Second, 2 * time.Second, 3 * time.Second, 5 * time.Second, 8 * time.Second},
+		},
+		{
+			name:   "斐波那契递增不超过上限",
+			policy: Policy{Strategy: StrategyFibonacci, Interval: time.Second, MaxInterval: 4 * time.Second},
+			want:   []time.Duration{time.Second, time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second},
+		},
+		{
+			name:   "固定范围的随机间隔",
+			policy: Policy{Strategy: StrategyRandom, RandomMin: 2 * time.Second, RandomMax: 2 * time.Second},
+			want:   []time.Duration{2 * time.Second, 2 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, want := range tt.want {
+				if got := tt.policy.Backoff(i + 1); got != want {
+					t.Errorf("Backoff(%d) = %v, want %v", i+1, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBackoffLargeRetry(t *testing.T) {
+	// 重试次数很大时不溢出，返回上限
+	for _, strategy := range []Strategy{StrategyLinear, StrategyExponential, StrategyFibonacci} {
+		policy := Policy{Strategy: strategy, Interval: time.Second, MaxInterval: time.Minute}
+		if got := policy.Backoff(1000); got != time.Minute {
+			t.Errorf("%s Backoff(1000) = %v, want %v", strategy, got, time.Minute)
+		}
+	}
+}
+
+func TestBackoffRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   Policy
+		retry    int
+		min, max time.Duration
+	}{
+		{
+			name:   "随机间隔在范围内",
+			policy: Policy{Strategy: StrategyRandom, RandomMin: time.Second, RandomMax: 3 * time.Second},
+			retry:  1,
+			min:    time.Second,
+			max:    3 * time.Second,
+		},
+		{
+			name:   "未配置范围时在零到基础间隔之间",
+			policy: Policy{Strategy: StrategyRandom, Interval: 500 * time.Millisecond},
+			retry:  1,
+			min:    0,
+			max:    500 * time.Millisecond,
+		},
+		{
+			name:   "随机策略不叠加抖动",
+			policy: Policy{Strategy: StrategyRandom, RandomMin: time.Second, RandomMax: time.Second, Jitter: 1},
+			retry:  1,
+			min:    time.Second,
+			max:    time.Second,
+		},
+		{
+			name:   "抖动在比例范围内",
+			policy: Policy{Strategy: StrategyConstant, Interval: time.Second, Jitter: 0.2},
+			retry:  1,
+			min:    800 * time.Millisecond,
+			max:    1200 * time.Millisecond,
+		},
+		{
+			name:   "抖动叠加在上限之后",
+			policy: Policy{Strategy: StrategyExponential, Interval: time.Second, MaxInterval: 2 * time.Second, Jitter: 0.5},
+			retry:  10,
+			min:    time.Second,
+			max:    3 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 200; i++ {
+				if got := tt.policy.Backoff(tt.retry); got < tt.min || got > tt.max {
+					t.Fatalf("Backoff(%d) = %v, want in [%v, %v]", tt.retry, got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestParseStrategy(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   Strategy
+		wantOK bool
+	}{
+		{"", StrategyConstant, true},
+		{"constant", StrategyConstant, true},
+		{"linear_backoff", StrategyLinear, true},
+		{"exponential_backoff", StrategyExponential, true},
+		{"fibonacci_backoff", StrategyFibonacci, true},
+		{"random_backoff", StrategyRandom, true},
+		{"quadratic", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseStrategy(tt.input)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("ParseStrategy(%q) = %q, %v, want %q, %v", tt.input, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package retry
+
+import (
+	"context"
+	"time"
+)
+
+// Do 按策略执行 fn，失败且可重试时等待后重试
+// attempt 从 1 开始；ctx 结束时停止等待并返回 ctx 的错误；
+// 重试次数用尽时返回 *ExhaustedError，不可重试的错误原样返回
+func Do(ctx context.Context, policy Policy, fn func(ctx context.Context, attempt int) error) error {
+	_, err := DoValue(ctx, policy, func(ctx context.Context, attempt int) (struct{}, error) {
+		return struct{}{}, fn(ctx, attempt)
+	})
+	return err
+}
+
+// DoValue 与 Do 相同，返回最后一次执行的结果
+func DoValue[T any](ctx context.Context, policy Policy, fn func(ctx context.Context, attempt int) (T, error)) (T, error) {
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+
+	for attempt := 1; ; attempt++ {
+		value, err := fn(ctx, attempt)
+		if err == nil {
+			return value, nil
+		}
+		if ctx.Err() != nil || !retryable(err) {
+			return value, err
+		}
+		if attempt > policy.MaxRetries {
+			if policy.MaxRetries == 0 {
+				return value, err
+			}
+			return value, &ExhaustedError{Attempts: attempt, Err: err}
+		}
+
+		wait := policy.Backoff(attempt)
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, err, wait)
+		}
+		if err := Sleep(ctx, wait); err != nil {
+			return value, err
+		}
+	}
+}
+
+// Sleep 等待 d，ctx 结束时提前返回 ctx 的错误
+func Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,183 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+var (
+	errTemporary = errors.New("temporary")
+	errNotFound  = &StatusError{StatusCode: 404}
+)
+
+func TestDo(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		// 第 attempt 次执行返回的错误，超出长度时成功
+		errs         []error
+		wantAttempts int
+		wantErr      error
+		wantExhaust  bool
+	}{
+		{
+			name:         "首次成功",
+			policy:       Constant(3, time.Millisecond),
+			wantAttempts: 1,
+		},
+		{
+			name:         "重试后成功",
+			policy:       Constant(3, time.Millisecond),
+			errs:         []error{errTemporary, errTemporary},
+			wantAttempts: 3,
+		},
+		{
+			name:         "重试次数用尽",
+			policy:       Constant(2, time.Millisecond),
+			errs:         []error{errTemporary, errTemporary, errTemporary, errTemporary},
+			wantAttempts: 3,
+			wantErr:      errTemporary,
+			wantExhaust:  true,
+		},
+		{
+			name:         "不重试时原样返回错误",
+			policy:       None(),
+			errs:         []error{errTemporary},
+			wantAttempts: 1,
+			wantErr:      errTemporary,
+		},
+		{
+			name:         "不可重试的错误",
+			policy:       Constant(3, time.Millisecond),
+			errs:         []error{Permanent(errTemporary)},
+			wantAttempts: 1,
+			wantErr:      errTemporary,
+		},
+		{
+			name:         "不可重试的状态码",
+			policy:       Constant(3, time.Millisecond),
+			errs:         []error{errNotFound},
+			wantAttempts: 1,
+			wantErr:      errNotFound,
+		},
+		{
+			name:         "可重试的状态码",
+			policy:       Constant(3, time.Millisecond),
+			errs:         []error{&StatusError{StatusCode: 503}, &StatusError{StatusCode: 429}},
+			wantAttempts: 3,
+		},
+		{
+			name: "自定义错误分类",
+			policy: Policy{
+				MaxRetries: 3,
+				Interval:   time.Millisecond,
+				Retryable:  func(err error) bool { return false },
+			},
+			errs:         []error{errTemporary},
+			wantAttempts: 1,
+			wantErr:      errTemporary,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			err := Do(context.Background(), tt.policy, func(ctx context.Context, attempt int) error {
+				attempts++
+				if attempt != attempts {
+					t.Errorf("attempt = %d, want %d", attempt, attempts)
+				}
+				if attempt <= len(tt.errs) {
+					return tt.errs[attempt-1]
+				}
+				return nil
+			})
+
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+			var exhausted *ExhaustedError
+			if got := errors.As(err, &exhausted); got != tt.wantExhaust {
+				t.Errorf("Do() error = %v, exhausted %v, want %v", err, got, tt.wantExhaust)
+			}
+			if tt.wantExhaust && exhausted.Attempts != tt.wantAttempts {
+				t.Errorf("ExhaustedError.Attempts = %d, want %d", exhausted.Attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestDoOnRetry(t *testing.T) {
+	policy := Policy{MaxRetries: 3, Strategy: StrategyLinear, Interval: time.Millisecond}
+	var waits []time.Duration
+	policy.OnRetry = func(attempt int, err error, wait time.Duration) {
+		if attempt != len(waits)+1 {
+			t.Errorf("OnRetry attempt = %d, want %d", attempt, len(waits)+1)
+		}
+		waits = append(waits, wait)
+	}
+
+	Do(context.Background(), policy, func(ctx context.Context, attempt int) error {
+		return errTemporary
+	})
+	want := []time.Duration{time.Millisecond, 2 * time.Millisecond, 3 * time.Millisecond}
+	if fmt.Sprint(waits) != fmt.Sprint(want) {
+		t.Errorf("OnRetry waits = %v, want %v", waits, want)
+	}
+}
+
+func TestDoStopsOnContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	attempts := 0
+	start := time.Now()
+	err := Do(ctx, Constant(100, time.Hour), func(ctx context.Context, attempt int) error {
+		attempts++
+		return errTemporary
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Do() 在 ctx 结束后仍在等待: %v", elapsed)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"普通错误", errTemporary, true},
+		{"包装的普通错误", fmt.Errorf("请求失败: %w", errTemporary), true},
+		{"不可重试", Permanent(errTemporary), false},
+		{"包装的不可重试", fmt.Errorf("请求失败: %w", Permanent(errTemporary)), false},
+		{"取消", context.Canceled, false},
+		{"单次请求超时", context.DeadlineExceeded, true},
+		{"408", &StatusError{StatusCode: 408}, true},
+		{"429", &StatusError{StatusCode: 429}, true},
+		{"500", &StatusError{StatusCode: 500}, true},
+		{"501", &StatusError{StatusCode: 501}, false},
+		{"505", &StatusError{StatusCode: 505}, false},
+		{"400", &StatusError{StatusCode: 400}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
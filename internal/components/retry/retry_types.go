@@ -0,0 +1,71 @@
+package retry
+
+import (
+	"time"
+)
+
+// Strategy 重试间隔策略
+type Strategy string
+
+const (
+	// StrategyConstant 固定间隔
+	StrategyConstant Strategy = "constant"
+	// StrategyLinear 线性递增，第 n 次重试间隔为 n 倍基础间隔
+	StrategyLinear Strategy = "linear_backoff"
+	// StrategyExponential 指数递增，每次重试间隔翻倍
+	StrategyExponential Strategy = "exponential_backoff"
+	// StrategyFibonacci 按斐波那契数列递增：1, 1, 2, 3, 5... 倍基础间隔
+	StrategyFibonacci Strategy = "fibonacci_backoff"
+	// StrategyRandom 在 [RandomMin, RandomMax] 内随机选择间隔
+	StrategyRandom Strategy = "random_backoff"
+)
+
+const (
+	// DefaultInterval 未配置基础间隔时使用的间隔
+	DefaultInterval = time.Second
+	// DefaultMaxInterval 未配置间隔上限时，递增策略的间隔上限
+	DefaultMaxInterval = 5 * time.Minute
+)
+
+// Policy 重试策略
+// 零值表示不重试；MaxRetries 不含首次执行
+type Policy struct {
+	// 最大重试次数
+	MaxRetries int
+	// 间隔策略，为空时使用 StrategyConstant
+	Strategy Strategy
+	// 基础间隔，<=0 时使用 DefaultInterval
+	Interval time.Duration
+	// 间隔上限，<=0 时使用 DefaultMaxInterval
+	MaxInterval time.Duration
+	// random_backoff 策略的间隔范围，未配置时为 [0, Interval]
+	RandomMin time.Duration
+	RandomMax time.Duration
+	// 抖动比例，取值 [0, 1]，实际间隔在 ±Jitter 范围内随机浮动，避免集中重试
+	Jitter float64
+	// 判断错误是否可以重试，为空时使用 IsRetryable
+	Retryable func(err error) bool
+	// 每次重试前的回调，attempt 为即将开始的重试序号（从 1 开始）
+	OnRetry func(attempt int, err error, wait time.Duration)
+}
+
+// ParseStrategy 解析间隔策略，为空时返回 StrategyConstant
+func ParseStrategy(s string) (Strategy, bool) {
+	switch Strategy(s) {
+	case "":
+		return StrategyConstant, true
+	case StrategyConstant, StrategyLinear, StrategyExponential, StrategyFibonacci, StrategyRandom:
+		return Strategy(s), true
+	}
+	return "", false
+}
+
+// None 不重试的策略
+func None() Policy {
+	return Policy{}
+}
+
+// Constant 固定间隔重试
+func Constant(maxRetries int, interval time.Duration) Policy {
+	return Policy{MaxRetries: maxRetries, Strategy: StrategyConstant, Interval: interval}
+}
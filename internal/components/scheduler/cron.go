@@ -0,0 +1,315 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// starBit 字段为 * 或 ? 时置位，用于日期与星期的匹配规则
+const starBit = 1 << 63
+
+// Schedule 解析后的 cron 表达式
+// 支持 5 段（分 时 日 月 周）和 6 段（秒 分 时 日 月 周）格式、
+// CRON_TZ=/TZ= 时区前缀，以及 @daily、@every 1h 等描述符
+type Schedule struct {
+	second, minute, hour, dom, month, dow uint64
+	// @every 的固定间隔，非 0 时忽略其他字段
+	every    time.Duration
+	location *time.Location
+}
+
+type bounds struct {
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	secondBounds = bounds{0, 59, nil}
+	minuteBounds = bounds{0, 59, nil}
+	hourBounds   = bounds{0, 23, nil}
+	domBounds    = bounds{1, 31, nil}
+	monthBounds  = bounds{1, 12, map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 星期中 7 与 0 均表示周日
+	dowBounds = bounds{0, 7, map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// ParseCron 解析 cron 表达式，表达式未通过 CRON_TZ= 指定时区时使用 loc
+func ParseCron(expr string, loc *time.Location) (*Schedule, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	spec := strings.TrimSpace(expr)
+	if spec == "" {
+		return nil, fmt.Errorf("cron表达式为空")
+	}
+
+	// 时区前缀
+	if strings.HasPrefix(spec, "CRON_TZ=") || strings.HasPrefix(spec, "TZ=") {
+		i := strings.Index(spec, " ")
+		if i < 0 {
+			return nil, fmt.Errorf("cron表达式缺少时区之后的字段: %s", expr)
+		}
+		name := spec[strings.Index(spec, "=")+1 : i]
+		tz, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("无效的时区 %s: %w", name, err)
+		}
+		loc = tz
+		spec = strings.TrimSpace(spec[i:])
+	}
+
+	if strings.HasPrefix(spec, "@every ") {
+		every, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil {
+			return nil, fmt.Errorf("无效的间隔 %s: %w", spec, err)
+		}
+		if every < time.Second {
+			return nil, fmt.Errorf("间隔不能小于1秒: %s", spec)
+		}
+		return &Schedule{every: every, location: loc}, nil
+	}
+	if strings.HasPrefix(spec, "@") {
+		full, ok := descriptors[strings.ToLower(spec)]
+		if !ok {
+			return nil, fmt.Errorf("不支持的描述符: %s", spec)
+		}
+		spec = full
+	}
+
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("cron表达式应为5段或6段, 实际为%d段: %s", len(fields), expr)
+	}
+
+	schedule := &Schedule{location: loc}
+	var err error
+	targets := []struct {
+		field *uint64
+		b     bounds
+		name  string
+	}{
+		{&schedule.second, secondBounds, "秒"},
+		{&schedule.minute, minuteBounds, "分"},
+		{&schedule.hour, hourBounds, "时"},
+		{&schedule.dom, domBounds, "日"},
+		{&schedule.month, monthBounds, "月"},
+		{&schedule.dow, dowBounds, "周"},
+	}
+	for i, target := range targets {
+		if *target.field, err = parseField(fields[i], target.b); err != nil {
+			return nil, fmt.Errorf("cron表达式%s字段无效: %w", target.name, err)
+		}
+	}
+	if schedule.dow&(1<<7) > 0 {
+		schedule.dow = schedule.dow&^(1<<7) | 1
+	}
+	return schedule, nil
+}
+
+// parseField 解析单个字段，支持 *、?、列表、范围和步长
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		v, err := parseRange(part, b)
+		if err != nil {
+			return 0, err
+		}
+		bits |= v
+	}
+	return bits, nil
+}
+
+func parseRange(expr string, b bounds) (uint64, error) {
+	var start, end, step uint
+	rangeAndStep := strings.Split(expr, "/")
+	if len(rangeAndStep) > 2 {
+		return 0, fmt.Errorf("步长格式错误: %s", expr)
+	}
+	lowAndHigh := strings.Split(rangeAndStep[0], "-")
+	star := false
+
+	if lowAndHigh[0] == "*" || lowAndHigh[0] == "?" {
+		if len(lowAndHigh) > 1 {
+			return 0, fmt.Errorf("范围格式错误: %s", expr)
+		}
+		start, end, star = b.min, b.max, true
+	} else {
+		var err error
+		if start, err = parseValue(lowAndHigh[0], b); err != nil {
+			return 0, err
+		}
+		switch len(lowAndHigh) {
+		case 1:
+			end = start
+		case 2:
+			if end, err = parseValue(lowAndHigh[1], b); err != nil {
+				return 0, err
+			}
+		default:
+			return 0, fmt.Errorf("范围格式错误: %s", expr)
+		}
+	}
+
+	step = 1
+	if len(rangeAndStep) == 2 {
+		n, err := strconv.ParseUint(rangeAndStep[1], 10, 32)
+		if err != nil || n == 0 {
+			return 0, fmt.Errorf("步长应为正整数: %s", expr)
+		}
+		step = uint(n)
+		// 形如 5/10 表示从 5 开始到上限
+		if len(lowAndHigh) == 1 && !star {
+			end = b.max
+		}
+		if step > 1 {
+			star = false
+		}
+	}
+
+	if start < b.min || end > b.max || start > end {
+		return 0, fmt.Errorf("取值超出范围[%d, %d]: %s", b.min, b.max, expr)
+	}
+
+	var bits uint64
+	for i := start; i <= end; i += step {
+		bits |= 1 << i
+	}
+	if star {
+		bits |= starBit
+	}
+	return bits, nil
+}
+
+func parseValue(s string, b bounds) (uint, error) {
+	if b.names != nil {
+		if v, ok := b.names[strings.ToLower(s)]; ok {
+			return v, nil
+		}
+	}
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("无效的取值: %s", s)
+	}
+	return uint(n), nil
+}
+
+// Location 调度使用的时区
+func (s *Schedule) Location() *time.Location {
+	return s.location
+}
+
+// Next 返回 t 之后的下一次触发时间，五年内没有匹配时返回零值
+func (s *Schedule) Next(t time.Time) time.Time {
+	if s.every > 0 {
+		return t.Truncate(time.Second).Add(s.every)
+	}
+
+	origLocation := t.Location()
+	t = t.In(s.location)
+	// 从下一整秒开始查找
+	t = t.Add(time.Second - time.Duration(t.Nanosecond()))
+
+	added := false
+	yearLimit := t.Year() + 5
+
+WRAP:
+	if t.Year() > yearLimit {
+		return time.Time{}
+	}
+
+	for 1<<uint(t.Month())&s.month == 0 {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, s.location)
+		}
+		t = t.AddDate(0, 1, 0)
+		if t.Month() == time.January {
+			goto WRAP
+		}
+	}
+
+	for !s.dayMatches(t) {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.location)
+		}
+		t = t.AddDate(0, 0, 1)
+		// 夏令时切换可能使零点不存在，校正到当天零点附近
+		if t.Hour() != 0 {
+			if t.Hour() > 12 {
+				t = t.Add(time.Duration(24-t.Hour()) * time.Hour)
+			} else {
+				t = t.Add(time.Duration(-t.Hour()) * time.Hour)
+			}
+		}
+		if t.Day() == 1 {
+			goto WRAP
+		}
+	}
+
+	for 1<<uint(t.Hour())&s.hour == 0 {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, s.location)
+		}
+		t = t.Add(time.Hour)
+		if t.Hour() == 0 {
+			goto WRAP
+		}
+	}
+
+	for 1<<uint(t.Minute())&s.minute == 0 {
+		if !added {
+			added = true
+			t = t.Truncate(time.Minute)
+		}
+		t = t.Add(time.Minute)
+		if t.Minute() == 0 {
+			goto WRAP
+		}
+	}
+
+	for 1<<uint(t.Second())&s.second == 0 {
+		if !added {
+			added = true
+			t = t.Truncate(time.Second)
+		}
+		t = t.Add(time.Second)
+		if t.Second() == 0 {
+			goto WRAP
+		}
+	}
+
+	return t.In(origLocation)
+}
+
+// dayMatches 日与星期均有限定时满足其一即可，否则两者都需满足
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := 1<<uint(t.Day())&s.dom > 0
+	dowMatch := 1<<uint(t.Weekday())&s.dow > 0
+	if s.dom&starBit > 0 || s.dow&starBit > 0 {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,230 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func mustLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("LoadLocation(%s) error = %v", name, err)
+	}
+	return loc
+}
+
+func TestParseCronErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{"空表达式", "  "},
+		{"段数不足", "* * * *"},
+		{"段数过多", "0 0 0 * * * *"},
+		{"秒超出范围", "60 * * * * *"},
+		{"分超出范围", "60 * * * *"},
+		{"日为0", "0 0 0 * *"},
+		{"月超出范围", "0 0 1 13 *"},
+		{"星期超出范围", "0 0 * * 8"},
+		{"反向范围", "0 10-5 * * *"},
+		{"步长为0", "*/0 * * * *"},
+		{"步长不是数字", "*/x * * * *"},
+		{"星号带范围", "*-5 * * * *"},
+		{"无效的名称", "0 0 * * funday"},
+		{"未知描述符", "@fortnightly"},
+		{"间隔小于1秒", "@every 500ms"},
+		{"无效的间隔", "@every soon"},
+		{"无效的时区", "CRON_TZ=Mars/Olympus 0 0 * * *"},
+		{"时区后缺少字段", "CRON_TZ=UTC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCron(tt.expr, time.UTC); err == nil {
+				t.Errorf("ParseCron(%q) error = nil, want error", tt.expr)
+			}
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	shanghai := mustLocation(t, "Asia/Shanghai")
+	newYork := mustLocation(t, "America/New_York")
+
+	tests := []struct {
+		name string
+		expr string
+		loc  *time.Location
+		from time.Time
+		want time.Time
+	}{
+		// 时区
+		{
+			name: "按默认时区计算",
+			expr: "0 9 * * *",
+			loc:  shanghai,
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "CRON_TZ 覆盖默认时区",
+			expr: "CRON_TZ=Asia/Shanghai 0 9 * * *",
+			loc:  time.UTC,
+			from: time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "TZ 前缀",
+			expr: "TZ=America/New_York 0 9 * * *",
+			loc:  time.UTC,
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "夏令时开始后按当地时间触发",
+			expr: "0 9 * * *",
+			loc:  newYork,
+			from: time.Date(2024, 3, 9, 15, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 3, 10, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "夏令时跳过的时间当天不触发",
+			expr: "30 2 * * *",
+			loc:  newYork,
+			from: time.Date(2024, 3, 9, 8, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 3, 11, 6, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "结果保留输入的时区",
+			expr: "0 9 * * *",
+			loc:  time.UTC,
+			from: time.Date(2024, 1, 1, 10, 0, 0, 0, shanghai),
+			want: time.Date(2024, 1, 1, 17, 0, 0, 0, shanghai),
+		},
+
+		// 日与星期
+		{
+			name: "日与星期都限定时满足日即可",
+			expr: "0 0 10 * 1",
+			loc:  time.UTC,
+			from: time.Date(2024, 10, 8, 0, 0, 0, 0, time.UTC), // 周二
+			want: time.Date(2024, 10, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "日与星期都限定时满足星期即可",
+			expr: "0 0 10 * 1",
+			loc:  time.UTC,
+			from: time.Date(2024, 10, 10, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 10, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "只限定日时跳过没有该日的月份",
+			expr: "0 0 31 * *",
+			loc:  time.UTC,
+			from: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "只限定星期",
+			expr: "0 0 * * MON-FRI",
+			loc:  time.UTC,
+			from: time.Date(2024, 10, 12, 0, 0, 0, 0, time.UTC), // 周六
+			want: time.Date(2024, 10, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "日为问号时只按星期匹配，7 表示周日",
+			expr: "0 0 ? * 7",
+			loc:  time.UTC,
+			from: time.Date(2024, 10, 8, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 10, 13, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "星期为问号时只按日匹配",
+			expr: "0 0 15 * ?",
+			loc:  time.UTC,
+			from: time.Date(2024, 10, 16, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 11, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "带步长的星期不视为星号",
+			expr: "0 0 1 * */2",
+			loc:  time.UTC,
+			from: time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), // 周二
+			want: time.Date(2024, 10, 3, 0, 0, 0, 0, time.UTC), // 周四，不是 1 日但满足星期
+		},
+		{
+			name: "闰日",
+			expr: "0 0 29 2 *",
+			loc:  time.UTC,
+			from: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "五年内没有匹配",
+			expr: "0 0 30 2 *",
+			loc:  time.UTC,
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Time{},
+		},
+
+		// 格式
+		{
+			name: "6 段表达式的秒",
+			expr: "*/15 * * * * *",
+			loc:  time.UTC,
+			from: time.Date(2024, 1, 1, 0, 0, 7, 0, time.UTC),
+			want: time.Date(2024, 1, 1, 0, 0, 15, 0, time.UTC),
+		},
+		{
+			name: "列表与月份名称",
+			expr: "0 0 1 jan,jul *",
+			loc:  time.UTC,
+			from: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "起点加步长",
+			expr: "5/20 * * * *",
+			loc:  time.UTC,
+			from: time.Date(2024, 1, 1, 0, 26, 0, 0, time.UTC),
+			want: time.Date(2024, 1, 1, 0, 45, 0, 0, time.UTC),
+		},
+		{
+			name: "@weekly",
+			expr: "@weekly",
+			loc:  time.UTC,
+			from: time.Date(2024, 10, 9, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 10, 13, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "@every",
+			expr: "@every 90s",
+			loc:  time.UTC,
+			from: time.Date(2024, 1, 1, 0, 0, 0, 500, time.UTC),
+			want: time.Date(2024, 1, 1, 0, 1, 30, 0, time.UTC),
+		},
+		{
+			name: "恰好在触发时间时返回下一次",
+			expr: "0 * * * *",
+			loc:  time.UTC,
+			from: time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCron(tt.expr, tt.loc)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) error = %v", tt.expr, err)
+			}
+			got := schedule.Next(tt.from)
+			if !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", tt.from, got, tt.want)
+			}
+			if !got.IsZero() && got.Location() != tt.from.Location() {
+				t.Errorf("Next() location = %v, want %v", got.Location(), tt.from.Location())
+			}
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"Storage/internal/components/lock"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// leaderKey 选主使用的锁名
+const leaderKey = "leader"
+
+// Scheduler 定时调度器
+// 多个副本通过任务锁选主，只有 leader 加载任务并按 cron 表达式触发执行；
+// 任务变更时递增共享版本号，leader 检测到变化后重新加载
+type Scheduler struct {
+	opts    Options
+	store   Store
+	elector *lock.Locker
+	load    TaskLoader
+	trigger Trigger
+
+	// 只在调度协程内访问
+	entries map[string]*entry
+
+	reload   chan struct{}
+	started  bool
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewScheduler 创建调度器，elector 用于副本间选主
+func NewScheduler(store Store, elector *lock.Locker, load TaskLoader, opts Options) *Scheduler {
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	if opts.KeyPrefix == "" {
+		opts.KeyPrefix = DefaultKeyPrefix
+	}
+	return &Scheduler{
+		opts:    opts,
+		store:   store,
+		elector: elector,
+		load:    load,
+		entries: make(map[string]*entry),
+		reload:  make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start 开始参与选主并调度，trigger 用于触发任务执行
+func (s *Scheduler) Start(trigger Trigger) {
+	s.trigger = trigger
+	s.started = true
+	go s.run()
+}
+
+// Stop 停止调度并释放 leader
+func (s *Scheduler) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+	if s.started {
+		<-s.done
+	}
+}
+
+// Notify 任务创建、更新或删除后调用，通知 leader 重新加载调度
+func (s *Scheduler) Notify(ctx context.Context) {
+	if _, err := s.store.Incr(ctx, s.versionKey()); err != nil {
+		logx.Errorf("更新调度版本失败: %v", err)
+	}
+	select {
+	case s.reload <- struct{}{}:
+	default:
+	}
+}
+
+// run 竞选 leader，失去 leader 后重新竞选
+func (s *Scheduler) run() {
+	defer close(s.done)
+
+	for {
+		lease := s.campaign()
+		if lease == nil {
+			return
+		}
+		logx.Infof("调度器 %s 成为 leader", s.opts.Owner)
+		s.lead(lease)
+		if err := lease.Release(context.Background()); err != nil {
+			logx.Errorf("释放调度 leader 失败: %v", err)
+		}
+
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+	}
+}
+
+// campaign 定期尝试成为 leader，停止时返回 nil
+func (s *Scheduler) campaign() *lock.Lease {
+	ticker := time.NewTicker(s.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		lease, err := s.elector.TryAcquire(context.Background(), leaderKey, s.opts.Owner)
+		if err == nil {
+			return lease
+		}
+		if !lock.IsLocked(err) {
+			logx.Errorf("调度器竞选 leader 失败: %v", err)
+		}
+
+		select {
+		case <-s.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// lead 作为 leader 调度，直到停止或失去 leader
+func (s *Scheduler) lead(lease *lock.Lease) {
+	ctx := context.Background()
+	// 新任期从共享状态重新计算，以便补跑错过的调度
+	s.entries = make(map[string]*entry)
+	version := s.version(ctx)
+	s.reloadEntries(ctx)
+
+	ticker := time.NewTicker(s.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		timer := time.NewTimer(s.nextWait())
+		select {
+		case <-s.stop:
+			timer.Stop()
+			return
+		case <-lease.Lost():
+			timer.Stop()
+			logx.Errorf("调度器 %s 失去 leader: %v", s.opts.Owner, lease.Err())
+			return
+		case <-s.reload:
+			version = s.version(ctx)
+			s.reloadEntries(ctx)
+		case <-ticker.C:
+			if v := s.version(ctx); v != version {
+				version = v
+				s.reloadEntries(ctx)
+			}
+		case <-timer.C:
+			s.fireDue(ctx)
+		}
+		timer.Stop()
+	}
+}
+
+// reloadEntries 重新加载任务，表达式未变化的任务保留原有的下次触发时间
+func (s *Scheduler) reloadEntries(ctx context.Context) {
+	tasks, err := s.load(ctx)
+	if err != nil {
+		logx.Errorf("加载定时任务失败: %v", err)
+		return
+	}
+
+	now := time.Now()
+	entries := make(map[string]*entry, len(tasks))
+	for _, task := range tasks {
+		expr, timezone, ok := cronOf(task)
+		if !ok {
+			continue
+		}
+
+		location := s.opts.Location
+		if timezone != "" {
+			if location, err = time.LoadLocation(timezone); err != nil {
+				logx.Errorf("任务 %s 的时区 %s 无效: %v", task.TaskId, timezone, err)
+				continue
+			}
+		}
+		schedule, err := ParseCron(expr, location)
+		if err != nil {
+			logx.Errorf("任务 %s 的cron表达式无效: %v", task.TaskId, err)
+			continue
+		}
+
+		e := &entry{
+			taskID:   task.TaskId,
+			spec:     fmt.Sprintf("%s|%s", expr, location),
+			schedule: schedule,
+		}
+		switch old, ok := s.entries[task.TaskId]; {
+		case ok && old.spec == e.spec:
+			e.next = old.next
+		case ok:
+			// 表达式变更后从现在开始计算，不补跑
+			e.next = schedule.Next(now)
+		default:
+			e.next = s.firstRun(ctx, e, now)
+		}
+		if e.next.IsZero() {
+			logx.Errorf("任务 %s 的cron表达式 %s 没有可触发的时间", task.TaskId, expr)
+			continue
+		}
+		entries[task.TaskId] = e
+	}
+
+	s.entries = entries
+	logx.Infof("调度器已加载 %d 个定时任务", len(entries))
+}
+
+// firstRun 计算任务首次调度时间：上次调度之后错过的触发在补跑窗口内时立即触发一次
+func (s *Scheduler) firstRun(ctx context.Context, e *entry, now time.Time) time.Time {
+	if s.opts.MaxCatchUp <= 0 {
+		return e.schedule.Next(now)
+	}
+
+	value, ok, err := s.store.Get(ctx, s.lastRunKey(e.taskID))
+	if err != nil || !ok {
+		return e.schedule.Next(now)
+	}
+	lastRun, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return e.schedule.Next(now)
+	}
+
+	missed := e.schedule.Next(lastRun)
+	if !missed.IsZero() && missed.Before(now) && now.Sub(missed) <= s.opts.MaxCatchUp {
+		logx.Infof("任务 %s 错过了 %s 的调度, 立即补跑", e.taskID, missed.Format(time.RFC3339))
+		return now
+	}
+	return e.schedule.Next(now)
+}
+
+// fireDue 触发已到期的任务并计算下次触发时间
+func (s *Scheduler) fireDue(ctx context.Context) {
+	now := time.Now()
+	for _, e := range s.entries {
+		if e.next.After(now) {
+			continue
+		}
+		scheduled := e.next
+		// 从当前时间计算，长时间阻塞后不会连续触发
+		e.next = e.schedule.Next(now)
+
+		// 先记录调度时间，避免 leader 切换后重复补跑
+		if err := s.store.Set(ctx, s.lastRunKey(e.taskID), scheduled.Format(time.RFC3339Nano), 0); err != nil {
+			logx.Errorf("记录任务 %s 的调度时间失败: %v", e.taskID, err)
+		}
+		go s.fire(e.taskID, scheduled)
+	}
+}
+
+func (s *Scheduler) fire(taskID string, scheduled time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), triggerTimeout)
+	defer cancel()
+
+	logx.Infof("定时触发任务 %s (计划时间 %s)", taskID, scheduled.Format(time.RFC3339))
+	if err := s.trigger(ctx, taskID); err != nil {
+		logx.Errorf("定时触发任务 %s 失败: %v", taskID, err)
+	}
+}
+
+// nextWait 距最近一次触发的时长，没有任务时等待一个轮询间隔
+func (s *Scheduler) nextWait() time.Duration {
+	var earliest time.Time
+	for _, e := range s.entries {
+		if earliest.IsZero() || e.next.Before(earliest) {
+			earliest = e.next
+		}
+	}
+	if earliest.IsZero() {
+		return s.opts.PollInterval
+	}
+	if wait := time.Until(earliest); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// version 任务变更版本号
+func (s *Scheduler) version(ctx context.Context) string {
+	value, _, err := s.store.Get(ctx, s.versionKey())
+	if err != nil {
+		logx.Errorf("获取调度版本失败: %v", err)
+	}
+	return value
+}
+
+func (s *Scheduler) versionKey() string {
+	return s.opts.KeyPrefix + ":version"
+}
+
+func (s *Scheduler) lastRunKey(taskID string) string {
+	return fmt.Sprintf("%s:last_run:%s", s.opts.KeyPrefix, taskID)
+}
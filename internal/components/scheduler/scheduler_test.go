@@ -0,0 +1,224 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Storage/internal/components/lock"
+	model "Storage/internal/model/task"
+	"Storage/storage"
+)
+
+func syncTask(id, cron, timezone string, auto bool) *model.Task {
+	return &model.Task{
+		TaskId: id,
+		Enable: true,
+		SyncSpec: &model.SyncTaskSpec{
+			Strategy: &storage.Strategy{Auto: auto, CronExpression: cron, Timezone: timezone},
+		},
+	}
+}
+
+func apiTask(id, cron, timezone string, enabled bool) *model.Task {
+	return &model.Task{
+		TaskId: id,
+		Enable: true,
+		APISpec: &model.APITaskSpec{
+			Strategy: model.TaskStrategy{
+				AutoExecute: &model.AutoExecuteSetting{Enabled: enabled, Cron: cron, Timezone: timezone},
+			},
+		},
+	}
+}
+
+func workflowTask(id, cron, timezone string, enabled bool) *model.Task {
+	return &model.Task{
+		TaskId: id,
+		Enable: true,
+		WorkflowSpec: &model.WorkflowTaskSpec{
+			Strategy: model.TaskStrategy{
+				AutoExecute: &model.AutoExecuteSetting{Enabled: enabled, Cron: cron, Timezone: timezone},
+			},
+		},
+	}
+}
+
+func TestCronOf(t *testing.T) {
+	disabled := syncTask("disabled", "0 * * * *", "", true)
+	disabled.Enable = false
+
+	tests := []struct {
+		name     string
+		task     *model.Task
+		wantExpr string
+		wantTZ   string
+		wantOK   bool
+	}{
+		{name: "空任务", task: nil},
+		{name: "任务未启用", task: disabled},
+		{name: "同步任务未开启自动执行", task: syncTask("t", "0 * * * *", "", false)},
+		{name: "同步任务未配置表达式", task: syncTask("t", "", "", true)},
+		{name: "同步任务", task: syncTask("t", "0 * * * *", "Asia/Shanghai", true), wantExpr: "0 * * * *", wantTZ: "Asia/Shanghai", wantOK: true},
+		{name: "API 任务未开启自动执行", task: apiTask("t", "0 0 * * *", "", false)},
+		{name: "API 任务", task: apiTask("t", "0 0 * * *", "UTC", true), wantExpr: "0 0 * * *", wantTZ: "UTC", wantOK: true},
+		{name: "工作流任务未开启自动执行", task: workflowTask("t", "0 1 * * *", "", false)},
+		{name: "工作流任务", task: workflowTask("t", "0 1 * * *", "UTC", true), wantExpr: "0 1 * * *", wantTZ: "UTC", wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, tz, ok := cronOf(tt.task)
+			if expr != tt.wantExpr || tz != tt.wantTZ || ok != tt.wantOK {
+				t.Errorf("cronOf() = %q, %q, %v, want %q, %q, %v", expr, tz, ok, tt.wantExpr, tt.wantTZ, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFirstRunCatchUp(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	schedule, err := ParseCron("0 * * * *", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		maxCatchUp time.Duration
+		// 上次调度时间，为零时不写入
+		lastRun time.Time
+		want    time.Time
+	}{
+		{
+			name:       "未开启补跑",
+			maxCatchUp: 0,
+			lastRun:    time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			want:       time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "没有调度记录",
+			maxCatchUp: time.Hour,
+			want:       time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "错过的调度在补跑窗口内",
+			maxCatchUp: time.Hour,
+			lastRun:    time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			want:       now,
+		},
+		{
+			name:       "多次错过只补跑一次",
+			maxCatchUp: 2 * time.Hour,
+			lastRun:    time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC),
+			want:       now,
+		},
+		{
+			name:       "错过的调度超出补跑窗口",
+			maxCatchUp: 10 * time.Minute,
+			lastRun:    time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			want:       time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "没有错过调度",
+			maxCatchUp: time.Hour,
+			lastRun:    time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+			want:       time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := lock.NewMemoryStore()
+			s := NewScheduler(store, nil, nil, Options{MaxCatchUp: tt.maxCatchUp})
+			if !tt.lastRun.IsZero() {
+				store.Set(ctx, s.lastRunKey("task"), tt.lastRun.Format(time.RFC3339Nano), 0)
+			}
+
+			got := s.firstRun(ctx, &entry{taskID: "task", schedule: schedule}, now)
+			if !got.Equal(tt.want) {
+				t.Errorf("firstRun() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReloadEntries(t *testing.T) {
+	ctx := context.Background()
+	tasks := []*model.Task{
+		syncTask("hourly", "0 * * * *", "", true),
+		apiTask("shanghai", "0 9 * * *", "Asia/Shanghai", true),
+		syncTask("invalid-cron", "61 * * * *", "", true),
+		apiTask("invalid-tz", "0 9 * * *", "Mars/Olympus", true),
+		syncTask("manual", "0 * * * *", "", false),
+	}
+	s := NewScheduler(lock.NewMemoryStore(), nil, func(ctx context.Context) ([]*model.Task, error) {
+		return tasks, nil
+	}, Options{Location: time.UTC})
+
+	s.reloadEntries(ctx)
+	if len(s.entries) != 2 || s.entries["hourly"] == nil || s.entries["shanghai"] == nil {
+		t.Fatalf("entries = %v, want hourly and shanghai", s.entries)
+	}
+	if got := s.entries["shanghai"].schedule.Location().String(); got != "Asia/Shanghai" {
+		t.Errorf("shanghai location = %s, want Asia/Shanghai", got)
+	}
+
+	// 表达式未变化时保留下次触发时间，变化时重新计算
+	kept := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.entries["hourly"].next = kept
+	s.entries["shanghai"].next = kept
+	tasks[1] = apiTask("shanghai", "0 10 * * *", "Asia/Shanghai", true)
+	s.reloadEntries(ctx)
+	if !s.entries["hourly"].next.Equal(kept) {
+		t.Errorf("hourly next = %v, want kept %v", s.entries["hourly"].next, kept)
+	}
+	if s.entries["shanghai"].next.Equal(kept) {
+		t.Error("shanghai next 未在表达式变更后重新计算")
+	}
+}
+
+func TestFireDue(t *testing.T) {
+	ctx := context.Background()
+	store := lock.NewMemoryStore()
+	schedule, err := ParseCron("0 * * * *", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fired := make(chan string, 2)
+	s := NewScheduler(store, nil, nil, Options{})
+	s.trigger = func(ctx context.Context, taskID string) error {
+		fired <- taskID
+		return nil
+	}
+
+	scheduled := time.Now().Add(-time.Minute).Truncate(time.Second)
+	s.entries = map[string]*entry{
+		"due":    {taskID: "due", schedule: schedule, next: scheduled},
+		"future": {taskID: "future", schedule: schedule, next: time.Now().Add(time.Hour)},
+	}
+	s.fireDue(ctx)
+
+	select {
+	case taskID := <-fired:
+		if taskID != "due" {
+			t.Errorf("fired %s, want due", taskID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("到期任务未触发")
+	}
+	select {
+	case taskID := <-fired:
+		t.Errorf("unexpected fire of %s", taskID)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if next := s.entries["due"].next; !next.After(time.Now()) {
+		t.Errorf("due next = %v, want in the future", next)
+	}
+	value, ok, _ := store.Get(ctx, s.lastRunKey("due"))
+	if !ok || value != scheduled.Format(time.RFC3339Nano) {
+		t.Errorf("last run = %q, want %q", value, scheduled.Format(time.RFC3339Nano))
+	}
+}
@@ -0,0 +1,79 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	model "Storage/internal/model/task"
+)
+
+const (
+	// DefaultPollInterval 默认选主与检查任务变更的间隔
+	DefaultPollInterval = 5 * time.Second
+	// DefaultKeyPrefix 默认调度状态键前缀
+	DefaultKeyPrefix = "storage:scheduler"
+	// triggerTimeout 单次触发执行的超时时间
+	triggerTimeout = 30 * time.Second
+)
+
+// Store 调度状态存储，多副本共享，用于记录任务变更版本和最近调度时间
+type Store interface {
+	Get(ctx context.Context, key string) (string, bool, error)
+	Set(ctx context.Context, key, value string, ttl time.Duration) error
+	Incr(ctx context.Context, key string) (int64, error)
+}
+
+// TaskLoader 加载已启用的任务
+type TaskLoader func(ctx context.Context) ([]*model.Task, error)
+
+// Trigger 触发一次任务执行
+type Trigger func(ctx context.Context, taskID string) error
+
+// Options 调度器配置
+type Options struct {
+	// 表达式未指定时区时使用的时区，为空时使用 time.Local
+	Location *time.Location
+	// 错过的调度在此时长内补跑一次（多次错过合并为一次），0 表示不补跑
+	MaxCatchUp time.Duration
+	// 选主与检查任务变更的间隔，<=0 时使用 DefaultPollInterval
+	PollInterval time.Duration
+	// 调度状态键前缀，为空时使用 DefaultKeyPrefix
+	KeyPrefix string
+	// 当前实例标识
+	Owner string
+}
+
+// entry 一个任务的调度
+type entry struct {
+	taskID string
+	// 表达式与时区，变化时重新计算下次触发时间
+	spec     string
+	schedule *Schedule
+	next     time.Time
+}
+
+// cronOf 获取任务的 cron 表达式和时区，未开启自动执行时返回 false
+func cronOf(task *model.Task) (expr, timezone string, ok bool) {
+	if task == nil || !task.Enable {
+		return "", "", false
+	}
+	if task.SyncSpec != nil && task.SyncSpec.Strategy != nil {
+		strategy := task.SyncSpec.Strategy
+		if strategy.Auto && strategy.CronExpression != "" {
+			return strategy.CronExpression, strategy.Timezone, true
+		}
+	}
+	if task.APISpec != nil && task.APISpec.Strategy.AutoExecute != nil {
+		auto := task.APISpec.Strategy.AutoExecute
+		if auto.Enabled && auto.Cron != "" {
+			return auto.Cron, auto.Timezone, true
+		}
+	}
+	if task.WorkflowSpec != nil && task.WorkflowSpec.Strategy.AutoExecute != nil {
+		auto := task.WorkflowSpec.Strategy.AutoExecute
+		if auto.Enabled && auto.Cron != "" {
+			return auto.Cron, auto.Timezone, true
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,157 @@
+package taskqueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-queue/kq"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/queue"
+)
+
+// forwardRetryInterval 延迟消息转回运行 topic 失败时的重试间隔
+const forwardRetryInterval = time.Second
+
+// KafkaQueue 基于 Kafka 的任务运行队列，同一任务的消息使用任务ID作为分区键
+// 配置延迟 topic 后，未到处理时间的消息投递到延迟 topic，到期后由延迟消费者转回运行 topic，
+// 转回成功后才确认，进程退出或崩溃时消息不会丢失
+type KafkaQueue struct {
+	pusher   *kq.Pusher
+	conf     kq.KqConf
+	consumer queue.MessageQueue
+
+	delayPusher   *kq.Pusher
+	delayConf     kq.KqConf
+	delayConsumer queue.MessageQueue
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewKafkaQueue 创建 Kafka 队列，conf 为空时只能投递不能消费（如死信队列）
+func NewKafkaQueue(pusher *kq.Pusher, conf *kq.KqConf) *KafkaQueue {
+	q := &KafkaQueue{pusher: pusher, stop: make(chan struct{})}
+	if conf != nil {
+		q.conf = *conf
+	}
+	return q
+}
+
+// SetDelayTopic 设置延迟 topic，需在 Start 之前调用
+// 延迟 topic 按投递顺序转发，消费者数决定同时等待的消息数，排在长延迟消息之后的消息会相应推迟
+func (q *KafkaQueue) SetDelayTopic(pusher *kq.Pusher, conf kq.KqConf) {
+	// 转回失败的消息不确认，由 Kafka 重新投递
+	conf.ForceCommit = false
+	q.delayPusher = pusher
+	q.delayConf = conf
+}
+
+// Publish 投递消息，未到处理时间的消息投递到延迟 topic（已配置时）
+func (q *KafkaQueue) Publish(ctx context.Context, msg *RunMessage) error {
+	pusher := q.pusher
+	if q.delayPusher != nil && msg.NotBefore.After(time.Now()) {
+		pusher = q.delayPusher
+	}
+	return push(ctx, pusher, msg)
+}
+
+// Start 开始消费
+func (q *KafkaQueue) Start(handler Handler) {
+	if len(q.conf.Brokers) == 0 {
+		logx.Errorf("任务运行队列 %s 未配置消费者", q.pusher.Name())
+		return
+	}
+	q.consumer = kq.MustNewQueue(q.conf, kq.WithHandle(func(ctx context.Context, key, value string) error {
+		msg, ok := decode(value)
+		if !ok {
+			return nil
+		}
+		if err := handler(ctx, msg); err != nil {
+			logx.Errorf("处理任务运行消息 %s 失败: %v", msg.ExecutionID, err)
+			return err
+		}
+		return nil
+	}))
+	go q.consumer.Start()
+
+	if q.delayPusher != nil {
+		q.delayConsumer = kq.MustNewQueue(q.delayConf, kq.WithHandle(func(ctx context.Context, key, value string) error {
+			msg, ok := decode(value)
+			if !ok {
+				return nil
+			}
+			return q.forward(ctx, msg)
+		}))
+		go q.delayConsumer.Start()
+	}
+}
+
+// forward 等待延迟消息到期后转回运行 topic，失败时重试，队列停止时返回 ErrStopped 且不确认
+func (q *KafkaQueue) forward(ctx context.Context, msg *RunMessage) error {
+	if wait := time.Until(msg.NotBefore); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-q.stop:
+			return ErrStopped
+		case <-timer.C:
+		}
+	}
+
+	for {
+		err := push(ctx, q.pusher, msg)
+		if err == nil {
+			return nil
+		}
+		logx.Errorf("任务 %s 的执行 %s 转回运行队列失败: %v", msg.TaskID, msg.ExecutionID, err)
+		select {
+		case <-q.stop:
+			return ErrStopped
+		case <-time.After(forwardRetryInterval):
+		}
+	}
+}
+
+// Stop 停止消费并关闭投递
+func (q *KafkaQueue) Stop() {
+	q.stopOnce.Do(func() { close(q.stop) })
+	if q.delayConsumer != nil {
+		q.delayConsumer.Stop()
+	}
+	if q.consumer != nil {
+		q.consumer.Stop()
+	}
+	if q.delayPusher != nil {
+		if err := q.delayPusher.Close(); err != nil {
+			logx.Errorf("关闭延迟队列 %s 失败: %v", q.delayPusher.Name(), err)
+		}
+	}
+	if err := q.pusher.Close(); err != nil {
+		logx.Errorf("关闭任务运行队列 %s 失败: %v", q.pusher.Name(), err)
+	}
+}
+
+// push 序列化消息并以任务ID为分区键投递
+func push(ctx context.Context, pusher *kq.Pusher, msg *RunMessage) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("序列化任务运行消息失败: %w", err)
+	}
+	if err := pusher.PushWithKey(ctx, msg.TaskID, string(body)); err != nil {
+		return fmt.Errorf("投递任务运行消息到 %s 失败: %w", pusher.Name(), err)
+	}
+	return nil
+}
+
+// decode 解析任务运行消息，无法解析的消息重试也不会成功，记录后丢弃
+func decode(value string) (*RunMessage, bool) {
+	var msg RunMessage
+	if err := json.Unmarshal([]byte(value), &msg); err != nil {
+		logx.Errorf("解析任务运行消息失败: %v, 消息: %s", err, value)
+		return nil, false
+	}
+	return &msg, true
+}
@@ -0,0 +1,210 @@
+package taskqueue
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// delayedRetryInterval 延迟消息到期但队列已满时，再次尝试转入队列的间隔
+const delayedRetryInterval = 100 * time.Millisecond
+
+// MemoryQueue 进程内任务运行队列，用于单实例部署和测试
+// 未到处理时间的消息（重试、重新投递）暂存在延迟堆中，到期后转入队列，不占用队列容量
+type MemoryQueue struct {
+	messages chan *RunMessage
+	workers  int
+
+	mu      sync.RWMutex
+	delayed delayHeap
+	wake    chan struct{}
+	stopped bool
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewMemoryQueue 创建进程内队列，size 为队列容量，workers 为消费协程数
+func NewMemoryQueue(size, workers int) *MemoryQueue {
+	if size <= 0 {
+		size = 1
+	}
+	if workers <= 0 {
+		workers = 1
+	}
+	return &MemoryQueue{
+		messages: make(chan *RunMessage, size),
+		workers:  workers,
+		wake:     make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Publish 投递消息，队列已满时返回 ErrQueueFull
+// NotBefore 晚于当前时间的消息进入延迟堆，已接收的消息重试时不会因队列已满而丢失
+func (q *MemoryQueue) Publish(ctx context.Context, msg *RunMessage) error {
+	// 复制一份，避免与处理中的消息共享
+	copied := *msg
+
+	if copied.NotBefore.After(time.Now()) {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		if q.stopped {
+			return ErrStopped
+		}
+		heap.Push(&q.delayed, &copied)
+		select {
+		case q.wake <- struct{}{}:
+		default:
+		}
+		return nil
+	}
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.stopped {
+		return ErrStopped
+	}
+	select {
+	case q.messages <- &copied:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Start 开始消费
+func (q *MemoryQueue) Start(handler Handler) {
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+		q.promote()
+	}()
+
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+			for {
+				select {
+				case <-q.stop:
+					return
+				case msg := <-q.messages:
+					if err := handler(context.Background(), msg); err != nil {
+						logx.Errorf("处理任务运行消息 %s 失败: %v", msg.ExecutionID, err)
+					}
+				}
+			}
+		}()
+	}
+}
+
+// promote 将到期的延迟消息转入队列，直到队列停止
+func (q *MemoryQueue) promote() {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		wait := q.promoteDue()
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		if wait > 0 {
+			timer.Reset(wait)
+		}
+
+		select {
+		case <-q.stop:
+			return
+		case <-q.wake:
+		case <-timer.C:
+		}
+	}
+}
+
+// promoteDue 转入所有到期的延迟消息，返回距下一次转入的等待时间，没有延迟消息时返回 0
+func (q *MemoryQueue) promoteDue() time.Duration {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.delayed.Len() > 0 {
+		next := q.delayed[0]
+		if wait := time.Until(next.NotBefore); wait > 0 {
+			return wait
+		}
+		select {
+		case q.messages <- next:
+			heap.Pop(&q.delayed)
+		default:
+			// 队列已满，消息保留在延迟堆中稍后再试
+			return delayedRetryInterval
+		}
+	}
+	return 0
+}
+
+// Stop 停止消费，未处理的消息（包括延迟消息）保留在队列中
+func (q *MemoryQueue) Stop() {
+	q.mu.Lock()
+	if q.stopped {
+		q.mu.Unlock()
+		return
+	}
+	q.stopped = true
+	close(q.stop)
+	q.mu.Unlock()
+	q.wg.Wait()
+}
+
+// Len 队列中未处理的消息数，包括未到处理时间的消息
+func (q *MemoryQueue) Len() int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return len(q.messages) + q.delayed.Len()
+}
+
+// Drain 取出队列中所有未处理的消息（包括未到处理时间的消息），用于查看死信队列
+func (q *MemoryQueue) Drain() []*RunMessage {
+	var messages []*RunMessage
+drain:
+	for {
+		select {
+		case msg := <-q.messages:
+			messages = append(messages, msg)
+		default:
+			break drain
+		}
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for q.delayed.Len() > 0 {
+		messages = append(messages, heap.Pop(&q.delayed).(*RunMessage))
+	}
+	return messages
+}
+
+// delayHeap 按 NotBefore 排序的延迟消息
+type delayHeap []*RunMessage
+
+func (h delayHeap) Len() int           { return len(h) }
+func (h delayHeap) Less(i, j int) bool { return h[i].NotBefore.Before(h[j].NotBefore) }
+func (h delayHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *delayHeap) Push(x interface{}) {
+	*h = append(*h, x.(*RunMessage))
+}
+
+func (h *delayHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	msg := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return msg
+}
@@ -0,0 +1,169 @@
+package taskqueue
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestMemoryQueuePublish(t *testing.T) {
+	ctx := context.Background()
+	queue := NewMemoryQueue(1, 1)
+
+	tests := []struct {
+		name    string
+		msg     *RunMessage
+		wantErr error
+		wantLen int
+	}{
+		{name: "投递成功", msg: &RunMessage{ExecutionID: "exec-1"}, wantLen: 1},
+		{name: "队列已满", msg: &RunMessage{ExecutionID: "exec-2"}, wantErr: ErrQueueFull, wantLen: 1},
+		{name: "延迟消息不受容量限制", msg: &RunMessage{ExecutionID: "exec-3", NotBefore: time.Now().Add(time.Minute)}, wantLen: 2},
+		{name: "过期的重试时间立即入队", msg: &RunMessage{ExecutionID: "exec-4", NotBefore: time.Now().Add(-time.Minute)}, wantErr: ErrQueueFull, wantLen: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := queue.Publish(ctx, tt.msg); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Publish() error = %v, want %v", err, tt.wantErr)
+			}
+			if n := queue.Len(); n != tt.wantLen {
+				t.Errorf("Len() = %d, want %d", n, tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestMemoryQueuePublishCopies(t *testing.T) {
+	queue := NewMemoryQueue(1, 1)
+	msg := &RunMessage{ExecutionID: "exec-1"}
+	if err := queue.Publish(context.Background(), msg); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	msg.Attempt = 5
+
+	drained := queue.Drain()
+	if len(drained) != 1 || drained[0].Attempt != 0 {
+		t.Errorf("Drain() = %+v, want a copy with Attempt 0", drained)
+	}
+}
+
+func TestMemoryQueueDelayed(t *testing.T) {
+	ctx := context.Background()
+	queue := NewMemoryQueue(10, 1)
+
+	start := time.Now()
+	delays := map[string]time.Duration{"late": 80 * time.Millisecond, "soon": 30 * time.Millisecond, "now": 0}
+	for id, delay := range delays {
+		msg := &RunMessage{ExecutionID: id}
+		if delay > 0 {
+			msg.NotBefore = start.Add(delay)
+		}
+		if err := queue.Publish(ctx, msg); err != nil {
+			t.Fatalf("Publish(%s) error = %v", id, err)
+		}
+	}
+
+	done := make(chan *RunMessage, len(delays))
+	queue.Start(func(ctx context.Context, msg *RunMessage) error {
+		done <- msg
+		return nil
+	})
+	defer queue.Stop()
+
+	// 按重试时间依次处理，且不早于重试时间
+	for _, want := range []string{"now", "soon", "late"} {
+		got := waitMessage(done, time.Second)
+		if got == nil || got.ExecutionID != want {
+			t.Fatalf("processed = %+v, want %s", got, want)
+		}
+		if elapsed := time.Since(start); elapsed < delays[want] {
+			t.Errorf("%s processed after %v, want >= %v", want, elapsed, delays[want])
+		}
+	}
+}
+
+func TestMemoryQueueDelayedWaitsForSpace(t *testing.T) {
+	ctx := context.Background()
+	queue := NewMemoryQueue(1, 1)
+
+	release := make(chan struct{})
+	done := make(chan *RunMessage, 3)
+	queue.Start(func(ctx context.Context, msg *RunMessage) error {
+		if msg.ExecutionID == "blocking" {
+			<-release
+		}
+		done <- msg
+		return nil
+	})
+	defer queue.Stop()
+
+	// 消费者阻塞在第一条消息，第二条消息占满队列
+	if err := queue.Publish(ctx, &RunMessage{ExecutionID: "blocking"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if err := queue.Publish(ctx, &RunMessage{ExecutionID: "queued"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if err := queue.Publish(ctx, &RunMessage{ExecutionID: "delayed", NotBefore: time.Now().Add(10 * time.Millisecond)}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	// 到期时队列已满，延迟消息保留而不是丢弃
+	time.Sleep(50 * time.Millisecond)
+	if n := queue.Len(); n != 2 {
+		t.Errorf("Len() = %d, want 2", n)
+	}
+
+	close(release)
+	for _, want := range []string{"blocking", "queued", "delayed"} {
+		got := waitMessage(done, time.Second)
+		if got == nil || got.ExecutionID != want {
+			t.Fatalf("processed = %+v, want %s", got, want)
+		}
+	}
+}
+
+func TestMemoryQueueStop(t *testing.T) {
+	ctx := context.Background()
+	queue := NewMemoryQueue(10, 2)
+	queue.Start(func(ctx context.Context, msg *RunMessage) error { return nil })
+	queue.Stop()
+	queue.Stop()
+
+	for _, msg := range []*RunMessage{
+		{ExecutionID: "exec-1"},
+		{ExecutionID: "exec-2", NotBefore: time.Now().Add(time.Minute)},
+	} {
+		if err := queue.Publish(ctx, msg); !errors.Is(err, ErrStopped) {
+			t.Errorf("Publish(%s) error = %v, want %v", msg.ExecutionID, err, ErrStopped)
+		}
+	}
+}
+
+func TestMemoryQueueDrain(t *testing.T) {
+	ctx := context.Background()
+	queue := NewMemoryQueue(10, 1)
+	for _, msg := range []*RunMessage{
+		{ExecutionID: "exec-1"},
+		{ExecutionID: "exec-2", NotBefore: time.Now().Add(time.Minute)},
+	} {
+		if err := queue.Publish(ctx, msg); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	var ids []string
+	for _, msg := range queue.Drain() {
+		ids = append(ids, msg.ExecutionID)
+	}
+	sort.Strings(ids)
+	if len(ids) != 2 || ids[0] != "exec-1" || ids[1] != "exec-2" {
+		t.Errorf("Drain() = %v, want [exec-1 exec-2]", ids)
+	}
+	if n := queue.Len(); n != 0 {
+		t.Errorf("Len() after Drain = %d, want 0", n)
+	}
+}
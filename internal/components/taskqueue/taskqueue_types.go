@@ -0,0 +1,107 @@
+package taskqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"Storage/internal/components/retry"
+)
+
+const (
+	// DefaultMaxAttempts 默认最大投递次数
+	DefaultMaxAttempts = 3
+	// DefaultRetryBackoff 默认重试间隔，随投递次数翻倍
+	DefaultRetryBackoff = 5 * time.Second
+	// maxRetryBackoff 重试间隔上限
+	maxRetryBackoff = 5 * time.Minute
+	// retryJitter 重试间隔的抖动比例，避免同时失败的消息集中重试
+	retryJitter = 0.1
+)
+
+var (
+	// ErrQueueFull 队列已满
+	ErrQueueFull = errors.New("任务运行队列已满")
+	// ErrStopped 队列已停止
+	ErrStopped = errors.New("任务运行队列已停止")
+)
+
+// RunMessage 任务运行消息
+type RunMessage struct {
+	// 执行ID，入队时生成，重试时保持不变
+	ExecutionID string `json:"execution_id"`
+	TaskID      string `json:"task_id"`
+	// 触发方式：manual/schedule
+	Trigger string `json:"trigger,omitempty"`
+	// 执行环境ID，为空时不使用执行环境
+	EnvironmentID string `json:"environment_id,omitempty"`
+	// 已投递次数，每次处理前加一
+	Attempt int `json:"attempt"`
+	// 最大投递次数，<=0 时使用消费端配置
+	MaxAttempts int `json:"max_attempts,omitempty"`
+	// 首次重试间隔，之后按指数递增，<=0 时使用消费端配置
+	RetryInterval time.Duration `json:"retry_interval,omitempty"`
+	// 重试消息在此时间之前不处理
+	NotBefore time.Time `json:"not_before,omitempty"`
+	// 最近一次处理失败的原因
+	LastError   string    `json:"last_error,omitempty"`
+	EnqueueTime time.Time `json:"enqueue_time"`
+}
+
+// Handler 处理任务运行消息，返回错误时按重试策略重新投递
+type Handler func(ctx context.Context, msg *RunMessage) error
+
+// Publisher 投递任务运行消息
+type Publisher interface {
+	Publish(ctx context.Context, msg *RunMessage) error
+}
+
+// Queue 任务运行队列
+type Queue interface {
+	Publisher
+	// Start 开始消费，消息交给 handler 处理
+	Start(handler Handler)
+	// Stop 停止消费
+	Stop()
+}
+
+// Store 入队状态存储，多实例共享，用于取消尚未开始的执行
+type Store interface {
+	Get(ctx context.Context, key string) (string, bool, error)
+	Set(ctx context.Context, key, value string, ttl time.Duration) error
+	Release(ctx context.Context, key, value string) (bool, error)
+}
+
+// Permanent 标记错误不可重试，消息直接转入死信队列
+func Permanent(err error) error {
+	return retry.Permanent(err)
+}
+
+// IsPermanent 是否为不可重试的错误
+func IsPermanent(err error) bool {
+	return retry.IsPermanent(err)
+}
+
+// requeueError 消息暂时无法处理，延迟后重新投递
+type requeueError struct {
+	after time.Duration
+}
+
+func (e *requeueError) Error() string {
+	return fmt.Sprintf("消息将在 %s 后重新投递", e.after)
+}
+
+// Requeue 标记消息暂时无法处理（如等待任务锁），延迟 after 后重新投递，不计入投递次数
+func Requeue(after time.Duration) error {
+	return &requeueError{after: after}
+}
+
+// RequeueAfter 错误为 Requeue 标记时返回重新投递的延迟
+func RequeueAfter(err error) (time.Duration, bool) {
+	var requeue *requeueError
+	if !errors.As(err, &requeue) {
+		return 0, false
+	}
+	return requeue.after, true
+}
@@ -0,0 +1,78 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultKeyPrefix 默认入队记录键前缀
+	DefaultKeyPrefix = "storage:task_run"
+	// DefaultPendingTTL 入队记录的保留时长
+	DefaultPendingTTL = 24 * time.Hour
+)
+
+// Tracker 记录已入队但尚未开始的执行，支持跨实例取消
+type Tracker struct {
+	store     Store
+	keyPrefix string
+	ttl       time.Duration
+}
+
+// NewTracker 创建入队记录
+func NewTracker(store Store, keyPrefix string, ttl time.Duration) *Tracker {
+	if ttl <= 0 {
+		ttl = DefaultPendingTTL
+	}
+	return &Tracker{store: store, keyPrefix: keyPrefix, ttl: ttl}
+}
+
+// MarkPending 记录执行已入队
+func (t *Tracker) MarkPending(ctx context.Context, executionID, taskID string) error {
+	return t.store.Set(ctx, t.pendingKey(executionID), taskID, t.ttl)
+}
+
+// Reopen 重新记录已结束的执行入队，清除之前的取消标记
+func (t *Tracker) Reopen(ctx context.Context, executionID, taskID string) error {
+	if _, err := t.store.Release(ctx, t.canceledKey(executionID), "1"); err != nil {
+		return err
+	}
+	return t.MarkPending(ctx, executionID, taskID)
+}
+
+// Cancel 取消尚未开始的执行，执行不在队列中时返回 false
+func (t *Tracker) Cancel(ctx context.Context, executionID string) (bool, error) {
+	if _, ok, err := t.store.Get(ctx, t.pendingKey(executionID)); err != nil || !ok {
+		return false, err
+	}
+	if err := t.store.Set(ctx, t.canceledKey(executionID), "1", t.ttl); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Canceled 执行是否已在队列中被取消
+func (t *Tracker) Canceled(ctx context.Context, executionID string) (bool, error) {
+	_, ok, err := t.store.Get(ctx, t.canceledKey(executionID))
+	return ok, err
+}
+
+// Started 执行已开始，移除入队记录
+func (t *Tracker) Started(ctx context.Context, executionID string) error {
+	key := t.pendingKey(executionID)
+	value, ok, err := t.store.Get(ctx, key)
+	if err != nil || !ok {
+		return err
+	}
+	_, err = t.store.Release(ctx, key, value)
+	return err
+}
+
+func (t *Tracker) pendingKey(executionID string) string {
+	return fmt.Sprintf("%s:pending:%s", t.keyPrefix, executionID)
+}
+
+func (t *Tracker) canceledKey(executionID string) string {
+	return fmt.Sprintf("%s:canceled:%s", t.keyPrefix, executionID)
+}
@@ -0,0 +1,114 @@
+package taskqueue
+
+import (
+	"context"
+	"time"
+
+	"Storage/internal/components/retry"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// Options 消费端重试配置
+type Options struct {
+	// 最大投递次数，<=0 时使用 DefaultMaxAttempts
+	MaxAttempts int
+	// 首次重试间隔，之后每次翻倍，<=0 时使用 DefaultRetryBackoff
+	RetryBackoff time.Duration
+	// 消息转入死信队列前的回调，用于记录最终失败
+	OnDeadLetter func(ctx context.Context, msg *RunMessage, err error)
+	// 入队记录，重新投递前记录执行已入队，等待重试期间可以取消
+	Tracker *Tracker
+}
+
+// Worker 消费任务运行消息
+// 处理失败时设置重试时间后重新投递，由队列在到期前暂存（内存队列的延迟堆、Kafka 的延迟 topic）；
+// 不可重试或超过最大投递次数时转入死信队列。重新投递成功后才确认当前消息，进程崩溃时重试不会丢失
+type Worker struct {
+	queue      Publisher
+	deadLetter Publisher
+	handler    Handler
+	opts       Options
+	policy     retry.Policy
+}
+
+// NewWorker 创建消费者，queue 用于重新入队，deadLetter 接收最终失败的消息
+func NewWorker(queue, deadLetter Publisher, handler Handler, opts Options) *Worker {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultMaxAttempts
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = DefaultRetryBackoff
+	}
+	return &Worker{
+		queue:      queue,
+		deadLetter: deadLetter,
+		handler:    handler,
+		opts:       opts,
+		policy: retry.Policy{
+			Strategy:    retry.StrategyExponential,
+			Interval:    opts.RetryBackoff,
+			MaxInterval: maxRetryBackoff,
+			Jitter:      retryJitter,
+		},
+	}
+}
+
+// Handle 处理一条消息，只有重新入队或转入死信失败时才返回错误
+func (w *Worker) Handle(ctx context.Context, msg *RunMessage) error {
+	// 提前投递的重试消息（如 Kafka 未配置延迟 topic）重新投递，由队列暂存到重试时间
+	if time.Until(msg.NotBefore) > 0 {
+		return w.queue.Publish(ctx, msg)
+	}
+
+	msg.Attempt++
+	err := w.handler(ctx, msg)
+	if err == nil {
+		return nil
+	}
+	// 暂时无法处理的消息重新投递到队尾，不占用消费者等待，也不计入投递次数
+	if after, ok := RequeueAfter(err); ok {
+		msg.Attempt--
+		msg.NotBefore = time.Now().Add(after)
+		return w.queue.Publish(ctx, msg)
+	}
+	return w.Retry(ctx, msg, err)
+}
+
+// Retryable 处理失败的消息是否还会重新入队，不可重试或已达最大投递次数时返回 false
+func (w *Worker) Retryable(msg *RunMessage, err error) bool {
+	maxAttempts := msg.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = w.opts.MaxAttempts
+	}
+	return !IsPermanent(err) && msg.Attempt < maxAttempts
+}
+
+// Retry 处理失败后按重试策略延迟重新入队，不可重试时转入死信队列
+// 消息提交执行后在执行中失败时，由执行方调用以复用消费端的重试策略
+func (w *Worker) Retry(ctx context.Context, msg *RunMessage, err error) error {
+	msg.LastError = err.Error()
+	if !w.Retryable(msg, err) {
+		logx.Errorf("任务 %s 的执行 %s 第 %d 次处理失败, 转入死信队列: %v", msg.TaskID, msg.ExecutionID, msg.Attempt, err)
+		if w.opts.OnDeadLetter != nil {
+			w.opts.OnDeadLetter(ctx, msg, err)
+		}
+		return w.deadLetter.Publish(ctx, msg)
+	}
+
+	policy := w.policy
+	if msg.RetryInterval > 0 {
+		policy.Interval = msg.RetryInterval
+	}
+	msg.NotBefore = time.Now().Add(policy.Backoff(msg.Attempt))
+
+	// 先记录入队再投递，重试消息投递后立即可以取消，取消后到期的消息由消费者跳过
+	if w.opts.Tracker != nil {
+		if err := w.opts.Tracker.MarkPending(ctx, msg.ExecutionID, msg.TaskID); err != nil {
+			logx.Errorf("记录执行 %s 入队失败, 等待重试期间无法取消: %v", msg.ExecutionID, err)
+		}
+	}
+	logx.Errorf("任务 %s 的执行 %s 第 %d 次处理失败, %s 后重试: %v",
+		msg.TaskID, msg.ExecutionID, msg.Attempt, msg.NotBefore.Format(time.RFC3339), err)
+	return w.queue.Publish(ctx, msg)
+}
@@ -0,0 +1,318 @@
+package taskqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"Storage/internal/components/lock"
+)
+
+// recordPublisher 记录投递的消息，err 不为空时投递失败
+type recordPublisher struct {
+	mu       sync.Mutex
+	messages []RunMessage
+	err      error
+}
+
+func (p *recordPublisher) Publish(ctx context.Context, msg *RunMessage) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	p.messages = append(p.messages, *msg)
+	return nil
+}
+
+func (p *recordPublisher) published() []RunMessage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]RunMessage(nil), p.messages...)
+}
+
+// waitMessage 等待处理完成的消息，超时返回 nil
+func waitMessage(ch <-chan *RunMessage, timeout time.Duration) *RunMessage {
+	select {
+	case msg := <-ch:
+		return msg
+	case <-time.After(timeout):
+		return nil
+	}
+}
+
+func TestWorkerRequeueDoesNotBlockOtherTasks(t *testing.T) {
+	ctx := context.Background()
+	locker := lock.NewLocker(lock.NewMemoryStore(), lock.Options{
+		TTL:           time.Second,
+		RetryInterval: 10 * time.Millisecond,
+		Owner:         "test",
+	})
+	running, err := locker.TryAcquire(ctx, "task-a", "running")
+	if err != nil {
+		t.Fatalf("TryAcquire() error = %v", err)
+	}
+
+	// 与 queue 策略一致：锁被占用时延迟重新入队，而不是在消费者中等待
+	done := make(chan *RunMessage, 2)
+	handler := func(ctx context.Context, msg *RunMessage) error {
+		lease, err := locker.TryAcquire(ctx, msg.TaskID, msg.ExecutionID)
+		if lock.IsLocked(err) {
+			return Requeue(locker.RetryInterval())
+		}
+		if err != nil {
+			return err
+		}
+		defer lease.Release(ctx)
+		copied := *msg
+		done <- &copied
+		return nil
+	}
+
+	// 只有一个消费协程，等待锁的消息不能阻塞其他任务
+	queue := NewMemoryQueue(16, 1)
+	deadLetter := NewMemoryQueue(16, 1)
+	worker := NewWorker(queue, deadLetter, handler, Options{})
+	queue.Start(worker.Handle)
+	defer queue.Stop()
+
+	for _, msg := range []*RunMessage{
+		{ExecutionID: "exec-a", TaskID: "task-a"},
+		{ExecutionID: "exec-b", TaskID: "task-b"},
+	} {
+		if err := queue.Publish(ctx, msg); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	got := waitMessage(done, time.Second)
+	if got == nil || got.ExecutionID != "exec-b" {
+		t.Fatalf("first processed = %+v, want exec-b while task-a is locked", got)
+	}
+
+	running.Release(ctx)
+	got = waitMessage(done, time.Second)
+	if got == nil || got.ExecutionID != "exec-a" {
+		t.Fatalf("processed after release = %+v, want exec-a", got)
+	}
+	if got.Attempt != 1 {
+		t.Errorf("Attempt = %d, want 1 (requeue is not an attempt)", got.Attempt)
+	}
+	if n := deadLetter.Len(); n != 0 {
+		t.Errorf("deadLetter.Len() = %d, want 0", n)
+	}
+}
+
+func TestWorkerHandle(t *testing.T) {
+	transient := errors.New("连接失败")
+
+	tests := []struct {
+		name       string
+		msg        RunMessage
+		handlerErr error
+		// 期望的处理器调用次数
+		wantCalls int
+		// 期望重新投递到运行队列和死信队列的消息数
+		wantRequeued   int
+		wantDeadLetter int
+		// 期望的投递次数
+		wantAttempt int
+		// 重新投递时的期望延迟范围
+		minDelay, maxDelay time.Duration
+	}{
+		{name: "处理成功", wantCalls: 1, wantAttempt: 1},
+		{
+			name:         "失败后延迟重试",
+			handlerErr:   transient,
+			wantCalls:    1,
+			wantRequeued: 1,
+			wantAttempt:  1,
+			minDelay:     85 * time.Millisecond,
+			maxDelay:     120 * time.Millisecond,
+		},
+		{
+			name:         "消息的重试间隔覆盖消费端配置",
+			msg:          RunMessage{RetryInterval: time.Second},
+			handlerErr:   transient,
+			wantCalls:    1,
+			wantRequeued: 1,
+			wantAttempt:  1,
+			minDelay:     850 * time.Millisecond,
+			maxDelay:     1200 * time.Millisecond,
+		},
+		{
+			name:         "重试间隔按投递次数递增",
+			msg:          RunMessage{Attempt: 1},
+			handlerErr:   transient,
+			wantCalls:    1,
+			wantRequeued: 1,
+			wantAttempt:  2,
+			minDelay:     170 * time.Millisecond,
+			maxDelay:     240 * time.Millisecond,
+		},
+		{name: "不可重试的错误转入死信", handlerErr: Permanent(transient), wantCalls: 1, wantDeadLetter: 1, wantAttempt: 1},
+		{name: "达到最大投递次数转入死信", msg: RunMessage{Attempt: 2}, handlerErr: transient, wantCalls: 1, wantDeadLetter: 1, wantAttempt: 3},
+		{name: "消息的最大投递次数覆盖消费端配置", msg: RunMessage{Attempt: 2, MaxAttempts: 5}, handlerErr: transient, wantCalls: 1, wantRequeued: 1, wantAttempt: 3, minDelay: 300 * time.Millisecond, maxDelay: 500 * time.Millisecond},
+		{
+			name:         "暂时无法处理时重新投递且不计入投递次数",
+			handlerErr:   Requeue(50 * time.Millisecond),
+			wantCalls:    1,
+			wantRequeued: 1,
+			wantAttempt:  0,
+			minDelay:     40 * time.Millisecond,
+			maxDelay:     60 * time.Millisecond,
+		},
+		{
+			name:         "提前投递的消息不处理直接重新投递",
+			msg:          RunMessage{Attempt: 1, NotBefore: time.Now().Add(time.Minute)},
+			wantRequeued: 1,
+			wantAttempt:  1,
+			minDelay:     50 * time.Second,
+			maxDelay:     time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queue, deadLetter := &recordPublisher{}, &recordPublisher{}
+			calls, deadLetterCalls := 0, 0
+			worker := NewWorker(queue, deadLetter, func(ctx context.Context, msg *RunMessage) error {
+				calls++
+				return tt.handlerErr
+			}, Options{
+				RetryBackoff: 100 * time.Millisecond,
+				OnDeadLetter: func(ctx context.Context, msg *RunMessage, err error) { deadLetterCalls++ },
+			})
+
+			msg := tt.msg
+			msg.ExecutionID, msg.TaskID = "exec-1", "task-1"
+			start := time.Now()
+			if err := worker.Handle(context.Background(), &msg); err != nil {
+				t.Fatalf("Handle() error = %v", err)
+			}
+
+			if calls != tt.wantCalls {
+				t.Errorf("handler calls = %d, want %d", calls, tt.wantCalls)
+			}
+			if msg.Attempt != tt.wantAttempt {
+				t.Errorf("Attempt = %d, want %d", msg.Attempt, tt.wantAttempt)
+			}
+			requeued, dead := queue.published(), deadLetter.published()
+			if len(requeued) != tt.wantRequeued || len(dead) != tt.wantDeadLetter || deadLetterCalls != tt.wantDeadLetter {
+				t.Fatalf("requeued = %d, dead letter = %d (callback %d), want %d, %d",
+					len(requeued), len(dead), deadLetterCalls, tt.wantRequeued, tt.wantDeadLetter)
+			}
+			if len(requeued) == 1 {
+				if delay := requeued[0].NotBefore.Sub(start); delay < tt.minDelay || delay > tt.maxDelay {
+					t.Errorf("requeue delay = %v, want between %v and %v", delay, tt.minDelay, tt.maxDelay)
+				}
+			}
+			if tt.handlerErr != nil && len(dead) == 1 && dead[0].LastError == "" {
+				t.Errorf("dead letter LastError is empty")
+			}
+		})
+	}
+}
+
+func TestWorkerHandlePublishError(t *testing.T) {
+	// 重新投递失败时返回错误，由队列决定是否确认当前消息
+	queue := &recordPublisher{err: ErrStopped}
+	worker := NewWorker(queue, &recordPublisher{}, func(ctx context.Context, msg *RunMessage) error {
+		return errors.New("连接失败")
+	}, Options{})
+
+	err := worker.Handle(context.Background(), &RunMessage{ExecutionID: "exec-1"})
+	if !errors.Is(err, ErrStopped) {
+		t.Errorf("Handle() error = %v, want %v", err, ErrStopped)
+	}
+}
+
+func TestWorkerRetryWhenQueueFull(t *testing.T) {
+	ctx := context.Background()
+	queue := NewMemoryQueue(1, 1)
+	if err := queue.Publish(ctx, &RunMessage{ExecutionID: "other"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	// 队列已满时重试消息进入延迟堆，不会被丢弃
+	worker := NewWorker(queue, &recordPublisher{}, nil, Options{RetryBackoff: 10 * time.Millisecond})
+	msg := &RunMessage{ExecutionID: "exec-1", Attempt: 1}
+	if err := worker.Retry(ctx, msg, errors.New("连接失败")); err != nil {
+		t.Fatalf("Retry() error = %v", err)
+	}
+	if n := queue.Len(); n != 2 {
+		t.Fatalf("Len() = %d, want 2", n)
+	}
+
+	done := make(chan *RunMessage, 2)
+	queue.Start(func(ctx context.Context, msg *RunMessage) error {
+		done <- msg
+		return nil
+	})
+	defer queue.Stop()
+
+	for _, want := range []string{"other", "exec-1"} {
+		got := waitMessage(done, time.Second)
+		if got == nil || got.ExecutionID != want {
+			t.Fatalf("processed = %+v, want %s", got, want)
+		}
+	}
+}
+
+func TestWorkerCancelDuringBackoff(t *testing.T) {
+	ctx := context.Background()
+	tracker := NewTracker(lock.NewMemoryStore(), "test", 0)
+	queue := NewMemoryQueue(16, 1)
+
+	// 与 RunTask 一致：开始执行时移除入队记录，已取消的执行跳过
+	runs := make(chan int, 2)
+	skipped := make(chan struct{}, 1)
+	attempts := 0
+	worker := NewWorker(queue, &recordPublisher{}, func(ctx context.Context, msg *RunMessage) error {
+		if canceled, _ := tracker.Canceled(ctx, msg.ExecutionID); canceled {
+			skipped <- struct{}{}
+			return tracker.Started(ctx, msg.ExecutionID)
+		}
+		if err := tracker.Started(ctx, msg.ExecutionID); err != nil {
+			return err
+		}
+		attempts++
+		runs <- attempts
+		return errors.New("连接失败")
+	}, Options{RetryBackoff: 100 * time.Millisecond, Tracker: tracker})
+	queue.Start(worker.Handle)
+	defer queue.Stop()
+
+	msg := &RunMessage{ExecutionID: "exec-1", TaskID: "task-1"}
+	if err := tracker.MarkPending(ctx, msg.ExecutionID, msg.TaskID); err != nil {
+		t.Fatalf("MarkPending() error = %v", err)
+	}
+	if err := queue.Publish(ctx, msg); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	select {
+	case <-runs:
+	case <-time.After(time.Second):
+		t.Fatal("first attempt did not run")
+	}
+
+	// 等待重试期间取消，重试投递前已重新记录入队
+	time.Sleep(20 * time.Millisecond)
+	canceled, err := tracker.Cancel(ctx, msg.ExecutionID)
+	if err != nil || !canceled {
+		t.Fatalf("Cancel() = %v, %v, want true during backoff", canceled, err)
+	}
+
+	select {
+	case <-skipped:
+	case n := <-runs:
+		t.Fatalf("attempt %d ran after cancel", n)
+	case <-time.After(time.Second):
+		t.Fatal("canceled retry was not dropped")
+	}
+	if n := queue.Len(); n != 0 {
+		t.Errorf("Len() = %d, want 0", n)
+	}
+}
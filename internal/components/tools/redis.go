@@ -165,6 +165,14 @@ func (r *RedisClient) IncrementBy(ctx context.Context, key string, increment int
 	return r.client.IncrBy(ctx, key, increment).Result()
 }
 
+// Eval 执行 Lua 脚本
+func (r *RedisClient) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	if r.client == nil {
+		return nil, fmt.Errorf("Redis 客户端未连接")
+	}
+	return r.client.Eval(ctx, script, keys, args...).Result()
+}
+
 // 哈希操作
 
 // HSet 设置哈希表中的字段值
@@ -293,6 +301,29 @@ func (r *RedisClient) ZRem(ctx context.Context, key string, members ...interface
 	return r.client.ZRem(ctx, key, members...).Result()
 }
 
+// 发布订阅
+
+// Publish 向频道发布消息
+func (r *RedisClient) Publish(ctx context.Context, channel string, message interface{}) error {
+	if r.client == nil {
+		return fmt.Errorf("Redis 客户端未连接")
+	}
+	return r.client.Publish(ctx, channel, message).Err()
+}
+
+// Subscribe 订阅频道，返回前确认订阅已生效，调用方结束时须关闭返回的订阅
+func (r *RedisClient) Subscribe(ctx context.Context, channels ...string) (*redis.PubSub, error) {
+	if r.client == nil {
+		return nil, fmt.Errorf("Redis 客户端未连接")
+	}
+	pubsub := r.client.Subscribe(ctx, channels...)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+	return pubsub, nil
+}
+
 // Close 关闭 Redis 连接
 func (r *RedisClient) Close() error {
 	return r.Disconnect(context.Background())
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/zeromicro/go-queue/kq"
 	"github.com/zeromicro/go-zero/rest"
 	"github.com/zeromicro/go-zero/zrpc"
@@ -25,6 +27,46 @@ type RedisConf struct {
 	Tls  bool   `json:",optional"`
 }
 
+// ExecutorConf 任务执行器配置
+type ExecutorConf struct {
+	Workers    int            `json:",default=8"`    // 全局并发执行数
+	QueueSize  int            `json:",default=1000"` // 排队上限，超过时拒绝执行
+	TypeLimits map[string]int `json:",optional"`     // 按管道类型限制并发数，如 apifox: 2
+}
+
+// LockConf 任务分布式锁配置
+type LockConf struct {
+	Store         string        `json:",default=redis,options=redis|memory"` // 锁存储，memory 仅适用于单实例部署
+	TTL           time.Duration `json:",default=30s"`                        // 租约时长，持有者崩溃后最迟在此时长后释放
+	RenewInterval time.Duration `json:",default=10s"`                        // 续约间隔
+	RetryInterval time.Duration `json:",default=1s"`                         // queue 策略锁被占用时消息重新入队的间隔
+}
+
+// SchedulerConf 定时调度配置
+type SchedulerConf struct {
+	Enabled      bool          `json:",default=true"`  // 是否参与定时调度
+	Timezone     string        `json:",default=Local"` // 表达式未指定时区时使用的时区
+	MaxCatchUp   time.Duration `json:",default=1h"`    // 错过的调度在此时长内补跑一次，0 表示不补跑
+	PollInterval time.Duration `json:",default=5s"`    // 选主与检查任务变更的间隔
+}
+
+// TaskQueueConf 任务运行队列配置
+type TaskQueueConf struct {
+	Driver          string        `json:",default=kafka,options=kafka|memory"` // memory 仅适用于单实例部署
+	MaxAttempts     int           `json:",default=3"`                          // 最大投递次数，超过后转入死信队列
+	RetryBackoff    time.Duration `json:",default=5s"`                         // 首次重试间隔，之后每次翻倍
+	DeadLetterTopic string        `json:",default=task_run_dlq"`               // 死信队列 topic
+	DelayTopic      string        `json:",default=task_run_delay"`             // 延迟 topic，暂存未到重试时间的消息
+	MemorySize      int           `json:",default=1000"`                       // memory 队列容量
+	MemoryWorkers   int           `json:",default=2"`                          // memory 队列消费协程数
+}
+
+// MockConf Mock 服务配置
+type MockConf struct {
+	Host          string `json:",default=0.0.0.0"`   // 监听地址
+	AdvertiseHost string `json:",default=127.0.0.1"` // 返回的 Mock 服务URL中使用的主机名，需能被执行场景的实例访问
+}
+
 type Config struct {
 	zrpc.RpcServerConf
 	RedisConf        RedisConf `json:"RedisConf"`
@@ -35,7 +77,12 @@ type Config struct {
 		Topic        string
 		TaskRunTopic string
 	}
-	Database DatabaseConfig `json:"Database"`
+	Database  DatabaseConfig `json:"Database"`
+	Executor  ExecutorConf   `json:",optional"`
+	Lock      LockConf       `json:",optional"`
+	Scheduler SchedulerConf  `json:",optional"`
+	TaskQueue TaskQueueConf  `json:",optional"`
+	Mock      MockConf       `json:",optional"`
 }
 
 type KafkaConfig struct {
@@ -0,0 +1,191 @@
+package environmentservicelogic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	api "Storage/internal/components/pipeline/runner/api/apirunner"
+	"Storage/internal/components/pipeline/runner/api/apirunner/auth"
+	"Storage/internal/model/environment"
+	"Storage/storage"
+)
+
+// environmentFields 创建和更新请求中的环境配置
+type environmentFields struct {
+	Name        string
+	Desc        string
+	BaseURL     string
+	ServiceURLs map[string]string
+	Variables   map[string]string
+	Auth        string
+	TLS         *storage.EnvironmentTLS
+
+	// 更新前的环境，请求中为空的密钥沿用原有值，创建时为空
+	Current *environment.Environment
+}
+
+// toEnvironment 校验请求中的环境配置并转换为环境模型
+func toEnvironment(in environmentFields) (*environment.Environment, error) {
+	if in.Name == "" {
+		return nil, fmt.Errorf("环境名称不能为空")
+	}
+	if in.BaseURL != "" && !isAbsoluteURL(in.BaseURL) {
+		return nil, fmt.Errorf("基础URL %s 无效, 需要包含协议和主机", in.BaseURL)
+	}
+	for service, serviceURL := range in.ServiceURLs {
+		if !isAbsoluteURL(serviceURL) {
+			return nil, fmt.Errorf("服务 %s 的基础URL %s 无效, 需要包含协议和主机", service, serviceURL)
+		}
+	}
+
+	env := &environment.Environment{
+		Name:        in.Name,
+		Desc:        in.Desc,
+		BaseURL:     in.BaseURL,
+		ServiceURLs: in.ServiceURLs,
+		Variables:   in.Variables,
+	}
+
+	if in.Auth != "" {
+		var config auth.Config
+		decoder := json.NewDecoder(bytes.NewReader([]byte(in.Auth)))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&config); err != nil {
+			return nil, fmt.Errorf("认证配置不是有效的JSON: %w", err)
+		}
+		env.Auth = &config
+	}
+	if in.TLS != nil {
+		env.TLS = &environment.TLSSetting{
+			CACert:             in.TLS.CaCert,
+			ClientCert:         in.TLS.ClientCert,
+			ClientKey:          in.TLS.ClientKey,
+			InsecureSkipVerify: in.TLS.InsecureSkipVerify,
+			ServerName:         in.TLS.ServerName,
+		}
+	}
+
+	if in.Current != nil {
+		keepSecrets(env, in.Current)
+	}
+
+	if err := RunnerEnvironment(env).Validate(); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// keepSecrets 密钥只写不读，查询时不返回，更新请求中为空的密钥沿用原有环境的值。
+// 认证方式或客户端证书变化时不沿用
+func keepSecrets(env, current *environment.Environment) {
+	if env.Auth != nil && current.Auth != nil && env.Auth.Type == current.Auth.Type {
+		a, c := env.Auth, current.Auth
+		if a.Bearer != nil && c.Bearer != nil && a.Bearer.Token == "" {
+			a.Bearer.Token = c.Bearer.Token
+		}
+		if a.Basic != nil && c.Basic != nil && a.Basic.Password == "" {
+			a.Basic.Password = c.Basic.Password
+		}
+		if a.APIKey != nil && c.APIKey != nil && a.APIKey.Value == "" {
+			a.APIKey.Value = c.APIKey.Value
+		}
+		if a.OAuth2 != nil && c.OAuth2 != nil && a.OAuth2.ClientSecret == "" {
+			a.OAuth2.ClientSecret = c.OAuth2.ClientSecret
+		}
+		if a.HMAC != nil && c.HMAC != nil && a.HMAC.Secret == "" {
+			a.HMAC.Secret = c.HMAC.Secret
+		}
+	}
+	if env.TLS != nil && current.TLS != nil && env.TLS.ClientKey == "" && env.TLS.ClientCert == current.TLS.ClientCert {
+		env.TLS.ClientKey = current.TLS.ClientKey
+	}
+}
+
+// redactAuth 返回去掉密钥的认证配置副本
+func redactAuth(config *auth.Config) *auth.Config {
+	redacted := *config
+	if config.Bearer != nil {
+		bearer := *config.Bearer
+		bearer.Token = ""
+		redacted.Bearer = &bearer
+	}
+	if config.Basic != nil {
+		basic := *config.Basic
+		basic.Password = ""
+		redacted.Basic = &basic
+	}
+	if config.APIKey != nil {
+		apiKey := *config.APIKey
+		apiKey.Value = ""
+		redacted.APIKey = &apiKey
+	}
+	if config.OAuth2 != nil {
+		oauth2 := *config.OAuth2
+		oauth2.ClientSecret = ""
+		redacted.OAuth2 = &oauth2
+	}
+	if config.HMAC != nil {
+		hmac := *config.HMAC
+		hmac.Secret = ""
+		redacted.HMAC = &hmac
+	}
+	return &redacted
+}
+
+// RunnerEnvironment 转换为执行器使用的执行环境
+func RunnerEnvironment(env *environment.Environment) *api.Environment {
+	runnerEnv := &api.Environment{
+		EnvID:       env.EnvID,
+		Name:        env.Name,
+		BaseURL:     env.BaseURL,
+		ServiceURLs: env.ServiceURLs,
+		Variables:   env.Variables,
+		Auth:        env.Auth,
+	}
+	if env.TLS != nil {
+		runnerEnv.TLS = &api.TLSConfig{
+			CACert:             env.TLS.CACert,
+			ClientCert:         env.TLS.ClientCert,
+			ClientKey:          env.TLS.ClientKey,
+			InsecureSkipVerify: env.TLS.InsecureSkipVerify,
+			ServerName:         env.TLS.ServerName,
+		}
+	}
+	return runnerEnv
+}
+
+// toEnvironmentProto 转换为响应中的环境，认证密钥和客户端私钥不返回
+func toEnvironmentProto(env *environment.Environment) *storage.Environment {
+	item := &storage.Environment{
+		EnvId:       env.EnvID,
+		Name:        env.Name,
+		Desc:        env.Desc,
+		BaseUrl:     env.BaseURL,
+		ServiceUrls: env.ServiceURLs,
+		Variables:   env.Variables,
+		CreateAt:    env.CreateAt.Format("2006-01-02 15:04:05"),
+		UpdateAt:    env.UpdateAt.Format("2006-01-02 15:04:05"),
+	}
+	if env.Auth != nil {
+		if data, err := json.Marshal(redactAuth(env.Auth)); err == nil {
+			item.Auth = string(data)
+		}
+	}
+	if env.TLS != nil {
+		item.Tls = &storage.EnvironmentTLS{
+			CaCert:             env.TLS.CACert,
+			ClientCert:         env.TLS.ClientCert,
+			InsecureSkipVerify: env.TLS.InsecureSkipVerify,
+			ServerName:         env.TLS.ServerName,
+		}
+	}
+	return item
+}
+
+// isAbsoluteURL 是否为包含协议和主机的完整URL
+func isAbsoluteURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
@@ -0,0 +1,157 @@
+package environmentservicelogic
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner/auth"
+	"Storage/internal/model/environment"
+)
+
+func TestToEnvironmentProtoRedactsSecrets(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *auth.Config
+		// 期望返回的认证配置
+		want string
+	}{
+		{
+			name:   "bearer",
+			config: &auth.Config{Type: auth.TypeBearer, Bearer: &auth.BearerConfig{Token: "t-1", Prefix: "Token"}},
+			want:   `{"type":"bearer","bearer":{"token":"","prefix":"Token"}}`,
+		},
+		{
+			name:   "basic",
+			config: &auth.Config{Type: auth.TypeBasic, Basic: &auth.BasicConfig{Username: "admin", Password: "p@ss"}},
+			want:   `{"type":"basic","basic":{"username":"admin","password":""}}`,
+		},
+		{
+			name:   "api_key",
+			config: &auth.Config{Type: auth.TypeAPIKey, APIKey: &auth.APIKeyConfig{Name: "X-Key", Value: "k-1"}},
+			want:   `{"type":"api_key","api_key":{"name":"X-Key","value":""}}`,
+		},
+		{
+			name:   "oauth2",
+			config: &auth.Config{Type: auth.TypeOAuth2, OAuth2: &auth.OAuth2Config{TokenURL: "http://a/token", ClientID: "c", ClientSecret: "s-1"}},
+			want:   `{"type":"oauth2","oauth2":{"token_url":"http://a/token","client_id":"c","client_secret":""}}`,
+		},
+		{
+			name:   "hmac",
+			config: &auth.Config{Type: auth.TypeHMAC, HMAC: &auth.HMACConfig{KeyID: "k1", Secret: "s-1"}},
+			want:   `{"type":"hmac","hmac":{"key_id":"k1","secret":""}}`,
+		},
+		{
+			name:   "不认证",
+			config: &auth.Config{Type: auth.TypeNone},
+			want:   `{"type":"none"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored, _ := json.Marshal(tt.config)
+			env := &environment.Environment{
+				EnvID: "e-1",
+				Name:  "dev",
+				Auth:  tt.config,
+				TLS:   &environment.TLSSetting{CACert: "ca", ClientCert: "cert", ClientKey: "key", ServerName: "api"},
+			}
+
+			item := toEnvironmentProto(env)
+			if item.Auth != tt.want {
+				t.Errorf("Auth = %s, want %s", item.Auth, tt.want)
+			}
+			if item.Tls.ClientKey != "" || item.Tls.ClientCert != "cert" || item.Tls.CaCert != "ca" || item.Tls.ServerName != "api" {
+				t.Errorf("Tls = %v, want client key redacted only", item.Tls)
+			}
+			// 不修改查询到的环境
+			if got, _ := json.Marshal(env.Auth); string(got) != string(stored) {
+				t.Errorf("env.Auth = %s, want %s", got, stored)
+			}
+			if env.TLS.ClientKey != "key" {
+				t.Errorf("env.TLS.ClientKey = %q, want %q", env.TLS.ClientKey, "key")
+			}
+		})
+	}
+}
+
+func TestToEnvironmentKeepsSecrets(t *testing.T) {
+	current := &environment.Environment{
+		Auth: &auth.Config{Type: auth.TypeOAuth2, OAuth2: &auth.OAuth2Config{TokenURL: "http://a/token", ClientID: "c", ClientSecret: "s-1"}},
+	}
+
+	tests := []struct {
+		name    string
+		auth    string
+		current *environment.Environment
+		// 期望保存的认证配置
+		want    *auth.Config
+		wantErr string
+	}{
+		{
+			name:    "为空的密钥沿用原有值",
+			auth:    `{"type":"oauth2","oauth2":{"token_url":"http://b/token","client_id":"c","client_secret":""}}`,
+			current: current,
+			want:    &auth.Config{Type: auth.TypeOAuth2, OAuth2: &auth.OAuth2Config{TokenURL: "http://b/token", ClientID: "c", ClientSecret: "s-1"}},
+		},
+		{
+			name:    "请求中的密钥替换原有值",
+			auth:    `{"type":"oauth2","oauth2":{"token_url":"http://a/token","client_id":"c","client_secret":"s-2"}}`,
+			current: current,
+			want:    &auth.Config{Type: auth.TypeOAuth2, OAuth2: &auth.OAuth2Config{TokenURL: "http://a/token", ClientID: "c", ClientSecret: "s-2"}},
+		},
+		{
+			name:    "认证方式变化时不沿用",
+			auth:    `{"type":"hmac","hmac":{"key_id":"k1"}}`,
+			current: current,
+			wantErr: "认证配置错误: hmac 认证需要配置 secret",
+		},
+		{
+			name:    "创建时没有原有值",
+			auth:    `{"type":"bearer","bearer":{"token":""}}`,
+			wantErr: "认证配置错误: bearer 认证需要配置 token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := toEnvironment(environmentFields{Name: "dev", Auth: tt.auth, Current: tt.current})
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("toEnvironment() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("toEnvironment() error = %v", err)
+			}
+			if !reflect.DeepEqual(env.Auth, tt.want) {
+				t.Errorf("Auth = %+v, want %+v", env.Auth.OAuth2, tt.want.OAuth2)
+			}
+		})
+	}
+}
+
+func TestKeepSecretsClientKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		clientCert string
+		clientKey  string
+		want       string
+	}{
+		{name: "证书不变时沿用私钥", clientCert: "cert", want: "key"},
+		{name: "请求中的私钥替换原有值", clientCert: "cert", clientKey: "new-key", want: "new-key"},
+		{name: "证书变化时不沿用", clientCert: "new-cert", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := &environment.Environment{TLS: &environment.TLSSetting{ClientCert: tt.clientCert, ClientKey: tt.clientKey}}
+			keepSecrets(env, &environment.Environment{TLS: &environment.TLSSetting{ClientCert: "cert", ClientKey: "key"}})
+			if env.TLS.ClientKey != tt.want {
+				t.Errorf("ClientKey = %q, want %q", env.TLS.ClientKey, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package environmentservicelogic
+
+import (
+	"context"
+
+	"Storage/internal/errors"
+	"Storage/internal/model/environment"
+	"Storage/internal/svc"
+	"Storage/storage"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreateEnvironmentLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewCreateEnvironmentLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateEnvironmentLogic {
+	return &CreateEnvironmentLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 创建执行环境
+func (l *CreateEnvironmentLogic) CreateEnvironment(in *storage.CreateEnvironmentRequest) (*storage.EnvironmentResponse, error) {
+	env, err := toEnvironment(environmentFields{
+		Name:        in.Name,
+		Desc:        in.Desc,
+		BaseURL:     in.BaseUrl,
+		ServiceURLs: in.ServiceUrls,
+		Variables:   in.Variables,
+		Auth:        in.Auth,
+		TLS:         in.Tls,
+	})
+	if err != nil {
+		return &storage.EnvironmentResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InvalidParameter),
+				Message: err.Error(),
+			},
+		}, nil
+	}
+
+	if err := l.svcCtx.EnvironmentModel.Insert(l.ctx, env); err != nil {
+		if err == environment.ErrDuplicateName {
+			return &storage.EnvironmentResponse{
+				Header: &storage.ResponseHeader{
+					Code:    int64(errors.Conflict),
+					Message: "环境 " + in.Name + " 已存在",
+				},
+			}, nil
+		}
+		l.Errorf("创建执行环境 %s 失败: %v", in.Name, err)
+		return &storage.EnvironmentResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: "创建执行环境失败: " + err.Error(),
+			},
+		}, nil
+	}
+
+	return &storage.EnvironmentResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "创建执行环境成功",
+		},
+		Data: toEnvironmentProto(env),
+	}, nil
+}
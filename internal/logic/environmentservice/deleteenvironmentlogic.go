@@ -0,0 +1,55 @@
+package environmentservicelogic
+
+import (
+	"context"
+
+	"Storage/internal/errors"
+	"Storage/internal/svc"
+	"Storage/storage"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteEnvironmentLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewDeleteEnvironmentLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteEnvironmentLogic {
+	return &DeleteEnvironmentLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 删除执行环境
+func (l *DeleteEnvironmentLogic) DeleteEnvironment(in *storage.DeleteEnvironmentRequest) (*storage.DeleteResponse, error) {
+	affectedRows, err := l.svcCtx.EnvironmentModel.Delete(l.ctx, in.EnvId)
+	if err != nil {
+		l.Errorf("删除执行环境 %s 失败: %v", in.EnvId, err)
+		return &storage.DeleteResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: "删除执行环境失败: " + err.Error(),
+			},
+		}, nil
+	}
+	if affectedRows == 0 {
+		return &storage.DeleteResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.NotFound),
+				Message: "环境不存在",
+			},
+		}, nil
+	}
+
+	return &storage.DeleteResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "删除执行环境成功",
+		},
+		AffectedRows: affectedRows,
+	}, nil
+}
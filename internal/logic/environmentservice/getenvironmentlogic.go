@@ -0,0 +1,56 @@
+package environmentservicelogic
+
+import (
+	"context"
+
+	"Storage/internal/errors"
+	"Storage/internal/model/environment"
+	"Storage/internal/svc"
+	"Storage/storage"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetEnvironmentLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetEnvironmentLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetEnvironmentLogic {
+	return &GetEnvironmentLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 查询执行环境
+func (l *GetEnvironmentLogic) GetEnvironment(in *storage.GetEnvironmentRequest) (*storage.EnvironmentResponse, error) {
+	env, err := l.svcCtx.EnvironmentModel.FindOneByEnvID(l.ctx, in.EnvId)
+	if err == environment.ErrNotFound {
+		return &storage.EnvironmentResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.NotFound),
+				Message: "环境不存在",
+			},
+		}, nil
+	}
+	if err != nil {
+		l.Errorf("查询执行环境 %s 失败: %v", in.EnvId, err)
+		return &storage.EnvironmentResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: "查询执行环境失败: " + err.Error(),
+			},
+		}, nil
+	}
+
+	return &storage.EnvironmentResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "Success",
+		},
+		Data: toEnvironmentProto(env),
+	}, nil
+}
@@ -0,0 +1,52 @@
+package environmentservicelogic
+
+import (
+	"context"
+
+	"Storage/internal/errors"
+	"Storage/internal/svc"
+	"Storage/storage"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListEnvironmentsLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewListEnvironmentsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListEnvironmentsLogic {
+	return &ListEnvironmentsLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 按名称列出所有执行环境
+func (l *ListEnvironmentsLogic) ListEnvironments(in *storage.Empty) (*storage.EnvironmentListResponse, error) {
+	envs, err := l.svcCtx.EnvironmentModel.FindAll(l.ctx)
+	if err != nil {
+		l.Errorf("查询执行环境列表失败: %v", err)
+		return &storage.EnvironmentListResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: "查询执行环境列表失败: " + err.Error(),
+			},
+		}, nil
+	}
+
+	items := make([]*storage.Environment, 0, len(envs))
+	for _, env := range envs {
+		items = append(items, toEnvironmentProto(env))
+	}
+	return &storage.EnvironmentListResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "Success",
+		},
+		Data:  items,
+		Total: int32(len(items)),
+	}, nil
+}
@@ -0,0 +1,109 @@
+package environmentservicelogic
+
+import (
+	"context"
+
+	"Storage/internal/errors"
+	"Storage/internal/model/environment"
+	"Storage/internal/svc"
+	"Storage/storage"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UpdateEnvironmentLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewUpdateEnvironmentLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateEnvironmentLogic {
+	return &UpdateEnvironmentLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 更新执行环境，请求中的配置整体替换原有配置，为空的密钥沿用原有值
+func (l *UpdateEnvironmentLogic) UpdateEnvironment(in *storage.UpdateEnvironmentRequest) (*storage.EnvironmentResponse, error) {
+	current, err := l.svcCtx.EnvironmentModel.FindOneByEnvID(l.ctx, in.EnvId)
+	switch err {
+	case nil:
+	case environment.ErrNotFound:
+		return &storage.EnvironmentResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.NotFound),
+				Message: "环境不存在",
+			},
+		}, nil
+	default:
+		l.Errorf("查询执行环境 %s 失败: %v", in.EnvId, err)
+		return &storage.EnvironmentResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: "更新执行环境失败: " + err.Error(),
+			},
+		}, nil
+	}
+
+	env, err := toEnvironment(environmentFields{
+		Name:        in.Name,
+		Desc:        in.Desc,
+		BaseURL:     in.BaseUrl,
+		ServiceURLs: in.ServiceUrls,
+		Variables:   in.Variables,
+		Auth:        in.Auth,
+		TLS:         in.Tls,
+		Current:     current,
+	})
+	if err != nil {
+		return &storage.EnvironmentResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InvalidParameter),
+				Message: err.Error(),
+			},
+		}, nil
+	}
+	env.EnvID = in.EnvId
+
+	err = l.svcCtx.EnvironmentModel.Update(l.ctx, env)
+	switch err {
+	case nil:
+	case environment.ErrNotFound:
+		return &storage.EnvironmentResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.NotFound),
+				Message: "环境不存在",
+			},
+		}, nil
+	case environment.ErrDuplicateName:
+		return &storage.EnvironmentResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.Conflict),
+				Message: "环境 " + in.Name + " 已存在",
+			},
+		}, nil
+	default:
+		l.Errorf("更新执行环境 %s 失败: %v", in.EnvId, err)
+		return &storage.EnvironmentResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: "更新执行环境失败: " + err.Error(),
+			},
+		}, nil
+	}
+
+	updated, err := l.svcCtx.EnvironmentModel.FindOneByEnvID(l.ctx, in.EnvId)
+	if err != nil {
+		l.Errorf("查询更新后的执行环境 %s 失败: %v", in.EnvId, err)
+		updated = env
+	}
+	return &storage.EnvironmentResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "更新执行环境成功",
+		},
+		Data: toEnvironmentProto(updated),
+	}, nil
+}
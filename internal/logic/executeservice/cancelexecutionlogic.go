@@ -0,0 +1,130 @@
+package executeservicelogic
+
+import (
+	"context"
+	"time"
+
+	"Storage/internal/errors"
+	"Storage/internal/model/taskrecord"
+	"Storage/internal/svc"
+	"Storage/storage"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type CancelExecutionLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewCancelExecutionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CancelExecutionLogic {
+	return &CancelExecutionLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 取消执行
+func (l *CancelExecutionLogic) CancelExecution(in *storage.CancelExecutionRequest) (*storage.CancelExecutionResponse, error) {
+	if in.ExecutionId == "" {
+		return &storage.CancelExecutionResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InvalidParameter),
+				Message: "执行ID不能为空",
+			},
+		}, nil
+	}
+
+	// 排队中的执行直接出队，由出队回调取消管道并发布汇总
+	if l.svcCtx.Executor.Remove(in.ExecutionId) {
+		return &storage.CancelExecutionResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.Success),
+				Message: "执行已取消",
+			},
+		}, nil
+	}
+
+	found, err := l.svcCtx.Executions.Cancel(l.ctx, in.ExecutionId)
+	if !found {
+		// 仍在 task_run 队列中的执行，标记取消后由消费者跳过
+		if queued, err := l.svcCtx.RunTracker.Cancel(l.ctx, in.ExecutionId); err != nil {
+			l.Errorf("取消队列中的执行 %s 失败: %v", in.ExecutionId, err)
+		} else if queued {
+			markExecution(l.ctx, l.svcCtx, in.ExecutionId, taskrecord.StatusCanceled, bson.M{
+				"finished_at": time.Now(),
+			})
+			return &storage.CancelExecutionResponse{
+				Header: &storage.ResponseHeader{
+					Code:    int64(errors.Success),
+					Message: "执行已取消",
+				},
+			}, nil
+		}
+		return l.requestCancel(in.ExecutionId)
+	}
+	if err != nil {
+		l.Errorf("取消执行 %s 失败: %v", in.ExecutionId, err)
+		return &storage.CancelExecutionResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.StateTransitionError),
+				Message: "取消执行失败: " + err.Error(),
+			},
+		}, nil
+	}
+
+	return &storage.CancelExecutionResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "执行已取消",
+		},
+	}, nil
+}
+
+// requestCancel 执行不在本实例时按执行记录判断是否仍在运行，运行中的执行通过共享存储通知所在实例取消
+// 所在实例在任务锁续约时发现取消请求，等待重试的执行在重新投递时跳过
+func (l *CancelExecutionLogic) requestCancel(executionID string) (*storage.CancelExecutionResponse, error) {
+	record, err := l.svcCtx.TaskRecordModel.FindByExecutionID(l.ctx, executionID)
+	if err != nil && err != taskrecord.ErrNotFound {
+		l.Errorf("查询执行记录 %s 失败: %v", executionID, err)
+		return &storage.CancelExecutionResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: "查询执行记录失败: " + err.Error(),
+			},
+		}, nil
+	}
+	if record == nil || taskrecord.IsFinished(record.Status) {
+		return &storage.CancelExecutionResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.NotFound),
+				Message: "执行不存在或已结束",
+			},
+		}, nil
+	}
+
+	if err := l.svcCtx.Locker.RequestCancel(l.ctx, executionID); err != nil {
+		l.Errorf("请求取消执行 %s 失败: %v", executionID, err)
+		return &storage.CancelExecutionResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: "请求取消执行失败: " + err.Error(),
+			},
+		}, nil
+	}
+	// 等待重试的执行没有运行中的实例，直接结束记录
+	if record.Status == taskrecord.StatusRetrying {
+		markExecution(l.ctx, l.svcCtx, executionID, taskrecord.StatusCanceled, bson.M{
+			"finished_at": time.Now(),
+		})
+	}
+	return &storage.CancelExecutionResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "已通知执行所在实例取消",
+		},
+	}, nil
+}
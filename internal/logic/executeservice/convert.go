@@ -0,0 +1,129 @@
+package executeservicelogic
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"Storage/internal/model/taskrecord"
+	"Storage/storage"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// toTimestamp 转换为自定义 Timestamp，零值返回 nil
+func toTimestamp(t time.Time) *storage.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return &storage.Timestamp{
+		Seconds: t.Unix(),
+		Nanos:   int32(t.Nanosecond()),
+	}
+}
+
+// fromTimestamp 转换自定义 Timestamp，nil 返回零值
+func fromTimestamp(ts *storage.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return time.Unix(ts.Seconds, int64(ts.Nanos))
+}
+
+// toExecutionRecord 转换执行记录
+func toExecutionRecord(record *taskrecord.TaskRecord) *storage.ExecutionRecord {
+	item := &storage.ExecutionRecord{
+		ExecutionId: record.ExecutionID,
+		TaskId:      record.TaskID,
+		TaskType:    record.TaskType,
+		SubType:     record.SubType,
+		Trigger:     record.Trigger,
+		Status:      record.Status,
+		Attempts:    int32(record.Attempts),
+		CreateTime:  toTimestamp(record.CreatedAt),
+		DurationMs:  record.DurationMs,
+		Error:       record.Error,
+		Errors:      record.Errors,
+		Summary:     toStruct(record.Summary),
+	}
+	if record.StartedAt != nil {
+		item.StartTime = toTimestamp(*record.StartedAt)
+	}
+	if record.FinishedAt != nil {
+		item.EndTime = toTimestamp(*record.FinishedAt)
+	}
+	for _, result := range record.Result {
+		item.Results = append(item.Results, toStruct(result))
+	}
+	return item
+}
+
+// toStruct 将 map 转换为自定义 Struct
+func toStruct(m map[string]interface{}) *storage.Struct {
+	if m == nil {
+		return nil
+	}
+	fields := make(map[string]*storage.Value, len(m))
+	for k, v := range m {
+		fields[k] = toValue(v)
+	}
+	return &storage.Struct{Fields: fields}
+}
+
+// toValue 将任意值转换为自定义 Value
+// 非基础类型先经 JSON 编解码为 map/slice 再转换
+func toValue(v interface{}) *storage.Value {
+	switch val := v.(type) {
+	case nil:
+		return &storage.Value{Kind: &storage.Value_NullValue{NullValue: storage.NullValue_NULL_VALUE}}
+	case string:
+		return &storage.Value{Kind: &storage.Value_StringValue{StringValue: val}}
+	case bool:
+		return &storage.Value{Kind: &storage.Value_BoolValue{BoolValue: val}}
+	case float64:
+		return &storage.Value{Kind: &storage.Value_NumberValue{NumberValue: val}}
+	case time.Time:
+		return &storage.Value{Kind: &storage.Value_StringValue{StringValue: val.Format(time.RFC3339)}}
+	case error:
+		return &storage.Value{Kind: &storage.Value_StringValue{StringValue: val.Error()}}
+	case map[string]interface{}:
+		return &storage.Value{Kind: &storage.Value_StructValue{StructValue: toStruct(val)}}
+	case primitive.M:
+		return toValue(map[string]interface{}(val))
+	case primitive.D:
+		return toValue(map[string]interface{}(val.Map()))
+	case primitive.A:
+		return toValue([]interface{}(val))
+	case primitive.DateTime:
+		return toValue(val.Time())
+	case []interface{}:
+		values := make([]*storage.Value, 0, len(val))
+		for _, item := range val {
+			values = append(values, toValue(item))
+		}
+		return &storage.Value{Kind: &storage.Value_ListValue{ListValue: &storage.ListValue{Values: values}}}
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &storage.Value{Kind: &storage.Value_NumberValue{NumberValue: float64(rv.Int())}}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &storage.Value{Kind: &storage.Value_NumberValue{NumberValue: float64(rv.Uint())}}
+	case reflect.Float32:
+		return &storage.Value{Kind: &storage.Value_NumberValue{NumberValue: rv.Float()}}
+	case reflect.String:
+		return &storage.Value{Kind: &storage.Value_StringValue{StringValue: rv.String()}}
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return &storage.Value{Kind: &storage.Value_StringValue{StringValue: fmt.Sprintf("%v", v)}}
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return &storage.Value{Kind: &storage.Value_StringValue{StringValue: string(data)}}
+	}
+	return toValue(generic)
+}
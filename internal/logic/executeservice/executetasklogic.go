@@ -2,18 +2,20 @@ package executeservicelogic
 
 import (
 	"context"
-	"net/http"
-	"strconv"
+	"time"
 
+	"Storage/internal/components/lock"
+	"Storage/internal/components/taskqueue"
 	"Storage/internal/errors"
-	"Storage/internal/logic/pipelines"
-	"Storage/internal/logic/tools"
-	"Storage/internal/logic/workflows/core"
+	"Storage/internal/model/environment"
 	model "Storage/internal/model/task"
+	"Storage/internal/model/taskrecord"
 	"Storage/internal/svc"
 	"Storage/storage"
 
+	"github.com/google/uuid"
 	"github.com/zeromicro/go-zero/core/logx"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 type ExecuteTaskLogic struct {
@@ -22,15 +24,6 @@ type ExecuteTaskLogic struct {
 	logx.Logger
 }
 
-// parsePort 将端口字符串转换为整数
-func parsePort(portStr string) int {
-	port, err := strconv.Atoi(portStr)
-	if err != nil {
-		return 27017 // 默认 MongoDB 端口
-	}
-	return port
-}
-
 func NewExecuteTaskLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ExecuteTaskLogic {
 	return &ExecuteTaskLogic{
 		ctx:    ctx,
@@ -40,90 +33,196 @@ func NewExecuteTaskLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Execu
 }
 
 // 任务执行
+// 校验任务后将运行消息投递到 task_run 队列，由队列消费者获取任务锁并提交到执行器
 func (l *ExecuteTaskLogic) ExecuteTask(in *storage.ExecuteTaskRequest) (*storage.ExecuteTaskResponse, error) {
-	// todo: add your logic here and delete this line
-	// 构建 ApiFoxSyncPipeline 实例
-
-	taskModel := model.NewTaskModel(l.svcCtx.GetMongoURI(), l.svcCtx.Config.Database.Mongo.UseDb, model.TaskCollectionName)
-	task, err := taskModel.FindOneByTaskID(l.ctx, in.TaskId)
-	if err != nil {
+	task, policy, terr := loadRunnableTask(l.ctx, l.svcCtx, in.TaskId)
+	if terr != nil {
 		return &storage.ExecuteTaskResponse{
 			Header: &storage.ResponseHeader{
-				Code:    int64(errors.InternalError),
-				Message: "获取任务信息失败: " + err.Error(),
+				Code:    int64(terr.Code),
+				Message: terr.GetMessage(),
 			},
 		}, nil
 	}
-	if task == nil {
-		return &storage.ExecuteTaskResponse{
-			Header: &storage.ResponseHeader{
-				Code:    int64(errors.DBNotFound),
-				Message: "任务不存在",
-			},
-		}, nil
+
+	// reject 策略下任务正在运行时直接拒绝，消费时仍以加锁结果为准
+	if policy == lock.PolicyReject {
+		holder, err := l.svcCtx.Locker.Holder(l.ctx, task.TaskId)
+		if err != nil {
+			l.Errorf("查询任务 %s 的锁持有者失败: %v", task.TaskId, err)
+		} else if holder != nil {
+			return &storage.ExecuteTaskResponse{
+				Header: &storage.ResponseHeader{
+					Code:    int64(errors.Conflict),
+					Message: "任务正在执行中, 执行ID: " + holder.ExecutionID,
+				},
+			}, nil
+		}
 	}
 
-	if !task.Enable {
+	// 指定了执行环境时确认环境存在，执行时再按ID加载最新配置
+	if in.EnvironmentId != "" {
+		if _, err := l.svcCtx.EnvironmentModel.FindOneByEnvID(l.ctx, in.EnvironmentId); err != nil {
+			code, message := errors.InternalError, "查询执行环境失败: "+err.Error()
+			if err == environment.ErrNotFound {
+				code, message = errors.NotFound, "执行环境不存在"
+			}
+			return &storage.ExecuteTaskResponse{
+				Header: &storage.ResponseHeader{
+					Code:    int64(code),
+					Message: message,
+				},
+			}, nil
+		}
+	}
+
+	// 本次执行的ID，用于取消和查询
+	executionID := uuid.New().String()
+	enqueueTime := time.Now()
+	trigger := in.Trigger
+	if trigger == "" {
+		trigger = "manual"
+	}
+
+	// 每次执行都有一条执行记录，记录创建失败时不投递
+	taskType, subType := "sync", "apifox"
+	switch {
+	case isWorkflow(task):
+		taskType, subType = "workflow", "dag"
+	case isApiTest(task):
+		taskType, subType = "api", "scene"
+	}
+	if err := l.svcCtx.TaskRecordModel.Create(l.ctx, &taskrecord.TaskRecord{
+		ExecutionID:   executionID,
+		TaskID:        task.TaskId,
+		TaskType:      taskType,
+		SubType:       subType,
+		Trigger:       trigger,
+		EnvironmentID: in.EnvironmentId,
+		CreatedAt:     enqueueTime,
+		Status:        taskrecord.StatusPending,
+	}); err != nil {
+		l.Errorf("创建执行 %s 的执行记录失败: %v", executionID, err)
 		return &storage.ExecuteTaskResponse{
 			Header: &storage.ResponseHeader{
-				Code:    int64(errors.InvalidParameter),
-				Message: "任务未启用",
+				Code:    int64(errors.InternalError),
+				Message: "创建执行记录失败: " + err.Error(),
 			},
 		}, nil
 	}
 
-	if task.Type != int32(1) {
+	if err := l.svcCtx.RunTracker.MarkPending(l.ctx, executionID, task.TaskId); err != nil {
+		l.Errorf("记录执行 %s 的入队状态失败: %v", executionID, err)
+	}
+	msg := &taskqueue.RunMessage{
+		ExecutionID:   executionID,
+		TaskID:        task.TaskId,
+		Trigger:       trigger,
+		EnvironmentID: in.EnvironmentId,
+		EnqueueTime:   enqueueTime,
+	}
+	applyRetry(msg, task)
+	err := l.svcCtx.TaskQueue.Publish(l.ctx, msg)
+	if err != nil {
+		l.Errorf("投递任务 %s 的运行消息失败: %v", task.TaskId, err)
+		markExecution(l.ctx, l.svcCtx, executionID, taskrecord.StatusFailed, bson.M{
+			"error":       "投递执行失败: " + err.Error(),
+			"finished_at": time.Now(),
+		})
+		code := errors.InternalError
+		if err == taskqueue.ErrQueueFull {
+			code = errors.TooManyRequests
+		}
 		return &storage.ExecuteTaskResponse{
 			Header: &storage.ResponseHeader{
-				Code:    int64(errors.InvalidParameter),
-				Message: "不是同步类型任务",
+				Code:    int64(code),
+				Message: "投递执行失败: " + err.Error(),
 			},
 		}, nil
 	}
 
-	// 遍历所有数据源
-	for _, source := range task.SyncSpec.Source {
-		// 构建 MongoDB 配置列表
-		var mongoConfigs []tools.MongoConfig
-		for _, dest := range task.SyncSpec.Destination {
-			if dest.DestType == "mongodb" {
-				mongoConfigs = append(mongoConfigs, tools.MongoConfig{
-					MongoHost:   dest.MongoConfig.Host,
-					MongoPort:   parsePort(dest.MongoConfig.Port),
-					MongoUser:   dest.MongoConfig.Username,
-					MongoPasswd: dest.MongoConfig.Password,
-					UseDb:       dest.MongoConfig.Dbname[0], // 使用第一个数据库
-				})
-			}
+	return &storage.ExecuteTaskResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "任务已加入运行队列",
+		},
+		ExecutionId: executionID,
+		StartTime:   toTimestamp(enqueueTime),
+	}, nil
+}
+
+// applyRetry 任务配置了重试次数时按任务配置重试，否则使用消费端配置
+func applyRetry(msg *taskqueue.RunMessage, task *model.Task) {
+	if strategy := taskStrategy(task); strategy != nil {
+		if retry := strategy.Retry; retry != nil && retry.Enabled && retry.MaxAttempts > 0 {
+			msg.MaxAttempts = retry.MaxAttempts + 1
+			msg.RetryInterval = retry.Interval
 		}
+	} else if strategy := task.SyncSpec.Strategy; strategy.GetRetryCount() > 0 {
+		msg.MaxAttempts = int(strategy.GetRetryCount()) + 1
+		msg.RetryInterval = time.Duration(strategy.GetRetryInterval()) * time.Second
+	}
+}
 
-		// 初始化 pipeline
-		syncPipeline := &pipelines.ApiFoxSyncPipeline{
-			BasePipeline: &core.BasePipeline{},
-			Config: pipelines.ApiFoxSyncConfig{
-				ProjectID:   source.Apifox.ProjectId,
-				SharedDocID: source.Apifox.ProjectId,
-				Username:    source.Apifox.Username,
-				Password:    source.Apifox.Password,
-				Mongo:       mongoConfigs,
-			},
-			Client:  &pipelines.ApiClient{Client: &http.Client{}},
-			BaseURL: source.Apifox.Base,
+// loadRunnableTask 加载任务并校验是否可以执行，返回任务和冲突策略
+func loadRunnableTask(ctx context.Context, svcCtx *svc.ServiceContext, taskID string) (*model.Task, lock.Policy, *errors.Error) {
+	taskModel := model.NewTaskModel(svcCtx.GetMongoURI(), svcCtx.Config.Database.Mongo.UseDb, model.TaskCollectionName)
+	task, err := taskModel.FindOneByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, "", errors.New(errors.InternalError).WithDetails("获取任务信息失败: "+err.Error(), nil)
+	}
+	if task == nil {
+		return nil, "", errors.New(errors.DBNotFound).WithDetails("任务不存在", nil)
+	}
+
+	if !task.Enable {
+		return nil, "", errors.New(errors.InvalidParameter).WithDetails("任务未启用", nil)
+	}
+
+	// 工作流任务按节点执行引用的任务，冲突策略使用默认的 reject
+	if isWorkflow(task) {
+		if len(task.WorkflowSpec.Config) == 0 {
+			return nil, "", errors.New(errors.InvalidParameter).WithDetails("任务未配置工作流", nil)
 		}
+		return task, lock.PolicyReject, nil
+	}
 
-		// 执行 ApiFox 同步
-		go func(pipeline *pipelines.ApiFoxSyncPipeline) {
-			err := pipeline.Execute(l.ctx)
-			if err != nil {
-				logx.Errorf("执行 ApiFox 同步失败: %v", err)
-			}
-		}(syncPipeline)
+	// API 测试任务按场景执行，冲突策略使用默认的 reject
+	if isApiTest(task) {
+		if len(task.APISpec.Scenarios) == 0 {
+			return nil, "", errors.New(errors.InvalidParameter).WithDetails("任务未配置测试场景", nil)
+		}
+		return task, lock.PolicyReject, nil
 	}
 
-	return &storage.ExecuteTaskResponse{
-		Header: &storage.ResponseHeader{
-			Code:    int64(errors.Success),
-			Message: "ApiFox 同步执行成功",
-		},
-	}, nil
+	if task.Type != int32(1) || task.SyncSpec == nil {
+		return nil, "", errors.New(errors.InvalidParameter).WithDetails("不是同步类型任务", nil)
+	}
+
+	policy, err := lock.ParsePolicy(task.SyncSpec.Strategy.GetConflictPolicy())
+	if err != nil {
+		return nil, "", errors.New(errors.InvalidParameter).WithDetails(err.Error(), nil)
+	}
+	return task, policy, nil
+}
+
+// isApiTest 是否为按场景执行的 API 测试任务，未配置同步数据源且配置了 API 测试时成立
+func isApiTest(task *model.Task) bool {
+	return task.SyncSpec == nil && task.APISpec != nil
+}
+
+// isWorkflow 是否为按依赖关系执行引用任务的工作流任务
+func isWorkflow(task *model.Task) bool {
+	return task.WorkflowSpec != nil
+}
+
+// taskStrategy API 测试任务和工作流任务的执行策略，同步任务使用 SyncSpec.Strategy，返回 nil
+func taskStrategy(task *model.Task) *model.TaskStrategy {
+	switch {
+	case isWorkflow(task):
+		return &task.WorkflowSpec.Strategy
+	case isApiTest(task):
+		return &task.APISpec.Strategy
+	}
+	return nil
 }
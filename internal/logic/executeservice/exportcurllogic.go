@@ -0,0 +1,69 @@
+package executeservicelogic
+
+import (
+	"context"
+	"fmt"
+
+	"Storage/internal/errors"
+	"Storage/internal/svc"
+	"Storage/storage"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ExportCurlLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewExportCurlLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ExportCurlLogic {
+	return &ExportCurlLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 将执行中的请求导出为 curl 命令，条目下标与 GetExecutionHar 返回的顺序一致
+func (l *ExportCurlLogic) ExportCurl(in *storage.ExportCurlRequest) (*storage.ExportCurlResponse, error) {
+	if in.ExecutionId == "" {
+		return &storage.ExportCurlResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InvalidParameter),
+				Message: "执行ID不能为空",
+			},
+		}, nil
+	}
+	if in.EntryIndex < 0 {
+		return &storage.ExportCurlResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InvalidParameter),
+				Message: "条目下标不能小于 0",
+			},
+		}, nil
+	}
+
+	entries, header := loadHarEntries(l.ctx, l.svcCtx, in.ExecutionId)
+	if header != nil {
+		return &storage.ExportCurlResponse{Header: header}, nil
+	}
+	if int(in.EntryIndex) >= len(entries) {
+		return &storage.ExportCurlResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.NotFound),
+				Message: fmt.Sprintf("执行共有 %d 个请求, 下标 %d 不存在", len(entries), in.EntryIndex),
+			},
+		}, nil
+	}
+
+	entry := entries[in.EntryIndex]
+	return &storage.ExportCurlResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "导出curl命令成功",
+		},
+		Command: entry.Request.Curl(),
+		ApiName: entry.Comment,
+	}, nil
+}
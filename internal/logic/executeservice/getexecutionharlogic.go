@@ -0,0 +1,99 @@
+package executeservicelogic
+
+import (
+	"context"
+	"encoding/json"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner/har"
+	"Storage/internal/errors"
+	"Storage/internal/model/taskrecord"
+	"Storage/internal/svc"
+	"Storage/storage"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetExecutionHarLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetExecutionHarLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetExecutionHarLogic {
+	return &GetExecutionHarLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 下载执行中所有请求和响应的 HAR 文件
+func (l *GetExecutionHarLogic) GetExecutionHar(in *storage.GetExecutionHarRequest) (*storage.GetExecutionHarResponse, error) {
+	if in.ExecutionId == "" {
+		return &storage.GetExecutionHarResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InvalidParameter),
+				Message: "执行ID不能为空",
+			},
+		}, nil
+	}
+
+	entries, header := loadHarEntries(l.ctx, l.svcCtx, in.ExecutionId)
+	if header != nil {
+		return &storage.GetExecutionHarResponse{Header: header}, nil
+	}
+
+	data, err := json.MarshalIndent(har.NewDocument(entries), "", "  ")
+	if err != nil {
+		l.Errorf("序列化执行 %s 的HAR失败: %v", in.ExecutionId, err)
+		return &storage.GetExecutionHarResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: "序列化HAR失败: " + err.Error(),
+			},
+		}, nil
+	}
+
+	return &storage.GetExecutionHarResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "获取HAR成功",
+		},
+		Har:        data,
+		EntryCount: int32(len(entries)),
+	}, nil
+}
+
+// loadHarEntries 按请求开始时间加载执行的 HAR 条目，执行记录不存在或查询失败时返回错误响应头
+func loadHarEntries(ctx context.Context, svcCtx *svc.ServiceContext, executionID string) ([]*har.Entry, *storage.ResponseHeader) {
+	logger := logx.WithContext(ctx)
+	if _, err := svcCtx.TaskRecordModel.FindByExecutionID(ctx, executionID); err == taskrecord.ErrNotFound {
+		return nil, &storage.ResponseHeader{
+			Code:    int64(errors.NotFound),
+			Message: "执行记录不存在",
+		}
+	} else if err != nil {
+		logger.Errorf("查询执行记录 %s 失败: %v", executionID, err)
+		return nil, &storage.ResponseHeader{
+			Code:    int64(errors.InternalError),
+			Message: "查询执行记录失败: " + err.Error(),
+		}
+	}
+
+	records, err := svcCtx.HarLogModel.FindByExecutionID(ctx, executionID)
+	if err != nil {
+		logger.Errorf("查询执行 %s 的HAR条目失败: %v", executionID, err)
+		return nil, &storage.ResponseHeader{
+			Code:    int64(errors.InternalError),
+			Message: "查询HAR条目失败: " + err.Error(),
+		}
+	}
+
+	entries := make([]*har.Entry, 0, len(records))
+	for _, record := range records {
+		if record.Entry != nil {
+			entries = append(entries, record.Entry)
+		}
+	}
+	return entries, nil
+}
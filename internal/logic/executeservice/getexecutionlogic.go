@@ -0,0 +1,65 @@
+package executeservicelogic
+
+import (
+	"context"
+
+	"Storage/internal/errors"
+	"Storage/internal/model/taskrecord"
+	"Storage/internal/svc"
+	"Storage/storage"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetExecutionLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetExecutionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetExecutionLogic {
+	return &GetExecutionLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 查询执行记录
+func (l *GetExecutionLogic) GetExecution(in *storage.GetExecutionRequest) (*storage.GetExecutionResponse, error) {
+	if in.ExecutionId == "" {
+		return &storage.GetExecutionResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InvalidParameter),
+				Message: "执行ID不能为空",
+			},
+		}, nil
+	}
+
+	record, err := l.svcCtx.TaskRecordModel.FindByExecutionID(l.ctx, in.ExecutionId)
+	if err == taskrecord.ErrNotFound {
+		return &storage.GetExecutionResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.NotFound),
+				Message: "执行记录不存在",
+			},
+		}, nil
+	}
+	if err != nil {
+		l.Errorf("查询执行记录 %s 失败: %v", in.ExecutionId, err)
+		return &storage.GetExecutionResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: "查询执行记录失败: " + err.Error(),
+			},
+		}, nil
+	}
+
+	return &storage.GetExecutionResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "获取执行记录成功",
+		},
+		Execution: toExecutionRecord(record),
+	}, nil
+}
@@ -0,0 +1,66 @@
+package executeservicelogic
+
+import (
+	"context"
+
+	"Storage/internal/errors"
+	"Storage/internal/svc"
+	"Storage/storage"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListExecutionQueueLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewListExecutionQueueLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListExecutionQueueLogic {
+	return &ListExecutionQueueLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 查看执行队列
+func (l *ListExecutionQueueLogic) ListExecutionQueue(in *storage.ListExecutionQueueRequest) (*storage.ListExecutionQueueResponse, error) {
+	items := make([]*storage.QueuedExecution, 0)
+	for _, job := range l.svcCtx.Executor.List() {
+		if in.TaskType != "" && string(job.Type) != in.TaskType {
+			continue
+		}
+		items = append(items, &storage.QueuedExecution{
+			ExecutionId: job.ExecutionID,
+			TaskId:      job.TaskID,
+			TaskType:    string(job.Type),
+			Priority:    job.Priority,
+			Position:    int32(job.Position),
+			EnqueueTime: toTimestamp(job.EnqueueTime),
+		})
+	}
+
+	stats := l.svcCtx.Executor.Stats()
+	runningByType := make(map[string]int32, len(stats.RunningByType))
+	for t, n := range stats.RunningByType {
+		runningByType[string(t)] = int32(n)
+	}
+	queuedByType := make(map[string]int32, len(stats.QueuedByType))
+	for t, n := range stats.QueuedByType {
+		queuedByType[string(t)] = int32(n)
+	}
+
+	return &storage.ListExecutionQueueResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "获取执行队列成功",
+		},
+		Items:         items,
+		Workers:       int32(stats.Workers),
+		Running:       int32(stats.Running),
+		Queued:        int32(stats.Queued),
+		RunningByType: runningByType,
+		QueuedByType:  queuedByType,
+	}, nil
+}
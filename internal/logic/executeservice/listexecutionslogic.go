@@ -0,0 +1,89 @@
+package executeservicelogic
+
+import (
+	"context"
+
+	"Storage/internal/errors"
+	"Storage/internal/model/taskrecord"
+	"Storage/internal/svc"
+	"Storage/storage"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const (
+	// defaultExecutionPageSize 执行记录默认每页条数
+	defaultExecutionPageSize = 20
+	// maxExecutionPageSize 执行记录每页最大条数
+	maxExecutionPageSize = 100
+)
+
+type ListExecutionsLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewListExecutionsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListExecutionsLogic {
+	return &ListExecutionsLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 按任务、状态、时间范围分页查询执行记录，按入队时间倒序
+func (l *ListExecutionsLogic) ListExecutions(in *storage.ListExecutionsRequest) (*storage.ListExecutionsResponse, error) {
+	filter := taskrecord.ListFilter{
+		TaskID:    in.TaskId,
+		Status:    in.Status,
+		StartTime: fromTimestamp(in.StartTime),
+		EndTime:   fromTimestamp(in.EndTime),
+	}
+	if !filter.StartTime.IsZero() && !filter.EndTime.IsZero() && filter.EndTime.Before(filter.StartTime) {
+		return &storage.ListExecutionsResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InvalidParameter),
+				Message: "结束时间不能早于开始时间",
+			},
+		}, nil
+	}
+
+	// 设置默认分页参数
+	page := int64(in.Page)
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := int64(in.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultExecutionPageSize
+	}
+	if pageSize > maxExecutionPageSize {
+		pageSize = maxExecutionPageSize
+	}
+
+	records, total, err := l.svcCtx.TaskRecordModel.List(l.ctx, filter, (page-1)*pageSize, pageSize)
+	if err != nil {
+		l.Errorf("查询执行记录失败: %v", err)
+		return &storage.ListExecutionsResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: "查询执行记录失败: " + err.Error(),
+			},
+		}, nil
+	}
+
+	items := make([]*storage.ExecutionRecord, 0, len(records))
+	for _, record := range records {
+		items = append(items, toExecutionRecord(record))
+	}
+
+	return &storage.ListExecutionsResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "获取执行记录成功",
+		},
+		Items: items,
+		Total: total,
+	}, nil
+}
@@ -0,0 +1,55 @@
+package executeservicelogic
+
+import (
+	"context"
+
+	"Storage/internal/errors"
+	"Storage/internal/svc"
+	"Storage/storage"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ReorderExecutionLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewReorderExecutionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ReorderExecutionLogic {
+	return &ReorderExecutionLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 调整排队中执行的优先级
+func (l *ReorderExecutionLogic) ReorderExecution(in *storage.ReorderExecutionRequest) (*storage.ReorderExecutionResponse, error) {
+	if in.ExecutionId == "" {
+		return &storage.ReorderExecutionResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InvalidParameter),
+				Message: "执行ID不能为空",
+			},
+		}, nil
+	}
+
+	position, err := l.svcCtx.Executor.Reorder(in.ExecutionId, in.Priority)
+	if err != nil {
+		return &storage.ReorderExecutionResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.NotFound),
+				Message: "执行不在队列中或已开始运行",
+			},
+		}, nil
+	}
+
+	return &storage.ReorderExecutionResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "调整优先级成功",
+		},
+		Position: int32(position),
+	}, nil
+}
@@ -0,0 +1,140 @@
+package executeservicelogic
+
+import (
+	"context"
+	"time"
+
+	"Storage/internal/components/taskqueue"
+	"Storage/internal/errors"
+	"Storage/internal/model/taskrecord"
+	"Storage/internal/svc"
+	"Storage/storage"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TriggerResume 恢复执行的触发方式
+const TriggerResume = "resume"
+
+type ResumeExecutionLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewResumeExecutionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ResumeExecutionLogic {
+	return &ResumeExecutionLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 恢复失败或已取消的执行
+// 沿用原执行ID重新投递到 task_run 队列，管道按执行ID加载检查点，已完成的接口和场景不再执行
+func (l *ResumeExecutionLogic) ResumeExecution(in *storage.ResumeExecutionRequest) (*storage.ResumeExecutionResponse, error) {
+	if in.ExecutionId == "" {
+		return &storage.ResumeExecutionResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InvalidParameter),
+				Message: "执行ID不能为空",
+			},
+		}, nil
+	}
+
+	record, err := l.svcCtx.TaskRecordModel.FindByExecutionID(l.ctx, in.ExecutionId)
+	if err == taskrecord.ErrNotFound {
+		return &storage.ResumeExecutionResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.NotFound),
+				Message: "执行不存在",
+			},
+		}, nil
+	}
+	if err != nil {
+		return &storage.ResumeExecutionResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: "查询执行记录失败: " + err.Error(),
+			},
+		}, nil
+	}
+	if record.Status != taskrecord.StatusFailed && record.Status != taskrecord.StatusCanceled {
+		return &storage.ResumeExecutionResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.Conflict),
+				Message: "只能恢复失败或已取消的执行, 当前状态: " + record.Status,
+			},
+		}, nil
+	}
+
+	task, _, terr := loadRunnableTask(l.ctx, l.svcCtx, record.TaskID)
+	if terr != nil {
+		return &storage.ResumeExecutionResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(terr.Code),
+				Message: terr.GetMessage(),
+			},
+		}, nil
+	}
+
+	// 并发恢复同一执行时只有一个请求能将记录改回等待中
+	ok, err := l.svcCtx.TaskRecordModel.Reopen(l.ctx, in.ExecutionId, TriggerResume)
+	if err != nil {
+		return &storage.ResumeExecutionResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: "更新执行记录失败: " + err.Error(),
+			},
+		}, nil
+	}
+	if !ok {
+		return &storage.ResumeExecutionResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.Conflict),
+				Message: "执行已在恢复中或状态已变更",
+			},
+		}, nil
+	}
+
+	if err := l.svcCtx.Locker.ClearCancel(l.ctx, in.ExecutionId); err != nil {
+		l.Errorf("清除执行 %s 的取消请求失败: %v", in.ExecutionId, err)
+	}
+	if err := l.svcCtx.RunTracker.Reopen(l.ctx, in.ExecutionId, task.TaskId); err != nil {
+		l.Errorf("记录执行 %s 的入队状态失败: %v", in.ExecutionId, err)
+	}
+	msg := &taskqueue.RunMessage{
+		ExecutionID:   in.ExecutionId,
+		TaskID:        task.TaskId,
+		Trigger:       TriggerResume,
+		EnvironmentID: record.EnvironmentID,
+		EnqueueTime:   time.Now(),
+	}
+	applyRetry(msg, task)
+	if err := l.svcCtx.TaskQueue.Publish(l.ctx, msg); err != nil {
+		l.Errorf("投递执行 %s 的恢复消息失败: %v", in.ExecutionId, err)
+		markExecution(l.ctx, l.svcCtx, in.ExecutionId, taskrecord.StatusFailed, bson.M{
+			"error":       "投递执行失败: " + err.Error(),
+			"finished_at": time.Now(),
+		})
+		code := errors.InternalError
+		if err == taskqueue.ErrQueueFull {
+			code = errors.TooManyRequests
+		}
+		return &storage.ResumeExecutionResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(code),
+				Message: "投递执行失败: " + err.Error(),
+			},
+		}, nil
+	}
+
+	return &storage.ResumeExecutionResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "执行已重新加入运行队列",
+		},
+		ExecutionId: in.ExecutionId,
+	}, nil
+}
@@ -0,0 +1,434 @@
+package executeservicelogic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"Storage/internal/components/executor"
+	"Storage/internal/components/lock"
+	"Storage/internal/components/pipeline/core"
+	api "Storage/internal/components/pipeline/runner/api/apirunner"
+	"Storage/internal/components/pipeline/runner/pipelines"
+	"Storage/internal/components/taskqueue"
+	"Storage/internal/components/tools"
+	"Storage/internal/errors"
+	environmentservicelogic "Storage/internal/logic/environmentservice"
+	"Storage/internal/model/environment"
+	model "Storage/internal/model/task"
+	"Storage/internal/model/taskrecord"
+	"Storage/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type RunTaskLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+// parsePort 将端口字符串转换为整数
+func parsePort(portStr string) int {
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return 27017 // 默认 MongoDB 端口
+	}
+	return port
+}
+
+func NewRunTaskLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RunTaskLogic {
+	return &RunTaskLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// RunTask 消费 task_run 队列中的运行消息：获取任务锁、构建管道并提交到执行器
+// 返回的错误由队列消费者按重试策略处理，不可重试的错误使用 taskqueue.Permanent 标记
+func (l *RunTaskLogic) RunTask(msg *taskqueue.RunMessage) error {
+	if err := l.svcCtx.TaskRecordModel.UpdateByExecutionID(l.ctx, msg.ExecutionID, bson.M{
+		"$set": bson.M{"attempts": msg.Attempt},
+	}); err != nil {
+		l.Errorf("更新执行记录 %s 的投递次数失败: %v", msg.ExecutionID, err)
+	}
+
+	err := l.runTask(msg)
+	if _, requeue := taskqueue.RequeueAfter(err); err != nil && !requeue {
+		// 等待重试，转入死信队列时由 DeadLetter 置为失败
+		markExecution(l.ctx, l.svcCtx, msg.ExecutionID, taskrecord.StatusRetrying, bson.M{"error": err.Error()})
+	}
+	return err
+}
+
+// DeadLetter 运行消息转入死信队列时将执行记录置为失败
+func (l *RunTaskLogic) DeadLetter(msg *taskqueue.RunMessage, err error) {
+	markExecution(l.ctx, l.svcCtx, msg.ExecutionID, taskrecord.StatusFailed, bson.M{
+		"attempts":    msg.Attempt,
+		"error":       err.Error(),
+		"finished_at": time.Now(),
+	})
+}
+
+func (l *RunTaskLogic) runTask(msg *taskqueue.RunMessage) error {
+	// 在队列中等待（包括等待重试）期间已被取消
+	canceled, err := l.svcCtx.RunTracker.Canceled(l.ctx, msg.ExecutionID)
+	if err != nil {
+		return fmt.Errorf("查询执行 %s 的取消状态失败: %w", msg.ExecutionID, err)
+	}
+	if !canceled {
+		canceled, err = l.svcCtx.Locker.CancelRequested(l.ctx, msg.ExecutionID)
+		if err != nil {
+			return fmt.Errorf("查询执行 %s 的取消请求失败: %w", msg.ExecutionID, err)
+		}
+	}
+	if canceled {
+		l.Infof("执行 %s 在队列中已被取消, 跳过", msg.ExecutionID)
+		markExecution(l.ctx, l.svcCtx, msg.ExecutionID, taskrecord.StatusCanceled, bson.M{
+			"finished_at": time.Now(),
+		})
+		return l.svcCtx.RunTracker.Started(l.ctx, msg.ExecutionID)
+	}
+
+	task, policy, terr := loadRunnableTask(l.ctx, l.svcCtx, msg.TaskID)
+	if terr != nil {
+		// 查询失败可以重试，任务不存在或不可执行时重试也不会成功
+		err := fmt.Errorf("%s", terr.GetMessage())
+		if terr.Code == errors.InternalError {
+			return err
+		}
+		return taskqueue.Permanent(err)
+	}
+
+	// 执行环境在执行时加载，入队后对环境的修改对本次执行生效
+	env, err := l.loadEnvironment(msg.EnvironmentID)
+	if err != nil {
+		return err
+	}
+
+	executionID := msg.ExecutionID
+	startTime := time.Now()
+
+	// 管道在获取任务锁之前构建并注入执行环境，构建失败时无需释放任何资源
+	var taskPipelines []taskPipeline
+	jobType, source, priority := core.TypeApiFox, "apifox_sync", int32(0)
+	if isWorkflow(task) {
+		// 工作流节点任务的管道在构建时注入执行环境
+		wf, err := l.buildWorkflow(task, env, executionID)
+		if err != nil {
+			return err
+		}
+		jobType, source = core.TypeWorkflow, string(core.TypeWorkflow)
+		taskPipelines = append(taskPipelines, workflowPipeline{
+			Workflow: wf,
+			taskID:   task.TaskId,
+			timeout:  task.WorkflowSpec.Strategy.Timeout.Budget(),
+		})
+	} else {
+		taskPipelines, err = l.buildTaskPipelines(task, env, executionID)
+		if err != nil {
+			return err
+		}
+		if isApiTest(task) {
+			jobType, source = core.TypeAPI, "api_runtime"
+		} else {
+			priority = task.SyncSpec.Strategy.GetPriority()
+		}
+	}
+
+	for _, pipeline := range taskPipelines {
+		pipeline.BindEvents(executionID, l.svcCtx.Events)
+	}
+
+	// 按冲突策略获取任务锁，queue 策略锁被占用时消息延迟后重新入队，不占用消费者和执行器
+	var lease *lock.Lease
+	switch policy {
+	case lock.PolicyReject:
+		lease, err = l.svcCtx.Locker.TryAcquire(l.ctx, task.TaskId, executionID)
+	case lock.PolicyQueue:
+		lease, err = l.svcCtx.Locker.TryAcquire(l.ctx, task.TaskId, executionID)
+		if lock.IsLocked(err) {
+			l.Infof("任务 %s 正在执行, 执行 %s 稍后重新入队: %v", task.TaskId, executionID, err)
+			return taskqueue.Requeue(l.svcCtx.Locker.RetryInterval())
+		}
+	case lock.PolicyCancelOlder:
+		var preempted *lock.LeaseInfo
+		lease, preempted, err = l.svcCtx.Locker.Preempt(l.ctx, task.TaskId, executionID)
+		if preempted != nil {
+			l.Infof("执行 %s 取消了任务 %s 正在运行的执行 %s", executionID, task.TaskId, preempted.ExecutionID)
+		}
+	}
+	if err != nil {
+		if lock.IsLocked(err) {
+			return taskqueue.Permanent(fmt.Errorf("获取任务锁失败: %w", err))
+		}
+		return fmt.Errorf("获取任务锁失败: %w", err)
+	}
+
+	l.svcCtx.Events.Open(executionID)
+	for _, pipeline := range taskPipelines {
+		l.svcCtx.Executions.Register(executionID, pipeline)
+	}
+
+	// 所有数据源同步结束（或排队中被取消）后释放锁、移除执行，发布汇总事件并写入执行记录，失败时按重试配置重新入队
+	var runStart time.Time
+	finalize := func() {
+		if err := lease.Release(context.Background()); err != nil {
+			logx.Errorf("执行 %s 释放任务锁失败: %v", executionID, err)
+		}
+		l.svcCtx.Executions.Unregister(executionID)
+		summary := executionSummary(executionID, source, startTime, taskPipelines)
+		l.svcCtx.Events.Publish(summary)
+		l.svcCtx.Events.Close(executionID)
+
+		fields := bson.M{
+			"error":   summary.Error,
+			"summary": summary.Result,
+		}
+		if !runStart.IsZero() {
+			fields["duration_ms"] = summary.Timestamp.Sub(runStart).Milliseconds()
+		}
+
+		// 执行失败时按消息的重试配置重新入队，重试时重新构建管道并重新打开事件主题
+		// Retry 在投递前重新记录入队，等待重试期间的取消由消费者在到期时跳过
+		if summary.Status == core.TaskStatusFailed && l.svcCtx.TaskWorker != nil {
+			runErr := fmt.Errorf("%s", summary.Error)
+			if l.svcCtx.TaskWorker.Retryable(msg, runErr) {
+				markExecution(context.Background(), l.svcCtx, executionID, taskrecord.StatusRetrying, fields)
+				if err := l.svcCtx.TaskWorker.Retry(context.Background(), msg, runErr); err != nil {
+					logx.Errorf("执行 %s 重新入队失败: %v", executionID, err)
+					markExecution(context.Background(), l.svcCtx, executionID, taskrecord.StatusFailed, bson.M{
+						"error":       "重新入队失败: " + err.Error(),
+						"finished_at": time.Now(),
+					})
+				}
+				return
+			}
+			fields["finished_at"] = summary.Timestamp
+			markExecution(context.Background(), l.svcCtx, executionID, string(summary.Status), fields)
+			if err := l.svcCtx.TaskWorker.Retry(context.Background(), msg, runErr); err != nil {
+				logx.Errorf("执行 %s 转入死信队列失败: %v", executionID, err)
+			}
+			return
+		}
+
+		fields["finished_at"] = summary.Timestamp
+		markExecution(context.Background(), l.svcCtx, executionID, string(summary.Status), fields)
+	}
+
+	// 租约失效（被新执行抢占或续约失败）或其他实例请求取消时取消本次执行
+	cancelExecution := func(reason string) {
+		if l.svcCtx.Executor.Remove(executionID) {
+			return
+		}
+		if _, err := l.svcCtx.Executions.Cancel(context.Background(), executionID); err != nil {
+			logx.Errorf("%s后取消执行 %s 失败: %v", reason, executionID, err)
+		}
+	}
+	for _, pipeline := range taskPipelines {
+		pipeline.SetFence(lease)
+	}
+	lease.OnLost(func(err error) { cancelExecution("租约失效") })
+	lease.OnCancel(func() { cancelExecution("收到取消请求") })
+
+	// 提交到执行器排队，由执行器控制并发
+	markExecution(l.ctx, l.svcCtx, executionID, taskrecord.StatusQueued, nil)
+	position, err := l.svcCtx.Executor.Submit(&executor.Job{
+		ExecutionID: executionID,
+		TaskID:      task.TaskId,
+		Type:        jobType,
+		Priority:    priority,
+		Run: func(ctx context.Context) {
+			runStart = time.Now()
+			defer finalize()
+
+			var wg sync.WaitGroup
+			for _, pipeline := range taskPipelines {
+				wg.Add(1)
+				go func(pipeline taskPipeline) {
+					defer wg.Done()
+					if err := pipeline.run(ctx); err != nil {
+						logx.Errorf("执行 %s 的 %s 失败: %v", executionID, pipeline.sourceKey(), err)
+					}
+				}(pipeline)
+			}
+			wg.Wait()
+		},
+		Discard: func() {
+			if _, err := l.svcCtx.Executions.Cancel(context.Background(), executionID); err != nil {
+				logx.Errorf("取消排队中的执行 %s 失败: %v", executionID, err)
+			}
+			finalize()
+		},
+	})
+	if err != nil {
+		// 提交失败时清理本次准备的资源，由消息重试重新准备
+		lease.Release(context.Background())
+		l.svcCtx.Executions.Unregister(executionID)
+		l.svcCtx.Events.Close(executionID)
+		return fmt.Errorf("提交执行失败: %w", err)
+	}
+
+	if err := l.svcCtx.RunTracker.Started(l.ctx, executionID); err != nil {
+		l.Errorf("移除执行 %s 的入队记录失败: %v", executionID, err)
+	}
+	if position > 0 {
+		l.Infof("任务 %s 的执行 %s 已加入执行器队列, 当前排在第 %d 位", task.TaskId, executionID, position)
+	} else {
+		l.Infof("任务 %s 的执行 %s 已开始执行", task.TaskId, executionID)
+	}
+	return nil
+}
+
+// buildTaskPipelines 构建 API 测试任务或同步任务的管道，检查点按执行ID保存，重试或恢复本次执行时从上次进度继续
+// API 测试按 env 发送请求，apifox 同步管道不受执行环境影响
+func (l *RunTaskLogic) buildTaskPipelines(task *model.Task, env *api.Environment, executionID string) ([]taskPipeline, error) {
+	if !isApiTest(task) {
+		return l.buildSyncPipelines(task, executionID), nil
+	}
+	apiTest, err := buildApiTest(l.ctx, l.svcCtx, task, env, executionID)
+	if err != nil {
+		return nil, err
+	}
+	return []taskPipeline{apiTestPipeline{
+		ApiRuntimePipeline: apiTest,
+		timeout:            task.APISpec.Strategy.Timeout.Budget(),
+	}}, nil
+}
+
+// buildSyncPipelines 为同步任务的每个数据源构建 ApiFox 同步管道
+func (l *RunTaskLogic) buildSyncPipelines(task *model.Task, executionID string) []taskPipeline {
+	// 任务的超时预算，未配置时使用默认值
+	taskTimeout := pipelines.DefaultSyncTimeout
+	if timeout := task.SyncSpec.Strategy.GetTimeout(); timeout > 0 {
+		taskTimeout = time.Duration(timeout) * time.Second
+	}
+
+	var taskPipelines []taskPipeline
+
+	// 遍历所有数据源
+	for _, source := range task.SyncSpec.Source {
+		// 构建 MongoDB 配置列表
+		var mongoConfigs []tools.MongoConfig
+		for _, dest := range task.SyncSpec.Destination {
+			if dest.DestType == "mongodb" {
+				mongoConfigs = append(mongoConfigs, tools.MongoConfig{
+					MongoHost:   dest.MongoConfig.Host,
+					MongoPort:   parsePort(dest.MongoConfig.Port),
+					MongoUser:   dest.MongoConfig.Username,
+					MongoPasswd: dest.MongoConfig.Password,
+					UseDb:       dest.MongoConfig.Dbname[0], // 使用第一个数据库
+				})
+			}
+		}
+
+		// 初始化 pipeline
+		apifoxPipeline := &pipelines.ApiFoxSyncPipeline{
+			BasePipeline: core.NewBasePipeline("apifox_sync", "ApiFox 接口文档同步"),
+			Config: pipelines.ApiFoxSyncConfig{
+				ProjectID:   source.Apifox.ProjectId,
+				SharedDocID: source.Apifox.ProjectId,
+				Username:    source.Apifox.Username,
+				Password:    source.Apifox.Password,
+				Mongo:       mongoConfigs,
+			},
+			Client:  &pipelines.ApiClient{Client: &http.Client{}},
+			BaseURL: source.Apifox.Base,
+		}
+
+		// 检查点按本次执行的数据源保存，工作流中不同节点任务的同一数据源互不影响
+		apifoxPipeline.SetCheckpointer(core.NewCheckpointer(
+			l.svcCtx.CheckpointModel,
+			executionID,
+			fmt.Sprintf("%s:%s", task.TaskId, source.Apifox.ProjectId),
+			task.TaskId,
+			core.TypeApiFox,
+			core.DefaultCheckpointInterval,
+		))
+
+		apifoxPipeline.SetTaskRecord(l.svcCtx.TaskRecordModel, task.TaskId)
+		apifoxPipeline.SetTimeout(core.TimeoutTask, taskTimeout)
+		taskPipelines = append(taskPipelines, syncPipeline{ApiFoxSyncPipeline: apifoxPipeline})
+	}
+
+	return taskPipelines
+}
+
+// loadEnvironment 加载执行环境，未指定环境时返回 nil
+func (l *RunTaskLogic) loadEnvironment(envID string) (*api.Environment, error) {
+	if envID == "" {
+		return nil, nil
+	}
+	record, err := l.svcCtx.EnvironmentModel.FindOneByEnvID(l.ctx, envID)
+	if err == environment.ErrNotFound {
+		return nil, taskqueue.Permanent(fmt.Errorf("执行环境 %s 不存在", envID))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("查询执行环境 %s 失败: %w", envID, err)
+	}
+	return environmentservicelogic.RunnerEnvironment(record), nil
+}
+
+// markExecution 更新执行记录的状态，失败时只记录日志，不影响执行
+func markExecution(ctx context.Context, svcCtx *svc.ServiceContext, executionID, status string, fields bson.M) {
+	ok, err := svcCtx.TaskRecordModel.Transition(ctx, executionID, status, fields)
+	if err != nil {
+		logx.WithContext(ctx).Errorf("更新执行记录 %s 为 %s 失败: %v", executionID, status, err)
+	} else if !ok {
+		logx.WithContext(ctx).Infof("执行记录 %s 不存在或已结束, 忽略状态 %s", executionID, status)
+	}
+}
+
+// executionSummary 汇总各管道（同步任务为各数据源）的执行结果
+// 任一管道失败则整体失败，其次为取消，否则为完成
+func executionSummary(executionID, source string, startTime time.Time, taskPipelines []taskPipeline) core.ExecutionEvent {
+	ctx := context.Background()
+	status := core.TaskStatusCompleted
+	sources := make(map[string]interface{}, len(taskPipelines))
+	var errs []string
+	var timeoutLevel core.TimeoutLevel
+	for _, pipeline := range taskPipelines {
+		pipelineStatus := pipeline.GetStatus(ctx)
+		switch {
+		case pipelineStatus == core.TaskStatusFailed:
+			status = core.TaskStatusFailed
+		case pipelineStatus == core.TaskStatusCanceled && status != core.TaskStatusFailed:
+			status = core.TaskStatusCanceled
+		}
+		if err := pipeline.lastError(); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", pipeline.sourceKey(), err))
+			if level := core.TimeoutLevelOf(err); level != "" {
+				timeoutLevel = level
+			}
+		}
+		sources[pipeline.sourceKey()] = pipeline.GetMetrics(ctx)
+	}
+
+	endTime := time.Now()
+	result := map[string]interface{}{
+		"sources":    sources,
+		"start_time": startTime.Format(time.RFC3339),
+		"end_time":   endTime.Format(time.RFC3339),
+		"duration":   endTime.Sub(startTime).Seconds(),
+	}
+	// 记录超时发生的层级
+	if timeoutLevel != "" {
+		result["timeout_level"] = string(timeoutLevel)
+	}
+	return core.ExecutionEvent{
+		ExecutionID: executionID,
+		Type:        core.EventSummary,
+		Source:      source,
+		Status:      status,
+		Progress:    1.0,
+		Result:      result,
+		Error:       strings.Join(errs, "; "),
+		Timestamp:   endTime,
+	}
+}
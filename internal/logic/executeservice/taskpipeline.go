@@ -0,0 +1,340 @@
+package executeservicelogic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"Storage/internal/components/pipeline/core"
+	apiruntime "Storage/internal/components/pipeline/runner/api"
+	api "Storage/internal/components/pipeline/runner/api/apirunner"
+	"Storage/internal/components/pipeline/runner/api/apirunner/runner"
+	"Storage/internal/components/pipeline/runner/api/apirunner/template"
+	"Storage/internal/components/pipeline/runner/api/scene"
+	"Storage/internal/components/pipeline/runner/pipelines"
+	"Storage/internal/components/taskqueue"
+	apimodel "Storage/internal/model/api"
+	scenemodel "Storage/internal/model/scene"
+	model "Storage/internal/model/task"
+	"Storage/internal/svc"
+)
+
+// taskPipeline 一次执行中运行的管道
+// 同步任务每个数据源一个 ApiFox 同步管道，API 测试任务为一个按场景执行的流水线
+type taskPipeline interface {
+	Cancel(ctx context.Context) error
+	GetStatus(ctx context.Context) core.TaskStatus
+	GetMetrics(ctx context.Context) map[string]interface{}
+	BindEvents(executionID string, publisher core.EventPublisher)
+	SetFence(fence core.Fence)
+
+	// run 执行管道并等待结束
+	run(ctx context.Context) error
+	// sourceKey 管道在执行汇总中的标识
+	sourceKey() string
+	// lastError 管道执行失败的原因
+	lastError() error
+}
+
+// syncPipeline ApiFox 同步管道，执行在后台进行，run 等待同步结束
+type syncPipeline struct {
+	*pipelines.ApiFoxSyncPipeline
+}
+
+func (p syncPipeline) run(ctx context.Context) error {
+	if err := p.Execute(ctx); err != nil {
+		return err
+	}
+	<-p.Done()
+	return nil
+}
+
+func (p syncPipeline) sourceKey() string {
+	return p.Config.ProjectID
+}
+
+func (p syncPipeline) lastError() error {
+	return p.Error
+}
+
+// apiTestPipeline 按场景执行的 API 测试流水线，timeout 为任务级的超时预算
+type apiTestPipeline struct {
+	*apiruntime.ApiRuntimePipeline
+	timeout time.Duration
+}
+
+// SetFence API 测试不写入同步目标，无需校验防护令牌
+func (p apiTestPipeline) SetFence(fence core.Fence) {}
+
+func (p apiTestPipeline) run(ctx context.Context) error {
+	ctx, cancel := core.WithTimeout(ctx, core.TimeoutTask, p.timeout)
+	defer cancel()
+	_, err := p.Execute(ctx, nil)
+	return err
+}
+
+func (p apiTestPipeline) sourceKey() string {
+	return p.PipelineID
+}
+
+func (p apiTestPipeline) lastError() error {
+	if p.Error == nil {
+		return nil
+	}
+	if p.Error.Cause != nil {
+		return p.Error.Cause
+	}
+	return fmt.Errorf("%s", p.Error.Message)
+}
+
+// buildApiTest 按任务引用的场景模板构建 API 测试流水线，场景的步骤为模板中启用的关联接口
+// 已完成的场景记录在本次执行的检查点中，重试或恢复本次执行时跳过
+// 流水线使用本次执行的环境、OAuth2 令牌存储、HAR 记录器和磁带存储，env 为空时不使用执行环境
+// 场景或接口不存在、步骤配置无法解析、执行环境无效时重试也不会成功，返回 taskqueue.Permanent 错误
+func buildApiTest(ctx context.Context, svcCtx *svc.ServiceContext, task *model.Task, env *api.Environment, executionID string) (*apiruntime.ApiRuntimePipeline, error) {
+	sceneModel, err := svcCtx.SceneTemplateModel()
+	if err != nil {
+		return nil, fmt.Errorf("初始化场景模型失败: %w", err)
+	}
+
+	scenes := make([]*scene.ScenePipeline, 0, len(task.APISpec.Scenarios))
+	for _, ref := range task.APISpec.Scenarios {
+		tmpl, err := sceneModel.FindBySceneId(ctx, ref.ID)
+		if err == scenemodel.ErrNotFound || err == scenemodel.ErrInvalidObjectId || (err == nil && tmpl == nil) {
+			return nil, taskqueue.Permanent(fmt.Errorf("场景 %s 不存在", ref.ID))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("查询场景 %s 失败: %w", ref.ID, err)
+		}
+
+		var steps []*api.ApiPipeline
+		for _, related := range tmpl.RelatedApi {
+			if !related.Enabled {
+				continue
+			}
+			step, err := buildApiStep(ctx, svcCtx, related)
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, step)
+		}
+
+		scenePipeline := scene.NewScenePipeline(tmpl.SceneName, tmpl.SceneDesc, steps)
+		scenePipeline.SceneDefinition.SceneID = ref.ID
+		scenePipeline.SceneDefinition.Strategy = sceneStrategy(tmpl.Strategy)
+		scenePipeline.SceneDefinition.Cassette = tmpl.Cassette
+		scenes = append(scenes, scenePipeline)
+	}
+
+	pipeline := &apiruntime.ApiRuntimePipeline{
+		PipelineID:          task.TaskId,
+		PipelineName:        "api_runtime",
+		PipelineDescription: task.TaskName,
+		Scenes:              scenes,
+		Strategy:            task.APISpec.Strategy,
+		Stats:               &apiruntime.RuntimeStats{},
+		Checkpointer: core.NewCheckpointer(
+			svcCtx.CheckpointModel,
+			executionID,
+			task.TaskId,
+			task.TaskId,
+			core.TypeAPI,
+			core.DefaultCheckpointInterval,
+		),
+	}
+
+	// 令牌存储在执行环境之前设置，环境的认证方式创建时使用
+	pipeline.SetTokenStore(svcCtx.TokenStore)
+	if env != nil {
+		if err := pipeline.SetEnvironment(env); err != nil {
+			return nil, taskqueue.Permanent(err)
+		}
+	}
+	pipeline.SetHarRecorder(svcCtx.HarLogModel.Recorder(executionID))
+	pipeline.SetCassetteStore(svcCtx.CassetteLogModel)
+	return pipeline, nil
+}
+
+// buildApiStep 按关联接口的接口文档构建步骤，依赖、断言和提取器为 JSON 配置
+func buildApiStep(ctx context.Context, svcCtx *svc.ServiceContext, related *scenemodel.RelatedApi) (*api.ApiPipeline, error) {
+	doc, err := svcCtx.ApiModel.FindOneByApiID(ctx, related.ApiId)
+	if err != nil {
+		return nil, fmt.Errorf("查询接口 %s 失败: %w", related.ApiId, err)
+	}
+	if doc == nil {
+		return nil, taskqueue.Permanent(fmt.Errorf("接口 %s 不存在", related.ApiId))
+	}
+
+	name := related.Name
+	if name == "" {
+		name = doc.Name
+	}
+	headers := make(map[string]interface{}, len(doc.Headers))
+	for _, header := range doc.Headers {
+		headers[header.Name] = header.Value
+	}
+	spec := map[string]interface{}{
+		"api_id":  doc.ApiID,
+		"name":    name,
+		"method":  doc.Method,
+		"path":    doc.Path,
+		"headers": headers,
+	}
+	if err := apiRequestSpec(doc, spec); err != nil {
+		return nil, taskqueue.Permanent(fmt.Errorf("步骤 %s: %w", name, err))
+	}
+
+	for key, raw := range map[string]string{
+		"dependencies": related.Dependency,
+		"assertions":   related.Expect,
+		"extractors":   related.Extractor,
+	} {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			return nil, taskqueue.Permanent(fmt.Errorf("步骤 %s 的 %s 配置不是合法的 JSON: %w", name, key, err))
+		}
+		spec[key] = value
+	}
+
+	var apiRunner api.ApiRunner = runner.NewHttpRunner(nil)
+	if strings.EqualFold(doc.Method, api.MethodGRPC) {
+		apiRunner = runner.NewRpcRunner(nil)
+	}
+	step := api.NewApiPipeline(name, doc.Description, apiRunner, nil)
+	step.StepSpec = spec
+	return step, nil
+}
+
+// apiRequestSpec 按接口文档设置步骤的路径参数、查询参数和请求体，参数值取 ApiFox 文档中的示例值
+// 没有示例值的路径参数从依赖数据中按名称获取，没有示例值的查询参数和表单字段必填时发送空值，否则不发送
+func apiRequestSpec(doc *apimodel.Api, spec map[string]interface{}) error {
+	parameters, _ := doc.RawData["parameters"].(map[string]interface{})
+	pathParams, err := exampleValues(parameters["path"], false)
+	if err != nil {
+		return fmt.Errorf("路径参数 %w", err)
+	}
+	if len(pathParams) > 0 {
+		spec["path_params"] = pathParams
+	}
+	queryParams, err := exampleValues(parameters["query"], true)
+	if err != nil {
+		return fmt.Errorf("查询参数 %w", err)
+	}
+	if len(queryParams) > 0 {
+		spec["query_params"] = queryParams
+	}
+
+	requestBody, _ := doc.RawData["requestBody"].(map[string]interface{})
+	contentType, _ := requestBody["type"].(string)
+	switch {
+	case contentType == "" || contentType == "none":
+	case strings.Contains(contentType, "multipart/form-data"), strings.Contains(contentType, "x-www-form-urlencoded"):
+		fields, err := formFields(doc.Parameters, requestBody["parameters"])
+		if err != nil {
+			return fmt.Errorf("表单字段 %w", err)
+		}
+		spec["body_type"] = runner.BodyTypeForm
+		if strings.Contains(contentType, "multipart/form-data") {
+			spec["body_type"] = runner.BodyTypeMultipart
+		}
+		if len(fields) > 0 {
+			spec["body"] = fields
+		}
+	default:
+		example, ok := requestBody["example"]
+		if !ok || example == nil {
+			return nil
+		}
+		switch {
+		case strings.Contains(contentType, "json"):
+			spec["body_type"] = runner.BodyTypeJSON
+			// 文档中的 JSON 示例为字符串时按 JSON 发送，无法解析时原样发送
+			if raw, ok := example.(string); ok {
+				var value interface{}
+				if err := json.Unmarshal([]byte(raw), &value); err != nil {
+					spec["body_type"] = runner.BodyTypeRaw
+				} else {
+					example = value
+				}
+			}
+		case strings.Contains(contentType, "xml"):
+			spec["body_type"] = runner.BodyTypeXML
+		default:
+			spec["body_type"] = runner.BodyTypeRaw
+		}
+		spec["body"] = example
+	}
+	return nil
+}
+
+// exampleValues ApiFox 文档参数列表中各参数的示例值，没有示例值的参数在 required 为 true 且参数必填时取空值
+func exampleValues(list interface{}, required bool) (map[string]interface{}, error) {
+	items, _ := list.([]interface{})
+	values := make(map[string]interface{}, len(items))
+	for _, item := range items {
+		param, _ := item.(map[string]interface{})
+		name, _ := param["name"].(string)
+		if name == "" {
+			continue
+		}
+		if example, ok := param["example"]; ok && example != nil {
+			value, err := template.Stringify(example)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			values[name] = value
+		} else if isRequired, _ := param["required"].(bool); required && isRequired {
+			values[name] = ""
+		}
+	}
+	return values, nil
+}
+
+// formFields 表单请求体的字段为接口文档的 Parameters，示例值取自 ApiFox 文档的 requestBody.parameters
+// 文件字段无法从文档生成内容，不发送
+func formFields(params []apimodel.Parameter, list interface{}) (map[string]interface{}, error) {
+	examples, err := exampleValues(list, false)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]interface{}, len(params))
+	for _, param := range params {
+		if param.Name == "" || param.Type == "file" {
+			continue
+		}
+		if value, ok := examples[param.Name]; ok {
+			fields[param.Name] = value
+		} else if param.Required {
+			fields[param.Name] = ""
+		}
+	}
+	return fields, nil
+}
+
+// sceneStrategy 将场景模板的超时（秒）和重试配置转换为场景的执行策略
+// RetrySetting.MaxAttempts 与场景模板的 MaxRetry 同为重试次数（不含首次执行），直接对应
+func sceneStrategy(strategy *scenemodel.SceneStrategy) *scene.SceneStrategy {
+	if strategy == nil {
+		return nil
+	}
+	result := &scene.SceneStrategy{}
+	if timeout := strategy.Timeout; timeout != nil {
+		result.Timeout = &model.TimeoutSetting{
+			Enabled:  timeout.Enabled,
+			Duration: timeout.Budget(),
+		}
+	}
+	if retry := strategy.Retry; retry != nil {
+		result.Retry = &model.RetrySetting{
+			Enabled:     retry.Enabled,
+			MaxAttempts: retry.MaxRetry,
+			Interval:    time.Duration(retry.Interval) * time.Second,
+		}
+	}
+	return result
+}
@@ -0,0 +1,139 @@
+package executeservicelogic
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	apimodel "Storage/internal/model/api"
+	scenemodel "Storage/internal/model/scene"
+)
+
+func TestSceneStrategyRetry(t *testing.T) {
+	tests := []struct {
+		name  string
+		retry *scenemodel.SceneRetrySetting
+		// 期望的重试次数（不含首次执行）
+		wantRetries  int
+		wantInterval time.Duration
+	}{
+		{name: "未配置重试", retry: nil},
+		{name: "未启用", retry: &scenemodel.SceneRetrySetting{MaxRetry: 3, Interval: 1}},
+		{name: "重试两次", retry: &scenemodel.SceneRetrySetting{Enabled: true, MaxRetry: 2, Interval: 3}, wantRetries: 2, wantInterval: 3 * time.Second},
+		{name: "重试一次", retry: &scenemodel.SceneRetrySetting{Enabled: true, MaxRetry: 1}, wantRetries: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := sceneStrategy(&scenemodel.SceneStrategy{Retry: tt.retry})
+			policy := strategy.Retry.Policy()
+			if policy.MaxRetries != tt.wantRetries {
+				t.Errorf("MaxRetries = %d, want %d", policy.MaxRetries, tt.wantRetries)
+			}
+			if tt.wantRetries > 0 && policy.Interval != tt.wantInterval {
+				t.Errorf("Interval = %v, want %v", policy.Interval, tt.wantInterval)
+			}
+			// 与场景模板自身的重试策略一致
+			if want := tt.retry.Policy().MaxRetries; policy.MaxRetries != want {
+				t.Errorf("MaxRetries = %d, want %d from template policy", policy.MaxRetries, want)
+			}
+		})
+	}
+}
+
+func TestApiRequestSpec(t *testing.T) {
+	params := map[string]interface{}{
+		"path": []interface{}{
+			map[string]interface{}{"name": "id", "example": float64(42), "required": true},
+			map[string]interface{}{"name": "shop", "required": true},
+		},
+		"query": []interface{}{
+			map[string]interface{}{"name": "page", "example": "1"},
+			map[string]interface{}{"name": "size", "required": true},
+			map[string]interface{}{"name": "sort"},
+		},
+	}
+	formParams := []apimodel.Parameter{
+		{Name: "name", Type: "string", Required: true},
+		{Name: "age", Type: "integer"},
+		{Name: "tag", Type: "string"},
+		{Name: "avatar", Type: "file", Required: true},
+	}
+	formExamples := []interface{}{
+		map[string]interface{}{"name": "name", "example": "tom"},
+		map[string]interface{}{"name": "age", "example": float64(18)},
+	}
+
+	tests := []struct {
+		name   string
+		params []apimodel.Parameter
+		raw    map[string]interface{}
+		want   map[string]interface{}
+	}{
+		{
+			name: "路径参数和查询参数",
+			raw:  map[string]interface{}{"parameters": params, "requestBody": map[string]interface{}{"type": "none"}},
+			want: map[string]interface{}{
+				"path_params":  map[string]interface{}{"id": "42"},
+				"query_params": map[string]interface{}{"page": "1", "size": ""},
+			},
+		},
+		{
+			name:   "multipart 表单",
+			params: formParams,
+			raw:    map[string]interface{}{"requestBody": map[string]interface{}{"type": "multipart/form-data", "parameters": formExamples}},
+			want: map[string]interface{}{
+				"body_type": "multipart",
+				"body":      map[string]interface{}{"name": "tom", "age": "18"},
+			},
+		},
+		{
+			name:   "urlencoded 表单",
+			params: formParams[:1],
+			raw:    map[string]interface{}{"requestBody": map[string]interface{}{"type": "application/x-www-form-urlencoded"}},
+			want: map[string]interface{}{
+				"body_type": "form",
+				"body":      map[string]interface{}{"name": ""},
+			},
+		},
+		{
+			name: "JSON 示例",
+			raw:  map[string]interface{}{"requestBody": map[string]interface{}{"type": "application/json", "example": `{"name":"tom","tags":["a"]}`}},
+			want: map[string]interface{}{
+				"body_type": "json",
+				"body":      map[string]interface{}{"name": "tom", "tags": []interface{}{"a"}},
+			},
+		},
+		{
+			name: "无法解析的 JSON 示例原样发送",
+			raw:  map[string]interface{}{"requestBody": map[string]interface{}{"type": "application/json", "example": `{"name":`}},
+			want: map[string]interface{}{"body_type": "raw", "body": `{"name":`},
+		},
+		{
+			name: "XML 示例",
+			raw:  map[string]interface{}{"requestBody": map[string]interface{}{"type": "application/xml", "example": "<a/>"}},
+			want: map[string]interface{}{"body_type": "xml", "body": "<a/>"},
+		},
+		{
+			name: "没有示例的请求体",
+			raw:  map[string]interface{}{"requestBody": map[string]interface{}{"type": "application/json"}},
+			want: map[string]interface{}{},
+		},
+		{
+			name: "没有原始文档",
+			want: map[string]interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := map[string]interface{}{}
+			if err := apiRequestSpec(&apimodel.Api{Parameters: tt.params, RawData: tt.raw}, spec); err != nil {
+				t.Fatalf("apiRequestSpec() error = %v", err)
+			}
+			if !reflect.DeepEqual(spec, tt.want) {
+				t.Errorf("apiRequestSpec() = %v, want %v", spec, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package executeservicelogic
+
+import (
+	"context"
+	"time"
+
+	"Storage/internal/components/pipeline/core"
+	"Storage/internal/errors"
+	"Storage/internal/model/taskrecord"
+	"Storage/internal/svc"
+	"Storage/storage"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type WatchExecutionLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewWatchExecutionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *WatchExecutionLogic {
+	return &WatchExecutionLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// watchPollInterval 执行尚未开始时检查事件主题的间隔，观察其他实例上的执行时检查执行记录是否已结束的间隔
+const watchPollInterval = 500 * time.Millisecond
+
+// 订阅执行事件，执行结束后流关闭
+// 执行在本实例运行时订阅本实例的事件主题；在其他实例运行时通过共享发布订阅接收事件；
+// 执行已结束时推送执行记录中的汇总。执行在运行队列中等待（或等待重试）时按执行记录的状态等待执行开始
+func (l *WatchExecutionLogic) WatchExecution(in *storage.WatchExecutionRequest, stream storage.ExecuteService_WatchExecutionServer) error {
+	if in.ExecutionId == "" {
+		return stream.Send(&storage.WatchExecutionResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InvalidParameter),
+				Message: "执行ID不能为空",
+			},
+		})
+	}
+
+	// 重试的执行重新打开事件主题，已推送的事件不再重复推送
+	var lastSent time.Time
+	for {
+		if events, unsubscribe, ok := l.svcCtx.Events.Subscribe(in.ExecutionId); ok {
+			done, err := l.forward(stream, events, &lastSent, nil)
+			unsubscribe()
+			if err != nil || done {
+				return err
+			}
+		}
+
+		record, err := l.record(in.ExecutionId)
+		if err != nil {
+			l.Errorf("查询执行记录 %s 失败: %v", in.ExecutionId, err)
+			return stream.Send(&storage.WatchExecutionResponse{
+				Header: &storage.ResponseHeader{
+					Code:    int64(errors.InternalError),
+					Message: "查询执行记录失败: " + err.Error(),
+				},
+				ExecutionId: in.ExecutionId,
+			})
+		}
+		if record == nil {
+			if !lastSent.IsZero() {
+				return nil
+			}
+			return stream.Send(&storage.WatchExecutionResponse{
+				Header: &storage.ResponseHeader{
+					Code:    int64(errors.NotFound),
+					Message: "执行不存在",
+				},
+				ExecutionId: in.ExecutionId,
+			})
+		}
+		if taskrecord.IsFinished(record.Status) {
+			// 推送过的汇总不再重复推送
+			if summary := recordSummary(record); summary.Timestamp.After(lastSent) {
+				return stream.Send(toWatchResponse(summary))
+			}
+			return nil
+		}
+
+		// 执行在其他实例运行或尚未开始，通过共享发布订阅接收事件，期间定期检查执行是否已结束
+		if relay := l.svcCtx.Events.Relay(); relay != nil {
+			events, unsubscribe, err := relay.Subscribe(l.ctx, in.ExecutionId)
+			if err == nil {
+				done, err := l.forward(stream, events, &lastSent, l.finished(in.ExecutionId))
+				unsubscribe()
+				if err != nil || done {
+					return err
+				}
+				continue
+			}
+			l.Errorf("订阅执行 %s 的共享事件失败: %v", in.ExecutionId, err)
+		}
+
+		timer := time.NewTimer(watchPollInterval)
+		select {
+		case <-l.ctx.Done():
+			// 客户端断开
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// forward 推送事件直到执行结束，返回 true 表示观察结束
+// 执行失败后重新入队、finished 报告执行已结束或执行开始时返回 false，由调用方重新判断执行所在位置
+// finished 不为 nil 时定期调用，用于发现订阅前已广播的汇总
+func (l *WatchExecutionLogic) forward(stream storage.ExecuteService_WatchExecutionServer, events <-chan core.ExecutionEvent, lastSent *time.Time, finished func() bool) (bool, error) {
+	var poll <-chan time.Time
+	if finished != nil {
+		ticker := time.NewTicker(watchPollInterval)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+
+	for {
+		select {
+		case <-l.ctx.Done():
+			// 客户端断开
+			return true, nil
+		case <-poll:
+			if finished() {
+				return false, nil
+			}
+		case event, ok := <-events:
+			if !ok {
+				return false, nil
+			}
+			if !event.Timestamp.After(*lastSent) {
+				continue
+			}
+			if err := stream.Send(toWatchResponse(event)); err != nil {
+				l.Errorf("推送执行 %s 的事件失败: %v", event.ExecutionID, err)
+				return true, err
+			}
+			*lastSent = event.Timestamp
+			if event.Type == core.EventSummary {
+				return event.Status != core.TaskStatusFailed, nil
+			}
+			// 在其他实例观察时执行转到本实例运行，改为订阅本实例的事件主题
+			if finished != nil && l.svcCtx.Events.Local(event.ExecutionID) {
+				return false, nil
+			}
+		}
+	}
+}
+
+// record 查询执行记录，不存在时返回 nil
+func (l *WatchExecutionLogic) record(executionID string) (*taskrecord.TaskRecord, error) {
+	record, err := l.svcCtx.TaskRecordModel.FindByExecutionID(l.ctx, executionID)
+	if err == taskrecord.ErrNotFound {
+		return nil, nil
+	}
+	return record, err
+}
+
+// finished 返回检查执行是否已结束的函数，查询失败时视为未结束
+func (l *WatchExecutionLogic) finished(executionID string) func() bool {
+	return func() bool {
+		record, err := l.record(executionID)
+		if err != nil {
+			l.Errorf("查询执行记录 %s 失败: %v", executionID, err)
+			return false
+		}
+		return record == nil || taskrecord.IsFinished(record.Status)
+	}
+}
+
+// recordSummary 由已结束的执行记录构造汇总事件，用于事件主题已过期或执行在其他实例结束时
+func recordSummary(record *taskrecord.TaskRecord) core.ExecutionEvent {
+	timestamp := record.UpdatedAt
+	if record.FinishedAt != nil {
+		timestamp = *record.FinishedAt
+	}
+	return core.ExecutionEvent{
+		ExecutionID: record.ExecutionID,
+		Type:        core.EventSummary,
+		Source:      record.TaskType,
+		Status:      core.TaskStatus(record.Status),
+		Progress:    1.0,
+		Result:      record.Summary,
+		Error:       record.Error,
+		Timestamp:   timestamp,
+	}
+}
+
+// toWatchResponse 将执行事件转换为响应
+func toWatchResponse(event core.ExecutionEvent) *storage.WatchExecutionResponse {
+	return &storage.WatchExecutionResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "success",
+		},
+		ExecutionId: event.ExecutionID,
+		EventType:   string(event.Type),
+		Source:      event.Source,
+		Name:        event.Name,
+		Status:      string(event.Status),
+		Progress:    event.Progress,
+		Result:      toStruct(event.Result),
+		Error:       event.Error,
+		Timestamp:   toTimestamp(event.Timestamp),
+	}
+}
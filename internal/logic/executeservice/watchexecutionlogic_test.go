@@ -0,0 +1,192 @@
+package executeservicelogic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Storage/internal/components/pipeline/core"
+	"Storage/internal/model/taskrecord"
+	"Storage/internal/svc"
+	"Storage/storage"
+
+	"google.golang.org/grpc"
+)
+
+// fakeWatchStream 记录推送的事件
+type fakeWatchStream struct {
+	grpc.ServerStream
+	sent []*storage.WatchExecutionResponse
+}
+
+func (s *fakeWatchStream) Send(resp *storage.WatchExecutionResponse) error {
+	s.sent = append(s.sent, resp)
+	return nil
+}
+
+func newTestWatchLogic() *WatchExecutionLogic {
+	return NewWatchExecutionLogic(context.Background(), &svc.ServiceContext{Events: core.NewExecutionEventBus()})
+}
+
+func TestWatchForward(t *testing.T) {
+	start := time.Now()
+	event := func(offset int, eventType core.ExecutionEventType, status core.TaskStatus) core.ExecutionEvent {
+		return core.ExecutionEvent{
+			ExecutionID: "exec-1",
+			Type:        eventType,
+			Status:      status,
+			Timestamp:   start.Add(time.Duration(offset) * time.Millisecond),
+		}
+	}
+
+	tests := []struct {
+		name   string
+		events []core.ExecutionEvent
+		// 是否关闭事件 channel
+		close bool
+		// 执行记录是否已结束，nil 表示订阅本实例的事件主题
+		finished func() bool
+		// 已推送过的最后一个事件的时间偏移
+		lastSent  int
+		wantDone  bool
+		wantTypes []string
+	}{
+		{
+			name:      "完成汇总后结束",
+			events:    []core.ExecutionEvent{event(1, core.EventStatus, core.TaskStatusRunning), event(2, core.EventSummary, core.TaskStatusCompleted)},
+			wantDone:  true,
+			wantTypes: []string{"status", "summary"},
+		},
+		{
+			name:      "失败汇总后等待重试",
+			events:    []core.ExecutionEvent{event(1, core.EventSummary, core.TaskStatusFailed)},
+			wantTypes: []string{"summary"},
+		},
+		{
+			name:      "重试时不重复推送",
+			events:    []core.ExecutionEvent{event(1, core.EventStatus, core.TaskStatusRunning), event(3, core.EventSummary, core.TaskStatusCompleted)},
+			lastSent:  2,
+			wantDone:  true,
+			wantTypes: []string{"summary"},
+		},
+		{
+			name:      "主题关闭",
+			events:    []core.ExecutionEvent{event(1, core.EventStatus, core.TaskStatusRunning)},
+			close:     true,
+			wantTypes: []string{"status"},
+		},
+		{
+			name:      "其他实例上的执行已结束",
+			finished:  func() bool { return true },
+			wantTypes: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := make(chan core.ExecutionEvent, len(tt.events))
+			for _, e := range tt.events {
+				events <- e
+			}
+			if tt.close {
+				close(events)
+			}
+			stream := &fakeWatchStream{}
+			lastSent := start.Add(time.Duration(tt.lastSent) * time.Millisecond)
+
+			done, err := newTestWatchLogic().forward(stream, events, &lastSent, tt.finished)
+			if err != nil {
+				t.Fatalf("forward() error = %v", err)
+			}
+			if done != tt.wantDone {
+				t.Errorf("forward() done = %v, want %v", done, tt.wantDone)
+			}
+			types := []string{}
+			for _, resp := range stream.sent {
+				types = append(types, resp.EventType)
+			}
+			if len(types) != len(tt.wantTypes) {
+				t.Fatalf("sent = %v, want %v", types, tt.wantTypes)
+			}
+			for i := range types {
+				if types[i] != tt.wantTypes[i] {
+					t.Errorf("sent = %v, want %v", types, tt.wantTypes)
+				}
+			}
+		})
+	}
+}
+
+func TestWatchForwardSwitchesToLocal(t *testing.T) {
+	l := newTestWatchLogic()
+	events := make(chan core.ExecutionEvent, 1)
+	// 执行重试时转到本实例运行
+	l.svcCtx.Events.Open("exec-1")
+	events <- core.ExecutionEvent{ExecutionID: "exec-1", Type: core.EventStatus, Status: core.TaskStatusRunning, Timestamp: time.Now()}
+
+	stream := &fakeWatchStream{}
+	var lastSent time.Time
+	done, err := l.forward(stream, events, &lastSent, func() bool { return false })
+	if err != nil || done {
+		t.Errorf("forward() = %v, %v, want false to resubscribe locally", done, err)
+	}
+	if len(stream.sent) != 1 {
+		t.Errorf("sent %d events, want 1", len(stream.sent))
+	}
+}
+
+func TestRecordSummary(t *testing.T) {
+	finishedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	updatedAt := finishedAt.Add(time.Minute)
+
+	tests := []struct {
+		name   string
+		record *taskrecord.TaskRecord
+		want   core.ExecutionEvent
+	}{
+		{
+			name: "完成",
+			record: &taskrecord.TaskRecord{
+				ExecutionID: "exec-1",
+				TaskType:    "api",
+				Status:      taskrecord.StatusCompleted,
+				Summary:     map[string]interface{}{"duration": 1.5},
+				FinishedAt:  &finishedAt,
+				UpdatedAt:   updatedAt,
+			},
+			want: core.ExecutionEvent{ExecutionID: "exec-1", Source: "api", Status: core.TaskStatusCompleted, Timestamp: finishedAt},
+		},
+		{
+			name: "失败",
+			record: &taskrecord.TaskRecord{
+				ExecutionID: "exec-2",
+				TaskType:    "sync",
+				Status:      taskrecord.StatusFailed,
+				Error:       "断言失败",
+				FinishedAt:  &finishedAt,
+			},
+			want: core.ExecutionEvent{ExecutionID: "exec-2", Source: "sync", Status: core.TaskStatusFailed, Error: "断言失败", Timestamp: finishedAt},
+		},
+		{
+			name:   "缺少结束时间",
+			record: &taskrecord.TaskRecord{ExecutionID: "exec-3", Status: taskrecord.StatusCanceled, UpdatedAt: updatedAt},
+			want:   core.ExecutionEvent{ExecutionID: "exec-3", Status: core.TaskStatusCanceled, Timestamp: updatedAt},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := recordSummary(tt.record)
+			if got.Type != core.EventSummary || got.Progress != 1.0 {
+				t.Errorf("recordSummary() type = %s progress = %v, want summary 1.0", got.Type, got.Progress)
+			}
+			if got.ExecutionID != tt.want.ExecutionID || got.Source != tt.want.Source || got.Status != tt.want.Status ||
+				got.Error != tt.want.Error || !got.Timestamp.Equal(tt.want.Timestamp) {
+				t.Errorf("recordSummary() = %+v, want %+v", got, tt.want)
+			}
+			if tt.record.Summary != nil && got.Result["duration"] != 1.5 {
+				t.Errorf("recordSummary() result = %v, want record summary", got.Result)
+			}
+		})
+	}
+}
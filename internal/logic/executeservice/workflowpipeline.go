@@ -0,0 +1,217 @@
+package executeservicelogic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"Storage/internal/components/lock"
+	"Storage/internal/components/pipeline/core"
+	api "Storage/internal/components/pipeline/runner/api/apirunner"
+	"Storage/internal/components/pipeline/workflow"
+	"Storage/internal/components/taskqueue"
+	"Storage/internal/errors"
+	model "Storage/internal/model/task"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// workflowPipeline 按依赖关系执行引用任务的工作流，timeout 为任务级的超时预算
+type workflowPipeline struct {
+	*workflow.Workflow
+	taskID  string
+	timeout time.Duration
+}
+
+// SetFence 节点执行时各自获取节点任务的任务锁，工作流任务的锁无需传给节点
+func (p workflowPipeline) SetFence(fence core.Fence) {}
+
+func (p workflowPipeline) run(ctx context.Context) error {
+	ctx, cancel := core.WithTimeout(ctx, core.TimeoutTask, p.timeout)
+	defer cancel()
+	_, err := p.Execute(ctx, nil)
+	return err
+}
+
+func (p workflowPipeline) sourceKey() string {
+	return p.taskID
+}
+
+func (p workflowPipeline) lastError() error {
+	return p.Error
+}
+
+// taskRunner 工作流中执行一个已配置任务的节点，节点任务的管道在构建工作流时创建
+// 执行时单独获取节点任务的任务锁，与该任务的独立执行互斥
+type taskRunner struct {
+	*core.BasePipeline
+
+	taskID    string
+	locker    *lock.Locker
+	pipelines []taskPipeline
+}
+
+// 确保实现了 core.PipelineRunner 接口
+var _ core.PipelineRunner = (*taskRunner)(nil)
+
+// Execute 获取节点任务的锁后执行任务的全部管道，结果为各管道的执行指标
+func (r *taskRunner) Execute(ctx context.Context, spec map[string]interface{}) (map[string]interface{}, error) {
+	if err := r.Begin(ctx, spec); err != nil {
+		return nil, err
+	}
+	runCtx := r.ExecutionContext()
+
+	lease, err := r.locker.TryAcquire(runCtx, r.taskID, r.ExecutionID())
+	if err != nil {
+		err = fmt.Errorf("获取任务 %s 的任务锁失败: %w", r.taskID, err)
+		r.Finish(ctx, nil, err)
+		return nil, err
+	}
+	defer func() {
+		if err := lease.Release(context.Background()); err != nil {
+			logx.Errorf("执行 %s 释放节点任务 %s 的锁失败: %v", r.ExecutionID(), r.taskID, err)
+		}
+	}()
+	// 租约失效（被独立执行抢占或续约失败）时取消节点
+	lease.OnLost(func(err error) {
+		if err := r.Cancel(context.Background()); err != nil {
+			logx.Errorf("租约失效后取消节点任务 %s 失败: %v", r.taskID, err)
+		}
+	})
+
+	var wg sync.WaitGroup
+	for _, pipeline := range r.pipelines {
+		pipeline.SetFence(lease)
+		wg.Add(1)
+		go func(pipeline taskPipeline) {
+			defer wg.Done()
+			if err := pipeline.run(runCtx); err != nil {
+				logx.Errorf("执行 %s 的节点任务 %s 的 %s 失败: %v", r.ExecutionID(), r.taskID, pipeline.sourceKey(), err)
+			}
+		}(pipeline)
+	}
+	wg.Wait()
+
+	result, runErr := r.summary()
+	// 已通过 Cancel 取消，状态已流转
+	if r.GetStatus(ctx) == core.TaskStatusCanceled {
+		return result, context.Canceled
+	}
+	if err := r.Finish(ctx, result, runErr); err != nil && runErr == nil {
+		return result, err
+	}
+	return result, runErr
+}
+
+// summary 汇总各管道的执行指标和失败原因
+func (r *taskRunner) summary() (map[string]interface{}, error) {
+	ctx := context.Background()
+	sources := make(map[string]interface{}, len(r.pipelines))
+	var errs []string
+	for _, pipeline := range r.pipelines {
+		sources[pipeline.sourceKey()] = pipeline.GetMetrics(ctx)
+		switch status := pipeline.GetStatus(ctx); {
+		case pipeline.lastError() != nil:
+			errs = append(errs, fmt.Sprintf("%s: %v", pipeline.sourceKey(), pipeline.lastError()))
+		case status == core.TaskStatusFailed || status == core.TaskStatusCanceled:
+			errs = append(errs, fmt.Sprintf("%s: %s", pipeline.sourceKey(), status))
+		}
+	}
+
+	result := map[string]interface{}{
+		"task_id": r.taskID,
+		"sources": sources,
+	}
+	if len(errs) > 0 {
+		return result, fmt.Errorf("任务 %s 执行失败: %s", r.taskID, strings.Join(errs, "; "))
+	}
+	return result, nil
+}
+
+// Cancel 取消节点及节点任务中未结束的管道
+func (r *taskRunner) Cancel(ctx context.Context) error {
+	if err := r.BasePipeline.Cancel(ctx); err != nil {
+		return err
+	}
+	for _, pipeline := range r.pipelines {
+		if pipeline.GetStatus(ctx).IsTerminal() {
+			continue
+		}
+		if err := pipeline.Cancel(ctx); err != nil {
+			return fmt.Errorf("取消任务 %s 的 %s 失败: %w", r.taskID, pipeline.sourceKey(), err)
+		}
+	}
+	return nil
+}
+
+// BindEvents 绑定所属执行，节点任务的管道一并绑定
+func (r *taskRunner) BindEvents(executionID string, publisher core.EventPublisher) {
+	r.BasePipeline.BindEvents(executionID, publisher)
+	for _, pipeline := range r.pipelines {
+		pipeline.BindEvents(executionID, publisher)
+	}
+}
+
+// buildWorkflow 按工作流任务的配置构建工作流，节点任务的管道按本次执行的环境构建
+// 配置无效、节点任务不存在或不可执行时重试也不会成功，返回 taskqueue.Permanent 错误
+func (l *RunTaskLogic) buildWorkflow(task *model.Task, env *api.Environment, executionID string) (*workflow.Workflow, error) {
+	// 查询节点任务失败等可重试的错误
+	var retryable error
+
+	factory := core.NewPipelineFactory()
+	factory.Register(core.TypeTask, func(config *core.PipelineConfig) (core.PipelineRunner, error) {
+		var node workflow.TaskNodeConfig
+		if err := core.DecodeSpec(core.TypeTask, config.Config, &node); err != nil {
+			return nil, err
+		}
+
+		nodeTask, _, terr := loadRunnableTask(l.ctx, l.svcCtx, node.TaskID)
+		if terr != nil {
+			err := fmt.Errorf("节点任务 %s: %s", node.TaskID, terr.GetMessage())
+			if terr.Code == errors.InternalError {
+				retryable = err
+			}
+			return nil, err
+		}
+		if isWorkflow(nodeTask) {
+			return nil, fmt.Errorf("节点任务 %s 是工作流任务, 不支持嵌套", node.TaskID)
+		}
+
+		pipelines, err := l.buildTaskPipelines(nodeTask, env, executionID)
+		if err != nil {
+			if !taskqueue.IsPermanent(err) {
+				retryable = err
+			}
+			return nil, err
+		}
+
+		name := config.Name
+		if name == "" {
+			name = nodeTask.TaskName
+		}
+		return &taskRunner{
+			BasePipeline: core.NewBasePipeline(name, nodeTask.TaskDesc),
+			taskID:       nodeTask.TaskId,
+			locker:       l.svcCtx.Locker,
+			pipelines:    pipelines,
+		}, nil
+	})
+	workflow.Register(factory)
+
+	runner, err := factory.Create(&core.PipelineConfig{
+		Type:        core.TypeWorkflow,
+		Name:        task.TaskName,
+		Description: task.TaskDesc,
+		Config:      task.WorkflowSpec.Config,
+	})
+	if err != nil {
+		err = fmt.Errorf("构建工作流失败: %w", err)
+		if retryable != nil {
+			return nil, err
+		}
+		return nil, taskqueue.Permanent(err)
+	}
+	return runner.(*workflow.Workflow), nil
+}
@@ -0,0 +1,233 @@
+package executeservicelogic
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"Storage/internal/components/lock"
+	"Storage/internal/components/pipeline/core"
+	"Storage/internal/components/pipeline/workflow"
+)
+
+// fakeTaskPipeline 测试用的任务管道，run 结束后按返回的错误完成或失败
+type fakeTaskPipeline struct {
+	*core.BasePipeline
+	key   string
+	fence core.Fence
+	exec  func(ctx context.Context) error
+}
+
+func (p *fakeTaskPipeline) SetFence(fence core.Fence) {
+	p.fence = fence
+}
+
+func (p *fakeTaskPipeline) run(ctx context.Context) error {
+	if err := p.Begin(ctx, nil); err != nil {
+		return err
+	}
+	err := p.exec(p.ExecutionContext())
+	if p.GetStatus(ctx) == core.TaskStatusCanceled {
+		return err
+	}
+	p.Finish(ctx, nil, err)
+	return err
+}
+
+func (p *fakeTaskPipeline) sourceKey() string {
+	return p.key
+}
+
+func (p *fakeTaskPipeline) lastError() error {
+	return p.Error
+}
+
+// newTaskNode 创建执行一个任务管道的工作流节点，order 记录节点任务的执行顺序
+func newTaskNode(locker *lock.Locker, taskID string, order *[]string, mu *sync.Mutex, exec func(ctx context.Context) error) (*taskRunner, *fakeTaskPipeline) {
+	pipeline := &fakeTaskPipeline{
+		BasePipeline: core.NewBasePipeline(taskID, ""),
+		key:          taskID + "-source",
+		exec: func(ctx context.Context) error {
+			mu.Lock()
+			*order = append(*order, taskID)
+			mu.Unlock()
+			if exec == nil {
+				return nil
+			}
+			return exec(ctx)
+		},
+	}
+	return &taskRunner{
+		BasePipeline: core.NewBasePipeline(taskID, ""),
+		taskID:       taskID,
+		locker:       locker,
+		pipelines:    []taskPipeline{pipeline},
+	}, pipeline
+}
+
+func newTestLocker() *lock.Locker {
+	return lock.NewLocker(lock.NewMemoryStore(), lock.Options{TTL: time.Minute, Owner: "test"})
+}
+
+func TestWorkflowPipelineRunsTaskNodes(t *testing.T) {
+	locker := newTestLocker()
+	var order []string
+	var mu sync.Mutex
+
+	syncNode, syncSource := newTaskNode(locker, "sync", &order, &mu, nil)
+	apiNode, _ := newTaskNode(locker, "api", &order, &mu, nil)
+	reportNode, _ := newTaskNode(locker, "report", &order, &mu, nil)
+
+	wf := workflow.NewWorkflow("nightly", "")
+	for _, node := range []struct {
+		id        string
+		runner    core.PipelineRunner
+		dependsOn []string
+	}{
+		{"sync", syncNode, nil},
+		{"api", apiNode, []string{"sync"}},
+		{"report", reportNode, []string{"api"}},
+	} {
+		if err := wf.AddNode(node.id, node.runner, nil, node.dependsOn...); err != nil {
+			t.Fatalf("AddNode(%s) error = %v", node.id, err)
+		}
+	}
+	pipeline := workflowPipeline{Workflow: wf, taskID: "wf"}
+	pipeline.BindEvents("exec-1", nil)
+
+	if err := pipeline.run(context.Background()); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if got := strings.Join(order, ","); got != "sync,api,report" {
+		t.Errorf("执行顺序 = %s, want sync,api,report", got)
+	}
+	if status := pipeline.GetStatus(context.Background()); status != core.TaskStatusCompleted {
+		t.Errorf("GetStatus() = %s, want completed", status)
+	}
+	if pipeline.lastError() != nil {
+		t.Errorf("lastError() = %v, want nil", pipeline.lastError())
+	}
+	// 节点任务的管道使用节点任务自己的租约，执行结束后释放
+	if syncSource.fence == nil {
+		t.Error("节点任务的管道未设置防护令牌")
+	}
+	if syncSource.ExecutionID() != "exec-1" {
+		t.Errorf("节点任务管道的执行ID = %q, want exec-1", syncSource.ExecutionID())
+	}
+	if holder, err := locker.Holder(context.Background(), "sync"); err != nil || holder != nil {
+		t.Errorf("Holder(sync) = %v, %v, want released", holder, err)
+	}
+
+	// 上游结果按节点任务的管道汇总
+	for _, state := range wf.GetNodeStates(context.Background()) {
+		sources, _ := state.Result["sources"].(map[string]interface{})
+		if state.Result["task_id"] != state.ID || sources[state.ID+"-source"] == nil {
+			t.Errorf("node %s result = %v, want sources of task %s", state.ID, state.Result, state.ID)
+		}
+	}
+}
+
+func TestWorkflowPipelineNodeFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		// 准备节点任务，返回 api 节点的执行函数
+		setup     func(locker *lock.Locker) func(ctx context.Context) error
+		wantError string
+	}{
+		{
+			name: "节点任务执行失败",
+			setup: func(locker *lock.Locker) func(ctx context.Context) error {
+				return func(ctx context.Context) error { return errors.New("断言失败") }
+			},
+			wantError: "断言失败",
+		},
+		{
+			name: "节点任务正在独立执行",
+			setup: func(locker *lock.Locker) func(ctx context.Context) error {
+				if _, err := locker.TryAcquire(context.Background(), "api", "other-exec"); err != nil {
+					panic(err)
+				}
+				return nil
+			},
+			wantError: "获取任务 api 的任务锁失败",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locker := newTestLocker()
+			var order []string
+			var mu sync.Mutex
+
+			apiExec := tt.setup(locker)
+			syncNode, _ := newTaskNode(locker, "sync", &order, &mu, nil)
+			apiNode, _ := newTaskNode(locker, "api", &order, &mu, apiExec)
+			reportNode, _ := newTaskNode(locker, "report", &order, &mu, nil)
+
+			wf := workflow.NewWorkflow("nightly", "")
+			wf.AddNode("sync", syncNode, nil)
+			wf.AddNode("api", apiNode, nil, "sync")
+			wf.AddNode("report", reportNode, nil, "api")
+			pipeline := workflowPipeline{Workflow: wf, taskID: "wf"}
+			pipeline.BindEvents("exec-1", nil)
+
+			err := pipeline.run(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+				t.Fatalf("run() error = %v, want containing %q", err, tt.wantError)
+			}
+			if status := pipeline.GetStatus(context.Background()); status != core.TaskStatusFailed {
+				t.Errorf("GetStatus() = %s, want failed", status)
+			}
+			if status := reportNode.GetStatus(context.Background()); status != core.TaskStatusPending {
+				t.Errorf("下游节点状态 = %s, want pending", status)
+			}
+			for _, taskID := range order {
+				if taskID == "report" {
+					t.Error("上游失败后下游节点任务仍被执行")
+				}
+			}
+		})
+	}
+}
+
+func TestWorkflowPipelineCancel(t *testing.T) {
+	locker := newTestLocker()
+	var order []string
+	var mu sync.Mutex
+
+	running := make(chan struct{})
+	node, source := newTaskNode(locker, "api", &order, &mu, func(ctx context.Context) error {
+		close(running)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	wf := workflow.NewWorkflow("nightly", "")
+	wf.AddNode("api", node, nil)
+	pipeline := workflowPipeline{Workflow: wf, taskID: "wf"}
+	pipeline.BindEvents("exec-1", nil)
+
+	done := make(chan error, 1)
+	go func() { done <- pipeline.run(context.Background()) }()
+	<-running
+	if err := pipeline.Cancel(context.Background()); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("run() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Cancel() 后工作流未结束")
+	}
+	if status := source.GetStatus(context.Background()); status != core.TaskStatusCanceled {
+		t.Errorf("节点任务管道状态 = %s, want canceled", status)
+	}
+	if holder, err := locker.Holder(context.Background(), "api"); err != nil || holder != nil {
+		t.Errorf("Holder(api) = %v, %v, want released", holder, err)
+	}
+}
@@ -0,0 +1,72 @@
+package mockservicelogic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Storage/internal/components/mock"
+	"Storage/internal/model/mockoverride"
+	"Storage/internal/svc"
+	"Storage/storage"
+)
+
+// loadRoutes 由项目已同步的接口创建 Mock 路由
+func loadRoutes(ctx context.Context, svcCtx *svc.ServiceContext, projectID string) ([]*mock.Route, error) {
+	apis, err := svcCtx.ApiModel.FindByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("查询项目 %s 的接口失败: %w", projectID, err)
+	}
+	return mock.NewRoutes(apis), nil
+}
+
+// loadOverrides 查询项目的覆盖配置，按接口ID索引
+func loadOverrides(ctx context.Context, svcCtx *svc.ServiceContext, projectID string) (map[string]*mockoverride.MockOverride, error) {
+	records, err := svcCtx.MockOverrideModel.FindByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("查询项目 %s 的Mock覆盖配置失败: %w", projectID, err)
+	}
+	overrides := make(map[string]*mockoverride.MockOverride, len(records))
+	for _, record := range records {
+		overrides[record.ApiID] = record
+	}
+	return overrides, nil
+}
+
+// toOverride 转换为 Mock 服务使用的覆盖配置
+func toOverride(record *mockoverride.MockOverride) *mock.Override {
+	return &mock.Override{
+		Status:      record.Status,
+		Delay:       time.Duration(record.DelayMs) * time.Millisecond,
+		Body:        record.Body,
+		Headers:     record.Headers,
+		ContentType: record.ContentType,
+	}
+}
+
+func toOverrideProto(record *mockoverride.MockOverride) *storage.MockOverride {
+	if record == nil {
+		return nil
+	}
+	return &storage.MockOverride{
+		ApiId:       record.ApiID,
+		Status:      int32(record.Status),
+		DelayMs:     int32(record.DelayMs),
+		Body:        record.Body,
+		Headers:     record.Headers,
+		ContentType: record.ContentType,
+	}
+}
+
+// validateOverride 校验覆盖配置，返回错误信息
+func validateOverride(in *storage.MockOverride) string {
+	switch {
+	case in == nil || in.ApiId == "":
+		return "接口ID不能为空"
+	case in.Status != 0 && (in.Status < 100 || in.Status > 599):
+		return fmt.Sprintf("无效的状态码: %d", in.Status)
+	case in.DelayMs < 0 || time.Duration(in.DelayMs)*time.Millisecond > mock.MaxDelay:
+		return fmt.Sprintf("响应延迟需在 0 到 %d 毫秒之间", mock.MaxDelay.Milliseconds())
+	}
+	return ""
+}
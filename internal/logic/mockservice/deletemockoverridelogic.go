@@ -0,0 +1,67 @@
+package mockservicelogic
+
+import (
+	"context"
+
+	"Storage/internal/errors"
+	"Storage/internal/svc"
+	"Storage/storage"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteMockOverrideLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewDeleteMockOverrideLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteMockOverrideLogic {
+	return &DeleteMockOverrideLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 删除接口的 Mock 覆盖配置，恢复按接口定义返回
+func (l *DeleteMockOverrideLogic) DeleteMockOverride(in *storage.DeleteMockOverrideRequest) (*storage.DeleteMockOverrideResponse, error) {
+	if in.ProjectId == "" || in.ApiId == "" {
+		return &storage.DeleteMockOverrideResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InvalidParameter),
+				Message: "项目ID和接口ID不能为空",
+			},
+		}, nil
+	}
+
+	deleted, err := l.svcCtx.MockOverrideModel.Delete(l.ctx, in.ProjectId, in.ApiId)
+	if err != nil {
+		l.Errorf("删除接口 %s 的Mock覆盖配置失败: %v", in.ApiId, err)
+		return &storage.DeleteMockOverrideResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: "删除Mock覆盖配置失败: " + err.Error(),
+			},
+		}, nil
+	}
+	if deleted == 0 {
+		return &storage.DeleteMockOverrideResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.NotFound),
+				Message: "接口没有Mock覆盖配置",
+			},
+		}, nil
+	}
+
+	if server, ok := l.svcCtx.MockServers.Get(in.ProjectId); ok {
+		server.SetOverride(in.ApiId, nil)
+	}
+
+	return &storage.DeleteMockOverrideResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "删除Mock覆盖配置成功",
+		},
+	}, nil
+}
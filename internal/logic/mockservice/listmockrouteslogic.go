@@ -0,0 +1,92 @@
+package mockservicelogic
+
+import (
+	"context"
+
+	"Storage/internal/components/mock"
+	"Storage/internal/errors"
+	"Storage/internal/svc"
+	"Storage/storage"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListMockRoutesLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewListMockRoutesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListMockRoutesLogic {
+	return &ListMockRoutesLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 列出项目的 Mock 路由和覆盖配置，服务未启动时按已同步的接口列出
+func (l *ListMockRoutesLogic) ListMockRoutes(in *storage.ListMockRoutesRequest) (*storage.ListMockRoutesResponse, error) {
+	if in.ProjectId == "" {
+		return &storage.ListMockRoutesResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InvalidParameter),
+				Message: "项目ID不能为空",
+			},
+		}, nil
+	}
+
+	resp := &storage.ListMockRoutesResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "查询Mock路由成功",
+		},
+	}
+
+	var routes []*mock.Route
+	var err error
+	if server, ok := l.svcCtx.MockServers.Get(in.ProjectId); ok {
+		resp.Running = true
+		resp.Url = server.URL()
+		routes = server.Routes()
+	} else {
+		routes, err = loadRoutes(l.ctx, l.svcCtx, in.ProjectId)
+	}
+	if err != nil {
+		l.Errorf("%v", err)
+		return &storage.ListMockRoutesResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: err.Error(),
+			},
+		}, nil
+	}
+
+	overrides, err := loadOverrides(l.ctx, l.svcCtx, in.ProjectId)
+	if err != nil {
+		l.Errorf("%v", err)
+		return &storage.ListMockRoutesResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: err.Error(),
+			},
+		}, nil
+	}
+
+	resp.Routes = make([]*storage.MockRoute, 0, len(routes))
+	for _, route := range routes {
+		statuses := make([]int32, 0, len(route.Responses))
+		for _, response := range route.Responses {
+			statuses = append(statuses, int32(response.Status))
+		}
+		resp.Routes = append(resp.Routes, &storage.MockRoute{
+			ApiId:    route.ApiID,
+			Name:     route.Name,
+			Method:   route.Method,
+			Path:     route.Path,
+			Statuses: statuses,
+			Override: toOverrideProto(overrides[route.ApiID]),
+		})
+	}
+	return resp, nil
+}
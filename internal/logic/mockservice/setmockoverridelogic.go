@@ -0,0 +1,95 @@
+package mockservicelogic
+
+import (
+	"context"
+
+	"Storage/internal/errors"
+	"Storage/internal/model/mockoverride"
+	"Storage/internal/svc"
+	"Storage/storage"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SetMockOverrideLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewSetMockOverrideLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SetMockOverrideLogic {
+	return &SetMockOverrideLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 设置接口的 Mock 覆盖配置，Mock 服务运行中时立即生效
+func (l *SetMockOverrideLogic) SetMockOverride(in *storage.SetMockOverrideRequest) (*storage.SetMockOverrideResponse, error) {
+	if in.ProjectId == "" {
+		return &storage.SetMockOverrideResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InvalidParameter),
+				Message: "项目ID不能为空",
+			},
+		}, nil
+	}
+	if msg := validateOverride(in.Override); msg != "" {
+		return &storage.SetMockOverrideResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InvalidParameter),
+				Message: msg,
+			},
+		}, nil
+	}
+
+	api, err := l.svcCtx.ApiModel.FindOneByApiID(l.ctx, in.Override.ApiId)
+	if err != nil {
+		l.Errorf("查询接口 %s 失败: %v", in.Override.ApiId, err)
+		return &storage.SetMockOverrideResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: "查询接口失败: " + err.Error(),
+			},
+		}, nil
+	}
+	if api == nil || api.ProjectID != in.ProjectId {
+		return &storage.SetMockOverrideResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.NotFound),
+				Message: "项目中不存在该接口",
+			},
+		}, nil
+	}
+
+	record := &mockoverride.MockOverride{
+		ProjectID:   in.ProjectId,
+		ApiID:       in.Override.ApiId,
+		Status:      int(in.Override.Status),
+		DelayMs:     int(in.Override.DelayMs),
+		Body:        in.Override.Body,
+		Headers:     in.Override.Headers,
+		ContentType: in.Override.ContentType,
+	}
+	if err := l.svcCtx.MockOverrideModel.Upsert(l.ctx, record); err != nil {
+		l.Errorf("保存接口 %s 的Mock覆盖配置失败: %v", record.ApiID, err)
+		return &storage.SetMockOverrideResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: "保存Mock覆盖配置失败: " + err.Error(),
+			},
+		}, nil
+	}
+
+	if server, ok := l.svcCtx.MockServers.Get(in.ProjectId); ok {
+		server.SetOverride(record.ApiID, toOverride(record))
+	}
+
+	return &storage.SetMockOverrideResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "设置Mock覆盖配置成功",
+		},
+	}, nil
+}
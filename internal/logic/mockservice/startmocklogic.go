@@ -0,0 +1,102 @@
+package mockservicelogic
+
+import (
+	"context"
+	"fmt"
+
+	"Storage/internal/components/mock"
+	"Storage/internal/errors"
+	"Storage/internal/svc"
+	"Storage/storage"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type StartMockLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewStartMockLogic(ctx context.Context, svcCtx *svc.ServiceContext) *StartMockLogic {
+	return &StartMockLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 启动项目的 Mock 服务，已启动时重新加载接口和覆盖配置
+func (l *StartMockLogic) StartMock(in *storage.StartMockRequest) (*storage.StartMockResponse, error) {
+	if in.ProjectId == "" {
+		return &storage.StartMockResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InvalidParameter),
+				Message: "项目ID不能为空",
+			},
+		}, nil
+	}
+	if in.Port < 0 || in.Port > 65535 {
+		return &storage.StartMockResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InvalidParameter),
+				Message: fmt.Sprintf("无效的端口: %d", in.Port),
+			},
+		}, nil
+	}
+
+	routes, err := loadRoutes(l.ctx, l.svcCtx, in.ProjectId)
+	if err != nil {
+		l.Errorf("%v", err)
+		return &storage.StartMockResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: err.Error(),
+			},
+		}, nil
+	}
+	if len(routes) == 0 {
+		return &storage.StartMockResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.NotFound),
+				Message: "项目没有已同步的接口",
+			},
+		}, nil
+	}
+
+	records, err := loadOverrides(l.ctx, l.svcCtx, in.ProjectId)
+	if err != nil {
+		l.Errorf("%v", err)
+		return &storage.StartMockResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: err.Error(),
+			},
+		}, nil
+	}
+	overrides := make(map[string]*mock.Override, len(records))
+	for apiID, record := range records {
+		overrides[apiID] = toOverride(record)
+	}
+
+	server, err := l.svcCtx.MockServers.Start(in.ProjectId, int(in.Port), routes, overrides)
+	if err != nil {
+		l.Errorf("启动项目 %s 的Mock服务失败: %v", in.ProjectId, err)
+		return &storage.StartMockResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: "启动Mock服务失败: " + err.Error(),
+			},
+		}, nil
+	}
+
+	l.Infof("项目 %s 的Mock服务已启动: %s, 共 %d 个接口", in.ProjectId, server.URL(), len(routes))
+	return &storage.StartMockResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "启动Mock服务成功",
+		},
+		Url:        server.URL(),
+		RouteCount: int32(len(routes)),
+	}, nil
+}
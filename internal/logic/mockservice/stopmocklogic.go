@@ -0,0 +1,64 @@
+package mockservicelogic
+
+import (
+	"context"
+
+	"Storage/internal/components/mock"
+	"Storage/internal/errors"
+	"Storage/internal/svc"
+	"Storage/storage"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type StopMockLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewStopMockLogic(ctx context.Context, svcCtx *svc.ServiceContext) *StopMockLogic {
+	return &StopMockLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 停止项目的 Mock 服务
+func (l *StopMockLogic) StopMock(in *storage.StopMockRequest) (*storage.StopMockResponse, error) {
+	if in.ProjectId == "" {
+		return &storage.StopMockResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InvalidParameter),
+				Message: "项目ID不能为空",
+			},
+		}, nil
+	}
+
+	err := l.svcCtx.MockServers.Stop(l.ctx, in.ProjectId)
+	if err == mock.ErrNotRunning {
+		return &storage.StopMockResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.NotFound),
+				Message: "项目的Mock服务未启动",
+			},
+		}, nil
+	}
+	if err != nil {
+		l.Errorf("停止项目 %s 的Mock服务失败: %v", in.ProjectId, err)
+		return &storage.StopMockResponse{
+			Header: &storage.ResponseHeader{
+				Code:    int64(errors.InternalError),
+				Message: "停止Mock服务失败: " + err.Error(),
+			},
+		}, nil
+	}
+
+	return &storage.StopMockResponse{
+		Header: &storage.ResponseHeader{
+			Code:    int64(errors.Success),
+			Message: "停止Mock服务成功",
+		},
+	}, nil
+}
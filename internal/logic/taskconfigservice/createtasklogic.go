@@ -2,9 +2,14 @@ package taskconfigservicelogic
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
+	"Storage/internal/components/pipeline/core"
+	"Storage/internal/components/pipeline/workflow"
+	"Storage/internal/components/scheduler"
 	model "Storage/internal/model/task"
 	"Storage/internal/svc"
 	"Storage/storage"
@@ -71,6 +76,9 @@ func (l *CreateTaskLogic) CreateTask(in *storage.CreateTaskRequest) (*storage.Ta
 			Destination: syncSpec.Destination,
 			Strategy:    syncSpec.Strategy,
 		}
+	case in.GetWorkflowSpec() != nil:
+		// 工作流任务，配置已在参数校验时解析
+		task.WorkflowSpec = convertWorkflowSpec(in.GetWorkflowSpec())
 	default:
 		cresponse.Header.Code = int64(errors.InvalidParameter)
 		cresponse.Header.Message = "无效的任务配置类型"
@@ -87,6 +95,9 @@ func (l *CreateTaskLogic) CreateTask(in *storage.CreateTaskRequest) (*storage.Ta
 		return cresponse, nil
 	}
 
+	// 通知调度器加载新任务的定时配置
+	l.svcCtx.Scheduler.Notify(l.ctx)
+
 	// 5. 返回响应
 	cresponse.Meta = &storage.TaskMeta{
 		TaskId:   task.TaskId,
@@ -98,6 +109,8 @@ func (l *CreateTaskLogic) CreateTask(in *storage.CreateTaskRequest) (*storage.Ta
 		cresponse.Spec = &storage.TaskResponse_ApiSpec{ApiSpec: convertToAPISpecResponse(task.APISpec)}
 	case task.SyncSpec != nil:
 		cresponse.Spec = &storage.TaskResponse_SyncSpec{SyncSpec: convertToSyncSpecResponse(task.SyncSpec)}
+	case task.WorkflowSpec != nil:
+		cresponse.Spec = &storage.TaskResponse_WorkflowSpec{WorkflowSpec: convertToWorkflowSpecResponse(task.WorkflowSpec)}
 	}
 	cresponse.Type = int64(task.Type)
 	cresponse.CreateAt = task.CreateAt.Format(time.RFC3339)
@@ -134,6 +147,8 @@ func validateCreateRequest(in *storage.CreateTaskRequest) error {
 		return validateAPISpec(in.GetApiSpec())
 	case in.GetSyncSpec() != nil:
 		return validateSyncSpec(in.GetSyncSpec())
+	case in.GetWorkflowSpec() != nil:
+		return validateWorkflowSpec(in.GetWorkflowSpec())
 	default:
 		return errors.New(errors.InvalidParameter).WithDetails("请指定任务配置", nil)
 		// return errors.New("请指定任务配置")
@@ -173,8 +188,9 @@ func convertStrategy(s *storage.Strategy) model.TaskStrategy {
 			Interval:    time.Duration(s.RetryInterval) * time.Second,
 		},
 		AutoExecute: &model.AutoExecuteSetting{
-			Enabled: s.Auto,
-			Cron:    s.CronExpression,
+			Enabled:  s.Auto,
+			Cron:     s.CronExpression,
+			Timezone: s.Timezone,
 		},
 	}
 }
@@ -196,7 +212,7 @@ func validateAPISpec(spec *storage.TaskAPISpec) error {
 		// return errors.New("API任务策略不能为空")
 	}
 
-	return nil
+	return validateSchedule(spec.Strategy)
 }
 
 // 验证同步任务配置
@@ -218,6 +234,62 @@ func validateSyncSpec(spec *storage.TaskSyncSpec) error {
 		return errors.New(errors.InvalidParameter).WithDetails("同步任务策略不能为空", nil)
 	}
 
+	return validateSchedule(spec.Strategy)
+}
+
+// 验证工作流任务配置，节点只能引用已有任务，依赖关系不能成环
+func validateWorkflowSpec(spec *storage.TaskWorkflowSpec) error {
+	if spec == nil {
+		return errors.New(errors.InvalidParameter).WithDetails("工作流任务配置不能为空", nil)
+	}
+
+	var config map[string]interface{}
+	if err := json.Unmarshal([]byte(spec.Config), &config); err != nil {
+		return errors.New(errors.InvalidParameter).WithDetails("工作流配置不是合法的 JSON: "+err.Error(), nil)
+	}
+	workflowConfig, err := workflow.ParseConfig(config)
+	if err != nil {
+		return errors.New(errors.InvalidParameter).WithDetails(err.Error(), nil)
+	}
+	if err := workflow.ValidateConfig(workflowConfig); err != nil {
+		return errors.New(errors.InvalidParameter).WithDetails(err.Error(), nil)
+	}
+	for _, node := range workflowConfig.Nodes {
+		if node.Pipeline.Type != core.TypeTask {
+			return errors.New(errors.InvalidParameter).WithDetails(fmt.Sprintf("节点 %s 的类型只能为 %s", node.ID, core.TypeTask), nil)
+		}
+		var taskNode workflow.TaskNodeConfig
+		if err := core.DecodeSpec(core.TypeTask, node.Pipeline.Config, &taskNode); err != nil {
+			return errors.New(errors.InvalidParameter).WithDetails(fmt.Sprintf("节点 %s 的配置无效: %v", node.ID, err), nil)
+		}
+	}
+
+	if spec.Strategy == nil {
+		return errors.New(errors.InvalidParameter).WithDetails("工作流任务策略不能为空", nil)
+	}
+
+	return validateSchedule(spec.Strategy)
+}
+
+// 验证定时执行配置
+func validateSchedule(strategy *storage.Strategy) error {
+	if !strategy.Auto {
+		return nil
+	}
+	if strings.TrimSpace(strategy.CronExpression) == "" {
+		return errors.New(errors.InvalidParameter).WithDetails("开启自动执行时cron表达式不能为空", nil)
+	}
+
+	location := time.Local
+	if strategy.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(strategy.Timezone); err != nil {
+			return errors.New(errors.InvalidParameter).WithDetails("无效的时区: "+strategy.Timezone, nil)
+		}
+	}
+	if _, err := scheduler.ParseCron(strategy.CronExpression, location); err != nil {
+		return errors.New(errors.InvalidParameter).WithDetails("无效的cron表达式: "+err.Error(), nil)
+	}
 	return nil
 }
 
@@ -247,6 +319,29 @@ func convertToSyncSpecResponse(spec *model.SyncTaskSpec) *storage.TaskSyncSpec {
 	}
 }
 
+// 转换工作流任务配置，配置已通过 validateWorkflowSpec 校验
+func convertWorkflowSpec(spec *storage.TaskWorkflowSpec) *model.WorkflowTaskSpec {
+	var config map[string]interface{}
+	json.Unmarshal([]byte(spec.Config), &config)
+	return &model.WorkflowTaskSpec{
+		Config:   config,
+		Strategy: convertStrategy(spec.Strategy),
+	}
+}
+
+// 转换为工作流任务响应
+func convertToWorkflowSpecResponse(spec *model.WorkflowTaskSpec) *storage.TaskWorkflowSpec {
+	if spec == nil {
+		return nil
+	}
+
+	config, _ := json.Marshal(spec.Config)
+	return &storage.TaskWorkflowSpec{
+		Config:   string(config),
+		Strategy: convertToStrategyResponse(&spec.Strategy),
+	}
+}
+
 // 转换场景响应
 func convertToScenariosResponse(scenarios []model.ScenarioRef) []*storage.Scenarios {
 	if len(scenarios) == 0 {
@@ -272,6 +367,7 @@ func convertToStrategyResponse(s *model.TaskStrategy) *storage.Strategy {
 	return &storage.Strategy{
 		Auto:           s.AutoExecute.Enabled,
 		CronExpression: s.AutoExecute.Cron,
+		Timezone:       s.AutoExecute.Timezone,
 		RetryCount:     int32(s.Retry.MaxAttempts),
 		RetryInterval:  int32(s.Retry.Interval.Seconds()),
 		Timeout:        int32(s.Timeout.Duration.Seconds()),
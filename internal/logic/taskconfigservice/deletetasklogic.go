@@ -38,6 +38,9 @@ func (l *DeleteTaskLogic) DeleteTask(in *storage.DeleteTaskRequest) (*storage.De
 		logx.Error(err)
 		dresponse.Header.Code = int64(errors.DeleteMgoRecordError)
 		dresponse.Header.Message = errors.NewWithError(err, errors.DeleteMgoRecordError).WithDetails("删除任务记录错误", err).GetMessage()
+	} else {
+		// 通知调度器移除已删除任务的定时配置
+		l.svcCtx.Scheduler.Notify(l.ctx)
 	}
 	dresponse.Header.Message = "删除任务成功"
 	dresponse.AffectedRows = affectedRows
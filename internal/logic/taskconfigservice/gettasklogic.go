@@ -70,6 +70,10 @@ func (l *GetTaskLogic) GetTask(in *storage.GetTaskRequest) (*storage.TaskRespons
 		response.Spec = &storage.TaskResponse_SyncSpec{
 			SyncSpec: convertToSyncSpecResponse(task.SyncSpec),
 		}
+	case task.WorkflowSpec != nil:
+		response.Spec = &storage.TaskResponse_WorkflowSpec{
+			WorkflowSpec: convertToWorkflowSpecResponse(task.WorkflowSpec),
+		}
 	}
 
 	response.Header.Message = "获取任务成功"
@@ -67,6 +67,10 @@ func (l *ListTasksLogic) ListTasks(in *storage.Empty) (*storage.TaskListResponse
 			item.Spec = &storage.TaskListResponse_TaskItem_SyncSpec{
 				SyncSpec: convertToSyncSpecResponse(task.SyncSpec),
 			}
+		case task.WorkflowSpec != nil:
+			item.Spec = &storage.TaskListResponse_TaskItem_WorkflowSpec{
+				WorkflowSpec: convertToWorkflowSpecResponse(task.WorkflowSpec),
+			}
 		}
 		taskItems = append(taskItems, item)
 	}
@@ -66,13 +66,14 @@ func (l *UpdateTaskLogic) UpdateTask(in *storage.UpdateTaskRequest) (*storage.Ta
 
 	// 4. 更新任务信息
 	updateFields := model.Task{
-		TaskName: in.Name,
-		TaskDesc: in.Desc,
-		Enable:   existingTask.Enable,
-		Version:  existingTask.Version + 1, // 乐观锁版本控制
-		UpdateAt: time.Now(),
-		APISpec:  existingTask.APISpec,
-		SyncSpec: existingTask.SyncSpec,
+		TaskName:     in.Name,
+		TaskDesc:     in.Desc,
+		Enable:       existingTask.Enable,
+		Version:      existingTask.Version + 1, // 乐观锁版本控制
+		UpdateAt:     time.Now(),
+		APISpec:      existingTask.APISpec,
+		SyncSpec:     existingTask.SyncSpec,
+		WorkflowSpec: existingTask.WorkflowSpec,
 	}
 
 	// 处理具体配置更新
@@ -100,6 +101,13 @@ func (l *UpdateTaskLogic) UpdateTask(in *storage.UpdateTaskRequest) (*storage.Ta
 			Destination: spec.SyncSpec.Destination,
 			Strategy:    spec.SyncSpec.Strategy,
 		}
+	case *storage.UpdateTaskRequest_WorkflowSpec:
+		if err := validateWorkflowSpec(spec.WorkflowSpec); err != nil {
+			response.Header.Code = int64(errors.InvalidParameter)
+			response.Header.Message = err.(*errors.Error).GetMessage()
+			return response, nil
+		}
+		updateFields.WorkflowSpec = convertWorkflowSpec(spec.WorkflowSpec)
 	}
 
 	// 5. 持久化更新
@@ -110,6 +118,9 @@ func (l *UpdateTaskLogic) UpdateTask(in *storage.UpdateTaskRequest) (*storage.Ta
 		return response, nil
 	}
 
+	// 通知调度器重新加载定时配置
+	l.svcCtx.Scheduler.Notify(l.ctx)
+
 	// 6. 构造响应
 	updatedTask, _ := taskModel.FindOneByTaskID(l.ctx, in.TaskId)
 	return buildTaskResponse(updatedTask), nil
@@ -138,7 +149,7 @@ func validateUpdateRequest(in *storage.UpdateTaskRequest) error {
 	switch {
 	case in.GetApiSpec() != nil && in.GetSyncSpec() != nil:
 		return errors.New(errors.InvalidParameter).WithDetails("只能指定一种任务配置类型", nil)
-	case in.GetApiSpec() == nil && in.GetSyncSpec() == nil:
+	case in.GetApiSpec() == nil && in.GetSyncSpec() == nil && in.GetWorkflowSpec() == nil:
 		return errors.New(errors.InvalidParameter).WithDetails("必须指定任务配置", nil)
 	}
 
@@ -170,6 +181,10 @@ func buildTaskResponse(task *model.Task) *storage.TaskResponse {
 		resp.Spec = &storage.TaskResponse_SyncSpec{
 			SyncSpec: convertToSyncSpecResponse(task.SyncSpec),
 		}
+	case task.WorkflowSpec != nil:
+		resp.Spec = &storage.TaskResponse_WorkflowSpec{
+			WorkflowSpec: convertToWorkflowSpecResponse(task.WorkflowSpec),
+		}
 	}
 
 	return resp
@@ -74,7 +74,7 @@ func (m *defaultApiModel) UpdateApi(ctx context.Context, apiId string, data *Api
 
 func (m *defaultApiModel) FindOneByApiID(ctx context.Context, apiId string) (*Api, error) {
 	var api Api
-	err := m.conn.FindOne(ctx, bson.M{"apiId": apiId}, &api)
+	err := m.conn.FindOne(ctx, &api, bson.M{"apiId": apiId})
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, nil
@@ -86,7 +86,7 @@ func (m *defaultApiModel) FindOneByApiID(ctx context.Context, apiId string) (*Ap
 
 func (m *defaultApiModel) FindByProjectID(ctx context.Context, projectId string) ([]*Api, error) {
 	var apis []*Api
-	err := m.conn.Find(ctx, bson.M{"projectId": projectId}, &apis)
+	err := m.conn.Find(ctx, &apis, bson.M{"projectId": projectId})
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +100,7 @@ func (m *defaultApiModel) DeleteOneByApiID(ctx context.Context, apiId string) er
 
 func (m *defaultApiModel) FindAll(ctx context.Context) ([]*Api, error) {
 	var apis []*Api
-	err := m.conn.Find(ctx, bson.M{}, &apis)
+	err := m.conn.Find(ctx, &apis, bson.M{})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,71 @@
+package cassettelog
+
+import (
+	"context"
+	"time"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner/cassette"
+
+	"github.com/zeromicro/go-zero/core/stores/mon"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const CassetteLogCollectionName = "cassette_interaction" // 集合名称
+
+// CassetteLogModel 磁带的 mongo 存储，每次请求保存为一个文档
+type CassetteLogModel interface {
+	cassette.Store
+	// EnsureIndexes 创建磁带名称和序号的唯一索引
+	EnsureIndexes(ctx context.Context) error
+}
+
+type defaultCassetteLogModel struct {
+	conn *mon.Model
+}
+
+func NewCassetteLogModel(url, db, collection string) CassetteLogModel {
+	conn := mon.MustNewModel(url, db, collection)
+	return &defaultCassetteLogModel{
+		conn: conn,
+	}
+}
+
+func (m *defaultCassetteLogModel) Load(ctx context.Context, name string) ([]*cassette.Interaction, error) {
+	var data []*CassetteInteraction
+	err := m.conn.Find(ctx, &data, bson.M{"name": name}, options.Find().SetSort(bson.D{{Key: "index", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	interactions := make([]*cassette.Interaction, 0, len(data))
+	for _, item := range data {
+		interactions = append(interactions, item.Interaction)
+	}
+	return interactions, nil
+}
+
+func (m *defaultCassetteLogModel) Reset(ctx context.Context, name string) error {
+	_, err := m.conn.DeleteMany(ctx, bson.M{"name": name})
+	return err
+}
+
+// Append 按序号写入，同一序号已存在时替换
+func (m *defaultCassetteLogModel) Append(ctx context.Context, name string, index int, interaction *cassette.Interaction) error {
+	update := bson.M{
+		"$set": bson.M{
+			"interaction": interaction,
+			"createAt":    time.Now(),
+		},
+	}
+	_, err := m.conn.UpdateOne(ctx, bson.M{"name": name, "index": index}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (m *defaultCassetteLogModel) EnsureIndexes(ctx context.Context) error {
+	_, err := m.conn.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "name", Value: 1}, {Key: "index", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
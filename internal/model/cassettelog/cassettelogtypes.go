@@ -0,0 +1,18 @@
+package cassettelog
+
+import (
+	"time"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner/cassette"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CassetteInteraction 磁带中录制的一次请求和响应
+type CassetteInteraction struct {
+	ID          primitive.ObjectID    `bson:"_id,omitempty" json:"id"`
+	Name        string                `bson:"name" json:"name"`   // 磁带名称
+	Index       int                   `bson:"index" json:"index"` // 请求在磁带中的序号
+	Interaction *cassette.Interaction `bson:"interaction" json:"interaction"`
+	CreateAt    time.Time             `bson:"createAt" json:"createAt"`
+}
@@ -0,0 +1,9 @@
+package cassettelog
+
+import (
+	"github.com/zeromicro/go-zero/core/stores/mon"
+)
+
+var (
+	ErrNotFound = mon.ErrNotFound
+)
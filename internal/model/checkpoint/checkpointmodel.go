@@ -0,0 +1,113 @@
+package checkpoint
+
+import (
+	"context"
+	"time"
+
+	"Storage/internal/components/pipeline/core"
+
+	"github.com/zeromicro/go-zero/core/stores/mon"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const CheckpointCollectionName = "pipeline_checkpoint" // Collection name for pipeline checkpoints
+
+// CheckpointModel 检查点的 mongo 存储，每次执行的每个管道保存为一个文档
+type CheckpointModel interface {
+	core.CheckpointStore
+	// FindByExecutionID 查询执行的全部检查点
+	FindByExecutionID(ctx context.Context, executionId string) ([]*Checkpoint, error)
+	// Delete 删除执行的全部检查点
+	Delete(ctx context.Context, executionId string) error
+	// EnsureIndexes 创建执行ID和来源的唯一索引
+	EnsureIndexes(ctx context.Context) error
+}
+
+type defaultCheckpointModel struct {
+	conn *mon.Model
+}
+
+func NewCheckpointModel(url, db, collection string) CheckpointModel {
+	conn := mon.MustNewModel(url, db, collection)
+	return &defaultCheckpointModel{
+		conn: conn,
+	}
+}
+
+func (m *defaultCheckpointModel) Save(ctx context.Context, state *core.CheckpointState) error {
+	now := time.Now()
+	createAt := state.CreateAt
+	if createAt.IsZero() {
+		createAt = now
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"taskId":          state.TaskID,
+			"pipelineType":    string(state.PipelineType),
+			"status":          StatusActive,
+			"completedApiIds": state.CompletedApiIds,
+			"finishedScenes":  state.FinishedScenes,
+			"variables":       state.Variables,
+			"updateAt":        now,
+		},
+		"$setOnInsert": bson.M{
+			"createAt": createAt,
+		},
+	}
+
+	filter := bson.M{"executionId": state.ExecutionID, "source": state.Source}
+	_, err := m.conn.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (m *defaultCheckpointModel) Load(ctx context.Context, executionId, source string) (*core.CheckpointState, error) {
+	var data Checkpoint
+	err := m.conn.FindOne(ctx, &data, bson.M{
+		"executionId": executionId,
+		"source":      source,
+		"status":      StatusActive,
+	})
+	switch err {
+	case nil:
+		return data.State(), nil
+	case mon.ErrNotFound:
+		return nil, nil
+	default:
+		return nil, err
+	}
+}
+
+func (m *defaultCheckpointModel) MarkDone(ctx context.Context, executionId, source string) error {
+	_, err := m.conn.UpdateOne(ctx, bson.M{"executionId": executionId, "source": source}, bson.M{
+		"$set": bson.M{
+			"status":   StatusDone,
+			"updateAt": time.Now(),
+		},
+	})
+	return err
+}
+
+func (m *defaultCheckpointModel) FindByExecutionID(ctx context.Context, executionId string) ([]*Checkpoint, error) {
+	var data []*Checkpoint
+	err := m.conn.Find(ctx, &data, bson.M{"executionId": executionId}, options.Find().SetSort(bson.D{{Key: "source", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (m *defaultCheckpointModel) Delete(ctx context.Context, executionId string) error {
+	_, err := m.conn.DeleteMany(ctx, bson.M{"executionId": executionId})
+	return err
+}
+
+func (m *defaultCheckpointModel) EnsureIndexes(ctx context.Context) error {
+	_, err := m.conn.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "executionId", Value: 1}, {Key: "source", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
@@ -0,0 +1,138 @@
+package checkpoint
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"Storage/internal/components/pipeline/core"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// newTestModel 连接本地 MongoDB 创建独立的测试库，MongoDB 不可用时跳过
+// 地址默认为 mongodb://localhost:27017，可通过 MONGO_URI 指定
+func newTestModel(t *testing.T) CheckpointModel {
+	t.Helper()
+	uri := os.Getenv("MONGO_URI")
+	if uri == "" {
+		uri = "mongodb://localhost:27017"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri).SetServerSelectionTimeout(2*time.Second))
+	if err != nil {
+		t.Skipf("MongoDB 不可用: %v", err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		client.Disconnect(context.Background())
+		t.Skipf("MongoDB 不可用: %v", err)
+	}
+
+	db := fmt.Sprintf("checkpoint_test_%d", time.Now().UnixNano())
+	t.Cleanup(func() {
+		client.Database(db).Drop(context.Background())
+		client.Disconnect(context.Background())
+	})
+
+	model := NewCheckpointModel(uri, db, CheckpointCollectionName)
+	if err := model.EnsureIndexes(context.Background()); err != nil {
+		t.Fatalf("EnsureIndexes() error = %v", err)
+	}
+	return model
+}
+
+func TestCheckpointModelSaveAndLoad(t *testing.T) {
+	model := newTestModel(t)
+	ctx := context.Background()
+
+	state := &core.CheckpointState{
+		ExecutionID:     "exec-1",
+		Source:          "task-1:project-1",
+		TaskID:          "task-1",
+		PipelineType:    core.TypeApiFox,
+		CompletedApiIds: []string{"api-1"},
+		Variables:       map[string]interface{}{"token": "abc"},
+	}
+	if err := model.Save(ctx, state); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	// 再次保存时整体覆盖进度
+	state.CompletedApiIds = []string{"api-1", "api-2"}
+	if err := model.Save(ctx, state); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	tests := []struct {
+		name                string
+		executionID, source string
+		wantApis            string
+	}{
+		{name: "同一执行同一来源", executionID: "exec-1", source: "task-1:project-1", wantApis: "[api-1 api-2]"},
+		{name: "其他执行", executionID: "exec-2", source: "task-1:project-1"},
+		{name: "同一执行的其他来源", executionID: "exec-1", source: "task-1:project-2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := model.Load(ctx, tt.executionID, tt.source)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if tt.wantApis == "" {
+				if got != nil {
+					t.Errorf("Load() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("Load() = nil, want checkpoint")
+			}
+			if fmt.Sprint(got.CompletedApiIds) != tt.wantApis {
+				t.Errorf("CompletedApiIds = %v, want %s", got.CompletedApiIds, tt.wantApis)
+			}
+			if got.TaskID != "task-1" || got.PipelineType != core.TypeApiFox || got.Variables["token"] != "abc" {
+				t.Errorf("Load() = %+v, want task-1 %s with token", got, core.TypeApiFox)
+			}
+			if got.CreateAt.IsZero() {
+				t.Error("CreateAt 未写入")
+			}
+		})
+	}
+
+	records, err := model.FindByExecutionID(ctx, "exec-1")
+	if err != nil || len(records) != 1 {
+		t.Errorf("FindByExecutionID() = %d records, %v, want 1", len(records), err)
+	}
+}
+
+func TestCheckpointModelMarkDone(t *testing.T) {
+	model := newTestModel(t)
+	ctx := context.Background()
+
+	for _, source := range []string{"a", "b"} {
+		if err := model.Save(ctx, &core.CheckpointState{ExecutionID: "exec-1", Source: source}); err != nil {
+			t.Fatalf("Save(%s) error = %v", source, err)
+		}
+	}
+	if err := model.MarkDone(ctx, "exec-1", "a"); err != nil {
+		t.Fatalf("MarkDone() error = %v", err)
+	}
+
+	if got, err := model.Load(ctx, "exec-1", "a"); err != nil || got != nil {
+		t.Errorf("Load(a) = %+v, %v, want nil after MarkDone", got, err)
+	}
+	if got, err := model.Load(ctx, "exec-1", "b"); err != nil || got == nil {
+		t.Errorf("Load(b) = %+v, %v, want checkpoint", got, err)
+	}
+
+	if err := model.Delete(ctx, "exec-1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if records, err := model.FindByExecutionID(ctx, "exec-1"); err != nil || len(records) != 0 {
+		t.Errorf("FindByExecutionID() after Delete = %d records, %v, want 0", len(records), err)
+	}
+}
@@ -0,0 +1,46 @@
+package checkpoint
+
+import (
+	"time"
+
+	"Storage/internal/components/pipeline/core"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// StatusActive 执行中，可从该检查点恢复
+	StatusActive = "active"
+
+	// StatusDone 执行已结束，不再用于恢复
+	StatusDone = "done"
+)
+
+// Checkpoint 管道执行的检查点
+type Checkpoint struct {
+	ID              primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
+	ExecutionID     string                 `bson:"executionId" json:"executionId"`                             // 执行ID，与来源一起作为唯一键
+	Source          string                 `bson:"source" json:"source"`                                       // 执行中的管道标识
+	TaskID          string                 `bson:"taskId,omitempty" json:"taskId"`                             // 任务ID
+	PipelineType    string                 `bson:"pipelineType" json:"pipelineType"`                           // 管道类型
+	Status          string                 `bson:"status" json:"status"`                                       // 检查点状态
+	CompletedApiIds []string               `bson:"completedApiIds,omitempty" json:"completedApiIds,omitempty"` // 已完成的API ID
+	FinishedScenes  []string               `bson:"finishedScenes,omitempty" json:"finishedScenes,omitempty"`   // 已完成的场景ID
+	Variables       map[string]interface{} `bson:"variables,omitempty" json:"variables,omitempty"`             // 已解析的变量
+	CreateAt        time.Time              `bson:"createAt" json:"createAt"`
+	UpdateAt        time.Time              `bson:"updateAt" json:"updateAt"`
+}
+
+// State 转换为管道使用的检查点数据
+func (c *Checkpoint) State() *core.CheckpointState {
+	return &core.CheckpointState{
+		ExecutionID:     c.ExecutionID,
+		Source:          c.Source,
+		TaskID:          c.TaskID,
+		PipelineType:    core.PipelineType(c.PipelineType),
+		CompletedApiIds: c.CompletedApiIds,
+		FinishedScenes:  c.FinishedScenes,
+		Variables:       c.Variables,
+		CreateAt:        c.CreateAt,
+	}
+}
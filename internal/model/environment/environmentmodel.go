@@ -0,0 +1,131 @@
+package environment
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeromicro/go-zero/core/stores/mon"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const EnvironmentCollectionName = "environment" // 集合名称
+
+type EnvironmentModel interface {
+	// Insert 创建环境并生成环境ID，名称重复时返回 ErrDuplicateName
+	Insert(ctx context.Context, data *Environment) error
+	// FindOneByEnvID 按环境ID查询，不存在时返回 ErrNotFound
+	FindOneByEnvID(ctx context.Context, envId string) (*Environment, error)
+	// FindAll 按名称排序返回所有环境
+	FindAll(ctx context.Context) ([]*Environment, error)
+	// Update 按环境ID整体更新，不存在时返回 ErrNotFound，名称重复时返回 ErrDuplicateName
+	Update(ctx context.Context, data *Environment) error
+	// Delete 按环境ID删除，返回删除的条数
+	Delete(ctx context.Context, envId string) (int64, error)
+	// EnsureIndexes 创建环境ID和名称的唯一索引
+	EnsureIndexes(ctx context.Context) error
+}
+
+type defaultEnvironmentModel struct {
+	conn *mon.Model
+}
+
+func NewEnvironmentModel(url, db, collection string) EnvironmentModel {
+	conn := mon.MustNewModel(url, db, collection)
+	return &defaultEnvironmentModel{
+		conn: conn,
+	}
+}
+
+func (m *defaultEnvironmentModel) Insert(ctx context.Context, data *Environment) error {
+	now := time.Now()
+	data.EnvID = generateEnvID(data.Name)
+	data.CreateAt = now
+	data.UpdateAt = now
+
+	_, err := m.conn.InsertOne(ctx, data)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDuplicateName
+	}
+	return err
+}
+
+func (m *defaultEnvironmentModel) FindOneByEnvID(ctx context.Context, envId string) (*Environment, error) {
+	var data Environment
+	err := m.conn.FindOne(ctx, &data, bson.M{"envId": envId})
+	switch err {
+	case nil:
+		return &data, nil
+	case mon.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
+
+func (m *defaultEnvironmentModel) FindAll(ctx context.Context) ([]*Environment, error) {
+	var data []*Environment
+	err := m.conn.Find(ctx, &data, bson.M{}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (m *defaultEnvironmentModel) Update(ctx context.Context, data *Environment) error {
+	data.UpdateAt = time.Now()
+	result, err := m.conn.UpdateOne(ctx, bson.M{"envId": data.EnvID}, bson.M{
+		"$set": bson.M{
+			"name":        data.Name,
+			"desc":        data.Desc,
+			"baseUrl":     data.BaseURL,
+			"serviceUrls": data.ServiceURLs,
+			"variables":   data.Variables,
+			"auth":        data.Auth,
+			"tls":         data.TLS,
+			"updateAt":    data.UpdateAt,
+		},
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDuplicateName
+	}
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (m *defaultEnvironmentModel) Delete(ctx context.Context, envId string) (int64, error) {
+	return m.conn.DeleteOne(ctx, bson.M{"envId": envId})
+}
+
+func (m *defaultEnvironmentModel) EnsureIndexes(ctx context.Context) error {
+	_, err := m.conn.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "envId", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "name", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	})
+	return err
+}
+
+// generateEnvID 生成环境ID
+func generateEnvID(name string) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s-%d-%s",
+		name,
+		time.Now().UnixNano(),
+		uuid.New().String(),
+	)))
+	return fmt.Sprintf("env-%x", hash[:8])
+}
@@ -0,0 +1,33 @@
+package environment
+
+import (
+	"time"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner/auth"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Environment 执行环境，如 dev、staging、prod
+type Environment struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	EnvID       string             `bson:"envId" json:"envId"`                                 // 环境ID
+	Name        string             `bson:"name" json:"name"`                                   // 环境名称，唯一
+	Desc        string             `bson:"desc,omitempty" json:"desc"`                         // 环境描述
+	BaseURL     string             `bson:"baseUrl,omitempty" json:"baseUrl,omitempty"`         // 默认基础URL
+	ServiceURLs map[string]string  `bson:"serviceUrls,omitempty" json:"serviceUrls,omitempty"` // 服务名 -> 基础URL
+	Variables   map[string]string  `bson:"variables,omitempty" json:"variables,omitempty"`     // 环境变量
+	Auth        *auth.Config       `bson:"auth,omitempty" json:"auth,omitempty"`               // 认证配置
+	TLS         *TLSSetting        `bson:"tls,omitempty" json:"tls,omitempty"`                 // TLS 配置
+	CreateAt    time.Time          `bson:"createAt" json:"createAt"`
+	UpdateAt    time.Time          `bson:"updateAt" json:"updateAt"`
+}
+
+// TLSSetting 执行环境的TLS配置，证书和私钥均为 PEM 格式
+type TLSSetting struct {
+	CACert             string `bson:"caCert,omitempty" json:"caCert,omitempty"`                         // 自定义CA证书
+	ClientCert         string `bson:"clientCert,omitempty" json:"clientCert,omitempty"`                 // 客户端证书
+	ClientKey          string `bson:"clientKey,omitempty" json:"clientKey,omitempty"`                   // 客户端私钥
+	InsecureSkipVerify bool   `bson:"insecureSkipVerify,omitempty" json:"insecureSkipVerify,omitempty"` // 跳过服务端证书校验
+	ServerName         string `bson:"serverName,omitempty" json:"serverName,omitempty"`                 // 校验证书使用的服务端名称
+}
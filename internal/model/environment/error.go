@@ -0,0 +1,12 @@
+package environment
+
+import (
+	"errors"
+
+	"github.com/zeromicro/go-zero/core/stores/mon"
+)
+
+var (
+	ErrNotFound      = mon.ErrNotFound
+	ErrDuplicateName = errors.New("环境名称已存在")
+)
@@ -0,0 +1,81 @@
+package harlog
+
+import (
+	"context"
+	"time"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner/har"
+
+	"github.com/zeromicro/go-zero/core/stores/mon"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const HarLogCollectionName = "har_entry" // 集合名称
+
+type HarLogModel interface {
+	// Insert 写入一个条目
+	Insert(ctx context.Context, data *HarEntry) error
+	// FindByExecutionID 按请求开始时间返回执行的所有条目
+	FindByExecutionID(ctx context.Context, executionId string) ([]*HarEntry, error)
+	// Recorder 将条目记录到指定执行的记录器
+	Recorder(executionId string) har.Recorder
+	// EnsureIndexes 创建执行ID和请求开始时间的索引
+	EnsureIndexes(ctx context.Context) error
+}
+
+type defaultHarLogModel struct {
+	conn *mon.Model
+}
+
+func NewHarLogModel(url, db, collection string) HarLogModel {
+	conn := mon.MustNewModel(url, db, collection)
+	return &defaultHarLogModel{
+		conn: conn,
+	}
+}
+
+func (m *defaultHarLogModel) Insert(ctx context.Context, data *HarEntry) error {
+	if data.CreateAt.IsZero() {
+		data.CreateAt = time.Now()
+	}
+	_, err := m.conn.InsertOne(ctx, data)
+	return err
+}
+
+func (m *defaultHarLogModel) FindByExecutionID(ctx context.Context, executionId string) ([]*HarEntry, error) {
+	var data []*HarEntry
+	err := m.conn.Find(ctx, &data, bson.M{"executionId": executionId}, options.Find().SetSort(bson.D{
+		{Key: "entry.startedDateTime", Value: 1},
+		{Key: "_id", Value: 1},
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (m *defaultHarLogModel) Recorder(executionId string) har.Recorder {
+	return &recorder{model: m, executionId: executionId}
+}
+
+func (m *defaultHarLogModel) EnsureIndexes(ctx context.Context) error {
+	_, err := m.conn.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "executionId", Value: 1}, {Key: "entry.startedDateTime", Value: 1}},
+	})
+	return err
+}
+
+// recorder 按执行ID写入条目的记录器
+type recorder struct {
+	model       HarLogModel
+	executionId string
+}
+
+func (r *recorder) Record(ctx context.Context, entry *har.Entry) error {
+	return r.model.Insert(ctx, &HarEntry{
+		ExecutionID: r.executionId,
+		Entry:       entry,
+	})
+}
@@ -0,0 +1,17 @@
+package harlog
+
+import (
+	"time"
+
+	"Storage/internal/components/pipeline/runner/api/apirunner/har"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// HarEntry 执行中一次请求和响应的 HAR 条目
+type HarEntry struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ExecutionID string             `bson:"executionId" json:"executionId"` // 执行ID
+	Entry       *har.Entry         `bson:"entry" json:"entry"`             // HAR 1.2 条目
+	CreateAt    time.Time          `bson:"createAt" json:"createAt"`
+}
@@ -0,0 +1,9 @@
+package mockoverride
+
+import (
+	"github.com/zeromicro/go-zero/core/stores/mon"
+)
+
+var (
+	ErrNotFound = mon.ErrNotFound
+)
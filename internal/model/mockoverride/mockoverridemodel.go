@@ -0,0 +1,80 @@
+package mockoverride
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/mon"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const MockOverrideCollectionName = "mock_override" // 集合名称
+
+type MockOverrideModel interface {
+	// Upsert 按项目ID和接口ID整体写入覆盖配置，不存在时创建
+	Upsert(ctx context.Context, data *MockOverride) error
+	// FindByProjectID 查询项目的所有覆盖配置
+	FindByProjectID(ctx context.Context, projectId string) ([]*MockOverride, error)
+	// Delete 删除接口的覆盖配置，返回删除的条数
+	Delete(ctx context.Context, projectId, apiId string) (int64, error)
+	// EnsureIndexes 创建项目ID和接口ID的唯一索引
+	EnsureIndexes(ctx context.Context) error
+}
+
+type defaultMockOverrideModel struct {
+	conn *mon.Model
+}
+
+func NewMockOverrideModel(url, db, collection string) MockOverrideModel {
+	conn := mon.MustNewModel(url, db, collection)
+	return &defaultMockOverrideModel{
+		conn: conn,
+	}
+}
+
+func (m *defaultMockOverrideModel) Upsert(ctx context.Context, data *MockOverride) error {
+	now := time.Now()
+	if data.CreateAt.IsZero() {
+		data.CreateAt = now
+	}
+	data.UpdateAt = now
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":      data.Status,
+			"delayMs":     data.DelayMs,
+			"body":        data.Body,
+			"headers":     data.Headers,
+			"contentType": data.ContentType,
+			"updateAt":    data.UpdateAt,
+		},
+		"$setOnInsert": bson.M{
+			"createAt": data.CreateAt,
+		},
+	}
+	_, err := m.conn.UpdateOne(ctx, bson.M{"projectId": data.ProjectID, "apiId": data.ApiID}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (m *defaultMockOverrideModel) FindByProjectID(ctx context.Context, projectId string) ([]*MockOverride, error) {
+	var data []*MockOverride
+	err := m.conn.Find(ctx, &data, bson.M{"projectId": projectId})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (m *defaultMockOverrideModel) Delete(ctx context.Context, projectId, apiId string) (int64, error) {
+	return m.conn.DeleteOne(ctx, bson.M{"projectId": projectId, "apiId": apiId})
+}
+
+func (m *defaultMockOverrideModel) EnsureIndexes(ctx context.Context) error {
+	_, err := m.conn.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "projectId", Value: 1}, {Key: "apiId", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
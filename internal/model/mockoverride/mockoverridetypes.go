@@ -0,0 +1,21 @@
+package mockoverride
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockOverride 项目 Mock 服务中一个接口的覆盖配置
+type MockOverride struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ProjectID   string             `bson:"projectId" json:"projectId"`                         // ApiFox 项目ID
+	ApiID       string             `bson:"apiId" json:"apiId"`                                 // 接口ID
+	Status      int                `bson:"status,omitempty" json:"status,omitempty"`           // 响应状态码，为 0 时使用接口定义
+	DelayMs     int                `bson:"delayMs,omitempty" json:"delayMs,omitempty"`         // 响应延迟（毫秒）
+	Body        string             `bson:"body,omitempty" json:"body,omitempty"`               // 响应体，为空时使用接口定义
+	Headers     map[string]string  `bson:"headers,omitempty" json:"headers,omitempty"`         // 额外的响应头
+	ContentType string             `bson:"contentType,omitempty" json:"contentType,omitempty"` // 响应的 Content-Type
+	CreateAt    time.Time          `bson:"createAt" json:"createAt"`
+	UpdateAt    time.Time          `bson:"updateAt" json:"updateAt"`
+}
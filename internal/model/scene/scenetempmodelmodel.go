@@ -63,7 +63,7 @@ func (m *customScenetempmodelModel) FindAll(ctx context.Context, page, pageSize
 	findOptions := options.Find()
 	findOptions.SetSkip(int64((page - 1) * pageSize))
 	findOptions.SetLimit(int64(pageSize))
-	findOptions.SetSort(bson.D{{"createAt", -1}})
+	findOptions.SetSort(bson.D{{Key: "createAt", Value: -1}})
 
 	// Find documentsd
 	var results []*Scenetempmodel
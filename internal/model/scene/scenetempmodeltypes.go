@@ -3,6 +3,9 @@ package scene
 import (
 	"time"
 
+	"Storage/internal/components/pipeline/runner/api/apirunner/cassette"
+	"Storage/internal/components/retry"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -13,6 +16,7 @@ type Scenetempmodel struct {
 	SceneDesc  string             `bson:"sceneDesc,omitempty" json:"sceneDesc,omitempty"`
 	RelatedApi []*RelatedApi      `bson:"relatedApi,omitempty" json:"relatedApi,omitempty"`
 	Strategy   *SceneStrategy     `bson:"strategy,omitempty" json:"strategy,omitempty"` // 场景策略
+	Cassette   *cassette.Config   `bson:"cassette,omitempty" json:"cassette,omitempty"` // 磁带配置，按配置录制或回放场景的请求
 	UpdateAt   time.Time          `bson:"updateAt,omitempty" json:"updateAt,omitempty"`
 	CreateAt   time.Time          `bson:"createAt,omitempty" json:"createAt,omitempty"`
 }
@@ -36,8 +40,24 @@ type SceneTimeoutSetting struct {
 	Enabled  bool `bson:"enabled,omitempty" json:"enabled,omitempty"`   // 是否启用超时
 }
 
+// Budget 超时预算，单位为秒，未启用时返回 0 表示不限制
+func (s *SceneTimeoutSetting) Budget() time.Duration {
+	if s == nil || !s.Enabled || s.Duration <= 0 {
+		return 0
+	}
+	return time.Duration(s.Duration) * time.Second
+}
+
 type SceneRetrySetting struct {
 	Enabled  bool `bson:"enabled,omitempty" json:"enabled,omitempty"`   // 是否启用重试
 	MaxRetry int  `bson:"maxRetry,omitempty" json:"maxRetry,omitempty"` // 最大重试次数
 	Interval int  `bson:"interval,omitempty" json:"interval,omitempty"` // 重试间隔
 }
+
+// Policy 转换为重试策略，间隔单位为秒，未启用时不重试
+func (s *SceneRetrySetting) Policy() retry.Policy {
+	if s == nil || !s.Enabled || s.MaxRetry <= 0 {
+		return retry.None()
+	}
+	return retry.Constant(s.MaxRetry, time.Duration(s.Interval)*time.Second)
+}
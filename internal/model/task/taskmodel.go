@@ -160,13 +160,14 @@ func (m *customTaskModel) UpdateTask(ctx context.Context, taskId string, data *T
 
 	updateDoc := bson.M{
 		"$set": bson.M{
-			"taskName": data.TaskName,
-			"taskDesc": data.TaskDesc,
-			"enable":   data.Enable,
-			"version":  data.Version,
-			"apiSpec":  data.APISpec,
-			"syncSpec": data.SyncSpec,
-			"updateAt": time.Now(),
+			"taskName":     data.TaskName,
+			"taskDesc":     data.TaskDesc,
+			"enable":       data.Enable,
+			"version":      data.Version,
+			"apiSpec":      data.APISpec,
+			"syncSpec":     data.SyncSpec,
+			"workflowSpec": data.WorkflowSpec,
+			"updateAt":     time.Now(),
 		},
 	}
 
@@ -306,7 +307,7 @@ func (m *customTaskModel) FindByCreator(ctx context.Context, creator string, pag
 }
 
 func (m *customTaskModel) FindEnabledTasks(ctx context.Context, enabled bool) ([]*Task, error) {
-	filter := bson.M{"enabled": enabled}
+	filter := bson.M{"enable": enabled}
 	var result []*Task
 	err := m.conn.Find(ctx, &result, filter)
 	if pkgerr.Is(err, mon.ErrNotFound) {
@@ -1,6 +1,7 @@
 package task
 
 import (
+	"Storage/internal/components/retry"
 	"Storage/storage"
 	"time"
 
@@ -21,8 +22,9 @@ type Task struct {
 	UpdateAt time.Time          `bson:"updateAt" json:"updateAt"`
 
 	// 任务配置 - 根据Type使用不同的配置结构
-	APISpec  *APITaskSpec  `bson:"apiSpec,omitempty" json:"apiSpec,omitempty"`
-	SyncSpec *SyncTaskSpec `bson:"syncSpec,omitempty" json:"syncSpec,omitempty"`
+	APISpec      *APITaskSpec      `bson:"apiSpec,omitempty" json:"apiSpec,omitempty"`
+	SyncSpec     *SyncTaskSpec     `bson:"syncSpec,omitempty" json:"syncSpec,omitempty"`
+	WorkflowSpec *WorkflowTaskSpec `bson:"workflowSpec,omitempty" json:"workflowSpec,omitempty"`
 }
 
 // APITaskSpec API测试任务配置
@@ -41,6 +43,12 @@ type SyncTaskSpec struct {
 	Strategy    *storage.Strategy          `bson:"strategy,omitempty" json:"strategy,omitempty"`       // 任务执行策略，定时/重试/超时
 }
 
+// WorkflowTaskSpec 工作流任务配置，按节点的依赖关系依次执行引用的任务
+type WorkflowTaskSpec struct {
+	Config   map[string]interface{} `bson:"config" json:"config"`                         // 工作流配置，结构见 workflow.WorkflowConfig
+	Strategy TaskStrategy           `bson:"strategy,omitempty" json:"strategy,omitempty"` // 任务执行策略，定时/重试/超时
+}
+
 type ScenarioRef struct {
 	ID   string `bson:"id" json:"id"`
 	Name string `bson:"name" json:"name"`
@@ -59,6 +67,14 @@ type TimeoutSetting struct {
 	Duration time.Duration `bson:"duration" json:"duration"` // 超时时长（单位：纳秒）
 }
 
+// Budget 超时预算，未启用时返回 0 表示不限制
+func (s *TimeoutSetting) Budget() time.Duration {
+	if s == nil || !s.Enabled || s.Duration <= 0 {
+		return 0
+	}
+	return s.Duration
+}
+
 // 重试设置（示例：最多重试3次，间隔5秒）
 type RetrySetting struct {
 	Enabled     bool          `bson:"enabled" json:"enabled"`
@@ -66,8 +82,17 @@ type RetrySetting struct {
 	Interval    time.Duration `bson:"interval" json:"interval"`       // 重试间隔
 }
 
+// Policy 转换为重试策略，未启用时不重试
+func (s *RetrySetting) Policy() retry.Policy {
+	if s == nil || !s.Enabled || s.MaxAttempts <= 0 {
+		return retry.None()
+	}
+	return retry.Constant(s.MaxAttempts, s.Interval)
+}
+
 // 自动执行配置（示例：每天0点执行）
 type AutoExecuteSetting struct {
-	Enabled bool   `bson:"enabled" json:"enabled"`
-	Cron    string `bson:"cron,omitempty" json:"cron,omitempty"` // cron表达式（如："0 0 * * *"）
+	Enabled  bool   `bson:"enabled" json:"enabled"`
+	Cron     string `bson:"cron,omitempty" json:"cron,omitempty"`         // cron表达式（如："0 0 * * *"）
+	Timezone string `bson:"timezone,omitempty" json:"timezone,omitempty"` // cron表达式的时区（如："Asia/Shanghai"）
 }
@@ -2,6 +2,7 @@ package taskrecord
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -56,6 +57,168 @@ func (m *TaskRecordModel) Update(ctx context.Context, id primitive.ObjectID, upd
 	return err
 }
 
+// EnsureIndexes creates the indexes used by execution queries
+func (m *TaskRecordModel) EnsureIndexes(ctx context.Context) error {
+	_, err := m.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			// 历史记录没有执行ID，只对非空执行ID做唯一约束
+			Keys: bson.D{{Key: "execution_id", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"execution_id": bson.M{"$gt": ""}}),
+		},
+		{
+			Keys: bson.D{{Key: "task_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+	})
+	return err
+}
+
+// FindByExecutionID retrieves a task record by its execution ID
+func (m *TaskRecordModel) FindByExecutionID(ctx context.Context, executionID string) (*TaskRecord, error) {
+	var record TaskRecord
+	err := m.collection.FindOne(ctx, bson.M{"execution_id": executionID}).Decode(&record)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+// UpdateByExecutionID applies the update to the record of the execution, updated_at is always refreshed
+func (m *TaskRecordModel) UpdateByExecutionID(ctx context.Context, executionID string, update bson.M) error {
+	result, err := m.collection.UpdateOne(ctx, bson.M{"execution_id": executionID}, withUpdatedAt(update))
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Transition moves the execution to status and sets fields in the same update.
+// It returns false when the record does not exist or the current status cannot move to status,
+// so a finished execution is never overwritten by a late update.
+func (m *TaskRecordModel) Transition(ctx context.Context, executionID, status string, fields bson.M) (bool, error) {
+	from, ok := transitions[status]
+	if !ok {
+		return false, fmt.Errorf("unknown task record status: %s", status)
+	}
+
+	set := bson.M{"status": status}
+	for k, v := range fields {
+		set[k] = v
+	}
+	filter := bson.M{
+		"execution_id": executionID,
+		"status":       bson.M{"$in": from},
+	}
+	result, err := m.collection.UpdateOne(ctx, filter, withUpdatedAt(bson.M{"$set": set}))
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// Reopen moves a failed or canceled execution back to pending so it can be resumed with the same execution ID.
+// It returns false when the record does not exist or is not failed or canceled.
+func (m *TaskRecordModel) Reopen(ctx context.Context, executionID, trigger string) (bool, error) {
+	filter := bson.M{
+		"execution_id": executionID,
+		"status":       bson.M{"$in": []string{StatusFailed, StatusCanceled}},
+	}
+	update := withUpdatedAt(bson.M{
+		"$set":   bson.M{"status": StatusPending, "trigger": trigger, "attempts": 0},
+		"$unset": bson.M{"finished_at": "", "error": ""},
+	})
+	result, err := m.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// AppendResult appends a result to the record of the execution
+func (m *TaskRecordModel) AppendResult(ctx context.Context, executionID string, result map[string]interface{}) error {
+	return m.UpdateByExecutionID(ctx, executionID, bson.M{
+		"$push": bson.M{"result": result},
+	})
+}
+
+// AppendError appends an error message, only the latest MaxRecordErrors messages are kept
+func (m *TaskRecordModel) AppendError(ctx context.Context, executionID, message string) error {
+	return m.UpdateByExecutionID(ctx, executionID, bson.M{
+		"$push": bson.M{"errors": bson.M{
+			"$each":  []string{message},
+			"$slice": -MaxRecordErrors,
+		}},
+	})
+}
+
+// List retrieves the records matching filter, newest first, together with the total count
+func (m *TaskRecordModel) List(ctx context.Context, filter ListFilter, skip, limit int64) ([]*TaskRecord, int64, error) {
+	query := bson.M{}
+	if filter.TaskID != "" {
+		query["task_id"] = filter.TaskID
+	}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	createdAt := bson.M{}
+	if !filter.StartTime.IsZero() {
+		createdAt["$gte"] = filter.StartTime
+	}
+	if !filter.EndTime.IsZero() {
+		createdAt["$lte"] = filter.EndTime
+	}
+	if len(createdAt) > 0 {
+		query["created_at"] = createdAt
+	}
+
+	total, err := m.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(skip).
+		SetLimit(limit)
+	cursor, err := m.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	records := make([]*TaskRecord, 0)
+	if err = cursor.All(ctx, &records); err != nil {
+		return nil, 0, err
+	}
+	return records, total, nil
+}
+
+// withUpdatedAt adds updated_at to the $set of update
+func withUpdatedAt(update bson.M) bson.M {
+	merged := bson.M{}
+	for k, v := range update {
+		merged[k] = v
+	}
+	set := bson.M{"updated_at": time.Now()}
+	if existing, ok := update["$set"].(bson.M); ok {
+		for k, v := range existing {
+			set[k] = v
+		}
+	}
+	merged["$set"] = set
+	return merged
+}
+
 // UpdateByRecordId updates a task record by its RecordID
 func (m *TaskRecordModel) UpdateByRecordId(ctx context.Context, recordID string, update bson.M) error {
 	// 1. Query to validate the record exists
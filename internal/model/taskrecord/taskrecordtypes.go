@@ -77,16 +77,80 @@ import (
 // 	IOWrites    int64   `bson:"ioWrites" json:"ioWrites"`
 // }
 
+// 执行记录状态
+const (
+	// StatusPending 已投递到运行队列，等待消费
+	StatusPending = "pending"
+	// StatusQueued 已提交到执行器，排队等待执行
+	StatusQueued = "queued"
+	// StatusRunning 执行中
+	StatusRunning = "running"
+	// StatusRetrying 处理失败，等待队列重新投递
+	StatusRetrying = "retrying"
+	// StatusCompleted 执行完成
+	StatusCompleted = "completed"
+	// StatusFailed 执行失败
+	StatusFailed = "failed"
+	// StatusCanceled 执行被取消
+	StatusCanceled = "canceled"
+)
+
+// MaxRecordErrors 每条执行记录保留的错误明细条数
+const MaxRecordErrors = 100
+
+// activeStatuses 未结束的状态，结束后的记录不再变更状态
+var activeStatuses = []string{StatusPending, StatusQueued, StatusRunning, StatusRetrying}
+
+// transitions 各状态允许的来源状态
+var transitions = map[string][]string{
+	StatusQueued:    {StatusPending, StatusRetrying},
+	StatusRunning:   {StatusPending, StatusQueued, StatusRetrying},
+	StatusRetrying:  {StatusPending, StatusQueued, StatusRetrying},
+	StatusCompleted: activeStatuses,
+	StatusFailed:    activeStatuses,
+	StatusCanceled:  activeStatuses,
+}
+
+// IsFinished 状态是否已结束
+func IsFinished(status string) bool {
+	return status == StatusCompleted || status == StatusFailed || status == StatusCanceled
+}
+
+// TaskRecord 一次执行的记录，以执行ID标识
 type TaskRecord struct {
-	ID        primitive.ObjectID       `bson:"_id,omitempty"`
-	TaskID    string                   `bson:"task_id"`
-	TaskType  string                   `bson:"task_type"`
-	SubType   string                   `bson:"sub_type"` // 任务子类型, 比如 tasktype=sync, subtype=apifox
-	CreatedAt time.Time                `bson:"created_at"`
-	UpdatedAt time.Time                `bson:"updated_at"`
-	Status    string                   `bson:"status"`
-	TaskSpec  map[string]interface{}   `bson:"task_spec,omitempty"`
-	Result    []map[string]interface{} `bson:"result,omitempty"`
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	ExecutionID string             `bson:"execution_id"`
+	TaskID      string             `bson:"task_id"`
+	TaskType    string             `bson:"task_type"`
+	SubType     string             `bson:"sub_type"` // 任务子类型, 比如 tasktype=sync, subtype=apifox
+	Trigger     string             `bson:"trigger,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at"`
+	Status      string             `bson:"status"`
+	// 运行队列的投递次数
+	Attempts   int        `bson:"attempts"`
+	StartedAt  *time.Time `bson:"started_at,omitempty"`
+	FinishedAt *time.Time `bson:"finished_at,omitempty"`
+	DurationMs int64      `bson:"duration_ms,omitempty"`
+	// 执行环境ID
+	EnvironmentID string `bson:"environment_id,omitempty"`
+	// 最终的错误信息
+	Error string `bson:"error,omitempty"`
+	// 执行过程中的错误明细，只保留最近 MaxRecordErrors 条
+	Errors   []string                 `bson:"errors,omitempty"`
+	TaskSpec map[string]interface{}   `bson:"task_spec,omitempty"`
+	Result   []map[string]interface{} `bson:"result,omitempty"`
 	// 任务结果,sync 任务,表示是同步 dest 的状态
 	// 任务结果,apiruntime 任务,表示是最后的执行结果
+	// 执行结束时的汇总结果
+	Summary map[string]interface{} `bson:"summary,omitempty"`
+}
+
+// ListFilter 执行记录查询条件，零值字段不参与过滤
+type ListFilter struct {
+	TaskID string
+	Status string
+	// 按入队时间过滤
+	StartTime time.Time
+	EndTime   time.Time
 }
@@ -28,3 +28,57 @@ func (s *ExecuteServiceServer) ExecuteTask(ctx context.Context, in *storage.Exec
 	l := executeservicelogic.NewExecuteTaskLogic(ctx, s.svcCtx)
 	return l.ExecuteTask(in)
 }
+
+// 取消执行
+func (s *ExecuteServiceServer) CancelExecution(ctx context.Context, in *storage.CancelExecutionRequest) (*storage.CancelExecutionResponse, error) {
+	l := executeservicelogic.NewCancelExecutionLogic(ctx, s.svcCtx)
+	return l.CancelExecution(in)
+}
+
+// 恢复失败或已取消的执行
+func (s *ExecuteServiceServer) ResumeExecution(ctx context.Context, in *storage.ResumeExecutionRequest) (*storage.ResumeExecutionResponse, error) {
+	l := executeservicelogic.NewResumeExecutionLogic(ctx, s.svcCtx)
+	return l.ResumeExecution(in)
+}
+
+// 订阅执行事件，执行结束后流关闭
+func (s *ExecuteServiceServer) WatchExecution(in *storage.WatchExecutionRequest, stream storage.ExecuteService_WatchExecutionServer) error {
+	l := executeservicelogic.NewWatchExecutionLogic(stream.Context(), s.svcCtx)
+	return l.WatchExecution(in, stream)
+}
+
+// 查看执行队列
+func (s *ExecuteServiceServer) ListExecutionQueue(ctx context.Context, in *storage.ListExecutionQueueRequest) (*storage.ListExecutionQueueResponse, error) {
+	l := executeservicelogic.NewListExecutionQueueLogic(ctx, s.svcCtx)
+	return l.ListExecutionQueue(in)
+}
+
+// 调整排队中执行的优先级
+func (s *ExecuteServiceServer) ReorderExecution(ctx context.Context, in *storage.ReorderExecutionRequest) (*storage.ReorderExecutionResponse, error) {
+	l := executeservicelogic.NewReorderExecutionLogic(ctx, s.svcCtx)
+	return l.ReorderExecution(in)
+}
+
+// 查询执行记录
+func (s *ExecuteServiceServer) GetExecution(ctx context.Context, in *storage.GetExecutionRequest) (*storage.GetExecutionResponse, error) {
+	l := executeservicelogic.NewGetExecutionLogic(ctx, s.svcCtx)
+	return l.GetExecution(in)
+}
+
+// 按任务、状态、时间范围分页查询执行记录
+func (s *ExecuteServiceServer) ListExecutions(ctx context.Context, in *storage.ListExecutionsRequest) (*storage.ListExecutionsResponse, error) {
+	l := executeservicelogic.NewListExecutionsLogic(ctx, s.svcCtx)
+	return l.ListExecutions(in)
+}
+
+// 下载执行中所有请求和响应的 HAR 文件
+func (s *ExecuteServiceServer) GetExecutionHar(ctx context.Context, in *storage.GetExecutionHarRequest) (*storage.GetExecutionHarResponse, error) {
+	l := executeservicelogic.NewGetExecutionHarLogic(ctx, s.svcCtx)
+	return l.GetExecutionHar(in)
+}
+
+// 将执行中的请求导出为 curl 命令
+func (s *ExecuteServiceServer) ExportCurl(ctx context.Context, in *storage.ExportCurlRequest) (*storage.ExportCurlResponse, error) {
+	l := executeservicelogic.NewExportCurlLogic(ctx, s.svcCtx)
+	return l.ExportCurl(in)
+}
@@ -3,15 +3,36 @@ package svc
 import (
 	"context"
 	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/zeromicro/go-queue/kq"
+	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/core/stores/redis"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
+	"Storage/internal/components/eventrelay"
+	"Storage/internal/components/executor"
+	"Storage/internal/components/lock"
+	"Storage/internal/components/mock"
+	"Storage/internal/components/pipeline/core"
+	"Storage/internal/components/pipeline/runner/api/apirunner/auth"
+	"Storage/internal/components/scheduler"
+	"Storage/internal/components/taskqueue"
+	"Storage/internal/components/tools"
 	"Storage/internal/config"
 	"Storage/internal/model/api"
+	"Storage/internal/model/cassettelog"
+	"Storage/internal/model/checkpoint"
+	"Storage/internal/model/environment"
+	"Storage/internal/model/harlog"
+	"Storage/internal/model/mockoverride"
 	"Storage/internal/model/scene"
+	"Storage/internal/model/task"
+	"Storage/internal/model/taskrecord"
 )
 
 type ServiceContext struct {
@@ -22,6 +43,38 @@ type ServiceContext struct {
 	TaskPushClient *kq.Pusher
 	SceneTemplateModel func() (scene.ScenetempmodelModel, error)
 	ApiModel api.ApiModel
+	CheckpointModel checkpoint.CheckpointModel
+	// 执行环境
+	EnvironmentModel environment.EnvironmentModel
+	// 执行中请求和响应的 HAR 条目
+	HarLogModel harlog.HarLogModel
+	// Mock 服务的接口覆盖配置
+	MockOverrideModel mockoverride.MockOverrideModel
+	// 场景录制的磁带
+	CassetteLogModel cassettelog.CassetteLogModel
+	// 执行记录
+	TaskRecordModel *taskrecord.TaskRecordModel
+	// 进程内运行中的执行，用于按执行ID取消
+	Executions *core.ExecutionRegistry
+	// 执行事件总线，供 WatchExecution 订阅
+	Events *core.ExecutionEventBus
+	// 有界执行器，按优先级排队执行任务
+	Executor *executor.Executor
+	// 任务分布式锁，避免多个实例同时执行同一任务
+	Locker *lock.Locker
+	// OAuth2 令牌的缓存存储，执行时注入各场景和步骤
+	TokenStore auth.TokenStore
+	// 定时调度器，按任务的 cron 表达式触发执行
+	Scheduler *scheduler.Scheduler
+	// task_run 运行队列及其死信队列
+	TaskQueue       taskqueue.Queue
+	DeadLetterQueue taskqueue.Queue
+	// task_run 队列的消费者，执行中失败的执行按其重试策略重新入队，启动消费时设置
+	TaskWorker *taskqueue.Worker
+	// 已入队未开始的执行，用于取消
+	RunTracker *taskqueue.Tracker
+	// 进程内按项目运行的 Mock 服务
+	MockServers *mock.Registry
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
@@ -63,12 +116,183 @@ func NewServiceContext(c config.Config) *ServiceContext {
 		), nil
 	}
 
-	return &ServiceContext{
+	// 初始化执行记录模型
+	taskRecordModel := taskrecord.NewTaskRecordModel(client.Database(c.Database.Mongo.UseDb))
+	if err := taskRecordModel.EnsureIndexes(context.Background()); err != nil {
+		logx.Errorf("创建执行记录索引失败: %v", err)
+	}
+
+	// 初始化执行器
+	typeLimits := make(map[core.PipelineType]int, len(c.Executor.TypeLimits))
+	for pipelineType, limit := range c.Executor.TypeLimits {
+		typeLimits[core.PipelineType(pipelineType)] = limit
+	}
+
+	// 初始化任务锁
+	hostname, _ := os.Hostname()
+	owner := fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	store, relay := newStateStore(c)
+	locker := lock.NewLocker(store, lock.Options{
+		TTL:           c.Lock.TTL,
+		RenewInterval: c.Lock.RenewInterval,
+		RetryInterval: c.Lock.RetryInterval,
+		Owner:         owner,
+	})
+
+	// 初始化定时调度器，与任务锁共用存储，选主使用单独的键前缀
+	location, err := time.LoadLocation(c.Scheduler.Timezone)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid Scheduler.Timezone %s: %v", c.Scheduler.Timezone, err))
+	}
+	elector := lock.NewLocker(store, lock.Options{
+		TTL:           c.Lock.TTL,
+		RenewInterval: c.Lock.RenewInterval,
+		KeyPrefix:     scheduler.DefaultKeyPrefix,
+		Owner:         owner,
+	})
+
+	svcCtx := &ServiceContext{
 		Config: c,
 		// MongoClient: client,
 		SceneTemplateModel: sceneTemplateModelFunc,
 		ApiModel: apiModel,
+		TaskRecordModel: taskRecordModel,
+		Executions: core.NewExecutionRegistry(),
+		Events: core.NewExecutionEventBus(),
+		Executor: executor.NewExecutor(executor.Options{
+			Workers:    c.Executor.Workers,
+			QueueSize:  c.Executor.QueueSize,
+			TypeLimits: typeLimits,
+		}),
+		Locker: locker,
+		// OAuth2 令牌与任务锁共用存储，多实例共享已申请的令牌
+		TokenStore: store,
+		MockServers: mock.NewRegistry(mock.Options{
+			Host:          c.Mock.Host,
+			AdvertiseHost: c.Mock.AdvertiseHost,
+		}),
+	}
+
+	// 初始化 CheckpointModel
+	svcCtx.CheckpointModel = checkpoint.NewCheckpointModel(
+		svcCtx.GetMongoURI(),
+		c.Database.Mongo.UseDb,
+		checkpoint.CheckpointCollectionName,
+	)
+	if err := svcCtx.CheckpointModel.EnsureIndexes(context.Background()); err != nil {
+		logx.Errorf("创建检查点索引失败: %v", err)
+	}
+
+	// 初始化执行环境模型
+	svcCtx.EnvironmentModel = environment.NewEnvironmentModel(
+		svcCtx.GetMongoURI(),
+		c.Database.Mongo.UseDb,
+		environment.EnvironmentCollectionName,
+	)
+	if err := svcCtx.EnvironmentModel.EnsureIndexes(context.Background()); err != nil {
+		logx.Errorf("创建执行环境索引失败: %v", err)
+	}
+
+	// 初始化 HAR 条目模型
+	svcCtx.HarLogModel = harlog.NewHarLogModel(
+		svcCtx.GetMongoURI(),
+		c.Database.Mongo.UseDb,
+		harlog.HarLogCollectionName,
+	)
+	if err := svcCtx.HarLogModel.EnsureIndexes(context.Background()); err != nil {
+		logx.Errorf("创建HAR条目索引失败: %v", err)
+	}
+
+	// 初始化 Mock 覆盖配置模型
+	svcCtx.MockOverrideModel = mockoverride.NewMockOverrideModel(
+		svcCtx.GetMongoURI(),
+		c.Database.Mongo.UseDb,
+		mockoverride.MockOverrideCollectionName,
+	)
+	if err := svcCtx.MockOverrideModel.EnsureIndexes(context.Background()); err != nil {
+		logx.Errorf("创建Mock覆盖配置索引失败: %v", err)
+	}
+
+	// 初始化磁带模型
+	svcCtx.CassetteLogModel = cassettelog.NewCassetteLogModel(
+		svcCtx.GetMongoURI(),
+		c.Database.Mongo.UseDb,
+		cassettelog.CassetteLogCollectionName,
+	)
+	if err := svcCtx.CassetteLogModel.EnsureIndexes(context.Background()); err != nil {
+		logx.Errorf("创建磁带索引失败: %v", err)
+	}
+
+	taskLoader := func(ctx context.Context) ([]*task.Task, error) {
+		taskModel := task.NewTaskModel(svcCtx.GetMongoURI(), c.Database.Mongo.UseDb, task.TaskCollectionName)
+		return taskModel.FindEnabledTasks(ctx, true)
+	}
+	svcCtx.TaskPushClient, svcCtx.TaskQueue, svcCtx.DeadLetterQueue = newTaskQueue(c)
+	svcCtx.RunTracker = taskqueue.NewTracker(store, taskqueue.DefaultKeyPrefix, taskqueue.DefaultPendingTTL)
+	svcCtx.Scheduler = scheduler.NewScheduler(store, elector, taskLoader, scheduler.Options{
+		Location:     location,
+		MaxCatchUp:   c.Scheduler.MaxCatchUp,
+		PollInterval: c.Scheduler.PollInterval,
+		Owner:        owner,
+	})
+
+	if relay != nil {
+		svcCtx.Events.SetRelay(relay)
+	}
+
+	return svcCtx
+}
+
+// newTaskQueue 根据配置创建 task_run 队列（Kafka 时附带延迟 topic）和死信队列
+func newTaskQueue(c config.Config) (*kq.Pusher, taskqueue.Queue, taskqueue.Queue) {
+	if c.TaskQueue.Driver == "memory" {
+		return nil,
+			taskqueue.NewMemoryQueue(c.TaskQueue.MemorySize, c.TaskQueue.MemoryWorkers),
+			taskqueue.NewMemoryQueue(c.TaskQueue.MemorySize, 1)
+	}
+
+	taskPusher := kq.NewPusher(c.KqPusherConf.Brokers, c.KqPusherConf.TaskRunTopic)
+	deadLetterPusher := kq.NewPusher(c.KqPusherConf.Brokers, c.TaskQueue.DeadLetterTopic)
+	taskQueue := taskqueue.NewKafkaQueue(taskPusher, &c.TaskConsumerConf)
+
+	// 延迟 topic 使用独立的消费组，与运行 topic 的消费互不影响
+	delayConf := c.TaskConsumerConf
+	delayConf.Name += "Delay"
+	delayConf.Group += "_delay"
+	delayConf.Topic = c.TaskQueue.DelayTopic
+	taskQueue.SetDelayTopic(kq.NewPusher(c.KqPusherConf.Brokers, c.TaskQueue.DelayTopic), delayConf)
+
+	return taskPusher, taskQueue, taskqueue.NewKafkaQueue(deadLetterPusher, nil)
+}
+
+// stateStore 任务锁、调度器与运行队列共用的存储
+type stateStore interface {
+	lock.Store
+	scheduler.Store
+	taskqueue.Store
+}
+
+// newStateStore 根据配置创建任务锁、调度器与运行队列共用的存储，以及跨实例转发执行事件的发布订阅
+// 使用内存存储时为单实例部署，执行事件无需转发，返回的 relay 为 nil
+func newStateStore(c config.Config) (stateStore, core.EventRelay) {
+	if c.Lock.Store == "memory" {
+		return lock.NewMemoryStore(), nil
+	}
+
+	host, port, err := net.SplitHostPort(c.RedisConf.Host)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid RedisConf.Host %s: %v", c.RedisConf.Host, err))
+	}
+	redisPort, _ := strconv.Atoi(port)
+	redisClient := tools.NewRedisClient(tools.RedisConfig{
+		Host:     host,
+		Port:     redisPort,
+		Password: c.RedisConf.Pass,
+	})
+	if err := redisClient.Connect(); err != nil {
+		panic(fmt.Sprintf("Failed to connect Redis: %v", err))
 	}
+	return lock.NewRedisStore(redisClient), eventrelay.NewRedisRelay(redisClient, eventrelay.DefaultChannelPrefix)
 }
 
 // 生成MongoDB连接URI
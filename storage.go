@@ -1,13 +1,20 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"time"
 
+	"Storage/internal/components/taskqueue"
 	"Storage/internal/config"
+	"Storage/internal/errors"
+	executeservicelogic "Storage/internal/logic/executeservice"
+	environmentservice "Storage/internal/server/environmentservice"
 	executeservice "Storage/internal/server/executeservice"
 	generateservice "Storage/internal/server/generateservice"
 	interfaceservice "Storage/internal/server/interfaceservice"
+	mockservice "Storage/internal/server/mockservice"
 	reportservice "Storage/internal/server/reportservice"
 	sceneconfigservice "Storage/internal/server/sceneconfigservice"
 	taskconfigservice "Storage/internal/server/taskconfigservice"
@@ -16,6 +23,7 @@ import (
 	"Storage/storage"
 
 	"github.com/zeromicro/go-zero/core/conf"
+	"github.com/zeromicro/go-zero/core/proc"
 	"github.com/zeromicro/go-zero/core/service"
 	"github.com/zeromicro/go-zero/zrpc"
 	"google.golang.org/grpc"
@@ -34,9 +42,11 @@ func main() {
 		c.RpcServerConf,
 		func(grpcServer *grpc.Server) {
 			// 注册所有服务
+			storage.RegisterEnvironmentServiceServer(grpcServer, environmentservice.NewEnvironmentServiceServer(ctx))
 			storage.RegisterExecuteServiceServer(grpcServer, executeservice.NewExecuteServiceServer(ctx))
 			storage.RegisterGenerateServiceServer(grpcServer, generateservice.NewGenerateServiceServer(ctx))
 			storage.RegisterInterfaceServiceServer(grpcServer, interfaceservice.NewInterfaceServiceServer(ctx))
+			storage.RegisterMockServiceServer(grpcServer, mockservice.NewMockServiceServer(ctx))
 			storage.RegisterReportServiceServer(grpcServer, reportservice.NewReportServiceServer(ctx))
 			storage.RegisterSceneConfigServiceServer(grpcServer, sceneconfigservice.NewSceneConfigServiceServer(ctx))
 			storage.RegisterTaskConfigServiceServer(grpcServer, taskconfigservice.NewTaskConfigServiceServer(ctx))
@@ -50,6 +60,50 @@ func main() {
 	)
 	defer s.Stop()
 
+	// 消费 task_run 队列，失败时按配置重试，超过次数后转入死信队列
+	worker := taskqueue.NewWorker(ctx.TaskQueue, ctx.DeadLetterQueue, func(msgCtx context.Context, msg *taskqueue.RunMessage) error {
+		return executeservicelogic.NewRunTaskLogic(msgCtx, ctx).RunTask(msg)
+	}, taskqueue.Options{
+		MaxAttempts:  c.TaskQueue.MaxAttempts,
+		RetryBackoff: c.TaskQueue.RetryBackoff,
+		Tracker:      ctx.RunTracker,
+		OnDeadLetter: func(msgCtx context.Context, msg *taskqueue.RunMessage, err error) {
+			executeservicelogic.NewRunTaskLogic(msgCtx, ctx).DeadLetter(msg, err)
+		},
+	})
+	ctx.TaskWorker = worker
+	ctx.TaskQueue.Start(worker.Handle)
+
+	// 定时调度通过 ExecuteTask 投递到 task_run 队列，与手动执行共用执行器与任务锁
+	if c.Scheduler.Enabled {
+		ctx.Scheduler.Start(func(triggerCtx context.Context, taskID string) error {
+			resp, err := executeservicelogic.NewExecuteTaskLogic(triggerCtx, ctx).ExecuteTask(&storage.ExecuteTaskRequest{
+				TaskId:  taskID,
+				Trigger: "schedule",
+			})
+			if err != nil {
+				return err
+			}
+			if resp.Header.Code != int64(errors.Success) {
+				return fmt.Errorf("%s", resp.Header.Message)
+			}
+			return nil
+		})
+	}
+
+	// 退出时停止调度和队列消费，丢弃排队中的执行，并等待运行中的执行结束，最后关闭 Mock 服务
+	proc.AddShutdownListener(func() {
+		ctx.Scheduler.Stop()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		ctx.TaskQueue.Stop()
+		ctx.DeadLetterQueue.Stop()
+		if err := ctx.Executor.Stop(shutdownCtx); err != nil {
+			fmt.Printf("Executor stop timeout: %v\n", err)
+		}
+		ctx.MockServers.Close(shutdownCtx)
+	})
+
 	fmt.Printf("Starting rpc server at %s...\n", c.ListenOn)
 	s.Start()
 }
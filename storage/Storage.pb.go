@@ -916,6 +916,59 @@ func (x *TaskSyncSpec) GetStrategy() *Strategy {
 	return nil
 }
 
+// workflow任务的 Spec，按依赖关系依次执行引用的任务
+type TaskWorkflowSpec struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        string                 `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`     // 工作流配置（JSON），nodes 为节点列表，节点的 pipeline 为 {"type": "task", "config": {"task_id": "..."}}
+	Strategy      *Strategy              `protobuf:"bytes,2,opt,name=strategy,proto3" json:"strategy,omitempty"` // 任务执行策略，定时/重试/超时
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskWorkflowSpec) Reset() {
+	*x = TaskWorkflowSpec{}
+	mi := &file_Storage_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskWorkflowSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskWorkflowSpec) ProtoMessage() {}
+
+func (x *TaskWorkflowSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskWorkflowSpec.ProtoReflect.Descriptor instead.
+func (*TaskWorkflowSpec) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TaskWorkflowSpec) GetConfig() string {
+	if x != nil {
+		return x.Config
+	}
+	return ""
+}
+
+func (x *TaskWorkflowSpec) GetStrategy() *Strategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return nil
+}
+
 type SyncSource struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Apifox        *ApifoxConfig          `protobuf:"bytes,1,opt,name=apifox,proto3" json:"apifox,omitempty"`
@@ -925,7 +978,7 @@ type SyncSource struct {
 
 func (x *SyncSource) Reset() {
 	*x = SyncSource{}
-	mi := &file_Storage_proto_msgTypes[10]
+	mi := &file_Storage_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -937,7 +990,7 @@ func (x *SyncSource) String() string {
 func (*SyncSource) ProtoMessage() {}
 
 func (x *SyncSource) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[10]
+	mi := &file_Storage_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -950,7 +1003,7 @@ func (x *SyncSource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncSource.ProtoReflect.Descriptor instead.
 func (*SyncSource) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{10}
+	return file_Storage_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SyncSource) GetApifox() *ApifoxConfig {
@@ -971,7 +1024,7 @@ type SyncDestination struct {
 
 func (x *SyncDestination) Reset() {
 	*x = SyncDestination{}
-	mi := &file_Storage_proto_msgTypes[11]
+	mi := &file_Storage_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -983,7 +1036,7 @@ func (x *SyncDestination) String() string {
 func (*SyncDestination) ProtoMessage() {}
 
 func (x *SyncDestination) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[11]
+	mi := &file_Storage_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -996,7 +1049,7 @@ func (x *SyncDestination) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncDestination.ProtoReflect.Descriptor instead.
 func (*SyncDestination) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{11}
+	return file_Storage_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *SyncDestination) GetDestType() string {
@@ -1033,7 +1086,7 @@ type ApifoxConfig struct {
 
 func (x *ApifoxConfig) Reset() {
 	*x = ApifoxConfig{}
-	mi := &file_Storage_proto_msgTypes[12]
+	mi := &file_Storage_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1045,7 +1098,7 @@ func (x *ApifoxConfig) String() string {
 func (*ApifoxConfig) ProtoMessage() {}
 
 func (x *ApifoxConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[12]
+	mi := &file_Storage_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1058,7 +1111,7 @@ func (x *ApifoxConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApifoxConfig.ProtoReflect.Descriptor instead.
 func (*ApifoxConfig) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{12}
+	return file_Storage_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ApifoxConfig) GetBase() string {
@@ -1110,7 +1163,7 @@ type MongoConfig struct {
 
 func (x *MongoConfig) Reset() {
 	*x = MongoConfig{}
-	mi := &file_Storage_proto_msgTypes[13]
+	mi := &file_Storage_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1122,7 +1175,7 @@ func (x *MongoConfig) String() string {
 func (*MongoConfig) ProtoMessage() {}
 
 func (x *MongoConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[13]
+	mi := &file_Storage_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1135,7 +1188,7 @@ func (x *MongoConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MongoConfig.ProtoReflect.Descriptor instead.
 func (*MongoConfig) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{13}
+	return file_Storage_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *MongoConfig) GetHost() string {
@@ -1189,13 +1242,15 @@ type Strategy struct {
 	RetryInterval  int32                  `protobuf:"varint,4,opt,name=retry_interval,json=retryInterval,proto3" json:"retry_interval,omitempty"`
 	Priority       int32                  `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
 	Timeout        int32                  `protobuf:"varint,6,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	ConflictPolicy string                 `protobuf:"bytes,7,opt,name=conflict_policy,json=conflictPolicy,proto3" json:"conflict_policy,omitempty"` // 同一任务重复执行时的策略: reject(默认)/queue/cancel_older
+	Timezone       string                 `protobuf:"bytes,8,opt,name=timezone,proto3" json:"timezone,omitempty"`                                   // cron 表达式的时区，如 Asia/Shanghai，为空时使用服务配置的时区
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Strategy) Reset() {
 	*x = Strategy{}
-	mi := &file_Storage_proto_msgTypes[14]
+	mi := &file_Storage_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1207,7 +1262,7 @@ func (x *Strategy) String() string {
 func (*Strategy) ProtoMessage() {}
 
 func (x *Strategy) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[14]
+	mi := &file_Storage_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1220,7 +1275,7 @@ func (x *Strategy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Strategy.ProtoReflect.Descriptor instead.
 func (*Strategy) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{14}
+	return file_Storage_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *Strategy) GetAuto() bool {
@@ -1265,6 +1320,20 @@ func (x *Strategy) GetTimeout() int32 {
 	return 0
 }
 
+func (x *Strategy) GetConflictPolicy() string {
+	if x != nil {
+		return x.ConflictPolicy
+	}
+	return ""
+}
+
+func (x *Strategy) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
 type TestData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	DataId        string                 `protobuf:"bytes,1,opt,name=data_id,json=dataId,proto3" json:"data_id,omitempty"`
@@ -1276,7 +1345,7 @@ type TestData struct {
 
 func (x *TestData) Reset() {
 	*x = TestData{}
-	mi := &file_Storage_proto_msgTypes[15]
+	mi := &file_Storage_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1288,7 +1357,7 @@ func (x *TestData) String() string {
 func (*TestData) ProtoMessage() {}
 
 func (x *TestData) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[15]
+	mi := &file_Storage_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1301,7 +1370,7 @@ func (x *TestData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestData.ProtoReflect.Descriptor instead.
 func (*TestData) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{15}
+	return file_Storage_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *TestData) GetDataId() string {
@@ -1339,7 +1408,7 @@ type TestReport struct {
 
 func (x *TestReport) Reset() {
 	*x = TestReport{}
-	mi := &file_Storage_proto_msgTypes[16]
+	mi := &file_Storage_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1351,7 +1420,7 @@ func (x *TestReport) String() string {
 func (*TestReport) ProtoMessage() {}
 
 func (x *TestReport) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[16]
+	mi := &file_Storage_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1364,7 +1433,7 @@ func (x *TestReport) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestReport.ProtoReflect.Descriptor instead.
 func (*TestReport) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{16}
+	return file_Storage_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *TestReport) GetId() string {
@@ -1425,7 +1494,7 @@ type SceneConfig struct {
 
 func (x *SceneConfig) Reset() {
 	*x = SceneConfig{}
-	mi := &file_Storage_proto_msgTypes[17]
+	mi := &file_Storage_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1437,7 +1506,7 @@ func (x *SceneConfig) String() string {
 func (*SceneConfig) ProtoMessage() {}
 
 func (x *SceneConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[17]
+	mi := &file_Storage_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1450,7 +1519,7 @@ func (x *SceneConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SceneConfig.ProtoReflect.Descriptor instead.
 func (*SceneConfig) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{17}
+	return file_Storage_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *SceneConfig) GetSceneId() string {
@@ -1530,7 +1599,7 @@ type InterfaceInfo struct {
 
 func (x *InterfaceInfo) Reset() {
 	*x = InterfaceInfo{}
-	mi := &file_Storage_proto_msgTypes[18]
+	mi := &file_Storage_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1542,7 +1611,7 @@ func (x *InterfaceInfo) String() string {
 func (*InterfaceInfo) ProtoMessage() {}
 
 func (x *InterfaceInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[18]
+	mi := &file_Storage_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1555,7 +1624,7 @@ func (x *InterfaceInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InterfaceInfo.ProtoReflect.Descriptor instead.
 func (*InterfaceInfo) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{18}
+	return file_Storage_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *InterfaceInfo) GetApiId() string {
@@ -1652,7 +1721,7 @@ type Header struct {
 
 func (x *Header) Reset() {
 	*x = Header{}
-	mi := &file_Storage_proto_msgTypes[19]
+	mi := &file_Storage_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1664,7 +1733,7 @@ func (x *Header) String() string {
 func (*Header) ProtoMessage() {}
 
 func (x *Header) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[19]
+	mi := &file_Storage_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1677,7 +1746,7 @@ func (x *Header) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Header.ProtoReflect.Descriptor instead.
 func (*Header) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{19}
+	return file_Storage_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *Header) GetName() string {
@@ -1704,7 +1773,7 @@ type Parameter struct {
 
 func (x *Parameter) Reset() {
 	*x = Parameter{}
-	mi := &file_Storage_proto_msgTypes[20]
+	mi := &file_Storage_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1716,7 +1785,7 @@ func (x *Parameter) String() string {
 func (*Parameter) ProtoMessage() {}
 
 func (x *Parameter) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[20]
+	mi := &file_Storage_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1729,7 +1798,7 @@ func (x *Parameter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Parameter.ProtoReflect.Descriptor instead.
 func (*Parameter) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{20}
+	return file_Storage_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *Parameter) GetName() string {
@@ -1756,7 +1825,7 @@ type Scenarios struct {
 
 func (x *Scenarios) Reset() {
 	*x = Scenarios{}
-	mi := &file_Storage_proto_msgTypes[21]
+	mi := &file_Storage_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1768,7 +1837,7 @@ func (x *Scenarios) String() string {
 func (*Scenarios) ProtoMessage() {}
 
 func (x *Scenarios) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[21]
+	mi := &file_Storage_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1781,7 +1850,7 @@ func (x *Scenarios) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Scenarios.ProtoReflect.Descriptor instead.
 func (*Scenarios) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{21}
+	return file_Storage_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *Scenarios) GetScid() string {
@@ -1808,6 +1877,7 @@ type CreateTaskRequest struct {
 	//
 	//	*CreateTaskRequest_ApiSpec
 	//	*CreateTaskRequest_SyncSpec
+	//	*CreateTaskRequest_WorkflowSpec
 	Spec          isCreateTaskRequest_Spec `protobuf_oneof:"spec"`
 	Desc          string                   `protobuf:"bytes,5,opt,name=desc,proto3" json:"desc,omitempty"`
 	unknownFields protoimpl.UnknownFields
@@ -1816,7 +1886,7 @@ type CreateTaskRequest struct {
 
 func (x *CreateTaskRequest) Reset() {
 	*x = CreateTaskRequest{}
-	mi := &file_Storage_proto_msgTypes[22]
+	mi := &file_Storage_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1828,7 +1898,7 @@ func (x *CreateTaskRequest) String() string {
 func (*CreateTaskRequest) ProtoMessage() {}
 
 func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[22]
+	mi := &file_Storage_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1841,7 +1911,7 @@ func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
 func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{22}
+	return file_Storage_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *CreateTaskRequest) GetName() string {
@@ -1883,6 +1953,15 @@ func (x *CreateTaskRequest) GetSyncSpec() *TaskSyncSpec {
 	return nil
 }
 
+func (x *CreateTaskRequest) GetWorkflowSpec() *TaskWorkflowSpec {
+	if x != nil {
+		if x, ok := x.Spec.(*CreateTaskRequest_WorkflowSpec); ok {
+			return x.WorkflowSpec
+		}
+	}
+	return nil
+}
+
 func (x *CreateTaskRequest) GetDesc() string {
 	if x != nil {
 		return x.Desc
@@ -1902,10 +1981,16 @@ type CreateTaskRequest_SyncSpec struct {
 	SyncSpec *TaskSyncSpec `protobuf:"bytes,4,opt,name=sync_spec,json=syncSpec,proto3,oneof"`
 }
 
+type CreateTaskRequest_WorkflowSpec struct {
+	WorkflowSpec *TaskWorkflowSpec `protobuf:"bytes,6,opt,name=workflow_spec,json=workflowSpec,proto3,oneof"`
+}
+
 func (*CreateTaskRequest_ApiSpec) isCreateTaskRequest_Spec() {}
 
 func (*CreateTaskRequest_SyncSpec) isCreateTaskRequest_Spec() {}
 
+func (*CreateTaskRequest_WorkflowSpec) isCreateTaskRequest_Spec() {}
+
 type GetTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
@@ -1915,7 +2000,7 @@ type GetTaskRequest struct {
 
 func (x *GetTaskRequest) Reset() {
 	*x = GetTaskRequest{}
-	mi := &file_Storage_proto_msgTypes[23]
+	mi := &file_Storage_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1927,7 +2012,7 @@ func (x *GetTaskRequest) String() string {
 func (*GetTaskRequest) ProtoMessage() {}
 
 func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[23]
+	mi := &file_Storage_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1940,7 +2025,7 @@ func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
 func (*GetTaskRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{23}
+	return file_Storage_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *GetTaskRequest) GetTaskId() string {
@@ -1959,6 +2044,7 @@ type UpdateTaskRequest struct {
 	//
 	//	*UpdateTaskRequest_ApiSpec
 	//	*UpdateTaskRequest_SyncSpec
+	//	*UpdateTaskRequest_WorkflowSpec
 	Spec          isUpdateTaskRequest_Spec `protobuf_oneof:"spec"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -1966,7 +2052,7 @@ type UpdateTaskRequest struct {
 
 func (x *UpdateTaskRequest) Reset() {
 	*x = UpdateTaskRequest{}
-	mi := &file_Storage_proto_msgTypes[24]
+	mi := &file_Storage_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1978,7 +2064,7 @@ func (x *UpdateTaskRequest) String() string {
 func (*UpdateTaskRequest) ProtoMessage() {}
 
 func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[24]
+	mi := &file_Storage_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1991,7 +2077,7 @@ func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
 func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{24}
+	return file_Storage_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *UpdateTaskRequest) GetTaskId() string {
@@ -2040,6 +2126,15 @@ func (x *UpdateTaskRequest) GetSyncSpec() *TaskSyncSpec {
 	return nil
 }
 
+func (x *UpdateTaskRequest) GetWorkflowSpec() *TaskWorkflowSpec {
+	if x != nil {
+		if x, ok := x.Spec.(*UpdateTaskRequest_WorkflowSpec); ok {
+			return x.WorkflowSpec
+		}
+	}
+	return nil
+}
+
 type isUpdateTaskRequest_Spec interface {
 	isUpdateTaskRequest_Spec()
 }
@@ -2052,10 +2147,16 @@ type UpdateTaskRequest_SyncSpec struct {
 	SyncSpec *TaskSyncSpec `protobuf:"bytes,5,opt,name=sync_spec,json=syncSpec,proto3,oneof"`
 }
 
+type UpdateTaskRequest_WorkflowSpec struct {
+	WorkflowSpec *TaskWorkflowSpec `protobuf:"bytes,6,opt,name=workflow_spec,json=workflowSpec,proto3,oneof"`
+}
+
 func (*UpdateTaskRequest_ApiSpec) isUpdateTaskRequest_Spec() {}
 
 func (*UpdateTaskRequest_SyncSpec) isUpdateTaskRequest_Spec() {}
 
+func (*UpdateTaskRequest_WorkflowSpec) isUpdateTaskRequest_Spec() {}
+
 type DeleteTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
@@ -2065,7 +2166,7 @@ type DeleteTaskRequest struct {
 
 func (x *DeleteTaskRequest) Reset() {
 	*x = DeleteTaskRequest{}
-	mi := &file_Storage_proto_msgTypes[25]
+	mi := &file_Storage_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2077,7 +2178,7 @@ func (x *DeleteTaskRequest) String() string {
 func (*DeleteTaskRequest) ProtoMessage() {}
 
 func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[25]
+	mi := &file_Storage_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2090,7 +2191,7 @@ func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
 func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{25}
+	return file_Storage_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *DeleteTaskRequest) GetTaskId() string {
@@ -2110,7 +2211,7 @@ type GetTestDataRequest struct {
 
 func (x *GetTestDataRequest) Reset() {
 	*x = GetTestDataRequest{}
-	mi := &file_Storage_proto_msgTypes[26]
+	mi := &file_Storage_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2122,7 +2223,7 @@ func (x *GetTestDataRequest) String() string {
 func (*GetTestDataRequest) ProtoMessage() {}
 
 func (x *GetTestDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[26]
+	mi := &file_Storage_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2135,7 +2236,7 @@ func (x *GetTestDataRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTestDataRequest.ProtoReflect.Descriptor instead.
 func (*GetTestDataRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{26}
+	return file_Storage_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *GetTestDataRequest) GetDataId() string {
@@ -2156,7 +2257,7 @@ type UpdateTestDataRequest struct {
 
 func (x *UpdateTestDataRequest) Reset() {
 	*x = UpdateTestDataRequest{}
-	mi := &file_Storage_proto_msgTypes[27]
+	mi := &file_Storage_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2168,7 +2269,7 @@ func (x *UpdateTestDataRequest) String() string {
 func (*UpdateTestDataRequest) ProtoMessage() {}
 
 func (x *UpdateTestDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[27]
+	mi := &file_Storage_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2181,7 +2282,7 @@ func (x *UpdateTestDataRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateTestDataRequest.ProtoReflect.Descriptor instead.
 func (*UpdateTestDataRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{27}
+	return file_Storage_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *UpdateTestDataRequest) GetDataId() string {
@@ -2214,7 +2315,7 @@ type DeleteTestDataRequest struct {
 
 func (x *DeleteTestDataRequest) Reset() {
 	*x = DeleteTestDataRequest{}
-	mi := &file_Storage_proto_msgTypes[28]
+	mi := &file_Storage_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2226,7 +2327,7 @@ func (x *DeleteTestDataRequest) String() string {
 func (*DeleteTestDataRequest) ProtoMessage() {}
 
 func (x *DeleteTestDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[28]
+	mi := &file_Storage_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2239,7 +2340,7 @@ func (x *DeleteTestDataRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteTestDataRequest.ProtoReflect.Descriptor instead.
 func (*DeleteTestDataRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{28}
+	return file_Storage_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *DeleteTestDataRequest) GetDataId() string {
@@ -2259,7 +2360,7 @@ type GetSceneConfigRequest struct {
 
 func (x *GetSceneConfigRequest) Reset() {
 	*x = GetSceneConfigRequest{}
-	mi := &file_Storage_proto_msgTypes[29]
+	mi := &file_Storage_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2271,7 +2372,7 @@ func (x *GetSceneConfigRequest) String() string {
 func (*GetSceneConfigRequest) ProtoMessage() {}
 
 func (x *GetSceneConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[29]
+	mi := &file_Storage_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2284,7 +2385,7 @@ func (x *GetSceneConfigRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSceneConfigRequest.ProtoReflect.Descriptor instead.
 func (*GetSceneConfigRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{29}
+	return file_Storage_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *GetSceneConfigRequest) GetSceneId() string {
@@ -2309,7 +2410,7 @@ type UpdateSceneConfigRequest struct {
 
 func (x *UpdateSceneConfigRequest) Reset() {
 	*x = UpdateSceneConfigRequest{}
-	mi := &file_Storage_proto_msgTypes[30]
+	mi := &file_Storage_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2321,7 +2422,7 @@ func (x *UpdateSceneConfigRequest) String() string {
 func (*UpdateSceneConfigRequest) ProtoMessage() {}
 
 func (x *UpdateSceneConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[30]
+	mi := &file_Storage_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2334,7 +2435,7 @@ func (x *UpdateSceneConfigRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateSceneConfigRequest.ProtoReflect.Descriptor instead.
 func (*UpdateSceneConfigRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{30}
+	return file_Storage_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *UpdateSceneConfigRequest) GetSceneId() string {
@@ -2395,7 +2496,7 @@ type DeleteSceneConfigRequest struct {
 
 func (x *DeleteSceneConfigRequest) Reset() {
 	*x = DeleteSceneConfigRequest{}
-	mi := &file_Storage_proto_msgTypes[31]
+	mi := &file_Storage_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2407,7 +2508,7 @@ func (x *DeleteSceneConfigRequest) String() string {
 func (*DeleteSceneConfigRequest) ProtoMessage() {}
 
 func (x *DeleteSceneConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[31]
+	mi := &file_Storage_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2420,7 +2521,7 @@ func (x *DeleteSceneConfigRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteSceneConfigRequest.ProtoReflect.Descriptor instead.
 func (*DeleteSceneConfigRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{31}
+	return file_Storage_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *DeleteSceneConfigRequest) GetSceneId() string {
@@ -2440,7 +2541,7 @@ type ListSceneConfigsRequest struct {
 
 func (x *ListSceneConfigsRequest) Reset() {
 	*x = ListSceneConfigsRequest{}
-	mi := &file_Storage_proto_msgTypes[32]
+	mi := &file_Storage_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2452,7 +2553,7 @@ func (x *ListSceneConfigsRequest) String() string {
 func (*ListSceneConfigsRequest) ProtoMessage() {}
 
 func (x *ListSceneConfigsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[32]
+	mi := &file_Storage_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2465,7 +2566,7 @@ func (x *ListSceneConfigsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSceneConfigsRequest.ProtoReflect.Descriptor instead.
 func (*ListSceneConfigsRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{32}
+	return file_Storage_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *ListSceneConfigsRequest) GetPage() int32 {
@@ -2493,7 +2594,7 @@ type GetInterfaceListResponse struct {
 
 func (x *GetInterfaceListResponse) Reset() {
 	*x = GetInterfaceListResponse{}
-	mi := &file_Storage_proto_msgTypes[33]
+	mi := &file_Storage_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2505,7 +2606,7 @@ func (x *GetInterfaceListResponse) String() string {
 func (*GetInterfaceListResponse) ProtoMessage() {}
 
 func (x *GetInterfaceListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[33]
+	mi := &file_Storage_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2518,7 +2619,7 @@ func (x *GetInterfaceListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetInterfaceListResponse.ProtoReflect.Descriptor instead.
 func (*GetInterfaceListResponse) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{33}
+	return file_Storage_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *GetInterfaceListResponse) GetHeader() *ResponseHeader {
@@ -2544,7 +2645,7 @@ type GetInterfaceRequest struct {
 
 func (x *GetInterfaceRequest) Reset() {
 	*x = GetInterfaceRequest{}
-	mi := &file_Storage_proto_msgTypes[34]
+	mi := &file_Storage_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2556,7 +2657,7 @@ func (x *GetInterfaceRequest) String() string {
 func (*GetInterfaceRequest) ProtoMessage() {}
 
 func (x *GetInterfaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[34]
+	mi := &file_Storage_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2569,7 +2670,7 @@ func (x *GetInterfaceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetInterfaceRequest.ProtoReflect.Descriptor instead.
 func (*GetInterfaceRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{34}
+	return file_Storage_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *GetInterfaceRequest) GetInterfaceId() string {
@@ -2589,7 +2690,7 @@ type GetInterfaceResponse struct {
 
 func (x *GetInterfaceResponse) Reset() {
 	*x = GetInterfaceResponse{}
-	mi := &file_Storage_proto_msgTypes[35]
+	mi := &file_Storage_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2601,7 +2702,7 @@ func (x *GetInterfaceResponse) String() string {
 func (*GetInterfaceResponse) ProtoMessage() {}
 
 func (x *GetInterfaceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[35]
+	mi := &file_Storage_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2614,7 +2715,7 @@ func (x *GetInterfaceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetInterfaceResponse.ProtoReflect.Descriptor instead.
 func (*GetInterfaceResponse) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{35}
+	return file_Storage_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *GetInterfaceResponse) GetHeader() *ResponseHeader {
@@ -2640,7 +2741,7 @@ type DeleteInterfaceRequest struct {
 
 func (x *DeleteInterfaceRequest) Reset() {
 	*x = DeleteInterfaceRequest{}
-	mi := &file_Storage_proto_msgTypes[36]
+	mi := &file_Storage_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2652,7 +2753,7 @@ func (x *DeleteInterfaceRequest) String() string {
 func (*DeleteInterfaceRequest) ProtoMessage() {}
 
 func (x *DeleteInterfaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[36]
+	mi := &file_Storage_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2665,7 +2766,7 @@ func (x *DeleteInterfaceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteInterfaceRequest.ProtoReflect.Descriptor instead.
 func (*DeleteInterfaceRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{36}
+	return file_Storage_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *DeleteInterfaceRequest) GetInterfaceId() string {
@@ -2685,7 +2786,7 @@ type SyncInterfaceRequest struct {
 
 func (x *SyncInterfaceRequest) Reset() {
 	*x = SyncInterfaceRequest{}
-	mi := &file_Storage_proto_msgTypes[37]
+	mi := &file_Storage_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2697,7 +2798,7 @@ func (x *SyncInterfaceRequest) String() string {
 func (*SyncInterfaceRequest) ProtoMessage() {}
 
 func (x *SyncInterfaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[37]
+	mi := &file_Storage_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2710,7 +2811,7 @@ func (x *SyncInterfaceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncInterfaceRequest.ProtoReflect.Descriptor instead.
 func (*SyncInterfaceRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{37}
+	return file_Storage_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *SyncInterfaceRequest) GetInterfaceId() string {
@@ -2738,7 +2839,7 @@ type SyncInterfaceResponse struct {
 
 func (x *SyncInterfaceResponse) Reset() {
 	*x = SyncInterfaceResponse{}
-	mi := &file_Storage_proto_msgTypes[38]
+	mi := &file_Storage_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2750,7 +2851,7 @@ func (x *SyncInterfaceResponse) String() string {
 func (*SyncInterfaceResponse) ProtoMessage() {}
 
 func (x *SyncInterfaceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[38]
+	mi := &file_Storage_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2763,7 +2864,7 @@ func (x *SyncInterfaceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncInterfaceResponse.ProtoReflect.Descriptor instead.
 func (*SyncInterfaceResponse) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{38}
+	return file_Storage_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *SyncInterfaceResponse) GetHeader() *ResponseHeader {
@@ -2796,6 +2897,7 @@ type TaskResponse struct {
 	//
 	//	*TaskResponse_ApiSpec
 	//	*TaskResponse_SyncSpec
+	//	*TaskResponse_WorkflowSpec
 	Spec          isTaskResponse_Spec `protobuf_oneof:"spec"`
 	Type          int64               `protobuf:"varint,5,opt,name=type,proto3" json:"type,omitempty"`
 	CreateAt      string              `protobuf:"bytes,6,opt,name=create_at,json=createAt,proto3" json:"create_at,omitempty"`
@@ -2806,7 +2908,7 @@ type TaskResponse struct {
 
 func (x *TaskResponse) Reset() {
 	*x = TaskResponse{}
-	mi := &file_Storage_proto_msgTypes[39]
+	mi := &file_Storage_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2818,7 +2920,7 @@ func (x *TaskResponse) String() string {
 func (*TaskResponse) ProtoMessage() {}
 
 func (x *TaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[39]
+	mi := &file_Storage_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2831,7 +2933,7 @@ func (x *TaskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TaskResponse.ProtoReflect.Descriptor instead.
 func (*TaskResponse) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{39}
+	return file_Storage_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *TaskResponse) GetHeader() *ResponseHeader {
@@ -2873,6 +2975,15 @@ func (x *TaskResponse) GetSyncSpec() *TaskSyncSpec {
 	return nil
 }
 
+func (x *TaskResponse) GetWorkflowSpec() *TaskWorkflowSpec {
+	if x != nil {
+		if x, ok := x.Spec.(*TaskResponse_WorkflowSpec); ok {
+			return x.WorkflowSpec
+		}
+	}
+	return nil
+}
+
 func (x *TaskResponse) GetType() int64 {
 	if x != nil {
 		return x.Type
@@ -2906,10 +3017,16 @@ type TaskResponse_SyncSpec struct {
 	SyncSpec *TaskSyncSpec `protobuf:"bytes,4,opt,name=sync_spec,json=syncSpec,proto3,oneof"`
 }
 
+type TaskResponse_WorkflowSpec struct {
+	WorkflowSpec *TaskWorkflowSpec `protobuf:"bytes,8,opt,name=workflow_spec,json=workflowSpec,proto3,oneof"`
+}
+
 func (*TaskResponse_ApiSpec) isTaskResponse_Spec() {}
 
 func (*TaskResponse_SyncSpec) isTaskResponse_Spec() {}
 
+func (*TaskResponse_WorkflowSpec) isTaskResponse_Spec() {}
+
 type TaskListResponse struct {
 	state         protoimpl.MessageState       `protogen:"open.v1"`
 	Header        *ResponseHeader              `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
@@ -2921,7 +3038,7 @@ type TaskListResponse struct {
 
 func (x *TaskListResponse) Reset() {
 	*x = TaskListResponse{}
-	mi := &file_Storage_proto_msgTypes[40]
+	mi := &file_Storage_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2933,7 +3050,7 @@ func (x *TaskListResponse) String() string {
 func (*TaskListResponse) ProtoMessage() {}
 
 func (x *TaskListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[40]
+	mi := &file_Storage_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2946,7 +3063,7 @@ func (x *TaskListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TaskListResponse.ProtoReflect.Descriptor instead.
 func (*TaskListResponse) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{40}
+	return file_Storage_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *TaskListResponse) GetHeader() *ResponseHeader {
@@ -2980,7 +3097,7 @@ type DeleteResponse struct {
 
 func (x *DeleteResponse) Reset() {
 	*x = DeleteResponse{}
-	mi := &file_Storage_proto_msgTypes[41]
+	mi := &file_Storage_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2992,7 +3109,7 @@ func (x *DeleteResponse) String() string {
 func (*DeleteResponse) ProtoMessage() {}
 
 func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[41]
+	mi := &file_Storage_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3005,7 +3122,7 @@ func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
 func (*DeleteResponse) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{41}
+	return file_Storage_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *DeleteResponse) GetHeader() *ResponseHeader {
@@ -3025,13 +3142,15 @@ func (x *DeleteResponse) GetAffectedRows() int64 {
 type ExecuteTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Trigger       string                 `protobuf:"bytes,2,opt,name=trigger,proto3" json:"trigger,omitempty"`                                  // 触发方式: manual(默认)/schedule
+	EnvironmentId string                 `protobuf:"bytes,3,opt,name=environment_id,json=environmentId,proto3" json:"environment_id,omitempty"` // 执行环境ID，为空时按API定义中的地址执行
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ExecuteTaskRequest) Reset() {
 	*x = ExecuteTaskRequest{}
-	mi := &file_Storage_proto_msgTypes[42]
+	mi := &file_Storage_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3043,7 +3162,7 @@ func (x *ExecuteTaskRequest) String() string {
 func (*ExecuteTaskRequest) ProtoMessage() {}
 
 func (x *ExecuteTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[42]
+	mi := &file_Storage_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3056,7 +3175,7 @@ func (x *ExecuteTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecuteTaskRequest.ProtoReflect.Descriptor instead.
 func (*ExecuteTaskRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{42}
+	return file_Storage_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *ExecuteTaskRequest) GetTaskId() string {
@@ -3066,6 +3185,20 @@ func (x *ExecuteTaskRequest) GetTaskId() string {
 	return ""
 }
 
+func (x *ExecuteTaskRequest) GetTrigger() string {
+	if x != nil {
+		return x.Trigger
+	}
+	return ""
+}
+
+func (x *ExecuteTaskRequest) GetEnvironmentId() string {
+	if x != nil {
+		return x.EnvironmentId
+	}
+	return ""
+}
+
 type ExecuteTaskResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
@@ -3077,7 +3210,7 @@ type ExecuteTaskResponse struct {
 
 func (x *ExecuteTaskResponse) Reset() {
 	*x = ExecuteTaskResponse{}
-	mi := &file_Storage_proto_msgTypes[43]
+	mi := &file_Storage_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3089,7 +3222,7 @@ func (x *ExecuteTaskResponse) String() string {
 func (*ExecuteTaskResponse) ProtoMessage() {}
 
 func (x *ExecuteTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[43]
+	mi := &file_Storage_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3102,7 +3235,7 @@ func (x *ExecuteTaskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecuteTaskResponse.ProtoReflect.Descriptor instead.
 func (*ExecuteTaskResponse) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{43}
+	return file_Storage_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *ExecuteTaskResponse) GetHeader() *ResponseHeader {
@@ -3126,28 +3259,28 @@ func (x *ExecuteTaskResponse) GetStartTime() *Timestamp {
 	return nil
 }
 
-type GetTestReportRequest struct {
+type CancelExecutionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ReportId      string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTestReportRequest) Reset() {
-	*x = GetTestReportRequest{}
-	mi := &file_Storage_proto_msgTypes[44]
+func (x *CancelExecutionRequest) Reset() {
+	*x = CancelExecutionRequest{}
+	mi := &file_Storage_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTestReportRequest) String() string {
+func (x *CancelExecutionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTestReportRequest) ProtoMessage() {}
+func (*CancelExecutionRequest) ProtoMessage() {}
 
-func (x *GetTestReportRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[44]
+func (x *CancelExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3158,41 +3291,40 @@ func (x *GetTestReportRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTestReportRequest.ProtoReflect.Descriptor instead.
-func (*GetTestReportRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{44}
+// Deprecated: Use CancelExecutionRequest.ProtoReflect.Descriptor instead.
+func (*CancelExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *GetTestReportRequest) GetReportId() string {
+func (x *CancelExecutionRequest) GetExecutionId() string {
 	if x != nil {
-		return x.ReportId
+		return x.ExecutionId
 	}
 	return ""
 }
 
-type TestReportResponse struct {
+type CancelExecutionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Report        *TestReport            `protobuf:"bytes,2,opt,name=report,proto3" json:"report,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TestReportResponse) Reset() {
-	*x = TestReportResponse{}
-	mi := &file_Storage_proto_msgTypes[45]
+func (x *CancelExecutionResponse) Reset() {
+	*x = CancelExecutionResponse{}
+	mi := &file_Storage_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TestReportResponse) String() string {
+func (x *CancelExecutionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TestReportResponse) ProtoMessage() {}
+func (*CancelExecutionResponse) ProtoMessage() {}
 
-func (x *TestReportResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[45]
+func (x *CancelExecutionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3203,49 +3335,41 @@ func (x *TestReportResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TestReportResponse.ProtoReflect.Descriptor instead.
-func (*TestReportResponse) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{45}
+// Deprecated: Use CancelExecutionResponse.ProtoReflect.Descriptor instead.
+func (*CancelExecutionResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *TestReportResponse) GetHeader() *ResponseHeader {
+func (x *CancelExecutionResponse) GetHeader() *ResponseHeader {
 	if x != nil {
 		return x.Header
 	}
 	return nil
 }
 
-func (x *TestReportResponse) GetReport() *TestReport {
-	if x != nil {
-		return x.Report
-	}
-	return nil
-}
-
-type GetTaskReportListRequest struct {
+// 恢复失败或已取消的执行，沿用执行ID，已完成的部分按检查点跳过
+type ResumeExecutionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskReportListRequest) Reset() {
-	*x = GetTaskReportListRequest{}
-	mi := &file_Storage_proto_msgTypes[46]
+func (x *ResumeExecutionRequest) Reset() {
+	*x = ResumeExecutionRequest{}
+	mi := &file_Storage_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskReportListRequest) String() string {
+func (x *ResumeExecutionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskReportListRequest) ProtoMessage() {}
+func (*ResumeExecutionRequest) ProtoMessage() {}
 
-func (x *GetTaskReportListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[46]
+func (x *ResumeExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3256,56 +3380,41 @@ func (x *GetTaskReportListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskReportListRequest.ProtoReflect.Descriptor instead.
-func (*GetTaskReportListRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{46}
+// Deprecated: Use ResumeExecutionRequest.ProtoReflect.Descriptor instead.
+func (*ResumeExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *GetTaskReportListRequest) GetTaskId() string {
+func (x *ResumeExecutionRequest) GetExecutionId() string {
 	if x != nil {
-		return x.TaskId
+		return x.ExecutionId
 	}
 	return ""
 }
 
-func (x *GetTaskReportListRequest) GetPage() int32 {
-	if x != nil {
-		return x.Page
-	}
-	return 0
-}
-
-func (x *GetTaskReportListRequest) GetPageSize() int32 {
-	if x != nil {
-		return x.PageSize
-	}
-	return 0
-}
-
-type ReportListResponse struct {
+type ResumeExecutionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Data          []*TestReport          `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
-	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	ExecutionId   string                 `protobuf:"bytes,2,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReportListResponse) Reset() {
-	*x = ReportListResponse{}
-	mi := &file_Storage_proto_msgTypes[47]
+func (x *ResumeExecutionResponse) Reset() {
+	*x = ResumeExecutionResponse{}
+	mi := &file_Storage_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReportListResponse) String() string {
+func (x *ResumeExecutionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReportListResponse) ProtoMessage() {}
+func (*ResumeExecutionResponse) ProtoMessage() {}
 
-func (x *ReportListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[47]
+func (x *ResumeExecutionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3316,55 +3425,53 @@ func (x *ReportListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReportListResponse.ProtoReflect.Descriptor instead.
-func (*ReportListResponse) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{47}
+// Deprecated: Use ResumeExecutionResponse.ProtoReflect.Descriptor instead.
+func (*ResumeExecutionResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *ReportListResponse) GetHeader() *ResponseHeader {
+func (x *ResumeExecutionResponse) GetHeader() *ResponseHeader {
 	if x != nil {
 		return x.Header
 	}
 	return nil
 }
 
-func (x *ReportListResponse) GetData() []*TestReport {
-	if x != nil {
-		return x.Data
-	}
-	return nil
-}
-
-func (x *ReportListResponse) GetTotal() int32 {
+func (x *ResumeExecutionResponse) GetExecutionId() string {
 	if x != nil {
-		return x.Total
+		return x.ExecutionId
 	}
-	return 0
+	return ""
 }
 
-type CreateTestDataRequest struct {
+// 排队中的执行
+type QueuedExecution struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	TaskId        string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	TaskType      string                 `protobuf:"bytes,3,opt,name=task_type,json=taskType,proto3" json:"task_type,omitempty"`
+	Priority      int32                  `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	Position      int32                  `protobuf:"varint,5,opt,name=position,proto3" json:"position,omitempty"` // 队列中的位置，从1开始
+	EnqueueTime   *Timestamp             `protobuf:"bytes,6,opt,name=enqueue_time,json=enqueueTime,proto3" json:"enqueue_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTestDataRequest) Reset() {
-	*x = CreateTestDataRequest{}
-	mi := &file_Storage_proto_msgTypes[48]
+func (x *QueuedExecution) Reset() {
+	*x = QueuedExecution{}
+	mi := &file_Storage_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTestDataRequest) String() string {
+func (x *QueuedExecution) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTestDataRequest) ProtoMessage() {}
+func (*QueuedExecution) ProtoMessage() {}
 
-func (x *CreateTestDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[48]
+func (x *QueuedExecution) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3375,100 +3482,74 @@ func (x *CreateTestDataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTestDataRequest.ProtoReflect.Descriptor instead.
-func (*CreateTestDataRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{48}
+// Deprecated: Use QueuedExecution.ProtoReflect.Descriptor instead.
+func (*QueuedExecution) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *CreateTestDataRequest) GetContent() string {
+func (x *QueuedExecution) GetExecutionId() string {
 	if x != nil {
-		return x.Content
+		return x.ExecutionId
 	}
 	return ""
 }
 
-func (x *CreateTestDataRequest) GetMetadata() map[string]string {
+func (x *QueuedExecution) GetTaskId() string {
 	if x != nil {
-		return x.Metadata
+		return x.TaskId
 	}
-	return nil
-}
-
-type TestDataResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Data          *TestData              `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *TestDataResponse) Reset() {
-	*x = TestDataResponse{}
-	mi := &file_Storage_proto_msgTypes[49]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *TestDataResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return ""
 }
 
-func (*TestDataResponse) ProtoMessage() {}
-
-func (x *TestDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[49]
+func (x *QueuedExecution) GetTaskType() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.TaskType
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use TestDataResponse.ProtoReflect.Descriptor instead.
-func (*TestDataResponse) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{49}
+func (x *QueuedExecution) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
 }
 
-func (x *TestDataResponse) GetHeader() *ResponseHeader {
+func (x *QueuedExecution) GetPosition() int32 {
 	if x != nil {
-		return x.Header
+		return x.Position
 	}
-	return nil
+	return 0
 }
 
-func (x *TestDataResponse) GetData() *TestData {
+func (x *QueuedExecution) GetEnqueueTime() *Timestamp {
 	if x != nil {
-		return x.Data
+		return x.EnqueueTime
 	}
 	return nil
 }
 
-type TestDataListResponse struct {
+type ListExecutionQueueRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Data          []*TestData            `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
-	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	TaskType      string                 `protobuf:"bytes,1,opt,name=task_type,json=taskType,proto3" json:"task_type,omitempty"` // 为空时返回所有类型
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TestDataListResponse) Reset() {
-	*x = TestDataListResponse{}
+func (x *ListExecutionQueueRequest) Reset() {
+	*x = ListExecutionQueueRequest{}
 	mi := &file_Storage_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TestDataListResponse) String() string {
+func (x *ListExecutionQueueRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TestDataListResponse) ProtoMessage() {}
+func (*ListExecutionQueueRequest) ProtoMessage() {}
 
-func (x *TestDataListResponse) ProtoReflect() protoreflect.Message {
+func (x *ListExecutionQueueRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_Storage_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3480,59 +3561,45 @@ func (x *TestDataListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TestDataListResponse.ProtoReflect.Descriptor instead.
-func (*TestDataListResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListExecutionQueueRequest.ProtoReflect.Descriptor instead.
+func (*ListExecutionQueueRequest) Descriptor() ([]byte, []int) {
 	return file_Storage_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *TestDataListResponse) GetHeader() *ResponseHeader {
-	if x != nil {
-		return x.Header
-	}
-	return nil
-}
-
-func (x *TestDataListResponse) GetData() []*TestData {
-	if x != nil {
-		return x.Data
-	}
-	return nil
-}
-
-func (x *TestDataListResponse) GetTotal() int32 {
+func (x *ListExecutionQueueRequest) GetTaskType() string {
 	if x != nil {
-		return x.Total
+		return x.TaskType
 	}
-	return 0
+	return ""
 }
 
-type CreateSceneConfigRequest struct {
+type ListExecutionQueueResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Desc          string                 `protobuf:"bytes,2,opt,name=desc,proto3" json:"desc,omitempty"`
-	Retry         *RetrySetting          `protobuf:"bytes,3,opt,name=retry,proto3" json:"retry,omitempty"`
-	Timeout       *TimeoutSetting        `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
-	RelatedApi    []*RelatedApi          `protobuf:"bytes,5,rep,name=related_api,json=relatedApi,proto3" json:"related_api,omitempty"`
-	CreateAt      string                 `protobuf:"bytes,6,opt,name=create_at,json=createAt,proto3" json:"create_at,omitempty"`
-	UpdateAt      string                 `protobuf:"bytes,7,opt,name=update_at,json=updateAt,proto3" json:"update_at,omitempty"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Items         []*QueuedExecution     `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Workers       int32                  `protobuf:"varint,3,opt,name=workers,proto3" json:"workers,omitempty"`
+	Running       int32                  `protobuf:"varint,4,opt,name=running,proto3" json:"running,omitempty"`
+	Queued        int32                  `protobuf:"varint,5,opt,name=queued,proto3" json:"queued,omitempty"`
+	RunningByType map[string]int32       `protobuf:"bytes,6,rep,name=running_by_type,json=runningByType,proto3" json:"running_by_type,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	QueuedByType  map[string]int32       `protobuf:"bytes,7,rep,name=queued_by_type,json=queuedByType,proto3" json:"queued_by_type,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateSceneConfigRequest) Reset() {
-	*x = CreateSceneConfigRequest{}
+func (x *ListExecutionQueueResponse) Reset() {
+	*x = ListExecutionQueueResponse{}
 	mi := &file_Storage_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateSceneConfigRequest) String() string {
+func (x *ListExecutionQueueResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateSceneConfigRequest) ProtoMessage() {}
+func (*ListExecutionQueueResponse) ProtoMessage() {}
 
-func (x *CreateSceneConfigRequest) ProtoReflect() protoreflect.Message {
+func (x *ListExecutionQueueResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_Storage_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3544,16 +3611,1368 @@ func (x *CreateSceneConfigRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateSceneConfigRequest.ProtoReflect.Descriptor instead.
-func (*CreateSceneConfigRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListExecutionQueueResponse.ProtoReflect.Descriptor instead.
+func (*ListExecutionQueueResponse) Descriptor() ([]byte, []int) {
 	return file_Storage_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *CreateSceneConfigRequest) GetName() string {
+func (x *ListExecutionQueueResponse) GetHeader() *ResponseHeader {
 	if x != nil {
-		return x.Name
+		return x.Header
 	}
-	return ""
+	return nil
+}
+
+func (x *ListExecutionQueueResponse) GetItems() []*QueuedExecution {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListExecutionQueueResponse) GetWorkers() int32 {
+	if x != nil {
+		return x.Workers
+	}
+	return 0
+}
+
+func (x *ListExecutionQueueResponse) GetRunning() int32 {
+	if x != nil {
+		return x.Running
+	}
+	return 0
+}
+
+func (x *ListExecutionQueueResponse) GetQueued() int32 {
+	if x != nil {
+		return x.Queued
+	}
+	return 0
+}
+
+func (x *ListExecutionQueueResponse) GetRunningByType() map[string]int32 {
+	if x != nil {
+		return x.RunningByType
+	}
+	return nil
+}
+
+func (x *ListExecutionQueueResponse) GetQueuedByType() map[string]int32 {
+	if x != nil {
+		return x.QueuedByType
+	}
+	return nil
+}
+
+type ReorderExecutionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	Priority      int32                  `protobuf:"varint,2,opt,name=priority,proto3" json:"priority,omitempty"` // 新优先级，数值越大越先执行
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderExecutionRequest) Reset() {
+	*x = ReorderExecutionRequest{}
+	mi := &file_Storage_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderExecutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderExecutionRequest) ProtoMessage() {}
+
+func (x *ReorderExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderExecutionRequest.ProtoReflect.Descriptor instead.
+func (*ReorderExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ReorderExecutionRequest) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+func (x *ReorderExecutionRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+type ReorderExecutionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Position      int32                  `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"` // 调整后在队列中的位置
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderExecutionResponse) Reset() {
+	*x = ReorderExecutionResponse{}
+	mi := &file_Storage_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderExecutionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderExecutionResponse) ProtoMessage() {}
+
+func (x *ReorderExecutionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderExecutionResponse.ProtoReflect.Descriptor instead.
+func (*ReorderExecutionResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ReorderExecutionResponse) GetHeader() *ResponseHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *ReorderExecutionResponse) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+// 执行记录，status 取值 pending/queued/running/retrying/completed/failed/canceled
+type ExecutionRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	TaskId        string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	TaskType      string                 `protobuf:"bytes,3,opt,name=task_type,json=taskType,proto3" json:"task_type,omitempty"`
+	SubType       string                 `protobuf:"bytes,4,opt,name=sub_type,json=subType,proto3" json:"sub_type,omitempty"`
+	Trigger       string                 `protobuf:"bytes,5,opt,name=trigger,proto3" json:"trigger,omitempty"` // manual/schedule
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Attempts      int32                  `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`                      // 队列投递次数
+	CreateTime    *Timestamp             `protobuf:"bytes,8,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"` // 入队时间
+	StartTime     *Timestamp             `protobuf:"bytes,9,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *Timestamp             `protobuf:"bytes,10,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,11,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Error         string                 `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`
+	Errors        []string               `protobuf:"bytes,13,rep,name=errors,proto3" json:"errors,omitempty"`   // 执行过程中的错误明细
+	Results       []*Struct              `protobuf:"bytes,14,rep,name=results,proto3" json:"results,omitempty"` // 各数据源的结果
+	Summary       *Struct                `protobuf:"bytes,15,opt,name=summary,proto3" json:"summary,omitempty"` // 汇总结果
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecutionRecord) Reset() {
+	*x = ExecutionRecord{}
+	mi := &file_Storage_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionRecord) ProtoMessage() {}
+
+func (x *ExecutionRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionRecord.ProtoReflect.Descriptor instead.
+func (*ExecutionRecord) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ExecutionRecord) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+func (x *ExecutionRecord) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ExecutionRecord) GetTaskType() string {
+	if x != nil {
+		return x.TaskType
+	}
+	return ""
+}
+
+func (x *ExecutionRecord) GetSubType() string {
+	if x != nil {
+		return x.SubType
+	}
+	return ""
+}
+
+func (x *ExecutionRecord) GetTrigger() string {
+	if x != nil {
+		return x.Trigger
+	}
+	return ""
+}
+
+func (x *ExecutionRecord) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ExecutionRecord) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *ExecutionRecord) GetCreateTime() *Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *ExecutionRecord) GetStartTime() *Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ExecutionRecord) GetEndTime() *Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ExecutionRecord) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *ExecutionRecord) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ExecutionRecord) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ExecutionRecord) GetResults() []*Struct {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ExecutionRecord) GetSummary() *Struct {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+type GetExecutionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExecutionRequest) Reset() {
+	*x = GetExecutionRequest{}
+	mi := &file_Storage_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExecutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExecutionRequest) ProtoMessage() {}
+
+func (x *GetExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExecutionRequest.ProtoReflect.Descriptor instead.
+func (*GetExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetExecutionRequest) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+type GetExecutionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Execution     *ExecutionRecord       `protobuf:"bytes,2,opt,name=execution,proto3" json:"execution,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExecutionResponse) Reset() {
+	*x = GetExecutionResponse{}
+	mi := &file_Storage_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExecutionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExecutionResponse) ProtoMessage() {}
+
+func (x *GetExecutionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExecutionResponse.ProtoReflect.Descriptor instead.
+func (*GetExecutionResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetExecutionResponse) GetHeader() *ResponseHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *GetExecutionResponse) GetExecution() *ExecutionRecord {
+	if x != nil {
+		return x.Execution
+	}
+	return nil
+}
+
+type ListExecutionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	StartTime     *Timestamp             `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // 按入队时间过滤
+	EndTime       *Timestamp             `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Page          int32                  `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExecutionsRequest) Reset() {
+	*x = ListExecutionsRequest{}
+	mi := &file_Storage_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExecutionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExecutionsRequest) ProtoMessage() {}
+
+func (x *ListExecutionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExecutionsRequest.ProtoReflect.Descriptor instead.
+func (*ListExecutionsRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListExecutionsRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ListExecutionsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListExecutionsRequest) GetStartTime() *Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ListExecutionsRequest) GetEndTime() *Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ListExecutionsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListExecutionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListExecutionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Items         []*ExecutionRecord     `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Total         int64                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExecutionsResponse) Reset() {
+	*x = ListExecutionsResponse{}
+	mi := &file_Storage_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExecutionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExecutionsResponse) ProtoMessage() {}
+
+func (x *ListExecutionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExecutionsResponse.ProtoReflect.Descriptor instead.
+func (*ListExecutionsResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ListExecutionsResponse) GetHeader() *ResponseHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *ListExecutionsResponse) GetItems() []*ExecutionRecord {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListExecutionsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetExecutionHarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExecutionHarRequest) Reset() {
+	*x = GetExecutionHarRequest{}
+	mi := &file_Storage_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExecutionHarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExecutionHarRequest) ProtoMessage() {}
+
+func (x *GetExecutionHarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExecutionHarRequest.ProtoReflect.Descriptor instead.
+func (*GetExecutionHarRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetExecutionHarRequest) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+// har 为 HAR 1.2 格式的 JSON 文件内容
+type GetExecutionHarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Har           []byte                 `protobuf:"bytes,2,opt,name=har,proto3" json:"har,omitempty"`
+	EntryCount    int32                  `protobuf:"varint,3,opt,name=entry_count,json=entryCount,proto3" json:"entry_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExecutionHarResponse) Reset() {
+	*x = GetExecutionHarResponse{}
+	mi := &file_Storage_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExecutionHarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExecutionHarResponse) ProtoMessage() {}
+
+func (x *GetExecutionHarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExecutionHarResponse.ProtoReflect.Descriptor instead.
+func (*GetExecutionHarResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *GetExecutionHarResponse) GetHeader() *ResponseHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *GetExecutionHarResponse) GetHar() []byte {
+	if x != nil {
+		return x.Har
+	}
+	return nil
+}
+
+func (x *GetExecutionHarResponse) GetEntryCount() int32 {
+	if x != nil {
+		return x.EntryCount
+	}
+	return 0
+}
+
+// entry_index 为 HAR 中条目的下标，从 0 开始
+type ExportCurlRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	EntryIndex    int32                  `protobuf:"varint,2,opt,name=entry_index,json=entryIndex,proto3" json:"entry_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportCurlRequest) Reset() {
+	*x = ExportCurlRequest{}
+	mi := &file_Storage_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportCurlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportCurlRequest) ProtoMessage() {}
+
+func (x *ExportCurlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportCurlRequest.ProtoReflect.Descriptor instead.
+func (*ExportCurlRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ExportCurlRequest) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+func (x *ExportCurlRequest) GetEntryIndex() int32 {
+	if x != nil {
+		return x.EntryIndex
+	}
+	return 0
+}
+
+type ExportCurlResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Command       string                 `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	ApiName       string                 `protobuf:"bytes,3,opt,name=api_name,json=apiName,proto3" json:"api_name,omitempty"` // 发出请求的API名称
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportCurlResponse) Reset() {
+	*x = ExportCurlResponse{}
+	mi := &file_Storage_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportCurlResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportCurlResponse) ProtoMessage() {}
+
+func (x *ExportCurlResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportCurlResponse.ProtoReflect.Descriptor instead.
+func (*ExportCurlResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ExportCurlResponse) GetHeader() *ResponseHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *ExportCurlResponse) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *ExportCurlResponse) GetApiName() string {
+	if x != nil {
+		return x.ApiName
+	}
+	return ""
+}
+
+type WatchExecutionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchExecutionRequest) Reset() {
+	*x = WatchExecutionRequest{}
+	mi := &file_Storage_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchExecutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchExecutionRequest) ProtoMessage() {}
+
+func (x *WatchExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchExecutionRequest.ProtoReflect.Descriptor instead.
+func (*WatchExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *WatchExecutionRequest) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+// 执行事件，event_type 取值 status/progress/scene/step/summary
+type WatchExecutionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	ExecutionId   string                 `protobuf:"bytes,2,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	EventType     string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Source        string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	Name          string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Progress      float64                `protobuf:"fixed64,7,opt,name=progress,proto3" json:"progress,omitempty"`
+	Result        *Struct                `protobuf:"bytes,8,opt,name=result,proto3" json:"result,omitempty"`
+	Error         string                 `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	Timestamp     *Timestamp             `protobuf:"bytes,10,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchExecutionResponse) Reset() {
+	*x = WatchExecutionResponse{}
+	mi := &file_Storage_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchExecutionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchExecutionResponse) ProtoMessage() {}
+
+func (x *WatchExecutionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchExecutionResponse.ProtoReflect.Descriptor instead.
+func (*WatchExecutionResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *WatchExecutionResponse) GetHeader() *ResponseHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *WatchExecutionResponse) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+func (x *WatchExecutionResponse) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *WatchExecutionResponse) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *WatchExecutionResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WatchExecutionResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WatchExecutionResponse) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *WatchExecutionResponse) GetResult() *Struct {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *WatchExecutionResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *WatchExecutionResponse) GetTimestamp() *Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type GetTestReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTestReportRequest) Reset() {
+	*x = GetTestReportRequest{}
+	mi := &file_Storage_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTestReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTestReportRequest) ProtoMessage() {}
+
+func (x *GetTestReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTestReportRequest.ProtoReflect.Descriptor instead.
+func (*GetTestReportRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *GetTestReportRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+type TestReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Report        *TestReport            `protobuf:"bytes,2,opt,name=report,proto3" json:"report,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestReportResponse) Reset() {
+	*x = TestReportResponse{}
+	mi := &file_Storage_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestReportResponse) ProtoMessage() {}
+
+func (x *TestReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestReportResponse.ProtoReflect.Descriptor instead.
+func (*TestReportResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *TestReportResponse) GetHeader() *ResponseHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *TestReportResponse) GetReport() *TestReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+type GetTaskReportListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskReportListRequest) Reset() {
+	*x = GetTaskReportListRequest{}
+	mi := &file_Storage_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskReportListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskReportListRequest) ProtoMessage() {}
+
+func (x *GetTaskReportListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskReportListRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskReportListRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *GetTaskReportListRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *GetTaskReportListRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetTaskReportListRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ReportListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Data          []*TestReport          `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportListResponse) Reset() {
+	*x = ReportListResponse{}
+	mi := &file_Storage_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportListResponse) ProtoMessage() {}
+
+func (x *ReportListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportListResponse.ProtoReflect.Descriptor instead.
+func (*ReportListResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ReportListResponse) GetHeader() *ResponseHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *ReportListResponse) GetData() []*TestReport {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ReportListResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type CreateTestDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTestDataRequest) Reset() {
+	*x = CreateTestDataRequest{}
+	mi := &file_Storage_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTestDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTestDataRequest) ProtoMessage() {}
+
+func (x *CreateTestDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTestDataRequest.ProtoReflect.Descriptor instead.
+func (*CreateTestDataRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *CreateTestDataRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *CreateTestDataRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type TestDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Data          *TestData              `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestDataResponse) Reset() {
+	*x = TestDataResponse{}
+	mi := &file_Storage_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestDataResponse) ProtoMessage() {}
+
+func (x *TestDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestDataResponse.ProtoReflect.Descriptor instead.
+func (*TestDataResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *TestDataResponse) GetHeader() *ResponseHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *TestDataResponse) GetData() *TestData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type TestDataListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Data          []*TestData            `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestDataListResponse) Reset() {
+	*x = TestDataListResponse{}
+	mi := &file_Storage_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestDataListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestDataListResponse) ProtoMessage() {}
+
+func (x *TestDataListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestDataListResponse.ProtoReflect.Descriptor instead.
+func (*TestDataListResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *TestDataListResponse) GetHeader() *ResponseHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *TestDataListResponse) GetData() []*TestData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *TestDataListResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type CreateSceneConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Desc          string                 `protobuf:"bytes,2,opt,name=desc,proto3" json:"desc,omitempty"`
+	Retry         *RetrySetting          `protobuf:"bytes,3,opt,name=retry,proto3" json:"retry,omitempty"`
+	Timeout       *TimeoutSetting        `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	RelatedApi    []*RelatedApi          `protobuf:"bytes,5,rep,name=related_api,json=relatedApi,proto3" json:"related_api,omitempty"`
+	CreateAt      string                 `protobuf:"bytes,6,opt,name=create_at,json=createAt,proto3" json:"create_at,omitempty"`
+	UpdateAt      string                 `protobuf:"bytes,7,opt,name=update_at,json=updateAt,proto3" json:"update_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSceneConfigRequest) Reset() {
+	*x = CreateSceneConfigRequest{}
+	mi := &file_Storage_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSceneConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSceneConfigRequest) ProtoMessage() {}
+
+func (x *CreateSceneConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSceneConfigRequest.ProtoReflect.Descriptor instead.
+func (*CreateSceneConfigRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *CreateSceneConfigRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
 func (x *CreateSceneConfigRequest) GetDesc() string {
@@ -3563,68 +4982,1252 @@ func (x *CreateSceneConfigRequest) GetDesc() string {
 	return ""
 }
 
-func (x *CreateSceneConfigRequest) GetRetry() *RetrySetting {
+func (x *CreateSceneConfigRequest) GetRetry() *RetrySetting {
+	if x != nil {
+		return x.Retry
+	}
+	return nil
+}
+
+func (x *CreateSceneConfigRequest) GetTimeout() *TimeoutSetting {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *CreateSceneConfigRequest) GetRelatedApi() []*RelatedApi {
+	if x != nil {
+		return x.RelatedApi
+	}
+	return nil
+}
+
+func (x *CreateSceneConfigRequest) GetCreateAt() string {
+	if x != nil {
+		return x.CreateAt
+	}
+	return ""
+}
+
+func (x *CreateSceneConfigRequest) GetUpdateAt() string {
+	if x != nil {
+		return x.UpdateAt
+	}
+	return ""
+}
+
+type RelatedApi struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiId         string                 `protobuf:"bytes,1,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Enabled       bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Dependency    string                 `protobuf:"bytes,4,opt,name=dependency,proto3" json:"dependency,omitempty"`
+	Expect        string                 `protobuf:"bytes,5,opt,name=expect,proto3" json:"expect,omitempty"`
+	Extractor     string                 `protobuf:"bytes,6,opt,name=extractor,proto3" json:"extractor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelatedApi) Reset() {
+	*x = RelatedApi{}
+	mi := &file_Storage_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelatedApi) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelatedApi) ProtoMessage() {}
+
+func (x *RelatedApi) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelatedApi.ProtoReflect.Descriptor instead.
+func (*RelatedApi) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *RelatedApi) GetApiId() string {
+	if x != nil {
+		return x.ApiId
+	}
+	return ""
+}
+
+func (x *RelatedApi) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RelatedApi) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *RelatedApi) GetDependency() string {
+	if x != nil {
+		return x.Dependency
+	}
+	return ""
+}
+
+func (x *RelatedApi) GetExpect() string {
+	if x != nil {
+		return x.Expect
+	}
+	return ""
+}
+
+func (x *RelatedApi) GetExtractor() string {
+	if x != nil {
+		return x.Extractor
+	}
+	return ""
+}
+
+type TimeoutSetting struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Duration      int64                  `protobuf:"varint,1,opt,name=duration,proto3" json:"duration,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeoutSetting) Reset() {
+	*x = TimeoutSetting{}
+	mi := &file_Storage_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeoutSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeoutSetting) ProtoMessage() {}
+
+func (x *TimeoutSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeoutSetting.ProtoReflect.Descriptor instead.
+func (*TimeoutSetting) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *TimeoutSetting) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *TimeoutSetting) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type RetrySetting struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxRetry      int64                  `protobuf:"varint,1,opt,name=max_retry,json=maxRetry,proto3" json:"max_retry,omitempty"`
+	Interval      int64                  `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	Enabled       bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetrySetting) Reset() {
+	*x = RetrySetting{}
+	mi := &file_Storage_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetrySetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetrySetting) ProtoMessage() {}
+
+func (x *RetrySetting) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetrySetting.ProtoReflect.Descriptor instead.
+func (*RetrySetting) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *RetrySetting) GetMaxRetry() int64 {
+	if x != nil {
+		return x.MaxRetry
+	}
+	return 0
+}
+
+func (x *RetrySetting) GetInterval() int64 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *RetrySetting) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type SceneConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Data          *SceneConfig           `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SceneConfigResponse) Reset() {
+	*x = SceneConfigResponse{}
+	mi := &file_Storage_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SceneConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SceneConfigResponse) ProtoMessage() {}
+
+func (x *SceneConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SceneConfigResponse.ProtoReflect.Descriptor instead.
+func (*SceneConfigResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *SceneConfigResponse) GetHeader() *ResponseHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *SceneConfigResponse) GetData() *SceneConfig {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SceneConfigListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Data          []*SceneConfig         `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SceneConfigListResponse) Reset() {
+	*x = SceneConfigListResponse{}
+	mi := &file_Storage_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SceneConfigListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SceneConfigListResponse) ProtoMessage() {}
+
+func (x *SceneConfigListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SceneConfigListResponse.ProtoReflect.Descriptor instead.
+func (*SceneConfigListResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *SceneConfigListResponse) GetHeader() *ResponseHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *SceneConfigListResponse) GetData() []*SceneConfig {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SceneConfigListResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GenerateDependencyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiId         string                 `protobuf:"bytes,1,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateDependencyRequest) Reset() {
+	*x = GenerateDependencyRequest{}
+	mi := &file_Storage_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateDependencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateDependencyRequest) ProtoMessage() {}
+
+func (x *GenerateDependencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateDependencyRequest.ProtoReflect.Descriptor instead.
+func (*GenerateDependencyRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *GenerateDependencyRequest) GetApiId() string {
+	if x != nil {
+		return x.ApiId
+	}
+	return ""
+}
+
+type GenerateDependencyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Dependency    *Dependency            `protobuf:"bytes,2,opt,name=dependency,proto3" json:"dependency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateDependencyResponse) Reset() {
+	*x = GenerateDependencyResponse{}
+	mi := &file_Storage_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateDependencyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateDependencyResponse) ProtoMessage() {}
+
+func (x *GenerateDependencyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateDependencyResponse.ProtoReflect.Descriptor instead.
+func (*GenerateDependencyResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *GenerateDependencyResponse) GetHeader() *ResponseHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *GenerateDependencyResponse) GetDependency() *Dependency {
+	if x != nil {
+		return x.Dependency
+	}
+	return nil
+}
+
+type GenerateExtractorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiId         string                 `protobuf:"bytes,1,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateExtractorRequest) Reset() {
+	*x = GenerateExtractorRequest{}
+	mi := &file_Storage_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateExtractorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateExtractorRequest) ProtoMessage() {}
+
+func (x *GenerateExtractorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateExtractorRequest.ProtoReflect.Descriptor instead.
+func (*GenerateExtractorRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *GenerateExtractorRequest) GetApiId() string {
+	if x != nil {
+		return x.ApiId
+	}
+	return ""
+}
+
+type GenerateExtractorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Extractor     *Extractor             `protobuf:"bytes,2,opt,name=extractor,proto3" json:"extractor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateExtractorResponse) Reset() {
+	*x = GenerateExtractorResponse{}
+	mi := &file_Storage_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateExtractorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateExtractorResponse) ProtoMessage() {}
+
+func (x *GenerateExtractorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateExtractorResponse.ProtoReflect.Descriptor instead.
+func (*GenerateExtractorResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *GenerateExtractorResponse) GetHeader() *ResponseHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *GenerateExtractorResponse) GetExtractor() *Extractor {
+	if x != nil {
+		return x.Extractor
+	}
+	return nil
+}
+
+type GenerateExpectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiId         string                 `protobuf:"bytes,1,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateExpectRequest) Reset() {
+	*x = GenerateExpectRequest{}
+	mi := &file_Storage_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateExpectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateExpectRequest) ProtoMessage() {}
+
+func (x *GenerateExpectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateExpectRequest.ProtoReflect.Descriptor instead.
+func (*GenerateExpectRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *GenerateExpectRequest) GetApiId() string {
+	if x != nil {
+		return x.ApiId
+	}
+	return ""
+}
+
+type GenerateExpectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Expect        *Expect                `protobuf:"bytes,2,opt,name=expect,proto3" json:"expect,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateExpectResponse) Reset() {
+	*x = GenerateExpectResponse{}
+	mi := &file_Storage_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateExpectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateExpectResponse) ProtoMessage() {}
+
+func (x *GenerateExpectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateExpectResponse.ProtoReflect.Descriptor instead.
+func (*GenerateExpectResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *GenerateExpectResponse) GetHeader() *ResponseHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *GenerateExpectResponse) GetExpect() *Expect {
+	if x != nil {
+		return x.Expect
+	}
+	return nil
+}
+
+type Dependency struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiId         string                 `protobuf:"bytes,1,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
+	DependId      string                 `protobuf:"bytes,2,opt,name=depend_id,json=dependId,proto3" json:"depend_id,omitempty"`
+	DependType    string                 `protobuf:"bytes,3,opt,name=depend_type,json=dependType,proto3" json:"depend_type,omitempty"`
+	RelatedScene  string                 `protobuf:"bytes,4,opt,name=related_scene,json=relatedScene,proto3" json:"related_scene,omitempty"`
+	RelatedApi    string                 `protobuf:"bytes,5,opt,name=related_api,json=relatedApi,proto3" json:"related_api,omitempty"`
+	DbConfig      string                 `protobuf:"bytes,6,opt,name=db_config,json=dbConfig,proto3" json:"db_config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dependency) Reset() {
+	*x = Dependency{}
+	mi := &file_Storage_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dependency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dependency) ProtoMessage() {}
+
+func (x *Dependency) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dependency.ProtoReflect.Descriptor instead.
+func (*Dependency) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *Dependency) GetApiId() string {
+	if x != nil {
+		return x.ApiId
+	}
+	return ""
+}
+
+func (x *Dependency) GetDependId() string {
+	if x != nil {
+		return x.DependId
+	}
+	return ""
+}
+
+func (x *Dependency) GetDependType() string {
+	if x != nil {
+		return x.DependType
+	}
+	return ""
+}
+
+func (x *Dependency) GetRelatedScene() string {
+	if x != nil {
+		return x.RelatedScene
+	}
+	return ""
+}
+
+func (x *Dependency) GetRelatedApi() string {
+	if x != nil {
+		return x.RelatedApi
+	}
+	return ""
+}
+
+func (x *Dependency) GetDbConfig() string {
+	if x != nil {
+		return x.DbConfig
+	}
+	return ""
+}
+
+type Expect struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiId         string                 `protobuf:"bytes,1,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
+	JsonPath      string                 `protobuf:"bytes,2,opt,name=json_path,json=jsonPath,proto3" json:"json_path,omitempty"`
+	Operation     string                 `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`
+	Value         *Dependency            `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Expect) Reset() {
+	*x = Expect{}
+	mi := &file_Storage_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Expect) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Expect) ProtoMessage() {}
+
+func (x *Expect) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Expect.ProtoReflect.Descriptor instead.
+func (*Expect) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *Expect) GetApiId() string {
+	if x != nil {
+		return x.ApiId
+	}
+	return ""
+}
+
+func (x *Expect) GetJsonPath() string {
+	if x != nil {
+		return x.JsonPath
+	}
+	return ""
+}
+
+func (x *Expect) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *Expect) GetValue() *Dependency {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type Extractor struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiId         string                 `protobuf:"bytes,1,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
+	Extractors    []*ExtractConfig       `protobuf:"bytes,2,rep,name=extractors,proto3" json:"extractors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Extractor) Reset() {
+	*x = Extractor{}
+	mi := &file_Storage_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Extractor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Extractor) ProtoMessage() {}
+
+func (x *Extractor) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Extractor.ProtoReflect.Descriptor instead.
+func (*Extractor) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *Extractor) GetApiId() string {
+	if x != nil {
+		return x.ApiId
+	}
+	return ""
+}
+
+func (x *Extractor) GetExtractors() []*ExtractConfig {
+	if x != nil {
+		return x.Extractors
+	}
+	return nil
+}
+
+type ExtractConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JsonPath      string                 `protobuf:"bytes,1,opt,name=json_path,json=jsonPath,proto3" json:"json_path,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExtractConfig) Reset() {
+	*x = ExtractConfig{}
+	mi := &file_Storage_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtractConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtractConfig) ProtoMessage() {}
+
+func (x *ExtractConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtractConfig.ProtoReflect.Descriptor instead.
+func (*ExtractConfig) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *ExtractConfig) GetJsonPath() string {
+	if x != nil {
+		return x.JsonPath
+	}
+	return ""
+}
+
+func (x *ExtractConfig) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// 执行环境的TLS配置，证书和私钥均为 PEM 格式
+type EnvironmentTLS struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	CaCert             string                 `protobuf:"bytes,1,opt,name=ca_cert,json=caCert,proto3" json:"ca_cert,omitempty"`                                        // 自定义CA证书
+	ClientCert         string                 `protobuf:"bytes,2,opt,name=client_cert,json=clientCert,proto3" json:"client_cert,omitempty"`                            // 客户端证书
+	ClientKey          string                 `protobuf:"bytes,3,opt,name=client_key,json=clientKey,proto3" json:"client_key,omitempty"`                               // 客户端私钥，只写，查询时不返回
+	InsecureSkipVerify bool                   `protobuf:"varint,4,opt,name=insecure_skip_verify,json=insecureSkipVerify,proto3" json:"insecure_skip_verify,omitempty"` // 跳过服务端证书校验
+	ServerName         string                 `protobuf:"bytes,5,opt,name=server_name,json=serverName,proto3" json:"server_name,omitempty"`                            // 校验证书使用的服务端名称
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *EnvironmentTLS) Reset() {
+	*x = EnvironmentTLS{}
+	mi := &file_Storage_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnvironmentTLS) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnvironmentTLS) ProtoMessage() {}
+
+func (x *EnvironmentTLS) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnvironmentTLS.ProtoReflect.Descriptor instead.
+func (*EnvironmentTLS) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *EnvironmentTLS) GetCaCert() string {
+	if x != nil {
+		return x.CaCert
+	}
+	return ""
+}
+
+func (x *EnvironmentTLS) GetClientCert() string {
+	if x != nil {
+		return x.ClientCert
+	}
+	return ""
+}
+
+func (x *EnvironmentTLS) GetClientKey() string {
+	if x != nil {
+		return x.ClientKey
+	}
+	return ""
+}
+
+func (x *EnvironmentTLS) GetInsecureSkipVerify() bool {
+	if x != nil {
+		return x.InsecureSkipVerify
+	}
+	return false
+}
+
+func (x *EnvironmentTLS) GetServerName() string {
+	if x != nil {
+		return x.ServerName
+	}
+	return ""
+}
+
+// 执行环境
+type Environment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EnvId         string                 `protobuf:"bytes,1,opt,name=env_id,json=envId,proto3" json:"env_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Desc          string                 `protobuf:"bytes,3,opt,name=desc,proto3" json:"desc,omitempty"`
+	BaseUrl       string                 `protobuf:"bytes,4,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`                                                                                       // 默认基础URL
+	ServiceUrls   map[string]string      `protobuf:"bytes,5,rep,name=service_urls,json=serviceUrls,proto3" json:"service_urls,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 服务名 -> 基础URL
+	Variables     map[string]string      `protobuf:"bytes,6,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`                        // 环境变量
+	Auth          string                 `protobuf:"bytes,7,opt,name=auth,proto3" json:"auth,omitempty"`                                                                                                            // 认证配置，JSON 格式，密钥只写，查询时不返回
+	Tls           *EnvironmentTLS        `protobuf:"bytes,8,opt,name=tls,proto3" json:"tls,omitempty"`
+	CreateAt      string                 `protobuf:"bytes,9,opt,name=create_at,json=createAt,proto3" json:"create_at,omitempty"`
+	UpdateAt      string                 `protobuf:"bytes,10,opt,name=update_at,json=updateAt,proto3" json:"update_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Environment) Reset() {
+	*x = Environment{}
+	mi := &file_Storage_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Environment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Environment) ProtoMessage() {}
+
+func (x *Environment) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Environment.ProtoReflect.Descriptor instead.
+func (*Environment) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *Environment) GetEnvId() string {
+	if x != nil {
+		return x.EnvId
+	}
+	return ""
+}
+
+func (x *Environment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Environment) GetDesc() string {
+	if x != nil {
+		return x.Desc
+	}
+	return ""
+}
+
+func (x *Environment) GetBaseUrl() string {
 	if x != nil {
-		return x.Retry
+		return x.BaseUrl
+	}
+	return ""
+}
+
+func (x *Environment) GetServiceUrls() map[string]string {
+	if x != nil {
+		return x.ServiceUrls
 	}
 	return nil
 }
 
-func (x *CreateSceneConfigRequest) GetTimeout() *TimeoutSetting {
+func (x *Environment) GetVariables() map[string]string {
 	if x != nil {
-		return x.Timeout
+		return x.Variables
 	}
 	return nil
 }
 
-func (x *CreateSceneConfigRequest) GetRelatedApi() []*RelatedApi {
+func (x *Environment) GetAuth() string {
 	if x != nil {
-		return x.RelatedApi
+		return x.Auth
+	}
+	return ""
+}
+
+func (x *Environment) GetTls() *EnvironmentTLS {
+	if x != nil {
+		return x.Tls
 	}
 	return nil
 }
 
-func (x *CreateSceneConfigRequest) GetCreateAt() string {
+func (x *Environment) GetCreateAt() string {
 	if x != nil {
 		return x.CreateAt
 	}
 	return ""
 }
 
-func (x *CreateSceneConfigRequest) GetUpdateAt() string {
+func (x *Environment) GetUpdateAt() string {
 	if x != nil {
 		return x.UpdateAt
 	}
 	return ""
 }
 
-type RelatedApi struct {
+type CreateEnvironmentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ApiId         string                 `protobuf:"bytes,1,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Enabled       bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	Dependency    string                 `protobuf:"bytes,4,opt,name=dependency,proto3" json:"dependency,omitempty"`
-	Expect        string                 `protobuf:"bytes,5,opt,name=expect,proto3" json:"expect,omitempty"`
-	Extractor     string                 `protobuf:"bytes,6,opt,name=extractor,proto3" json:"extractor,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Desc          string                 `protobuf:"bytes,2,opt,name=desc,proto3" json:"desc,omitempty"`
+	BaseUrl       string                 `protobuf:"bytes,3,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	ServiceUrls   map[string]string      `protobuf:"bytes,4,rep,name=service_urls,json=serviceUrls,proto3" json:"service_urls,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Variables     map[string]string      `protobuf:"bytes,5,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Auth          string                 `protobuf:"bytes,6,opt,name=auth,proto3" json:"auth,omitempty"`
+	Tls           *EnvironmentTLS        `protobuf:"bytes,7,opt,name=tls,proto3" json:"tls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateEnvironmentRequest) Reset() {
+	*x = CreateEnvironmentRequest{}
+	mi := &file_Storage_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateEnvironmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEnvironmentRequest) ProtoMessage() {}
+
+func (x *CreateEnvironmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEnvironmentRequest.ProtoReflect.Descriptor instead.
+func (*CreateEnvironmentRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *CreateEnvironmentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateEnvironmentRequest) GetDesc() string {
+	if x != nil {
+		return x.Desc
+	}
+	return ""
+}
+
+func (x *CreateEnvironmentRequest) GetBaseUrl() string {
+	if x != nil {
+		return x.BaseUrl
+	}
+	return ""
+}
+
+func (x *CreateEnvironmentRequest) GetServiceUrls() map[string]string {
+	if x != nil {
+		return x.ServiceUrls
+	}
+	return nil
+}
+
+func (x *CreateEnvironmentRequest) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *CreateEnvironmentRequest) GetAuth() string {
+	if x != nil {
+		return x.Auth
+	}
+	return ""
+}
+
+func (x *CreateEnvironmentRequest) GetTls() *EnvironmentTLS {
+	if x != nil {
+		return x.Tls
+	}
+	return nil
+}
+
+type GetEnvironmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EnvId         string                 `protobuf:"bytes,1,opt,name=env_id,json=envId,proto3" json:"env_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEnvironmentRequest) Reset() {
+	*x = GetEnvironmentRequest{}
+	mi := &file_Storage_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEnvironmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEnvironmentRequest) ProtoMessage() {}
+
+func (x *GetEnvironmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEnvironmentRequest.ProtoReflect.Descriptor instead.
+func (*GetEnvironmentRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *GetEnvironmentRequest) GetEnvId() string {
+	if x != nil {
+		return x.EnvId
+	}
+	return ""
+}
+
+type UpdateEnvironmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EnvId         string                 `protobuf:"bytes,1,opt,name=env_id,json=envId,proto3" json:"env_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Desc          string                 `protobuf:"bytes,3,opt,name=desc,proto3" json:"desc,omitempty"`
+	BaseUrl       string                 `protobuf:"bytes,4,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	ServiceUrls   map[string]string      `protobuf:"bytes,5,rep,name=service_urls,json=serviceUrls,proto3" json:"service_urls,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Variables     map[string]string      `protobuf:"bytes,6,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Auth          string                 `protobuf:"bytes,7,opt,name=auth,proto3" json:"auth,omitempty"`
+	Tls           *EnvironmentTLS        `protobuf:"bytes,8,opt,name=tls,proto3" json:"tls,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RelatedApi) Reset() {
-	*x = RelatedApi{}
-	mi := &file_Storage_proto_msgTypes[52]
+func (x *UpdateEnvironmentRequest) Reset() {
+	*x = UpdateEnvironmentRequest{}
+	mi := &file_Storage_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RelatedApi) String() string {
+func (x *UpdateEnvironmentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RelatedApi) ProtoMessage() {}
+func (*UpdateEnvironmentRequest) ProtoMessage() {}
 
-func (x *RelatedApi) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[52]
+func (x *UpdateEnvironmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3635,76 +6238,134 @@ func (x *RelatedApi) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RelatedApi.ProtoReflect.Descriptor instead.
-func (*RelatedApi) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{52}
+// Deprecated: Use UpdateEnvironmentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEnvironmentRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{92}
 }
 
-func (x *RelatedApi) GetApiId() string {
+func (x *UpdateEnvironmentRequest) GetEnvId() string {
 	if x != nil {
-		return x.ApiId
+		return x.EnvId
 	}
 	return ""
 }
 
-func (x *RelatedApi) GetName() string {
+func (x *UpdateEnvironmentRequest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *RelatedApi) GetEnabled() bool {
+func (x *UpdateEnvironmentRequest) GetDesc() string {
 	if x != nil {
-		return x.Enabled
+		return x.Desc
 	}
-	return false
+	return ""
 }
 
-func (x *RelatedApi) GetDependency() string {
+func (x *UpdateEnvironmentRequest) GetBaseUrl() string {
 	if x != nil {
-		return x.Dependency
+		return x.BaseUrl
 	}
 	return ""
 }
 
-func (x *RelatedApi) GetExpect() string {
+func (x *UpdateEnvironmentRequest) GetServiceUrls() map[string]string {
 	if x != nil {
-		return x.Expect
+		return x.ServiceUrls
+	}
+	return nil
+}
+
+func (x *UpdateEnvironmentRequest) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *UpdateEnvironmentRequest) GetAuth() string {
+	if x != nil {
+		return x.Auth
 	}
 	return ""
 }
 
-func (x *RelatedApi) GetExtractor() string {
+func (x *UpdateEnvironmentRequest) GetTls() *EnvironmentTLS {
 	if x != nil {
-		return x.Extractor
+		return x.Tls
+	}
+	return nil
+}
+
+type DeleteEnvironmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EnvId         string                 `protobuf:"bytes,1,opt,name=env_id,json=envId,proto3" json:"env_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEnvironmentRequest) Reset() {
+	*x = DeleteEnvironmentRequest{}
+	mi := &file_Storage_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEnvironmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEnvironmentRequest) ProtoMessage() {}
+
+func (x *DeleteEnvironmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEnvironmentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEnvironmentRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *DeleteEnvironmentRequest) GetEnvId() string {
+	if x != nil {
+		return x.EnvId
 	}
 	return ""
 }
 
-type TimeoutSetting struct {
+type EnvironmentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Duration      int64                  `protobuf:"varint,1,opt,name=duration,proto3" json:"duration,omitempty"`
-	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Data          *Environment           `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TimeoutSetting) Reset() {
-	*x = TimeoutSetting{}
-	mi := &file_Storage_proto_msgTypes[53]
+func (x *EnvironmentResponse) Reset() {
+	*x = EnvironmentResponse{}
+	mi := &file_Storage_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TimeoutSetting) String() string {
+func (x *EnvironmentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TimeoutSetting) ProtoMessage() {}
+func (*EnvironmentResponse) ProtoMessage() {}
 
-func (x *TimeoutSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[53]
+func (x *EnvironmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3715,49 +6376,49 @@ func (x *TimeoutSetting) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TimeoutSetting.ProtoReflect.Descriptor instead.
-func (*TimeoutSetting) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{53}
+// Deprecated: Use EnvironmentResponse.ProtoReflect.Descriptor instead.
+func (*EnvironmentResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{94}
 }
 
-func (x *TimeoutSetting) GetDuration() int64 {
+func (x *EnvironmentResponse) GetHeader() *ResponseHeader {
 	if x != nil {
-		return x.Duration
+		return x.Header
 	}
-	return 0
+	return nil
 }
 
-func (x *TimeoutSetting) GetEnabled() bool {
+func (x *EnvironmentResponse) GetData() *Environment {
 	if x != nil {
-		return x.Enabled
+		return x.Data
 	}
-	return false
+	return nil
 }
 
-type RetrySetting struct {
+type EnvironmentListResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MaxRetry      int64                  `protobuf:"varint,1,opt,name=max_retry,json=maxRetry,proto3" json:"max_retry,omitempty"`
-	Interval      int64                  `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`
-	Enabled       bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Data          []*Environment         `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RetrySetting) Reset() {
-	*x = RetrySetting{}
-	mi := &file_Storage_proto_msgTypes[54]
+func (x *EnvironmentListResponse) Reset() {
+	*x = EnvironmentListResponse{}
+	mi := &file_Storage_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RetrySetting) String() string {
+func (x *EnvironmentListResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RetrySetting) ProtoMessage() {}
+func (*EnvironmentListResponse) ProtoMessage() {}
 
-func (x *RetrySetting) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[54]
+func (x *EnvironmentListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3768,55 +6429,60 @@ func (x *RetrySetting) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RetrySetting.ProtoReflect.Descriptor instead.
-func (*RetrySetting) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{54}
+// Deprecated: Use EnvironmentListResponse.ProtoReflect.Descriptor instead.
+func (*EnvironmentListResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{95}
 }
 
-func (x *RetrySetting) GetMaxRetry() int64 {
+func (x *EnvironmentListResponse) GetHeader() *ResponseHeader {
 	if x != nil {
-		return x.MaxRetry
+		return x.Header
 	}
-	return 0
+	return nil
 }
 
-func (x *RetrySetting) GetInterval() int64 {
+func (x *EnvironmentListResponse) GetData() []*Environment {
 	if x != nil {
-		return x.Interval
+		return x.Data
 	}
-	return 0
+	return nil
 }
 
-func (x *RetrySetting) GetEnabled() bool {
+func (x *EnvironmentListResponse) GetTotal() int32 {
 	if x != nil {
-		return x.Enabled
+		return x.Total
 	}
-	return false
+	return 0
 }
 
-type SceneConfigResponse struct {
+// Mock 服务中接口的覆盖配置，未设置的字段使用接口定义
+type MockOverride struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Data          *SceneConfig           `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	ApiId         string                 `protobuf:"bytes,1,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
+	Status        int32                  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`                                                                            // 响应状态码
+	DelayMs       int32                  `protobuf:"varint,3,opt,name=delay_ms,json=delayMs,proto3" json:"delay_ms,omitempty"`                                                           // 响应延迟（毫秒），不超过 60000
+	Body          string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`                                                                                 // 响应体
+	Headers       map[string]string      `protobuf:"bytes,5,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 额外的响应头
+	ContentType   string                 `protobuf:"bytes,6,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SceneConfigResponse) Reset() {
-	*x = SceneConfigResponse{}
-	mi := &file_Storage_proto_msgTypes[55]
+func (x *MockOverride) Reset() {
+	*x = MockOverride{}
+	mi := &file_Storage_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SceneConfigResponse) String() string {
+func (x *MockOverride) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SceneConfigResponse) ProtoMessage() {}
+func (*MockOverride) ProtoMessage() {}
 
-func (x *SceneConfigResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[55]
+func (x *MockOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3827,49 +6493,80 @@ func (x *SceneConfigResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SceneConfigResponse.ProtoReflect.Descriptor instead.
-func (*SceneConfigResponse) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{55}
+// Deprecated: Use MockOverride.ProtoReflect.Descriptor instead.
+func (*MockOverride) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{96}
 }
 
-func (x *SceneConfigResponse) GetHeader() *ResponseHeader {
+func (x *MockOverride) GetApiId() string {
 	if x != nil {
-		return x.Header
+		return x.ApiId
 	}
-	return nil
+	return ""
 }
 
-func (x *SceneConfigResponse) GetData() *SceneConfig {
+func (x *MockOverride) GetStatus() int32 {
 	if x != nil {
-		return x.Data
+		return x.Status
+	}
+	return 0
+}
+
+func (x *MockOverride) GetDelayMs() int32 {
+	if x != nil {
+		return x.DelayMs
+	}
+	return 0
+}
+
+func (x *MockOverride) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *MockOverride) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
 	}
 	return nil
 }
 
-type SceneConfigListResponse struct {
+func (x *MockOverride) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+type MockRoute struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Data          []*SceneConfig         `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
-	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	ApiId         string                 `protobuf:"bytes,1,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Method        string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	Path          string                 `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	Statuses      []int32                `protobuf:"varint,5,rep,packed,name=statuses,proto3" json:"statuses,omitempty"` // 接口定义的响应状态码
+	Override      *MockOverride          `protobuf:"bytes,6,opt,name=override,proto3" json:"override,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SceneConfigListResponse) Reset() {
-	*x = SceneConfigListResponse{}
-	mi := &file_Storage_proto_msgTypes[56]
+func (x *MockRoute) Reset() {
+	*x = MockRoute{}
+	mi := &file_Storage_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SceneConfigListResponse) String() string {
+func (x *MockRoute) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SceneConfigListResponse) ProtoMessage() {}
+func (*MockRoute) ProtoMessage() {}
 
-func (x *SceneConfigListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[56]
+func (x *MockRoute) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3880,54 +6577,77 @@ func (x *SceneConfigListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SceneConfigListResponse.ProtoReflect.Descriptor instead.
-func (*SceneConfigListResponse) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{56}
+// Deprecated: Use MockRoute.ProtoReflect.Descriptor instead.
+func (*MockRoute) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{97}
 }
 
-func (x *SceneConfigListResponse) GetHeader() *ResponseHeader {
+func (x *MockRoute) GetApiId() string {
 	if x != nil {
-		return x.Header
+		return x.ApiId
 	}
-	return nil
+	return ""
 }
 
-func (x *SceneConfigListResponse) GetData() []*SceneConfig {
+func (x *MockRoute) GetName() string {
 	if x != nil {
-		return x.Data
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MockRoute) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *MockRoute) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *MockRoute) GetStatuses() []int32 {
+	if x != nil {
+		return x.Statuses
 	}
 	return nil
 }
 
-func (x *SceneConfigListResponse) GetTotal() int32 {
+func (x *MockRoute) GetOverride() *MockOverride {
 	if x != nil {
-		return x.Total
+		return x.Override
 	}
-	return 0
+	return nil
 }
 
-type GenerateDependencyRequest struct {
+// port 为 0 时随机分配端口
+type StartMockRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ApiId         string                 `protobuf:"bytes,1,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Port          int32                  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateDependencyRequest) Reset() {
-	*x = GenerateDependencyRequest{}
-	mi := &file_Storage_proto_msgTypes[57]
+func (x *StartMockRequest) Reset() {
+	*x = StartMockRequest{}
+	mi := &file_Storage_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateDependencyRequest) String() string {
+func (x *StartMockRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateDependencyRequest) ProtoMessage() {}
+func (*StartMockRequest) ProtoMessage() {}
 
-func (x *GenerateDependencyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[57]
+func (x *StartMockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3938,41 +6658,49 @@ func (x *GenerateDependencyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateDependencyRequest.ProtoReflect.Descriptor instead.
-func (*GenerateDependencyRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{57}
+// Deprecated: Use StartMockRequest.ProtoReflect.Descriptor instead.
+func (*StartMockRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{98}
 }
 
-func (x *GenerateDependencyRequest) GetApiId() string {
+func (x *StartMockRequest) GetProjectId() string {
 	if x != nil {
-		return x.ApiId
+		return x.ProjectId
 	}
 	return ""
 }
 
-type GenerateDependencyResponse struct {
+func (x *StartMockRequest) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+type StartMockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Dependency    *Dependency            `protobuf:"bytes,2,opt,name=dependency,proto3" json:"dependency,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"` // Mock 服务的基础URL，可作为执行环境的 base_url
+	RouteCount    int32                  `protobuf:"varint,3,opt,name=route_count,json=routeCount,proto3" json:"route_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateDependencyResponse) Reset() {
-	*x = GenerateDependencyResponse{}
-	mi := &file_Storage_proto_msgTypes[58]
+func (x *StartMockResponse) Reset() {
+	*x = StartMockResponse{}
+	mi := &file_Storage_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateDependencyResponse) String() string {
+func (x *StartMockResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateDependencyResponse) ProtoMessage() {}
+func (*StartMockResponse) ProtoMessage() {}
 
-func (x *GenerateDependencyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[58]
+func (x *StartMockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3983,47 +6711,54 @@ func (x *GenerateDependencyResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateDependencyResponse.ProtoReflect.Descriptor instead.
-func (*GenerateDependencyResponse) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{58}
+// Deprecated: Use StartMockResponse.ProtoReflect.Descriptor instead.
+func (*StartMockResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{99}
 }
 
-func (x *GenerateDependencyResponse) GetHeader() *ResponseHeader {
+func (x *StartMockResponse) GetHeader() *ResponseHeader {
 	if x != nil {
 		return x.Header
 	}
 	return nil
 }
 
-func (x *GenerateDependencyResponse) GetDependency() *Dependency {
+func (x *StartMockResponse) GetUrl() string {
 	if x != nil {
-		return x.Dependency
+		return x.Url
 	}
-	return nil
+	return ""
 }
 
-type GenerateExtractorRequest struct {
+func (x *StartMockResponse) GetRouteCount() int32 {
+	if x != nil {
+		return x.RouteCount
+	}
+	return 0
+}
+
+type StopMockRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ApiId         string                 `protobuf:"bytes,1,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateExtractorRequest) Reset() {
-	*x = GenerateExtractorRequest{}
-	mi := &file_Storage_proto_msgTypes[59]
+func (x *StopMockRequest) Reset() {
+	*x = StopMockRequest{}
+	mi := &file_Storage_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateExtractorRequest) String() string {
+func (x *StopMockRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateExtractorRequest) ProtoMessage() {}
+func (*StopMockRequest) ProtoMessage() {}
 
-func (x *GenerateExtractorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[59]
+func (x *StopMockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4034,41 +6769,40 @@ func (x *GenerateExtractorRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateExtractorRequest.ProtoReflect.Descriptor instead.
-func (*GenerateExtractorRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{59}
+// Deprecated: Use StopMockRequest.ProtoReflect.Descriptor instead.
+func (*StopMockRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{100}
 }
 
-func (x *GenerateExtractorRequest) GetApiId() string {
+func (x *StopMockRequest) GetProjectId() string {
 	if x != nil {
-		return x.ApiId
+		return x.ProjectId
 	}
 	return ""
 }
 
-type GenerateExtractorResponse struct {
+type StopMockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Extractor     *Extractor             `protobuf:"bytes,2,opt,name=extractor,proto3" json:"extractor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateExtractorResponse) Reset() {
-	*x = GenerateExtractorResponse{}
-	mi := &file_Storage_proto_msgTypes[60]
+func (x *StopMockResponse) Reset() {
+	*x = StopMockResponse{}
+	mi := &file_Storage_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateExtractorResponse) String() string {
+func (x *StopMockResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateExtractorResponse) ProtoMessage() {}
+func (*StopMockResponse) ProtoMessage() {}
 
-func (x *GenerateExtractorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[60]
+func (x *StopMockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4079,47 +6813,40 @@ func (x *GenerateExtractorResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateExtractorResponse.ProtoReflect.Descriptor instead.
-func (*GenerateExtractorResponse) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{60}
+// Deprecated: Use StopMockResponse.ProtoReflect.Descriptor instead.
+func (*StopMockResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{101}
 }
 
-func (x *GenerateExtractorResponse) GetHeader() *ResponseHeader {
+func (x *StopMockResponse) GetHeader() *ResponseHeader {
 	if x != nil {
 		return x.Header
 	}
 	return nil
 }
 
-func (x *GenerateExtractorResponse) GetExtractor() *Extractor {
-	if x != nil {
-		return x.Extractor
-	}
-	return nil
-}
-
-type GenerateExpectRequest struct {
+type ListMockRoutesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ApiId         string                 `protobuf:"bytes,1,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateExpectRequest) Reset() {
-	*x = GenerateExpectRequest{}
-	mi := &file_Storage_proto_msgTypes[61]
+func (x *ListMockRoutesRequest) Reset() {
+	*x = ListMockRoutesRequest{}
+	mi := &file_Storage_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateExpectRequest) String() string {
+func (x *ListMockRoutesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateExpectRequest) ProtoMessage() {}
+func (*ListMockRoutesRequest) ProtoMessage() {}
 
-func (x *GenerateExpectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[61]
+func (x *ListMockRoutesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4130,41 +6857,43 @@ func (x *GenerateExpectRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateExpectRequest.ProtoReflect.Descriptor instead.
-func (*GenerateExpectRequest) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{61}
+// Deprecated: Use ListMockRoutesRequest.ProtoReflect.Descriptor instead.
+func (*ListMockRoutesRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{102}
 }
 
-func (x *GenerateExpectRequest) GetApiId() string {
+func (x *ListMockRoutesRequest) GetProjectId() string {
 	if x != nil {
-		return x.ApiId
+		return x.ProjectId
 	}
 	return ""
 }
 
-type GenerateExpectResponse struct {
+type ListMockRoutesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Expect        *Expect                `protobuf:"bytes,2,opt,name=expect,proto3" json:"expect,omitempty"`
+	Running       bool                   `protobuf:"varint,2,opt,name=running,proto3" json:"running,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Routes        []*MockRoute           `protobuf:"bytes,4,rep,name=routes,proto3" json:"routes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateExpectResponse) Reset() {
-	*x = GenerateExpectResponse{}
-	mi := &file_Storage_proto_msgTypes[62]
+func (x *ListMockRoutesResponse) Reset() {
+	*x = ListMockRoutesResponse{}
+	mi := &file_Storage_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateExpectResponse) String() string {
+func (x *ListMockRoutesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateExpectResponse) ProtoMessage() {}
+func (*ListMockRoutesResponse) ProtoMessage() {}
 
-func (x *GenerateExpectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[62]
+func (x *ListMockRoutesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4175,52 +6904,62 @@ func (x *GenerateExpectResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateExpectResponse.ProtoReflect.Descriptor instead.
-func (*GenerateExpectResponse) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{62}
+// Deprecated: Use ListMockRoutesResponse.ProtoReflect.Descriptor instead.
+func (*ListMockRoutesResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{103}
 }
 
-func (x *GenerateExpectResponse) GetHeader() *ResponseHeader {
+func (x *ListMockRoutesResponse) GetHeader() *ResponseHeader {
 	if x != nil {
 		return x.Header
 	}
 	return nil
 }
 
-func (x *GenerateExpectResponse) GetExpect() *Expect {
+func (x *ListMockRoutesResponse) GetRunning() bool {
 	if x != nil {
-		return x.Expect
+		return x.Running
+	}
+	return false
+}
+
+func (x *ListMockRoutesResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ListMockRoutesResponse) GetRoutes() []*MockRoute {
+	if x != nil {
+		return x.Routes
 	}
 	return nil
 }
 
-type Dependency struct {
+type SetMockOverrideRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ApiId         string                 `protobuf:"bytes,1,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
-	DependId      string                 `protobuf:"bytes,2,opt,name=depend_id,json=dependId,proto3" json:"depend_id,omitempty"`
-	DependType    string                 `protobuf:"bytes,3,opt,name=depend_type,json=dependType,proto3" json:"depend_type,omitempty"`
-	RelatedScene  string                 `protobuf:"bytes,4,opt,name=related_scene,json=relatedScene,proto3" json:"related_scene,omitempty"`
-	RelatedApi    string                 `protobuf:"bytes,5,opt,name=related_api,json=relatedApi,proto3" json:"related_api,omitempty"`
-	DbConfig      string                 `protobuf:"bytes,6,opt,name=db_config,json=dbConfig,proto3" json:"db_config,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Override      *MockOverride          `protobuf:"bytes,2,opt,name=override,proto3" json:"override,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Dependency) Reset() {
-	*x = Dependency{}
-	mi := &file_Storage_proto_msgTypes[63]
+func (x *SetMockOverrideRequest) Reset() {
+	*x = SetMockOverrideRequest{}
+	mi := &file_Storage_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Dependency) String() string {
+func (x *SetMockOverrideRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Dependency) ProtoMessage() {}
+func (*SetMockOverrideRequest) ProtoMessage() {}
 
-func (x *Dependency) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[63]
+func (x *SetMockOverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4231,78 +6970,47 @@ func (x *Dependency) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Dependency.ProtoReflect.Descriptor instead.
-func (*Dependency) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{63}
-}
-
-func (x *Dependency) GetApiId() string {
-	if x != nil {
-		return x.ApiId
-	}
-	return ""
-}
-
-func (x *Dependency) GetDependId() string {
-	if x != nil {
-		return x.DependId
-	}
-	return ""
-}
-
-func (x *Dependency) GetDependType() string {
-	if x != nil {
-		return x.DependType
-	}
-	return ""
-}
-
-func (x *Dependency) GetRelatedScene() string {
-	if x != nil {
-		return x.RelatedScene
-	}
-	return ""
+// Deprecated: Use SetMockOverrideRequest.ProtoReflect.Descriptor instead.
+func (*SetMockOverrideRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{104}
 }
 
-func (x *Dependency) GetRelatedApi() string {
+func (x *SetMockOverrideRequest) GetProjectId() string {
 	if x != nil {
-		return x.RelatedApi
+		return x.ProjectId
 	}
 	return ""
 }
 
-func (x *Dependency) GetDbConfig() string {
+func (x *SetMockOverrideRequest) GetOverride() *MockOverride {
 	if x != nil {
-		return x.DbConfig
+		return x.Override
 	}
-	return ""
+	return nil
 }
 
-type Expect struct {
+type SetMockOverrideResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ApiId         string                 `protobuf:"bytes,1,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
-	JsonPath      string                 `protobuf:"bytes,2,opt,name=json_path,json=jsonPath,proto3" json:"json_path,omitempty"`
-	Operation     string                 `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`
-	Value         *Dependency            `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Expect) Reset() {
-	*x = Expect{}
-	mi := &file_Storage_proto_msgTypes[64]
+func (x *SetMockOverrideResponse) Reset() {
+	*x = SetMockOverrideResponse{}
+	mi := &file_Storage_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Expect) String() string {
+func (x *SetMockOverrideResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Expect) ProtoMessage() {}
+func (*SetMockOverrideResponse) ProtoMessage() {}
 
-func (x *Expect) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[64]
+func (x *SetMockOverrideResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4313,62 +7021,41 @@ func (x *Expect) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Expect.ProtoReflect.Descriptor instead.
-func (*Expect) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{64}
-}
-
-func (x *Expect) GetApiId() string {
-	if x != nil {
-		return x.ApiId
-	}
-	return ""
-}
-
-func (x *Expect) GetJsonPath() string {
-	if x != nil {
-		return x.JsonPath
-	}
-	return ""
-}
-
-func (x *Expect) GetOperation() string {
-	if x != nil {
-		return x.Operation
-	}
-	return ""
+// Deprecated: Use SetMockOverrideResponse.ProtoReflect.Descriptor instead.
+func (*SetMockOverrideResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{105}
 }
 
-func (x *Expect) GetValue() *Dependency {
+func (x *SetMockOverrideResponse) GetHeader() *ResponseHeader {
 	if x != nil {
-		return x.Value
+		return x.Header
 	}
 	return nil
 }
 
-type Extractor struct {
+type DeleteMockOverrideRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ApiId         string                 `protobuf:"bytes,1,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
-	Extractors    []*ExtractConfig       `protobuf:"bytes,2,rep,name=extractors,proto3" json:"extractors,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	ApiId         string                 `protobuf:"bytes,2,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Extractor) Reset() {
-	*x = Extractor{}
-	mi := &file_Storage_proto_msgTypes[65]
+func (x *DeleteMockOverrideRequest) Reset() {
+	*x = DeleteMockOverrideRequest{}
+	mi := &file_Storage_proto_msgTypes[106]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Extractor) String() string {
+func (x *DeleteMockOverrideRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Extractor) ProtoMessage() {}
+func (*DeleteMockOverrideRequest) ProtoMessage() {}
 
-func (x *Extractor) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[65]
+func (x *DeleteMockOverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[106]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4379,48 +7066,47 @@ func (x *Extractor) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Extractor.ProtoReflect.Descriptor instead.
-func (*Extractor) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{65}
+// Deprecated: Use DeleteMockOverrideRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMockOverrideRequest) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{106}
 }
 
-func (x *Extractor) GetApiId() string {
+func (x *DeleteMockOverrideRequest) GetProjectId() string {
 	if x != nil {
-		return x.ApiId
+		return x.ProjectId
 	}
 	return ""
 }
 
-func (x *Extractor) GetExtractors() []*ExtractConfig {
+func (x *DeleteMockOverrideRequest) GetApiId() string {
 	if x != nil {
-		return x.Extractors
+		return x.ApiId
 	}
-	return nil
+	return ""
 }
 
-type ExtractConfig struct {
+type DeleteMockOverrideResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	JsonPath      string                 `protobuf:"bytes,1,opt,name=json_path,json=jsonPath,proto3" json:"json_path,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ExtractConfig) Reset() {
-	*x = ExtractConfig{}
-	mi := &file_Storage_proto_msgTypes[66]
+func (x *DeleteMockOverrideResponse) Reset() {
+	*x = DeleteMockOverrideResponse{}
+	mi := &file_Storage_proto_msgTypes[107]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExtractConfig) String() string {
+func (x *DeleteMockOverrideResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExtractConfig) ProtoMessage() {}
+func (*DeleteMockOverrideResponse) ProtoMessage() {}
 
-func (x *ExtractConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[66]
+func (x *DeleteMockOverrideResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_Storage_proto_msgTypes[107]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4431,23 +7117,16 @@ func (x *ExtractConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExtractConfig.ProtoReflect.Descriptor instead.
-func (*ExtractConfig) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{66}
-}
-
-func (x *ExtractConfig) GetJsonPath() string {
-	if x != nil {
-		return x.JsonPath
-	}
-	return ""
+// Deprecated: Use DeleteMockOverrideResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMockOverrideResponse) Descriptor() ([]byte, []int) {
+	return file_Storage_proto_rawDescGZIP(), []int{107}
 }
 
-func (x *ExtractConfig) GetName() string {
+func (x *DeleteMockOverrideResponse) GetHeader() *ResponseHeader {
 	if x != nil {
-		return x.Name
+		return x.Header
 	}
-	return ""
+	return nil
 }
 
 type TaskListResponse_TaskItem struct {
@@ -4457,6 +7136,7 @@ type TaskListResponse_TaskItem struct {
 	//
 	//	*TaskListResponse_TaskItem_ApiSpec
 	//	*TaskListResponse_TaskItem_SyncSpec
+	//	*TaskListResponse_TaskItem_WorkflowSpec
 	Spec          isTaskListResponse_TaskItem_Spec `protobuf_oneof:"spec"`
 	Type          int64                            `protobuf:"varint,4,opt,name=type,proto3" json:"type,omitempty"`
 	CreateAt      string                           `protobuf:"bytes,5,opt,name=create_at,json=createAt,proto3" json:"create_at,omitempty"`
@@ -4467,7 +7147,7 @@ type TaskListResponse_TaskItem struct {
 
 func (x *TaskListResponse_TaskItem) Reset() {
 	*x = TaskListResponse_TaskItem{}
-	mi := &file_Storage_proto_msgTypes[71]
+	mi := &file_Storage_proto_msgTypes[112]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4479,7 +7159,7 @@ func (x *TaskListResponse_TaskItem) String() string {
 func (*TaskListResponse_TaskItem) ProtoMessage() {}
 
 func (x *TaskListResponse_TaskItem) ProtoReflect() protoreflect.Message {
-	mi := &file_Storage_proto_msgTypes[71]
+	mi := &file_Storage_proto_msgTypes[112]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4492,7 +7172,7 @@ func (x *TaskListResponse_TaskItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TaskListResponse_TaskItem.ProtoReflect.Descriptor instead.
 func (*TaskListResponse_TaskItem) Descriptor() ([]byte, []int) {
-	return file_Storage_proto_rawDescGZIP(), []int{40, 0}
+	return file_Storage_proto_rawDescGZIP(), []int{41, 0}
 }
 
 func (x *TaskListResponse_TaskItem) GetMeta() *TaskMeta {
@@ -4527,6 +7207,15 @@ func (x *TaskListResponse_TaskItem) GetSyncSpec() *TaskSyncSpec {
 	return nil
 }
 
+func (x *TaskListResponse_TaskItem) GetWorkflowSpec() *TaskWorkflowSpec {
+	if x != nil {
+		if x, ok := x.Spec.(*TaskListResponse_TaskItem_WorkflowSpec); ok {
+			return x.WorkflowSpec
+		}
+	}
+	return nil
+}
+
 func (x *TaskListResponse_TaskItem) GetType() int64 {
 	if x != nil {
 		return x.Type
@@ -4560,10 +7249,16 @@ type TaskListResponse_TaskItem_SyncSpec struct {
 	SyncSpec *TaskSyncSpec `protobuf:"bytes,3,opt,name=sync_spec,json=syncSpec,proto3,oneof"`
 }
 
+type TaskListResponse_TaskItem_WorkflowSpec struct {
+	WorkflowSpec *TaskWorkflowSpec `protobuf:"bytes,7,opt,name=workflow_spec,json=workflowSpec,proto3,oneof"`
+}
+
 func (*TaskListResponse_TaskItem_ApiSpec) isTaskListResponse_TaskItem_Spec() {}
 
 func (*TaskListResponse_TaskItem_SyncSpec) isTaskListResponse_TaskItem_Spec() {}
 
+func (*TaskListResponse_TaskItem_WorkflowSpec) isTaskListResponse_TaskItem_Spec() {}
+
 var File_Storage_proto protoreflect.FileDescriptor
 
 const file_Storage_proto_rawDesc = "" +
@@ -4615,7 +7310,10 @@ const file_Storage_proto_rawDesc = "" +
 	"\tsync_type\x18\x01 \x01(\tR\bsyncType\x12+\n" +
 	"\x06source\x18\x02 \x03(\v2\x13.storage.SyncSourceR\x06source\x12:\n" +
 	"\vdestination\x18\x03 \x03(\v2\x18.storage.SyncDestinationR\vdestination\x12-\n" +
-	"\bstrategy\x18\x04 \x01(\v2\x11.storage.StrategyR\bstrategy\";\n" +
+	"\bstrategy\x18\x04 \x01(\v2\x11.storage.StrategyR\bstrategy\"Y\n" +
+	"\x10TaskWorkflowSpec\x12\x16\n" +
+	"\x06config\x18\x01 \x01(\tR\x06config\x12-\n" +
+	"\bstrategy\x18\x02 \x01(\v2\x11.storage.StrategyR\bstrategy\";\n" +
 	"\n" +
 	"SyncSource\x12-\n" +
 	"\x06apifox\x18\x01 \x01(\v2\x15.storage.ApifoxConfigR\x06apifox\"z\n" +
@@ -4637,7 +7335,7 @@ const file_Storage_proto_rawDesc = "" +
 	"\x06dbname\x18\x05 \x03(\tR\x06dbname\x12\x1e\n" +
 	"\n" +
 	"collection\x18\x06 \x03(\tR\n" +
-	"collection\"\xc5\x01\n" +
+	"collection\"\x8a\x02\n" +
 	"\bStrategy\x12\x12\n" +
 	"\x04auto\x18\x01 \x01(\bR\x04auto\x12'\n" +
 	"\x0fcron_expression\x18\x02 \x01(\tR\x0ecronExpression\x12\x1f\n" +
@@ -4645,7 +7343,9 @@ const file_Storage_proto_rawDesc = "" +
 	"retryCount\x12%\n" +
 	"\x0eretry_interval\x18\x04 \x01(\x05R\rretryInterval\x12\x1a\n" +
 	"\bpriority\x18\x05 \x01(\x05R\bpriority\x12\x18\n" +
-	"\atimeout\x18\x06 \x01(\x05R\atimeout\"\xb7\x01\n" +
+	"\atimeout\x18\x06 \x01(\x05R\atimeout\x12'\n" +
+	"\x0fconflict_policy\x18\a \x01(\tR\x0econflictPolicy\x12\x1a\n" +
+	"\btimezone\x18\b \x01(\tR\btimezone\"\xb7\x01\n" +
 	"\bTestData\x12\x17\n" +
 	"\adata_id\x18\x01 \x01(\tR\x06dataId\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12;\n" +
@@ -4696,22 +7396,24 @@ const file_Storage_proto_rawDesc = "" +
 	"\x05value\x18\x02 \x01(\tR\x05value\"7\n" +
 	"\tScenarios\x12\x12\n" +
 	"\x04scid\x18\x01 \x01(\tR\x04scid\x12\x16\n" +
-	"\x06scname\x18\x02 \x01(\tR\x06scname\"\xd3\x01\n" +
+	"\x06scname\x18\x02 \x01(\tR\x06scname\"\x95\x02\n" +
 	"\x11CreateTaskRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
 	"\x04type\x18\x02 \x01(\x0e2\x11.storage.TaskTypeR\x04type\x121\n" +
 	"\bapi_spec\x18\x03 \x01(\v2\x14.storage.TaskAPISpecH\x00R\aapiSpec\x124\n" +
-	"\tsync_spec\x18\x04 \x01(\v2\x15.storage.TaskSyncSpecH\x00R\bsyncSpec\x12\x12\n" +
+	"\tsync_spec\x18\x04 \x01(\v2\x15.storage.TaskSyncSpecH\x00R\bsyncSpec\x12@\n" +
+	"\rworkflow_spec\x18\x06 \x01(\v2\x19.storage.TaskWorkflowSpecH\x00R\fworkflowSpec\x12\x12\n" +
 	"\x04desc\x18\x05 \x01(\tR\x04descB\x06\n" +
 	"\x04spec\")\n" +
 	"\x0eGetTaskRequest\x12\x17\n" +
-	"\atask_id\x18\x01 \x01(\tR\x06taskId\"\xc5\x01\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"\x87\x02\n" +
 	"\x11UpdateTaskRequest\x12\x17\n" +
 	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
 	"\x04desc\x18\x03 \x01(\tR\x04desc\x121\n" +
 	"\bapi_spec\x18\x04 \x01(\v2\x14.storage.TaskAPISpecH\x00R\aapiSpec\x124\n" +
-	"\tsync_spec\x18\x05 \x01(\v2\x15.storage.TaskSyncSpecH\x00R\bsyncSpecB\x06\n" +
+	"\tsync_spec\x18\x05 \x01(\v2\x15.storage.TaskSyncSpecH\x00R\bsyncSpec\x12@\n" +
+	"\rworkflow_spec\x18\x06 \x01(\v2\x19.storage.TaskWorkflowSpecH\x00R\fworkflowSpecB\x06\n" +
 	"\x04spec\",\n" +
 	"\x11DeleteTaskRequest\x12\x17\n" +
 	"\atask_id\x18\x01 \x01(\tR\x06taskId\"-\n" +
@@ -4764,38 +7466,147 @@ const file_Storage_proto_rawDesc = "" +
 	"\x15SyncInterfaceResponse\x12/\n" +
 	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header\x12%\n" +
 	"\x0esynced_records\x18\x02 \x01(\x05R\rsyncedRecords\x12/\n" +
-	"\tsync_time\x18\x03 \x01(\v2\x12.storage.TimestampR\bsyncTime\"\xa5\x02\n" +
+	"\tsync_time\x18\x03 \x01(\v2\x12.storage.TimestampR\bsyncTime\"\xe7\x02\n" +
 	"\fTaskResponse\x12/\n" +
 	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header\x12%\n" +
 	"\x04meta\x18\x02 \x01(\v2\x11.storage.TaskMetaR\x04meta\x121\n" +
 	"\bapi_spec\x18\x03 \x01(\v2\x14.storage.TaskAPISpecH\x00R\aapiSpec\x124\n" +
-	"\tsync_spec\x18\x04 \x01(\v2\x15.storage.TaskSyncSpecH\x00R\bsyncSpec\x12\x12\n" +
+	"\tsync_spec\x18\x04 \x01(\v2\x15.storage.TaskSyncSpecH\x00R\bsyncSpec\x12@\n" +
+	"\rworkflow_spec\x18\b \x01(\v2\x19.storage.TaskWorkflowSpecH\x00R\fworkflowSpec\x12\x12\n" +
 	"\x04type\x18\x05 \x01(\x03R\x04type\x12\x1b\n" +
 	"\tcreate_at\x18\x06 \x01(\tR\bcreateAt\x12\x1b\n" +
 	"\tupdate_at\x18\a \x01(\tR\bupdateAtB\x06\n" +
-	"\x04spec\"\x84\x03\n" +
+	"\x04spec\"\xc6\x03\n" +
 	"\x10TaskListResponse\x12/\n" +
 	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header\x126\n" +
 	"\x04data\x18\x02 \x03(\v2\".storage.TaskListResponse.TaskItemR\x04data\x12\x14\n" +
-	"\x05total\x18\x03 \x01(\x05R\x05total\x1a\xf0\x01\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x1a\xb2\x02\n" +
 	"\bTaskItem\x12%\n" +
 	"\x04meta\x18\x01 \x01(\v2\x11.storage.TaskMetaR\x04meta\x121\n" +
 	"\bapi_spec\x18\x02 \x01(\v2\x14.storage.TaskAPISpecH\x00R\aapiSpec\x124\n" +
-	"\tsync_spec\x18\x03 \x01(\v2\x15.storage.TaskSyncSpecH\x00R\bsyncSpec\x12\x12\n" +
+	"\tsync_spec\x18\x03 \x01(\v2\x15.storage.TaskSyncSpecH\x00R\bsyncSpec\x12@\n" +
+	"\rworkflow_spec\x18\a \x01(\v2\x19.storage.TaskWorkflowSpecH\x00R\fworkflowSpec\x12\x12\n" +
 	"\x04type\x18\x04 \x01(\x03R\x04type\x12\x1b\n" +
 	"\tcreate_at\x18\x05 \x01(\tR\bcreateAt\x12\x1b\n" +
 	"\tupdate_at\x18\x06 \x01(\tR\bupdateAtB\x06\n" +
 	"\x04spec\"f\n" +
 	"\x0eDeleteResponse\x12/\n" +
 	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header\x12#\n" +
-	"\raffected_rows\x18\x02 \x01(\x03R\faffectedRows\"-\n" +
+	"\raffected_rows\x18\x02 \x01(\x03R\faffectedRows\"n\n" +
 	"\x12ExecuteTaskRequest\x12\x17\n" +
-	"\atask_id\x18\x01 \x01(\tR\x06taskId\"\x9c\x01\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x18\n" +
+	"\atrigger\x18\x02 \x01(\tR\atrigger\x12%\n" +
+	"\x0eenvironment_id\x18\x03 \x01(\tR\renvironmentId\"\x9c\x01\n" +
 	"\x13ExecuteTaskResponse\x12/\n" +
 	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header\x12!\n" +
 	"\fexecution_id\x18\x02 \x01(\tR\vexecutionId\x121\n" +
 	"\n" +
-	"start_time\x18\x03 \x01(\v2\x12.storage.TimestampR\tstartTime\"3\n" +
+	"start_time\x18\x03 \x01(\v2\x12.storage.TimestampR\tstartTime\";\n" +
+	"\x16CancelExecutionRequest\x12!\n" +
+	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId\"J\n" +
+	"\x17CancelExecutionResponse\x12/\n" +
+	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header\";\n" +
+	"\x16ResumeExecutionRequest\x12!\n" +
+	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId\"m\n" +
+	"\x17ResumeExecutionResponse\x12/\n" +
+	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header\x12!\n" +
+	"\fexecution_id\x18\x02 \x01(\tR\vexecutionId\"\xd9\x01\n" +
+	"\x0fQueuedExecution\x12!\n" +
+	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12\x1b\n" +
+	"\ttask_type\x18\x03 \x01(\tR\btaskType\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\x12\x1a\n" +
+	"\bposition\x18\x05 \x01(\x05R\bposition\x125\n" +
+	"\fenqueue_time\x18\x06 \x01(\v2\x12.storage.TimestampR\venqueueTime\"8\n" +
+	"\x19ListExecutionQueueRequest\x12\x1b\n" +
+	"\ttask_type\x18\x01 \x01(\tR\btaskType\"\x89\x04\n" +
+	"\x1aListExecutionQueueResponse\x12/\n" +
+	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header\x12.\n" +
+	"\x05items\x18\x02 \x03(\v2\x18.storage.QueuedExecutionR\x05items\x12\x18\n" +
+	"\aworkers\x18\x03 \x01(\x05R\aworkers\x12\x18\n" +
+	"\arunning\x18\x04 \x01(\x05R\arunning\x12\x16\n" +
+	"\x06queued\x18\x05 \x01(\x05R\x06queued\x12^\n" +
+	"\x0frunning_by_type\x18\x06 \x03(\v26.storage.ListExecutionQueueResponse.RunningByTypeEntryR\rrunningByType\x12[\n" +
+	"\x0equeued_by_type\x18\a \x03(\v25.storage.ListExecutionQueueResponse.QueuedByTypeEntryR\fqueuedByType\x1a@\n" +
+	"\x12RunningByTypeEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a?\n" +
+	"\x11QueuedByTypeEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"X\n" +
+	"\x17ReorderExecutionRequest\x12!\n" +
+	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId\x12\x1a\n" +
+	"\bpriority\x18\x02 \x01(\x05R\bpriority\"g\n" +
+	"\x18ReorderExecutionResponse\x12/\n" +
+	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header\x12\x1a\n" +
+	"\bposition\x18\x02 \x01(\x05R\bposition\"\x8f\x04\n" +
+	"\x0fExecutionRecord\x12!\n" +
+	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12\x1b\n" +
+	"\ttask_type\x18\x03 \x01(\tR\btaskType\x12\x19\n" +
+	"\bsub_type\x18\x04 \x01(\tR\asubType\x12\x18\n" +
+	"\atrigger\x18\x05 \x01(\tR\atrigger\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1a\n" +
+	"\battempts\x18\a \x01(\x05R\battempts\x123\n" +
+	"\vcreate_time\x18\b \x01(\v2\x12.storage.TimestampR\n" +
+	"createTime\x121\n" +
+	"\n" +
+	"start_time\x18\t \x01(\v2\x12.storage.TimestampR\tstartTime\x12-\n" +
+	"\bend_time\x18\n" +
+	" \x01(\v2\x12.storage.TimestampR\aendTime\x12\x1f\n" +
+	"\vduration_ms\x18\v \x01(\x03R\n" +
+	"durationMs\x12\x14\n" +
+	"\x05error\x18\f \x01(\tR\x05error\x12\x16\n" +
+	"\x06errors\x18\r \x03(\tR\x06errors\x12)\n" +
+	"\aresults\x18\x0e \x03(\v2\x0f.storage.StructR\aresults\x12)\n" +
+	"\asummary\x18\x0f \x01(\v2\x0f.storage.StructR\asummary\"8\n" +
+	"\x13GetExecutionRequest\x12!\n" +
+	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId\"\x7f\n" +
+	"\x14GetExecutionResponse\x12/\n" +
+	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header\x126\n" +
+	"\texecution\x18\x02 \x01(\v2\x18.storage.ExecutionRecordR\texecution\"\xdb\x01\n" +
+	"\x15ListExecutionsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x121\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\v2\x12.storage.TimestampR\tstartTime\x12-\n" +
+	"\bend_time\x18\x04 \x01(\v2\x12.storage.TimestampR\aendTime\x12\x12\n" +
+	"\x04page\x18\x05 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\"\x8f\x01\n" +
+	"\x16ListExecutionsResponse\x12/\n" +
+	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header\x12.\n" +
+	"\x05items\x18\x02 \x03(\v2\x18.storage.ExecutionRecordR\x05items\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\";\n" +
+	"\x16GetExecutionHarRequest\x12!\n" +
+	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId\"}\n" +
+	"\x17GetExecutionHarResponse\x12/\n" +
+	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header\x12\x10\n" +
+	"\x03har\x18\x02 \x01(\fR\x03har\x12\x1f\n" +
+	"\ventry_count\x18\x03 \x01(\x05R\n" +
+	"entryCount\"W\n" +
+	"\x11ExportCurlRequest\x12!\n" +
+	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId\x12\x1f\n" +
+	"\ventry_index\x18\x02 \x01(\x05R\n" +
+	"entryIndex\"z\n" +
+	"\x12ExportCurlResponse\x12/\n" +
+	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header\x12\x18\n" +
+	"\acommand\x18\x02 \x01(\tR\acommand\x12\x19\n" +
+	"\bapi_name\x18\x03 \x01(\tR\aapiName\":\n" +
+	"\x15WatchExecutionRequest\x12!\n" +
+	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId\"\xdc\x02\n" +
+	"\x16WatchExecutionResponse\x12/\n" +
+	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header\x12!\n" +
+	"\fexecution_id\x18\x02 \x01(\tR\vexecutionId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\tR\teventType\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1a\n" +
+	"\bprogress\x18\a \x01(\x01R\bprogress\x12'\n" +
+	"\x06result\x18\b \x01(\v2\x0f.storage.StructR\x06result\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\x120\n" +
+	"\ttimestamp\x18\n" +
+	" \x01(\v2\x12.storage.TimestampR\ttimestamp\"3\n" +
 	"\x14GetTestReportRequest\x12\x1b\n" +
 	"\treport_id\x18\x01 \x01(\tR\breportId\"r\n" +
 	"\x12TestReportResponse\x12/\n" +
@@ -4894,7 +7705,125 @@ const file_Storage_proto_rawDesc = "" +
 	"extractors\"@\n" +
 	"\rextractConfig\x12\x1b\n" +
 	"\tjson_path\x18\x01 \x01(\tR\bjsonPath\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name*\x1b\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\xbc\x01\n" +
+	"\x0eEnvironmentTLS\x12\x17\n" +
+	"\aca_cert\x18\x01 \x01(\tR\x06caCert\x12\x1f\n" +
+	"\vclient_cert\x18\x02 \x01(\tR\n" +
+	"clientCert\x12\x1d\n" +
+	"\n" +
+	"client_key\x18\x03 \x01(\tR\tclientKey\x120\n" +
+	"\x14insecure_skip_verify\x18\x04 \x01(\bR\x12insecureSkipVerify\x12\x1f\n" +
+	"\vserver_name\x18\x05 \x01(\tR\n" +
+	"serverName\"\xeb\x03\n" +
+	"\vEnvironment\x12\x15\n" +
+	"\x06env_id\x18\x01 \x01(\tR\x05envId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04desc\x18\x03 \x01(\tR\x04desc\x12\x19\n" +
+	"\bbase_url\x18\x04 \x01(\tR\abaseUrl\x12H\n" +
+	"\fservice_urls\x18\x05 \x03(\v2%.storage.Environment.ServiceUrlsEntryR\vserviceUrls\x12A\n" +
+	"\tvariables\x18\x06 \x03(\v2#.storage.Environment.VariablesEntryR\tvariables\x12\x12\n" +
+	"\x04auth\x18\a \x01(\tR\x04auth\x12)\n" +
+	"\x03tls\x18\b \x01(\v2\x17.storage.EnvironmentTLSR\x03tls\x12\x1b\n" +
+	"\tcreate_at\x18\t \x01(\tR\bcreateAt\x12\x1b\n" +
+	"\tupdate_at\x18\n" +
+	" \x01(\tR\bupdateAt\x1a>\n" +
+	"\x10ServiceUrlsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a<\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc1\x03\n" +
+	"\x18CreateEnvironmentRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04desc\x18\x02 \x01(\tR\x04desc\x12\x19\n" +
+	"\bbase_url\x18\x03 \x01(\tR\abaseUrl\x12U\n" +
+	"\fservice_urls\x18\x04 \x03(\v22.storage.CreateEnvironmentRequest.ServiceUrlsEntryR\vserviceUrls\x12N\n" +
+	"\tvariables\x18\x05 \x03(\v20.storage.CreateEnvironmentRequest.VariablesEntryR\tvariables\x12\x12\n" +
+	"\x04auth\x18\x06 \x01(\tR\x04auth\x12)\n" +
+	"\x03tls\x18\a \x01(\v2\x17.storage.EnvironmentTLSR\x03tls\x1a>\n" +
+	"\x10ServiceUrlsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a<\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\".\n" +
+	"\x15GetEnvironmentRequest\x12\x15\n" +
+	"\x06env_id\x18\x01 \x01(\tR\x05envId\"\xd8\x03\n" +
+	"\x18UpdateEnvironmentRequest\x12\x15\n" +
+	"\x06env_id\x18\x01 \x01(\tR\x05envId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04desc\x18\x03 \x01(\tR\x04desc\x12\x19\n" +
+	"\bbase_url\x18\x04 \x01(\tR\abaseUrl\x12U\n" +
+	"\fservice_urls\x18\x05 \x03(\v22.storage.UpdateEnvironmentRequest.ServiceUrlsEntryR\vserviceUrls\x12N\n" +
+	"\tvariables\x18\x06 \x03(\v20.storage.UpdateEnvironmentRequest.VariablesEntryR\tvariables\x12\x12\n" +
+	"\x04auth\x18\a \x01(\tR\x04auth\x12)\n" +
+	"\x03tls\x18\b \x01(\v2\x17.storage.EnvironmentTLSR\x03tls\x1a>\n" +
+	"\x10ServiceUrlsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a<\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"1\n" +
+	"\x18DeleteEnvironmentRequest\x12\x15\n" +
+	"\x06env_id\x18\x01 \x01(\tR\x05envId\"p\n" +
+	"\x13EnvironmentResponse\x12/\n" +
+	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header\x12(\n" +
+	"\x04data\x18\x02 \x01(\v2\x14.storage.EnvironmentR\x04data\"\x8a\x01\n" +
+	"\x17EnvironmentListResponse\x12/\n" +
+	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header\x12(\n" +
+	"\x04data\x18\x02 \x03(\v2\x14.storage.EnvironmentR\x04data\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\"\x89\x02\n" +
+	"\fMockOverride\x12\x15\n" +
+	"\x06api_id\x18\x01 \x01(\tR\x05apiId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\x05R\x06status\x12\x19\n" +
+	"\bdelay_ms\x18\x03 \x01(\x05R\adelayMs\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\x12<\n" +
+	"\aheaders\x18\x05 \x03(\v2\".storage.MockOverride.HeadersEntryR\aheaders\x12!\n" +
+	"\fcontent_type\x18\x06 \x01(\tR\vcontentType\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb1\x01\n" +
+	"\tMockRoute\x12\x15\n" +
+	"\x06api_id\x18\x01 \x01(\tR\x05apiId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04path\x12\x1a\n" +
+	"\bstatuses\x18\x05 \x03(\x05R\bstatuses\x121\n" +
+	"\boverride\x18\x06 \x01(\v2\x15.storage.MockOverrideR\boverride\"E\n" +
+	"\x10StartMockRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\x05R\x04port\"w\n" +
+	"\x11StartMockResponse\x12/\n" +
+	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1f\n" +
+	"\vroute_count\x18\x03 \x01(\x05R\n" +
+	"routeCount\"0\n" +
+	"\x0fStopMockRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\"C\n" +
+	"\x10StopMockResponse\x12/\n" +
+	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header\"6\n" +
+	"\x15ListMockRoutesRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\"\xa1\x01\n" +
+	"\x16ListMockRoutesResponse\x12/\n" +
+	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header\x12\x18\n" +
+	"\arunning\x18\x02 \x01(\bR\arunning\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12*\n" +
+	"\x06routes\x18\x04 \x03(\v2\x12.storage.MockRouteR\x06routes\"j\n" +
+	"\x16SetMockOverrideRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x121\n" +
+	"\boverride\x18\x02 \x01(\v2\x15.storage.MockOverrideR\boverride\"J\n" +
+	"\x17SetMockOverrideResponse\x12/\n" +
+	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header\"Q\n" +
+	"\x19DeleteMockOverrideRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x15\n" +
+	"\x06api_id\x18\x02 \x01(\tR\x05apiId\"M\n" +
+	"\x1aDeleteMockOverrideResponse\x12/\n" +
+	"\x06header\x18\x01 \x01(\v2\x17.storage.ResponseHeaderR\x06header*\x1b\n" +
 	"\tNullValue\x12\x0e\n" +
 	"\n" +
 	"NULL_VALUE\x10\x00*\x8d\x01\n" +
@@ -4940,9 +7869,19 @@ const file_Storage_proto_rawDesc = "" +
 	"\x0eGetSceneConfig\x12\x1e.storage.GetSceneConfigRequest\x1a\x1c.storage.SceneConfigResponse\x12T\n" +
 	"\x11UpdateSceneConfig\x12!.storage.UpdateSceneConfigRequest\x1a\x1c.storage.SceneConfigResponse\x12O\n" +
 	"\x11DeleteSceneConfig\x12!.storage.DeleteSceneConfigRequest\x1a\x17.storage.DeleteResponse\x12V\n" +
-	"\x10ListSceneConfigs\x12 .storage.ListSceneConfigsRequest\x1a .storage.SceneConfigListResponse2Z\n" +
+	"\x10ListSceneConfigs\x12 .storage.ListSceneConfigsRequest\x1a .storage.SceneConfigListResponse2\xd0\x06\n" +
 	"\x0eExecuteService\x12H\n" +
-	"\vExecuteTask\x12\x1b.storage.ExecuteTaskRequest\x1a\x1c.storage.ExecuteTaskResponse2\xc9\x02\n" +
+	"\vExecuteTask\x12\x1b.storage.ExecuteTaskRequest\x1a\x1c.storage.ExecuteTaskResponse\x12T\n" +
+	"\x0fCancelExecution\x12\x1f.storage.CancelExecutionRequest\x1a .storage.CancelExecutionResponse\x12T\n" +
+	"\x0fResumeExecution\x12\x1f.storage.ResumeExecutionRequest\x1a .storage.ResumeExecutionResponse\x12S\n" +
+	"\x0eWatchExecution\x12\x1e.storage.WatchExecutionRequest\x1a\x1f.storage.WatchExecutionResponse0\x01\x12]\n" +
+	"\x12ListExecutionQueue\x12\".storage.ListExecutionQueueRequest\x1a#.storage.ListExecutionQueueResponse\x12W\n" +
+	"\x10ReorderExecution\x12 .storage.ReorderExecutionRequest\x1a!.storage.ReorderExecutionResponse\x12K\n" +
+	"\fGetExecution\x12\x1c.storage.GetExecutionRequest\x1a\x1d.storage.GetExecutionResponse\x12Q\n" +
+	"\x0eListExecutions\x12\x1e.storage.ListExecutionsRequest\x1a\x1f.storage.ListExecutionsResponse\x12T\n" +
+	"\x0fGetExecutionHar\x12\x1f.storage.GetExecutionHarRequest\x1a .storage.GetExecutionHarResponse\x12E\n" +
+	"\n" +
+	"ExportCurl\x12\x1a.storage.ExportCurlRequest\x1a\x1b.storage.ExportCurlResponse2\xc9\x02\n" +
 	"\x10InterfaceService\x12E\n" +
 	"\x10GetInterfaceList\x12\x0e.storage.Empty\x1a!.storage.GetInterfaceListResponse\x12Q\n" +
 	"\x12GetInterfaceDetail\x12\x1c.storage.GetInterfaceRequest\x1a\x1d.storage.GetInterfaceResponse\x12K\n" +
@@ -4951,7 +7890,19 @@ const file_Storage_proto_rawDesc = "" +
 	"\x0fGenerateService\x12]\n" +
 	"\x12GenerateDependency\x12\".storage.GenerateDependencyRequest\x1a#.storage.GenerateDependencyResponse\x12Z\n" +
 	"\x11GenerateExtractor\x12!.storage.GenerateExtractorRequest\x1a\".storage.GenerateExtractorResponse\x12Q\n" +
-	"\x0eGenerateExpect\x12\x1e.storage.GenerateExpectRequest\x1a\x1f.storage.GenerateExpectResponseB\vZ\t./storageb\x06proto3"
+	"\x0eGenerateExpect\x12\x1e.storage.GenerateExpectRequest\x1a\x1f.storage.GenerateExpectResponse2\xa7\x03\n" +
+	"\x12EnvironmentService\x12T\n" +
+	"\x11CreateEnvironment\x12!.storage.CreateEnvironmentRequest\x1a\x1c.storage.EnvironmentResponse\x12N\n" +
+	"\x0eGetEnvironment\x12\x1e.storage.GetEnvironmentRequest\x1a\x1c.storage.EnvironmentResponse\x12T\n" +
+	"\x11UpdateEnvironment\x12!.storage.UpdateEnvironmentRequest\x1a\x1c.storage.EnvironmentResponse\x12O\n" +
+	"\x11DeleteEnvironment\x12!.storage.DeleteEnvironmentRequest\x1a\x17.storage.DeleteResponse\x12D\n" +
+	"\x10ListEnvironments\x12\x0e.storage.Empty\x1a .storage.EnvironmentListResponse2\x9a\x03\n" +
+	"\vMockService\x12B\n" +
+	"\tStartMock\x12\x19.storage.StartMockRequest\x1a\x1a.storage.StartMockResponse\x12?\n" +
+	"\bStopMock\x12\x18.storage.StopMockRequest\x1a\x19.storage.StopMockResponse\x12Q\n" +
+	"\x0eListMockRoutes\x12\x1e.storage.ListMockRoutesRequest\x1a\x1f.storage.ListMockRoutesResponse\x12T\n" +
+	"\x0fSetMockOverride\x12\x1f.storage.SetMockOverrideRequest\x1a .storage.SetMockOverrideResponse\x12]\n" +
+	"\x12DeleteMockOverride\x12\".storage.DeleteMockOverrideRequest\x1a#.storage.DeleteMockOverrideResponseB\vZ\t./storageb\x06proto3"
 
 var (
 	file_Storage_proto_rawDescOnce sync.Once
@@ -4966,7 +7917,7 @@ func file_Storage_proto_rawDescGZIP() []byte {
 }
 
 var file_Storage_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_Storage_proto_msgTypes = make([]protoimpl.MessageInfo, 73)
+var file_Storage_proto_msgTypes = make([]protoimpl.MessageInfo, 123)
 var file_Storage_proto_goTypes = []any{
 	(NullValue)(0),                     // 0: storage.NullValue
 	(StatusCode)(0),                    // 1: storage.StatusCode
@@ -4982,72 +7933,122 @@ var file_Storage_proto_goTypes = []any{
 	(*TaskMeta)(nil),                   // 11: storage.TaskMeta
 	(*TaskAPISpec)(nil),                // 12: storage.TaskAPISpec
 	(*TaskSyncSpec)(nil),               // 13: storage.TaskSyncSpec
-	(*SyncSource)(nil),                 // 14: storage.SyncSource
-	(*SyncDestination)(nil),            // 15: storage.SyncDestination
-	(*ApifoxConfig)(nil),               // 16: storage.ApifoxConfig
-	(*MongoConfig)(nil),                // 17: storage.MongoConfig
-	(*Strategy)(nil),                   // 18: storage.Strategy
-	(*TestData)(nil),                   // 19: storage.TestData
-	(*TestReport)(nil),                 // 20: storage.TestReport
-	(*SceneConfig)(nil),                // 21: storage.SceneConfig
-	(*InterfaceInfo)(nil),              // 22: storage.InterfaceInfo
-	(*Header)(nil),                     // 23: storage.Header
-	(*Parameter)(nil),                  // 24: storage.Parameter
-	(*Scenarios)(nil),                  // 25: storage.Scenarios
-	(*CreateTaskRequest)(nil),          // 26: storage.CreateTaskRequest
-	(*GetTaskRequest)(nil),             // 27: storage.GetTaskRequest
-	(*UpdateTaskRequest)(nil),          // 28: storage.UpdateTaskRequest
-	(*DeleteTaskRequest)(nil),          // 29: storage.DeleteTaskRequest
-	(*GetTestDataRequest)(nil),         // 30: storage.GetTestDataRequest
-	(*UpdateTestDataRequest)(nil),      // 31: storage.UpdateTestDataRequest
-	(*DeleteTestDataRequest)(nil),      // 32: storage.DeleteTestDataRequest
-	(*GetSceneConfigRequest)(nil),      // 33: storage.GetSceneConfigRequest
-	(*UpdateSceneConfigRequest)(nil),   // 34: storage.UpdateSceneConfigRequest
-	(*DeleteSceneConfigRequest)(nil),   // 35: storage.DeleteSceneConfigRequest
-	(*ListSceneConfigsRequest)(nil),    // 36: storage.ListSceneConfigsRequest
-	(*GetInterfaceListResponse)(nil),   // 37: storage.GetInterfaceListResponse
-	(*GetInterfaceRequest)(nil),        // 38: storage.GetInterfaceRequest
-	(*GetInterfaceResponse)(nil),       // 39: storage.GetInterfaceResponse
-	(*DeleteInterfaceRequest)(nil),     // 40: storage.DeleteInterfaceRequest
-	(*SyncInterfaceRequest)(nil),       // 41: storage.SyncInterfaceRequest
-	(*SyncInterfaceResponse)(nil),      // 42: storage.SyncInterfaceResponse
-	(*TaskResponse)(nil),               // 43: storage.TaskResponse
-	(*TaskListResponse)(nil),           // 44: storage.TaskListResponse
-	(*DeleteResponse)(nil),             // 45: storage.DeleteResponse
-	(*ExecuteTaskRequest)(nil),         // 46: storage.ExecuteTaskRequest
-	(*ExecuteTaskResponse)(nil),        // 47: storage.ExecuteTaskResponse
-	(*GetTestReportRequest)(nil),       // 48: storage.GetTestReportRequest
-	(*TestReportResponse)(nil),         // 49: storage.TestReportResponse
-	(*GetTaskReportListRequest)(nil),   // 50: storage.GetTaskReportListRequest
-	(*ReportListResponse)(nil),         // 51: storage.ReportListResponse
-	(*CreateTestDataRequest)(nil),      // 52: storage.CreateTestDataRequest
-	(*TestDataResponse)(nil),           // 53: storage.TestDataResponse
-	(*TestDataListResponse)(nil),       // 54: storage.TestDataListResponse
-	(*CreateSceneConfigRequest)(nil),   // 55: storage.CreateSceneConfigRequest
-	(*RelatedApi)(nil),                 // 56: storage.RelatedApi
-	(*TimeoutSetting)(nil),             // 57: storage.TimeoutSetting
-	(*RetrySetting)(nil),               // 58: storage.RetrySetting
-	(*SceneConfigResponse)(nil),        // 59: storage.SceneConfigResponse
-	(*SceneConfigListResponse)(nil),    // 60: storage.SceneConfigListResponse
-	(*GenerateDependencyRequest)(nil),  // 61: storage.GenerateDependencyRequest
-	(*GenerateDependencyResponse)(nil), // 62: storage.GenerateDependencyResponse
-	(*GenerateExtractorRequest)(nil),   // 63: storage.GenerateExtractorRequest
-	(*GenerateExtractorResponse)(nil),  // 64: storage.GenerateExtractorResponse
-	(*GenerateExpectRequest)(nil),      // 65: storage.GenerateExpectRequest
-	(*GenerateExpectResponse)(nil),     // 66: storage.GenerateExpectResponse
-	(*Dependency)(nil),                 // 67: storage.Dependency
-	(*Expect)(nil),                     // 68: storage.Expect
-	(*Extractor)(nil),                  // 69: storage.Extractor
-	(*ExtractConfig)(nil),              // 70: storage.extractConfig
-	nil,                                // 71: storage.Struct.FieldsEntry
-	nil,                                // 72: storage.TestData.MetadataEntry
-	nil,                                // 73: storage.UpdateTestDataRequest.MetadataEntry
-	nil,                                // 74: storage.SyncInterfaceRequest.SyncConfigEntry
-	(*TaskListResponse_TaskItem)(nil),  // 75: storage.TaskListResponse.TaskItem
-	nil,                                // 76: storage.CreateTestDataRequest.MetadataEntry
+	(*TaskWorkflowSpec)(nil),           // 14: storage.TaskWorkflowSpec
+	(*SyncSource)(nil),                 // 15: storage.SyncSource
+	(*SyncDestination)(nil),            // 16: storage.SyncDestination
+	(*ApifoxConfig)(nil),               // 17: storage.ApifoxConfig
+	(*MongoConfig)(nil),                // 18: storage.MongoConfig
+	(*Strategy)(nil),                   // 19: storage.Strategy
+	(*TestData)(nil),                   // 20: storage.TestData
+	(*TestReport)(nil),                 // 21: storage.TestReport
+	(*SceneConfig)(nil),                // 22: storage.SceneConfig
+	(*InterfaceInfo)(nil),              // 23: storage.InterfaceInfo
+	(*Header)(nil),                     // 24: storage.Header
+	(*Parameter)(nil),                  // 25: storage.Parameter
+	(*Scenarios)(nil),                  // 26: storage.Scenarios
+	(*CreateTaskRequest)(nil),          // 27: storage.CreateTaskRequest
+	(*GetTaskRequest)(nil),             // 28: storage.GetTaskRequest
+	(*UpdateTaskRequest)(nil),          // 29: storage.UpdateTaskRequest
+	(*DeleteTaskRequest)(nil),          // 30: storage.DeleteTaskRequest
+	(*GetTestDataRequest)(nil),         // 31: storage.GetTestDataRequest
+	(*UpdateTestDataRequest)(nil),      // 32: storage.UpdateTestDataRequest
+	(*DeleteTestDataRequest)(nil),      // 33: storage.DeleteTestDataRequest
+	(*GetSceneConfigRequest)(nil),      // 34: storage.GetSceneConfigRequest
+	(*UpdateSceneConfigRequest)(nil),   // 35: storage.UpdateSceneConfigRequest
+	(*DeleteSceneConfigRequest)(nil),   // 36: storage.DeleteSceneConfigRequest
+	(*ListSceneConfigsRequest)(nil),    // 37: storage.ListSceneConfigsRequest
+	(*GetInterfaceListResponse)(nil),   // 38: storage.GetInterfaceListResponse
+	(*GetInterfaceRequest)(nil),        // 39: storage.GetInterfaceRequest
+	(*GetInterfaceResponse)(nil),       // 40: storage.GetInterfaceResponse
+	(*DeleteInterfaceRequest)(nil),     // 41: storage.DeleteInterfaceRequest
+	(*SyncInterfaceRequest)(nil),       // 42: storage.SyncInterfaceRequest
+	(*SyncInterfaceResponse)(nil),      // 43: storage.SyncInterfaceResponse
+	(*TaskResponse)(nil),               // 44: storage.TaskResponse
+	(*TaskListResponse)(nil),           // 45: storage.TaskListResponse
+	(*DeleteResponse)(nil),             // 46: storage.DeleteResponse
+	(*ExecuteTaskRequest)(nil),         // 47: storage.ExecuteTaskRequest
+	(*ExecuteTaskResponse)(nil),        // 48: storage.ExecuteTaskResponse
+	(*CancelExecutionRequest)(nil),     // 49: storage.CancelExecutionRequest
+	(*CancelExecutionResponse)(nil),    // 50: storage.CancelExecutionResponse
+	(*ResumeExecutionRequest)(nil),     // 51: storage.ResumeExecutionRequest
+	(*ResumeExecutionResponse)(nil),    // 52: storage.ResumeExecutionResponse
+	(*QueuedExecution)(nil),            // 53: storage.QueuedExecution
+	(*ListExecutionQueueRequest)(nil),  // 54: storage.ListExecutionQueueRequest
+	(*ListExecutionQueueResponse)(nil), // 55: storage.ListExecutionQueueResponse
+	(*ReorderExecutionRequest)(nil),    // 56: storage.ReorderExecutionRequest
+	(*ReorderExecutionResponse)(nil),   // 57: storage.ReorderExecutionResponse
+	(*ExecutionRecord)(nil),            // 58: storage.ExecutionRecord
+	(*GetExecutionRequest)(nil),        // 59: storage.GetExecutionRequest
+	(*GetExecutionResponse)(nil),       // 60: storage.GetExecutionResponse
+	(*ListExecutionsRequest)(nil),      // 61: storage.ListExecutionsRequest
+	(*ListExecutionsResponse)(nil),     // 62: storage.ListExecutionsResponse
+	(*GetExecutionHarRequest)(nil),     // 63: storage.GetExecutionHarRequest
+	(*GetExecutionHarResponse)(nil),    // 64: storage.GetExecutionHarResponse
+	(*ExportCurlRequest)(nil),          // 65: storage.ExportCurlRequest
+	(*ExportCurlResponse)(nil),         // 66: storage.ExportCurlResponse
+	(*WatchExecutionRequest)(nil),      // 67: storage.WatchExecutionRequest
+	(*WatchExecutionResponse)(nil),     // 68: storage.WatchExecutionResponse
+	(*GetTestReportRequest)(nil),       // 69: storage.GetTestReportRequest
+	(*TestReportResponse)(nil),         // 70: storage.TestReportResponse
+	(*GetTaskReportListRequest)(nil),   // 71: storage.GetTaskReportListRequest
+	(*ReportListResponse)(nil),         // 72: storage.ReportListResponse
+	(*CreateTestDataRequest)(nil),      // 73: storage.CreateTestDataRequest
+	(*TestDataResponse)(nil),           // 74: storage.TestDataResponse
+	(*TestDataListResponse)(nil),       // 75: storage.TestDataListResponse
+	(*CreateSceneConfigRequest)(nil),   // 76: storage.CreateSceneConfigRequest
+	(*RelatedApi)(nil),                 // 77: storage.RelatedApi
+	(*TimeoutSetting)(nil),             // 78: storage.TimeoutSetting
+	(*RetrySetting)(nil),               // 79: storage.RetrySetting
+	(*SceneConfigResponse)(nil),        // 80: storage.SceneConfigResponse
+	(*SceneConfigListResponse)(nil),    // 81: storage.SceneConfigListResponse
+	(*GenerateDependencyRequest)(nil),  // 82: storage.GenerateDependencyRequest
+	(*GenerateDependencyResponse)(nil), // 83: storage.GenerateDependencyResponse
+	(*GenerateExtractorRequest)(nil),   // 84: storage.GenerateExtractorRequest
+	(*GenerateExtractorResponse)(nil),  // 85: storage.GenerateExtractorResponse
+	(*GenerateExpectRequest)(nil),      // 86: storage.GenerateExpectRequest
+	(*GenerateExpectResponse)(nil),     // 87: storage.GenerateExpectResponse
+	(*Dependency)(nil),                 // 88: storage.Dependency
+	(*Expect)(nil),                     // 89: storage.Expect
+	(*Extractor)(nil),                  // 90: storage.Extractor
+	(*ExtractConfig)(nil),              // 91: storage.extractConfig
+	(*EnvironmentTLS)(nil),             // 92: storage.EnvironmentTLS
+	(*Environment)(nil),                // 93: storage.Environment
+	(*CreateEnvironmentRequest)(nil),   // 94: storage.CreateEnvironmentRequest
+	(*GetEnvironmentRequest)(nil),      // 95: storage.GetEnvironmentRequest
+	(*UpdateEnvironmentRequest)(nil),   // 96: storage.UpdateEnvironmentRequest
+	(*DeleteEnvironmentRequest)(nil),   // 97: storage.DeleteEnvironmentRequest
+	(*EnvironmentResponse)(nil),        // 98: storage.EnvironmentResponse
+	(*EnvironmentListResponse)(nil),    // 99: storage.EnvironmentListResponse
+	(*MockOverride)(nil),               // 100: storage.MockOverride
+	(*MockRoute)(nil),                  // 101: storage.MockRoute
+	(*StartMockRequest)(nil),           // 102: storage.StartMockRequest
+	(*StartMockResponse)(nil),          // 103: storage.StartMockResponse
+	(*StopMockRequest)(nil),            // 104: storage.StopMockRequest
+	(*StopMockResponse)(nil),           // 105: storage.StopMockResponse
+	(*ListMockRoutesRequest)(nil),      // 106: storage.ListMockRoutesRequest
+	(*ListMockRoutesResponse)(nil),     // 107: storage.ListMockRoutesResponse
+	(*SetMockOverrideRequest)(nil),     // 108: storage.SetMockOverrideRequest
+	(*SetMockOverrideResponse)(nil),    // 109: storage.SetMockOverrideResponse
+	(*DeleteMockOverrideRequest)(nil),  // 110: storage.DeleteMockOverrideRequest
+	(*DeleteMockOverrideResponse)(nil), // 111: storage.DeleteMockOverrideResponse
+	nil,                                // 112: storage.Struct.FieldsEntry
+	nil,                                // 113: storage.TestData.MetadataEntry
+	nil,                                // 114: storage.UpdateTestDataRequest.MetadataEntry
+	nil,                                // 115: storage.SyncInterfaceRequest.SyncConfigEntry
+	(*TaskListResponse_TaskItem)(nil),  // 116: storage.TaskListResponse.TaskItem
+	nil,                                // 117: storage.ListExecutionQueueResponse.RunningByTypeEntry
+	nil,                                // 118: storage.ListExecutionQueueResponse.QueuedByTypeEntry
+	nil,                                // 119: storage.CreateTestDataRequest.MetadataEntry
+	nil,                                // 120: storage.Environment.ServiceUrlsEntry
+	nil,                                // 121: storage.Environment.VariablesEntry
+	nil,                                // 122: storage.CreateEnvironmentRequest.ServiceUrlsEntry
+	nil,                                // 123: storage.CreateEnvironmentRequest.VariablesEntry
+	nil,                                // 124: storage.UpdateEnvironmentRequest.ServiceUrlsEntry
+	nil,                                // 125: storage.UpdateEnvironmentRequest.VariablesEntry
+	nil,                                // 126: storage.MockOverride.HeadersEntry
 }
 var file_Storage_proto_depIdxs = []int32{
-	71,  // 0: storage.Struct.fields:type_name -> storage.Struct.FieldsEntry
+	112, // 0: storage.Struct.fields:type_name -> storage.Struct.FieldsEntry
 	0,   // 1: storage.Value.null_value:type_name -> storage.NullValue
 	6,   // 2: storage.Value.list_value:type_name -> storage.ListValue
 	4,   // 3: storage.Value.struct_value:type_name -> storage.Struct
@@ -5055,130 +8056,219 @@ var file_Storage_proto_depIdxs = []int32{
 	11,  // 5: storage.Task.meta:type_name -> storage.TaskMeta
 	12,  // 6: storage.Task.api_spec:type_name -> storage.TaskAPISpec
 	13,  // 7: storage.Task.sync_spec:type_name -> storage.TaskSyncSpec
-	25,  // 8: storage.TaskAPISpec.scenarios:type_name -> storage.Scenarios
-	18,  // 9: storage.TaskAPISpec.strategy:type_name -> storage.Strategy
-	14,  // 10: storage.TaskSyncSpec.source:type_name -> storage.SyncSource
-	15,  // 11: storage.TaskSyncSpec.destination:type_name -> storage.SyncDestination
-	18,  // 12: storage.TaskSyncSpec.strategy:type_name -> storage.Strategy
-	16,  // 13: storage.SyncSource.apifox:type_name -> storage.ApifoxConfig
-	17,  // 14: storage.SyncDestination.mongoConfig:type_name -> storage.MongoConfig
-	72,  // 15: storage.TestData.metadata:type_name -> storage.TestData.MetadataEntry
-	7,   // 16: storage.TestReport.generate_time:type_name -> storage.Timestamp
-	58,  // 17: storage.SceneConfig.retry:type_name -> storage.RetrySetting
-	57,  // 18: storage.SceneConfig.timeout:type_name -> storage.TimeoutSetting
-	56,  // 19: storage.SceneConfig.related_api:type_name -> storage.RelatedApi
-	23,  // 20: storage.InterfaceInfo.headers:type_name -> storage.Header
-	24,  // 21: storage.InterfaceInfo.parameters:type_name -> storage.Parameter
-	2,   // 22: storage.CreateTaskRequest.type:type_name -> storage.TaskType
-	12,  // 23: storage.CreateTaskRequest.api_spec:type_name -> storage.TaskAPISpec
-	13,  // 24: storage.CreateTaskRequest.sync_spec:type_name -> storage.TaskSyncSpec
-	12,  // 25: storage.UpdateTaskRequest.api_spec:type_name -> storage.TaskAPISpec
-	13,  // 26: storage.UpdateTaskRequest.sync_spec:type_name -> storage.TaskSyncSpec
-	73,  // 27: storage.UpdateTestDataRequest.metadata:type_name -> storage.UpdateTestDataRequest.MetadataEntry
-	58,  // 28: storage.UpdateSceneConfigRequest.retry:type_name -> storage.RetrySetting
-	57,  // 29: storage.UpdateSceneConfigRequest.timeout:type_name -> storage.TimeoutSetting
-	56,  // 30: storage.UpdateSceneConfigRequest.related_api:type_name -> storage.RelatedApi
-	9,   // 31: storage.GetInterfaceListResponse.header:type_name -> storage.ResponseHeader
-	22,  // 32: storage.GetInterfaceListResponse.interfaces:type_name -> storage.InterfaceInfo
-	9,   // 33: storage.GetInterfaceResponse.header:type_name -> storage.ResponseHeader
-	22,  // 34: storage.GetInterfaceResponse.detail:type_name -> storage.InterfaceInfo
-	74,  // 35: storage.SyncInterfaceRequest.sync_config:type_name -> storage.SyncInterfaceRequest.SyncConfigEntry
-	9,   // 36: storage.SyncInterfaceResponse.header:type_name -> storage.ResponseHeader
-	7,   // 37: storage.SyncInterfaceResponse.sync_time:type_name -> storage.Timestamp
-	9,   // 38: storage.TaskResponse.header:type_name -> storage.ResponseHeader
-	11,  // 39: storage.TaskResponse.meta:type_name -> storage.TaskMeta
-	12,  // 40: storage.TaskResponse.api_spec:type_name -> storage.TaskAPISpec
-	13,  // 41: storage.TaskResponse.sync_spec:type_name -> storage.TaskSyncSpec
-	9,   // 42: storage.TaskListResponse.header:type_name -> storage.ResponseHeader
-	75,  // 43: storage.TaskListResponse.data:type_name -> storage.TaskListResponse.TaskItem
-	9,   // 44: storage.DeleteResponse.header:type_name -> storage.ResponseHeader
-	9,   // 45: storage.ExecuteTaskResponse.header:type_name -> storage.ResponseHeader
-	7,   // 46: storage.ExecuteTaskResponse.start_time:type_name -> storage.Timestamp
-	9,   // 47: storage.TestReportResponse.header:type_name -> storage.ResponseHeader
-	20,  // 48: storage.TestReportResponse.report:type_name -> storage.TestReport
-	9,   // 49: storage.ReportListResponse.header:type_name -> storage.ResponseHeader
-	20,  // 50: storage.ReportListResponse.data:type_name -> storage.TestReport
-	76,  // 51: storage.CreateTestDataRequest.metadata:type_name -> storage.CreateTestDataRequest.MetadataEntry
-	9,   // 52: storage.TestDataResponse.header:type_name -> storage.ResponseHeader
-	19,  // 53: storage.TestDataResponse.data:type_name -> storage.TestData
-	9,   // 54: storage.TestDataListResponse.header:type_name -> storage.ResponseHeader
-	19,  // 55: storage.TestDataListResponse.data:type_name -> storage.TestData
-	58,  // 56: storage.CreateSceneConfigRequest.retry:type_name -> storage.RetrySetting
-	57,  // 57: storage.CreateSceneConfigRequest.timeout:type_name -> storage.TimeoutSetting
-	56,  // 58: storage.CreateSceneConfigRequest.related_api:type_name -> storage.RelatedApi
-	9,   // 59: storage.SceneConfigResponse.header:type_name -> storage.ResponseHeader
-	21,  // 60: storage.SceneConfigResponse.data:type_name -> storage.SceneConfig
-	9,   // 61: storage.SceneConfigListResponse.header:type_name -> storage.ResponseHeader
-	21,  // 62: storage.SceneConfigListResponse.data:type_name -> storage.SceneConfig
-	9,   // 63: storage.GenerateDependencyResponse.header:type_name -> storage.ResponseHeader
-	67,  // 64: storage.GenerateDependencyResponse.dependency:type_name -> storage.Dependency
-	9,   // 65: storage.GenerateExtractorResponse.header:type_name -> storage.ResponseHeader
-	69,  // 66: storage.GenerateExtractorResponse.extractor:type_name -> storage.Extractor
-	9,   // 67: storage.GenerateExpectResponse.header:type_name -> storage.ResponseHeader
-	68,  // 68: storage.GenerateExpectResponse.expect:type_name -> storage.Expect
-	67,  // 69: storage.Expect.value:type_name -> storage.Dependency
-	70,  // 70: storage.Extractor.extractors:type_name -> storage.extractConfig
-	5,   // 71: storage.Struct.FieldsEntry.value:type_name -> storage.Value
-	11,  // 72: storage.TaskListResponse.TaskItem.meta:type_name -> storage.TaskMeta
-	12,  // 73: storage.TaskListResponse.TaskItem.api_spec:type_name -> storage.TaskAPISpec
-	13,  // 74: storage.TaskListResponse.TaskItem.sync_spec:type_name -> storage.TaskSyncSpec
-	26,  // 75: storage.TaskConfigService.CreateTask:input_type -> storage.CreateTaskRequest
-	27,  // 76: storage.TaskConfigService.GetTask:input_type -> storage.GetTaskRequest
-	28,  // 77: storage.TaskConfigService.UpdateTask:input_type -> storage.UpdateTaskRequest
-	29,  // 78: storage.TaskConfigService.DeleteTask:input_type -> storage.DeleteTaskRequest
-	8,   // 79: storage.TaskConfigService.ListTasks:input_type -> storage.Empty
-	48,  // 80: storage.ReportService.GetReport:input_type -> storage.GetTestReportRequest
-	50,  // 81: storage.ReportService.ListReports:input_type -> storage.GetTaskReportListRequest
-	48,  // 82: storage.ReportService.DeleteReport:input_type -> storage.GetTestReportRequest
-	52,  // 83: storage.TestDataService.CreateTestData:input_type -> storage.CreateTestDataRequest
-	30,  // 84: storage.TestDataService.GetTestData:input_type -> storage.GetTestDataRequest
-	31,  // 85: storage.TestDataService.UpdateTestData:input_type -> storage.UpdateTestDataRequest
-	32,  // 86: storage.TestDataService.DeleteTestData:input_type -> storage.DeleteTestDataRequest
-	8,   // 87: storage.TestDataService.ListTestData:input_type -> storage.Empty
-	55,  // 88: storage.SceneConfigService.CreateSceneConfig:input_type -> storage.CreateSceneConfigRequest
-	33,  // 89: storage.SceneConfigService.GetSceneConfig:input_type -> storage.GetSceneConfigRequest
-	34,  // 90: storage.SceneConfigService.UpdateSceneConfig:input_type -> storage.UpdateSceneConfigRequest
-	35,  // 91: storage.SceneConfigService.DeleteSceneConfig:input_type -> storage.DeleteSceneConfigRequest
-	36,  // 92: storage.SceneConfigService.ListSceneConfigs:input_type -> storage.ListSceneConfigsRequest
-	46,  // 93: storage.ExecuteService.ExecuteTask:input_type -> storage.ExecuteTaskRequest
-	8,   // 94: storage.InterfaceService.GetInterfaceList:input_type -> storage.Empty
-	38,  // 95: storage.InterfaceService.GetInterfaceDetail:input_type -> storage.GetInterfaceRequest
-	40,  // 96: storage.InterfaceService.DeleteInterface:input_type -> storage.DeleteInterfaceRequest
-	41,  // 97: storage.InterfaceService.SyncInterface:input_type -> storage.SyncInterfaceRequest
-	61,  // 98: storage.GenerateService.GenerateDependency:input_type -> storage.GenerateDependencyRequest
-	63,  // 99: storage.GenerateService.GenerateExtractor:input_type -> storage.GenerateExtractorRequest
-	65,  // 100: storage.GenerateService.GenerateExpect:input_type -> storage.GenerateExpectRequest
-	43,  // 101: storage.TaskConfigService.CreateTask:output_type -> storage.TaskResponse
-	43,  // 102: storage.TaskConfigService.GetTask:output_type -> storage.TaskResponse
-	43,  // 103: storage.TaskConfigService.UpdateTask:output_type -> storage.TaskResponse
-	45,  // 104: storage.TaskConfigService.DeleteTask:output_type -> storage.DeleteResponse
-	44,  // 105: storage.TaskConfigService.ListTasks:output_type -> storage.TaskListResponse
-	49,  // 106: storage.ReportService.GetReport:output_type -> storage.TestReportResponse
-	51,  // 107: storage.ReportService.ListReports:output_type -> storage.ReportListResponse
-	45,  // 108: storage.ReportService.DeleteReport:output_type -> storage.DeleteResponse
-	53,  // 109: storage.TestDataService.CreateTestData:output_type -> storage.TestDataResponse
-	53,  // 110: storage.TestDataService.GetTestData:output_type -> storage.TestDataResponse
-	53,  // 111: storage.TestDataService.UpdateTestData:output_type -> storage.TestDataResponse
-	45,  // 112: storage.TestDataService.DeleteTestData:output_type -> storage.DeleteResponse
-	54,  // 113: storage.TestDataService.ListTestData:output_type -> storage.TestDataListResponse
-	59,  // 114: storage.SceneConfigService.CreateSceneConfig:output_type -> storage.SceneConfigResponse
-	59,  // 115: storage.SceneConfigService.GetSceneConfig:output_type -> storage.SceneConfigResponse
-	59,  // 116: storage.SceneConfigService.UpdateSceneConfig:output_type -> storage.SceneConfigResponse
-	45,  // 117: storage.SceneConfigService.DeleteSceneConfig:output_type -> storage.DeleteResponse
-	60,  // 118: storage.SceneConfigService.ListSceneConfigs:output_type -> storage.SceneConfigListResponse
-	47,  // 119: storage.ExecuteService.ExecuteTask:output_type -> storage.ExecuteTaskResponse
-	37,  // 120: storage.InterfaceService.GetInterfaceList:output_type -> storage.GetInterfaceListResponse
-	39,  // 121: storage.InterfaceService.GetInterfaceDetail:output_type -> storage.GetInterfaceResponse
-	45,  // 122: storage.InterfaceService.DeleteInterface:output_type -> storage.DeleteResponse
-	42,  // 123: storage.InterfaceService.SyncInterface:output_type -> storage.SyncInterfaceResponse
-	62,  // 124: storage.GenerateService.GenerateDependency:output_type -> storage.GenerateDependencyResponse
-	64,  // 125: storage.GenerateService.GenerateExtractor:output_type -> storage.GenerateExtractorResponse
-	66,  // 126: storage.GenerateService.GenerateExpect:output_type -> storage.GenerateExpectResponse
-	101, // [101:127] is the sub-list for method output_type
-	75,  // [75:101] is the sub-list for method input_type
-	75,  // [75:75] is the sub-list for extension type_name
-	75,  // [75:75] is the sub-list for extension extendee
-	0,   // [0:75] is the sub-list for field type_name
+	26,  // 8: storage.TaskAPISpec.scenarios:type_name -> storage.Scenarios
+	19,  // 9: storage.TaskAPISpec.strategy:type_name -> storage.Strategy
+	15,  // 10: storage.TaskSyncSpec.source:type_name -> storage.SyncSource
+	16,  // 11: storage.TaskSyncSpec.destination:type_name -> storage.SyncDestination
+	19,  // 12: storage.TaskSyncSpec.strategy:type_name -> storage.Strategy
+	19,  // 13: storage.TaskWorkflowSpec.strategy:type_name -> storage.Strategy
+	17,  // 14: storage.SyncSource.apifox:type_name -> storage.ApifoxConfig
+	18,  // 15: storage.SyncDestination.mongoConfig:type_name -> storage.MongoConfig
+	113, // 16: storage.TestData.metadata:type_name -> storage.TestData.MetadataEntry
+	7,   // 17: storage.TestReport.generate_time:type_name -> storage.Timestamp
+	79,  // 18: storage.SceneConfig.retry:type_name -> storage.RetrySetting
+	78,  // 19: storage.SceneConfig.timeout:type_name -> storage.TimeoutSetting
+	77,  // 20: storage.SceneConfig.related_api:type_name -> storage.RelatedApi
+	24,  // 21: storage.InterfaceInfo.headers:type_name -> storage.Header
+	25,  // 22: storage.InterfaceInfo.parameters:type_name -> storage.Parameter
+	2,   // 23: storage.CreateTaskRequest.type:type_name -> storage.TaskType
+	12,  // 24: storage.CreateTaskRequest.api_spec:type_name -> storage.TaskAPISpec
+	13,  // 25: storage.CreateTaskRequest.sync_spec:type_name -> storage.TaskSyncSpec
+	14,  // 26: storage.CreateTaskRequest.workflow_spec:type_name -> storage.TaskWorkflowSpec
+	12,  // 27: storage.UpdateTaskRequest.api_spec:type_name -> storage.TaskAPISpec
+	13,  // 28: storage.UpdateTaskRequest.sync_spec:type_name -> storage.TaskSyncSpec
+	14,  // 29: storage.UpdateTaskRequest.workflow_spec:type_name -> storage.TaskWorkflowSpec
+	114, // 30: storage.UpdateTestDataRequest.metadata:type_name -> storage.UpdateTestDataRequest.MetadataEntry
+	79,  // 31: storage.UpdateSceneConfigRequest.retry:type_name -> storage.RetrySetting
+	78,  // 32: storage.UpdateSceneConfigRequest.timeout:type_name -> storage.TimeoutSetting
+	77,  // 33: storage.UpdateSceneConfigRequest.related_api:type_name -> storage.RelatedApi
+	9,   // 34: storage.GetInterfaceListResponse.header:type_name -> storage.ResponseHeader
+	23,  // 35: storage.GetInterfaceListResponse.interfaces:type_name -> storage.InterfaceInfo
+	9,   // 36: storage.GetInterfaceResponse.header:type_name -> storage.ResponseHeader
+	23,  // 37: storage.GetInterfaceResponse.detail:type_name -> storage.InterfaceInfo
+	115, // 38: storage.SyncInterfaceRequest.sync_config:type_name -> storage.SyncInterfaceRequest.SyncConfigEntry
+	9,   // 39: storage.SyncInterfaceResponse.header:type_name -> storage.ResponseHeader
+	7,   // 40: storage.SyncInterfaceResponse.sync_time:type_name -> storage.Timestamp
+	9,   // 41: storage.TaskResponse.header:type_name -> storage.ResponseHeader
+	11,  // 42: storage.TaskResponse.meta:type_name -> storage.TaskMeta
+	12,  // 43: storage.TaskResponse.api_spec:type_name -> storage.TaskAPISpec
+	13,  // 44: storage.TaskResponse.sync_spec:type_name -> storage.TaskSyncSpec
+	14,  // 45: storage.TaskResponse.workflow_spec:type_name -> storage.TaskWorkflowSpec
+	9,   // 46: storage.TaskListResponse.header:type_name -> storage.ResponseHeader
+	116, // 47: storage.TaskListResponse.data:type_name -> storage.TaskListResponse.TaskItem
+	9,   // 48: storage.DeleteResponse.header:type_name -> storage.ResponseHeader
+	9,   // 49: storage.ExecuteTaskResponse.header:type_name -> storage.ResponseHeader
+	7,   // 50: storage.ExecuteTaskResponse.start_time:type_name -> storage.Timestamp
+	9,   // 51: storage.CancelExecutionResponse.header:type_name -> storage.ResponseHeader
+	9,   // 52: storage.ResumeExecutionResponse.header:type_name -> storage.ResponseHeader
+	7,   // 53: storage.QueuedExecution.enqueue_time:type_name -> storage.Timestamp
+	9,   // 54: storage.ListExecutionQueueResponse.header:type_name -> storage.ResponseHeader
+	53,  // 55: storage.ListExecutionQueueResponse.items:type_name -> storage.QueuedExecution
+	117, // 56: storage.ListExecutionQueueResponse.running_by_type:type_name -> storage.ListExecutionQueueResponse.RunningByTypeEntry
+	118, // 57: storage.ListExecutionQueueResponse.queued_by_type:type_name -> storage.ListExecutionQueueResponse.QueuedByTypeEntry
+	9,   // 58: storage.ReorderExecutionResponse.header:type_name -> storage.ResponseHeader
+	7,   // 59: storage.ExecutionRecord.create_time:type_name -> storage.Timestamp
+	7,   // 60: storage.ExecutionRecord.start_time:type_name -> storage.Timestamp
+	7,   // 61: storage.ExecutionRecord.end_time:type_name -> storage.Timestamp
+	4,   // 62: storage.ExecutionRecord.results:type_name -> storage.Struct
+	4,   // 63: storage.ExecutionRecord.summary:type_name -> storage.Struct
+	9,   // 64: storage.GetExecutionResponse.header:type_name -> storage.ResponseHeader
+	58,  // 65: storage.GetExecutionResponse.execution:type_name -> storage.ExecutionRecord
+	7,   // 66: storage.ListExecutionsRequest.start_time:type_name -> storage.Timestamp
+	7,   // 67: storage.ListExecutionsRequest.end_time:type_name -> storage.Timestamp
+	9,   // 68: storage.ListExecutionsResponse.header:type_name -> storage.ResponseHeader
+	58,  // 69: storage.ListExecutionsResponse.items:type_name -> storage.ExecutionRecord
+	9,   // 70: storage.GetExecutionHarResponse.header:type_name -> storage.ResponseHeader
+	9,   // 71: storage.ExportCurlResponse.header:type_name -> storage.ResponseHeader
+	9,   // 72: storage.WatchExecutionResponse.header:type_name -> storage.ResponseHeader
+	4,   // 73: storage.WatchExecutionResponse.result:type_name -> storage.Struct
+	7,   // 74: storage.WatchExecutionResponse.timestamp:type_name -> storage.Timestamp
+	9,   // 75: storage.TestReportResponse.header:type_name -> storage.ResponseHeader
+	21,  // 76: storage.TestReportResponse.report:type_name -> storage.TestReport
+	9,   // 77: storage.ReportListResponse.header:type_name -> storage.ResponseHeader
+	21,  // 78: storage.ReportListResponse.data:type_name -> storage.TestReport
+	119, // 79: storage.CreateTestDataRequest.metadata:type_name -> storage.CreateTestDataRequest.MetadataEntry
+	9,   // 80: storage.TestDataResponse.header:type_name -> storage.ResponseHeader
+	20,  // 81: storage.TestDataResponse.data:type_name -> storage.TestData
+	9,   // 82: storage.TestDataListResponse.header:type_name -> storage.ResponseHeader
+	20,  // 83: storage.TestDataListResponse.data:type_name -> storage.TestData
+	79,  // 84: storage.CreateSceneConfigRequest.retry:type_name -> storage.RetrySetting
+	78,  // 85: storage.CreateSceneConfigRequest.timeout:type_name -> storage.TimeoutSetting
+	77,  // 86: storage.CreateSceneConfigRequest.related_api:type_name -> storage.RelatedApi
+	9,   // 87: storage.SceneConfigResponse.header:type_name -> storage.ResponseHeader
+	22,  // 88: storage.SceneConfigResponse.data:type_name -> storage.SceneConfig
+	9,   // 89: storage.SceneConfigListResponse.header:type_name -> storage.ResponseHeader
+	22,  // 90: storage.SceneConfigListResponse.data:type_name -> storage.SceneConfig
+	9,   // 91: storage.GenerateDependencyResponse.header:type_name -> storage.ResponseHeader
+	88,  // 92: storage.GenerateDependencyResponse.dependency:type_name -> storage.Dependency
+	9,   // 93: storage.GenerateExtractorResponse.header:type_name -> storage.ResponseHeader
+	90,  // 94: storage.GenerateExtractorResponse.extractor:type_name -> storage.Extractor
+	9,   // 95: storage.GenerateExpectResponse.header:type_name -> storage.ResponseHeader
+	89,  // 96: storage.GenerateExpectResponse.expect:type_name -> storage.Expect
+	88,  // 97: storage.Expect.value:type_name -> storage.Dependency
+	91,  // 98: storage.Extractor.extractors:type_name -> storage.extractConfig
+	120, // 99: storage.Environment.service_urls:type_name -> storage.Environment.ServiceUrlsEntry
+	121, // 100: storage.Environment.variables:type_name -> storage.Environment.VariablesEntry
+	92,  // 101: storage.Environment.tls:type_name -> storage.EnvironmentTLS
+	122, // 102: storage.CreateEnvironmentRequest.service_urls:type_name -> storage.CreateEnvironmentRequest.ServiceUrlsEntry
+	123, // 103: storage.CreateEnvironmentRequest.variables:type_name -> storage.CreateEnvironmentRequest.VariablesEntry
+	92,  // 104: storage.CreateEnvironmentRequest.tls:type_name -> storage.EnvironmentTLS
+	124, // 105: storage.UpdateEnvironmentRequest.service_urls:type_name -> storage.UpdateEnvironmentRequest.ServiceUrlsEntry
+	125, // 106: storage.UpdateEnvironmentRequest.variables:type_name -> storage.UpdateEnvironmentRequest.VariablesEntry
+	92,  // 107: storage.UpdateEnvironmentRequest.tls:type_name -> storage.EnvironmentTLS
+	9,   // 108: storage.EnvironmentResponse.header:type_name -> storage.ResponseHeader
+	93,  // 109: storage.EnvironmentResponse.data:type_name -> storage.Environment
+	9,   // 110: storage.EnvironmentListResponse.header:type_name -> storage.ResponseHeader
+	93,  // 111: storage.EnvironmentListResponse.data:type_name -> storage.Environment
+	126, // 112: storage.MockOverride.headers:type_name -> storage.MockOverride.HeadersEntry
+	100, // 113: storage.MockRoute.override:type_name -> storage.MockOverride
+	9,   // 114: storage.StartMockResponse.header:type_name -> storage.ResponseHeader
+	9,   // 115: storage.StopMockResponse.header:type_name -> storage.ResponseHeader
+	9,   // 116: storage.ListMockRoutesResponse.header:type_name -> storage.ResponseHeader
+	101, // 117: storage.ListMockRoutesResponse.routes:type_name -> storage.MockRoute
+	100, // 118: storage.SetMockOverrideRequest.override:type_name -> storage.MockOverride
+	9,   // 119: storage.SetMockOverrideResponse.header:type_name -> storage.ResponseHeader
+	9,   // 120: storage.DeleteMockOverrideResponse.header:type_name -> storage.ResponseHeader
+	5,   // 121: storage.Struct.FieldsEntry.value:type_name -> storage.Value
+	11,  // 122: storage.TaskListResponse.TaskItem.meta:type_name -> storage.TaskMeta
+	12,  // 123: storage.TaskListResponse.TaskItem.api_spec:type_name -> storage.TaskAPISpec
+	13,  // 124: storage.TaskListResponse.TaskItem.sync_spec:type_name -> storage.TaskSyncSpec
+	14,  // 125: storage.TaskListResponse.TaskItem.workflow_spec:type_name -> storage.TaskWorkflowSpec
+	27,  // 126: storage.TaskConfigService.CreateTask:input_type -> storage.CreateTaskRequest
+	28,  // 127: storage.TaskConfigService.GetTask:input_type -> storage.GetTaskRequest
+	29,  // 128: storage.TaskConfigService.UpdateTask:input_type -> storage.UpdateTaskRequest
+	30,  // 129: storage.TaskConfigService.DeleteTask:input_type -> storage.DeleteTaskRequest
+	8,   // 130: storage.TaskConfigService.ListTasks:input_type -> storage.Empty
+	69,  // 131: storage.ReportService.GetReport:input_type -> storage.GetTestReportRequest
+	71,  // 132: storage.ReportService.ListReports:input_type -> storage.GetTaskReportListRequest
+	69,  // 133: storage.ReportService.DeleteReport:input_type -> storage.GetTestReportRequest
+	73,  // 134: storage.TestDataService.CreateTestData:input_type -> storage.CreateTestDataRequest
+	31,  // 135: storage.TestDataService.GetTestData:input_type -> storage.GetTestDataRequest
+	32,  // 136: storage.TestDataService.UpdateTestData:input_type -> storage.UpdateTestDataRequest
+	33,  // 137: storage.TestDataService.DeleteTestData:input_type -> storage.DeleteTestDataRequest
+	8,   // 138: storage.TestDataService.ListTestData:input_type -> storage.Empty
+	76,  // 139: storage.SceneConfigService.CreateSceneConfig:input_type -> storage.CreateSceneConfigRequest
+	34,  // 140: storage.SceneConfigService.GetSceneConfig:input_type -> storage.GetSceneConfigRequest
+	35,  // 141: storage.SceneConfigService.UpdateSceneConfig:input_type -> storage.UpdateSceneConfigRequest
+	36,  // 142: storage.SceneConfigService.DeleteSceneConfig:input_type -> storage.DeleteSceneConfigRequest
+	37,  // 143: storage.SceneConfigService.ListSceneConfigs:input_type -> storage.ListSceneConfigsRequest
+	47,  // 144: storage.ExecuteService.ExecuteTask:input_type -> storage.ExecuteTaskRequest
+	49,  // 145: storage.ExecuteService.CancelExecution:input_type -> storage.CancelExecutionRequest
+	51,  // 146: storage.ExecuteService.ResumeExecution:input_type -> storage.ResumeExecutionRequest
+	67,  // 147: storage.ExecuteService.WatchExecution:input_type -> storage.WatchExecutionRequest
+	54,  // 148: storage.ExecuteService.ListExecutionQueue:input_type -> storage.ListExecutionQueueRequest
+	56,  // 149: storage.ExecuteService.ReorderExecution:input_type -> storage.ReorderExecutionRequest
+	59,  // 150: storage.ExecuteService.GetExecution:input_type -> storage.GetExecutionRequest
+	61,  // 151: storage.ExecuteService.ListExecutions:input_type -> storage.ListExecutionsRequest
+	63,  // 152: storage.ExecuteService.GetExecutionHar:input_type -> storage.GetExecutionHarRequest
+	65,  // 153: storage.ExecuteService.ExportCurl:input_type -> storage.ExportCurlRequest
+	8,   // 154: storage.InterfaceService.GetInterfaceList:input_type -> storage.Empty
+	39,  // 155: storage.InterfaceService.GetInterfaceDetail:input_type -> storage.GetInterfaceRequest
+	41,  // 156: storage.InterfaceService.DeleteInterface:input_type -> storage.DeleteInterfaceRequest
+	42,  // 157: storage.InterfaceService.SyncInterface:input_type -> storage.SyncInterfaceRequest
+	82,  // 158: storage.GenerateService.GenerateDependency:input_type -> storage.GenerateDependencyRequest
+	84,  // 159: storage.GenerateService.GenerateExtractor:input_type -> storage.GenerateExtractorRequest
+	86,  // 160: storage.GenerateService.GenerateExpect:input_type -> storage.GenerateExpectRequest
+	94,  // 161: storage.EnvironmentService.CreateEnvironment:input_type -> storage.CreateEnvironmentRequest
+	95,  // 162: storage.EnvironmentService.GetEnvironment:input_type -> storage.GetEnvironmentRequest
+	96,  // 163: storage.EnvironmentService.UpdateEnvironment:input_type -> storage.UpdateEnvironmentRequest
+	97,  // 164: storage.EnvironmentService.DeleteEnvironment:input_type -> storage.DeleteEnvironmentRequest
+	8,   // 165: storage.EnvironmentService.ListEnvironments:input_type -> storage.Empty
+	102, // 166: storage.MockService.StartMock:input_type -> storage.StartMockRequest
+	104, // 167: storage.MockService.StopMock:input_type -> storage.StopMockRequest
+	106, // 168: storage.MockService.ListMockRoutes:input_type -> storage.ListMockRoutesRequest
+	108, // 169: storage.MockService.SetMockOverride:input_type -> storage.SetMockOverrideRequest
+	110, // 170: storage.MockService.DeleteMockOverride:input_type -> storage.DeleteMockOverrideRequest
+	44,  // 171: storage.TaskConfigService.CreateTask:output_type -> storage.TaskResponse
+	44,  // 172: storage.TaskConfigService.GetTask:output_type -> storage.TaskResponse
+	44,  // 173: storage.TaskConfigService.UpdateTask:output_type -> storage.TaskResponse
+	46,  // 174: storage.TaskConfigService.DeleteTask:output_type -> storage.DeleteResponse
+	45,  // 175: storage.TaskConfigService.ListTasks:output_type -> storage.TaskListResponse
+	70,  // 176: storage.ReportService.GetReport:output_type -> storage.TestReportResponse
+	72,  // 177: storage.ReportService.ListReports:output_type -> storage.ReportListResponse
+	46,  // 178: storage.ReportService.DeleteReport:output_type -> storage.DeleteResponse
+	74,  // 179: storage.TestDataService.CreateTestData:output_type -> storage.TestDataResponse
+	74,  // 180: storage.TestDataService.GetTestData:output_type -> storage.TestDataResponse
+	74,  // 181: storage.TestDataService.UpdateTestData:output_type -> storage.TestDataResponse
+	46,  // 182: storage.TestDataService.DeleteTestData:output_type -> storage.DeleteResponse
+	75,  // 183: storage.TestDataService.ListTestData:output_type -> storage.TestDataListResponse
+	80,  // 184: storage.SceneConfigService.CreateSceneConfig:output_type -> storage.SceneConfigResponse
+	80,  // 185: storage.SceneConfigService.GetSceneConfig:output_type -> storage.SceneConfigResponse
+	80,  // 186: storage.SceneConfigService.UpdateSceneConfig:output_type -> storage.SceneConfigResponse
+	46,  // 187: storage.SceneConfigService.DeleteSceneConfig:output_type -> storage.DeleteResponse
+	81,  // 188: storage.SceneConfigService.ListSceneConfigs:output_type -> storage.SceneConfigListResponse
+	48,  // 189: storage.ExecuteService.ExecuteTask:output_type -> storage.ExecuteTaskResponse
+	50,  // 190: storage.ExecuteService.CancelExecution:output_type -> storage.CancelExecutionResponse
+	52,  // 191: storage.ExecuteService.ResumeExecution:output_type -> storage.ResumeExecutionResponse
+	68,  // 192: storage.ExecuteService.WatchExecution:output_type -> storage.WatchExecutionResponse
+	55,  // 193: storage.ExecuteService.ListExecutionQueue:output_type -> storage.ListExecutionQueueResponse
+	57,  // 194: storage.ExecuteService.ReorderExecution:output_type -> storage.ReorderExecutionResponse
+	60,  // 195: storage.ExecuteService.GetExecution:output_type -> storage.GetExecutionResponse
+	62,  // 196: storage.ExecuteService.ListExecutions:output_type -> storage.ListExecutionsResponse
+	64,  // 197: storage.ExecuteService.GetExecutionHar:output_type -> storage.GetExecutionHarResponse
+	66,  // 198: storage.ExecuteService.ExportCurl:output_type -> storage.ExportCurlResponse
+	38,  // 199: storage.InterfaceService.GetInterfaceList:output_type -> storage.GetInterfaceListResponse
+	40,  // 200: storage.InterfaceService.GetInterfaceDetail:output_type -> storage.GetInterfaceResponse
+	46,  // 201: storage.InterfaceService.DeleteInterface:output_type -> storage.DeleteResponse
+	43,  // 202: storage.InterfaceService.SyncInterface:output_type -> storage.SyncInterfaceResponse
+	83,  // 203: storage.GenerateService.GenerateDependency:output_type -> storage.GenerateDependencyResponse
+	85,  // 204: storage.GenerateService.GenerateExtractor:output_type -> storage.GenerateExtractorResponse
+	87,  // 205: storage.GenerateService.GenerateExpect:output_type -> storage.GenerateExpectResponse
+	98,  // 206: storage.EnvironmentService.CreateEnvironment:output_type -> storage.EnvironmentResponse
+	98,  // 207: storage.EnvironmentService.GetEnvironment:output_type -> storage.EnvironmentResponse
+	98,  // 208: storage.EnvironmentService.UpdateEnvironment:output_type -> storage.EnvironmentResponse
+	46,  // 209: storage.EnvironmentService.DeleteEnvironment:output_type -> storage.DeleteResponse
+	99,  // 210: storage.EnvironmentService.ListEnvironments:output_type -> storage.EnvironmentListResponse
+	103, // 211: storage.MockService.StartMock:output_type -> storage.StartMockResponse
+	105, // 212: storage.MockService.StopMock:output_type -> storage.StopMockResponse
+	107, // 213: storage.MockService.ListMockRoutes:output_type -> storage.ListMockRoutesResponse
+	109, // 214: storage.MockService.SetMockOverride:output_type -> storage.SetMockOverrideResponse
+	111, // 215: storage.MockService.DeleteMockOverride:output_type -> storage.DeleteMockOverrideResponse
+	171, // [171:216] is the sub-list for method output_type
+	126, // [126:171] is the sub-list for method input_type
+	126, // [126:126] is the sub-list for extension type_name
+	126, // [126:126] is the sub-list for extension extendee
+	0,   // [0:126] is the sub-list for field type_name
 }
 
 func init() { file_Storage_proto_init() }
@@ -5198,21 +8288,25 @@ func file_Storage_proto_init() {
 		(*Task_ApiSpec)(nil),
 		(*Task_SyncSpec)(nil),
 	}
-	file_Storage_proto_msgTypes[22].OneofWrappers = []any{
+	file_Storage_proto_msgTypes[23].OneofWrappers = []any{
 		(*CreateTaskRequest_ApiSpec)(nil),
 		(*CreateTaskRequest_SyncSpec)(nil),
+		(*CreateTaskRequest_WorkflowSpec)(nil),
 	}
-	file_Storage_proto_msgTypes[24].OneofWrappers = []any{
+	file_Storage_proto_msgTypes[25].OneofWrappers = []any{
 		(*UpdateTaskRequest_ApiSpec)(nil),
 		(*UpdateTaskRequest_SyncSpec)(nil),
+		(*UpdateTaskRequest_WorkflowSpec)(nil),
 	}
-	file_Storage_proto_msgTypes[39].OneofWrappers = []any{
+	file_Storage_proto_msgTypes[40].OneofWrappers = []any{
 		(*TaskResponse_ApiSpec)(nil),
 		(*TaskResponse_SyncSpec)(nil),
+		(*TaskResponse_WorkflowSpec)(nil),
 	}
-	file_Storage_proto_msgTypes[71].OneofWrappers = []any{
+	file_Storage_proto_msgTypes[112].OneofWrappers = []any{
 		(*TaskListResponse_TaskItem_ApiSpec)(nil),
 		(*TaskListResponse_TaskItem_SyncSpec)(nil),
+		(*TaskListResponse_TaskItem_WorkflowSpec)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -5220,9 +8314,9 @@ func file_Storage_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_Storage_proto_rawDesc), len(file_Storage_proto_rawDesc)),
 			NumEnums:      4,
-			NumMessages:   73,
+			NumMessages:   123,
 			NumExtensions: 0,
-			NumServices:   7,
+			NumServices:   9,
 		},
 		GoTypes:           file_Storage_proto_goTypes,
 		DependencyIndexes: file_Storage_proto_depIdxs,
@@ -967,7 +967,16 @@ var SceneConfigService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	ExecuteService_ExecuteTask_FullMethodName = "/storage.ExecuteService/ExecuteTask"
+	ExecuteService_ExecuteTask_FullMethodName        = "/storage.ExecuteService/ExecuteTask"
+	ExecuteService_CancelExecution_FullMethodName    = "/storage.ExecuteService/CancelExecution"
+	ExecuteService_ResumeExecution_FullMethodName    = "/storage.ExecuteService/ResumeExecution"
+	ExecuteService_WatchExecution_FullMethodName     = "/storage.ExecuteService/WatchExecution"
+	ExecuteService_ListExecutionQueue_FullMethodName = "/storage.ExecuteService/ListExecutionQueue"
+	ExecuteService_ReorderExecution_FullMethodName   = "/storage.ExecuteService/ReorderExecution"
+	ExecuteService_GetExecution_FullMethodName       = "/storage.ExecuteService/GetExecution"
+	ExecuteService_ListExecutions_FullMethodName     = "/storage.ExecuteService/ListExecutions"
+	ExecuteService_GetExecutionHar_FullMethodName    = "/storage.ExecuteService/GetExecutionHar"
+	ExecuteService_ExportCurl_FullMethodName         = "/storage.ExecuteService/ExportCurl"
 )
 
 // ExecuteServiceClient is the client API for ExecuteService service.
@@ -978,6 +987,24 @@ const (
 type ExecuteServiceClient interface {
 	// 任务执行
 	ExecuteTask(ctx context.Context, in *ExecuteTaskRequest, opts ...grpc.CallOption) (*ExecuteTaskResponse, error)
+	// 取消执行
+	CancelExecution(ctx context.Context, in *CancelExecutionRequest, opts ...grpc.CallOption) (*CancelExecutionResponse, error)
+	// 恢复失败或已取消的执行
+	ResumeExecution(ctx context.Context, in *ResumeExecutionRequest, opts ...grpc.CallOption) (*ResumeExecutionResponse, error)
+	// 订阅执行事件，执行结束后流关闭
+	WatchExecution(ctx context.Context, in *WatchExecutionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchExecutionResponse], error)
+	// 查看执行队列
+	ListExecutionQueue(ctx context.Context, in *ListExecutionQueueRequest, opts ...grpc.CallOption) (*ListExecutionQueueResponse, error)
+	// 调整排队中执行的优先级
+	ReorderExecution(ctx context.Context, in *ReorderExecutionRequest, opts ...grpc.CallOption) (*ReorderExecutionResponse, error)
+	// 查询执行记录
+	GetExecution(ctx context.Context, in *GetExecutionRequest, opts ...grpc.CallOption) (*GetExecutionResponse, error)
+	// 按任务、状态、时间范围分页查询执行记录
+	ListExecutions(ctx context.Context, in *ListExecutionsRequest, opts ...grpc.CallOption) (*ListExecutionsResponse, error)
+	// 下载执行中所有请求和响应的 HAR 文件
+	GetExecutionHar(ctx context.Context, in *GetExecutionHarRequest, opts ...grpc.CallOption) (*GetExecutionHarResponse, error)
+	// 将执行中的请求导出为 curl 命令
+	ExportCurl(ctx context.Context, in *ExportCurlRequest, opts ...grpc.CallOption) (*ExportCurlResponse, error)
 }
 
 type executeServiceClient struct {
@@ -998,6 +1025,105 @@ func (c *executeServiceClient) ExecuteTask(ctx context.Context, in *ExecuteTaskR
 	return out, nil
 }
 
+func (c *executeServiceClient) CancelExecution(ctx context.Context, in *CancelExecutionRequest, opts ...grpc.CallOption) (*CancelExecutionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelExecutionResponse)
+	err := c.cc.Invoke(ctx, ExecuteService_CancelExecution_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executeServiceClient) ResumeExecution(ctx context.Context, in *ResumeExecutionRequest, opts ...grpc.CallOption) (*ResumeExecutionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResumeExecutionResponse)
+	err := c.cc.Invoke(ctx, ExecuteService_ResumeExecution_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executeServiceClient) WatchExecution(ctx context.Context, in *WatchExecutionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchExecutionResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ExecuteService_ServiceDesc.Streams[0], ExecuteService_WatchExecution_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchExecutionRequest, WatchExecutionResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExecuteService_WatchExecutionClient = grpc.ServerStreamingClient[WatchExecutionResponse]
+
+func (c *executeServiceClient) ListExecutionQueue(ctx context.Context, in *ListExecutionQueueRequest, opts ...grpc.CallOption) (*ListExecutionQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExecutionQueueResponse)
+	err := c.cc.Invoke(ctx, ExecuteService_ListExecutionQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executeServiceClient) ReorderExecution(ctx context.Context, in *ReorderExecutionRequest, opts ...grpc.CallOption) (*ReorderExecutionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReorderExecutionResponse)
+	err := c.cc.Invoke(ctx, ExecuteService_ReorderExecution_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executeServiceClient) GetExecution(ctx context.Context, in *GetExecutionRequest, opts ...grpc.CallOption) (*GetExecutionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetExecutionResponse)
+	err := c.cc.Invoke(ctx, ExecuteService_GetExecution_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executeServiceClient) ListExecutions(ctx context.Context, in *ListExecutionsRequest, opts ...grpc.CallOption) (*ListExecutionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExecutionsResponse)
+	err := c.cc.Invoke(ctx, ExecuteService_ListExecutions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executeServiceClient) GetExecutionHar(ctx context.Context, in *GetExecutionHarRequest, opts ...grpc.CallOption) (*GetExecutionHarResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetExecutionHarResponse)
+	err := c.cc.Invoke(ctx, ExecuteService_GetExecutionHar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executeServiceClient) ExportCurl(ctx context.Context, in *ExportCurlRequest, opts ...grpc.CallOption) (*ExportCurlResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportCurlResponse)
+	err := c.cc.Invoke(ctx, ExecuteService_ExportCurl_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ExecuteServiceServer is the server API for ExecuteService service.
 // All implementations must embed UnimplementedExecuteServiceServer
 // for forward compatibility.
@@ -1006,6 +1132,24 @@ func (c *executeServiceClient) ExecuteTask(ctx context.Context, in *ExecuteTaskR
 type ExecuteServiceServer interface {
 	// 任务执行
 	ExecuteTask(context.Context, *ExecuteTaskRequest) (*ExecuteTaskResponse, error)
+	// 取消执行
+	CancelExecution(context.Context, *CancelExecutionRequest) (*CancelExecutionResponse, error)
+	// 恢复失败或已取消的执行
+	ResumeExecution(context.Context, *ResumeExecutionRequest) (*ResumeExecutionResponse, error)
+	// 订阅执行事件，执行结束后流关闭
+	WatchExecution(*WatchExecutionRequest, grpc.ServerStreamingServer[WatchExecutionResponse]) error
+	// 查看执行队列
+	ListExecutionQueue(context.Context, *ListExecutionQueueRequest) (*ListExecutionQueueResponse, error)
+	// 调整排队中执行的优先级
+	ReorderExecution(context.Context, *ReorderExecutionRequest) (*ReorderExecutionResponse, error)
+	// 查询执行记录
+	GetExecution(context.Context, *GetExecutionRequest) (*GetExecutionResponse, error)
+	// 按任务、状态、时间范围分页查询执行记录
+	ListExecutions(context.Context, *ListExecutionsRequest) (*ListExecutionsResponse, error)
+	// 下载执行中所有请求和响应的 HAR 文件
+	GetExecutionHar(context.Context, *GetExecutionHarRequest) (*GetExecutionHarResponse, error)
+	// 将执行中的请求导出为 curl 命令
+	ExportCurl(context.Context, *ExportCurlRequest) (*ExportCurlResponse, error)
 	mustEmbedUnimplementedExecuteServiceServer()
 }
 
@@ -1019,6 +1163,33 @@ type UnimplementedExecuteServiceServer struct{}
 func (UnimplementedExecuteServiceServer) ExecuteTask(context.Context, *ExecuteTaskRequest) (*ExecuteTaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExecuteTask not implemented")
 }
+func (UnimplementedExecuteServiceServer) CancelExecution(context.Context, *CancelExecutionRequest) (*CancelExecutionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelExecution not implemented")
+}
+func (UnimplementedExecuteServiceServer) ResumeExecution(context.Context, *ResumeExecutionRequest) (*ResumeExecutionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeExecution not implemented")
+}
+func (UnimplementedExecuteServiceServer) WatchExecution(*WatchExecutionRequest, grpc.ServerStreamingServer[WatchExecutionResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchExecution not implemented")
+}
+func (UnimplementedExecuteServiceServer) ListExecutionQueue(context.Context, *ListExecutionQueueRequest) (*ListExecutionQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExecutionQueue not implemented")
+}
+func (UnimplementedExecuteServiceServer) ReorderExecution(context.Context, *ReorderExecutionRequest) (*ReorderExecutionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderExecution not implemented")
+}
+func (UnimplementedExecuteServiceServer) GetExecution(context.Context, *GetExecutionRequest) (*GetExecutionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExecution not implemented")
+}
+func (UnimplementedExecuteServiceServer) ListExecutions(context.Context, *ListExecutionsRequest) (*ListExecutionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExecutions not implemented")
+}
+func (UnimplementedExecuteServiceServer) GetExecutionHar(context.Context, *GetExecutionHarRequest) (*GetExecutionHarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExecutionHar not implemented")
+}
+func (UnimplementedExecuteServiceServer) ExportCurl(context.Context, *ExportCurlRequest) (*ExportCurlResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportCurl not implemented")
+}
 func (UnimplementedExecuteServiceServer) mustEmbedUnimplementedExecuteServiceServer() {}
 func (UnimplementedExecuteServiceServer) testEmbeddedByValue()                        {}
 
@@ -1058,6 +1229,161 @@ func _ExecuteService_ExecuteTask_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ExecuteService_CancelExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelExecutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecuteServiceServer).CancelExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecuteService_CancelExecution_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecuteServiceServer).CancelExecution(ctx, req.(*CancelExecutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecuteService_ResumeExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeExecutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecuteServiceServer).ResumeExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecuteService_ResumeExecution_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecuteServiceServer).ResumeExecution(ctx, req.(*ResumeExecutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecuteService_WatchExecution_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchExecutionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExecuteServiceServer).WatchExecution(m, &grpc.GenericServerStream[WatchExecutionRequest, WatchExecutionResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExecuteService_WatchExecutionServer = grpc.ServerStreamingServer[WatchExecutionResponse]
+
+func _ExecuteService_ListExecutionQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExecutionQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecuteServiceServer).ListExecutionQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecuteService_ListExecutionQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecuteServiceServer).ListExecutionQueue(ctx, req.(*ListExecutionQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecuteService_ReorderExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderExecutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecuteServiceServer).ReorderExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecuteService_ReorderExecution_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecuteServiceServer).ReorderExecution(ctx, req.(*ReorderExecutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecuteService_GetExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExecutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecuteServiceServer).GetExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecuteService_GetExecution_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecuteServiceServer).GetExecution(ctx, req.(*GetExecutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecuteService_ListExecutions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExecutionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecuteServiceServer).ListExecutions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecuteService_ListExecutions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecuteServiceServer).ListExecutions(ctx, req.(*ListExecutionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecuteService_GetExecutionHar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExecutionHarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecuteServiceServer).GetExecutionHar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecuteService_GetExecutionHar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecuteServiceServer).GetExecutionHar(ctx, req.(*GetExecutionHarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecuteService_ExportCurl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportCurlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecuteServiceServer).ExportCurl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecuteService_ExportCurl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecuteServiceServer).ExportCurl(ctx, req.(*ExportCurlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ExecuteService_ServiceDesc is the grpc.ServiceDesc for ExecuteService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1069,8 +1395,46 @@ var ExecuteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ExecuteTask",
 			Handler:    _ExecuteService_ExecuteTask_Handler,
 		},
+		{
+			MethodName: "CancelExecution",
+			Handler:    _ExecuteService_CancelExecution_Handler,
+		},
+		{
+			MethodName: "ResumeExecution",
+			Handler:    _ExecuteService_ResumeExecution_Handler,
+		},
+		{
+			MethodName: "ListExecutionQueue",
+			Handler:    _ExecuteService_ListExecutionQueue_Handler,
+		},
+		{
+			MethodName: "ReorderExecution",
+			Handler:    _ExecuteService_ReorderExecution_Handler,
+		},
+		{
+			MethodName: "GetExecution",
+			Handler:    _ExecuteService_GetExecution_Handler,
+		},
+		{
+			MethodName: "ListExecutions",
+			Handler:    _ExecuteService_ListExecutions_Handler,
+		},
+		{
+			MethodName: "GetExecutionHar",
+			Handler:    _ExecuteService_GetExecutionHar_Handler,
+		},
+		{
+			MethodName: "ExportCurl",
+			Handler:    _ExecuteService_ExportCurl_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchExecution",
+			Handler:       _ExecuteService_WatchExecution_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "Storage.proto",
 }
 
@@ -1471,3 +1835,523 @@ var GenerateService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "Storage.proto",
 }
+
+const (
+	EnvironmentService_CreateEnvironment_FullMethodName = "/storage.EnvironmentService/CreateEnvironment"
+	EnvironmentService_GetEnvironment_FullMethodName    = "/storage.EnvironmentService/GetEnvironment"
+	EnvironmentService_UpdateEnvironment_FullMethodName = "/storage.EnvironmentService/UpdateEnvironment"
+	EnvironmentService_DeleteEnvironment_FullMethodName = "/storage.EnvironmentService/DeleteEnvironment"
+	EnvironmentService_ListEnvironments_FullMethodName  = "/storage.EnvironmentService/ListEnvironments"
+)
+
+// EnvironmentServiceClient is the client API for EnvironmentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EnvironmentServiceClient interface {
+	// 执行环境配置
+	CreateEnvironment(ctx context.Context, in *CreateEnvironmentRequest, opts ...grpc.CallOption) (*EnvironmentResponse, error)
+	GetEnvironment(ctx context.Context, in *GetEnvironmentRequest, opts ...grpc.CallOption) (*EnvironmentResponse, error)
+	UpdateEnvironment(ctx context.Context, in *UpdateEnvironmentRequest, opts ...grpc.CallOption) (*EnvironmentResponse, error)
+	DeleteEnvironment(ctx context.Context, in *DeleteEnvironmentRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	ListEnvironments(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*EnvironmentListResponse, error)
+}
+
+type environmentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEnvironmentServiceClient(cc grpc.ClientConnInterface) EnvironmentServiceClient {
+	return &environmentServiceClient{cc}
+}
+
+func (c *environmentServiceClient) CreateEnvironment(ctx context.Context, in *CreateEnvironmentRequest, opts ...grpc.CallOption) (*EnvironmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnvironmentResponse)
+	err := c.cc.Invoke(ctx, EnvironmentService_CreateEnvironment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *environmentServiceClient) GetEnvironment(ctx context.Context, in *GetEnvironmentRequest, opts ...grpc.CallOption) (*EnvironmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnvironmentResponse)
+	err := c.cc.Invoke(ctx, EnvironmentService_GetEnvironment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *environmentServiceClient) UpdateEnvironment(ctx context.Context, in *UpdateEnvironmentRequest, opts ...grpc.CallOption) (*EnvironmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnvironmentResponse)
+	err := c.cc.Invoke(ctx, EnvironmentService_UpdateEnvironment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *environmentServiceClient) DeleteEnvironment(ctx context.Context, in *DeleteEnvironmentRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, EnvironmentService_DeleteEnvironment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *environmentServiceClient) ListEnvironments(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*EnvironmentListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnvironmentListResponse)
+	err := c.cc.Invoke(ctx, EnvironmentService_ListEnvironments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EnvironmentServiceServer is the server API for EnvironmentService service.
+// All implementations must embed UnimplementedEnvironmentServiceServer
+// for forward compatibility.
+type EnvironmentServiceServer interface {
+	// 执行环境配置
+	CreateEnvironment(context.Context, *CreateEnvironmentRequest) (*EnvironmentResponse, error)
+	GetEnvironment(context.Context, *GetEnvironmentRequest) (*EnvironmentResponse, error)
+	UpdateEnvironment(context.Context, *UpdateEnvironmentRequest) (*EnvironmentResponse, error)
+	DeleteEnvironment(context.Context, *DeleteEnvironmentRequest) (*DeleteResponse, error)
+	ListEnvironments(context.Context, *Empty) (*EnvironmentListResponse, error)
+	mustEmbedUnimplementedEnvironmentServiceServer()
+}
+
+// UnimplementedEnvironmentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEnvironmentServiceServer struct{}
+
+func (UnimplementedEnvironmentServiceServer) CreateEnvironment(context.Context, *CreateEnvironmentRequest) (*EnvironmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEnvironment not implemented")
+}
+func (UnimplementedEnvironmentServiceServer) GetEnvironment(context.Context, *GetEnvironmentRequest) (*EnvironmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEnvironment not implemented")
+}
+func (UnimplementedEnvironmentServiceServer) UpdateEnvironment(context.Context, *UpdateEnvironmentRequest) (*EnvironmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEnvironment not implemented")
+}
+func (UnimplementedEnvironmentServiceServer) DeleteEnvironment(context.Context, *DeleteEnvironmentRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEnvironment not implemented")
+}
+func (UnimplementedEnvironmentServiceServer) ListEnvironments(context.Context, *Empty) (*EnvironmentListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEnvironments not implemented")
+}
+func (UnimplementedEnvironmentServiceServer) mustEmbedUnimplementedEnvironmentServiceServer() {}
+func (UnimplementedEnvironmentServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeEnvironmentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EnvironmentServiceServer will
+// result in compilation errors.
+type UnsafeEnvironmentServiceServer interface {
+	mustEmbedUnimplementedEnvironmentServiceServer()
+}
+
+func RegisterEnvironmentServiceServer(s grpc.ServiceRegistrar, srv EnvironmentServiceServer) {
+	// If the following call pancis, it indicates UnimplementedEnvironmentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EnvironmentService_ServiceDesc, srv)
+}
+
+func _EnvironmentService_CreateEnvironment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEnvironmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnvironmentServiceServer).CreateEnvironment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnvironmentService_CreateEnvironment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnvironmentServiceServer).CreateEnvironment(ctx, req.(*CreateEnvironmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnvironmentService_GetEnvironment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEnvironmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnvironmentServiceServer).GetEnvironment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnvironmentService_GetEnvironment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnvironmentServiceServer).GetEnvironment(ctx, req.(*GetEnvironmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnvironmentService_UpdateEnvironment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEnvironmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnvironmentServiceServer).UpdateEnvironment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnvironmentService_UpdateEnvironment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnvironmentServiceServer).UpdateEnvironment(ctx, req.(*UpdateEnvironmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnvironmentService_DeleteEnvironment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEnvironmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnvironmentServiceServer).DeleteEnvironment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnvironmentService_DeleteEnvironment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnvironmentServiceServer).DeleteEnvironment(ctx, req.(*DeleteEnvironmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnvironmentService_ListEnvironments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnvironmentServiceServer).ListEnvironments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnvironmentService_ListEnvironments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnvironmentServiceServer).ListEnvironments(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EnvironmentService_ServiceDesc is the grpc.ServiceDesc for EnvironmentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EnvironmentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "storage.EnvironmentService",
+	HandlerType: (*EnvironmentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateEnvironment",
+			Handler:    _EnvironmentService_CreateEnvironment_Handler,
+		},
+		{
+			MethodName: "GetEnvironment",
+			Handler:    _EnvironmentService_GetEnvironment_Handler,
+		},
+		{
+			MethodName: "UpdateEnvironment",
+			Handler:    _EnvironmentService_UpdateEnvironment_Handler,
+		},
+		{
+			MethodName: "DeleteEnvironment",
+			Handler:    _EnvironmentService_DeleteEnvironment_Handler,
+		},
+		{
+			MethodName: "ListEnvironments",
+			Handler:    _EnvironmentService_ListEnvironments_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "Storage.proto",
+}
+
+const (
+	MockService_StartMock_FullMethodName          = "/storage.MockService/StartMock"
+	MockService_StopMock_FullMethodName           = "/storage.MockService/StopMock"
+	MockService_ListMockRoutes_FullMethodName     = "/storage.MockService/ListMockRoutes"
+	MockService_SetMockOverride_FullMethodName    = "/storage.MockService/SetMockOverride"
+	MockService_DeleteMockOverride_FullMethodName = "/storage.MockService/DeleteMockOverride"
+)
+
+// MockServiceClient is the client API for MockService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MockServiceClient interface {
+	// 启动项目的 Mock 服务，已启动时重新加载接口和覆盖配置
+	StartMock(ctx context.Context, in *StartMockRequest, opts ...grpc.CallOption) (*StartMockResponse, error)
+	// 停止项目的 Mock 服务
+	StopMock(ctx context.Context, in *StopMockRequest, opts ...grpc.CallOption) (*StopMockResponse, error)
+	// 查看项目的 Mock 路由及覆盖配置
+	ListMockRoutes(ctx context.Context, in *ListMockRoutesRequest, opts ...grpc.CallOption) (*ListMockRoutesResponse, error)
+	// 设置接口的覆盖配置，立即作用于运行中的 Mock 服务
+	SetMockOverride(ctx context.Context, in *SetMockOverrideRequest, opts ...grpc.CallOption) (*SetMockOverrideResponse, error)
+	// 删除接口的覆盖配置
+	DeleteMockOverride(ctx context.Context, in *DeleteMockOverrideRequest, opts ...grpc.CallOption) (*DeleteMockOverrideResponse, error)
+}
+
+type mockServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMockServiceClient(cc grpc.ClientConnInterface) MockServiceClient {
+	return &mockServiceClient{cc}
+}
+
+func (c *mockServiceClient) StartMock(ctx context.Context, in *StartMockRequest, opts ...grpc.CallOption) (*StartMockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartMockResponse)
+	err := c.cc.Invoke(ctx, MockService_StartMock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mockServiceClient) StopMock(ctx context.Context, in *StopMockRequest, opts ...grpc.CallOption) (*StopMockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StopMockResponse)
+	err := c.cc.Invoke(ctx, MockService_StopMock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mockServiceClient) ListMockRoutes(ctx context.Context, in *ListMockRoutesRequest, opts ...grpc.CallOption) (*ListMockRoutesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMockRoutesResponse)
+	err := c.cc.Invoke(ctx, MockService_ListMockRoutes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mockServiceClient) SetMockOverride(ctx context.Context, in *SetMockOverrideRequest, opts ...grpc.CallOption) (*SetMockOverrideResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetMockOverrideResponse)
+	err := c.cc.Invoke(ctx, MockService_SetMockOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mockServiceClient) DeleteMockOverride(ctx context.Context, in *DeleteMockOverrideRequest, opts ...grpc.CallOption) (*DeleteMockOverrideResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteMockOverrideResponse)
+	err := c.cc.Invoke(ctx, MockService_DeleteMockOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MockServiceServer is the server API for MockService service.
+// All implementations must embed UnimplementedMockServiceServer
+// for forward compatibility.
+type MockServiceServer interface {
+	// 启动项目的 Mock 服务，已启动时重新加载接口和覆盖配置
+	StartMock(context.Context, *StartMockRequest) (*StartMockResponse, error)
+	// 停止项目的 Mock 服务
+	StopMock(context.Context, *StopMockRequest) (*StopMockResponse, error)
+	// 查看项目的 Mock 路由及覆盖配置
+	ListMockRoutes(context.Context, *ListMockRoutesRequest) (*ListMockRoutesResponse, error)
+	// 设置接口的覆盖配置，立即作用于运行中的 Mock 服务
+	SetMockOverride(context.Context, *SetMockOverrideRequest) (*SetMockOverrideResponse, error)
+	// 删除接口的覆盖配置
+	DeleteMockOverride(context.Context, *DeleteMockOverrideRequest) (*DeleteMockOverrideResponse, error)
+	mustEmbedUnimplementedMockServiceServer()
+}
+
+// UnimplementedMockServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMockServiceServer struct{}
+
+func (UnimplementedMockServiceServer) StartMock(context.Context, *StartMockRequest) (*StartMockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartMock not implemented")
+}
+func (UnimplementedMockServiceServer) StopMock(context.Context, *StopMockRequest) (*StopMockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopMock not implemented")
+}
+func (UnimplementedMockServiceServer) ListMockRoutes(context.Context, *ListMockRoutesRequest) (*ListMockRoutesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMockRoutes not implemented")
+}
+func (UnimplementedMockServiceServer) SetMockOverride(context.Context, *SetMockOverrideRequest) (*SetMockOverrideResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMockOverride not implemented")
+}
+func (UnimplementedMockServiceServer) DeleteMockOverride(context.Context, *DeleteMockOverrideRequest) (*DeleteMockOverrideResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMockOverride not implemented")
+}
+func (UnimplementedMockServiceServer) mustEmbedUnimplementedMockServiceServer() {}
+func (UnimplementedMockServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeMockServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MockServiceServer will
+// result in compilation errors.
+type UnsafeMockServiceServer interface {
+	mustEmbedUnimplementedMockServiceServer()
+}
+
+func RegisterMockServiceServer(s grpc.ServiceRegistrar, srv MockServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMockServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MockService_ServiceDesc, srv)
+}
+
+func _MockService_StartMock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartMockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MockServiceServer).StartMock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MockService_StartMock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MockServiceServer).StartMock(ctx, req.(*StartMockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MockService_StopMock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopMockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MockServiceServer).StopMock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MockService_StopMock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MockServiceServer).StopMock(ctx, req.(*StopMockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MockService_ListMockRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMockRoutesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MockServiceServer).ListMockRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MockService_ListMockRoutes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MockServiceServer).ListMockRoutes(ctx, req.(*ListMockRoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MockService_SetMockOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMockOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MockServiceServer).SetMockOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MockService_SetMockOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MockServiceServer).SetMockOverride(ctx, req.(*SetMockOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MockService_DeleteMockOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMockOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MockServiceServer).DeleteMockOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MockService_DeleteMockOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MockServiceServer).DeleteMockOverride(ctx, req.(*DeleteMockOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MockService_ServiceDesc is the grpc.ServiceDesc for MockService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MockService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "storage.MockService",
+	HandlerType: (*MockServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StartMock",
+			Handler:    _MockService_StartMock_Handler,
+		},
+		{
+			MethodName: "StopMock",
+			Handler:    _MockService_StopMock_Handler,
+		},
+		{
+			MethodName: "ListMockRoutes",
+			Handler:    _MockService_ListMockRoutes_Handler,
+		},
+		{
+			MethodName: "SetMockOverride",
+			Handler:    _MockService_SetMockOverride_Handler,
+		},
+		{
+			MethodName: "DeleteMockOverride",
+			Handler:    _MockService_DeleteMockOverride_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "Storage.proto",
+}